@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"io"
 	stdlog "log"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
 
+	"github.com/github/github-mcp-server/pkg/config"
 	"github.com/github/github-mcp-server/pkg/github"
 	iolog "github.com/github/github-mcp-server/pkg/log"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -39,7 +41,6 @@ var (
 		Long:  `Start a server that communicates via standard input/output streams using JSON-RPC messages.`,
 		Run: func(_ *cobra.Command, _ []string) {
 			logFile := viper.GetString("log-file")
-			readOnly := viper.GetBool("read-only")
 			exportTranslations := viper.GetBool("export-translations")
 			logger, err := initLogger(logFile)
 			if err != nil {
@@ -55,12 +56,16 @@ var (
 
 			logCommands := viper.GetBool("enable-command-logging")
 			cfg := runConfig{
-				readOnly:           readOnly,
+				server: config.ServerConfig{
+					EnabledToolsets: enabledToolsets,
+					DisabledTools:   disabledTools,
+					ReadOnly:        viper.GetBool("read-only"),
+					Timeout:         viper.GetString("timeout"),
+					BaseURL:         viper.GetString("host"),
+				},
 				logger:             logger,
 				logCommands:        logCommands,
 				exportTranslations: exportTranslations,
-				enabledToolsets:    enabledToolsets,
-				disabledTools:      disabledTools,
 			}
 			if err := runStdioServer(cfg); err != nil {
 				stdlog.Fatal("failed to run stdio server:", err)
@@ -83,6 +88,7 @@ func init() {
 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
+	rootCmd.PersistentFlags().String("timeout", "", "An optional timeout for each request to the GitHub API, such as 30s")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
@@ -93,6 +99,7 @@ func init() {
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
+	_ = viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
@@ -122,12 +129,10 @@ func initLogger(outPath string) (*log.Logger, error) {
 }
 
 type runConfig struct {
-	readOnly           bool
+	server             config.ServerConfig
 	logger             *log.Logger
 	logCommands        bool
 	exportTranslations bool
-	enabledToolsets    []string
-	disabledTools      []string
 }
 
 func runStdioServer(cfg runConfig) error {
@@ -135,19 +140,23 @@ func runStdioServer(cfg runConfig) error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	if err := cfg.server.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	// Create GH client
 	token := viper.GetString("personal_access_token")
 	if token == "" {
 		cfg.logger.Fatal("GITHUB_PERSONAL_ACCESS_TOKEN not set")
 	}
-	ghClient := gogithub.NewClient(nil).WithAuthToken(token)
+	// The configuration was validated, so the timeout is known to parse
+	timeout, _ := cfg.server.TimeoutDuration()
+	ghClient := gogithub.NewClient(&http.Client{Timeout: timeout}).WithAuthToken(token)
 	ghClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", version)
 
-	host := viper.GetString("host")
-
-	if host != "" {
+	if cfg.server.BaseURL != "" {
 		var err error
-		ghClient, err = ghClient.WithEnterpriseURLs(host, host)
+		ghClient, err = ghClient.WithEnterpriseURLs(cfg.server.BaseURL, cfg.server.UploadURLOrDefault())
 		if err != nil {
 			return fmt.Errorf("failed to create GitHub client with host: %w", err)
 		}
@@ -159,9 +168,7 @@ func runStdioServer(cfg runConfig) error {
 		ghClient.UserAgent = fmt.Sprintf("github-mcp-server/%s (%s/%s)", version, message.Params.ClientInfo.Name, message.Params.ClientInfo.Version)
 	}
 
-	getClient := func(_ context.Context) (*gogithub.Client, error) {
-		return ghClient, nil // closing over client
-	}
+	getClient := github.NewGetClientFn(github.WithGitHubClient(ghClient))
 
 	hooks := &server.Hooks{
 		OnBeforeInitialize: []server.OnBeforeInitializeFunc{beforeInit},
@@ -169,20 +176,20 @@ func runStdioServer(cfg runConfig) error {
 	// Create server
 	ghServer := github.NewServer(version, server.WithHooks(hooks))
 
-	enabled := cfg.enabledToolsets
+	serverCfg := cfg.server
 	dynamic := viper.GetBool("dynamic_toolsets")
 	if dynamic {
 		// filter "all" from the enabled toolsets
-		enabled = make([]string, 0, len(cfg.enabledToolsets))
-		for _, toolset := range cfg.enabledToolsets {
+		serverCfg.EnabledToolsets = make([]string, 0, len(cfg.server.EnabledToolsets))
+		for _, toolset := range cfg.server.EnabledToolsets {
 			if toolset != "all" {
-				enabled = append(enabled, toolset)
+				serverCfg.EnabledToolsets = append(serverCfg.EnabledToolsets, toolset)
 			}
 		}
 	}
 
 	// Create default toolsets
-	toolsets, err := github.InitToolsets(enabled, cfg.readOnly, getClient, t, cfg.disabledTools)
+	toolsets, err := github.InitToolsets(serverCfg, getClient, t)
 	context := github.InitContextToolset(getClient, t)
 
 	if err != nil {
@@ -194,6 +201,7 @@ func runStdioServer(cfg runConfig) error {
 	// Register the tools with the server
 	toolsets.RegisterTools(ghServer)
 	context.RegisterTools(ghServer)
+	_, _ = fmt.Fprintf(os.Stderr, "registered toolsets:\n%v", toolsets)
 
 	if dynamic {
 		dynamic := github.InitDynamicToolset(ghServer, toolsets, t)
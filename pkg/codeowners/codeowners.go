@@ -0,0 +1,159 @@
+// Package codeowners parses CODEOWNERS files and matches paths against their rules
+// following the semantics GitHub documents for them.
+package codeowners
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Locations are the paths GitHub looks for a CODEOWNERS file at, in order of precedence.
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// Rule is a single line of a CODEOWNERS file.
+type Rule struct {
+	// Pattern is the path pattern as written in the file.
+	Pattern string
+	// Owners are the users, teams or email addresses owning the matching paths.
+	// A rule without owners removes ownership from the paths it matches.
+	Owners []string
+	// Line is the line number of the rule in the file, starting at 1.
+	Line int
+
+	re *regexp.Regexp
+}
+
+// Matches checks if the given repository path matches the rule's pattern.
+func (r Rule) Matches(path string) bool {
+	return r.re.MatchString(strings.TrimPrefix(path, "/"))
+}
+
+// File is a parsed CODEOWNERS file.
+type File struct {
+	Rules []Rule
+}
+
+// Parse parses the content of a CODEOWNERS file. Lines GitHub would reject, such as
+// negated patterns or character ranges, are skipped just like GitHub ignores them.
+func Parse(content string) *File {
+	f := &File{}
+	for i, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(stripComment(line))
+		if len(fields) == 0 {
+			continue
+		}
+
+		re, err := compilePattern(fields[0])
+		if err != nil {
+			continue
+		}
+		f.Rules = append(f.Rules, Rule{
+			Pattern: fields[0],
+			Owners:  fields[1:],
+			Line:    i + 1,
+			re:      re,
+		})
+	}
+	return f
+}
+
+// Match returns the rule that decides the owners of the given path, or nil if no rule matches.
+// As in GitHub, the last matching rule in the file takes precedence.
+func (f *File) Match(path string) *Rule {
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if f.Rules[i].Matches(path) {
+			return &f.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Owners returns the owners of the given path, or nil if it has none.
+func (f *File) Owners(path string) []string {
+	if rule := f.Match(path); rule != nil {
+		return rule.Owners
+	}
+	return nil
+}
+
+// MatchPattern checks if the given repository path matches a single CODEOWNERS pattern.
+func MatchPattern(pattern, path string) (bool, error) {
+	re, err := compilePattern(pattern)
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(strings.TrimPrefix(path, "/")), nil
+}
+
+// stripComment removes a trailing comment from a line. An escaped \# is kept as part of the pattern.
+func stripComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] != '\\') {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// compilePattern converts a CODEOWNERS pattern into a regular expression matching repository paths.
+//
+// Patterns follow gitignore rules, minus negation and character ranges:
+//   - a pattern containing a slash other than a trailing one is relative to the repository root,
+//     otherwise it matches at any depth
+//   - a trailing slash only matches directories, i.e. everything below them
+//   - '*' matches anything but a slash, '?' a single character other than a slash,
+//     and '**' matches across directories
+//   - a pattern matching a directory also matches everything below it, unless its last
+//     segment contains a wildcard, so 'docs/*' doesn't match 'docs/guides/intro.md'
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") {
+		return nil, fmt.Errorf("negated pattern %q is not supported", pattern)
+	}
+	if strings.ContainsAny(pattern, "[]") {
+		return nil, fmt.Errorf("character ranges in pattern %q are not supported", pattern)
+	}
+
+	p := strings.ReplaceAll(pattern, `\#`, "#")
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return nil, fmt.Errorf("empty pattern %q", pattern)
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+		case p[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(p[i])))
+		}
+	}
+
+	lastSegment := p[strings.LastIndex(p, "/")+1:]
+	switch {
+	case dirOnly:
+		b.WriteString("/.*")
+	case strings.Contains(lastSegment, "*") && lastSegment != "**":
+	default:
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
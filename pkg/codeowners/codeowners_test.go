@@ -0,0 +1,129 @@
+package codeowners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		matches bool
+	}{
+		// Wildcards match at any depth
+		{"*", "main.go", true},
+		{"*", "pkg/github/server.go", true},
+		{"*.js", "app.js", true},
+		{"*.js", "src/app.js", true},
+		{"*.js", "src/app.ts", false},
+		// A pattern without slashes matches files and directories anywhere
+		{"docs", "docs/index.md", true},
+		{"docs", "web/docs/index.md", true},
+		{"README.md", "pkg/README.md", true},
+		// A trailing slash matches directories anywhere
+		{"apps/", "apps/web/main.go", true},
+		{"apps/", "services/apps/main.go", true},
+		{"apps/", "apps", false},
+		// A leading slash anchors to the repository root
+		{"/build/logs/", "build/logs/out.log", true},
+		{"/build/logs/", "src/build/logs/out.log", false},
+		{"/docs", "docs/a/b.md", true},
+		// A slash in the middle anchors to the repository root too
+		{"docs/*", "docs/getting-started.md", true},
+		{"docs/*", "docs/build-app/troubleshooting.md", false},
+		{"docs/*", "web/docs/getting-started.md", false},
+		{"pkg/github", "pkg/github/server.go", true},
+		{"pkg/github", "cmd/pkg/github/main.go", false},
+		// Double asterisks match across directories
+		{"**/logs", "logs/out.log", true},
+		{"**/logs", "deep/nested/logs/out.log", true},
+		{"docs/**/*.md", "docs/a/b/c.md", true},
+		{"docs/**/*.md", "docs/c.md", true},
+		{"docs/**/*.md", "docs/a/c.txt", false},
+		{"/src/**", "src/a/b.go", true},
+		// Question marks match a single character
+		{"file?.txt", "file1.txt", true},
+		{"file?.txt", "file10.txt", false},
+		// Leading slashes on paths are ignored
+		{"/docs/", "/docs/index.md", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.pattern+" "+tc.path, func(t *testing.T) {
+			matches, err := MatchPattern(tc.pattern, tc.path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.matches, matches)
+		})
+	}
+}
+
+func TestMatchPatternUnsupported(t *testing.T) {
+	for _, pattern := range []string{"!docs/", "[abc].go", "/"} {
+		_, err := MatchPattern(pattern, "docs/index.md")
+		assert.Error(t, err, pattern)
+	}
+}
+
+func TestParse(t *testing.T) {
+	content := `# Default owners
+*       @global-owner1 @global-owner2
+
+*.js    @js-owner #This is an inline comment.
+/docs/  docs@example.com
+!negated @nobody
+/apps/github
+\#notes @hash-owner
+`
+	f := Parse(content)
+	require.Len(t, f.Rules, 5)
+
+	assert.Equal(t, Rule{Pattern: "*", Owners: []string{"@global-owner1", "@global-owner2"}, Line: 2}, withoutRegexp(f.Rules[0]))
+	assert.Equal(t, Rule{Pattern: "*.js", Owners: []string{"@js-owner"}, Line: 4}, withoutRegexp(f.Rules[1]))
+	assert.Equal(t, Rule{Pattern: "/docs/", Owners: []string{"docs@example.com"}, Line: 5}, withoutRegexp(f.Rules[2]))
+	assert.Equal(t, Rule{Pattern: "/apps/github", Owners: []string{}, Line: 7}, withoutRegexp(f.Rules[3]))
+	assert.Equal(t, Rule{Pattern: `\#notes`, Owners: []string{"@hash-owner"}, Line: 8}, withoutRegexp(f.Rules[4]))
+}
+
+func TestFileOwners(t *testing.T) {
+	f := Parse(`*          @org/everyone
+*.go       @org/gophers
+/docs/     @org/writers
+/docs/api/ @org/api-team
+/apps/github
+`)
+
+	tests := []struct {
+		path         string
+		expectedRule string
+		expected     []string
+	}{
+		{"README.md", "*", []string{"@org/everyone"}},
+		{"pkg/server.go", "*.go", []string{"@org/gophers"}},
+		{"docs/index.md", "/docs/", []string{"@org/writers"}},
+		// The last matching rule wins, even over a more general earlier one
+		{"docs/api/tool.go", "/docs/api/", []string{"@org/api-team"}},
+		// A rule without owners removes ownership
+		{"apps/github/main.go", "/apps/github", []string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			rule := f.Match(tc.path)
+			require.NotNil(t, rule)
+			assert.Equal(t, tc.expectedRule, rule.Pattern)
+			assert.Equal(t, tc.expected, f.Owners(tc.path))
+		})
+	}
+
+	assert.Nil(t, Parse("/docs/ @org/writers").Match("README.md"))
+	assert.Nil(t, Parse("/docs/ @org/writers").Owners("README.md"))
+}
+
+// withoutRegexp drops the compiled pattern so rules can be compared by value.
+func withoutRegexp(r Rule) Rule {
+	r.re = nil
+	return r
+}
@@ -0,0 +1,78 @@
+// Package config holds the configuration the GitHub MCP server is started with, wherever it was read from:
+// command-line flags, environment variables or a file.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ServerConfig is the configuration of the server.
+type ServerConfig struct {
+	// EnabledToolsets are the names of the toolsets to enable, or "all" to enable every toolset.
+	EnabledToolsets []string
+	// DisabledTools are the names of the tools to leave out, even from the enabled toolsets.
+	DisabledTools []string
+	// ReadOnly restricts the server to the tools that don't change anything.
+	ReadOnly bool
+	// Timeout bounds every request to the GitHub API, as a duration such as 30s. Requests don't time out when
+	// it is empty.
+	Timeout string
+	// BaseURL is the URL of the GitHub API of a GitHub Enterprise Server, such as https://ghes.example.com.
+	// The GitHub.com API is used when it is empty.
+	BaseURL string
+	// UploadURL is the URL release assets are uploaded to on a GitHub Enterprise Server. It defaults to BaseURL.
+	UploadURL string
+}
+
+// Validate checks that the timeout is a positive duration and that the URLs are absolute HTTP or HTTPS URLs.
+func (c ServerConfig) Validate() error {
+	if _, err := c.TimeoutDuration(); err != nil {
+		return err
+	}
+	if err := validateURL("base URL", c.BaseURL); err != nil {
+		return err
+	}
+	if err := validateURL("upload URL", c.UploadURL); err != nil {
+		return err
+	}
+	if c.UploadURL != "" && c.BaseURL == "" {
+		return fmt.Errorf("an upload URL can only be set along with a base URL")
+	}
+	return nil
+}
+
+// TimeoutDuration returns the timeout of the requests to the GitHub API, or zero when they don't time out.
+func (c ServerConfig) TimeoutDuration() (time.Duration, error) {
+	if c.Timeout == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(c.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q: it must be a duration such as 30s or 2m", c.Timeout)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q: it must be positive", c.Timeout)
+	}
+	return timeout, nil
+}
+
+// UploadURLOrDefault returns the upload URL, or the base URL when no upload URL is set.
+func (c ServerConfig) UploadURLOrDefault() string {
+	if c.UploadURL == "" {
+		return c.BaseURL
+	}
+	return c.UploadURL
+}
+
+func validateURL(name, rawURL string) error {
+	if rawURL == "" {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid %s %q: it must be an absolute http or https URL", name, rawURL)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         ServerConfig
+		expectedErr string
+	}{
+		{
+			name: "empty configuration",
+			cfg:  ServerConfig{},
+		},
+		{
+			name: "GitHub Enterprise Server with a timeout",
+			cfg: ServerConfig{
+				EnabledToolsets: []string{"repos", "issues"},
+				Timeout:         "30s",
+				BaseURL:         "https://ghes.example.com",
+				UploadURL:       "https://uploads.ghes.example.com",
+			},
+		},
+		{
+			name:        "malformed timeout",
+			cfg:         ServerConfig{Timeout: "30"},
+			expectedErr: `invalid timeout "30": it must be a duration such as 30s or 2m`,
+		},
+		{
+			name:        "negative timeout",
+			cfg:         ServerConfig{Timeout: "-5s"},
+			expectedErr: `invalid timeout "-5s": it must be positive`,
+		},
+		{
+			name:        "hostname instead of a URL",
+			cfg:         ServerConfig{BaseURL: "ghes.example.com"},
+			expectedErr: `invalid base URL "ghes.example.com": it must be an absolute http or https URL`,
+		},
+		{
+			name:        "unsupported scheme",
+			cfg:         ServerConfig{BaseURL: "https://ghes.example.com", UploadURL: "ftp://ghes.example.com"},
+			expectedErr: `invalid upload URL "ftp://ghes.example.com": it must be an absolute http or https URL`,
+		},
+		{
+			name:        "upload URL without a base URL",
+			cfg:         ServerConfig{UploadURL: "https://uploads.ghes.example.com"},
+			expectedErr: "an upload URL can only be set along with a base URL",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestServerConfigTimeoutDuration(t *testing.T) {
+	timeout, err := ServerConfig{}.TimeoutDuration()
+	require.NoError(t, err)
+	assert.Zero(t, timeout)
+
+	timeout, err = ServerConfig{Timeout: "1m30s"}.TimeoutDuration()
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, timeout)
+}
+
+func TestServerConfigUploadURLOrDefault(t *testing.T) {
+	assert.Equal(t, "https://ghes.example.com", ServerConfig{BaseURL: "https://ghes.example.com"}.UploadURLOrDefault())
+	assert.Equal(t, "https://uploads.ghes.example.com", ServerConfig{
+		BaseURL:   "https://ghes.example.com",
+		UploadURL: "https://uploads.ghes.example.com",
+	}.UploadURLOrDefault())
+}
@@ -0,0 +1,112 @@
+// Package crypto fetches the public keys GitHub hands out for receiving secrets and encrypts
+// secret values with them, so every secret-related tool encrypts the same way.
+package crypto
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/google/go-github/v69/github"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// KeyScope identifies which public key to fetch, since Actions, Dependabot and Codespaces secrets
+// each have their own keys at the repository and organization level.
+type KeyScope int
+
+const (
+	// ActionsRepo is the key for GitHub Actions secrets of a repository.
+	ActionsRepo KeyScope = iota
+	// ActionsOrg is the key for GitHub Actions secrets of an organization.
+	ActionsOrg
+	// DependabotRepo is the key for Dependabot secrets of a repository.
+	DependabotRepo
+	// DependabotOrg is the key for Dependabot secrets of an organization.
+	DependabotOrg
+	// Codespaces is the key for Codespaces secrets of a repository.
+	Codespaces
+)
+
+// String returns a human readable name of the scope, for use in messages.
+func (s KeyScope) String() string {
+	switch s {
+	case ActionsRepo:
+		return "Actions repository"
+	case ActionsOrg:
+		return "Actions organization"
+	case DependabotRepo:
+		return "Dependabot repository"
+	case DependabotOrg:
+		return "Dependabot organization"
+	case Codespaces:
+		return "Codespaces repository"
+	default:
+		return fmt.Sprintf("KeyScope(%d)", int(s))
+	}
+}
+
+// PublicKey is a public key secrets have to be encrypted with before they are sent to GitHub.
+type PublicKey struct {
+	// KeyID identifies the key, and has to be sent along with secrets encrypted with it.
+	KeyID string `json:"key_id"`
+	// Key is the base64 encoded Curve25519 public key.
+	Key string `json:"key"`
+}
+
+// FetchPublicKey fetches the public key of the given scope. For organization scopes, owner is the
+// organization and repo is ignored.
+func FetchPublicKey(ctx context.Context, client *github.Client, scope KeyScope, owner, repo string) (*PublicKey, error) {
+	var (
+		key  *github.PublicKey
+		resp *github.Response
+		err  error
+	)
+	switch scope {
+	case ActionsRepo:
+		key, resp, err = client.Actions.GetRepoPublicKey(ctx, owner, repo)
+	case ActionsOrg:
+		key, resp, err = client.Actions.GetOrgPublicKey(ctx, owner)
+	case DependabotRepo:
+		key, resp, err = client.Dependabot.GetRepoPublicKey(ctx, owner, repo)
+	case DependabotOrg:
+		key, resp, err = client.Dependabot.GetOrgPublicKey(ctx, owner)
+	case Codespaces:
+		key, resp, err = client.Codespaces.GetRepoPublicKey(ctx, owner, repo)
+	default:
+		return nil, fmt.Errorf("unknown key scope %d", int(scope))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s public key: %w", scope, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return &PublicKey{
+		KeyID: key.GetKeyID(),
+		Key:   key.GetKey(),
+	}, nil
+}
+
+// EncryptSecret encrypts a secret value with a libsodium compatible sealed box, the format GitHub
+// expects, and returns it base64 encoded.
+func EncryptSecret(publicKey *PublicKey, plaintext string) (string, error) {
+	if publicKey == nil {
+		return "", fmt.Errorf("public key is required")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(publicKey.Key)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode public key %s: %w", publicKey.KeyID, err)
+	}
+	if len(decoded) != 32 {
+		return "", fmt.Errorf("public key %s has %d bytes, expected 32", publicKey.KeyID, len(decoded))
+	}
+
+	var recipient [32]byte
+	copy(recipient[:], decoded)
+	sealed, err := box.SealAnonymous(nil, []byte(plaintext), &recipient, rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
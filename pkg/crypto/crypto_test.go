@@ -0,0 +1,131 @@
+package crypto
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// keyFixture is a public key as GitHub returns it, along with its private key so tests can
+// decrypt what was encrypted for it.
+type keyFixture struct {
+	PublicKey
+	PrivateKey string `json:"private_key"`
+}
+
+func loadKeyFixture(t *testing.T) keyFixture {
+	t.Helper()
+	data, err := os.ReadFile("testdata/public_key.json")
+	require.NoError(t, err)
+
+	var fixture keyFixture
+	require.NoError(t, json.Unmarshal(data, &fixture))
+	return fixture
+}
+
+func TestFetchPublicKey(t *testing.T) {
+	fixture := loadKeyFixture(t)
+
+	tests := []struct {
+		scope    KeyScope
+		endpoint mock.EndpointPattern
+	}{
+		{ActionsRepo, mock.GetReposActionsSecretsPublicKeyByOwnerByRepo},
+		{ActionsOrg, mock.GetOrgsActionsSecretsPublicKeyByOrg},
+		{DependabotRepo, mock.GetReposDependabotSecretsPublicKeyByOwnerByRepo},
+		{DependabotOrg, mock.GetOrgsDependabotSecretsPublicKeyByOrg},
+		{Codespaces, mock.GetReposCodespacesSecretsPublicKeyByOwnerByRepo},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.scope.String(), func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(tc.endpoint, fixture.PublicKey),
+			))
+
+			key, err := FetchPublicKey(context.Background(), client, tc.scope, "owner", "repo")
+			require.NoError(t, err)
+			assert.Equal(t, &fixture.PublicKey, key)
+		})
+	}
+
+	t.Run("request fails", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposDependabotSecretsPublicKeyByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				}),
+			),
+		))
+
+		_, err := FetchPublicKey(context.Background(), client, DependabotRepo, "owner", "repo")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get Dependabot repository public key")
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		_, err := FetchPublicKey(context.Background(), github.NewClient(nil), KeyScope(42), "owner", "repo")
+		assert.EqualError(t, err, "unknown key scope 42")
+	})
+}
+
+func TestEncryptSecret(t *testing.T) {
+	fixture := loadKeyFixture(t)
+
+	encrypted, err := EncryptSecret(&fixture.PublicKey, "s3cr3t")
+	require.NoError(t, err)
+
+	// Decrypt with the fixture's private key to check the value round trips
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	require.NoError(t, err)
+	var publicKey, privateKey [32]byte
+	decodeKey(t, fixture.Key, &publicKey)
+	decodeKey(t, fixture.PrivateKey, &privateKey)
+	plaintext, ok := box.OpenAnonymous(nil, sealed, &publicKey, &privateKey)
+	require.True(t, ok, "expected the secret to decrypt with the fixture's private key")
+	assert.Equal(t, "s3cr3t", string(plaintext))
+
+	// Sealed boxes use an ephemeral key, so encrypting twice gives different results
+	again, err := EncryptSecret(&fixture.PublicKey, "s3cr3t")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, again)
+}
+
+func TestEncryptSecretInvalidKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         *PublicKey
+		expectedErr string
+	}{
+		{"missing key", nil, "public key is required"},
+		{"not base64", &PublicKey{KeyID: "1", Key: "not base64!"}, "failed to decode public key 1"},
+		{"wrong length", &PublicKey{KeyID: "2", Key: base64.StdEncoding.EncodeToString([]byte("short"))}, "public key 2 has 5 bytes, expected 32"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := EncryptSecret(tc.key, "s3cr3t")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
+
+func decodeKey(t *testing.T, encoded string, key *[32]byte) {
+	t.Helper()
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	require.Len(t, decoded, 32)
+	copy(key[:], decoded)
+}
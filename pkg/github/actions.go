@@ -0,0 +1,1826 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+// firstFailedStep returns the name of the first step of a job that failed, or an empty string if none did.
+func firstFailedStep(job *github.WorkflowJob) string {
+	for _, step := range job.Steps {
+		if step.GetConclusion() == "failure" {
+			return step.GetName()
+		}
+	}
+	return ""
+}
+
+// GetWorkflowJobSteps creates a tool to get the step-level status of a workflow job.
+func GetWorkflowJobSteps(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_workflow_job_steps",
+			mcp.WithDescription(t("TOOL_GET_WORKFLOW_JOB_STEPS_DESCRIPTION", "Get the status and conclusion of each step of a GitHub Actions workflow job")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("job_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the job"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			jobID, err := RequiredInt(request, "job_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			job, resp, err := client.Actions.GetWorkflowJobByID(ctx, owner, repo, int64(jobID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get workflow job: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get workflow job: %s", string(body))), nil
+			}
+
+			steps := make([]map[string]interface{}, 0, len(job.Steps))
+			for _, step := range job.Steps {
+				steps = append(steps, map[string]interface{}{
+					"name":         step.GetName(),
+					"status":       step.GetStatus(),
+					"conclusion":   step.GetConclusion(),
+					"number":       step.GetNumber(),
+					"started_at":   step.StartedAt,
+					"completed_at": step.CompletedAt,
+				})
+			}
+
+			result := map[string]interface{}{
+				"run_id":            job.GetRunID(),
+				"runner_name":       job.GetRunnerName(),
+				"runner_group_name": job.GetRunnerGroupName(),
+				"workflow_name":     job.GetWorkflowName(),
+				"html_url":          job.GetHTMLURL(),
+				"steps":             steps,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListWorkflowRunJobs creates a tool to list the jobs of a workflow run.
+func ListWorkflowRunJobs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_workflow_run_jobs",
+			mcp.WithDescription(t("TOOL_LIST_WORKFLOW_RUN_JOBS_DESCRIPTION", "List the jobs of a GitHub Actions workflow run with their status and first failed step")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+			mcp.WithString("filter",
+				mcp.Description("Filter jobs by the attempt they ran in: 'latest' for the most recent attempt, 'all' for every attempt"),
+				mcp.Enum("latest", "all"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filter, err := OptionalParam[string](request, "filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListWorkflowJobsOptions{
+				Filter: filter,
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, int64(runID), opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list workflow run jobs: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list workflow run jobs: %s", string(body))), nil
+			}
+
+			summaries := make([]map[string]interface{}, 0, len(jobs.Jobs))
+			for _, job := range jobs.Jobs {
+				summaries = append(summaries, map[string]interface{}{
+					"id":           job.GetID(),
+					"name":         job.GetName(),
+					"status":       job.GetStatus(),
+					"conclusion":   job.GetConclusion(),
+					"started_at":   job.StartedAt,
+					"completed_at": job.CompletedAt,
+					"steps_count":  len(job.Steps),
+					"failed_step":  firstFailedStep(job),
+				})
+			}
+
+			result := map[string]interface{}{
+				"total_count": jobs.GetTotalCount(),
+				"jobs":        summaries,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+const (
+	// defaultRecentFailures is how many failed runs list_recent_failures returns unless asked otherwise.
+	defaultRecentFailures = 10
+	// maxRecentFailures bounds the failed runs list_recent_failures looks into, as each one costs a call.
+	maxRecentFailures = 50
+)
+
+// workflowRunFailure is a failed workflow run along with the job and step that failed first.
+type workflowRunFailure struct {
+	RunID       int64             `json:"run_id"`
+	RunURL      string            `json:"run_url"`
+	Workflow    string            `json:"workflow"`
+	Branch      string            `json:"branch"`
+	HeadSHA     string            `json:"head_sha"`
+	CreatedAt   *github.Timestamp `json:"created_at"`
+	FailingJob  string            `json:"failing_job"`
+	FailingStep string            `json:"failing_step"`
+	JobURL      string            `json:"job_url,omitempty"`
+}
+
+// resolveRunFailure finds the first job of a failed workflow run that failed, and its first failed step.
+// Runs that failed without a failing job, such as those with an invalid workflow file, leave both empty.
+func resolveRunFailure(ctx context.Context, client *github.Client, owner, repo string, run *github.WorkflowRun) (workflowRunFailure, error) {
+	failure := workflowRunFailure{
+		RunID:     run.GetID(),
+		RunURL:    run.GetHTMLURL(),
+		Workflow:  run.GetName(),
+		Branch:    run.GetHeadBranch(),
+		HeadSHA:   run.GetHeadSHA(),
+		CreatedAt: run.CreatedAt,
+	}
+
+	opts := &github.ListWorkflowJobsOptions{Filter: "latest", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, run.GetID(), opts)
+		if err != nil {
+			return workflowRunFailure{}, fmt.Errorf("failed to list jobs of workflow run %d: %w", run.GetID(), err)
+		}
+		_ = resp.Body.Close()
+		for _, job := range jobs.Jobs {
+			if job.GetConclusion() == "failure" {
+				failure.FailingJob = job.GetName()
+				failure.FailingStep = firstFailedStep(job)
+				failure.JobURL = job.GetHTMLURL()
+				return failure, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return failure, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ListRecentFailures creates a tool to list the most recent failed workflow runs of a repository with what failed in each.
+func ListRecentFailures(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_recent_failures",
+			mcp.WithDescription(t("TOOL_LIST_RECENT_FAILURES_DESCRIPTION", "List the most recent failed GitHub Actions workflow runs of a repository, most recent first, with the first job and step that failed in each")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("count",
+				mcp.Description(fmt.Sprintf("Number of failed runs to return, defaults to %d (max %d)", defaultRecentFailures, maxRecentFailures)),
+				mcp.Min(1),
+				mcp.Max(maxRecentFailures),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			count, err := OptionalIntParamWithDefault(request, "count", defaultRecentFailures)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if count < 1 || count > maxRecentFailures {
+				return mcp.NewToolResultError(fmt.Sprintf("count must be between 1 and %d", maxRecentFailures)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			opts := &github.ListWorkflowRunsOptions{
+				Status:      "failure",
+				ListOptions: github.ListOptions{PerPage: count},
+			}
+			runs, resp, err := client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list workflow runs: %s", string(body))), nil
+			}
+
+			// Finding the failing step takes a call per run, so the runs are inspected concurrently, within bounds
+			failures := make([]workflowRunFailure, len(runs.WorkflowRuns))
+			errs := forEachConcurrently(ctx, runs.WorkflowRuns, func(ctx context.Context, i int, run *github.WorkflowRun) error {
+				var err error
+				failures[i], err = resolveRunFailure(ctx, client, owner, repo, run)
+				return err
+			})
+			if err := errors.Join(errs...); err != nil {
+				return nil, err
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"failures":    failures,
+				"total_count": runs.GetTotalCount(),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+const (
+	// defaultRedRepoScan is how many repositories list_org_red_repos inspects unless asked otherwise.
+	defaultRedRepoScan = 30
+	// maxRedRepoScan bounds the repositories list_org_red_repos inspects, as each one costs at least two calls.
+	maxRedRepoScan = 100
+)
+
+// redRepo is a repository whose latest commit on the default branch has failing statuses or check runs.
+type redRepo struct {
+	Repository    string   `json:"repository"`
+	DefaultBranch string   `json:"default_branch"`
+	HeadSHA       string   `json:"head_sha"`
+	FailingChecks []string `json:"failing_checks"`
+	HTMLURL       string   `json:"html_url"`
+}
+
+// defaultBranchFailures returns the failing statuses and check runs of the latest commit on the default branch
+// of a repository, sorted by name, and that commit. Repositories without commits have neither.
+func defaultBranchFailures(ctx context.Context, client *github.Client, repo *github.Repository) ([]string, string, error) {
+	owner, name, branch := repo.GetOwner().GetLogin(), repo.GetName(), repo.GetDefaultBranch()
+	combined, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, name, branch, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		// Empty repositories have no default branch to report on yet
+		if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusConflict) {
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to get the combined status of %s: %w", repo.GetFullName(), err)
+	}
+	_ = resp.Body.Close()
+	checkRuns, err := listAllCheckRuns(ctx, client, owner, name, branch)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", repo.GetFullName(), err)
+	}
+
+	failing := []string{}
+	for check, outcome := range statusCheckOutcomes(combined, checkRuns) {
+		if outcome == "failing" {
+			failing = append(failing, check)
+		}
+	}
+	slices.Sort(failing)
+	return failing, combined.GetSHA(), nil
+}
+
+// ListOrgRedRepos creates a tool to list the repositories of an organization whose default branch is failing CI.
+func ListOrgRedRepos(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_red_repos",
+			mcp.WithDescription(t("TOOL_LIST_ORG_RED_REPOS_DESCRIPTION", "List the repositories of an organization whose latest commit on the default branch has failing statuses or check runs. The most recently pushed repositories are inspected first, and archived repositories are skipped")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			mcp.WithNumber("max_repos",
+				mcp.Description(fmt.Sprintf("Number of repositories to inspect, defaults to %d (max %d)", defaultRedRepoScan, maxRedRepoScan)),
+				mcp.Min(1),
+				mcp.Max(maxRedRepoScan),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxRepos, err := OptionalIntParamWithDefault(request, "max_repos", defaultRedRepoScan)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxRepos < 1 || maxRepos > maxRedRepoScan {
+				return mcp.NewToolResultError(fmt.Sprintf("max_repos must be between 1 and %d", maxRedRepoScan)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repoOpts := &github.RepositoryListByOrgOptions{
+				Sort:        "pushed",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: maxRepos},
+			}
+			repos, resp, err := client.Repositories.ListByOrg(ctx, org, repoOpts)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("organization %s not found", org)), nil
+				}
+				return nil, fmt.Errorf("failed to list repositories: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			active := slices.DeleteFunc(repos, func(repo *github.Repository) bool {
+				return repo.GetArchived()
+			})
+
+			// Every repository costs a status and a check runs call, so they are inspected concurrently, within bounds
+			red := make([]*redRepo, len(active))
+			errs := forEachConcurrently(ctx, active, func(ctx context.Context, i int, repo *github.Repository) error {
+				failing, sha, err := defaultBranchFailures(ctx, client, repo)
+				if err != nil {
+					return err
+				}
+				if len(failing) > 0 {
+					red[i] = &redRepo{
+						Repository:    repo.GetFullName(),
+						DefaultBranch: repo.GetDefaultBranch(),
+						HeadSHA:       sha,
+						FailingChecks: failing,
+						HTMLURL:       repo.GetHTMLURL(),
+					}
+				}
+				return nil
+			})
+			if err := errors.Join(errs...); err != nil {
+				return nil, err
+			}
+
+			redRepos := []*redRepo{}
+			for _, repo := range red {
+				if repo != nil {
+					redRepos = append(redRepos, repo)
+				}
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"red_repos":     redRepos,
+				"repos_scanned": len(active),
+				// More repositories exist than were inspected, so red ones among them may be missing
+				"truncated": resp.NextPage != 0,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+const (
+	// maxJobLogBytes caps the size of the log returned by get_job_log.
+	maxJobLogBytes = 50 * 1024
+	// defaultJobLogTailLines is the number of log lines get_job_log returns by default.
+	defaultJobLogTailLines = 500
+	// defaultDownloadTimeout bounds downloads from pre-signed URLs when the GitHub client has no timeout.
+	defaultDownloadTimeout = 2 * time.Minute
+)
+
+// downloadClient returns a client for the short-lived pre-signed URLs logs and archives are served from. It doesn't
+// use the transport of the GitHub client, as that would send the GitHub credentials along, but it takes the timeout
+// the client was configured with.
+func downloadClient(client *github.Client) *http.Client {
+	timeout := client.Client().Timeout
+	if timeout <= 0 {
+		timeout = defaultDownloadTimeout
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+// tailWriter keeps only the last limit bytes written to it, counting all of them.
+type tailWriter struct {
+	limit int
+	buf   []byte
+	total int
+}
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	w.total += len(p)
+	if len(p) >= w.limit {
+		w.buf = append(w.buf[:0], p[len(p)-w.limit:]...)
+		return len(p), nil
+	}
+	if over := len(w.buf) + len(p) - w.limit; over > 0 {
+		w.buf = w.buf[:copy(w.buf, w.buf[over:])]
+	}
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+// tail returns the bytes kept, dropping the partial line they start with when the start was cut off.
+func (w *tailWriter) tail() string {
+	tail := string(w.buf)
+	if w.total > len(w.buf) {
+		if i := strings.IndexByte(tail, '\n'); i >= 0 {
+			tail = tail[i+1:]
+		}
+	}
+	return tail
+}
+
+// ansiEscapePattern matches ANSI escape sequences such as the color codes in workflow logs.
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// tailJobLog strips ANSI escape codes from a log and keeps only its last tailLines lines,
+// capped at maxJobLogBytes. It reports whether anything was cut off.
+func tailJobLog(log string, tailLines int) (string, bool) {
+	log = ansiEscapePattern.ReplaceAllString(log, "")
+	truncated := false
+
+	if tailLines > 0 {
+		lines := strings.Split(strings.TrimRight(log, "\n"), "\n")
+		if len(lines) > tailLines {
+			lines = lines[len(lines)-tailLines:]
+			truncated = true
+		}
+		log = strings.Join(lines, "\n")
+	}
+
+	if len(log) > maxJobLogBytes {
+		log = log[len(log)-maxJobLogBytes:]
+		// Don't start in the middle of a line
+		if i := strings.IndexByte(log, '\n'); i >= 0 && i < len(log)-1 {
+			log = log[i+1:]
+		}
+		log = strings.ToValidUTF8(log, "")
+		truncated = true
+	}
+
+	return log, truncated
+}
+
+// GetJobLog creates a tool to get the log of a single workflow job.
+func GetJobLog(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_job_log",
+			mcp.WithDescription(t("TOOL_GET_JOB_LOG_DESCRIPTION", "Get the plain-text log of a single GitHub Actions workflow job, such as a failed one. Returns the end of the log, at most 50 KB, with ANSI escape codes removed")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("job_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the job"),
+			),
+			mcp.WithNumber("tail_lines",
+				mcp.Description(fmt.Sprintf("Number of lines to return from the end of the log, defaults to %d", defaultJobLogTailLines)),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			jobID, err := RequiredInt(request, "job_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tailLines, err := OptionalIntParamWithDefault(request, "tail_lines", defaultJobLogTailLines)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			logURL, resp, err := client.Actions.GetWorkflowJobLogs(ctx, owner, repo, int64(jobID), 1)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("no log found for job %d, it may have expired or the job may not have run", jobID)), nil
+				}
+				return nil, fmt.Errorf("failed to get job log URL: %w", err)
+			}
+
+			// The log lives behind a short-lived pre-signed URL, so it is fetched without the GitHub credentials
+			logRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, logURL.String(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create job log request: %w", err)
+			}
+			logResp, err := downloadClient(client).Do(logRequest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to download job log: %w", err)
+			}
+			defer func() { _ = logResp.Body.Close() }()
+
+			if logResp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(io.LimitReader(logResp.Body, maxJobLogBytes))
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to download job log: %s", string(body))), nil
+			}
+
+			// Only the end of the log is returned, so only that much of it is kept while it streams in
+			body := &tailWriter{limit: maxJobLogBytes}
+			if _, err := io.Copy(body, logResp.Body); err != nil {
+				return nil, fmt.Errorf("failed to read response body: %w", err)
+			}
+
+			log, truncated := tailJobLog(body.tail(), tailLines)
+			result := map[string]interface{}{
+				"log":         log,
+				"truncated":   truncated || body.total > len(body.buf),
+				"total_bytes": body.total,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListPendingDeployments creates a tool to list the environments a workflow run is waiting on approval for.
+func ListPendingDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_pending_deployments",
+			mcp.WithDescription(t("TOOL_LIST_PENDING_DEPLOYMENTS_DESCRIPTION", "List the environments a GitHub Actions workflow run is waiting to deploy to, with the users and teams who can approve each deployment")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pending, resp, err := client.Actions.GetPendingDeployments(ctx, owner, repo, int64(runID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pending deployments: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pending deployments: %s", string(body))), nil
+			}
+
+			deployments := make([]map[string]interface{}, 0, len(pending))
+			for _, p := range pending {
+				reviewers := make([]map[string]interface{}, 0, len(p.Reviewers))
+				for _, r := range p.Reviewers {
+					switch reviewer := r.Reviewer.(type) {
+					case *github.User:
+						reviewers = append(reviewers, map[string]interface{}{
+							"type":  "user",
+							"login": reviewer.GetLogin(),
+						})
+					case *github.Team:
+						reviewers = append(reviewers, map[string]interface{}{
+							"type": "team",
+							"slug": reviewer.GetSlug(),
+							"name": reviewer.GetName(),
+						})
+					}
+				}
+				deployments = append(deployments, map[string]interface{}{
+					"environment":              p.GetEnvironment().GetName(),
+					"environment_id":           p.GetEnvironment().GetID(),
+					"html_url":                 p.GetEnvironment().GetHTMLURL(),
+					"wait_timer":               p.GetWaitTimer(),
+					"wait_timer_started_at":    p.WaitTimerStartedAt,
+					"current_user_can_approve": p.GetCurrentUserCanApprove(),
+					"reviewers":                reviewers,
+				})
+			}
+
+			r, err := json.Marshal(deployments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListEnvironmentSecrets creates a tool to list the names of the secrets of a deployment environment.
+func ListEnvironmentSecrets(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_environment_secrets",
+			mcp.WithDescription(t("TOOL_LIST_ENVIRONMENT_SECRETS_DESCRIPTION", "List the secrets of a deployment environment of a repository, with when each was last updated. Secret values can't be read back, only their names")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("environment",
+				mcp.Required(),
+				mcp.Description("Name of the environment"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := requiredParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github addresses environment secrets by repository ID, the request is built here to
+			// address them by owner and name instead and save looking the repository up
+			secrets := []map[string]interface{}{}
+			for page := 1; page != 0; {
+				u := fmt.Sprintf("repos/%v/%v/environments/%v/secrets?per_page=100&page=%d", owner, repo, url.PathEscape(environment), page)
+				req, err := client.NewRequest("GET", u, nil)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create request: %w", err)
+				}
+				listed := new(github.Secrets)
+				resp, err := client.Do(ctx, req, listed)
+				if err != nil {
+					if isGitHubErrorStatus(err, http.StatusNotFound) {
+						return mcp.NewToolResultError(fmt.Sprintf("environment %s not found in %s/%s", environment, owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to list environment secrets: %w", err)
+				}
+				_ = resp.Body.Close()
+
+				for _, secret := range listed.Secrets {
+					secrets = append(secrets, map[string]interface{}{
+						"name":       secret.Name,
+						"updated_at": secret.UpdatedAt,
+					})
+				}
+				page = resp.NextPage
+			}
+
+			r, err := json.Marshal(secrets)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// maxCurrentDeploymentEnvironments bounds the number of environments get_current_deployments looks at. Each
+// costs two calls, one for its latest deployment and one for the status of that deployment.
+const maxCurrentDeploymentEnvironments = 50
+
+// latestEnvironmentDeployment fetches the most recent deployment to an environment and its latest status.
+// It returns nil if nothing was ever deployed to the environment.
+func latestEnvironmentDeployment(ctx context.Context, client *github.Client, owner, repo, environment string) (map[string]interface{}, error) {
+	deployments, resp, err := client.Repositories.ListDeployments(ctx, owner, repo, &github.DeploymentsListOptions{
+		Environment: environment,
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments to %s: %w", environment, err)
+	}
+	_ = resp.Body.Close()
+	if len(deployments) == 0 {
+		return nil, nil
+	}
+	deployment := deployments[0]
+
+	// Statuses are listed newest first, and a deployment without any hasn't started yet
+	statuses, resp, err := client.Repositories.ListDeploymentStatuses(ctx, owner, repo, deployment.GetID(), &github.ListOptions{PerPage: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statuses of deployment %d: %w", deployment.GetID(), err)
+	}
+	_ = resp.Body.Close()
+	state := "pending"
+	var statusUpdatedAt *github.Timestamp
+	if len(statuses) > 0 {
+		state = statuses[0].GetState()
+		statusUpdatedAt = statuses[0].UpdatedAt
+	}
+
+	return map[string]interface{}{
+		"id":                deployment.GetID(),
+		"ref":               deployment.GetRef(),
+		"sha":               deployment.GetSHA(),
+		"creator":           deployment.GetCreator().GetLogin(),
+		"created_at":        deployment.CreatedAt,
+		"status":            state,
+		"status_updated_at": statusUpdatedAt,
+	}, nil
+}
+
+// GetCurrentDeployments creates a tool to get what is currently deployed to each environment of a repository.
+func GetCurrentDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_current_deployments",
+			mcp.WithDescription(t("TOOL_GET_CURRENT_DEPLOYMENTS_DESCRIPTION", fmt.Sprintf("Get the most recent deployment to each environment of a repository, with its ref and the state of its latest status, such as success, in_progress or failure. Environments never deployed to have no deployment. At most %d environments are looked at", maxCurrentDeploymentEnvironments))),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			listed, resp, err := client.Repositories.ListEnvironments(ctx, owner, repo, &github.EnvironmentListOptions{
+				ListOptions: github.ListOptions{PerPage: maxCurrentDeploymentEnvironments},
+			})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to list environments: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			environments := listed.Environments
+			deployments := make([]map[string]interface{}, len(environments))
+			errs := forEachConcurrently(ctx, environments, func(ctx context.Context, i int, env *github.Environment) error {
+				var err error
+				deployments[i], err = latestEnvironmentDeployment(ctx, client, owner, repo, env.GetName())
+				return err
+			})
+			if err := errors.Join(errs...); err != nil {
+				return nil, err
+			}
+
+			result := make([]map[string]interface{}, 0, len(environments))
+			for i, env := range environments {
+				result = append(result, map[string]interface{}{
+					"environment": env.GetName(),
+					"deployment":  deployments[i],
+				})
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"environments": result,
+				// More environments exist than were looked at, so the result is incomplete
+				"capped": listed.GetTotalCount() > len(environments),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ReviewPendingDeployment creates a tool to approve or reject the pending deployments of a workflow run.
+func ReviewPendingDeployment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("review_pending_deployment",
+			mcp.WithDescription(t("TOOL_REVIEW_PENDING_DEPLOYMENT_DESCRIPTION", "Approve or reject the deployments of a GitHub Actions workflow run that are waiting on environment protection rules. Only required reviewers of the environments can do this")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+			mcp.WithArray("environment_ids",
+				mcp.Required(),
+				mcp.Description("IDs of the environments to approve or reject, as returned by list_pending_deployments"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "number",
+					},
+				),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("Whether to approve or reject the deployments"),
+				mcp.Enum("approved", "rejected"),
+			),
+			mcp.WithString("comment",
+				mcp.Required(),
+				mcp.Description("Comment explaining the review"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environmentIDs, err := RequiredIntArrayParam(request, "environment_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := requiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if state != "approved" && state != "rejected" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid state %q, must be approved or rejected", state)), nil
+			}
+			comment, err := requiredParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			review := &github.PendingDeploymentsRequest{
+				EnvironmentIDs: make([]int64, 0, len(environmentIDs)),
+				State:          state,
+				Comment:        comment,
+			}
+			for _, id := range environmentIDs {
+				review.EnvironmentIDs = append(review.EnvironmentIDs, int64(id))
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			deployments, resp, err := client.Actions.PendingDeployments(ctx, owner, repo, int64(runID), review)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusForbidden) {
+					return mcp.NewToolResultError(fmt.Sprintf("not allowed to review the pending deployments of run %d: only required reviewers of the environments can approve or reject them", runID)), nil
+				}
+				return nil, fmt.Errorf("failed to review pending deployments: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to review pending deployments: %s", string(body))), nil
+			}
+
+			summaries := make([]map[string]interface{}, 0, len(deployments))
+			for _, d := range deployments {
+				summaries = append(summaries, map[string]interface{}{
+					"id":          d.GetID(),
+					"environment": d.GetEnvironment(),
+					"ref":         d.GetRef(),
+					"sha":         d.GetSHA(),
+					"created_at":  d.CreatedAt,
+				})
+			}
+
+			r, err := json.Marshal(summaries)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// rerunJobs sends a re-run request of jobs of a workflow run, then fetches the run to report its new attempt.
+// go-github's rerun methods send no body, so the request is built here to pass enable_debug_logging.
+func rerunJobs(ctx context.Context, client *github.Client, owner, repo, u string, runID int64, debugLogging bool) (*mcp.CallToolResult, error) {
+	req, err := client.NewRequest("POST", u, map[string]bool{"enable_debug_logging": debugLogging})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := client.Do(ctx, req, nil)
+	if err != nil {
+		// GitHub refuses to re-run jobs of a run that is still in progress
+		if isGitHubErrorStatus(err, http.StatusForbidden) {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to re-run jobs of workflow run %d: %s", runID, err)), nil
+		}
+		return nil, fmt.Errorf("failed to re-run jobs: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("failed to re-run jobs: %s", string(body))), nil
+	}
+
+	// The re-run request returns nothing, the run has to be fetched for its new attempt
+	run, runResp, err := client.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow run: %w", err)
+	}
+	defer func() { _ = runResp.Body.Close() }()
+
+	r, err := json.Marshal(map[string]interface{}{
+		"workflow_run": map[string]interface{}{
+			"id":          run.GetID(),
+			"run_attempt": run.GetRunAttempt(),
+			"status":      run.GetStatus(),
+			"rerun_url":   run.GetRerunURL(),
+			"html_url":    run.GetHTMLURL(),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
+
+// RerunFailedJobs creates a tool to re-run the failed jobs of a workflow run, along with the jobs depending on them.
+func RerunFailedJobs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rerun_failed_jobs",
+			mcp.WithDescription(t("TOOL_RERUN_FAILED_JOBS_DESCRIPTION", "Re-run the failed jobs of a GitHub Actions workflow run, along with the jobs depending on them. The run must have completed")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+			mcp.WithBoolean("enable_debug_logging",
+				mcp.Description("Enable debug logging for the re-run, defaults to false"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			debugLogging, err := OptionalParam[bool](request, "enable_debug_logging")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			u := fmt.Sprintf("repos/%v/%v/actions/runs/%v/rerun-failed-jobs", owner, repo, runID)
+			return rerunJobs(ctx, client, owner, repo, u, int64(runID), debugLogging)
+		}
+}
+
+// RerunSpecificJob creates a tool to re-run a single job of a workflow run, along with the jobs depending on it.
+func RerunSpecificJob(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rerun_specific_job",
+			mcp.WithDescription(t("TOOL_RERUN_SPECIFIC_JOB_DESCRIPTION", "Re-run a single job of a GitHub Actions workflow run, along with the jobs depending on it. The run must have completed")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("job_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the job"),
+			),
+			mcp.WithBoolean("enable_debug_logging",
+				mcp.Description("Enable debug logging for the re-run, defaults to false"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			jobID, err := RequiredInt(request, "job_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			debugLogging, err := OptionalParam[bool](request, "enable_debug_logging")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			// A job re-runs as a new attempt of its workflow run, so look the run up to report it
+			job, resp, err := client.Actions.GetWorkflowJobByID(ctx, owner, repo, int64(jobID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get workflow job: %w", err)
+			}
+			_ = resp.Body.Close()
+			u := fmt.Sprintf("repos/%v/%v/actions/jobs/%v/rerun", owner, repo, jobID)
+			return rerunJobs(ctx, client, owner, repo, u, job.GetRunID(), debugLogging)
+		}
+}
+
+// GetActionsCacheUsage creates a tool to get how much GitHub Actions cache storage a repository uses.
+func GetActionsCacheUsage(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_actions_cache_usage",
+			mcp.WithDescription(t("TOOL_GET_ACTIONS_CACHE_USAGE_DESCRIPTION", "Get the number of active GitHub Actions caches of a repository and their total size in bytes")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			usage, resp, err := client.Actions.GetCacheUsageForRepo(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Actions cache usage: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get Actions cache usage: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"active_caches_count":         usage.ActiveCachesCount,
+				"active_caches_size_in_bytes": usage.ActiveCachesSizeInBytes,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListActionsCaches creates a tool to list the GitHub Actions caches of a repository.
+func ListActionsCaches(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_actions_caches",
+			mcp.WithDescription(t("TOOL_LIST_ACTIONS_CACHES_DESCRIPTION", "List the GitHub Actions caches of a repository with their keys, refs, sizes and when they were last used, to decide which to clean up")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Only list the caches of this ref, such as refs/heads/main or refs/pull/42/merge"),
+			),
+			mcp.WithString("key",
+				mcp.Description("Only list the caches whose key starts with this prefix"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort by 'created_at', 'last_accessed_at' or 'size_in_bytes', defaults to last_accessed_at"),
+				mcp.Enum("created_at", "last_accessed_at", "size_in_bytes"),
+			),
+			mcp.WithString("direction",
+				mcp.Description("Sort direction ('asc', 'desc'), defaults to desc"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			key, err := OptionalParam[string](request, "key")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			direction, err := OptionalParam[string](request, "direction")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ActionsCacheListOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+			if ref != "" {
+				opts.Ref = github.Ptr(ref)
+			}
+			if key != "" {
+				opts.Key = github.Ptr(key)
+			}
+			if sort != "" {
+				opts.Sort = github.Ptr(sort)
+			}
+			if direction != "" {
+				opts.Direction = github.Ptr(direction)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			caches, resp, err := client.Actions.ListCaches(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list Actions caches: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list Actions caches: %s", string(body))), nil
+			}
+
+			result := make([]map[string]interface{}, 0, len(caches.ActionsCaches))
+			for _, cache := range caches.ActionsCaches {
+				result = append(result, map[string]interface{}{
+					"id":               cache.GetID(),
+					"key":              cache.GetKey(),
+					"ref":              cache.GetRef(),
+					"size_in_bytes":    cache.GetSizeInBytes(),
+					"last_accessed_at": cache.LastAccessedAt,
+					"created_at":       cache.CreatedAt,
+				})
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"total_count": caches.TotalCount,
+				"caches":      result,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+const (
+	// maxWorkflowRunWaitSeconds caps how long wait_for_workflow_run blocks.
+	maxWorkflowRunWaitSeconds = 300
+	// minWorkflowRunPollSeconds is the shortest interval between two polls of wait_for_workflow_run.
+	minWorkflowRunPollSeconds = 5
+)
+
+// terminalWorkflowRunStatuses are the statuses a workflow run doesn't leave anymore.
+var terminalWorkflowRunStatuses = []string{"completed", "failure", "cancelled", "timed_out", "skipped"}
+
+// waitForWorkflowRunTool creates a tool that polls a workflow run until it ends. Its durations are counted in
+// multiples of unit, which is a second except in tests that can't wait that long.
+func waitForWorkflowRunTool(getClient GetClientFn, t translations.TranslationHelperFunc, unit time.Duration) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("wait_for_workflow_run",
+			mcp.WithDescription(t("TOOL_WAIT_FOR_WORKFLOW_RUN_DESCRIPTION", "Wait for a GitHub Actions workflow run to finish, polling it until it completes or the timeout is reached, and return its conclusion and failed jobs")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+			mcp.WithNumber("timeout_seconds",
+				mcp.Description(fmt.Sprintf("How long to wait for the run to finish, in seconds (max %d), defaults to 120", maxWorkflowRunWaitSeconds)),
+				mcp.Min(1),
+				mcp.Max(maxWorkflowRunWaitSeconds),
+			),
+			mcp.WithNumber("poll_interval_seconds",
+				mcp.Description(fmt.Sprintf("How often to check the run, in seconds (min %d), defaults to 10. GitHub may ask for a longer interval", minWorkflowRunPollSeconds)),
+				mcp.Min(minWorkflowRunPollSeconds),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			timeoutSeconds, err := OptionalIntParamWithDefault(request, "timeout_seconds", 120)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if timeoutSeconds < 1 || timeoutSeconds > maxWorkflowRunWaitSeconds {
+				return mcp.NewToolResultError(fmt.Sprintf("timeout_seconds must be between 1 and %d", maxWorkflowRunWaitSeconds)), nil
+			}
+			pollSeconds, err := OptionalIntParamWithDefault(request, "poll_interval_seconds", 10)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if pollSeconds < minWorkflowRunPollSeconds {
+				return mcp.NewToolResultError(fmt.Sprintf("poll_interval_seconds must be at least %d", minWorkflowRunPollSeconds)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			deadline := time.NewTimer(time.Duration(timeoutSeconds) * unit)
+			defer deadline.Stop()
+			pollInterval := time.Duration(pollSeconds) * unit
+			ticker := time.NewTicker(pollInterval)
+			defer ticker.Stop()
+
+			var run *github.WorkflowRun
+			for {
+				var resp *github.Response
+				run, resp, err = client.Actions.GetWorkflowRunByID(ctx, owner, repo, int64(runID))
+				if err != nil {
+					if isGitHubErrorStatus(err, http.StatusNotFound) {
+						return mcp.NewToolResultError(fmt.Sprintf("workflow run %d not found in %s/%s", runID, owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to get workflow run: %w", err)
+				}
+				_ = resp.Body.Close()
+				if slices.Contains(terminalWorkflowRunStatuses, run.GetStatus()) {
+					break
+				}
+
+				// GitHub tells how often it wants to be polled, never poll faster than that
+				if seconds, err := strconv.Atoi(resp.Header.Get("X-Poll-Interval")); err == nil {
+					if requested := time.Duration(seconds) * unit; requested > pollInterval {
+						pollInterval = requested
+						ticker.Reset(pollInterval)
+					}
+				}
+
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-deadline.C:
+					r, err := json.Marshal(map[string]interface{}{
+						"timed_out":      true,
+						"current_status": run.GetStatus(),
+						"run_number":     run.GetRunNumber(),
+						"html_url":       run.GetHTMLURL(),
+					})
+					if err != nil {
+						return nil, fmt.Errorf("failed to marshal response: %w", err)
+					}
+					return mcp.NewToolResultText(string(r)), nil
+				case <-ticker.C:
+				}
+			}
+
+			jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, int64(runID), &github.ListWorkflowJobsOptions{
+				Filter:      "latest",
+				ListOptions: github.ListOptions{PerPage: 100},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list workflow run jobs: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			failedJobs := []string{}
+			for _, job := range jobs.Jobs {
+				if job.GetConclusion() == "failure" || job.GetConclusion() == "timed_out" {
+					failedJobs = append(failedJobs, job.GetName())
+				}
+			}
+
+			result := map[string]interface{}{
+				"timed_out":   false,
+				"status":      run.GetStatus(),
+				"conclusion":  run.GetConclusion(),
+				"run_number":  run.GetRunNumber(),
+				"html_url":    run.GetHTMLURL(),
+				"failed_jobs": failedJobs,
+			}
+			if run.RunStartedAt != nil && run.UpdatedAt != nil {
+				result["run_duration_seconds"] = int64(run.UpdatedAt.Sub(run.RunStartedAt.Time).Seconds())
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// WaitForWorkflowRun creates a tool to wait for a workflow run to finish.
+func WaitForWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return waitForWorkflowRunTool(getClient, t, time.Second)
+}
+
+// DeleteActionsCache creates a tool to delete GitHub Actions caches of a repository, by ID or by key.
+func DeleteActionsCache(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_actions_cache",
+			mcp.WithDescription(t("TOOL_DELETE_ACTIONS_CACHE_DESCRIPTION", "Delete a GitHub Actions cache of a repository by its ID, or every cache with a key, optionally only on one ref")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("cache_id",
+				mcp.Description("ID of the cache to delete, as returned by list_actions_caches. Either cache_id or key is required"),
+			),
+			mcp.WithString("key",
+				mcp.Description("Key of the caches to delete. Either cache_id or key is required"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Only delete the caches with the key on this ref, such as refs/heads/main. Only used with key"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			cacheID, err := OptionalIntParam(request, "cache_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			key, err := OptionalParam[string](request, "key")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			switch {
+			case (cacheID == 0) == (key == ""):
+				return mcp.NewToolResultError("either cache_id or key is required, but not both"), nil
+			case ref != "" && key == "":
+				return mcp.NewToolResultError("ref can only be used with key"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if cacheID != 0 {
+				resp, err := client.Actions.DeleteCachesByID(ctx, owner, repo, int64(cacheID))
+				if err != nil {
+					if isGitHubErrorStatus(err, http.StatusNotFound) {
+						return mcp.NewToolResultError(fmt.Sprintf("cache %d not found in %s/%s", cacheID, owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to delete Actions cache: %w", err)
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				if resp.StatusCode != http.StatusNoContent {
+					body, err := io.ReadAll(resp.Body)
+					if err != nil {
+						return nil, fmt.Errorf("failed to read response body: %w", err)
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to delete Actions cache: %s", string(body))), nil
+				}
+
+				return mcp.NewToolResultText(fmt.Sprintf("Actions cache %d of %s/%s deleted", cacheID, owner, repo)), nil
+			}
+
+			// go-github drops the caches deleted by key, so the request is built here to count them
+			query := url.Values{"key": []string{key}}
+			if ref != "" {
+				query.Set("ref", ref)
+			}
+			req, err := client.NewRequest("DELETE", fmt.Sprintf("repos/%v/%v/actions/caches?%s", owner, repo, query.Encode()), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			deleted := new(github.ActionsCacheList)
+			resp, err := client.Do(ctx, req, deleted)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(fmt.Sprintf("no cache with key %s found in %s/%s", key, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to delete Actions caches: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete Actions caches: %s", string(body))), nil
+			}
+
+			caches := make([]map[string]interface{}, 0, len(deleted.ActionsCaches))
+			for _, cache := range deleted.ActionsCaches {
+				caches = append(caches, map[string]interface{}{
+					"id":            cache.GetID(),
+					"ref":           cache.GetRef(),
+					"size_in_bytes": cache.GetSizeInBytes(),
+				})
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"deleted_count": deleted.TotalCount,
+				"deleted":       caches,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// workflowDispatchInput is an input a workflow takes when it is dispatched manually.
+type workflowDispatchInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required"`
+	// Type is string, boolean, choice, number or environment.
+	Type    string      `json:"type"`
+	Default interface{} `json:"default,omitempty"`
+	// Options are the values a choice input accepts.
+	Options []string `json:"options,omitempty"`
+}
+
+// parseWorkflowDispatchInputs returns whether a workflow file has a workflow_dispatch trigger, and the inputs
+// it takes, in the order the file declares them. The trigger may be given as a single event, a list of events,
+// or a map of events to their configuration.
+func parseWorkflowDispatchInputs(content string) (bool, []workflowDispatchInput, error) {
+	var workflow struct {
+		On yaml.Node `yaml:"on"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &workflow); err != nil {
+		return false, nil, err
+	}
+
+	inputs := []workflowDispatchInput{}
+	switch workflow.On.Kind {
+	case yaml.ScalarNode:
+		return workflow.On.Value == "workflow_dispatch", inputs, nil
+	case yaml.SequenceNode:
+		for _, event := range workflow.On.Content {
+			if event.Value == "workflow_dispatch" {
+				return true, inputs, nil
+			}
+		}
+		return false, inputs, nil
+	case yaml.MappingNode:
+	default:
+		return false, inputs, nil
+	}
+
+	dispatch := yamlMappingValue(&workflow.On, "workflow_dispatch")
+	if dispatch == nil {
+		return false, inputs, nil
+	}
+	declared := yamlMappingValue(dispatch, "inputs")
+	if declared == nil || declared.Kind != yaml.MappingNode {
+		return true, inputs, nil
+	}
+	for i := 0; i+1 < len(declared.Content); i += 2 {
+		var spec struct {
+			Description string      `yaml:"description"`
+			Required    bool        `yaml:"required"`
+			Type        string      `yaml:"type"`
+			Default     interface{} `yaml:"default"`
+			Options     []string    `yaml:"options"`
+		}
+		name := declared.Content[i].Value
+		if err := declared.Content[i+1].Decode(&spec); err != nil {
+			return false, nil, fmt.Errorf("input %s: %w", name, err)
+		}
+		if spec.Type == "" {
+			spec.Type = "string"
+		}
+		inputs = append(inputs, workflowDispatchInput{
+			Name:        name,
+			Description: spec.Description,
+			Required:    spec.Required,
+			Type:        spec.Type,
+			Default:     spec.Default,
+			Options:     spec.Options,
+		})
+	}
+	return true, inputs, nil
+}
+
+// yamlMappingValue returns the value of a key of a YAML mapping, or nil if the node isn't a mapping
+// or lacks the key.
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// GetWorkflowDispatchSchema creates a tool to get the inputs a workflow takes when it is dispatched manually.
+func GetWorkflowDispatchSchema(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_workflow_dispatch_schema",
+			mcp.WithDescription(t("TOOL_GET_WORKFLOW_DISPATCH_SCHEMA_DESCRIPTION", "Get the inputs a GitHub Actions workflow takes when it is dispatched manually, with their types, defaults and whether they are required, to dispatch it with the right inputs. dispatch_supported is false when the workflow has no workflow_dispatch trigger")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Required(),
+				mcp.Description("ID of the workflow, or the name of its file in .github/workflows, such as deploy.yml"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or SHA to read the workflow file at, defaults to the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			workflowID, err := requiredParam[string](request, "workflow_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			path := ".github/workflows/" + workflowID
+			if id, err := strconv.ParseInt(workflowID, 10, 64); err == nil {
+				workflow, resp, err := client.Actions.GetWorkflowByID(ctx, owner, repo, id)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("workflow %s not found in %s/%s", workflowID, owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to get workflow: %w", err)
+				}
+				_ = resp.Body.Close()
+				path = workflow.GetPath()
+			}
+
+			var opts *github.RepositoryContentGetOptions
+			if ref != "" {
+				opts = &github.RepositoryContentGetOptions{Ref: ref}
+			}
+			fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("workflow %s not found in %s/%s", path, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get workflow file: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if fileContent == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is a directory, not a workflow file", path)), nil
+			}
+
+			content, err := fileContent.GetContent()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode workflow file: %w", err)
+			}
+			dispatchSupported, inputs, err := parseWorkflowDispatchInputs(content)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to parse %s: %s", path, err)), nil
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"workflow_path":      path,
+				"dispatch_supported": dispatchSupported,
+				"inputs":             inputs,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,842 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+var (
+	// enabledRepositoriesPolicies are the policies for which repositories of an organization can run GitHub Actions.
+	enabledRepositoriesPolicies = []string{"all", "none", "selected"}
+	// allowedActionsPolicies are the policies for which actions and reusable workflows can run.
+	allowedActionsPolicies = []string{"all", "local_only", "selected"}
+)
+
+// allowedActionsConflictMessage explains the 409 GitHub returns for the allowed actions of an organization
+// or repository whose policy doesn't select them, pointing at the tool that changes the policy.
+func allowedActionsConflictMessage(target, permissionsTool string) string {
+	return fmt.Sprintf("the allowed actions of %s only apply when its allowed_actions policy is 'selected'; change it with %s first", target, permissionsTool)
+}
+
+// optionalAllowedActionsPolicy returns the allowed_actions parameter, checking it is a known policy.
+func optionalAllowedActionsPolicy(request mcp.CallToolRequest) (string, error) {
+	allowedActions, err := OptionalParam[string](request, "allowed_actions")
+	if err != nil {
+		return "", err
+	}
+	if allowedActions != "" && !slices.Contains(allowedActionsPolicies, allowedActions) {
+		return "", fmt.Errorf("allowed_actions must be one of %s", strings.Join(allowedActionsPolicies, ", "))
+	}
+	return allowedActions, nil
+}
+
+// GetActionsPermissionsForOrg creates a tool to get the GitHub Actions permissions of an organization.
+func GetActionsPermissionsForOrg(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_actions_permissions_for_org",
+			mcp.WithDescription(t("TOOL_GET_ACTIONS_PERMISSIONS_FOR_ORG_DESCRIPTION", "Get the GitHub Actions permissions of an organization: which repositories can run GitHub Actions and which actions they can use")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			permissions, resp, err := client.Actions.GetActionsPermissions(ctx, org)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get actions permissions: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get actions permissions: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(permissions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetActionsPermissionsForOrg creates a tool to set the GitHub Actions permissions of an organization.
+func SetActionsPermissionsForOrg(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_actions_permissions_for_org",
+			mcp.WithDescription(t("TOOL_SET_ACTIONS_PERMISSIONS_FOR_ORG_DESCRIPTION", "Set which repositories of an organization can run GitHub Actions and which actions they can use. Requires organization owner permissions")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			mcp.WithString("enabled_repos",
+				mcp.Required(),
+				mcp.Description("Repositories that can run GitHub Actions: all of them, none, or the selected ones"),
+				mcp.Enum(enabledRepositoriesPolicies...),
+			),
+			mcp.WithString("allowed_actions",
+				mcp.Description("Actions that can run: all of them, only those in the organization, or the selected ones set with set_allowed_actions_for_org"),
+				mcp.Enum(allowedActionsPolicies...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			enabledRepos, err := requiredParam[string](request, "enabled_repos")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !slices.Contains(enabledRepositoriesPolicies, enabledRepos) {
+				return mcp.NewToolResultError(fmt.Sprintf("enabled_repos must be one of %s", strings.Join(enabledRepositoriesPolicies, ", "))), nil
+			}
+			allowedActions, err := optionalAllowedActionsPolicy(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			permissions := github.ActionsPermissions{EnabledRepositories: github.Ptr(enabledRepos)}
+			if allowedActions != "" {
+				permissions.AllowedActions = github.Ptr(allowedActions)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			_, resp, err := client.Actions.EditActionsPermissions(ctx, org, permissions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to set actions permissions: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to set actions permissions: %s", string(body))), nil
+			}
+
+			// GitHub doesn't return the updated permissions, so return the ones that were set
+			r, err := json.Marshal(permissions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetActionsPermissionsForRepo creates a tool to get the GitHub Actions permissions of a repository.
+func GetActionsPermissionsForRepo(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_github_actions_permissions_for_repo",
+			mcp.WithDescription(t("TOOL_GET_GITHUB_ACTIONS_PERMISSIONS_FOR_REPO_DESCRIPTION", "Get the GitHub Actions permissions of a repository: whether it can run GitHub Actions and which actions it can use")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			permissions, resp, err := client.Repositories.GetActionsPermissions(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get actions permissions: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get actions permissions: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(permissions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetActionsPermissionsForRepo creates a tool to set the GitHub Actions permissions of a repository.
+func SetActionsPermissionsForRepo(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_github_actions_permissions_for_repo",
+			mcp.WithDescription(t("TOOL_SET_GITHUB_ACTIONS_PERMISSIONS_FOR_REPO_DESCRIPTION", "Set whether a repository can run GitHub Actions and which actions it can use. Requires admin permissions on the repository")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("enabled",
+				mcp.Required(),
+				mcp.Description("Whether the repository can run GitHub Actions"),
+			),
+			mcp.WithString("allowed_actions",
+				mcp.Description("Actions that can run: all of them, only those in the repository's owner, or the selected ones set with set_allowed_actions_for_repo"),
+				mcp.Enum(allowedActionsPolicies...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// requiredParam rejects false as a zero value, so check the parameter was given instead
+			enabled, ok, err := OptionalParamOK[bool](request, "enabled")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				return mcp.NewToolResultError("missing required parameter: enabled"), nil
+			}
+			allowedActions, err := optionalAllowedActionsPolicy(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			permissions := github.ActionsPermissionsRepository{Enabled: github.Ptr(enabled)}
+			if allowedActions != "" {
+				permissions.AllowedActions = github.Ptr(allowedActions)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			_, resp, err := client.Repositories.EditActionsPermissions(ctx, owner, repo, permissions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to set actions permissions: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to set actions permissions: %s", string(body))), nil
+			}
+
+			// GitHub doesn't return the updated permissions, so return the ones that were set
+			r, err := json.Marshal(permissions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// allowedActionsOptions returns the parameters of the allowed actions settings the set tools change.
+func allowedActionsOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithBoolean("github_owned_allowed",
+			mcp.Description("Allow actions created by GitHub, such as those in the actions organization"),
+		),
+		mcp.WithBoolean("verified_allowed",
+			mcp.Description("Allow actions from GitHub Marketplace verified creators"),
+		),
+		mcp.WithArray("patterns_allowed",
+			mcp.Description("Actions and reusable workflows to allow, as 'owner/*', 'owner/repo@*' or 'owner/repo@version' patterns"),
+			mcp.Items(map[string]interface{}{"type": "string"}),
+		),
+	}
+}
+
+// allowedActionsParams returns the allowed actions settings given to a set tool, checking the patterns look like
+// actions, and whether any setting was given.
+func allowedActionsParams(request mcp.CallToolRequest) (github.ActionsAllowed, bool, error) {
+	allowed := github.ActionsAllowed{}
+	updateNeeded := false
+	for param, field := range map[string]**bool{
+		"github_owned_allowed": &allowed.GithubOwnedAllowed,
+		"verified_allowed":     &allowed.VerifiedAllowed,
+	} {
+		value, ok, err := OptionalParamOK[bool](request, param)
+		if err != nil {
+			return github.ActionsAllowed{}, false, err
+		}
+		if ok {
+			*field = github.Ptr(value)
+			updateNeeded = true
+		}
+	}
+	if _, ok := request.Params.Arguments["patterns_allowed"]; ok {
+		patterns, err := OptionalStringArrayParam(request, "patterns_allowed")
+		if err != nil {
+			return github.ActionsAllowed{}, false, err
+		}
+		for _, pattern := range patterns {
+			if !strings.Contains(pattern, "/") {
+				return github.ActionsAllowed{}, false, fmt.Errorf("invalid pattern %q: patterns look like 'owner/*' or 'owner/repo@version'", pattern)
+			}
+		}
+		allowed.PatternsAllowed = patterns
+		updateNeeded = true
+	}
+	return allowed, updateNeeded, nil
+}
+
+// GetAllowedActionsForOrg creates a tool to get the actions the repositories of an organization can use.
+func GetAllowedActionsForOrg(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_allowed_actions_for_org",
+			mcp.WithDescription(t("TOOL_GET_ALLOWED_ACTIONS_FOR_ORG_DESCRIPTION", "Get the actions and reusable workflows the repositories of an organization can use when its allowed_actions policy is 'selected'")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			allowed, resp, err := client.Actions.GetActionsAllowed(ctx, org)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusConflict) {
+					return mcp.NewToolResultError(allowedActionsConflictMessage("organization "+org, "set_actions_permissions_for_org")), nil
+				}
+				return nil, fmt.Errorf("failed to get allowed actions: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get allowed actions: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(allowed)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetAllowedActionsForRepo creates a tool to get the actions a repository can use.
+func GetAllowedActionsForRepo(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_allowed_actions_for_repo",
+			mcp.WithDescription(t("TOOL_GET_ALLOWED_ACTIONS_FOR_REPO_DESCRIPTION", "Get the actions and reusable workflows a repository can use when its allowed_actions policy is 'selected'")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			allowed, resp, err := client.Repositories.GetActionsAllowed(ctx, owner, repo)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusConflict) {
+					return mcp.NewToolResultError(allowedActionsConflictMessage("repository "+owner+"/"+repo, "set_github_actions_permissions_for_repo")), nil
+				}
+				return nil, fmt.Errorf("failed to get allowed actions: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get allowed actions: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(allowed)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetAllowedActionsForOrg creates a tool to set the actions the repositories of an organization can use. Only the
+// given settings are sent.
+func SetAllowedActionsForOrg(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_SET_ALLOWED_ACTIONS_FOR_ORG_DESCRIPTION", "Set the actions and reusable workflows the repositories of an organization can use. Only applies when its allowed_actions policy is 'selected'. Requires organization owner permissions")),
+		mcp.WithString("org",
+			mcp.Required(),
+			mcp.Description("Organization name"),
+		),
+	}
+	return mcp.NewTool("set_allowed_actions_for_org", append(options, allowedActionsOptions()...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			allowed, updateNeeded, err := allowedActionsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !updateNeeded {
+				return mcp.NewToolResultError("No update parameters provided."), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			_, resp, err := client.Actions.EditActionsAllowed(ctx, org, allowed)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusConflict) {
+					return mcp.NewToolResultError(allowedActionsConflictMessage("organization "+org, "set_actions_permissions_for_org")), nil
+				}
+				return nil, fmt.Errorf("failed to set allowed actions: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to set allowed actions: %s", string(body))), nil
+			}
+
+			// GitHub doesn't return the updated settings, so return the ones that were set
+			r, err := json.Marshal(allowed)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetAllowedActionsForRepo creates a tool to set the actions a repository can use. Only the given settings are sent.
+func SetAllowedActionsForRepo(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_SET_ALLOWED_ACTIONS_FOR_REPO_DESCRIPTION", "Set the actions and reusable workflows a repository can use. Only applies when its allowed_actions policy is 'selected'. Requires admin permissions on the repository")),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		),
+	}
+	return mcp.NewTool("set_allowed_actions_for_repo", append(options, allowedActionsOptions()...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			allowed, updateNeeded, err := allowedActionsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !updateNeeded {
+				return mcp.NewToolResultError("No update parameters provided."), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			_, resp, err := client.Repositories.EditActionsAllowed(ctx, owner, repo, allowed)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusConflict) {
+					return mcp.NewToolResultError(allowedActionsConflictMessage("repository "+owner+"/"+repo, "set_github_actions_permissions_for_repo")), nil
+				}
+				return nil, fmt.Errorf("failed to set allowed actions: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to set allowed actions: %s", string(body))), nil
+			}
+
+			// GitHub doesn't return the updated settings, so return the ones that were set
+			r, err := json.Marshal(allowed)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// workflowPermissionsLevels are the permissions the GITHUB_TOKEN of a workflow can be given by default.
+var workflowPermissionsLevels = []string{"read", "write"}
+
+// orgRestrictsWorkflowPermissions reports whether the workflow permissions policy of an organization keeps its
+// repositories from giving the GITHUB_TOKEN write permissions or letting it approve pull requests.
+// Repositories owned by users, and organizations whose policy can't be read, aren't restricted as far as is known.
+func orgRestrictsWorkflowPermissions(ctx context.Context, client *github.Client, org string) (bool, error) {
+	permissions, resp, err := client.Actions.GetDefaultWorkflowPermissionsInOrganization(ctx, org)
+	if err != nil {
+		if isGitHubErrorStatus(err, http.StatusNotFound) || isGitHubErrorStatus(err, http.StatusForbidden) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get organization workflow permissions: %w", err)
+	}
+	_ = resp.Body.Close()
+	return permissions.GetDefaultWorkflowPermissions() == "read" || !permissions.GetCanApprovePullRequestReviews(), nil
+}
+
+// getWorkflowPermissionsResult is what the get workflow permissions tools return.
+func getWorkflowPermissionsResult(defaultPermissions string, canApprove bool) map[string]interface{} {
+	return map[string]interface{}{
+		"default_workflow_permissions":     defaultPermissions,
+		"can_approve_pull_request_reviews": canApprove,
+	}
+}
+
+// GetOrgWorkflowPermissions creates a tool to get the default GITHUB_TOKEN permissions of an organization.
+func GetOrgWorkflowPermissions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_workflow_permissions",
+			mcp.WithDescription(t("TOOL_GET_ORG_WORKFLOW_PERMISSIONS_DESCRIPTION", "Get the default permissions of the GITHUB_TOKEN in the workflows of an organization's repositories, and whether it can approve pull requests")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			permissions, resp, err := client.Actions.GetDefaultWorkflowPermissionsInOrganization(ctx, org)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get workflow permissions: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get workflow permissions: %s", string(body))), nil
+			}
+
+			result := getWorkflowPermissionsResult(permissions.GetDefaultWorkflowPermissions(), permissions.GetCanApprovePullRequestReviews())
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetRepoWorkflowPermissions creates a tool to get the default GITHUB_TOKEN permissions of a repository.
+func GetRepoWorkflowPermissions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repo_workflow_permissions",
+			mcp.WithDescription(t("TOOL_GET_REPO_WORKFLOW_PERMISSIONS_DESCRIPTION", "Get the default permissions of the GITHUB_TOKEN in the workflows of a repository, whether it can approve pull requests, and whether the owning organization's policy restricts them")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			permissions, resp, err := client.Repositories.GetDefaultWorkflowPermissions(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get workflow permissions: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get workflow permissions: %s", string(body))), nil
+			}
+
+			result := getWorkflowPermissionsResult(permissions.GetDefaultWorkflowPermissions(), permissions.GetCanApprovePullRequestReviews())
+			// An organization's policy caps what its repositories can set, which explains a setting that didn't take effect
+			restricted, err := orgRestrictsWorkflowPermissions(ctx, client, owner)
+			if err != nil {
+				return nil, err
+			}
+			result["restricted_by_org_policy"] = restricted
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// workflowPermissionsOptions returns the parameters of the workflow permissions the set tools change.
+func workflowPermissionsOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("default_workflow_permissions",
+			mcp.Description("Permissions the GITHUB_TOKEN gets by default: read access to the contents and packages scopes, or read and write access to all scopes"),
+			mcp.Enum(workflowPermissionsLevels...),
+		),
+		mcp.WithBoolean("can_approve_pull_request_reviews",
+			mcp.Description("Whether the GITHUB_TOKEN can approve pull requests"),
+		),
+	}
+}
+
+// workflowPermissionsParams returns the workflow permissions given to a set tool, as the settings to send and
+// return, checking the default permissions are a known level.
+func workflowPermissionsParams(request mcp.CallToolRequest) (defaultPermissions *string, canApprove *bool, err error) {
+	permissions, err := OptionalParam[string](request, "default_workflow_permissions")
+	if err != nil {
+		return nil, nil, err
+	}
+	if permissions != "" {
+		if !slices.Contains(workflowPermissionsLevels, permissions) {
+			return nil, nil, fmt.Errorf("default_workflow_permissions must be one of %s", strings.Join(workflowPermissionsLevels, ", "))
+		}
+		defaultPermissions = github.Ptr(permissions)
+	}
+	approve, ok, err := OptionalParamOK[bool](request, "can_approve_pull_request_reviews")
+	if err != nil {
+		return nil, nil, err
+	}
+	if ok {
+		canApprove = github.Ptr(approve)
+	}
+	return defaultPermissions, canApprove, nil
+}
+
+// setWorkflowPermissionsResult is what the set workflow permissions tools return: the settings that were set, as
+// GitHub doesn't return the updated ones.
+func setWorkflowPermissionsResult(defaultPermissions *string, canApprove *bool) map[string]interface{} {
+	result := map[string]interface{}{}
+	if defaultPermissions != nil {
+		result["default_workflow_permissions"] = *defaultPermissions
+	}
+	if canApprove != nil {
+		result["can_approve_pull_request_reviews"] = *canApprove
+	}
+	return result
+}
+
+// SetOrgWorkflowPermissions creates a tool to set the default GITHUB_TOKEN permissions of an organization. Only the
+// given settings are sent.
+func SetOrgWorkflowPermissions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_SET_ORG_WORKFLOW_PERMISSIONS_DESCRIPTION", "Set the default permissions of the GITHUB_TOKEN in the workflows of an organization's repositories, and whether it can approve pull requests. Requires organization owner permissions")),
+		mcp.WithString("org",
+			mcp.Required(),
+			mcp.Description("Organization name"),
+		),
+	}
+	return mcp.NewTool("set_org_workflow_permissions", append(options, workflowPermissionsOptions()...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			defaultPermissions, canApprove, err := workflowPermissionsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if defaultPermissions == nil && canApprove == nil {
+				return mcp.NewToolResultError("No update parameters provided."), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			permissions := github.DefaultWorkflowPermissionOrganization{
+				DefaultWorkflowPermissions:   defaultPermissions,
+				CanApprovePullRequestReviews: canApprove,
+			}
+			_, resp, err := client.Actions.EditDefaultWorkflowPermissionsInOrganization(ctx, org, permissions)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusConflict) {
+					return mcp.NewToolResultError(fmt.Sprintf("the workflow permissions of organization %s are restricted by a policy of its enterprise", org)), nil
+				}
+				return nil, fmt.Errorf("failed to set workflow permissions: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to set workflow permissions: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(setWorkflowPermissionsResult(defaultPermissions, canApprove))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetRepoWorkflowPermissions creates a tool to set the default GITHUB_TOKEN permissions of a repository. Only the
+// given settings are sent.
+func SetRepoWorkflowPermissions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_SET_REPO_WORKFLOW_PERMISSIONS_DESCRIPTION", "Set the default permissions of the GITHUB_TOKEN in the workflows of a repository, and whether it can approve pull requests. The owning organization's policy can restrict these. Requires admin permissions on the repository")),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		),
+	}
+	return mcp.NewTool("set_repo_workflow_permissions", append(options, workflowPermissionsOptions()...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			defaultPermissions, canApprove, err := workflowPermissionsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if defaultPermissions == nil && canApprove == nil {
+				return mcp.NewToolResultError("No update parameters provided."), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			permissions := github.DefaultWorkflowPermissionRepository{
+				DefaultWorkflowPermissions:   defaultPermissions,
+				CanApprovePullRequestReviews: canApprove,
+			}
+			_, resp, err := client.Repositories.EditDefaultWorkflowPermissions(ctx, owner, repo, permissions)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusConflict) {
+					return mcp.NewToolResultError(fmt.Sprintf("the workflow permissions of repository %s/%s are restricted by a policy of its organization or enterprise", owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to set workflow permissions: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to set workflow permissions: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(setWorkflowPermissionsResult(defaultPermissions, canApprove))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
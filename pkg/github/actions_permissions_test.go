@@ -0,0 +1,1160 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetActionsPermissionsForOrg(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetActionsPermissionsForOrg(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_actions_permissions_for_org", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockPermissions := &github.ActionsPermissions{
+		EnabledRepositories: github.Ptr("all"),
+		AllowedActions:      github.Ptr("selected"),
+		SelectedActionsURL:  github.Ptr("https://api.github.com/orgs/org/actions/permissions/selected-actions"),
+	}
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		requestArgs         map[string]interface{}
+		expectError         bool
+		expectedErrMsg      string
+		expectedPermissions *github.ActionsPermissions
+	}{
+		{
+			name: "organization permissions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsPermissionsByOrg,
+					mockPermissions,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:         false,
+			expectedPermissions: mockPermissions,
+		},
+		{
+			name: "permissions fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsPermissionsByOrg,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get actions permissions",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetActionsPermissionsForOrg(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned github.ActionsPermissions
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.expectedPermissions, returned)
+		})
+	}
+}
+
+func Test_SetActionsPermissionsForOrg(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetActionsPermissionsForOrg(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_actions_permissions_for_org", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "enabled_repos")
+	assert.Contains(t, tool.InputSchema.Properties, "allowed_actions")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "enabled_repos"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "set both policies",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsActionsPermissionsByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"enabled_repositories": "selected",
+						"allowed_actions":      "local_only",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":             "org",
+				"enabled_repos":   "selected",
+				"allowed_actions": "local_only",
+			},
+			expectError:  false,
+			expectedText: `{"enabled_repositories":"selected","allowed_actions":"local_only"}`,
+		},
+		{
+			name: "unknown enabled_repos policy",
+			requestArgs: map[string]interface{}{
+				"org":           "org",
+				"enabled_repos": "some",
+			},
+			expectError:    false,
+			expectedErrMsg: "enabled_repos must be one of all, none, selected",
+		},
+		{
+			name: "unknown allowed_actions policy",
+			requestArgs: map[string]interface{}{
+				"org":             "org",
+				"enabled_repos":   "all",
+				"allowed_actions": "verified",
+			},
+			expectError:    false,
+			expectedErrMsg: "allowed_actions must be one of all, local_only, selected",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetActionsPermissionsForOrg(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_GetActionsPermissionsForRepo(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetActionsPermissionsForRepo(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_github_actions_permissions_for_repo", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockPermissions := &github.ActionsPermissionsRepository{
+		Enabled:        github.Ptr(true),
+		AllowedActions: github.Ptr("all"),
+	}
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		requestArgs         map[string]interface{}
+		expectError         bool
+		expectedErrMsg      string
+		expectedPermissions *github.ActionsPermissionsRepository
+	}{
+		{
+			name: "repository permissions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsPermissionsByOwnerByRepo,
+					mockPermissions,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:         false,
+			expectedPermissions: mockPermissions,
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsPermissionsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get actions permissions",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetActionsPermissionsForRepo(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned github.ActionsPermissionsRepository
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.expectedPermissions, returned)
+		})
+	}
+}
+
+func Test_SetActionsPermissionsForRepo(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetActionsPermissionsForRepo(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_github_actions_permissions_for_repo", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "enabled")
+	assert.Contains(t, tool.InputSchema.Properties, "allowed_actions")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "enabled"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "restrict to selected actions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsPermissionsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"enabled":         true,
+						"allowed_actions": "selected",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"enabled":         true,
+				"allowed_actions": "selected",
+			},
+			expectError:  false,
+			expectedText: `{"enabled":true,"allowed_actions":"selected"}`,
+		},
+		{
+			name: "disable actions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsPermissionsByOwnerByRepo,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"enabled": false,
+			},
+			expectError:  false,
+			expectedText: `{"enabled":false}`,
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsPermissionsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"enabled": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to set actions permissions",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetActionsPermissionsForRepo(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_GetAllowedActionsForOrg(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetAllowedActionsForOrg(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_allowed_actions_for_org", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockAllowed := &github.ActionsAllowed{
+		GithubOwnedAllowed: github.Ptr(true),
+		VerifiedAllowed:    github.Ptr(false),
+		PatternsAllowed:    []string{"monalisa/octocat@*", "docker/*"},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedAllowed *github.ActionsAllowed
+	}{
+		{
+			name: "selected actions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsPermissionsSelectedActionsByOrg,
+					mockAllowed,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:     false,
+			expectedAllowed: mockAllowed,
+		},
+		{
+			name: "policy doesn't select actions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsPermissionsSelectedActionsByOrg,
+					mockResponse(t, http.StatusConflict, map[string]string{"message": "Conflict"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    false,
+			expectedErrMsg: "the allowed actions of organization org only apply when its allowed_actions policy is 'selected'; change it with set_actions_permissions_for_org first",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetAllowedActionsForOrg(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned github.ActionsAllowed
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.expectedAllowed, returned)
+		})
+	}
+}
+
+func Test_GetAllowedActionsForRepo(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetAllowedActionsForRepo(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_allowed_actions_for_repo", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockAllowed := &github.ActionsAllowed{
+		GithubOwnedAllowed: github.Ptr(true),
+		VerifiedAllowed:    github.Ptr(true),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedAllowed *github.ActionsAllowed
+	}{
+		{
+			name: "selected actions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsPermissionsSelectedActionsByOwnerByRepo,
+					mockAllowed,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:     false,
+			expectedAllowed: mockAllowed,
+		},
+		{
+			name: "policy doesn't select actions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsPermissionsSelectedActionsByOwnerByRepo,
+					mockResponse(t, http.StatusConflict, map[string]string{"message": "Conflict"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedErrMsg: "the allowed actions of repository owner/repo only apply when its allowed_actions policy is 'selected'; change it with set_github_actions_permissions_for_repo first",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetAllowedActionsForRepo(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned github.ActionsAllowed
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.expectedAllowed, returned)
+		})
+	}
+}
+
+func Test_SetAllowedActionsForOrg(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetAllowedActionsForOrg(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_allowed_actions_for_org", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "github_owned_allowed")
+	assert.Contains(t, tool.InputSchema.Properties, "verified_allowed")
+	assert.Contains(t, tool.InputSchema.Properties, "patterns_allowed")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "set every setting",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsActionsPermissionsSelectedActionsByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"github_owned_allowed": true,
+						"verified_allowed":     false,
+						"patterns_allowed":     []interface{}{"docker/*", "monalisa/octocat@v2"},
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                  "org",
+				"github_owned_allowed": true,
+				"verified_allowed":     false,
+				"patterns_allowed":     []interface{}{"docker/*", "monalisa/octocat@v2"},
+			},
+			expectError:  false,
+			expectedText: `{"github_owned_allowed":true,"verified_allowed":false,"patterns_allowed":["docker/*","monalisa/octocat@v2"]}`,
+		},
+		{
+			name: "invalid pattern",
+			requestArgs: map[string]interface{}{
+				"org":              "org",
+				"patterns_allowed": []interface{}{"checkout"},
+			},
+			expectError:    false,
+			expectedErrMsg: `invalid pattern "checkout"`,
+		},
+		{
+			name: "no settings",
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    false,
+			expectedErrMsg: "No update parameters provided.",
+		},
+		{
+			name: "policy doesn't select actions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsActionsPermissionsSelectedActionsByOrg,
+					mockResponse(t, http.StatusConflict, map[string]string{"message": "Conflict"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":              "org",
+				"verified_allowed": true,
+			},
+			expectError:    false,
+			expectedErrMsg: "change it with set_actions_permissions_for_org first",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetAllowedActionsForOrg(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_SetAllowedActionsForRepo(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetAllowedActionsForRepo(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_allowed_actions_for_repo", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "patterns_allowed")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "allow patterns only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsPermissionsSelectedActionsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"patterns_allowed": []interface{}{"org/*"},
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"patterns_allowed": []interface{}{"org/*"},
+			},
+			expectError:  false,
+			expectedText: `{"patterns_allowed":["org/*"]}`,
+		},
+		{
+			name: "policy doesn't select actions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsPermissionsSelectedActionsByOwnerByRepo,
+					mockResponse(t, http.StatusConflict, map[string]string{"message": "Conflict"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                "owner",
+				"repo":                 "repo",
+				"github_owned_allowed": true,
+			},
+			expectError:    false,
+			expectedErrMsg: "change it with set_github_actions_permissions_for_repo first",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetAllowedActionsForRepo(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_GetOrgWorkflowPermissions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgWorkflowPermissions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_org_workflow_permissions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "successful get",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsPermissionsWorkflowByOrg,
+					&github.DefaultWorkflowPermissionOrganization{
+						DefaultWorkflowPermissions:   github.Ptr("read"),
+						CanApprovePullRequestReviews: github.Ptr(false),
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:  false,
+			expectedText: `{"default_workflow_permissions":"read","can_approve_pull_request_reviews":false}`,
+		},
+		{
+			name: "get fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsPermissionsWorkflowByOrg,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must be an organization owner"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow permissions",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOrgWorkflowPermissions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_GetRepoWorkflowPermissions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepoWorkflowPermissions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_repo_workflow_permissions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	repoPermissions := &github.DefaultWorkflowPermissionRepository{
+		DefaultWorkflowPermissions:   github.Ptr("write"),
+		CanApprovePullRequestReviews: github.Ptr(true),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "organization policy restricts the repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsPermissionsWorkflowByOwnerByRepo,
+					repoPermissions,
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsPermissionsWorkflowByOrg,
+					&github.DefaultWorkflowPermissionOrganization{
+						DefaultWorkflowPermissions:   github.Ptr("read"),
+						CanApprovePullRequestReviews: github.Ptr(true),
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "org",
+				"repo":  "repo",
+			},
+			expectError:  false,
+			expectedText: `{"default_workflow_permissions":"write","can_approve_pull_request_reviews":true,"restricted_by_org_policy":true}`,
+		},
+		{
+			name: "permissive organization policy",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsPermissionsWorkflowByOwnerByRepo,
+					repoPermissions,
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsPermissionsWorkflowByOrg,
+					&github.DefaultWorkflowPermissionOrganization{
+						DefaultWorkflowPermissions:   github.Ptr("write"),
+						CanApprovePullRequestReviews: github.Ptr(true),
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "org",
+				"repo":  "repo",
+			},
+			expectError:  false,
+			expectedText: `{"default_workflow_permissions":"write","can_approve_pull_request_reviews":true,"restricted_by_org_policy":false}`,
+		},
+		{
+			name: "repository owned by a user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsPermissionsWorkflowByOwnerByRepo,
+					repoPermissions,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsPermissionsWorkflowByOrg,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octocat",
+				"repo":  "repo",
+			},
+			expectError:  false,
+			expectedText: `{"default_workflow_permissions":"write","can_approve_pull_request_reviews":true,"restricted_by_org_policy":false}`,
+		},
+		{
+			name: "get fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsPermissionsWorkflowByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow permissions",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepoWorkflowPermissions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_SetOrgWorkflowPermissions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetOrgWorkflowPermissions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_org_workflow_permissions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "default_workflow_permissions")
+	assert.Contains(t, tool.InputSchema.Properties, "can_approve_pull_request_reviews")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "set both settings",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsActionsPermissionsWorkflowByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"default_workflow_permissions":     "read",
+						"can_approve_pull_request_reviews": false,
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                              "org",
+				"default_workflow_permissions":     "read",
+				"can_approve_pull_request_reviews": false,
+			},
+			expectError:  false,
+			expectedText: `{"default_workflow_permissions":"read","can_approve_pull_request_reviews":false}`,
+		},
+		{
+			name:         "invalid permissions level",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":                          "org",
+				"default_workflow_permissions": "admin",
+			},
+			expectError:    false,
+			expectedErrMsg: "default_workflow_permissions must be one of read, write",
+		},
+		{
+			name:         "no settings given",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    false,
+			expectedErrMsg: "No update parameters provided.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetOrgWorkflowPermissions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_SetRepoWorkflowPermissions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetRepoWorkflowPermissions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_repo_workflow_permissions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "default_workflow_permissions")
+	assert.Contains(t, tool.InputSchema.Properties, "can_approve_pull_request_reviews")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "set default permissions only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsPermissionsWorkflowByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"default_workflow_permissions": "write",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                        "owner",
+				"repo":                         "repo",
+				"default_workflow_permissions": "write",
+			},
+			expectError:  false,
+			expectedText: `{"default_workflow_permissions":"write"}`,
+		},
+		{
+			name: "restricted by organization policy",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsPermissionsWorkflowByOwnerByRepo,
+					mockResponse(t, http.StatusConflict, map[string]string{"message": "Conflict"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                            "org",
+				"repo":                             "repo",
+				"can_approve_pull_request_reviews": true,
+			},
+			expectError:    false,
+			expectedErrMsg: "the workflow permissions of repository org/repo are restricted by a policy of its organization or enterprise",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetRepoWorkflowPermissions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
@@ -0,0 +1,2658 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetWorkflowJobSteps(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWorkflowJobSteps(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_workflow_job_steps", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "job_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "job_id"})
+
+	startedAt := &github.Timestamp{Time: time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)}
+	completedAt := &github.Timestamp{Time: time.Date(2025, 4, 1, 12, 5, 0, 0, time.UTC)}
+	mockJob := &github.WorkflowJob{
+		ID:              github.Ptr(int64(399444496)),
+		RunID:           github.Ptr(int64(29679449)),
+		HTMLURL:         github.Ptr("https://github.com/owner/repo/actions/runs/29679449/job/399444496"),
+		RunnerName:      github.Ptr("ubuntu-runner-1"),
+		RunnerGroupName: github.Ptr("GitHub Actions"),
+		WorkflowName:    github.Ptr("CI"),
+		Steps: []*github.TaskStep{
+			{
+				Name:        github.Ptr("Checkout"),
+				Status:      github.Ptr("completed"),
+				Conclusion:  github.Ptr("success"),
+				Number:      github.Ptr(int64(1)),
+				StartedAt:   startedAt,
+				CompletedAt: completedAt,
+			},
+			{
+				Name:        github.Ptr("Run tests"),
+				Status:      github.Ptr("completed"),
+				Conclusion:  github.Ptr("failure"),
+				Number:      github.Ptr(int64(2)),
+				StartedAt:   startedAt,
+				CompletedAt: completedAt,
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful job steps retrieval",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsJobsByOwnerByRepoByJobId,
+					mockJob,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"job_id": float64(399444496),
+			},
+			expectError: false,
+		},
+		{
+			name: "job not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsByOwnerByRepoByJobId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"job_id": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow job",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetWorkflowJobSteps(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returned struct {
+				RunID           int64  `json:"run_id"`
+				RunnerName      string `json:"runner_name"`
+				RunnerGroupName string `json:"runner_group_name"`
+				WorkflowName    string `json:"workflow_name"`
+				HTMLURL         string `json:"html_url"`
+				Steps           []struct {
+					Name        string    `json:"name"`
+					Status      string    `json:"status"`
+					Conclusion  string    `json:"conclusion"`
+					Number      int64     `json:"number"`
+					StartedAt   time.Time `json:"started_at"`
+					CompletedAt time.Time `json:"completed_at"`
+				} `json:"steps"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, mockJob.GetRunID(), returned.RunID)
+			assert.Equal(t, mockJob.GetRunnerName(), returned.RunnerName)
+			assert.Equal(t, mockJob.GetRunnerGroupName(), returned.RunnerGroupName)
+			assert.Equal(t, mockJob.GetWorkflowName(), returned.WorkflowName)
+			assert.Equal(t, mockJob.GetHTMLURL(), returned.HTMLURL)
+			require.Len(t, returned.Steps, 2)
+			assert.Equal(t, "Run tests", returned.Steps[1].Name)
+			assert.Equal(t, "completed", returned.Steps[1].Status)
+			assert.Equal(t, "failure", returned.Steps[1].Conclusion)
+			assert.Equal(t, int64(2), returned.Steps[1].Number)
+			assert.True(t, startedAt.Time.Equal(returned.Steps[1].StartedAt))
+			assert.True(t, completedAt.Time.Equal(returned.Steps[1].CompletedAt))
+		})
+	}
+}
+
+func Test_ListWorkflowRunJobs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWorkflowRunJobs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_workflow_run_jobs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.Contains(t, tool.InputSchema.Properties, "filter")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	mockJobs := &github.Jobs{
+		TotalCount: github.Ptr(2),
+		Jobs: []*github.WorkflowJob{
+			{
+				ID:         github.Ptr(int64(1)),
+				Name:       github.Ptr("build"),
+				Status:     github.Ptr("completed"),
+				Conclusion: github.Ptr("success"),
+				Steps: []*github.TaskStep{
+					{Name: github.Ptr("Checkout"), Conclusion: github.Ptr("success")},
+				},
+			},
+			{
+				ID:         github.Ptr(int64(2)),
+				Name:       github.Ptr("test"),
+				Status:     github.Ptr("completed"),
+				Conclusion: github.Ptr("failure"),
+				Steps: []*github.TaskStep{
+					{Name: github.Ptr("Checkout"), Conclusion: github.Ptr("success")},
+					{Name: github.Ptr("Run tests"), Conclusion: github.Ptr("failure")},
+					{Name: github.Ptr("Upload results"), Conclusion: github.Ptr("skipped")},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful jobs listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					expectQueryParams(t, map[string]string{
+						"filter":   "all",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockJobs),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(29679449),
+				"filter": "all",
+			},
+			expectError: false,
+		},
+		{
+			name: "run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list workflow run jobs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWorkflowRunJobs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returned struct {
+				TotalCount int `json:"total_count"`
+				Jobs       []struct {
+					Name       string `json:"name"`
+					Status     string `json:"status"`
+					Conclusion string `json:"conclusion"`
+					StepsCount int    `json:"steps_count"`
+					FailedStep string `json:"failed_step"`
+				} `json:"jobs"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, 2, returned.TotalCount)
+			require.Len(t, returned.Jobs, 2)
+			assert.Equal(t, "build", returned.Jobs[0].Name)
+			assert.Equal(t, 1, returned.Jobs[0].StepsCount)
+			assert.Empty(t, returned.Jobs[0].FailedStep)
+			assert.Equal(t, "test", returned.Jobs[1].Name)
+			assert.Equal(t, "failure", returned.Jobs[1].Conclusion)
+			assert.Equal(t, 3, returned.Jobs[1].StepsCount)
+			assert.Equal(t, "Run tests", returned.Jobs[1].FailedStep)
+		})
+	}
+}
+
+func Test_ListRecentFailures(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRecentFailures(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_recent_failures", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "count")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRuns := &github.WorkflowRuns{
+		TotalCount: github.Ptr(37),
+		WorkflowRuns: []*github.WorkflowRun{
+			{
+				ID:         github.Ptr(int64(101)),
+				Name:       github.Ptr("CI"),
+				HeadBranch: github.Ptr("main"),
+				HeadSHA:    github.Ptr("abc123"),
+				HTMLURL:    github.Ptr("https://github.com/owner/repo/actions/runs/101"),
+			},
+			{
+				ID:         github.Ptr(int64(102)),
+				Name:       github.Ptr("Release"),
+				HeadBranch: github.Ptr("v2"),
+				HeadSHA:    github.Ptr("def456"),
+				HTMLURL:    github.Ptr("https://github.com/owner/repo/actions/runs/102"),
+			},
+		},
+	}
+
+	// Run 101 fails in its second job, run 102 fails before any job ran
+	runJobs := map[string]*github.Jobs{
+		"101": {
+			TotalCount: github.Ptr(3),
+			Jobs: []*github.WorkflowJob{
+				{
+					Name:       github.Ptr("lint"),
+					Conclusion: github.Ptr("success"),
+					Steps:      []*github.TaskStep{{Name: github.Ptr("Run linter"), Conclusion: github.Ptr("success")}},
+				},
+				{
+					Name:       github.Ptr("test"),
+					Conclusion: github.Ptr("failure"),
+					HTMLURL:    github.Ptr("https://github.com/owner/repo/actions/runs/101/job/2"),
+					Steps: []*github.TaskStep{
+						{Name: github.Ptr("Set up job"), Conclusion: github.Ptr("success")},
+						{Name: github.Ptr("Run tests"), Conclusion: github.Ptr("failure")},
+						{Name: github.Ptr("Upload coverage"), Conclusion: github.Ptr("skipped")},
+					},
+				},
+				{
+					Name:       github.Ptr("build"),
+					Conclusion: github.Ptr("failure"),
+					Steps:      []*github.TaskStep{{Name: github.Ptr("Compile"), Conclusion: github.Ptr("failure")}},
+				},
+			},
+		},
+		"102": {
+			TotalCount: github.Ptr(0),
+			Jobs:       []*github.WorkflowJob{},
+		},
+	}
+	serveRunJobs := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		jobs, ok := runJobs[parts[len(parts)-2]]
+		if !ok {
+			mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+			return
+		}
+		assert.Equal(t, "latest", r.URL.Query().Get("filter"))
+		mockResponse(t, http.StatusOK, jobs)(w, r)
+	})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedFailures []workflowRunFailure
+	}{
+		{
+			name: "resolves the first failing job and step",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"status":   "failure",
+						"per_page": "2",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRuns),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					serveRunJobs,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"count": float64(2),
+			},
+			expectError: false,
+			expectedFailures: []workflowRunFailure{
+				{
+					RunID:       101,
+					RunURL:      "https://github.com/owner/repo/actions/runs/101",
+					Workflow:    "CI",
+					Branch:      "main",
+					HeadSHA:     "abc123",
+					FailingJob:  "test",
+					FailingStep: "Run tests",
+					JobURL:      "https://github.com/owner/repo/actions/runs/101/job/2",
+				},
+				{
+					RunID:    102,
+					RunURL:   "https://github.com/owner/repo/actions/runs/102",
+					Workflow: "Release",
+					Branch:   "v2",
+					HeadSHA:  "def456",
+				},
+			},
+		},
+		{
+			name:         "count out of range",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"count": float64(500),
+			},
+			expectError:    false,
+			expectedErrMsg: "count must be between 1 and 50",
+		},
+		{
+			name: "job lookup fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsByOwnerByRepo,
+					&github.WorkflowRuns{
+						TotalCount:   github.Ptr(1),
+						WorkflowRuns: []*github.WorkflowRun{{ID: github.Ptr(int64(999))}},
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					serveRunJobs,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list jobs of workflow run 999",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRecentFailures(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Failures   []workflowRunFailure `json:"failures"`
+				TotalCount int                  `json:"total_count"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, 37, response.TotalCount)
+			assert.Equal(t, tc.expectedFailures, response.Failures)
+		})
+	}
+}
+
+func Test_ListOrgRedRepos(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgRedRepos(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_red_repos", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "max_repos")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	orgRepo := func(name string, archived bool) *github.Repository {
+		return &github.Repository{
+			Name:          github.Ptr(name),
+			FullName:      github.Ptr("org/" + name),
+			Owner:         &github.User{Login: github.Ptr("org")},
+			DefaultBranch: github.Ptr("main"),
+			Archived:      github.Ptr(archived),
+			HTMLURL:       github.Ptr("https://github.com/org/" + name),
+		}
+	}
+	mockRepos := []*github.Repository{
+		orgRepo("api", false),
+		orgRepo("web", false),
+		orgRepo("docs", false),
+		orgRepo("legacy", true),
+		orgRepo("empty", false),
+	}
+	statuses := map[string]*github.CombinedStatus{
+		"/repos/org/api/commits/main/status": {
+			SHA: github.Ptr("aaa111"),
+			Statuses: []*github.RepoStatus{
+				{Context: github.Ptr("ci/lint"), State: github.Ptr("success")},
+				{Context: github.Ptr("ci/build"), State: github.Ptr("failure")},
+			},
+		},
+		"/repos/org/web/commits/main/status": {
+			SHA: github.Ptr("bbb222"),
+			Statuses: []*github.RepoStatus{
+				{Context: github.Ptr("ci/build"), State: github.Ptr("success")},
+			},
+		},
+		"/repos/org/docs/commits/main/status": {
+			SHA: github.Ptr("ccc333"),
+		},
+	}
+	checkRuns := map[string][]*github.CheckRun{
+		"/repos/org/api/commits/main/check-runs": {
+			{Name: github.Ptr("test"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+		},
+		"/repos/org/web/commits/main/check-runs": {
+			{Name: github.Ptr("e2e"), Status: github.Ptr("in_progress")},
+		},
+		"/repos/org/docs/commits/main/check-runs": {
+			{Name: github.Ptr("test"), Status: github.Ptr("completed"), Conclusion: github.Ptr("timed_out")},
+			{Name: github.Ptr("lint"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure")},
+		},
+	}
+	mockStatusesAndChecks := []mock.MockBackendOption{
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsStatusByOwnerByRepoByRef,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status, ok := statuses[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusConflict)
+					_, _ = w.Write([]byte(`{"message": "Git Repository is empty."}`))
+					return
+				}
+				mockResponse(t, http.StatusOK, status)(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				runs := checkRuns[r.URL.Path]
+				mockResponse(t, http.StatusOK, &github.ListCheckRunsResults{Total: github.Ptr(len(runs)), CheckRuns: runs})(w, r)
+			}),
+		),
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedRedRepos  []redRepo
+		expectedScanned   int
+		expectedTruncated bool
+	}{
+		{
+			name: "red repos among green ones",
+			mockedClient: mock.NewMockedHTTPClient(append(mockStatusesAndChecks,
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					expectQueryParams(t, map[string]string{
+						"sort":      "pushed",
+						"direction": "desc",
+						"per_page":  "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRepos),
+					),
+				),
+			)...),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError: false,
+			expectedRedRepos: []redRepo{
+				{
+					Repository:    "org/api",
+					DefaultBranch: "main",
+					HeadSHA:       "aaa111",
+					FailingChecks: []string{"ci/build"},
+					HTMLURL:       "https://github.com/org/api",
+				},
+				{
+					Repository:    "org/docs",
+					DefaultBranch: "main",
+					HeadSHA:       "ccc333",
+					FailingChecks: []string{"lint", "test"},
+					HTMLURL:       "https://github.com/org/docs",
+				},
+			},
+			expectedScanned: 4,
+		},
+		{
+			name: "more repos than inspected",
+			mockedClient: mock.NewMockedHTTPClient(append(mockStatusesAndChecks,
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					expectQueryParams(t, map[string]string{
+						"sort":      "pushed",
+						"direction": "desc",
+						"per_page":  "1",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/orgs/org/repos?page=2>; rel="next"`)
+							mockResponse(t, http.StatusOK, mockRepos[1:2])(w, r)
+						}),
+					),
+				),
+			)...),
+			requestArgs: map[string]interface{}{
+				"org":       "org",
+				"max_repos": float64(1),
+			},
+			expectError:       false,
+			expectedRedRepos:  []redRepo{},
+			expectedScanned:   1,
+			expectedTruncated: true,
+		},
+		{
+			name:         "max_repos out of range",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":       "org",
+				"max_repos": float64(101),
+			},
+			expectError:    false,
+			expectedErrMsg: "max_repos must be between 1 and 100",
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "organization missing not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgRedRepos(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				RedRepos     []redRepo `json:"red_repos"`
+				ReposScanned int       `json:"repos_scanned"`
+				Truncated    bool      `json:"truncated"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRedRepos, response.RedRepos)
+			assert.Equal(t, tc.expectedScanned, response.ReposScanned)
+			assert.Equal(t, tc.expectedTruncated, response.Truncated)
+		})
+	}
+}
+
+func Test_GetJobLog(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetJobLog(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_job_log", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "job_id")
+	assert.Contains(t, tool.InputSchema.Properties, "tail_lines")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "job_id"})
+
+	shortLog := "2025-04-01T12:00:00Z \x1b[36;1mRun go test ./...\x1b[0m\n2025-04-01T12:00:05Z \x1b[31mFAIL\x1b[0m pkg/github\n"
+	var longLog strings.Builder
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&longLog, "line %04d %s\n", i, strings.Repeat("x", 40))
+	}
+
+	// The blob storage the log URL redirects to
+	blobServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/short":
+			_, _ = w.Write([]byte(shortLog))
+		case "/long":
+			_, _ = w.Write([]byte(longLog.String()))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("AuthenticationFailed"))
+		}
+	}))
+	defer blobServer.Close()
+
+	redirectTo := func(path string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Location", blobServer.URL+path)
+			w.WriteHeader(http.StatusFound)
+		}
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedLog       string
+		expectedTruncated bool
+		expectedTotal     int
+		expectedLines     int
+	}{
+		{
+			name: "short log with ANSI codes stripped",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					redirectTo("/short"),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"job_id": float64(42),
+			},
+			expectError:       false,
+			expectedLog:       "2025-04-01T12:00:00Z Run go test ./...\n2025-04-01T12:00:05Z FAIL pkg/github",
+			expectedTruncated: false,
+			expectedTotal:     len(shortLog),
+		},
+		{
+			name: "long log is cut to the requested tail",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					redirectTo("/long"),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"job_id":     float64(42),
+				"tail_lines": float64(10),
+			},
+			expectError:       false,
+			expectedTruncated: true,
+			expectedTotal:     longLog.Len(),
+			expectedLines:     10,
+		},
+		{
+			name: "long log is capped at 50 KB",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					redirectTo("/long"),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"job_id":     float64(42),
+				"tail_lines": float64(5000),
+			},
+			expectError:       false,
+			expectedTruncated: true,
+			expectedTotal:     longLog.Len(),
+		},
+		{
+			name: "log not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"job_id": float64(42),
+			},
+			expectError:    false,
+			expectedErrMsg: "no log found for job 42",
+		},
+		{
+			name: "log download fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					redirectTo("/expired"),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"job_id": float64(42),
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to download job log: AuthenticationFailed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetJobLog(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returned struct {
+				Log        string `json:"log"`
+				Truncated  bool   `json:"truncated"`
+				TotalBytes int    `json:"total_bytes"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTruncated, returned.Truncated)
+			assert.Equal(t, tc.expectedTotal, returned.TotalBytes)
+			assert.LessOrEqual(t, len(returned.Log), maxJobLogBytes)
+			if tc.expectedLog != "" {
+				assert.Equal(t, tc.expectedLog, returned.Log)
+			}
+			if tc.expectedLines != 0 {
+				assert.Len(t, strings.Split(returned.Log, "\n"), tc.expectedLines)
+			}
+			if tc.expectedTruncated {
+				// The tail of the log is kept
+				assert.True(t, strings.HasSuffix(returned.Log, "line 1999 "+strings.Repeat("x", 40)))
+				assert.True(t, strings.HasPrefix(returned.Log, "line "))
+			}
+		})
+	}
+}
+
+func Test_tailWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		writes   []string
+		expected string
+	}{
+		{name: "under the limit", writes: []string{"a\n", "b\n"}, expected: "a\nb\n"},
+		{name: "small writes past the limit", writes: []string{"one\n", "two\n", "six\n"}, expected: "six\n"},
+		{name: "write larger than the limit", writes: []string{"one\ntwo\nsix\n"}, expected: "six\n"},
+		{name: "no line break kept", writes: []string{"abcdefghijklmnop"}, expected: "ijklmnop"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := &tailWriter{limit: 8}
+			total := 0
+			for _, write := range tc.writes {
+				n, err := w.Write([]byte(write))
+				require.NoError(t, err)
+				assert.Equal(t, len(write), n)
+				total += n
+			}
+			assert.Equal(t, tc.expected, w.tail())
+			assert.Equal(t, total, w.total)
+			assert.LessOrEqual(t, len(w.buf), w.limit)
+		})
+	}
+}
+
+func Test_ListPendingDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPendingDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_pending_deployments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	mockPending := []map[string]interface{}{
+		{
+			"environment": map[string]interface{}{
+				"id":       161088068,
+				"name":     "production",
+				"html_url": "https://github.com/owner/repo/deployments/activity_log?environments_filter=production",
+			},
+			"wait_timer":               30,
+			"wait_timer_started_at":    "2025-04-01T12:00:00Z",
+			"current_user_can_approve": true,
+			"reviewers": []map[string]interface{}{
+				{"type": "User", "reviewer": map[string]interface{}{"login": "octocat", "id": 1}},
+				{"type": "Team", "reviewer": map[string]interface{}{"slug": "release-managers", "name": "Release Managers", "id": 2}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		requestArgs         map[string]interface{}
+		expectError         bool
+		expectedErrMsg      string
+		expectedDeployments []map[string]interface{}
+	}{
+		{
+			name: "run waiting on one environment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					mockPending,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(29679449),
+			},
+			expectError: false,
+			expectedDeployments: []map[string]interface{}{
+				{
+					"environment":              "production",
+					"environment_id":           float64(161088068),
+					"html_url":                 "https://github.com/owner/repo/deployments/activity_log?environments_filter=production",
+					"wait_timer":               float64(30),
+					"wait_timer_started_at":    "2025-04-01T12:00:00Z",
+					"current_user_can_approve": true,
+					"reviewers": []interface{}{
+						map[string]interface{}{"type": "user", "login": "octocat"},
+						map[string]interface{}{"type": "team", "slug": "release-managers", "name": "Release Managers"},
+					},
+				},
+			},
+		},
+		{
+			name: "run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get pending deployments",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPendingDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var deployments []map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &deployments)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDeployments, deployments)
+		})
+	}
+}
+
+func Test_ListEnvironmentSecrets(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListEnvironmentSecrets(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_environment_secrets", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "environment")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment"})
+
+	mockListing := `{
+  "total_count": 2,
+  "secrets": [
+    {"name": "DEPLOY_KEY", "created_at": "2025-01-10T10:00:00Z", "updated_at": "2025-03-01T09:30:00Z"},
+    {"name": "SLACK_WEBHOOK", "created_at": "2025-02-02T08:00:00Z", "updated_at": "2025-02-02T08:00:00Z"}
+  ]
+}`
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedSecrets []map[string]interface{}
+	}{
+		{
+			name: "environment secrets",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsSecretsByOwnerByRepoByEnvironmentName,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/environments/production/secrets", r.URL.Path)
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(mockListing))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+			},
+			expectError: false,
+			expectedSecrets: []map[string]interface{}{
+				{"name": "DEPLOY_KEY", "updated_at": "2025-03-01T09:30:00Z"},
+				{"name": "SLACK_WEBHOOK", "updated_at": "2025-02-02T08:00:00Z"},
+			},
+		},
+		{
+			name: "environment without secrets",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposEnvironmentsSecretsByOwnerByRepoByEnvironmentName,
+					github.Secrets{TotalCount: 0, Secrets: []*github.Secret{}},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "staging",
+			},
+			expectError:     false,
+			expectedSecrets: []map[string]interface{}{},
+		},
+		{
+			name: "environment not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsSecretsByOwnerByRepoByEnvironmentName,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "environment missing not found in owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListEnvironmentSecrets(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var secrets []map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &secrets)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSecrets, secrets)
+		})
+	}
+}
+
+func Test_GetCurrentDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCurrentDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_current_deployments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	deployedAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	mockEnvironments := &github.EnvResponse{
+		TotalCount: github.Ptr(3),
+		Environments: []*github.Environment{
+			{Name: github.Ptr("production")},
+			{Name: github.Ptr("staging")},
+			{Name: github.Ptr("preview")},
+		},
+	}
+	mockDeployments := map[string][]*github.Deployment{
+		"production": {{ID: github.Ptr(int64(11)), Ref: github.Ptr("v1.2.0"), SHA: github.Ptr("abc123"), Creator: &github.User{Login: github.Ptr("octocat")}, CreatedAt: &github.Timestamp{Time: deployedAt}}},
+		"staging":    {{ID: github.Ptr(int64(12)), Ref: github.Ptr("main"), SHA: github.Ptr("def456"), Creator: &github.User{Login: github.Ptr("hubot")}, CreatedAt: &github.Timestamp{Time: deployedAt.Add(time.Hour)}}},
+	}
+	mockStatuses := map[string][]*github.DeploymentStatus{
+		"11": {{State: github.Ptr("success"), UpdatedAt: &github.Timestamp{Time: deployedAt.Add(5 * time.Minute)}}},
+		"12": {{State: github.Ptr("in_progress"), UpdatedAt: &github.Timestamp{Time: deployedAt.Add(time.Hour + time.Minute)}}},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       map[string]interface{}
+	}{
+		{
+			name: "latest deployment of each environment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposEnvironmentsByOwnerByRepo,
+					mockEnvironments,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposDeploymentsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "1", r.URL.Query().Get("per_page"))
+						deployments := mockDeployments[r.URL.Query().Get("environment")]
+						if deployments == nil {
+							deployments = []*github.Deployment{}
+						}
+						mockResponse(t, http.StatusOK, deployments)(w, r)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposDeploymentsStatusesByOwnerByRepoByDeploymentId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/deployments/"), "/statuses")
+						mockResponse(t, http.StatusOK, mockStatuses[id])(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expected: map[string]interface{}{
+				"environments": []interface{}{
+					map[string]interface{}{
+						"environment": "production",
+						"deployment": map[string]interface{}{
+							"id":                float64(11),
+							"ref":               "v1.2.0",
+							"sha":               "abc123",
+							"creator":           "octocat",
+							"created_at":        "2025-04-01T12:00:00Z",
+							"status":            "success",
+							"status_updated_at": "2025-04-01T12:05:00Z",
+						},
+					},
+					map[string]interface{}{
+						"environment": "staging",
+						"deployment": map[string]interface{}{
+							"id":                float64(12),
+							"ref":               "main",
+							"sha":               "def456",
+							"creator":           "hubot",
+							"created_at":        "2025-04-01T13:00:00Z",
+							"status":            "in_progress",
+							"status_updated_at": "2025-04-01T13:01:00Z",
+						},
+					},
+					map[string]interface{}{
+						"environment": "preview",
+						"deployment":  nil,
+					},
+				},
+				"capped": false,
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "repository owner/missing not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCurrentDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_ReviewPendingDeployment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReviewPendingDeployment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "review_pending_deployment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.Contains(t, tool.InputSchema.Properties, "environment_ids")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "comment")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id", "environment_ids", "state", "comment"})
+
+	mockDeployments := []*github.Deployment{
+		{
+			ID:          github.Ptr(int64(42)),
+			Environment: github.Ptr("production"),
+			Ref:         github.Ptr("main"),
+			SHA:         github.Ptr("abc123"),
+			CreatedAt:   &github.Timestamp{Time: time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		requestArgs         map[string]interface{}
+		expectError         bool
+		expectedErrMsg      string
+		expectedDeployments []map[string]interface{}
+	}{
+		{
+			name: "approves a pending deployment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					expectRequestBody(t, map[string]interface{}{
+						"environment_ids": []interface{}{float64(161088068)},
+						"state":           "approved",
+						"comment":         "Ship it",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockDeployments),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(29679449),
+				"environment_ids": []interface{}{float64(161088068)},
+				"state":           "approved",
+				"comment":         "Ship it",
+			},
+			expectError: false,
+			expectedDeployments: []map[string]interface{}{
+				{
+					"id":          float64(42),
+					"environment": "production",
+					"ref":         "main",
+					"sha":         "abc123",
+					"created_at":  "2025-04-01T12:00:00Z",
+				},
+			},
+		},
+		{
+			name: "caller is not a required reviewer",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(29679449),
+				"environment_ids": []interface{}{float64(161088068)},
+				"state":           "approved",
+				"comment":         "Ship it",
+			},
+			expectError:    false,
+			expectedErrMsg: "only required reviewers of the environments can approve or reject them",
+		},
+		{
+			name:         "invalid state",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(29679449),
+				"environment_ids": []interface{}{float64(161088068)},
+				"state":           "pending",
+				"comment":         "Ship it",
+			},
+			expectError:    false,
+			expectedErrMsg: "invalid state",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReviewPendingDeployment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var deployments []map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &deployments)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDeployments, deployments)
+		})
+	}
+}
+
+func Test_RerunFailedJobs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RerunFailedJobs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "rerun_failed_jobs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.Contains(t, tool.InputSchema.Properties, "enable_debug_logging")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	mockRun := &github.WorkflowRun{
+		ID:         github.Ptr(int64(30433642)),
+		RunAttempt: github.Ptr(2),
+		Status:     github.Ptr("queued"),
+		RerunURL:   github.Ptr("https://api.github.com/repos/owner/repo/actions/runs/30433642/rerun"),
+		HTMLURL:    github.Ptr("https://github.com/owner/repo/actions/runs/30433642"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRun    map[string]interface{}
+	}{
+		{
+			name: "re-runs failed jobs with debug logging",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsRerunFailedJobsByOwnerByRepoByRunId,
+					expectRequestBody(t, map[string]interface{}{
+						"enable_debug_logging": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, map[string]interface{}{}),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					mockRun,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                "owner",
+				"repo":                 "repo",
+				"run_id":               float64(30433642),
+				"enable_debug_logging": true,
+			},
+			expectError: false,
+			expectedRun: map[string]interface{}{
+				"id":          float64(30433642),
+				"run_attempt": float64(2),
+				"status":      "queued",
+				"rerun_url":   "https://api.github.com/repos/owner/repo/actions/runs/30433642/rerun",
+				"html_url":    "https://github.com/owner/repo/actions/runs/30433642",
+			},
+		},
+		{
+			name: "debug logging defaults to off",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsRerunFailedJobsByOwnerByRepoByRunId,
+					expectRequestBody(t, map[string]interface{}{
+						"enable_debug_logging": false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, map[string]interface{}{}),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					mockRun,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(30433642),
+			},
+			expectError: false,
+			expectedRun: map[string]interface{}{
+				"id":          float64(30433642),
+				"run_attempt": float64(2),
+				"status":      "queued",
+				"rerun_url":   "https://api.github.com/repos/owner/repo/actions/runs/30433642/rerun",
+				"html_url":    "https://github.com/owner/repo/actions/runs/30433642",
+			},
+		},
+		{
+			name: "run still in progress",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsRerunFailedJobsByOwnerByRepoByRunId,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "This workflow is already running"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(30433642),
+			},
+			expectError:    false,
+			expectedErrMsg: "This workflow is already running",
+		},
+		{
+			name: "run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsRerunFailedJobsByOwnerByRepoByRunId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to re-run jobs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RerunFailedJobs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRun, response["workflow_run"])
+		})
+	}
+}
+
+func Test_RerunSpecificJob(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RerunSpecificJob(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "rerun_specific_job", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "job_id")
+	assert.Contains(t, tool.InputSchema.Properties, "enable_debug_logging")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "job_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRun    map[string]interface{}
+	}{
+		{
+			name: "re-runs a job as a new attempt of its run",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsJobsByOwnerByRepoByJobId,
+					&github.WorkflowJob{
+						ID:    github.Ptr(int64(399444496)),
+						RunID: github.Ptr(int64(29679449)),
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsJobsRerunByOwnerByRepoByJobId,
+					expectRequestBody(t, map[string]interface{}{
+						"enable_debug_logging": false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, map[string]interface{}{}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/actions/runs/29679449", r.URL.Path)
+						mockResponse(t, http.StatusOK, &github.WorkflowRun{
+							ID:         github.Ptr(int64(29679449)),
+							RunAttempt: github.Ptr(3),
+							Status:     github.Ptr("queued"),
+							RerunURL:   github.Ptr("https://api.github.com/repos/owner/repo/actions/runs/29679449/rerun"),
+							HTMLURL:    github.Ptr("https://github.com/owner/repo/actions/runs/29679449"),
+						})(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"job_id": float64(399444496),
+			},
+			expectError: false,
+			expectedRun: map[string]interface{}{
+				"id":          float64(29679449),
+				"run_attempt": float64(3),
+				"status":      "queued",
+				"rerun_url":   "https://api.github.com/repos/owner/repo/actions/runs/29679449/rerun",
+				"html_url":    "https://github.com/owner/repo/actions/runs/29679449",
+			},
+		},
+		{
+			name: "job not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsByOwnerByRepoByJobId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"job_id": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow job",
+		},
+		{
+			name: "run still in progress",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsJobsByOwnerByRepoByJobId,
+					&github.WorkflowJob{
+						ID:    github.Ptr(int64(399444496)),
+						RunID: github.Ptr(int64(29679449)),
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsJobsRerunByOwnerByRepoByJobId,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "This workflow is already running"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"job_id": float64(399444496),
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to re-run jobs of workflow run 29679449",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RerunSpecificJob(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRun, response["workflow_run"])
+		})
+	}
+}
+
+func Test_GetActionsCacheUsage(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetActionsCacheUsage(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_actions_cache_usage", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedUsage  map[string]interface{}
+	}{
+		{
+			name: "cache usage totals",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsCacheUsageByOwnerByRepo,
+					&github.ActionsCacheUsage{
+						FullName:                "owner/repo",
+						ActiveCachesSizeInBytes: 2322142,
+						ActiveCachesCount:       3,
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expectedUsage: map[string]interface{}{
+				"active_caches_count":         float64(3),
+				"active_caches_size_in_bytes": float64(2322142),
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsCacheUsageByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get Actions cache usage",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetActionsCacheUsage(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedUsage, response)
+		})
+	}
+}
+
+func Test_ListActionsCaches(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListActionsCaches(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_actions_caches", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "key")
+	assert.Contains(t, tool.InputSchema.Properties, "sort")
+	assert.Contains(t, tool.InputSchema.Properties, "direction")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockCaches := &github.ActionsCacheList{
+		TotalCount: 2,
+		ActionsCaches: []*github.ActionsCache{
+			{
+				ID:             github.Ptr(int64(505)),
+				Ref:            github.Ptr("refs/heads/main"),
+				Key:            github.Ptr("Linux-node-958aff96db2d75d67787d1e634ae70b659de937b"),
+				SizeInBytes:    github.Ptr(int64(1022142)),
+				LastAccessedAt: &github.Timestamp{Time: time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)},
+				CreatedAt:      &github.Timestamp{Time: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)},
+			},
+			{
+				ID:             github.Ptr(int64(506)),
+				Ref:            github.Ptr("refs/pull/42/merge"),
+				Key:            github.Ptr("Linux-node-73b0d5d0e86996d4b2d6d1ea0bcb51c4c8ab1dc6"),
+				SizeInBytes:    github.Ptr(int64(300000)),
+				LastAccessedAt: &github.Timestamp{Time: time.Date(2025, 3, 20, 12, 0, 0, 0, time.UTC)},
+				CreatedAt:      &github.Timestamp{Time: time.Date(2025, 3, 20, 11, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedTotal  int
+		expectedCaches []map[string]interface{}
+	}{
+		{
+			name: "lists caches by size",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsCachesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"key":       "Linux-node-",
+						"sort":      "size_in_bytes",
+						"direction": "desc",
+						"page":      "1",
+						"per_page":  "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockCaches),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"key":       "Linux-node-",
+				"sort":      "size_in_bytes",
+				"direction": "desc",
+			},
+			expectError:   false,
+			expectedTotal: 2,
+			expectedCaches: []map[string]interface{}{
+				{
+					"id":               float64(505),
+					"key":              "Linux-node-958aff96db2d75d67787d1e634ae70b659de937b",
+					"ref":              "refs/heads/main",
+					"size_in_bytes":    float64(1022142),
+					"last_accessed_at": "2025-04-01T12:00:00Z",
+					"created_at":       "2025-03-01T12:00:00Z",
+				},
+				{
+					"id":               float64(506),
+					"key":              "Linux-node-73b0d5d0e86996d4b2d6d1ea0bcb51c4c8ab1dc6",
+					"ref":              "refs/pull/42/merge",
+					"size_in_bytes":    float64(300000),
+					"last_accessed_at": "2025-03-20T12:00:00Z",
+					"created_at":       "2025-03-20T11:00:00Z",
+				},
+			},
+		},
+		{
+			name: "no caches for a ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsCachesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"ref":      "refs/heads/stale",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.ActionsCacheList{TotalCount: 0}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "refs/heads/stale",
+			},
+			expectError:    false,
+			expectedTotal:  0,
+			expectedCaches: []map[string]interface{}{},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsCachesByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list Actions caches",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListActionsCaches(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var response struct {
+				TotalCount int                      `json:"total_count"`
+				Caches     []map[string]interface{} `json:"caches"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTotal, response.TotalCount)
+			assert.Equal(t, tc.expectedCaches, response.Caches)
+		})
+	}
+}
+
+func Test_WaitForWorkflowRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := WaitForWorkflowRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "wait_for_workflow_run", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.Contains(t, tool.InputSchema.Properties, "timeout_seconds")
+	assert.Contains(t, tool.InputSchema.Properties, "poll_interval_seconds")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	startedAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	runWithStatus := func(status, conclusion string) *github.WorkflowRun {
+		run := &github.WorkflowRun{
+			ID:           github.Ptr(int64(30433642)),
+			RunNumber:    github.Ptr(562),
+			Status:       github.Ptr(status),
+			HTMLURL:      github.Ptr("https://github.com/owner/repo/actions/runs/30433642"),
+			RunStartedAt: &github.Timestamp{Time: startedAt},
+			UpdatedAt:    &github.Timestamp{Time: startedAt.Add(90 * time.Second)},
+		}
+		if conclusion != "" {
+			run.Conclusion = github.Ptr(conclusion)
+		}
+		return run
+	}
+
+	var polls int
+	countingPolls := func(status string, header http.Header) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			polls++
+			for name, values := range header {
+				w.Header()[name] = values
+			}
+			mockResponse(t, http.StatusOK, runWithStatus(status, ""))(w, r)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+		expectedPolls  int
+	}{
+		{
+			name: "returns once the run completes",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					runWithStatus("queued", ""),
+					runWithStatus("in_progress", ""),
+					runWithStatus("completed", "failure"),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					expectQueryParams(t, map[string]string{
+						"filter":   "latest",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Jobs{
+							TotalCount: github.Ptr(3),
+							Jobs: []*github.WorkflowJob{
+								{Name: github.Ptr("build"), Conclusion: github.Ptr("success")},
+								{Name: github.Ptr("test"), Conclusion: github.Ptr("failure")},
+								{Name: github.Ptr("lint"), Conclusion: github.Ptr("timed_out")},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"run_id":                float64(30433642),
+				"poll_interval_seconds": float64(5),
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"timed_out":            false,
+				"status":               "completed",
+				"conclusion":           "failure",
+				"run_number":           float64(562),
+				"html_url":             "https://github.com/owner/repo/actions/runs/30433642",
+				"run_duration_seconds": float64(90),
+				"failed_jobs":          []interface{}{"test", "lint"},
+			},
+		},
+		{
+			name: "times out while the run is in progress",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					countingPolls("in_progress", nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"run_id":                float64(30433642),
+				"timeout_seconds":       float64(30),
+				"poll_interval_seconds": float64(5),
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"timed_out":      true,
+				"current_status": "in_progress",
+				"run_number":     float64(562),
+				"html_url":       "https://github.com/owner/repo/actions/runs/30433642",
+			},
+		},
+		{
+			name: "respects the poll interval asked by GitHub",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					countingPolls("queued", http.Header{"X-Poll-Interval": []string{"1000"}}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"run_id":                float64(30433642),
+				"timeout_seconds":       float64(50),
+				"poll_interval_seconds": float64(5),
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"timed_out":      true,
+				"current_status": "queued",
+				"run_number":     float64(562),
+				"html_url":       "https://github.com/owner/repo/actions/runs/30433642",
+			},
+			expectedPolls: 1,
+		},
+		{
+			name: "run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(999),
+			},
+			expectError:    false,
+			expectedErrMsg: "workflow run 999 not found in owner/repo",
+		},
+		{
+			name:         "poll interval too short",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"run_id":                float64(30433642),
+				"poll_interval_seconds": float64(1),
+			},
+			expectError:    false,
+			expectedErrMsg: "poll_interval_seconds must be at least 5",
+		},
+		{
+			name:         "timeout too long",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(30433642),
+				"timeout_seconds": float64(600),
+			},
+			expectError:    false,
+			expectedErrMsg: "timeout_seconds must be between 1 and 300",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			polls = 0
+
+			// Setup client with mock, counting durations in milliseconds rather than seconds
+			client := github.NewClient(tc.mockedClient)
+			_, handler := waitForWorkflowRunTool(stubGetClientFn(client), translations.NullTranslationHelper, time.Millisecond)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, response)
+			if tc.expectedPolls > 0 {
+				assert.Equal(t, tc.expectedPolls, polls)
+			}
+		})
+	}
+}
+
+func Test_DeleteActionsCache(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteActionsCache(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_actions_cache", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "cache_id")
+	assert.Contains(t, tool.InputSchema.Properties, "key")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedText     string
+		expectedCount    int
+		expectedCacheIDs []int64
+	}{
+		{
+			name: "deletes a cache by ID",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposActionsCachesByOwnerByRepoByCacheId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/actions/caches/505", r.URL.Path)
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"cache_id": float64(505),
+			},
+			expectError:  false,
+			expectedText: "Actions cache 505 of owner/repo deleted",
+		},
+		{
+			name: "deletes every cache with a key",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposActionsCachesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"key": "Linux-node-958aff96db2d75d67787d1e634ae70b659de937b",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.ActionsCacheList{
+							TotalCount: 2,
+							ActionsCaches: []*github.ActionsCache{
+								{ID: github.Ptr(int64(505)), Ref: github.Ptr("refs/heads/main"), SizeInBytes: github.Ptr(int64(1024))},
+								{ID: github.Ptr(int64(506)), Ref: github.Ptr("refs/pull/42/merge"), SizeInBytes: github.Ptr(int64(2048))},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"key":   "Linux-node-958aff96db2d75d67787d1e634ae70b659de937b",
+			},
+			expectError:      false,
+			expectedCount:    2,
+			expectedCacheIDs: []int64{505, 506},
+		},
+		{
+			name: "deletes the caches with a key on one ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposActionsCachesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"key": "Linux-node-958aff96db2d75d67787d1e634ae70b659de937b",
+						"ref": "refs/heads/main",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.ActionsCacheList{
+							TotalCount: 1,
+							ActionsCaches: []*github.ActionsCache{
+								{ID: github.Ptr(int64(505)), Ref: github.Ptr("refs/heads/main"), SizeInBytes: github.Ptr(int64(1024))},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"key":   "Linux-node-958aff96db2d75d67787d1e634ae70b659de937b",
+				"ref":   "refs/heads/main",
+			},
+			expectError:      false,
+			expectedCount:    1,
+			expectedCacheIDs: []int64{505},
+		},
+		{
+			name: "no cache with the key",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposActionsCachesByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"key":   "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "no cache with key missing found in owner/repo",
+		},
+		{
+			name:         "both cache_id and key",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"cache_id": float64(505),
+				"key":      "Linux-node-958aff96db2d75d67787d1e634ae70b659de937b",
+			},
+			expectError:    false,
+			expectedErrMsg: "either cache_id or key is required, but not both",
+		},
+		{
+			name:         "neither cache_id nor key",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedErrMsg: "either cache_id or key is required, but not both",
+		},
+		{
+			name:         "ref without key",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"cache_id": float64(505),
+				"ref":      "refs/heads/main",
+			},
+			expectError:    false,
+			expectedErrMsg: "ref can only be used with key",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteActionsCache(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+				return
+			}
+
+			var response struct {
+				DeletedCount int `json:"deleted_count"`
+				Deleted      []struct {
+					ID int64 `json:"id"`
+				} `json:"deleted"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCount, response.DeletedCount)
+			ids := []int64{}
+			for _, cache := range response.Deleted {
+				ids = append(ids, cache.ID)
+			}
+			assert.Equal(t, tc.expectedCacheIDs, ids)
+		})
+	}
+}
+
+func Test_GetWorkflowDispatchSchema(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWorkflowDispatchSchema(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_workflow_dispatch_schema", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "workflow_id")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "workflow_id"})
+
+	workflowFile := func(path, content string) *github.RepositoryContent {
+		return &github.RepositoryContent{
+			Type:     github.Ptr("file"),
+			Path:     github.Ptr(path),
+			Encoding: github.Ptr("base64"),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(content))),
+		}
+	}
+	deployWorkflow := `name: Deploy
+on:
+  push:
+    branches: [main]
+  workflow_dispatch:
+    inputs:
+      version:
+        description: Version to deploy
+        required: true
+      environment:
+        description: Where to deploy
+        type: environment
+        required: true
+      log_level:
+        type: choice
+        default: info
+        options:
+          - info
+          - debug
+      dry_run:
+        type: boolean
+        default: false
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo deploying
+`
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedPath      string
+		expectedSupported bool
+		expectedInputs    []workflowDispatchInput
+	}{
+		{
+			name: "inputs of every type",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/contents/.github/workflows/deploy.yml", r.URL.Path)
+						assert.Equal(t, "release", r.URL.Query().Get("ref"))
+						mockResponse(t, http.StatusOK, workflowFile(".github/workflows/deploy.yml", deployWorkflow))(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "deploy.yml",
+				"ref":         "release",
+			},
+			expectError:       false,
+			expectedPath:      ".github/workflows/deploy.yml",
+			expectedSupported: true,
+			expectedInputs: []workflowDispatchInput{
+				{Name: "version", Description: "Version to deploy", Required: true, Type: "string"},
+				{Name: "environment", Description: "Where to deploy", Required: true, Type: "environment"},
+				{Name: "log_level", Type: "choice", Default: "info", Options: []string{"info", "debug"}},
+				{Name: "dry_run", Type: "boolean", Default: false},
+			},
+		},
+		{
+			name: "workflow by ID",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsWorkflowsByOwnerByRepoByWorkflowId,
+					&github.Workflow{
+						ID:   github.Ptr(int64(161335)),
+						Path: github.Ptr(".github/workflows/release.yml"),
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/contents/.github/workflows/release.yml", r.URL.Path)
+						mockResponse(t, http.StatusOK, workflowFile(".github/workflows/release.yml", "on: [push, workflow_dispatch]\njobs: {}\n"))(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "161335",
+			},
+			expectError:       false,
+			expectedPath:      ".github/workflows/release.yml",
+			expectedSupported: true,
+			expectedInputs:    []workflowDispatchInput{},
+		},
+		{
+			name: "no workflow_dispatch trigger",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					workflowFile(".github/workflows/ci.yml", "on:\n  pull_request:\n    branches: [main]\njobs: {}\n"),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "ci.yml",
+			},
+			expectError:       false,
+			expectedPath:      ".github/workflows/ci.yml",
+			expectedSupported: false,
+			expectedInputs:    []workflowDispatchInput{},
+		},
+		{
+			name: "workflow not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "missing.yml",
+			},
+			expectError:    false,
+			expectedErrMsg: "workflow .github/workflows/missing.yml not found in owner/repo",
+		},
+		{
+			name: "invalid workflow file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					workflowFile(".github/workflows/broken.yml", "on: [push\n"),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "broken.yml",
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to parse .github/workflows/broken.yml",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetWorkflowDispatchSchema(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned struct {
+				WorkflowPath      string                  `json:"workflow_path"`
+				DispatchSupported bool                    `json:"dispatch_supported"`
+				Inputs            []workflowDispatchInput `json:"inputs"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPath, returned.WorkflowPath)
+			assert.Equal(t, tc.expectedSupported, returned.DispatchSupported)
+			assert.Equal(t, tc.expectedInputs, returned.Inputs)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxActivityTrendBuckets bounds the number of buckets of an activity trend. Each costs two searches, and
+// the search API only allows 30 requests a minute.
+const maxActivityTrendBuckets = 14
+
+// activityTrendNow is the time activity trends end at. Tests replace it.
+var activityTrendNow = time.Now
+
+// activityBucketDays are the bucket sizes an activity trend can be split into, in days.
+var activityBucketDays = map[string]int{
+	"day":  1,
+	"week": 7,
+}
+
+// activityBucket is how many issues or pull requests of a repository were opened and closed between two dates,
+// both included.
+type activityBucket struct {
+	Start  string `json:"start"`
+	End    string `json:"end"`
+	Opened int    `json:"opened"`
+	Closed int    `json:"closed"`
+}
+
+// activityTrendBuckets splits the windowDays days up to and including today into buckets of a day or a week,
+// oldest first. Buckets are counted back from today, so only the oldest one can be shorter than the others.
+func activityTrendBuckets(today time.Time, windowDays int, bucket string) ([]activityBucket, error) {
+	size, ok := activityBucketDays[bucket]
+	if !ok {
+		return nil, fmt.Errorf("bucket must be day or week, got %q", bucket)
+	}
+	if windowDays < 1 {
+		return nil, fmt.Errorf("window_days must be at least 1, got %d", windowDays)
+	}
+	count := (windowDays + size - 1) / size
+	if count > maxActivityTrendBuckets {
+		return nil, fmt.Errorf("a window of %d days has %d %s buckets, at most %d are allowed: shorten the window or use larger buckets", windowDays, count, bucket, maxActivityTrendBuckets)
+	}
+
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	first := today.AddDate(0, 0, 1-windowDays)
+	buckets := make([]activityBucket, count)
+	for i := range buckets {
+		end := today.AddDate(0, 0, -size*(count-1-i))
+		start := end.AddDate(0, 0, 1-size)
+		if start.Before(first) {
+			start = first
+		}
+		buckets[i] = activityBucket{Start: start.Format("2006-01-02"), End: end.Format("2006-01-02")}
+	}
+	return buckets, nil
+}
+
+// activityTrendQuery is the search for the items of a kind, issue or pr, of a repository that had an event,
+// created or closed, during a bucket.
+func activityTrendQuery(owner, repo, kind, event string, b activityBucket) string {
+	return fmt.Sprintf("repo:%s/%s is:%s %s:%s..%s", owner, repo, kind, event, b.Start, b.End)
+}
+
+// countSearchResults counts the issues and pull requests a search finds.
+func countSearchResults(ctx context.Context, client *github.Client, query string) (int, error) {
+	result, resp, err := client.Search.Issues(ctx, query, &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 1}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to search %q: %w", query, err)
+	}
+	_ = resp.Body.Close()
+	return result.GetTotal(), nil
+}
+
+// GetActivityTrend creates a tool to count the issues or pull requests of a repository opened and closed over time.
+func GetActivityTrend(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_activity_trend",
+			mcp.WithDescription(t("TOOL_GET_ACTIVITY_TREND_DESCRIPTION", fmt.Sprintf("Count the issues or pull requests of a repository opened and closed in each day or week of a window of time ending today, oldest first. At most %d buckets can be counted at once", maxActivityTrendBuckets))),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("window_days",
+				mcp.Description("Number of days, up to and including today, to count activity over. Defaults to 90"),
+				mcp.Min(1),
+			),
+			mcp.WithString("bucket",
+				mcp.Description("Size of the buckets the window is split into. Defaults to week"),
+				mcp.Enum("day", "week"),
+			),
+			mcp.WithString("type",
+				mcp.Description("Whether to count issues or pull requests. Defaults to issue"),
+				mcp.Enum("issue", "pr"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			windowDays, err := OptionalIntParamWithDefault(request, "window_days", 90)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			bucket, err := OptionalParam[string](request, "bucket")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			kind, err := OptionalParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if bucket == "" {
+				bucket = "week"
+			}
+			if kind == "" {
+				kind = "issue"
+			}
+			if kind != "issue" && kind != "pr" {
+				return mcp.NewToolResultError("type must be issue or pr"), nil
+			}
+			buckets, err := activityTrendBuckets(activityTrendNow().UTC(), windowDays, bucket)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			errs := forEachConcurrently(ctx, buckets, func(ctx context.Context, i int, b activityBucket) error {
+				opened, err := countSearchResults(ctx, client, activityTrendQuery(owner, repo, kind, "created", b))
+				if err != nil {
+					return err
+				}
+				closed, err := countSearchResults(ctx, client, activityTrendQuery(owner, repo, kind, "closed", b))
+				if err != nil {
+					return err
+				}
+				buckets[i].Opened, buckets[i].Closed = opened, closed
+				return nil
+			})
+			if err := errors.Join(errs...); err != nil {
+				return nil, err
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"type":    kind,
+				"bucket":  bucket,
+				"buckets": buckets,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
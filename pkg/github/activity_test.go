@@ -0,0 +1,213 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_activityTrendBuckets(t *testing.T) {
+	today := time.Date(2025, 4, 16, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		windowDays      int
+		bucket          string
+		expectedErrMsg  string
+		expectedBuckets []activityBucket
+	}{
+		{
+			name:       "daily buckets",
+			windowDays: 3,
+			bucket:     "day",
+			expectedBuckets: []activityBucket{
+				{Start: "2025-04-14", End: "2025-04-14"},
+				{Start: "2025-04-15", End: "2025-04-15"},
+				{Start: "2025-04-16", End: "2025-04-16"},
+			},
+		},
+		{
+			name:       "weekly buckets with a short oldest week",
+			windowDays: 17,
+			bucket:     "week",
+			expectedBuckets: []activityBucket{
+				{Start: "2025-03-31", End: "2025-04-02"},
+				{Start: "2025-04-03", End: "2025-04-09"},
+				{Start: "2025-04-10", End: "2025-04-16"},
+			},
+		},
+		{
+			name:           "too many buckets",
+			windowDays:     90,
+			bucket:         "day",
+			expectedErrMsg: "a window of 90 days has 90 day buckets, at most 14 are allowed",
+		},
+		{
+			name:           "unknown bucket",
+			windowDays:     30,
+			bucket:         "month",
+			expectedErrMsg: `bucket must be day or week, got "month"`,
+		},
+		{
+			name:           "empty window",
+			windowDays:     0,
+			bucket:         "day",
+			expectedErrMsg: "window_days must be at least 1, got 0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			buckets, err := activityTrendBuckets(today, tc.windowDays, tc.bucket)
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedBuckets, buckets)
+		})
+	}
+}
+
+func Test_GetActivityTrend(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetActivityTrend(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_activity_trend", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "window_days")
+	assert.Contains(t, tool.InputSchema.Properties, "bucket")
+	assert.Contains(t, tool.InputSchema.Properties, "type")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	activityTrendNow = func() time.Time { return time.Date(2025, 4, 16, 15, 30, 0, 0, time.UTC) }
+	t.Cleanup(func() { activityTrendNow = time.Now })
+
+	counts := map[string]int{
+		"repo:owner/repo is:issue created:2025-04-02..2025-04-02": 3,
+		"repo:owner/repo is:issue closed:2025-04-02..2025-04-02":  1,
+		"repo:owner/repo is:issue created:2025-04-03..2025-04-09": 5,
+		"repo:owner/repo is:issue closed:2025-04-03..2025-04-09":  4,
+		"repo:owner/repo is:issue created:2025-04-10..2025-04-16": 2,
+		"repo:owner/repo is:issue closed:2025-04-10..2025-04-16":  6,
+		"repo:owner/repo is:pr created:2025-04-15..2025-04-15":    1,
+		"repo:owner/repo is:pr closed:2025-04-15..2025-04-15":     0,
+		"repo:owner/repo is:pr created:2025-04-16..2025-04-16":    2,
+		"repo:owner/repo is:pr closed:2025-04-16..2025-04-16":     1,
+	}
+
+	tests := []struct {
+		name            string
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedBuckets []activityBucket
+	}{
+		{
+			name: "weekly issue activity",
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"window_days": float64(15),
+			},
+			expectError: false,
+			expectedBuckets: []activityBucket{
+				{Start: "2025-04-02", End: "2025-04-02", Opened: 3, Closed: 1},
+				{Start: "2025-04-03", End: "2025-04-09", Opened: 5, Closed: 4},
+				{Start: "2025-04-10", End: "2025-04-16", Opened: 2, Closed: 6},
+			},
+		},
+		{
+			name: "daily pull request activity",
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"window_days": float64(2),
+				"bucket":      "day",
+				"type":        "pr",
+			},
+			expectError: false,
+			expectedBuckets: []activityBucket{
+				{Start: "2025-04-15", End: "2025-04-15", Opened: 1, Closed: 0},
+				{Start: "2025-04-16", End: "2025-04-16", Opened: 2, Closed: 1},
+			},
+		},
+		{
+			name: "default window in daily buckets",
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"bucket": "day",
+			},
+			expectError:    false,
+			expectedErrMsg: "a window of 90 days has 90 day buckets, at most 14 are allowed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			var mu sync.Mutex
+			var queries []string
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						query := r.URL.Query().Get("q")
+						mu.Lock()
+						queries = append(queries, query)
+						mu.Unlock()
+						total, ok := counts[query]
+						require.True(t, ok, "unexpected search %q", query)
+						mockResponse(t, http.StatusOK, &github.IssuesSearchResult{Total: github.Ptr(total)})(w, r)
+					}),
+				),
+			))
+			_, handler := GetActivityTrend(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				assert.Empty(t, queries)
+				return
+			}
+
+			var trend struct {
+				Buckets []activityBucket `json:"buckets"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &trend)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedBuckets, trend.Buckets)
+			assert.Len(t, queries, 2*len(tc.expectedBuckets))
+		})
+	}
+}
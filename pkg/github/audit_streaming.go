@@ -0,0 +1,537 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/crypto"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// auditLogStreamProviders are the services GitHub can stream the audit log of an enterprise to.
+var auditLogStreamProviders = []string{
+	"Amazon S3",
+	"Azure Blob Storage",
+	"Azure Event Hubs",
+	"Datadog",
+	"Google Cloud Storage",
+	"HTTPS Event Collector",
+	"Splunk",
+}
+
+// encryptedConnectionPrefix marks the connection details GitHub expects encrypted with the stream key,
+// such as encrypted_token.
+const encryptedConnectionPrefix = "encrypted_"
+
+// auditLogStream is an audit log streaming configuration of an enterprise. go-github has no support for
+// audit log streaming, so the requests are built here.
+type auditLogStream struct {
+	ID            int64             `json:"id"`
+	StreamType    string            `json:"stream_type"`
+	StreamDetails string            `json:"stream_details"`
+	Enabled       bool              `json:"enabled"`
+	CreatedAt     *github.Timestamp `json:"created_at"`
+	UpdatedAt     *github.Timestamp `json:"updated_at"`
+	PausedAt      *github.Timestamp `json:"paused_at"`
+}
+
+// summary returns the configuration with the provider named as in the tool parameters.
+func (s *auditLogStream) summary() map[string]interface{} {
+	return map[string]interface{}{
+		"id":         s.ID,
+		"provider":   s.StreamType,
+		"enabled":    s.Enabled,
+		"details":    s.StreamDetails,
+		"paused_at":  s.PausedAt,
+		"created_at": s.CreatedAt,
+		"updated_at": s.UpdatedAt,
+	}
+}
+
+// auditLogStreamConfig is the body that creates or replaces an audit log streaming configuration.
+type auditLogStreamConfig struct {
+	Enabled        bool                   `json:"enabled"`
+	StreamType     string                 `json:"stream_type"`
+	VendorSpecific map[string]interface{} `json:"vendor_specific"`
+}
+
+// doAuditLogStreamRequest sends an audit log streaming request of an enterprise, decoding the response into v
+// when it is set.
+func doAuditLogStreamRequest(ctx context.Context, client *github.Client, method, u string, body, v interface{}) (*github.Response, error) {
+	req, err := client.NewRequest(method, u, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	return client.Do(ctx, req, v)
+}
+
+// encryptStreamConnection returns the connection details to send for a stream, with the encrypted_ details
+// encrypted with the stream key of the enterprise and the key ID added.
+func encryptStreamConnection(ctx context.Context, client *github.Client, enterprise string, connection map[string]interface{}) (map[string]interface{}, error) {
+	key := new(crypto.PublicKey)
+	resp, err := doAuditLogStreamRequest(ctx, client, "GET", fmt.Sprintf("enterprises/%v/audit-log/stream-key", enterprise), nil, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit log stream key: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	vendorSpecific := make(map[string]interface{}, len(connection)+1)
+	for name, value := range connection {
+		if strings.HasPrefix(name, encryptedConnectionPrefix) {
+			plaintext, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("connection detail %s must be a string", name)
+			}
+			if value, err = crypto.EncryptSecret(key, plaintext); err != nil {
+				return nil, err
+			}
+		}
+		vendorSpecific[name] = value
+	}
+	vendorSpecific["key_id"] = key.KeyID
+	return vendorSpecific, nil
+}
+
+// auditLogStreamNotFound is the message for a stream that doesn't exist, or an enterprise without audit log streaming.
+func auditLogStreamNotFound(enterprise string, streamID int) string {
+	return fmt.Sprintf("audit log streaming configuration %d not found in enterprise %s", streamID, enterprise)
+}
+
+// ListAuditLogStreamingConfigurations creates a tool to list the audit log streaming configurations of an enterprise.
+func ListAuditLogStreamingConfigurations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_audit_log_streaming_configurations",
+			mcp.WithDescription(t("TOOL_LIST_AUDIT_LOG_STREAMING_CONFIGURATIONS_DESCRIPTION", "List the configurations streaming the audit log of an enterprise to external services, such as a SIEM. Requires enterprise owner permissions")),
+			mcp.WithString("enterprise",
+				mcp.Required(),
+				mcp.Description("Enterprise slug"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			enterprise, err := requiredParam[string](request, "enterprise")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			var streams []*auditLogStream
+			resp, err := doAuditLogStreamRequest(ctx, client, "GET", fmt.Sprintf("enterprises/%v/audit-log/streams", enterprise), nil, &streams)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list audit log streaming configurations: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list audit log streaming configurations: %s", string(body))), nil
+			}
+
+			result := make([]map[string]interface{}, 0, len(streams))
+			for _, stream := range streams {
+				result = append(result, stream.summary())
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// getAuditLogStream fetches an audit log streaming configuration of an enterprise.
+func getAuditLogStream(ctx context.Context, client *github.Client, enterprise string, streamID int) (*auditLogStream, *github.Response, error) {
+	stream := new(auditLogStream)
+	resp, err := doAuditLogStreamRequest(ctx, client, "GET", fmt.Sprintf("enterprises/%v/audit-log/streams/%d", enterprise, streamID), nil, stream)
+	if err != nil {
+		return nil, resp, err
+	}
+	return stream, resp, nil
+}
+
+// GetAuditLogStreamingConfiguration creates a tool to get an audit log streaming configuration of an enterprise.
+func GetAuditLogStreamingConfiguration(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_audit_log_streaming_configuration",
+			mcp.WithDescription(t("TOOL_GET_AUDIT_LOG_STREAMING_CONFIGURATION_DESCRIPTION", "Get a configuration streaming the audit log of an enterprise to an external service. Requires enterprise owner permissions")),
+			mcp.WithString("enterprise",
+				mcp.Required(),
+				mcp.Description("Enterprise slug"),
+			),
+			mcp.WithNumber("stream_id",
+				mcp.Required(),
+				mcp.Description("The ID of the streaming configuration"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			enterprise, err := requiredParam[string](request, "enterprise")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			streamID, err := RequiredInt(request, "stream_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			stream, resp, err := getAuditLogStream(ctx, client, enterprise, streamID)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(auditLogStreamNotFound(enterprise, streamID)), nil
+				}
+				return nil, fmt.Errorf("failed to get audit log streaming configuration: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get audit log streaming configuration: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(stream.summary())
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CheckAuditLogStreamingConfiguration creates a tool to check whether an audit log streaming configuration of an
+// enterprise is streaming.
+func CheckAuditLogStreamingConfiguration(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("check_audit_log_streaming_configuration",
+			mcp.WithDescription(t("TOOL_CHECK_AUDIT_LOG_STREAMING_CONFIGURATION_DESCRIPTION", "Check whether a configuration is currently streaming the audit log of an enterprise: it is enabled and its stream isn't paused. Requires enterprise owner permissions")),
+			mcp.WithString("enterprise",
+				mcp.Required(),
+				mcp.Description("Enterprise slug"),
+			),
+			mcp.WithNumber("stream_id",
+				mcp.Required(),
+				mcp.Description("The ID of the streaming configuration"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			enterprise, err := requiredParam[string](request, "enterprise")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			streamID, err := RequiredInt(request, "stream_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			stream, resp, err := getAuditLogStream(ctx, client, enterprise, streamID)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(auditLogStreamNotFound(enterprise, streamID)), nil
+				}
+				return nil, fmt.Errorf("failed to get audit log streaming configuration: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get audit log streaming configuration: %s", string(body))), nil
+			}
+
+			// The API doesn't report the outcome of deliveries, only whether the stream is running
+			result := map[string]interface{}{
+				"id":        stream.ID,
+				"provider":  stream.StreamType,
+				"enabled":   stream.Enabled,
+				"paused":    stream.PausedAt != nil,
+				"paused_at": stream.PausedAt,
+				"streaming": stream.Enabled && stream.PausedAt == nil,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// auditLogStreamOptions returns the parameters describing the configuration the create and update tools send.
+func auditLogStreamOptions(enabledDescription string) []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("provider",
+			mcp.Required(),
+			mcp.Description("Service to stream the audit log to"),
+			mcp.Enum(auditLogStreamProviders...),
+		),
+		mcp.WithObject("connection",
+			mcp.Required(),
+			mcp.Description("Provider specific connection details, as documented for the REST API, such as bucket and region for Amazon S3 or domain, port and encrypted_token for Splunk. Give encrypted_ details in plain text: they are encrypted with the stream key of the enterprise, whose key_id is added"),
+		),
+		mcp.WithBoolean("enabled",
+			mcp.Description(enabledDescription),
+		),
+	}
+}
+
+// auditLogStreamParams are the configuration parameters given to the create and update tools. Enabled is nil when
+// it isn't given.
+type auditLogStreamParams struct {
+	provider   string
+	connection map[string]interface{}
+	enabled    *bool
+}
+
+// getAuditLogStreamParams returns the configuration parameters given to the create and update tools, checking the
+// provider is known.
+func getAuditLogStreamParams(request mcp.CallToolRequest) (auditLogStreamParams, error) {
+	provider, err := requiredParam[string](request, "provider")
+	if err != nil {
+		return auditLogStreamParams{}, err
+	}
+	if !slices.Contains(auditLogStreamProviders, provider) {
+		return auditLogStreamParams{}, fmt.Errorf("provider must be one of %s", strings.Join(auditLogStreamProviders, ", "))
+	}
+	connection, ok, err := OptionalParamOK[map[string]interface{}](request, "connection")
+	if err != nil {
+		return auditLogStreamParams{}, err
+	}
+	if !ok || len(connection) == 0 {
+		return auditLogStreamParams{}, fmt.Errorf("missing required parameter: connection")
+	}
+	params := auditLogStreamParams{provider: provider, connection: connection}
+	enabled, ok, err := OptionalParamOK[bool](request, "enabled")
+	if err != nil {
+		return auditLogStreamParams{}, err
+	}
+	if ok {
+		params.enabled = github.Ptr(enabled)
+	}
+	return params, nil
+}
+
+// newAuditLogStreamConfig returns the body sending the configuration parameters, with its connection details
+// encrypted.
+func newAuditLogStreamConfig(ctx context.Context, client *github.Client, enterprise string, params auditLogStreamParams, enabled bool) (*auditLogStreamConfig, error) {
+	vendorSpecific, err := encryptStreamConnection(ctx, client, enterprise, params.connection)
+	if err != nil {
+		return nil, err
+	}
+	return &auditLogStreamConfig{
+		Enabled:        enabled,
+		StreamType:     params.provider,
+		VendorSpecific: vendorSpecific,
+	}, nil
+}
+
+// CreateAuditLogStreamingConfiguration creates a tool to stream the audit log of an enterprise to an external service.
+func CreateAuditLogStreamingConfiguration(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_CREATE_AUDIT_LOG_STREAMING_CONFIGURATION_DESCRIPTION", "Stream the audit log of an enterprise to an external service, such as a SIEM. Requires enterprise owner permissions")),
+		mcp.WithString("enterprise",
+			mcp.Required(),
+			mcp.Description("Enterprise slug"),
+		),
+	}
+	return mcp.NewTool("create_audit_log_streaming_configuration", append(options, auditLogStreamOptions("Whether to start streaming right away. Defaults to true")...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			enterprise, err := requiredParam[string](request, "enterprise")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			params, err := getAuditLogStreamParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			enabled := params.enabled == nil || *params.enabled
+			config, err := newAuditLogStreamConfig(ctx, client, enterprise, params, enabled)
+			if err != nil {
+				return nil, err
+			}
+
+			stream := new(auditLogStream)
+			resp, err := doAuditLogStreamRequest(ctx, client, "POST", fmt.Sprintf("enterprises/%v/audit-log/streams", enterprise), config, stream)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusUnprocessableEntity) {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to create audit log streaming configuration: GitHub couldn't connect to %s with the given connection details: %s", params.provider, err)), nil
+				}
+				return nil, fmt.Errorf("failed to create audit log streaming configuration: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create audit log streaming configuration: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(stream.summary())
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateAuditLogStreamingConfiguration creates a tool to replace an audit log streaming configuration of an enterprise.
+func UpdateAuditLogStreamingConfiguration(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_UPDATE_AUDIT_LOG_STREAMING_CONFIGURATION_DESCRIPTION", "Replace the provider and connection details of a configuration streaming the audit log of an enterprise. Requires enterprise owner permissions")),
+		mcp.WithString("enterprise",
+			mcp.Required(),
+			mcp.Description("Enterprise slug"),
+		),
+		mcp.WithNumber("stream_id",
+			mcp.Required(),
+			mcp.Description("The ID of the streaming configuration"),
+		),
+	}
+	return mcp.NewTool("update_audit_log_streaming_configuration", append(options, auditLogStreamOptions("Whether the configuration streams. Defaults to its current state")...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			enterprise, err := requiredParam[string](request, "enterprise")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			streamID, err := RequiredInt(request, "stream_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			params, err := getAuditLogStreamParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The whole configuration is replaced, so keep the current state unless it is changed
+			var enabled bool
+			if params.enabled != nil {
+				enabled = *params.enabled
+			} else {
+				current, resp, err := getAuditLogStream(ctx, client, enterprise, streamID)
+				if err != nil {
+					if isGitHubErrorStatus(err, http.StatusNotFound) {
+						return mcp.NewToolResultError(auditLogStreamNotFound(enterprise, streamID)), nil
+					}
+					return nil, fmt.Errorf("failed to get audit log streaming configuration: %w", err)
+				}
+				_ = resp.Body.Close()
+				enabled = current.Enabled
+			}
+			config, err := newAuditLogStreamConfig(ctx, client, enterprise, params, enabled)
+			if err != nil {
+				return nil, err
+			}
+
+			stream := new(auditLogStream)
+			resp, err := doAuditLogStreamRequest(ctx, client, "PUT", fmt.Sprintf("enterprises/%v/audit-log/streams/%d", enterprise, streamID), config, stream)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(auditLogStreamNotFound(enterprise, streamID)), nil
+				}
+				if isGitHubErrorStatus(err, http.StatusUnprocessableEntity) {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to update audit log streaming configuration: GitHub couldn't connect to %s with the given connection details: %s", params.provider, err)), nil
+				}
+				return nil, fmt.Errorf("failed to update audit log streaming configuration: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update audit log streaming configuration: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(stream.summary())
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteAuditLogStreamingConfiguration creates a tool to stop streaming the audit log of an enterprise to a service.
+func DeleteAuditLogStreamingConfiguration(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_audit_log_streaming_configuration",
+			mcp.WithDescription(t("TOOL_DELETE_AUDIT_LOG_STREAMING_CONFIGURATION_DESCRIPTION", "Delete a configuration streaming the audit log of an enterprise, stopping the stream. Requires enterprise owner permissions")),
+			mcp.WithString("enterprise",
+				mcp.Required(),
+				mcp.Description("Enterprise slug"),
+			),
+			mcp.WithNumber("stream_id",
+				mcp.Required(),
+				mcp.Description("The ID of the streaming configuration"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			enterprise, err := requiredParam[string](request, "enterprise")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			streamID, err := RequiredInt(request, "stream_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := doAuditLogStreamRequest(ctx, client, "DELETE", fmt.Sprintf("enterprises/%v/audit-log/streams/%d", enterprise, streamID), nil, nil)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(auditLogStreamNotFound(enterprise, streamID)), nil
+				}
+				return nil, fmt.Errorf("failed to delete audit log streaming configuration: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete audit log streaming configuration: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Audit log streaming configuration %d of enterprise %s deleted", streamID, enterprise)), nil
+		}
+}
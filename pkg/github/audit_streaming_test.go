@@ -0,0 +1,727 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
+)
+
+var (
+	getEnterpriseAuditLogStreamKey = mock.EndpointPattern{
+		Pattern: "/enterprises/{enterprise}/audit-log/stream-key",
+		Method:  "GET",
+	}
+	getEnterpriseAuditLogStreams = mock.EndpointPattern{
+		Pattern: "/enterprises/{enterprise}/audit-log/streams",
+		Method:  "GET",
+	}
+	postEnterpriseAuditLogStreams = mock.EndpointPattern{
+		Pattern: "/enterprises/{enterprise}/audit-log/streams",
+		Method:  "POST",
+	}
+	getEnterpriseAuditLogStream = mock.EndpointPattern{
+		Pattern: "/enterprises/{enterprise}/audit-log/streams/{stream_id}",
+		Method:  "GET",
+	}
+	putEnterpriseAuditLogStream = mock.EndpointPattern{
+		Pattern: "/enterprises/{enterprise}/audit-log/streams/{stream_id}",
+		Method:  "PUT",
+	}
+	deleteEnterpriseAuditLogStream = mock.EndpointPattern{
+		Pattern: "/enterprises/{enterprise}/audit-log/streams/{stream_id}",
+		Method:  "DELETE",
+	}
+)
+
+// expectStreamConfig checks the body of a request creating or replacing an audit log stream, decrypting its
+// encrypted_token with privateKey, before responding with stream.
+func expectStreamConfig(t *testing.T, privateKey *[32]byte, publicKey *[32]byte, enabled bool, token string, stream interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var config struct {
+			Enabled        bool                   `json:"enabled"`
+			StreamType     string                 `json:"stream_type"`
+			VendorSpecific map[string]interface{} `json:"vendor_specific"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&config))
+		assert.Equal(t, enabled, config.Enabled)
+		assert.Equal(t, "Splunk", config.StreamType)
+		assert.Equal(t, "splunk.example.com", config.VendorSpecific["domain"])
+		assert.Equal(t, "stream-key-1", config.VendorSpecific["key_id"])
+
+		encrypted, _ := config.VendorSpecific["encrypted_token"].(string)
+		sealed, err := base64.StdEncoding.DecodeString(encrypted)
+		require.NoError(t, err)
+		decrypted, ok := box.OpenAnonymous(nil, sealed, publicKey, privateKey)
+		require.True(t, ok)
+		assert.Equal(t, token, string(decrypted))
+
+		mockResponse(t, http.StatusOK, stream)(w, r)
+	}
+}
+
+func Test_ListAuditLogStreamingConfigurations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListAuditLogStreamingConfigurations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_audit_log_streaming_configurations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "enterprise")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"enterprise"})
+
+	mockStreams := []map[string]interface{}{
+		{
+			"id":             1,
+			"stream_type":    "Splunk",
+			"stream_details": "splunk.example.com",
+			"enabled":        true,
+			"created_at":     "2025-01-01T00:00:00Z",
+			"updated_at":     "2025-01-02T00:00:00Z",
+			"paused_at":      nil,
+		},
+		{
+			"id":             2,
+			"stream_type":    "Amazon S3",
+			"stream_details": "audit-bucket",
+			"enabled":        false,
+			"created_at":     "2025-01-01T00:00:00Z",
+			"updated_at":     "2025-01-03T00:00:00Z",
+			"paused_at":      "2025-01-03T00:00:00Z",
+		},
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedProviders []string
+	}{
+		{
+			name: "streaming configurations",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					getEnterpriseAuditLogStreams,
+					mockStreams,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+			},
+			expectError:       false,
+			expectedProviders: []string{"Splunk", "Amazon S3"},
+		},
+		{
+			name: "not an enterprise owner",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getEnterpriseAuditLogStreams,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must be an enterprise owner"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list audit log streaming configurations",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListAuditLogStreamingConfigurations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned []map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			require.Len(t, returned, len(tc.expectedProviders))
+			for i, provider := range tc.expectedProviders {
+				assert.Equal(t, provider, returned[i]["provider"])
+				assert.Contains(t, returned[i], "details")
+			}
+		})
+	}
+}
+
+func Test_GetAuditLogStreamingConfiguration(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetAuditLogStreamingConfiguration(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_audit_log_streaming_configuration", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "enterprise")
+	assert.Contains(t, tool.InputSchema.Properties, "stream_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"enterprise", "stream_id"})
+
+	mockStream := map[string]interface{}{
+		"id":             1,
+		"stream_type":    "Datadog",
+		"stream_details": "US",
+		"enabled":        true,
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "streaming configuration",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					getEnterpriseAuditLogStream,
+					mockStream,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"stream_id":  float64(1),
+			},
+			expectError: false,
+		},
+		{
+			name: "streaming configuration not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getEnterpriseAuditLogStream,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"stream_id":  float64(9),
+			},
+			expectError:    false,
+			expectedErrMsg: "audit log streaming configuration 9 not found in enterprise acme",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetAuditLogStreamingConfiguration(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, float64(1), returned["id"])
+			assert.Equal(t, "Datadog", returned["provider"])
+			assert.Equal(t, true, returned["enabled"])
+			assert.Equal(t, "US", returned["details"])
+		})
+	}
+}
+
+func Test_CheckAuditLogStreamingConfiguration(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CheckAuditLogStreamingConfiguration(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "check_audit_log_streaming_configuration", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "enterprise")
+	assert.Contains(t, tool.InputSchema.Properties, "stream_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"enterprise", "stream_id"})
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedPaused    bool
+		expectedStreaming bool
+	}{
+		{
+			name: "streaming",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					getEnterpriseAuditLogStream,
+					map[string]interface{}{"id": 1, "stream_type": "Splunk", "enabled": true},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"stream_id":  float64(1),
+			},
+			expectError:       false,
+			expectedPaused:    false,
+			expectedStreaming: true,
+		},
+		{
+			name: "paused",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					getEnterpriseAuditLogStream,
+					map[string]interface{}{"id": 1, "stream_type": "Splunk", "enabled": true, "paused_at": time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"stream_id":  float64(1),
+			},
+			expectError:       false,
+			expectedPaused:    true,
+			expectedStreaming: false,
+		},
+		{
+			name: "streaming configuration not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getEnterpriseAuditLogStream,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"stream_id":  float64(9),
+			},
+			expectError:    false,
+			expectedErrMsg: "audit log streaming configuration 9 not found in enterprise acme",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CheckAuditLogStreamingConfiguration(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPaused, returned["paused"])
+			assert.Equal(t, tc.expectedStreaming, returned["streaming"])
+		})
+	}
+}
+
+func Test_CreateAuditLogStreamingConfiguration(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateAuditLogStreamingConfiguration(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_audit_log_streaming_configuration", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "enterprise")
+	assert.Contains(t, tool.InputSchema.Properties, "provider")
+	assert.Contains(t, tool.InputSchema.Properties, "connection")
+	assert.Contains(t, tool.InputSchema.Properties, "enabled")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"enterprise", "provider", "connection"})
+
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	mockKey := map[string]string{
+		"key_id": "stream-key-1",
+		"key":    base64.StdEncoding.EncodeToString(publicKey[:]),
+	}
+	mockStream := map[string]interface{}{
+		"id":             3,
+		"stream_type":    "Splunk",
+		"stream_details": "splunk.example.com",
+		"enabled":        true,
+	}
+	connection := map[string]interface{}{
+		"domain":          "splunk.example.com",
+		"port":            float64(443),
+		"encrypted_token": "hec-token",
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "secrets are encrypted with the stream key",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					getEnterpriseAuditLogStreamKey,
+					mockKey,
+				),
+				mock.WithRequestMatchHandler(
+					postEnterpriseAuditLogStreams,
+					expectStreamConfig(t, privateKey, publicKey, true, "hec-token", mockStream),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"provider":   "Splunk",
+				"connection": connection,
+			},
+			expectError: false,
+		},
+		{
+			name: "unknown provider",
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"provider":   "Sumo Logic",
+				"connection": connection,
+			},
+			expectError:    false,
+			expectedErrMsg: "provider must be one of",
+		},
+		{
+			name: "missing connection",
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"provider":   "Splunk",
+			},
+			expectError:    false,
+			expectedErrMsg: "missing required parameter: connection",
+		},
+		{
+			name: "provider unreachable",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					getEnterpriseAuditLogStreamKey,
+					mockKey,
+				),
+				mock.WithRequestMatchHandler(
+					postEnterpriseAuditLogStreams,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"provider":   "Splunk",
+				"connection": connection,
+			},
+			expectError:    false,
+			expectedErrMsg: "GitHub couldn't connect to Splunk with the given connection details",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateAuditLogStreamingConfiguration(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, float64(3), returned["id"])
+			assert.Equal(t, "Splunk", returned["provider"])
+		})
+	}
+}
+
+func Test_UpdateAuditLogStreamingConfiguration(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateAuditLogStreamingConfiguration(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_audit_log_streaming_configuration", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "stream_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"enterprise", "stream_id", "provider", "connection"})
+
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	mockKey := map[string]string{
+		"key_id": "stream-key-1",
+		"key":    base64.StdEncoding.EncodeToString(publicKey[:]),
+	}
+	disabledStream := map[string]interface{}{
+		"id":          3,
+		"stream_type": "Splunk",
+		"enabled":     false,
+	}
+	connection := map[string]interface{}{
+		"domain":          "splunk.example.com",
+		"encrypted_token": "rotated-token",
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "current state is kept",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					getEnterpriseAuditLogStream,
+					disabledStream,
+				),
+				mock.WithRequestMatch(
+					getEnterpriseAuditLogStreamKey,
+					mockKey,
+				),
+				mock.WithRequestMatchHandler(
+					putEnterpriseAuditLogStream,
+					expectStreamConfig(t, privateKey, publicKey, false, "rotated-token", disabledStream),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"stream_id":  float64(3),
+				"provider":   "Splunk",
+				"connection": connection,
+			},
+			expectError: false,
+		},
+		{
+			name: "re-enabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					getEnterpriseAuditLogStreamKey,
+					mockKey,
+				),
+				mock.WithRequestMatchHandler(
+					putEnterpriseAuditLogStream,
+					expectStreamConfig(t, privateKey, publicKey, true, "rotated-token", disabledStream),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"stream_id":  float64(3),
+				"provider":   "Splunk",
+				"connection": connection,
+				"enabled":    true,
+			},
+			expectError: false,
+		},
+		{
+			name: "streaming configuration not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getEnterpriseAuditLogStream,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"stream_id":  float64(9),
+				"provider":   "Splunk",
+				"connection": connection,
+			},
+			expectError:    false,
+			expectedErrMsg: "audit log streaming configuration 9 not found in enterprise acme",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateAuditLogStreamingConfiguration(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, float64(3), returned["id"])
+		})
+	}
+}
+
+func Test_DeleteAuditLogStreamingConfiguration(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteAuditLogStreamingConfiguration(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_audit_log_streaming_configuration", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "enterprise")
+	assert.Contains(t, tool.InputSchema.Properties, "stream_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"enterprise", "stream_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "streaming configuration deleted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					deleteEnterpriseAuditLogStream,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"stream_id":  float64(3),
+			},
+			expectError:  false,
+			expectedText: "Audit log streaming configuration 3 of enterprise acme deleted",
+		},
+		{
+			name: "streaming configuration not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					deleteEnterpriseAuditLogStream,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"stream_id":  float64(9),
+			},
+			expectError:    false,
+			expectedErrMsg: "audit log streaming configuration 9 not found in enterprise acme",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteAuditLogStreamingConfiguration(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
@@ -0,0 +1,536 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// requiredStatusChecksSummary returns the fields of a branch's required status checks protection that matter to callers.
+func requiredStatusChecksSummary(checks *github.RequiredStatusChecks) map[string]interface{} {
+	contexts := []string{}
+	if checks.Contexts != nil {
+		contexts = *checks.Contexts
+	}
+	summary := make([]map[string]interface{}, 0, len(currentStatusChecks(checks)))
+	for _, check := range currentStatusChecks(checks) {
+		summary = append(summary, map[string]interface{}{
+			"context": check.Context,
+			"app_id":  check.AppID,
+		})
+	}
+	return map[string]interface{}{
+		"strict":   checks.Strict,
+		"contexts": contexts,
+		"checks":   summary,
+	}
+}
+
+// currentStatusChecks returns the required checks of a protected branch. Branches protected
+// before checks could be tied to an app only list contexts, which are converted to checks.
+func currentStatusChecks(checks *github.RequiredStatusChecks) []*github.RequiredStatusCheck {
+	if checks.Checks != nil {
+		return *checks.Checks
+	}
+	if checks.Contexts == nil {
+		return nil
+	}
+	converted := make([]*github.RequiredStatusCheck, 0, len(*checks.Contexts))
+	for _, c := range *checks.Contexts {
+		converted = append(converted, &github.RequiredStatusCheck{Context: c})
+	}
+	return converted
+}
+
+// requiredStatusChecksUpdate is the body of a request to update the required status checks of a branch.
+// Unlike github.RequiredStatusChecksRequest it always sends checks, so that every check can be removed.
+type requiredStatusChecksUpdate struct {
+	Strict *bool                         `json:"strict,omitempty"`
+	Checks []*github.RequiredStatusCheck `json:"checks"`
+}
+
+// updateRequiredStatusChecks replaces the required status checks of a protected branch.
+func updateRequiredStatusChecks(ctx context.Context, client *github.Client, owner, repo, branch string, update *requiredStatusChecksUpdate) (*github.RequiredStatusChecks, *github.Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/branches/%v/protection/required_status_checks", owner, repo, url.PathEscape(branch))
+	req, err := client.NewRequest("PATCH", u, update)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	checks := new(github.RequiredStatusChecks)
+	resp, err := client.Do(ctx, req, checks)
+	if err != nil {
+		return nil, resp, err
+	}
+	return checks, resp, nil
+}
+
+// getRequiredStatusChecks fetches the required status checks of a protected branch. A branch
+// without them is reported as a tool error result rather than an error.
+func getRequiredStatusChecks(ctx context.Context, client *github.Client, owner, repo, branch string) (*github.RequiredStatusChecks, *mcp.CallToolResult, error) {
+	checks, resp, err := client.Repositories.GetRequiredStatusChecks(ctx, owner, repo, branch)
+	if err != nil {
+		// go-github reports a 404 for an unprotected branch as ErrBranchNotProtected
+		if errors.Is(err, github.ErrBranchNotProtected) || isGitHubErrorStatus(err, http.StatusNotFound) {
+			return nil, mcp.NewToolResultError(fmt.Sprintf("branch %s of %s/%s is not protected or does not require status checks", branch, owner, repo)), nil
+		}
+		return nil, nil, fmt.Errorf("failed to get required status checks: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, mcp.NewToolResultError(fmt.Sprintf("failed to get required status checks: %s", string(body))), nil
+	}
+	return checks, nil, nil
+}
+
+// marshalRequiredStatusChecks builds the result of the required status checks tools.
+func marshalRequiredStatusChecks(checks *github.RequiredStatusChecks) (*mcp.CallToolResult, error) {
+	r, err := json.Marshal(requiredStatusChecksSummary(checks))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return mcp.NewToolResultText(string(r)), nil
+}
+
+// ListRequiredStatusChecks creates a tool to list the status checks required on a protected branch.
+func ListRequiredStatusChecks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_required_status_checks",
+			mcp.WithDescription(t("TOOL_LIST_REQUIRED_STATUS_CHECKS_DESCRIPTION", "List the status checks that must pass before merging into a protected branch, and whether the branch must be up to date")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Name of the protected branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := requiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			checks, toolErr, err := getRequiredStatusChecks(ctx, client, owner, repo, branch)
+			if toolErr != nil || err != nil {
+				return toolErr, err
+			}
+
+			return marshalRequiredStatusChecks(checks)
+		}
+}
+
+// SetRequiredStatusChecks creates a tool to replace the status checks required on a protected branch.
+func SetRequiredStatusChecks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_required_status_checks",
+			mcp.WithDescription(t("TOOL_SET_REQUIRED_STATUS_CHECKS_DESCRIPTION", "Replace the status checks that must pass before merging into a protected branch")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Name of the protected branch"),
+			),
+			mcp.WithBoolean("strict",
+				mcp.Description("Require branches to be up to date with the base branch before merging"),
+			),
+			mcp.WithArray("checks",
+				mcp.Required(),
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"context"},
+						"properties": map[string]interface{}{
+							"context": map[string]interface{}{
+								"type":        "string",
+								"description": "name of the required check",
+							},
+							"app_id": map[string]interface{}{
+								"type":        "number",
+								"description": "ID of the GitHub App that must provide the check, -1 to allow any app",
+							},
+						},
+					}),
+				mcp.Description("The complete list of required checks, each object with context (string) and an optional app_id (number). An empty list removes every required check"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := requiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			update := &requiredStatusChecksUpdate{Checks: []*github.RequiredStatusCheck{}}
+			if strict, ok, err := OptionalParamOK[bool](request, "strict"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				update.Strict = &strict
+			}
+
+			checksObj, ok := request.Params.Arguments["checks"].([]interface{})
+			if !ok {
+				return mcp.NewToolResultError("checks parameter must be an array of objects with context and an optional app_id"), nil
+			}
+			for _, c := range checksObj {
+				checkMap, ok := c.(map[string]interface{})
+				if !ok {
+					return mcp.NewToolResultError("each check must be an object with context and an optional app_id"), nil
+				}
+				checkContext, ok := checkMap["context"].(string)
+				if !ok || checkContext == "" {
+					return mcp.NewToolResultError("each check must have a context"), nil
+				}
+				check := &github.RequiredStatusCheck{Context: checkContext}
+				if appID, ok := checkMap["app_id"].(float64); ok {
+					check.AppID = github.Ptr(int64(appID))
+				}
+				update.Checks = append(update.Checks, check)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			checks, resp, err := updateRequiredStatusChecks(ctx, client, owner, repo, branch, update)
+			if err != nil {
+				return nil, fmt.Errorf("failed to set required status checks: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return marshalRequiredStatusChecks(checks)
+		}
+}
+
+// requiredStatusCheckOptions returns the parameters selecting a required status check of a protected branch.
+func requiredStatusCheckOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		),
+		mcp.WithString("branch",
+			mcp.Required(),
+			mcp.Description("Name of the protected branch"),
+		),
+		mcp.WithString("context",
+			mcp.Required(),
+			mcp.Description("Name of the status check"),
+		),
+	}
+}
+
+// requiredStatusCheckParams returns the parameters selecting a required status check of a protected branch.
+func requiredStatusCheckParams(request mcp.CallToolRequest) (owner, repo, branch, checkContext string, err error) {
+	if owner, err = requiredParam[string](request, "owner"); err != nil {
+		return "", "", "", "", err
+	}
+	if repo, err = requiredParam[string](request, "repo"); err != nil {
+		return "", "", "", "", err
+	}
+	if branch, err = requiredParam[string](request, "branch"); err != nil {
+		return "", "", "", "", err
+	}
+	if checkContext, err = requiredParam[string](request, "context"); err != nil {
+		return "", "", "", "", err
+	}
+	return owner, repo, branch, checkContext, nil
+}
+
+// otherStatusChecks returns the checks of an update keeping the required checks of a branch other than
+// checkContext, and whether checkContext is one of them.
+func otherStatusChecks(current *github.RequiredStatusChecks, checkContext string) (*requiredStatusChecksUpdate, bool) {
+	update := &requiredStatusChecksUpdate{Checks: []*github.RequiredStatusCheck{}}
+	found := false
+	for _, c := range currentStatusChecks(current) {
+		if c.Context == checkContext {
+			found = true
+			continue
+		}
+		update.Checks = append(update.Checks, c)
+	}
+	return update, found
+}
+
+// AddRequiredStatusCheck creates a tool to require one more status check on a protected branch, keeping the
+// other checks of the branch as they are.
+func AddRequiredStatusCheck(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := append([]mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_ADD_REQUIRED_STATUS_CHECK_DESCRIPTION", "Require a status check to pass before merging into a protected branch, keeping the existing required checks")),
+	}, requiredStatusCheckOptions()...)
+	options = append(options, mcp.WithNumber("app_id",
+		mcp.Description("ID of the GitHub App that must provide the check, -1 to allow any app"),
+	))
+
+	return mcp.NewTool("add_required_status_check", options...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, branch, checkContext, err := requiredStatusCheckParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			check := &github.RequiredStatusCheck{Context: checkContext}
+			if appID, ok, err := OptionalParamOK[float64](request, "app_id"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				check.AppID = github.Ptr(int64(appID))
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			current, toolErr, err := getRequiredStatusChecks(ctx, client, owner, repo, branch)
+			if toolErr != nil || err != nil {
+				return toolErr, err
+			}
+
+			// A check already required is replaced, so its app can be changed
+			update, _ := otherStatusChecks(current, checkContext)
+			update.Checks = append(update.Checks, check)
+
+			checks, resp, err := updateRequiredStatusChecks(ctx, client, owner, repo, branch, update)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update required status checks: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return marshalRequiredStatusChecks(checks)
+		}
+}
+
+// RemoveRequiredStatusCheck creates a tool to stop requiring a status check on a protected branch, keeping the
+// other checks of the branch as they are.
+func RemoveRequiredStatusCheck(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := append([]mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_REMOVE_REQUIRED_STATUS_CHECK_DESCRIPTION", "Stop requiring a status check to pass before merging into a protected branch, keeping the other required checks")),
+	}, requiredStatusCheckOptions()...)
+
+	return mcp.NewTool("remove_required_status_check", options...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, branch, checkContext, err := requiredStatusCheckParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			current, toolErr, err := getRequiredStatusChecks(ctx, client, owner, repo, branch)
+			if toolErr != nil || err != nil {
+				return toolErr, err
+			}
+
+			update, found := otherStatusChecks(current, checkContext)
+			if !found {
+				return mcp.NewToolResultError(fmt.Sprintf("status check %s is not required on branch %s", checkContext, branch)), nil
+			}
+
+			checks, resp, err := updateRequiredStatusChecks(ctx, client, owner, repo, branch, update)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update required status checks: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return marshalRequiredStatusChecks(checks)
+		}
+}
+
+// deploymentReviewerTeams returns the slugs of the teams that must approve deployments to an environment,
+// or nil if the environment doesn't exist.
+func deploymentReviewerTeams(ctx context.Context, client *github.Client, owner, repo, environment string) ([]string, error) {
+	env, resp, err := client.Repositories.GetEnvironment(ctx, owner, repo, environment)
+	if err != nil {
+		if isGitHubErrorStatus(err, http.StatusNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get environment %s: %w", environment, err)
+	}
+	_ = resp.Body.Close()
+
+	teams := []string{}
+	for _, rule := range env.ProtectionRules {
+		for _, reviewer := range rule.Reviewers {
+			if team, ok := reviewer.Reviewer.(*github.Team); ok {
+				teams = append(teams, team.GetSlug())
+			}
+		}
+	}
+	return teams, nil
+}
+
+// GetRequiredReviewers creates a tool to get the reviews branch protection and rulesets require to merge into a branch.
+func GetRequiredReviewers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_required_reviewers",
+			mcp.WithDescription(t("TOOL_GET_REQUIRED_REVIEWERS_DESCRIPTION", "Get the reviews required to merge into a branch, combining its classic branch protection with the rulesets that apply to it: how many approving reviews are required, whether code owners must review, and the teams that must approve the deployments the branch requires. Reading classic protection requires admin access; without it only rulesets are considered")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := requiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			requiredApprovals := 0
+			codeOwnerReview := false
+			sources := []map[string]interface{}{}
+
+			// Without admin access, reading the protection of a branch fails as if the repository didn't exist
+			classicVisible := true
+			var errorResponse *github.ErrorResponse
+			protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			switch {
+			case errors.Is(err, github.ErrBranchNotProtected):
+			case errors.As(err, &errorResponse) && errorResponse.Message == "Branch not found":
+				return mcp.NewToolResultError(fmt.Sprintf("branch %s not found in %s/%s", branch, owner, repo)), nil
+			case isGitHubErrorStatus(err, http.StatusNotFound) || isGitHubErrorStatus(err, http.StatusForbidden):
+				classicVisible = false
+			case err != nil:
+				return nil, fmt.Errorf("failed to get branch protection: %w", err)
+			default:
+				_ = resp.Body.Close()
+				if reviews := protection.RequiredPullRequestReviews; reviews != nil {
+					requiredApprovals = reviews.RequiredApprovingReviewCount
+					codeOwnerReview = reviews.RequireCodeOwnerReviews
+					sources = append(sources, map[string]interface{}{
+						"source":                          "classic_protection",
+						"required_approving_review_count": reviews.RequiredApprovingReviewCount,
+						"require_code_owner_review":       reviews.RequireCodeOwnerReviews,
+					})
+				}
+			}
+
+			rules, resp, err := client.Repositories.GetRulesForBranch(ctx, owner, repo, branch)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get rules for branch: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			// Every rule applying to the branch is enforced, so the strictest requirement wins
+			for _, rule := range rules.PullRequest {
+				requiredApprovals = max(requiredApprovals, rule.Parameters.RequiredApprovingReviewCount)
+				codeOwnerReview = codeOwnerReview || rule.Parameters.RequireCodeOwnerReview
+				sources = append(sources, map[string]interface{}{
+					"source":                          "ruleset",
+					"ruleset_id":                      rule.RulesetID,
+					"ruleset_source":                  rule.RulesetSource,
+					"required_approving_review_count": rule.Parameters.RequiredApprovingReviewCount,
+					"require_code_owner_review":       rule.Parameters.RequireCodeOwnerReview,
+				})
+			}
+
+			deployments := []map[string]interface{}{}
+			seen := map[string]bool{}
+			for _, rule := range rules.RequiredDeployments {
+				for _, environment := range rule.Parameters.RequiredDeploymentEnvironments {
+					if seen[environment] {
+						continue
+					}
+					seen[environment] = true
+					teams, err := deploymentReviewerTeams(ctx, client, owner, repo, environment)
+					if err != nil {
+						return nil, err
+					}
+					deployment := map[string]interface{}{
+						"environment":    environment,
+						"reviewer_teams": teams,
+					}
+					if teams == nil {
+						deployment["reviewer_teams"] = []string{}
+						deployment["message"] = "environment not found"
+					}
+					deployments = append(deployments, deployment)
+				}
+			}
+
+			result := map[string]interface{}{
+				"branch":                          branch,
+				"required_approving_review_count": requiredApprovals,
+				"require_code_owner_review":       codeOwnerReview,
+				"required_deployments":            deployments,
+				"sources":                         sources,
+				"classic_protection_visible":      classicVisible,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,725 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusChecksResult is the result of the required status checks tools.
+type statusChecksResult struct {
+	Strict   bool     `json:"strict"`
+	Contexts []string `json:"contexts"`
+	Checks   []struct {
+		Context string `json:"context"`
+		AppID   *int64 `json:"app_id"`
+	} `json:"checks"`
+}
+
+// checkContexts returns the contexts of the checks in a required status checks result.
+func (r statusChecksResult) checkContexts() []string {
+	contexts := []string{}
+	for _, c := range r.Checks {
+		contexts = append(contexts, c.Context)
+	}
+	return contexts
+}
+
+func Test_ListRequiredStatusChecks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRequiredStatusChecks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_required_status_checks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	mockChecks := &github.RequiredStatusChecks{
+		Strict:   true,
+		Contexts: &[]string{"ci/build", "lint"},
+		Checks: &[]*github.RequiredStatusCheck{
+			{Context: "ci/build", AppID: github.Ptr(int64(15368))},
+			{Context: "lint"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "protected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					mockChecks,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectError: false,
+		},
+		{
+			name: "branch not protected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Required status checks not enabled"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "feature",
+			},
+			expectError:    false,
+			expectedErrMsg: "branch feature of owner/repo is not protected or does not require status checks",
+		},
+		{
+			name: "request fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get required status checks",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRequiredStatusChecks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returned statusChecksResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.True(t, returned.Strict)
+			assert.Equal(t, []string{"ci/build", "lint"}, returned.Contexts)
+			require.Len(t, returned.Checks, 2)
+			assert.Equal(t, "ci/build", returned.Checks[0].Context)
+			assert.Equal(t, github.Ptr(int64(15368)), returned.Checks[0].AppID)
+			assert.Equal(t, "lint", returned.Checks[1].Context)
+			assert.Nil(t, returned.Checks[1].AppID)
+		})
+	}
+}
+
+func Test_SetRequiredStatusChecks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetRequiredStatusChecks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_required_status_checks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "strict")
+	assert.Contains(t, tool.InputSchema.Properties, "checks")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "checks"})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedContexts []string
+	}{
+		{
+			name: "replace checks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]interface{}{
+						"strict": true,
+						"checks": []interface{}{
+							map[string]interface{}{"context": "ci/build", "app_id": float64(15368)},
+							map[string]interface{}{"context": "lint"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.RequiredStatusChecks{
+							Strict: true,
+							Checks: &[]*github.RequiredStatusCheck{
+								{Context: "ci/build", AppID: github.Ptr(int64(15368))},
+								{Context: "lint"},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"strict": true,
+				"checks": []interface{}{
+					map[string]interface{}{"context": "ci/build", "app_id": float64(15368)},
+					map[string]interface{}{"context": "lint"},
+				},
+			},
+			expectError:      false,
+			expectedContexts: []string{"ci/build", "lint"},
+		},
+		{
+			name: "remove every check",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]interface{}{
+						"checks": []interface{}{},
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.RequiredStatusChecks{
+							Checks: &[]*github.RequiredStatusCheck{},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"checks": []interface{}{},
+			},
+			expectError:      false,
+			expectedContexts: []string{},
+		},
+		{
+			name:         "check without context",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"checks": []interface{}{
+					map[string]interface{}{"app_id": float64(1)},
+				},
+			},
+			expectError:    false,
+			expectedErrMsg: "each check must have a context",
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Branch not protected"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"checks": []interface{}{
+					map[string]interface{}{"context": "lint"},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to set required status checks",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetRequiredStatusChecks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returned statusChecksResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedContexts, returned.checkContexts())
+		})
+	}
+}
+
+func Test_AddRequiredStatusCheck(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddRequiredStatusCheck(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_required_status_check", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "context")
+	assert.Contains(t, tool.InputSchema.Properties, "app_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "context"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "add to legacy contexts",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					&github.RequiredStatusChecks{
+						Strict:   true,
+						Contexts: &[]string{"ci/build"},
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]interface{}{
+						"checks": []interface{}{
+							map[string]interface{}{"context": "ci/build"},
+							map[string]interface{}{"context": "security/scan", "app_id": float64(-1)},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.RequiredStatusChecks{
+							Strict: true,
+							Checks: &[]*github.RequiredStatusCheck{
+								{Context: "ci/build"},
+								{Context: "security/scan", AppID: github.Ptr(int64(-1))},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"context": "security/scan",
+				"app_id":  float64(-1),
+			},
+			expectError: false,
+		},
+		{
+			name: "branch not protected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Branch not protected"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "feature",
+				"context": "security/scan",
+			},
+			expectError:    false,
+			expectedErrMsg: "branch feature of owner/repo is not protected",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddRequiredStatusCheck(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returned statusChecksResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"ci/build", "security/scan"}, returned.checkContexts())
+		})
+	}
+}
+
+func Test_RemoveRequiredStatusCheck(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveRequiredStatusCheck(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "remove_required_status_check", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "context")
+	assert.NotContains(t, tool.InputSchema.Properties, "app_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "context"})
+
+	mockChecks := &github.RequiredStatusChecks{
+		Checks: &[]*github.RequiredStatusCheck{
+			{Context: "ci/build", AppID: github.Ptr(int64(15368))},
+			{Context: "lint"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "remove one check",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					mockChecks,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]interface{}{
+						"checks": []interface{}{
+							map[string]interface{}{"context": "ci/build", "app_id": float64(15368)},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.RequiredStatusChecks{
+							Checks: &[]*github.RequiredStatusCheck{
+								{Context: "ci/build", AppID: github.Ptr(int64(15368))},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"context": "lint",
+			},
+			expectError: false,
+		},
+		{
+			name: "check not required",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					mockChecks,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"context": "deploy",
+			},
+			expectError:    false,
+			expectedErrMsg: "status check deploy is not required on branch main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RemoveRequiredStatusCheck(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returned statusChecksResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"ci/build"}, returned.checkContexts())
+		})
+	}
+}
+
+func Test_GetRequiredReviewers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRequiredReviewers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_required_reviewers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	notProtected := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Branch not protected"}`))
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name: "classic protection",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					&github.Protection{
+						RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+							RequiredApprovingReviewCount: 2,
+							RequireCodeOwnerReviews:      true,
+						},
+					},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+					[]interface{}{},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"branch":                          "main",
+				"required_approving_review_count": float64(2),
+				"require_code_owner_review":       true,
+				"required_deployments":            []interface{}{},
+				"sources": []interface{}{
+					map[string]interface{}{
+						"source":                          "classic_protection",
+						"required_approving_review_count": float64(2),
+						"require_code_owner_review":       true,
+					},
+				},
+				"classic_protection_visible": true,
+			},
+		},
+		{
+			name: "ruleset with required deployments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					notProtected,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+					[]map[string]interface{}{
+						{
+							"type":                "pull_request",
+							"ruleset_source_type": "Organization",
+							"ruleset_source":      "owner",
+							"ruleset_id":          7,
+							"parameters": map[string]interface{}{
+								"required_approving_review_count": 1,
+								"require_code_owner_review":       false,
+							},
+						},
+						{
+							"type":                "required_deployments",
+							"ruleset_source_type": "Repository",
+							"ruleset_source":      "owner/repo",
+							"ruleset_id":          42,
+							"parameters": map[string]interface{}{
+								"required_deployment_environments": []string{"staging"},
+							},
+						},
+					},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					map[string]interface{}{
+						"name": "staging",
+						"protection_rules": []map[string]interface{}{
+							{
+								"type": "required_reviewers",
+								"reviewers": []map[string]interface{}{
+									{"type": "Team", "reviewer": map[string]interface{}{"slug": "release-managers"}},
+									{"type": "User", "reviewer": map[string]interface{}{"login": "octocat"}},
+								},
+							},
+						},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"branch":                          "main",
+				"required_approving_review_count": float64(1),
+				"require_code_owner_review":       false,
+				"required_deployments": []interface{}{
+					map[string]interface{}{
+						"environment":    "staging",
+						"reviewer_teams": []interface{}{"release-managers"},
+					},
+				},
+				"sources": []interface{}{
+					map[string]interface{}{
+						"source":                          "ruleset",
+						"ruleset_id":                      float64(7),
+						"ruleset_source":                  "owner",
+						"required_approving_review_count": float64(1),
+						"require_code_owner_review":       false,
+					},
+				},
+				"classic_protection_visible": true,
+			},
+		},
+		{
+			name: "branch not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Branch not found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "branch missing not found in owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRequiredReviewers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
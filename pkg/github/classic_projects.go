@@ -0,0 +1,751 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// mediaTypeClassicProjectsPreview is the media type older GitHub Enterprise Server versions require for classic projects.
+const mediaTypeClassicProjectsPreview = "application/vnd.github.inertia-preview+json"
+
+// classicProject is a classic project board. go-github dropped classic projects once GitHub.com retired them,
+// so the requests are built here for the GitHub Enterprise Server versions that still have them.
+type classicProject struct {
+	ID        int64             `json:"id"`
+	Number    int               `json:"number"`
+	Name      string            `json:"name"`
+	Body      string            `json:"body"`
+	State     string            `json:"state"`
+	HTMLURL   string            `json:"html_url"`
+	Creator   *classicUser      `json:"creator,omitempty"`
+	CreatedAt *github.Timestamp `json:"created_at"`
+	UpdatedAt *github.Timestamp `json:"updated_at"`
+}
+
+// classicUser is the part of a user returned with classic projects and cards that tools keep.
+type classicUser struct {
+	Login string `json:"login"`
+}
+
+// projectColumn is a column of a classic project board.
+type projectColumn struct {
+	ID        int64             `json:"id"`
+	Name      string            `json:"name"`
+	CreatedAt *github.Timestamp `json:"created_at"`
+	UpdatedAt *github.Timestamp `json:"updated_at"`
+}
+
+// projectCard is a card of a classic project board: a note, or an issue or pull request linked by its content URL.
+type projectCard struct {
+	ID         int64             `json:"id"`
+	Note       string            `json:"note,omitempty"`
+	ContentURL string            `json:"content_url,omitempty"`
+	Archived   bool              `json:"archived"`
+	Creator    *classicUser      `json:"creator,omitempty"`
+	CreatedAt  *github.Timestamp `json:"created_at"`
+	UpdatedAt  *github.Timestamp `json:"updated_at"`
+}
+
+// classicProjectsGoneMessage explains a classic projects request that failed because the API is retired.
+const classicProjectsGoneMessage = "classic projects are not available on this server: GitHub.com retired them in favor of Projects, they remain only on older GitHub Enterprise Server versions"
+
+// doClassicProjectRequest sends a classic projects request, decoding the response into v when it is set. It returns
+// a tool result when the request failed in a way the caller can fix, such as a missing project, and nil on success.
+func doClassicProjectRequest(ctx context.Context, client *github.Client, method, u string, body, v interface{}, expectedStatus int, action string) (*mcp.CallToolResult, error) {
+	req, err := client.NewRequest(method, u, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", mediaTypeClassicProjectsPreview)
+
+	resp, err := client.Do(ctx, req, v)
+	if err != nil {
+		switch {
+		case isGitHubErrorStatus(err, http.StatusGone):
+			return mcp.NewToolResultError(classicProjectsGoneMessage), nil
+		case isGitHubErrorStatus(err, http.StatusNotFound), isGitHubErrorStatus(err, http.StatusUnprocessableEntity):
+			return mcp.NewToolResultError(fmt.Sprintf("failed to %s: %s", action, err)), nil
+		}
+		return nil, fmt.Errorf("failed to %s: %w", action, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != expectedStatus {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("failed to %s: %s", action, string(body))), nil
+	}
+	return nil, nil
+}
+
+// marshalToolResult returns v as the JSON text of a tool result.
+func marshalToolResult(v interface{}) (*mcp.CallToolResult, error) {
+	r, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return mcp.NewToolResultText(string(r)), nil
+}
+
+// validProjectPosition reports whether position is first or last, or after:<id> for the ID of another card or column.
+func validProjectPosition(position, first, last string) bool {
+	if position == first || position == last {
+		return true
+	}
+	id, ok := strings.CutPrefix(position, "after:")
+	if !ok {
+		return false
+	}
+	_, err := strconv.ParseInt(id, 10, 64)
+	return err == nil
+}
+
+// ListRepoProjects creates a tool to list the classic projects of a repository.
+func ListRepoProjects(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repo_projects",
+			mcp.WithDescription(t("TOOL_LIST_REPO_PROJECTS_DESCRIPTION", "List the classic project boards of a repository")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter by state ('open', 'closed', 'all'), defaults to open"),
+				mcp.Enum("open", "closed", "all"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			query := url.Values{
+				"page":     []string{strconv.Itoa(pagination.page)},
+				"per_page": []string{strconv.Itoa(pagination.perPage)},
+			}
+			if state != "" {
+				query.Set("state", state)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			projects := []*classicProject{}
+			u := fmt.Sprintf("repos/%v/%v/projects?%s", owner, repo, query.Encode())
+			if result, err := doClassicProjectRequest(ctx, client, "GET", u, nil, &projects, http.StatusOK, "list projects"); result != nil || err != nil {
+				return result, err
+			}
+
+			return marshalToolResult(projects)
+		}
+}
+
+// GetProject creates a tool to get a classic project.
+func GetProject(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_project",
+			mcp.WithDescription(t("TOOL_GET_PROJECT_DESCRIPTION", "Get a classic project board")),
+			mcp.WithNumber("project_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the project, as returned by list_repo_projects"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			projectID, err := RequiredInt(request, "project_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			project := new(classicProject)
+			if result, err := doClassicProjectRequest(ctx, client, "GET", fmt.Sprintf("projects/%d", projectID), nil, project, http.StatusOK, "get project"); result != nil || err != nil {
+				return result, err
+			}
+
+			return marshalToolResult(project)
+		}
+}
+
+// ListProjectColumns creates a tool to list the columns of a classic project.
+func ListProjectColumns(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_project_columns",
+			mcp.WithDescription(t("TOOL_LIST_PROJECT_COLUMNS_DESCRIPTION", "List the columns of a classic project board, in board order")),
+			mcp.WithNumber("project_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the project"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			projectID, err := RequiredInt(request, "project_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			columns := []*projectColumn{}
+			u := fmt.Sprintf("projects/%d/columns?page=%d&per_page=%d", projectID, pagination.page, pagination.perPage)
+			if result, err := doClassicProjectRequest(ctx, client, "GET", u, nil, &columns, http.StatusOK, "list project columns"); result != nil || err != nil {
+				return result, err
+			}
+
+			return marshalToolResult(columns)
+		}
+}
+
+// ListProjectCards creates a tool to list the cards of a classic project column.
+func ListProjectCards(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_project_cards",
+			mcp.WithDescription(t("TOOL_LIST_PROJECT_CARDS_DESCRIPTION", "List the cards of a classic project column, in column order. Cards are notes, or issues and pull requests linked by their content_url")),
+			mcp.WithNumber("column_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the column, as returned by list_project_columns"),
+			),
+			mcp.WithString("archived_state",
+				mcp.Description("Filter by archived state ('all', 'archived', 'not_archived'), defaults to not_archived"),
+				mcp.Enum("all", "archived", "not_archived"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			columnID, err := RequiredInt(request, "column_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			archivedState, err := OptionalParam[string](request, "archived_state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			query := url.Values{
+				"page":     []string{strconv.Itoa(pagination.page)},
+				"per_page": []string{strconv.Itoa(pagination.perPage)},
+			}
+			if archivedState != "" {
+				query.Set("archived_state", archivedState)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			cards := []*projectCard{}
+			u := fmt.Sprintf("projects/columns/%d/cards?%s", columnID, query.Encode())
+			if result, err := doClassicProjectRequest(ctx, client, "GET", u, nil, &cards, http.StatusOK, "list project cards"); result != nil || err != nil {
+				return result, err
+			}
+
+			return marshalToolResult(cards)
+		}
+}
+
+// CreateProject creates a tool to create a classic project in a repository.
+func CreateProject(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_project",
+			mcp.WithDescription(t("TOOL_CREATE_PROJECT_DESCRIPTION", "Create a classic project board in a repository")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Project name"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Project description"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := OptionalParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			project := new(classicProject)
+			u := fmt.Sprintf("repos/%v/%v/projects", owner, repo)
+			if result, err := doClassicProjectRequest(ctx, client, "POST", u, map[string]string{"name": name, "body": body}, project, http.StatusCreated, "create project"); result != nil || err != nil {
+				return result, err
+			}
+
+			return marshalToolResult(project)
+		}
+}
+
+// UpdateProject creates a tool to update a classic project.
+func UpdateProject(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_project",
+			mcp.WithDescription(t("TOOL_UPDATE_PROJECT_DESCRIPTION", "Rename, describe, close or reopen a classic project board")),
+			mcp.WithNumber("project_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the project"),
+			),
+			mcp.WithString("name",
+				mcp.Description("New project name"),
+			),
+			mcp.WithString("body",
+				mcp.Description("New project description"),
+			),
+			mcp.WithString("state",
+				mcp.Description("New state ('open', 'closed')"),
+				mcp.Enum("open", "closed"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			projectID, err := RequiredInt(request, "project_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			update := map[string]string{}
+			for _, field := range []string{"name", "body", "state"} {
+				value, ok, err := OptionalParamOK[string](request, field)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					update[field] = value
+				}
+			}
+			if len(update) == 0 {
+				return mcp.NewToolResultError("at least one of name, body or state is required"), nil
+			}
+			if state, ok := update["state"]; ok && state != "open" && state != "closed" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid state %q, must be open or closed", state)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			project := new(classicProject)
+			if result, err := doClassicProjectRequest(ctx, client, "PATCH", fmt.Sprintf("projects/%d", projectID), update, project, http.StatusOK, "update project"); result != nil || err != nil {
+				return result, err
+			}
+
+			return marshalToolResult(project)
+		}
+}
+
+// DeleteProject creates a tool to delete a classic project.
+func DeleteProject(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_project",
+			mcp.WithDescription(t("TOOL_DELETE_PROJECT_DESCRIPTION", "Delete a classic project board with all its columns and cards. The issues and pull requests on it are kept")),
+			mcp.WithNumber("project_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the project"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			projectID, err := RequiredInt(request, "project_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if result, err := doClassicProjectRequest(ctx, client, "DELETE", fmt.Sprintf("projects/%d", projectID), nil, nil, http.StatusNoContent, "delete project"); result != nil || err != nil {
+				return result, err
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Project %d deleted", projectID)), nil
+		}
+}
+
+// CreateProjectColumn creates a tool to add a column to a classic project.
+func CreateProjectColumn(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_project_column",
+			mcp.WithDescription(t("TOOL_CREATE_PROJECT_COLUMN_DESCRIPTION", "Add a column at the end of a classic project board")),
+			mcp.WithNumber("project_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the project"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Column name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			projectID, err := RequiredInt(request, "project_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			column := new(projectColumn)
+			u := fmt.Sprintf("projects/%d/columns", projectID)
+			if result, err := doClassicProjectRequest(ctx, client, "POST", u, map[string]string{"name": name}, column, http.StatusCreated, "create project column"); result != nil || err != nil {
+				return result, err
+			}
+
+			return marshalToolResult(column)
+		}
+}
+
+// UpdateProjectColumn creates a tool to rename a column of a classic project.
+func UpdateProjectColumn(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_project_column",
+			mcp.WithDescription(t("TOOL_UPDATE_PROJECT_COLUMN_DESCRIPTION", "Rename a column of a classic project board")),
+			mcp.WithNumber("column_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the column"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("New column name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			columnID, err := RequiredInt(request, "column_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			column := new(projectColumn)
+			u := fmt.Sprintf("projects/columns/%d", columnID)
+			if result, err := doClassicProjectRequest(ctx, client, "PATCH", u, map[string]string{"name": name}, column, http.StatusOK, "update project column"); result != nil || err != nil {
+				return result, err
+			}
+
+			return marshalToolResult(column)
+		}
+}
+
+// DeleteProjectColumn creates a tool to delete a column of a classic project.
+func DeleteProjectColumn(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_project_column",
+			mcp.WithDescription(t("TOOL_DELETE_PROJECT_COLUMN_DESCRIPTION", "Delete a column of a classic project board with all its cards")),
+			mcp.WithNumber("column_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the column"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			columnID, err := RequiredInt(request, "column_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if result, err := doClassicProjectRequest(ctx, client, "DELETE", fmt.Sprintf("projects/columns/%d", columnID), nil, nil, http.StatusNoContent, "delete project column"); result != nil || err != nil {
+				return result, err
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Project column %d deleted", columnID)), nil
+		}
+}
+
+// MoveProjectColumn creates a tool to move a column of a classic project.
+func MoveProjectColumn(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("move_project_column",
+			mcp.WithDescription(t("TOOL_MOVE_PROJECT_COLUMN_DESCRIPTION", "Move a column of a classic project board to another position on the board")),
+			mcp.WithNumber("column_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the column"),
+			),
+			mcp.WithString("position",
+				mcp.Required(),
+				mcp.Description("Where to move the column: 'first', 'last', or 'after:<column_id>' to place it after another column"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			columnID, err := RequiredInt(request, "column_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			position, err := requiredParam[string](request, "position")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !validProjectPosition(position, "first", "last") {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid position %q, must be first, last or after:<column_id>", position)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			u := fmt.Sprintf("projects/columns/%d/moves", columnID)
+			if result, err := doClassicProjectRequest(ctx, client, "POST", u, map[string]string{"position": position}, nil, http.StatusCreated, "move project column"); result != nil || err != nil {
+				return result, err
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Project column %d moved to %s", columnID, position)), nil
+		}
+}
+
+// CreateProjectCard creates a tool to add a card to a column of a classic project.
+func CreateProjectCard(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_project_card",
+			mcp.WithDescription(t("TOOL_CREATE_PROJECT_CARD_DESCRIPTION", "Add a card at the top of a column of a classic project board: either a note, or an issue or pull request of the project's repository")),
+			mcp.WithNumber("column_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the column"),
+			),
+			mcp.WithString("note",
+				mcp.Description("Text of a note card. Either note or content_id is required"),
+			),
+			mcp.WithNumber("content_id",
+				mcp.Description("The unique identifier (not the number) of the issue or pull request to add, as returned by get_issue or get_pull_request. Either note or content_id is required"),
+			),
+			mcp.WithString("content_type",
+				mcp.Description("Type of the content_id ('Issue', 'PullRequest'), required with content_id"),
+				mcp.Enum("Issue", "PullRequest"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			columnID, err := RequiredInt(request, "column_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			note, err := OptionalParam[string](request, "note")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentID, err := OptionalIntParam(request, "content_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentType, err := OptionalParam[string](request, "content_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var card map[string]interface{}
+			switch {
+			case (note == "") == (contentID == 0):
+				return mcp.NewToolResultError("either note or content_id is required, but not both"), nil
+			case note != "":
+				card = map[string]interface{}{"note": note}
+			case contentType != "Issue" && contentType != "PullRequest":
+				return mcp.NewToolResultError("content_type must be Issue or PullRequest when content_id is set"), nil
+			default:
+				card = map[string]interface{}{"content_id": contentID, "content_type": contentType}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			created := new(projectCard)
+			u := fmt.Sprintf("projects/columns/%d/cards", columnID)
+			if result, err := doClassicProjectRequest(ctx, client, "POST", u, card, created, http.StatusCreated, "create project card"); result != nil || err != nil {
+				return result, err
+			}
+
+			return marshalToolResult(created)
+		}
+}
+
+// UpdateProjectCard creates a tool to update a card of a classic project.
+func UpdateProjectCard(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_project_card",
+			mcp.WithDescription(t("TOOL_UPDATE_PROJECT_CARD_DESCRIPTION", "Edit the note of a classic project card, or archive or restore the card")),
+			mcp.WithNumber("card_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the card, as returned by list_project_cards"),
+			),
+			mcp.WithString("note",
+				mcp.Description("New text of a note card"),
+			),
+			mcp.WithBoolean("archived",
+				mcp.Description("Whether the card is archived"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			cardID, err := RequiredInt(request, "card_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			update := map[string]interface{}{}
+			note, ok, err := OptionalParamOK[string](request, "note")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ok {
+				update["note"] = note
+			}
+			archived, ok, err := OptionalParamOK[bool](request, "archived")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ok {
+				update["archived"] = archived
+			}
+			if len(update) == 0 {
+				return mcp.NewToolResultError("at least one of note or archived is required"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			card := new(projectCard)
+			u := fmt.Sprintf("projects/columns/cards/%d", cardID)
+			if result, err := doClassicProjectRequest(ctx, client, "PATCH", u, update, card, http.StatusOK, "update project card"); result != nil || err != nil {
+				return result, err
+			}
+
+			return marshalToolResult(card)
+		}
+}
+
+// DeleteProjectCard creates a tool to delete a card of a classic project.
+func DeleteProjectCard(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_project_card",
+			mcp.WithDescription(t("TOOL_DELETE_PROJECT_CARD_DESCRIPTION", "Delete a card of a classic project board. The issue or pull request of the card is kept")),
+			mcp.WithNumber("card_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the card"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			cardID, err := RequiredInt(request, "card_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if result, err := doClassicProjectRequest(ctx, client, "DELETE", fmt.Sprintf("projects/columns/cards/%d", cardID), nil, nil, http.StatusNoContent, "delete project card"); result != nil || err != nil {
+				return result, err
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Project card %d deleted", cardID)), nil
+		}
+}
+
+// MoveProjectCard creates a tool to move a card of a classic project within its column or to another column.
+func MoveProjectCard(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("move_project_card",
+			mcp.WithDescription(t("TOOL_MOVE_PROJECT_CARD_DESCRIPTION", "Move a card of a classic project board within its column, or to another column of the board")),
+			mcp.WithNumber("card_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the card"),
+			),
+			mcp.WithString("position",
+				mcp.Required(),
+				mcp.Description("Where to move the card: 'top', 'bottom', or 'after:<card_id>' to place it after another card"),
+			),
+			mcp.WithNumber("column_id",
+				mcp.Description("The unique identifier of the column to move the card to, defaults to its current column"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			cardID, err := RequiredInt(request, "card_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			position, err := requiredParam[string](request, "position")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !validProjectPosition(position, "top", "bottom") {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid position %q, must be top, bottom or after:<card_id>", position)), nil
+			}
+			columnID, err := OptionalIntParam(request, "column_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			move := map[string]interface{}{"position": position}
+			if columnID != 0 {
+				move["column_id"] = columnID
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			u := fmt.Sprintf("projects/columns/cards/%d/moves", cardID)
+			if result, err := doClassicProjectRequest(ctx, client, "POST", u, move, nil, http.StatusCreated, "move project card"); result != nil || err != nil {
+				return result, err
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Project card %d moved to %s", cardID, position)), nil
+		}
+}
@@ -0,0 +1,1503 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	mockClassicProject = map[string]interface{}{
+		"id":         1002604,
+		"number":     1,
+		"name":       "Roadmap",
+		"body":       "Plans for the next release",
+		"state":      "open",
+		"html_url":   "https://github.com/owner/repo/projects/1",
+		"creator":    map[string]interface{}{"login": "octocat"},
+		"created_at": "2025-01-01T00:00:00Z",
+		"updated_at": "2025-01-02T00:00:00Z",
+	}
+	mockProjectColumn = map[string]interface{}{
+		"id":         367,
+		"name":       "To Do",
+		"created_at": "2025-01-01T00:00:00Z",
+		"updated_at": "2025-01-02T00:00:00Z",
+	}
+	mockProjectCard = map[string]interface{}{
+		"id":          1478,
+		"note":        "Add payload for delete Project column",
+		"archived":    false,
+		"creator":     map[string]interface{}{"login": "octocat"},
+		"created_at":  "2025-01-01T00:00:00Z",
+		"updated_at":  "2025-01-02T00:00:00Z",
+		"column_url":  "https://api.github.com/projects/columns/367",
+		"project_url": "https://api.github.com/projects/120",
+	}
+)
+
+// classicProjectsResponse returns a handler responding with code after checking that the request asks for the
+// classic projects preview media type.
+func classicProjectsResponse(t *testing.T, code int, body interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, mediaTypeClassicProjectsPreview, r.Header.Get("Accept"))
+		mockResponse(t, code, body)(w, r)
+	}
+}
+
+func Test_ListRepoProjects(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepoProjects(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_repo_projects", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "projects filtered by state",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposProjectsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"state":    "all",
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						classicProjectsResponse(t, http.StatusOK, []map[string]interface{}{mockClassicProject}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"state":   "all",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+			expectError: false,
+		},
+		{
+			name: "classic projects retired",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposProjectsByOwnerByRepo,
+					mockResponse(t, http.StatusGone, map[string]string{"message": "Projects (classic) has been deprecated"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedErrMsg: "classic projects are not available on this server",
+		},
+		{
+			name: "server error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposProjectsByOwnerByRepo,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Internal Server Error"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list projects",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepoProjects(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned []classicProject
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			require.Len(t, returned, 1)
+			assert.Equal(t, int64(1002604), returned[0].ID)
+			assert.Equal(t, "Roadmap", returned[0].Name)
+			assert.Equal(t, "octocat", returned[0].Creator.Login)
+		})
+	}
+}
+
+func Test_GetProject(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetProject(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_project", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "project_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"project_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "project",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetProjectsByProjectId,
+					classicProjectsResponse(t, http.StatusOK, mockClassicProject),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"project_id": float64(1002604),
+			},
+			expectError: false,
+		},
+		{
+			name: "project not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetProjectsByProjectId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"project_id": float64(1),
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to get project",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetProject(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned classicProject
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, "Roadmap", returned.Name)
+			assert.Equal(t, "open", returned.State)
+		})
+	}
+}
+
+func Test_ListProjectColumns(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListProjectColumns(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_project_columns", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "project_id")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"project_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "columns",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetProjectsColumnsByProjectId,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						classicProjectsResponse(t, http.StatusOK, []map[string]interface{}{mockProjectColumn}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"project_id": float64(1002604),
+			},
+			expectError: false,
+		},
+		{
+			name: "classic projects retired",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetProjectsColumnsByProjectId,
+					mockResponse(t, http.StatusGone, map[string]string{"message": "Projects (classic) has been deprecated"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"project_id": float64(1002604),
+			},
+			expectError:    false,
+			expectedErrMsg: "classic projects are not available on this server",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListProjectColumns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned []projectColumn
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			require.Len(t, returned, 1)
+			assert.Equal(t, int64(367), returned[0].ID)
+			assert.Equal(t, "To Do", returned[0].Name)
+		})
+	}
+}
+
+func Test_ListProjectCards(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListProjectCards(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_project_cards", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "column_id")
+	assert.Contains(t, tool.InputSchema.Properties, "archived_state")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"column_id"})
+
+	mockIssueCard := map[string]interface{}{
+		"id":          1479,
+		"archived":    true,
+		"content_url": "https://api.github.com/repos/owner/repo/issues/3",
+		"created_at":  "2025-01-01T00:00:00Z",
+		"updated_at":  "2025-01-02T00:00:00Z",
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "note and issue cards",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetProjectsColumnsCardsByColumnId,
+					expectQueryParams(t, map[string]string{
+						"archived_state": "all",
+						"page":           "1",
+						"per_page":       "30",
+					}).andThen(
+						classicProjectsResponse(t, http.StatusOK, []map[string]interface{}{mockProjectCard, mockIssueCard}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"column_id":      float64(367),
+				"archived_state": "all",
+			},
+			expectError: false,
+		},
+		{
+			name: "column not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetProjectsColumnsCardsByColumnId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"column_id": float64(1),
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to list project cards",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListProjectCards(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned []projectCard
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			require.Len(t, returned, 2)
+			assert.Equal(t, "Add payload for delete Project column", returned[0].Note)
+			assert.Empty(t, returned[0].ContentURL)
+			assert.Equal(t, "https://api.github.com/repos/owner/repo/issues/3", returned[1].ContentURL)
+			assert.True(t, returned[1].Archived)
+		})
+	}
+}
+
+func Test_CreateProject(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateProject(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_project", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "project created",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposProjectsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"name": "Roadmap",
+						"body": "Plans for the next release",
+					}).andThen(
+						classicProjectsResponse(t, http.StatusCreated, mockClassicProject),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "Roadmap",
+				"body":  "Plans for the next release",
+			},
+			expectError: false,
+		},
+		{
+			name: "projects disabled in repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposProjectsByOwnerByRepo,
+					mockResponse(t, http.StatusGone, map[string]string{"message": "Projects are disabled for this repository"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "Roadmap",
+			},
+			expectError:    false,
+			expectedErrMsg: "classic projects are not available on this server",
+		},
+		{
+			name:         "missing name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedErrMsg: "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateProject(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned classicProject
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1002604), returned.ID)
+			assert.Equal(t, "https://github.com/owner/repo/projects/1", returned.HTMLURL)
+		})
+	}
+}
+
+func Test_UpdateProject(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateProject(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_project", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "project_id")
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"project_id"})
+
+	closedProject := map[string]interface{}{
+		"id":    1002604,
+		"name":  "Roadmap",
+		"state": "closed",
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "project closed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchProjectsByProjectId,
+					expectRequestBody(t, map[string]interface{}{
+						"state": "closed",
+					}).andThen(
+						classicProjectsResponse(t, http.StatusOK, closedProject),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"project_id": float64(1002604),
+				"state":      "closed",
+			},
+			expectError: false,
+		},
+		{
+			name:         "nothing to update",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"project_id": float64(1002604),
+			},
+			expectError:    false,
+			expectedErrMsg: "at least one of name, body or state is required",
+		},
+		{
+			name:         "invalid state",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"project_id": float64(1002604),
+				"state":      "archived",
+			},
+			expectError:    false,
+			expectedErrMsg: "invalid state",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateProject(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned classicProject
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, "closed", returned.State)
+		})
+	}
+}
+
+func Test_DeleteProject(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteProject(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_project", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "project_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"project_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "project deleted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteProjectsByProjectId,
+					classicProjectsResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"project_id": float64(1002604),
+			},
+			expectError:  false,
+			expectedText: "Project 1002604 deleted",
+		},
+		{
+			name: "project not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteProjectsByProjectId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"project_id": float64(1),
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to delete project",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteProject(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_CreateProjectColumn(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateProjectColumn(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_project_column", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "project_id")
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"project_id", "name"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "column created",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostProjectsColumnsByProjectId,
+					expectRequestBody(t, map[string]interface{}{
+						"name": "To Do",
+					}).andThen(
+						classicProjectsResponse(t, http.StatusCreated, mockProjectColumn),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"project_id": float64(1002604),
+				"name":       "To Do",
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid column",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostProjectsColumnsByProjectId,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"project_id": float64(1002604),
+				"name":       "To Do",
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to create project column",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateProjectColumn(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned projectColumn
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, int64(367), returned.ID)
+			assert.Equal(t, "To Do", returned.Name)
+		})
+	}
+}
+
+func Test_UpdateProjectColumn(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateProjectColumn(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_project_column", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "column_id")
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"column_id", "name"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "column renamed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchProjectsColumnsByColumnId,
+					expectRequestBody(t, map[string]interface{}{
+						"name": "Backlog",
+					}).andThen(
+						classicProjectsResponse(t, http.StatusOK, map[string]interface{}{"id": 367, "name": "Backlog"}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"column_id": float64(367),
+				"name":      "Backlog",
+			},
+			expectError: false,
+		},
+		{
+			name: "column not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchProjectsColumnsByColumnId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"column_id": float64(1),
+				"name":      "Backlog",
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to update project column",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateProjectColumn(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned projectColumn
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, "Backlog", returned.Name)
+		})
+	}
+}
+
+func Test_DeleteProjectColumn(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteProjectColumn(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_project_column", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "column_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"column_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "column deleted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteProjectsColumnsByColumnId,
+					classicProjectsResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"column_id": float64(367),
+			},
+			expectError:  false,
+			expectedText: "Project column 367 deleted",
+		},
+		{
+			name: "server error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteProjectsColumnsByColumnId,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Internal Server Error"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"column_id": float64(367),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete project column",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteProjectColumn(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_MoveProjectColumn(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := MoveProjectColumn(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "move_project_column", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "column_id")
+	assert.Contains(t, tool.InputSchema.Properties, "position")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"column_id", "position"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "column moved after another",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostProjectsColumnsMovesByColumnId,
+					expectRequestBody(t, map[string]interface{}{
+						"position": "after:368",
+					}).andThen(
+						classicProjectsResponse(t, http.StatusCreated, map[string]interface{}{}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"column_id": float64(367),
+				"position":  "after:368",
+			},
+			expectError:  false,
+			expectedText: "Project column 367 moved to after:368",
+		},
+		{
+			name:         "card position for a column",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"column_id": float64(367),
+				"position":  "top",
+			},
+			expectError:    false,
+			expectedErrMsg: "invalid position \"top\"",
+		},
+		{
+			name:         "position after a non numeric ID",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"column_id": float64(367),
+				"position":  "after:done",
+			},
+			expectError:    false,
+			expectedErrMsg: "invalid position \"after:done\"",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := MoveProjectColumn(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_CreateProjectCard(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateProjectCard(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_project_card", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "column_id")
+	assert.Contains(t, tool.InputSchema.Properties, "note")
+	assert.Contains(t, tool.InputSchema.Properties, "content_id")
+	assert.Contains(t, tool.InputSchema.Properties, "content_type")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"column_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "note card",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostProjectsColumnsCardsByColumnId,
+					expectRequestBody(t, map[string]interface{}{
+						"note": "Add payload for delete Project column",
+					}).andThen(
+						classicProjectsResponse(t, http.StatusCreated, mockProjectCard),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"column_id": float64(367),
+				"note":      "Add payload for delete Project column",
+			},
+			expectError: false,
+		},
+		{
+			name: "pull request card",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostProjectsColumnsCardsByColumnId,
+					expectRequestBody(t, map[string]interface{}{
+						"content_id":   float64(1296269),
+						"content_type": "PullRequest",
+					}).andThen(
+						classicProjectsResponse(t, http.StatusCreated, mockProjectCard),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"column_id":    float64(367),
+				"content_id":   float64(1296269),
+				"content_type": "PullRequest",
+			},
+			expectError: false,
+		},
+		{
+			name:         "note and content",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"column_id":    float64(367),
+				"note":         "Add payload",
+				"content_id":   float64(1296269),
+				"content_type": "Issue",
+			},
+			expectError:    false,
+			expectedErrMsg: "either note or content_id is required, but not both",
+		},
+		{
+			name:         "content without type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"column_id":  float64(367),
+				"content_id": float64(1296269),
+			},
+			expectError:    false,
+			expectedErrMsg: "content_type must be Issue or PullRequest",
+		},
+		{
+			name: "issue of another repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostProjectsColumnsCardsByColumnId,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"column_id":    float64(367),
+				"content_id":   float64(1296269),
+				"content_type": "Issue",
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to create project card",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateProjectCard(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned projectCard
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1478), returned.ID)
+		})
+	}
+}
+
+func Test_UpdateProjectCard(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateProjectCard(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_project_card", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "card_id")
+	assert.Contains(t, tool.InputSchema.Properties, "note")
+	assert.Contains(t, tool.InputSchema.Properties, "archived")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"card_id"})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedArchived bool
+	}{
+		{
+			name: "card archived",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchProjectsColumnsCardsByCardId,
+					expectRequestBody(t, map[string]interface{}{
+						"archived": true,
+					}).andThen(
+						classicProjectsResponse(t, http.StatusOK, map[string]interface{}{"id": 1478, "archived": true}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"card_id":  float64(1478),
+				"archived": true,
+			},
+			expectError:      false,
+			expectedArchived: true,
+		},
+		{
+			name: "card restored with a new note",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchProjectsColumnsCardsByCardId,
+					expectRequestBody(t, map[string]interface{}{
+						"note":     "Updated note",
+						"archived": false,
+					}).andThen(
+						classicProjectsResponse(t, http.StatusOK, map[string]interface{}{"id": 1478, "note": "Updated note", "archived": false}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"card_id":  float64(1478),
+				"note":     "Updated note",
+				"archived": false,
+			},
+			expectError:      false,
+			expectedArchived: false,
+		},
+		{
+			name:         "nothing to update",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"card_id": float64(1478),
+			},
+			expectError:    false,
+			expectedErrMsg: "at least one of note or archived is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateProjectCard(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned projectCard
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedArchived, returned.Archived)
+		})
+	}
+}
+
+func Test_DeleteProjectCard(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteProjectCard(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_project_card", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "card_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"card_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "card deleted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteProjectsColumnsCardsByCardId,
+					classicProjectsResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"card_id": float64(1478),
+			},
+			expectError:  false,
+			expectedText: "Project card 1478 deleted",
+		},
+		{
+			name: "not allowed to delete",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteProjectsColumnsCardsByCardId,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"card_id": float64(1478),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete project card",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteProjectCard(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_MoveProjectCard(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := MoveProjectCard(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "move_project_card", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "card_id")
+	assert.Contains(t, tool.InputSchema.Properties, "position")
+	assert.Contains(t, tool.InputSchema.Properties, "column_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"card_id", "position"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "card moved to the bottom of another column",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostProjectsColumnsCardsMovesByCardId,
+					expectRequestBody(t, map[string]interface{}{
+						"position":  "bottom",
+						"column_id": float64(368),
+					}).andThen(
+						classicProjectsResponse(t, http.StatusCreated, map[string]interface{}{}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"card_id":   float64(1478),
+				"position":  "bottom",
+				"column_id": float64(368),
+			},
+			expectError:  false,
+			expectedText: "Project card 1478 moved to bottom",
+		},
+		{
+			name: "card moved after another in its column",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostProjectsColumnsCardsMovesByCardId,
+					expectRequestBody(t, map[string]interface{}{
+						"position": "after:1479",
+					}).andThen(
+						classicProjectsResponse(t, http.StatusCreated, map[string]interface{}{}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"card_id":  float64(1478),
+				"position": "after:1479",
+			},
+			expectError:  false,
+			expectedText: "Project card 1478 moved to after:1479",
+		},
+		{
+			name:         "column position for a card",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"card_id":  float64(1478),
+				"position": "first",
+			},
+			expectError:    false,
+			expectedErrMsg: "invalid position \"first\"",
+		},
+		{
+			name: "card of another project",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostProjectsColumnsCardsMovesByCardId,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"card_id":   float64(1478),
+				"position":  "top",
+				"column_id": float64(999),
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to move project card",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := MoveProjectCard(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// copilotSeatAssignee describes who a Copilot seat is assigned to: a user, or a team or organization
+// for seats assigned through them.
+func copilotSeatAssignee(seat *github.CopilotSeatDetails) map[string]interface{} {
+	if user, ok := seat.GetUser(); ok {
+		return map[string]interface{}{"type": "User", "login": user.GetLogin()}
+	}
+	if team, ok := seat.GetTeam(); ok {
+		return map[string]interface{}{"type": "Team", "slug": team.GetSlug(), "name": team.GetName()}
+	}
+	if org, ok := seat.GetOrganization(); ok {
+		return map[string]interface{}{"type": "Organization", "login": org.GetLogin()}
+	}
+	return nil
+}
+
+// ListOrgCopilotSeats creates a tool to list the Copilot seats assigned in an organization.
+func ListOrgCopilotSeats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_copilot_seats",
+			mcp.WithDescription(t("TOOL_LIST_ORG_COPILOT_SEATS_DESCRIPTION", "List the GitHub Copilot seats assigned in an organization, with each assignee's last activity, plan and pending cancellation. Requires organization owner or billing manager permissions")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			opts := &github.ListOptions{
+				PerPage: pagination.perPage,
+				Page:    pagination.page,
+			}
+			seats, resp, err := client.Copilot.ListCopilotSeats(ctx, org, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list Copilot seats: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list Copilot seats: %s", string(body))), nil
+			}
+
+			result := make([]map[string]interface{}, 0, len(seats.Seats))
+			for _, seat := range seats.Seats {
+				result = append(result, map[string]interface{}{
+					"assignee":                  copilotSeatAssignee(seat),
+					"assigning_team":            seat.AssigningTeam.GetSlug(),
+					"last_activity_at":          seat.LastActivityAt,
+					"last_activity_editor":      seat.LastActivityEditor,
+					"plan_type":                 seat.PlanType,
+					"pending_cancellation_date": seat.PendingCancellationDate,
+					"created_at":                seat.CreatedAt,
+				})
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"total_seats": seats.TotalSeats,
+				"seats":       result,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// copilotSeatsOptions returns the parameters of a tool changing the Copilot seats of the users or teams an array
+// parameter selects.
+func copilotSeatsOptions(param, paramDescription string) []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("org",
+			mcp.Required(),
+			mcp.Description("Organization name"),
+		),
+		mcp.WithArray(param,
+			mcp.Required(),
+			mcp.Description(paramDescription),
+			mcp.Items(map[string]interface{}{"type": "string"}),
+		),
+	}
+}
+
+// copilotSeatsParams returns the organization and the users or teams given to a tool changing Copilot seats.
+func copilotSeatsParams(request mcp.CallToolRequest, param string) (org string, selected []string, err error) {
+	org, err = requiredParam[string](request, "org")
+	if err != nil {
+		return "", nil, err
+	}
+	selected, err = OptionalStringArrayParam(request, param)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(selected) == 0 {
+		return "", nil, fmt.Errorf("missing required parameter: %s", param)
+	}
+	return org, selected, nil
+}
+
+// copilotSeatsChangeResult returns the result of a request changing Copilot seats, or the tool error for it.
+// GitHub rejects the request when seats aren't managed per user and team, or when a user or team isn't in the
+// organization.
+func copilotSeatsChangeResult(resp *github.Response, err error, action string, expectedStatus int, result func() map[string]interface{}) (*mcp.CallToolResult, error) {
+	if err != nil {
+		if isGitHubErrorStatus(err, http.StatusUnprocessableEntity) {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to %s Copilot seats: %s", action, err)), nil
+		}
+		return nil, fmt.Errorf("failed to %s Copilot seats: %w", action, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != expectedStatus {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("failed to %s Copilot seats: %s", action, string(body))), nil
+	}
+
+	r, err := json.Marshal(result())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
+
+// AddCopilotSeatsForUsers creates a tool to assign Copilot seats to users of an organization.
+func AddCopilotSeatsForUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{mcp.WithDescription(t("TOOL_ADD_COPILOT_SEATS_FOR_USERS_DESCRIPTION", "Assign GitHub Copilot seats to users of an organization. The organization is billed for each new seat. Requires organization owner or billing manager permissions"))}
+	return mcp.NewTool("add_copilot_seats_for_users", append(options, copilotSeatsOptions("selected_usernames", "Usernames of the users")...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, selected, err := copilotSeatsParams(request, "selected_usernames")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			assignments, resp, err := client.Copilot.AddCopilotUsers(ctx, org, selected)
+			return copilotSeatsChangeResult(resp, err, "add", http.StatusCreated, func() map[string]interface{} {
+				return map[string]interface{}{"seats_created": assignments.SeatsCreated}
+			})
+		}
+}
+
+// AddCopilotSeatsForTeams creates a tool to assign Copilot seats to the members of teams of an organization.
+func AddCopilotSeatsForTeams(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{mcp.WithDescription(t("TOOL_ADD_COPILOT_SEATS_FOR_TEAMS_DESCRIPTION", "Assign GitHub Copilot seats to every member of teams of an organization. The organization is billed for each new seat. Requires organization owner or billing manager permissions"))}
+	return mcp.NewTool("add_copilot_seats_for_teams", append(options, copilotSeatsOptions("selected_teams", "Names of the teams")...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, selected, err := copilotSeatsParams(request, "selected_teams")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			assignments, resp, err := client.Copilot.AddCopilotTeams(ctx, org, selected)
+			return copilotSeatsChangeResult(resp, err, "add", http.StatusCreated, func() map[string]interface{} {
+				return map[string]interface{}{"seats_created": assignments.SeatsCreated}
+			})
+		}
+}
+
+// CancelCopilotSeatAssignmentForUsers creates a tool to cancel the Copilot seats of users of an organization.
+func CancelCopilotSeatAssignmentForUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{mcp.WithDescription(t("TOOL_CANCEL_COPILOT_SEAT_ASSIGNMENT_FOR_USERS_DESCRIPTION", "Cancel the GitHub Copilot seats of users of an organization. They lose access at the end of the current billing cycle. Requires organization owner or billing manager permissions"))}
+	return mcp.NewTool("cancel_copilot_seat_assignment_for_users", append(options, copilotSeatsOptions("selected_usernames", "Usernames of the users")...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, selected, err := copilotSeatsParams(request, "selected_usernames")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			cancellations, resp, err := client.Copilot.RemoveCopilotUsers(ctx, org, selected)
+			return copilotSeatsChangeResult(resp, err, "cancel", http.StatusOK, func() map[string]interface{} {
+				return map[string]interface{}{
+					"seats_cancelled": cancellations.SeatsCancelled,
+					"note":            "cancelled seats remain usable until the end of the current billing cycle, see pending_cancellation_date in list_org_copilot_seats",
+				}
+			})
+		}
+}
+
+// CancelCopilotSeatAssignmentForTeams creates a tool to cancel the Copilot seats assigned through teams of an organization.
+func CancelCopilotSeatAssignmentForTeams(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{mcp.WithDescription(t("TOOL_CANCEL_COPILOT_SEAT_ASSIGNMENT_FOR_TEAMS_DESCRIPTION", "Cancel the GitHub Copilot seats assigned through teams of an organization. Their members lose access at the end of the current billing cycle. Requires organization owner or billing manager permissions"))}
+	return mcp.NewTool("cancel_copilot_seat_assignment_for_teams", append(options, copilotSeatsOptions("selected_teams", "Names of the teams")...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, selected, err := copilotSeatsParams(request, "selected_teams")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			cancellations, resp, err := client.Copilot.RemoveCopilotTeams(ctx, org, selected)
+			return copilotSeatsChangeResult(resp, err, "cancel", http.StatusOK, func() map[string]interface{} {
+				return map[string]interface{}{
+					"seats_cancelled": cancellations.SeatsCancelled,
+					"note":            "cancelled seats remain usable until the end of the current billing cycle, see pending_cancellation_date in list_org_copilot_seats",
+				}
+			})
+		}
+}
+
+// copilotSeatsPermissionError is the tool error for a caller lacking the permissions to read an organization's Copilot seats.
+func copilotSeatsPermissionError(org string) *mcp.CallToolResult {
+	return mcp.NewToolResultError(fmt.Sprintf("reading the Copilot seats of %s requires organization owner permissions and a token with the admin:org scope", org))
+}
+
+// GetCopilotSeats creates a tool to summarize the Copilot seat usage of an organization.
+func GetCopilotSeats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_copilot_seats",
+			mcp.WithDescription(t("TOOL_GET_COPILOT_SEATS_DESCRIPTION", "Get the GitHub Copilot seat usage of an organization: how many seats it has and how many were active in the current billing cycle, along with a page of the seats with each assignee's last activity. Requires organization owner permissions and the admin:org scope")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			billing, resp, err := client.Copilot.GetCopilotBilling(ctx, org)
+			if err != nil {
+				switch {
+				case resp != nil && resp.StatusCode == http.StatusForbidden:
+					return copilotSeatsPermissionError(org), nil
+				case resp != nil && resp.StatusCode == http.StatusNotFound:
+					return mcp.NewToolResultError(fmt.Sprintf("organization %s not found or has no Copilot subscription", org)), nil
+				}
+				return nil, fmt.Errorf("failed to get Copilot billing: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			seats, resp, err := client.Copilot.ListCopilotSeats(ctx, org, &github.ListOptions{
+				PerPage: pagination.perPage,
+				Page:    pagination.page,
+			})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusForbidden {
+					return copilotSeatsPermissionError(org), nil
+				}
+				return nil, fmt.Errorf("failed to list Copilot seats: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			breakdown := billing.SeatBreakdown
+			if breakdown == nil {
+				breakdown = &github.CopilotSeatBreakdown{}
+			}
+			result := make([]map[string]interface{}, 0, len(seats.Seats))
+			for _, seat := range seats.Seats {
+				result = append(result, map[string]interface{}{
+					"assignee":             copilotSeatAssignee(seat),
+					"last_activity_at":     seat.LastActivityAt,
+					"last_activity_editor": seat.LastActivityEditor,
+				})
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"total_seats":          breakdown.Total,
+				"active_this_cycle":    breakdown.ActiveThisCycle,
+				"inactive_this_cycle":  breakdown.InactiveThisCycle,
+				"pending_invitation":   breakdown.PendingInvitation,
+				"pending_cancellation": breakdown.PendingCancellation,
+				"seats":                result,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
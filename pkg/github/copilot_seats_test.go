@@ -0,0 +1,678 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgCopilotSeats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgCopilotSeats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_copilot_seats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockSeats := map[string]interface{}{
+		"total_seats": 2,
+		"seats": []map[string]interface{}{
+			{
+				"assignee":             map[string]interface{}{"type": "User", "login": "octocat"},
+				"assigning_team":       map[string]interface{}{"slug": "engineering"},
+				"last_activity_at":     "2025-01-10T12:00:00Z",
+				"last_activity_editor": "vscode/1.96.0/copilot/1.250.0",
+				"plan_type":            "business",
+				"created_at":           "2024-06-01T00:00:00Z",
+			},
+			{
+				"assignee":                  map[string]interface{}{"type": "User", "login": "hubot"},
+				"pending_cancellation_date": "2025-02-01",
+				"plan_type":                 "business",
+				"created_at":                "2024-06-01T00:00:00Z",
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "seats with activity",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotBillingSeatsByOrg,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockSeats),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError: false,
+		},
+		{
+			name: "not a billing manager",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotBillingSeatsByOrg,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list Copilot seats",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgCopilotSeats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var response struct {
+				TotalSeats int `json:"total_seats"`
+				Seats      []struct {
+					Assignee                map[string]interface{} `json:"assignee"`
+					AssigningTeam           string                 `json:"assigning_team"`
+					LastActivityAt          *string                `json:"last_activity_at"`
+					LastActivityEditor      *string                `json:"last_activity_editor"`
+					PlanType                string                 `json:"plan_type"`
+					PendingCancellationDate *string                `json:"pending_cancellation_date"`
+				} `json:"seats"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, 2, response.TotalSeats)
+			require.Len(t, response.Seats, 2)
+
+			assert.Equal(t, map[string]interface{}{"type": "User", "login": "octocat"}, response.Seats[0].Assignee)
+			assert.Equal(t, "engineering", response.Seats[0].AssigningTeam)
+			require.NotNil(t, response.Seats[0].LastActivityAt)
+			assert.Equal(t, "vscode/1.96.0/copilot/1.250.0", *response.Seats[0].LastActivityEditor)
+			assert.Equal(t, "business", response.Seats[0].PlanType)
+			assert.Nil(t, response.Seats[0].PendingCancellationDate)
+
+			assert.Equal(t, "hubot", response.Seats[1].Assignee["login"])
+			assert.Nil(t, response.Seats[1].LastActivityAt)
+			require.NotNil(t, response.Seats[1].PendingCancellationDate)
+			assert.Equal(t, "2025-02-01", *response.Seats[1].PendingCancellationDate)
+		})
+	}
+}
+
+func Test_AddCopilotSeatsForUsers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddCopilotSeatsForUsers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_copilot_seats_for_users", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "selected_usernames")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "selected_usernames"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "seats created",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsCopilotBillingSelectedUsersByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"selected_usernames": []interface{}{"octocat", "hubot"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, map[string]int{"seats_created": 2}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                "org",
+				"selected_usernames": []interface{}{"octocat", "hubot"},
+			},
+			expectError:  false,
+			expectedText: `{"seats_created":2}`,
+		},
+		{
+			name: "no usernames",
+			requestArgs: map[string]interface{}{
+				"org":                "org",
+				"selected_usernames": []interface{}{},
+			},
+			expectError:    false,
+			expectedErrMsg: "missing required parameter: selected_usernames",
+		},
+		{
+			name: "seats not assignable",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsCopilotBillingSelectedUsersByOrg,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Copilot Business is not enabled for selected members"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                "org",
+				"selected_usernames": []interface{}{"octocat"},
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to add Copilot seats",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddCopilotSeatsForUsers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_AddCopilotSeatsForTeams(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddCopilotSeatsForTeams(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_copilot_seats_for_teams", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "selected_teams")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "selected_teams"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "seats created for team members",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsCopilotBillingSelectedTeamsByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"selected_teams": []interface{}{"engineering"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, map[string]int{"seats_created": 12}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":            "org",
+				"selected_teams": []interface{}{"engineering"},
+			},
+			expectError:  false,
+			expectedText: `{"seats_created":12}`,
+		},
+		{
+			name: "add fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsCopilotBillingSelectedTeamsByOrg,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Internal Server Error"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":            "org",
+				"selected_teams": []interface{}{"engineering"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add Copilot seats",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddCopilotSeatsForTeams(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_CancelCopilotSeatAssignmentForUsers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CancelCopilotSeatAssignmentForUsers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "cancel_copilot_seat_assignment_for_users", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "selected_usernames")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "selected_usernames"})
+
+	tests := []struct {
+		name                   string
+		mockedClient           *http.Client
+		requestArgs            map[string]interface{}
+		expectError            bool
+		expectedErrMsg         string
+		expectedSeatsCancelled int
+	}{
+		{
+			name: "seats cancelled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsCopilotBillingSelectedUsersByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"selected_usernames": []interface{}{"hubot"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]int{"seats_cancelled": 1}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                "org",
+				"selected_usernames": []interface{}{"hubot"},
+			},
+			expectError:            false,
+			expectedSeatsCancelled: 1,
+		},
+		{
+			name: "seats assigned through a team",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsCopilotBillingSelectedUsersByOrg,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Cannot cancel seats assigned through a team"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                "org",
+				"selected_usernames": []interface{}{"octocat"},
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to cancel Copilot seats",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CancelCopilotSeatAssignmentForUsers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				SeatsCancelled int    `json:"seats_cancelled"`
+				Note           string `json:"note"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSeatsCancelled, response.SeatsCancelled)
+			assert.Contains(t, response.Note, "end of the current billing cycle")
+		})
+	}
+}
+
+func Test_CancelCopilotSeatAssignmentForTeams(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CancelCopilotSeatAssignmentForTeams(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "cancel_copilot_seat_assignment_for_teams", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "selected_teams")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "selected_teams"})
+
+	tests := []struct {
+		name                   string
+		mockedClient           *http.Client
+		requestArgs            map[string]interface{}
+		expectError            bool
+		expectedErrMsg         string
+		expectedSeatsCancelled int
+	}{
+		{
+			name: "team seats cancelled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsCopilotBillingSelectedTeamsByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"selected_teams": []interface{}{"contractors", "interns"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]int{"seats_cancelled": 7}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":            "org",
+				"selected_teams": []interface{}{"contractors", "interns"},
+			},
+			expectError:            false,
+			expectedSeatsCancelled: 7,
+		},
+		{
+			name: "no teams",
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    false,
+			expectedErrMsg: "missing required parameter: selected_teams",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CancelCopilotSeatAssignmentForTeams(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				SeatsCancelled int `json:"seats_cancelled"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSeatsCancelled, response.SeatsCancelled)
+		})
+	}
+}
+
+func Test_GetCopilotSeats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCopilotSeats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_copilot_seats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockBilling := map[string]interface{}{
+		"seat_breakdown": map[string]interface{}{
+			"total":                12,
+			"added_this_cycle":     2,
+			"pending_cancellation": 1,
+			"pending_invitation":   0,
+			"active_this_cycle":    9,
+			"inactive_this_cycle":  3,
+		},
+		"seat_management_setting": "assign_selected",
+	}
+	mockSeatsPage := map[string]interface{}{
+		"total_seats": 12,
+		"seats": []map[string]interface{}{
+			{
+				"assignee":             map[string]interface{}{"type": "User", "login": "octocat"},
+				"last_activity_at":     "2025-01-10T12:00:00Z",
+				"last_activity_editor": "vscode/1.96.0/copilot/1.250.0",
+				"created_at":           "2024-06-01T00:00:00Z",
+			},
+			{
+				"assignee":   map[string]interface{}{"type": "User", "login": "hubot"},
+				"created_at": "2024-06-01T00:00:00Z",
+			},
+		},
+	}
+	forbidden := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "seat usage with activity",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsCopilotBillingByOrg,
+					mockBilling,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotBillingSeatsByOrg,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "2",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockSeatsPage),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":     "org",
+				"page":    float64(2),
+				"perPage": float64(2),
+			},
+			expectError: false,
+		},
+		{
+			name: "missing admin:org",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotBillingByOrg,
+					forbidden,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    false,
+			expectedErrMsg: "reading the Copilot seats of org requires organization owner permissions and a token with the admin:org scope",
+		},
+		{
+			name: "no Copilot subscription",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotBillingByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    false,
+			expectedErrMsg: "organization org not found or has no Copilot subscription",
+		},
+		{
+			name: "seat list forbidden",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsCopilotBillingByOrg,
+					mockBilling,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotBillingSeatsByOrg,
+					forbidden,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    false,
+			expectedErrMsg: "requires organization owner permissions",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCopilotSeats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				TotalSeats        int `json:"total_seats"`
+				ActiveThisCycle   int `json:"active_this_cycle"`
+				InactiveThisCycle int `json:"inactive_this_cycle"`
+				Seats             []struct {
+					Assignee           map[string]interface{} `json:"assignee"`
+					LastActivityAt     *string                `json:"last_activity_at"`
+					LastActivityEditor *string                `json:"last_activity_editor"`
+				} `json:"seats"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, 12, response.TotalSeats)
+			assert.Equal(t, 9, response.ActiveThisCycle)
+			assert.Equal(t, 3, response.InactiveThisCycle)
+			require.Len(t, response.Seats, 2)
+			assert.Equal(t, "octocat", response.Seats[0].Assignee["login"])
+			require.NotNil(t, response.Seats[0].LastActivityAt)
+			assert.Equal(t, "2025-01-10T12:00:00Z", *response.Seats[0].LastActivityAt)
+			assert.Equal(t, "vscode/1.96.0/copilot/1.250.0", *response.Seats[0].LastActivityEditor)
+			assert.Equal(t, "hubot", response.Seats[1].Assignee["login"])
+			assert.Nil(t, response.Seats[1].LastActivityAt)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/crypto"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetDependabotSecretPublicKey creates a tool to get the public key Dependabot secrets have to be encrypted with.
+func GetDependabotSecretPublicKey(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_dependabot_secret_public_key",
+			mcp.WithDescription(t("TOOL_GET_DEPENDABOT_SECRET_PUBLIC_KEY_DESCRIPTION", "Get the public key Dependabot secrets of a repository or organization have to be encrypted with before they are created or updated")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner, or the organization name when repo is omitted"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Repository name, omit to get the key of the organization"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			scope := crypto.DependabotRepo
+			if repo == "" {
+				scope = crypto.DependabotOrg
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			key, err := crypto.FetchPublicKey(ctx, client, scope, owner, repo)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := json.Marshal(key)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
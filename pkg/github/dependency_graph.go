@@ -0,0 +1,344 @@
+package github
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// dependencyChange is a dependency added or removed between two refs, as reported by the dependency review API.
+// go-github has no support for the API, so the request is built here.
+type dependencyChange struct {
+	ChangeType      string                    `json:"change_type"`
+	Manifest        string                    `json:"manifest"`
+	Ecosystem       string                    `json:"ecosystem"`
+	Name            string                    `json:"name"`
+	Version         string                    `json:"version"`
+	PackageURL      string                    `json:"package_url"`
+	License         *string                   `json:"license"`
+	Scope           string                    `json:"scope"`
+	Vulnerabilities []dependencyVulnerability `json:"vulnerabilities"`
+}
+
+// dependencyVulnerability is a security advisory affecting the version of a dependency.
+type dependencyVulnerability struct {
+	Severity        string `json:"severity"`
+	AdvisoryGHSAID  string `json:"advisory_ghsa_id"`
+	AdvisorySummary string `json:"advisory_summary"`
+	AdvisoryURL     string `json:"advisory_url"`
+}
+
+// advisorySeverityRank orders the severities of the dependency review API, most severe first.
+var advisorySeverityRank = map[string]int{
+	"critical": 0,
+	"high":     1,
+	"moderate": 2,
+	"low":      3,
+}
+
+// compareDependencies lists the dependencies added and removed between two refs of a repository. The SBOM endpoint
+// only describes the default branch, so the dependency review API is used instead, which diffs the dependency graph
+// snapshots of both refs.
+func compareDependencies(ctx context.Context, client *github.Client, owner, repo, baseRef, headRef string) ([]dependencyChange, error) {
+	u := fmt.Sprintf("repos/%v/%v/dependency-graph/compare/%v...%v", owner, repo, url.PathEscape(baseRef), url.PathEscape(headRef))
+	req, err := client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	var changes []dependencyChange
+	resp, err := client.Do(ctx, req, &changes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare dependencies: %w", err)
+	}
+	_ = resp.Body.Close()
+	return changes, nil
+}
+
+// compareDependenciesToolError turns the errors of comparing dependencies the caller can act on into tool errors,
+// and returns nil for the others.
+func compareDependenciesToolError(err error, owner, repo, baseRef, headRef string) *mcp.CallToolResult {
+	switch {
+	case isGitHubErrorStatus(err, http.StatusNotFound):
+		return mcp.NewToolResultError(fmt.Sprintf("no dependencies to compare between %s and %s in %s/%s: one of the refs doesn't exist, or the dependency graph hasn't been computed for it", baseRef, headRef, owner, repo))
+	case isGitHubErrorStatus(err, http.StatusForbidden):
+		return mcp.NewToolResultError(fmt.Sprintf("dependencies of %s/%s can't be compared, the dependency graph may not be enabled: %s", owner, repo, err))
+	}
+	return nil
+}
+
+// dependencyPackage is a package of a dependency diff.
+type dependencyPackage struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Ecosystem string `json:"ecosystem"`
+	PURL      string `json:"purl"`
+	Manifest  string `json:"manifest"`
+}
+
+// dependencyVersionChange is a package whose version changed between two refs.
+type dependencyVersionChange struct {
+	Name       string `json:"name"`
+	OldVersion string `json:"old_version"`
+	NewVersion string `json:"new_version"`
+	Ecosystem  string `json:"ecosystem"`
+	Manifest   string `json:"manifest"`
+}
+
+// diffDependencies sorts dependency changes into added, removed and version changed packages. A package removed and
+// added with another version in the same manifest changed version; when there are several versions of it on either
+// side, they can't be paired, so they are reported as added and removed.
+func diffDependencies(changes []dependencyChange) (added, removed []dependencyPackage, versionChanged []dependencyVersionChange) {
+	type packageKey struct{ ecosystem, name, manifest string }
+	addedByKey := map[packageKey][]dependencyChange{}
+	removedByKey := map[packageKey][]dependencyChange{}
+	var keys []packageKey
+	seen := map[packageKey]bool{}
+	for _, change := range changes {
+		key := packageKey{change.Ecosystem, change.Name, change.Manifest}
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+		switch change.ChangeType {
+		case "added":
+			addedByKey[key] = append(addedByKey[key], change)
+		case "removed":
+			removedByKey[key] = append(removedByKey[key], change)
+		}
+	}
+
+	toPackage := func(change dependencyChange) dependencyPackage {
+		return dependencyPackage{
+			Name:      change.Name,
+			Version:   change.Version,
+			Ecosystem: change.Ecosystem,
+			PURL:      change.PackageURL,
+			Manifest:  change.Manifest,
+		}
+	}
+	added, removed, versionChanged = []dependencyPackage{}, []dependencyPackage{}, []dependencyVersionChange{}
+	for _, key := range keys {
+		a, r := addedByKey[key], removedByKey[key]
+		if len(a) == 1 && len(r) == 1 {
+			versionChanged = append(versionChanged, dependencyVersionChange{
+				Name:       key.name,
+				OldVersion: r[0].Version,
+				NewVersion: a[0].Version,
+				Ecosystem:  key.ecosystem,
+				Manifest:   key.manifest,
+			})
+			continue
+		}
+		for _, change := range a {
+			added = append(added, toPackage(change))
+		}
+		for _, change := range r {
+			removed = append(removed, toPackage(change))
+		}
+	}
+
+	byPackage := func(a, b dependencyPackage) int {
+		return cmp.Or(cmp.Compare(a.Ecosystem, b.Ecosystem), cmp.Compare(a.Name, b.Name), cmp.Compare(a.Version, b.Version), cmp.Compare(a.Manifest, b.Manifest))
+	}
+	slices.SortFunc(added, byPackage)
+	slices.SortFunc(removed, byPackage)
+	slices.SortFunc(versionChanged, func(a, b dependencyVersionChange) int {
+		return cmp.Or(cmp.Compare(a.Ecosystem, b.Ecosystem), cmp.Compare(a.Name, b.Name), cmp.Compare(a.Manifest, b.Manifest))
+	})
+	return added, removed, versionChanged
+}
+
+// GetSBOMDiff creates a tool to compare the dependencies of a repository between two refs.
+func GetSBOMDiff(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_sbom_diff",
+			mcp.WithDescription(t("TOOL_GET_SBOM_DIFF_DESCRIPTION", "Compare the dependencies of a repository between two refs, such as two releases: the packages added, removed and whose version changed. Requires the dependency graph to be enabled")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base_ref",
+				mcp.Required(),
+				mcp.Description("Branch, tag or commit SHA to compare from"),
+			),
+			mcp.WithString("head_ref",
+				mcp.Required(),
+				mcp.Description("Branch, tag or commit SHA to compare to"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			baseRef, err := requiredParam[string](request, "base_ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			headRef, err := requiredParam[string](request, "head_ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			changes, err := compareDependencies(ctx, client, owner, repo, baseRef, headRef)
+			if err != nil {
+				if result := compareDependenciesToolError(err, owner, repo, baseRef, headRef); result != nil {
+					return result, nil
+				}
+				return nil, err
+			}
+
+			added, removed, versionChanged := diffDependencies(changes)
+
+			r, err := json.Marshal(map[string]interface{}{
+				"added":           added,
+				"removed":         removed,
+				"version_changed": versionChanged,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// reviewedDependency is a dependency added or removed between two refs, with its license and the advisories
+// affecting its version.
+type reviewedDependency struct {
+	Name            string                    `json:"name"`
+	Version         string                    `json:"version"`
+	Ecosystem       string                    `json:"ecosystem"`
+	PURL            string                    `json:"purl"`
+	Manifest        string                    `json:"manifest"`
+	Scope           string                    `json:"scope"`
+	License         *string                   `json:"license"`
+	Vulnerabilities []dependencyVulnerability `json:"vulnerabilities"`
+}
+
+// GetDependencyReview creates a tool to review the dependencies added and removed between two refs of a repository,
+// with their vulnerabilities and licenses.
+func GetDependencyReview(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_dependency_review",
+			mcp.WithDescription(t("TOOL_GET_DEPENDENCY_REVIEW_DESCRIPTION", "Review the dependencies added and removed between two refs of a repository, such as the base and head of a pull request, with their licenses and known vulnerabilities. vulnerable_additions lists the added dependencies with vulnerabilities, most severe first, and should be flagged. Requires the dependency graph to be enabled")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base_ref",
+				mcp.Required(),
+				mcp.Description("Branch, tag or commit SHA to compare from"),
+			),
+			mcp.WithString("head_ref",
+				mcp.Required(),
+				mcp.Description("Branch, tag or commit SHA to compare to"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			baseRef, err := requiredParam[string](request, "base_ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			headRef, err := requiredParam[string](request, "head_ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			changes, err := compareDependencies(ctx, client, owner, repo, baseRef, headRef)
+			if err != nil {
+				if result := compareDependenciesToolError(err, owner, repo, baseRef, headRef); result != nil {
+					return result, nil
+				}
+				return nil, err
+			}
+
+			added, removed, vulnerableAdditions := []reviewedDependency{}, []reviewedDependency{}, []reviewedDependency{}
+			for _, change := range changes {
+				vulnerabilities := change.Vulnerabilities
+				if vulnerabilities == nil {
+					vulnerabilities = []dependencyVulnerability{}
+				}
+				slices.SortStableFunc(vulnerabilities, func(a, b dependencyVulnerability) int {
+					return cmp.Compare(severityRank(a.Severity), severityRank(b.Severity))
+				})
+				dependency := reviewedDependency{
+					Name:            change.Name,
+					Version:         change.Version,
+					Ecosystem:       change.Ecosystem,
+					PURL:            change.PackageURL,
+					Manifest:        change.Manifest,
+					Scope:           change.Scope,
+					License:         change.License,
+					Vulnerabilities: vulnerabilities,
+				}
+				switch change.ChangeType {
+				case "added":
+					added = append(added, dependency)
+					if len(vulnerabilities) > 0 {
+						vulnerableAdditions = append(vulnerableAdditions, dependency)
+					}
+				case "removed":
+					removed = append(removed, dependency)
+				}
+			}
+			// Vulnerabilities are sorted most severe first, so the first one of a dependency is its most severe
+			slices.SortStableFunc(vulnerableAdditions, func(a, b reviewedDependency) int {
+				return cmp.Compare(severityRank(a.Vulnerabilities[0].Severity), severityRank(b.Vulnerabilities[0].Severity))
+			})
+
+			r, err := json.Marshal(map[string]interface{}{
+				"vulnerable_additions": vulnerableAdditions,
+				"added":                added,
+				"removed":              removed,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// severityRank ranks a severity of the dependency review API, unknown severities coming last.
+func severityRank(severity string) int {
+	if rank, ok := advisorySeverityRank[severity]; ok {
+		return rank
+	}
+	return len(advisorySeverityRank)
+}
@@ -0,0 +1,325 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetSBOMDiff(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetSBOMDiff(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_sbom_diff", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "base_ref")
+	assert.Contains(t, tool.InputSchema.Properties, "head_ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base_ref", "head_ref"})
+
+	mockChanges := []map[string]interface{}{
+		{"change_type": "removed", "manifest": "go.mod", "ecosystem": "gomod", "name": "github.com/pkg/errors", "version": "0.9.1", "package_url": "pkg:golang/github.com/pkg/errors@0.9.1"},
+		{"change_type": "added", "manifest": "go.mod", "ecosystem": "gomod", "name": "golang.org/x/crypto", "version": "0.36.0", "package_url": "pkg:golang/golang.org/x/crypto@0.36.0"},
+		{"change_type": "removed", "manifest": "go.mod", "ecosystem": "gomod", "name": "golang.org/x/crypto", "version": "0.31.0", "package_url": "pkg:golang/golang.org/x/crypto@0.31.0"},
+		{"change_type": "added", "manifest": "package-lock.json", "ecosystem": "npm", "name": "lodash", "version": "4.17.21", "package_url": "pkg:npm/lodash@4.17.21"},
+	}
+
+	tests := []struct {
+		name                   string
+		mockedClient           *http.Client
+		requestArgs            map[string]interface{}
+		expectError            bool
+		expectedErrMsg         string
+		expectedAdded          []dependencyPackage
+		expectedRemoved        []dependencyPackage
+		expectedVersionChanged []dependencyVersionChange
+	}{
+		{
+			name: "added, removed and upgraded packages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/dependency-graph/compare/v1.0.0...v2.0.0", r.URL.Path)
+						mockResponse(t, http.StatusOK, mockChanges)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"base_ref": "v1.0.0",
+				"head_ref": "v2.0.0",
+			},
+			expectError: false,
+			expectedAdded: []dependencyPackage{
+				{Name: "lodash", Version: "4.17.21", Ecosystem: "npm", PURL: "pkg:npm/lodash@4.17.21", Manifest: "package-lock.json"},
+			},
+			expectedRemoved: []dependencyPackage{
+				{Name: "github.com/pkg/errors", Version: "0.9.1", Ecosystem: "gomod", PURL: "pkg:golang/github.com/pkg/errors@0.9.1", Manifest: "go.mod"},
+			},
+			expectedVersionChanged: []dependencyVersionChange{
+				{Name: "golang.org/x/crypto", OldVersion: "0.31.0", NewVersion: "0.36.0", Ecosystem: "gomod", Manifest: "go.mod"},
+			},
+		},
+		{
+			name: "no dependency changes",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+					[]map[string]interface{}{},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"base_ref": "main",
+				"head_ref": "feature",
+			},
+			expectError:            false,
+			expectedAdded:          []dependencyPackage{},
+			expectedRemoved:        []dependencyPackage{},
+			expectedVersionChanged: []dependencyVersionChange{},
+		},
+		{
+			name: "dependency graph not computed for a ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"base_ref": "v1.0.0",
+				"head_ref": "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "no dependencies to compare between v1.0.0 and missing in owner/repo",
+		},
+		{
+			name: "dependency graph disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Dependency review is not supported on this repository."}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"base_ref": "v1.0.0",
+				"head_ref": "v2.0.0",
+			},
+			expectError:    false,
+			expectedErrMsg: "the dependency graph may not be enabled",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetSBOMDiff(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Added          []dependencyPackage       `json:"added"`
+				Removed        []dependencyPackage       `json:"removed"`
+				VersionChanged []dependencyVersionChange `json:"version_changed"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAdded, response.Added)
+			assert.Equal(t, tc.expectedRemoved, response.Removed)
+			assert.Equal(t, tc.expectedVersionChanged, response.VersionChanged)
+		})
+	}
+}
+
+func Test_GetDependencyReview(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetDependencyReview(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_dependency_review", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "base_ref")
+	assert.Contains(t, tool.InputSchema.Properties, "head_ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base_ref", "head_ref"})
+
+	mockChanges := []map[string]interface{}{
+		{
+			"change_type": "added", "manifest": "package-lock.json", "ecosystem": "npm", "name": "minimist", "version": "1.2.5",
+			"package_url": "pkg:npm/minimist@1.2.5", "license": "MIT", "scope": "runtime",
+			"vulnerabilities": []map[string]interface{}{
+				{"severity": "moderate", "advisory_ghsa_id": "GHSA-vh95-rmgr-6w4m", "advisory_summary": "Prototype Pollution in minimist", "advisory_url": "https://github.com/advisories/GHSA-vh95-rmgr-6w4m"},
+				{"severity": "critical", "advisory_ghsa_id": "GHSA-xvch-5gv4-984h", "advisory_summary": "Prototype Pollution in minimist", "advisory_url": "https://github.com/advisories/GHSA-xvch-5gv4-984h"},
+			},
+		},
+		{
+			"change_type": "added", "manifest": "package-lock.json", "ecosystem": "npm", "name": "lodash", "version": "4.17.19",
+			"package_url": "pkg:npm/lodash@4.17.19", "license": "MIT", "scope": "runtime",
+			"vulnerabilities": []map[string]interface{}{
+				{"severity": "high", "advisory_ghsa_id": "GHSA-35jh-r3h4-6jhm", "advisory_summary": "Command Injection in lodash", "advisory_url": "https://github.com/advisories/GHSA-35jh-r3h4-6jhm"},
+			},
+		},
+		{
+			"change_type": "added", "manifest": "package-lock.json", "ecosystem": "npm", "name": "left-pad", "version": "1.3.0",
+			"package_url": "pkg:npm/left-pad@1.3.0", "license": nil, "scope": "development", "vulnerabilities": []map[string]interface{}{},
+		},
+		{
+			"change_type": "removed", "manifest": "package-lock.json", "ecosystem": "npm", "name": "request", "version": "2.88.2",
+			"package_url": "pkg:npm/request@2.88.2", "license": "Apache-2.0", "scope": "runtime", "vulnerabilities": []map[string]interface{}{},
+		},
+	}
+
+	minimist := reviewedDependency{
+		Name: "minimist", Version: "1.2.5", Ecosystem: "npm", PURL: "pkg:npm/minimist@1.2.5", Manifest: "package-lock.json",
+		Scope: "runtime", License: github.Ptr("MIT"),
+		Vulnerabilities: []dependencyVulnerability{
+			{Severity: "critical", AdvisoryGHSAID: "GHSA-xvch-5gv4-984h", AdvisorySummary: "Prototype Pollution in minimist", AdvisoryURL: "https://github.com/advisories/GHSA-xvch-5gv4-984h"},
+			{Severity: "moderate", AdvisoryGHSAID: "GHSA-vh95-rmgr-6w4m", AdvisorySummary: "Prototype Pollution in minimist", AdvisoryURL: "https://github.com/advisories/GHSA-vh95-rmgr-6w4m"},
+		},
+	}
+	lodash := reviewedDependency{
+		Name: "lodash", Version: "4.17.19", Ecosystem: "npm", PURL: "pkg:npm/lodash@4.17.19", Manifest: "package-lock.json",
+		Scope: "runtime", License: github.Ptr("MIT"),
+		Vulnerabilities: []dependencyVulnerability{
+			{Severity: "high", AdvisoryGHSAID: "GHSA-35jh-r3h4-6jhm", AdvisorySummary: "Command Injection in lodash", AdvisoryURL: "https://github.com/advisories/GHSA-35jh-r3h4-6jhm"},
+		},
+	}
+	leftPad := reviewedDependency{
+		Name: "left-pad", Version: "1.3.0", Ecosystem: "npm", PURL: "pkg:npm/left-pad@1.3.0", Manifest: "package-lock.json",
+		Scope: "development", Vulnerabilities: []dependencyVulnerability{},
+	}
+	requestPackage := reviewedDependency{
+		Name: "request", Version: "2.88.2", Ecosystem: "npm", PURL: "pkg:npm/request@2.88.2", Manifest: "package-lock.json",
+		Scope: "runtime", License: github.Ptr("Apache-2.0"), Vulnerabilities: []dependencyVulnerability{},
+	}
+
+	tests := []struct {
+		name                        string
+		mockedClient                *http.Client
+		requestArgs                 map[string]interface{}
+		expectError                 bool
+		expectedErrMsg              string
+		expectedVulnerableAdditions []reviewedDependency
+		expectedAdded               []reviewedDependency
+		expectedRemoved             []reviewedDependency
+	}{
+		{
+			name: "vulnerable dependencies added",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/dependency-graph/compare/main...feature", r.URL.Path)
+						mockResponse(t, http.StatusOK, mockChanges)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"base_ref": "main",
+				"head_ref": "feature",
+			},
+			expectError:                 false,
+			expectedVulnerableAdditions: []reviewedDependency{minimist, lodash},
+			expectedAdded:               []reviewedDependency{minimist, lodash, leftPad},
+			expectedRemoved:             []reviewedDependency{requestPackage},
+		},
+		{
+			name: "dependency graph disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Dependency review is not supported on this repository."}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"base_ref": "main",
+				"head_ref": "feature",
+			},
+			expectError:    false,
+			expectedErrMsg: "dependencies of owner/repo can't be compared, the dependency graph may not be enabled",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetDependencyReview(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				VulnerableAdditions []reviewedDependency `json:"vulnerable_additions"`
+				Added               []reviewedDependency `json:"added"`
+				Removed             []reviewedDependency `json:"removed"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedVulnerableAdditions, response.VulnerableAdditions)
+			assert.Equal(t, tc.expectedAdded, response.Added)
+			assert.Equal(t, tc.expectedRemoved, response.Removed)
+		})
+	}
+}
@@ -13,9 +13,10 @@ import (
 
 func ToolsetEnum(toolsetGroup *toolsets.ToolsetGroup) mcp.PropertyOption {
 	toolsetNames := make([]string, 0, len(toolsetGroup.Toolsets))
-	for name := range toolsetGroup.Toolsets {
+	_ = toolsetGroup.ForEachToolset(func(name string, _ *toolsets.Toolset) error {
 		toolsetNames = append(toolsetNames, name)
-	}
+		return nil
+	})
 	return mcp.Enum(toolsetNames...)
 }
 
@@ -63,17 +64,16 @@ func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.T
 
 			payload := []map[string]string{}
 
-			for name, ts := range toolsetGroup.Toolsets {
-				{
-					t := map[string]string{
-						"name":              name,
-						"description":       ts.Description,
-						"can_enable":        "true",
-						"currently_enabled": fmt.Sprintf("%t", ts.Enabled),
-					}
-					payload = append(payload, t)
+			_ = toolsetGroup.ForEachToolset(func(name string, ts *toolsets.Toolset) error {
+				t := map[string]string{
+					"name":              name,
+					"description":       ts.Description,
+					"can_enable":        "true",
+					"currently_enabled": fmt.Sprintf("%t", ts.Enabled),
 				}
-			}
+				payload = append(payload, t)
+				return nil
+			})
 
 			r, err := json.Marshal(payload)
 			if err != nil {
@@ -123,3 +123,14 @@ func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.Transl
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// GetServerConfig creates a tool to describe the server's current toolset configuration. The toolset group is
+// looked up on every call, as the tool usually belongs to the group it describes.
+func GetServerConfig(getToolsetGroup func() *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_server_config",
+			mcp.WithDescription(t("TOOL_GET_SERVER_CONFIG_DESCRIPTION", "Get a summary of this GitHub MCP server's configuration: which toolsets are enabled or read-only, how many tools each one offers and which tools are disabled")),
+		),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(getToolsetGroup().SummarizeConfig()), nil
+		}
+}
@@ -0,0 +1,516 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// isEnterpriseServer reports whether client is connected to a GitHub Enterprise Server instance, rather than to
+// GitHub.com or to GitHub Enterprise Cloud with data residency, which is served from ghe.com.
+func isEnterpriseServer(client *github.Client) bool {
+	host := client.BaseURL.Hostname()
+	return host != "api.github.com" && !strings.HasSuffix(host, ".ghe.com")
+}
+
+// enterpriseServerOnly returns a tool error when client isn't connected to GitHub Enterprise Server, which is the
+// only place the site administration APIs exist, and nil otherwise.
+func enterpriseServerOnly(client *github.Client) *mcp.CallToolResult {
+	if isEnterpriseServer(client) {
+		return nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("site administration is only available on GitHub Enterprise Server, but this server is connected to %s: set --gh-host to the URL of the instance, such as https://ghes.example.com", client.BaseURL.Host))
+}
+
+// siteAdminError maps the errors of a site administration request that the caller can act on to a tool error.
+func siteAdminError(err error, action string) (*mcp.CallToolResult, error) {
+	switch {
+	case isGitHubErrorStatus(err, http.StatusForbidden):
+		return mcp.NewToolResultError(fmt.Sprintf("failed to %s: site administrator permissions are required: %s", action, err)), nil
+	case isGitHubErrorStatus(err, http.StatusNotFound), isGitHubErrorStatus(err, http.StatusUnprocessableEntity):
+		return mcp.NewToolResultError(fmt.Sprintf("failed to %s: %s", action, err)), nil
+	}
+	return nil, fmt.Errorf("failed to %s: %w", action, err)
+}
+
+// preReceiveHook is a pre-receive hook of a GitHub Enterprise Server instance. go-github has no support for
+// pre-receive hooks, so the requests are built here.
+type preReceiveHook struct {
+	ID                           int64                  `json:"id"`
+	Name                         string                 `json:"name"`
+	Enforcement                  string                 `json:"enforcement"`
+	Script                       string                 `json:"script"`
+	ScriptRepository             *preReceiveHookRef     `json:"script_repository,omitempty"`
+	Environment                  *preReceiveEnvironment `json:"environment,omitempty"`
+	AllowDownstreamConfiguration bool                   `json:"allow_downstream_configuration"`
+}
+
+// preReceiveHookRef is the repository holding the script of a pre-receive hook.
+type preReceiveHookRef struct {
+	ID       int64  `json:"id"`
+	FullName string `json:"full_name"`
+}
+
+// preReceiveEnvironment is the environment a pre-receive hook script runs in.
+type preReceiveEnvironment struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// ListEnterpriseOrgs creates a tool to list every organization of a GitHub Enterprise Server instance.
+func ListEnterpriseOrgs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_enterprise_orgs",
+			mcp.WithDescription(t("TOOL_LIST_ENTERPRISE_ORGS_DESCRIPTION", "List every organization of the GitHub Enterprise Server instance, in the order they were created. Only available on GitHub Enterprise Server")),
+			mcp.WithNumber("since",
+				mcp.Description("Only list organizations with an ID greater than this one, to fetch the next page pass the ID of the last organization listed"),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description("Results per page for pagination (min 1, max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			since, err := OptionalIntParam(request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			perPage, err := OptionalIntParamWithDefault(request, "perPage", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if result := enterpriseServerOnly(client); result != nil {
+				return result, nil
+			}
+			opts := &github.OrganizationsListOptions{
+				Since:       int64(since),
+				ListOptions: github.ListOptions{PerPage: perPage},
+			}
+			orgs, resp, err := client.Organizations.ListAll(ctx, opts)
+			if err != nil {
+				return siteAdminError(err, "list organizations")
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list organizations: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(orgs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetEnterpriseStats creates a tool to get the statistics of a GitHub Enterprise Server instance.
+func GetEnterpriseStats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_enterprise_stats",
+			mcp.WithDescription(t("TOOL_GET_ENTERPRISE_STATS_DESCRIPTION", "Get the statistics of the GitHub Enterprise Server instance: counts of users, organizations, repositories, issues, pull requests and more. Only available on GitHub Enterprise Server")),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if result := enterpriseServerOnly(client); result != nil {
+				return result, nil
+			}
+			stats, resp, err := client.Admin.GetAdminStats(ctx)
+			if err != nil {
+				return siteAdminError(err, "get enterprise stats")
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get enterprise stats: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(stats)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListPreReceiveHooks creates a tool to list the pre-receive hooks of a GitHub Enterprise Server instance.
+func ListPreReceiveHooks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_pre_receive_hooks",
+			mcp.WithDescription(t("TOOL_LIST_PRE_RECEIVE_HOOKS_DESCRIPTION", "List the pre-receive hooks of the GitHub Enterprise Server instance, with their enforcement and script. Only available on GitHub Enterprise Server")),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if result := enterpriseServerOnly(client); result != nil {
+				return result, nil
+			}
+			u := fmt.Sprintf("admin/pre-receive-hooks?page=%d&per_page=%d", pagination.page, pagination.perPage)
+			req, err := client.NewRequest("GET", u, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			hooks := []*preReceiveHook{}
+			resp, err := client.Do(ctx, req, &hooks)
+			if err != nil {
+				return siteAdminError(err, "list pre-receive hooks")
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list pre-receive hooks: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(hooks)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetPreReceiveHook creates a tool to get a pre-receive hook of a GitHub Enterprise Server instance.
+func GetPreReceiveHook(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pre_receive_hook",
+			mcp.WithDescription(t("TOOL_GET_PRE_RECEIVE_HOOK_DESCRIPTION", "Get a pre-receive hook of the GitHub Enterprise Server instance. Only available on GitHub Enterprise Server")),
+			mcp.WithNumber("pre_receive_hook_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the pre-receive hook"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			hookID, err := RequiredInt(request, "pre_receive_hook_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if result := enterpriseServerOnly(client); result != nil {
+				return result, nil
+			}
+			req, err := client.NewRequest("GET", fmt.Sprintf("admin/pre-receive-hooks/%d", hookID), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			hook := new(preReceiveHook)
+			resp, err := client.Do(ctx, req, hook)
+			if err != nil {
+				return siteAdminError(err, "get pre-receive hook")
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pre-receive hook: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(hook)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateEnterpriseOrg creates a tool to create an organization on a GitHub Enterprise Server instance.
+func CreateEnterpriseOrg(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_org",
+			mcp.WithDescription(t("TOOL_CREATE_ORG_DESCRIPTION", "Create an organization on the GitHub Enterprise Server instance, owned by an existing user. Only available on GitHub Enterprise Server")),
+			mcp.WithString("login",
+				mcp.Required(),
+				mcp.Description("Organization name, used in URLs"),
+			),
+			mcp.WithString("admin",
+				mcp.Required(),
+				mcp.Description("Login of the user who will own the organization"),
+			),
+			mcp.WithString("profile_name",
+				mcp.Description("Display name of the organization"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			login, err := requiredParam[string](request, "login")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			admin, err := requiredParam[string](request, "admin")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			profileName, err := OptionalParam[string](request, "profile_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if result := enterpriseServerOnly(client); result != nil {
+				return result, nil
+			}
+			// go-github's CreateOrg drops everything but the login and admin, so the request is built here
+			orgReq := map[string]string{"login": login, "admin": admin}
+			if profileName != "" {
+				orgReq["profile_name"] = profileName
+			}
+			req, err := client.NewRequest("POST", "admin/organizations", orgReq)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			created := new(github.Organization)
+			resp, err := client.Do(ctx, req, created)
+			if err != nil {
+				return siteAdminError(err, "create organization")
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create organization: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(created)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateEnterpriseUser creates a tool to create a user on a GitHub Enterprise Server instance.
+func CreateEnterpriseUser(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_user",
+			mcp.WithDescription(t("TOOL_CREATE_USER_DESCRIPTION", "Create a user on the GitHub Enterprise Server instance. Only available on GitHub Enterprise Server with built-in authentication; with LDAP, SAML or CAS users are created when they first sign in")),
+			mcp.WithString("login",
+				mcp.Required(),
+				mcp.Description("Username of the user"),
+			),
+			mcp.WithString("email",
+				mcp.Description("Email address of the user, required with built-in authentication"),
+			),
+			mcp.WithBoolean("suspended",
+				mcp.Description("Whether the user is created suspended"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			login, err := requiredParam[string](request, "login")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			email, err := OptionalParam[string](request, "email")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			suspended, ok, err := OptionalParamOK[bool](request, "suspended")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if result := enterpriseServerOnly(client); result != nil {
+				return result, nil
+			}
+			userReq := github.CreateUserRequest{Login: login}
+			if email != "" {
+				userReq.Email = github.Ptr(email)
+			}
+			if ok {
+				userReq.Suspended = github.Ptr(suspended)
+			}
+			user, resp, err := client.Admin.CreateUser(ctx, userReq)
+			if err != nil {
+				return siteAdminError(err, "create user")
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create user: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(user)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// changeSiteAdminUser applies a change to the user a site administration tool is called with, action naming the
+// change in errors and done in the result.
+func changeSiteAdminUser(ctx context.Context, getClient GetClientFn, request mcp.CallToolRequest, action, done string, change func(client *github.Client, username string) (*github.Response, error)) (*mcp.CallToolResult, error) {
+	username, err := requiredParam[string](request, "username")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+	if result := enterpriseServerOnly(client); result != nil {
+		return result, nil
+	}
+	resp, err := change(client, username)
+	if err != nil {
+		return siteAdminError(err, fmt.Sprintf("%s user", action))
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusNoContent {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("failed to %s user: %s", action, string(body))), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("User %s %s", username, done)), nil
+}
+
+// SuspendUser creates a tool to suspend a user of a GitHub Enterprise Server instance.
+func SuspendUser(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("suspend_user",
+			mcp.WithDescription(t("TOOL_SUSPEND_USER_DESCRIPTION", "Suspend a user of the GitHub Enterprise Server instance, so they can no longer sign in, push or pull. Only available on GitHub Enterprise Server")),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user"),
+			),
+			mcp.WithString("reason",
+				mcp.Description("Reason for the suspension, shown to the user when they try to sign in and recorded in the audit log"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			reason, err := OptionalParam[string](request, "reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var opts *github.UserSuspendOptions
+			if reason != "" {
+				opts = &github.UserSuspendOptions{Reason: github.Ptr(reason)}
+			}
+			return changeSiteAdminUser(ctx, getClient, request, "suspend", "suspended", func(client *github.Client, username string) (*github.Response, error) {
+				return client.Users.Suspend(ctx, username, opts)
+			})
+		}
+}
+
+// UnsuspendUser creates a tool to unsuspend a user of a GitHub Enterprise Server instance.
+func UnsuspendUser(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unsuspend_user",
+			mcp.WithDescription(t("TOOL_UNSUSPEND_USER_DESCRIPTION", "Unsuspend a suspended user of the GitHub Enterprise Server instance. Only available on GitHub Enterprise Server")),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return changeSiteAdminUser(ctx, getClient, request, "unsuspend", "unsuspended", func(client *github.Client, username string) (*github.Response, error) {
+				return client.Users.Unsuspend(ctx, username)
+			})
+		}
+}
+
+// DeleteEnterpriseUser creates a tool to delete a user of a GitHub Enterprise Server instance.
+func DeleteEnterpriseUser(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_user",
+			mcp.WithDescription(t("TOOL_DELETE_USER_DESCRIPTION", "Delete a user of the GitHub Enterprise Server instance with all their repositories, gists, issues and comments. This can't be undone, consider suspend_user instead. Only available on GitHub Enterprise Server")),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return changeSiteAdminUser(ctx, getClient, request, "delete", "deleted", func(client *github.Client, username string) (*github.Response, error) {
+				return client.Admin.DeleteUser(ctx, username)
+			})
+		}
+}
+
+// PromoteUserToAdmin creates a tool to promote a user of a GitHub Enterprise Server instance to site administrator.
+func PromoteUserToAdmin(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("promote_user_to_admin",
+			mcp.WithDescription(t("TOOL_PROMOTE_USER_TO_ADMIN_DESCRIPTION", "Promote a user of the GitHub Enterprise Server instance to site administrator. Only available on GitHub Enterprise Server")),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return changeSiteAdminUser(ctx, getClient, request, "promote", "promoted to site administrator", func(client *github.Client, username string) (*github.Response, error) {
+				return client.Users.PromoteSiteAdmin(ctx, username)
+			})
+		}
+}
+
+// DemoteAdminToUser creates a tool to demote a site administrator of a GitHub Enterprise Server instance.
+func DemoteAdminToUser(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("demote_admin_to_user",
+			mcp.WithDescription(t("TOOL_DEMOTE_ADMIN_TO_USER_DESCRIPTION", "Demote a site administrator of the GitHub Enterprise Server instance to a regular user. Only available on GitHub Enterprise Server")),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return changeSiteAdminUser(ctx, getClient, request, "demote", "demoted to a regular user", func(client *github.Client, username string) (*github.Response, error) {
+				return client.Users.DemoteSiteAdmin(ctx, username)
+			})
+		}
+}
@@ -0,0 +1,855 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	getEnterpriseStatsAll = mock.EndpointPattern{
+		Pattern: "/enterprise/stats/all",
+		Method:  "GET",
+	}
+	getAdminPreReceiveHooks = mock.EndpointPattern{
+		Pattern: "/admin/pre-receive-hooks",
+		Method:  "GET",
+	}
+	getAdminPreReceiveHook = mock.EndpointPattern{
+		Pattern: "/admin/pre-receive-hooks/{pre_receive_hook_id}",
+		Method:  "GET",
+	}
+	postAdminOrganizations = mock.EndpointPattern{
+		Pattern: "/admin/organizations",
+		Method:  "POST",
+	}
+	postAdminUsers = mock.EndpointPattern{
+		Pattern: "/admin/users",
+		Method:  "POST",
+	}
+	deleteAdminUser = mock.EndpointPattern{
+		Pattern: "/admin/users/{username}",
+		Method:  "DELETE",
+	}
+	putUserSuspended = mock.EndpointPattern{
+		Pattern: "/users/{username}/suspended",
+		Method:  "PUT",
+	}
+	deleteUserSuspended = mock.EndpointPattern{
+		Pattern: "/users/{username}/suspended",
+		Method:  "DELETE",
+	}
+	putUserSiteAdmin = mock.EndpointPattern{
+		Pattern: "/users/{username}/site_admin",
+		Method:  "PUT",
+	}
+	deleteUserSiteAdmin = mock.EndpointPattern{
+		Pattern: "/users/{username}/site_admin",
+		Method:  "DELETE",
+	}
+)
+
+// enterpriseServerClient returns a client for a GitHub Enterprise Server instance sending its requests through
+// httpClient. The API is served from the root rather than /api/v3 so the requests match the mocked endpoints.
+func enterpriseServerClient(t *testing.T, httpClient *http.Client) *github.Client {
+	client := github.NewClient(httpClient)
+	baseURL, err := url.Parse("https://ghes.example.com/")
+	require.NoError(t, err)
+	client.BaseURL = baseURL
+	return client
+}
+
+func Test_ListEnterpriseOrgs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListEnterpriseOrgs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_enterprise_orgs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockOrgs := []*github.Organization{
+		{ID: github.Ptr(int64(11)), Login: github.Ptr("platform")},
+		{ID: github.Ptr(int64(12)), Login: github.Ptr("security")},
+	}
+
+	tests := []struct {
+		name           string
+		client         *github.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "organizations after an ID",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrganizations,
+					expectQueryParams(t, map[string]string{
+						"since":    "10",
+						"per_page": "2",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockOrgs),
+					),
+				),
+			)),
+			requestArgs: map[string]interface{}{
+				"since":   float64(10),
+				"perPage": float64(2),
+			},
+			expectError: false,
+		},
+		{
+			name:           "connected to GitHub.com",
+			client:         github.NewClient(mock.NewMockedHTTPClient()),
+			requestArgs:    map[string]interface{}{},
+			expectError:    false,
+			expectedErrMsg: "site administration is only available on GitHub Enterprise Server, but this server is connected to api.github.com: set --gh-host to the URL of the instance, such as https://ghes.example.com",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListEnterpriseOrgs(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned []*github.Organization
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			require.Len(t, returned, 2)
+			assert.Equal(t, "platform", returned[0].GetLogin())
+			assert.Equal(t, "security", returned[1].GetLogin())
+		})
+	}
+}
+
+func Test_GetEnterpriseStats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetEnterpriseStats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_enterprise_stats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	tests := []struct {
+		name           string
+		client         *github.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "stats",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					getEnterpriseStatsAll,
+					&github.AdminStats{
+						Users: &github.UserStats{TotalUsers: github.Ptr(250), AdminUsers: github.Ptr(3), SuspendedUsers: github.Ptr(12)},
+						Orgs:  &github.OrgStats{TotalOrgs: github.Ptr(14)},
+					},
+				),
+			)),
+			expectError: false,
+		},
+		{
+			name: "not a site administrator",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getEnterpriseStatsAll,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must be a site administrator"}),
+				),
+			)),
+			expectError:    false,
+			expectedErrMsg: "site administrator permissions are required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetEnterpriseStats(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned github.AdminStats
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, 250, returned.GetUsers().GetTotalUsers())
+			assert.Equal(t, 12, returned.GetUsers().GetSuspendedUsers())
+			assert.Equal(t, 14, returned.GetOrgs().GetTotalOrgs())
+		})
+	}
+}
+
+func Test_ListPreReceiveHooks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPreReceiveHooks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_pre_receive_hooks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockHooks := []map[string]interface{}{
+		{
+			"id":                             1,
+			"name":                           "Check Commits",
+			"enforcement":                    "enabled",
+			"script":                         "scripts/commit_check.sh",
+			"script_repository":              map[string]interface{}{"id": 595, "full_name": "DevIT/hooks"},
+			"environment":                    map[string]interface{}{"id": 2, "name": "DevTools Hook Env"},
+			"allow_downstream_configuration": true,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		client         *github.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "hooks",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getAdminPreReceiveHooks,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockHooks),
+					),
+				),
+			)),
+			expectError: false,
+		},
+		{
+			name: "server error",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getAdminPreReceiveHooks,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Internal Server Error"}),
+				),
+			)),
+			expectError:    true,
+			expectedErrMsg: "failed to list pre-receive hooks",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListPreReceiveHooks(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned []preReceiveHook
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			require.Len(t, returned, 1)
+			assert.Equal(t, "Check Commits", returned[0].Name)
+			assert.Equal(t, "enabled", returned[0].Enforcement)
+			assert.Equal(t, "DevIT/hooks", returned[0].ScriptRepository.FullName)
+			assert.Equal(t, "DevTools Hook Env", returned[0].Environment.Name)
+			assert.True(t, returned[0].AllowDownstreamConfiguration)
+		})
+	}
+}
+
+func Test_GetPreReceiveHook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPreReceiveHook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_pre_receive_hook", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "pre_receive_hook_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"pre_receive_hook_id"})
+
+	// GitHub Enterprise Cloud with data residency has no site administration either
+	dataResidencyClient, err := github.NewClient(nil).WithEnterpriseURLs("https://api.octocorp.ghe.com/", "https://uploads.octocorp.ghe.com/")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		client         *github.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "hook",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					getAdminPreReceiveHook,
+					map[string]interface{}{"id": 1, "name": "Check Commits", "enforcement": "testing"},
+				),
+			)),
+			expectError: false,
+		},
+		{
+			name: "hook not found",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getAdminPreReceiveHook,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			)),
+			expectError:    false,
+			expectedErrMsg: "failed to get pre-receive hook",
+		},
+		{
+			name:           "connected to GitHub Enterprise Cloud",
+			client:         dataResidencyClient,
+			expectError:    false,
+			expectedErrMsg: "site administration is only available on GitHub Enterprise Server",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetPreReceiveHook(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"pre_receive_hook_id": float64(1),
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned preReceiveHook
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), returned.ID)
+			assert.Equal(t, "testing", returned.Enforcement)
+		})
+	}
+}
+
+func Test_CreateEnterpriseOrg(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateEnterpriseOrg(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_org", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "login")
+	assert.Contains(t, tool.InputSchema.Properties, "admin")
+	assert.Contains(t, tool.InputSchema.Properties, "profile_name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"login", "admin"})
+
+	tests := []struct {
+		name           string
+		client         *github.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "organization created",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					postAdminOrganizations,
+					expectRequestBody(t, map[string]interface{}{
+						"login":        "platform",
+						"admin":        "monalisa",
+						"profile_name": "Platform Engineering",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Organization{Login: github.Ptr("platform"), Name: github.Ptr("Platform Engineering")}),
+					),
+				),
+			)),
+			requestArgs: map[string]interface{}{
+				"login":        "platform",
+				"admin":        "monalisa",
+				"profile_name": "Platform Engineering",
+			},
+			expectError: false,
+		},
+		{
+			name: "organization exists",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					postAdminOrganizations,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			)),
+			requestArgs: map[string]interface{}{
+				"login": "platform",
+				"admin": "monalisa",
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to create organization",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := CreateEnterpriseOrg(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned github.Organization
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, "platform", returned.GetLogin())
+			assert.Equal(t, "Platform Engineering", returned.GetName())
+		})
+	}
+}
+
+func Test_CreateEnterpriseUser(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateEnterpriseUser(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_user", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "login")
+	assert.Contains(t, tool.InputSchema.Properties, "email")
+	assert.Contains(t, tool.InputSchema.Properties, "suspended")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"login"})
+
+	tests := []struct {
+		name           string
+		client         *github.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "user created suspended",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					postAdminUsers,
+					expectRequestBody(t, map[string]interface{}{
+						"login":     "hubot",
+						"email":     "hubot@example.com",
+						"suspended": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.User{Login: github.Ptr("hubot"), SuspendedAt: &github.Timestamp{}}),
+					),
+				),
+			)),
+			requestArgs: map[string]interface{}{
+				"login":     "hubot",
+				"email":     "hubot@example.com",
+				"suspended": true,
+			},
+			expectError: false,
+		},
+		{
+			name: "external authentication",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					postAdminUsers,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			)),
+			requestArgs: map[string]interface{}{
+				"login": "hubot",
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to create user",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := CreateEnterpriseUser(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned github.User
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, "hubot", returned.GetLogin())
+		})
+	}
+}
+
+func Test_SuspendUser(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SuspendUser(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "suspend_user", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.Contains(t, tool.InputSchema.Properties, "reason")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	tests := []struct {
+		name           string
+		client         *github.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "user suspended with a reason",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					putUserSuspended,
+					expectRequestBody(t, map[string]interface{}{
+						"reason": "Left the company",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			)),
+			requestArgs: map[string]interface{}{
+				"username": "hubot",
+				"reason":   "Left the company",
+			},
+			expectError:  false,
+			expectedText: "User hubot suspended",
+		},
+		{
+			name: "site administrators can't be suspended",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					putUserSuspended,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "You can't suspend a site administrator"}),
+				),
+			)),
+			requestArgs: map[string]interface{}{
+				"username": "monalisa",
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to suspend user",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := SuspendUser(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_UnsuspendUser(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UnsuspendUser(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "unsuspend_user", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.NotContains(t, tool.InputSchema.Properties, "reason")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	client := enterpriseServerClient(t, mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			deleteUserSuspended,
+			mockResponse(t, http.StatusNoContent, nil),
+		),
+	))
+	_, handler := UnsuspendUser(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"username": "hubot",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "User hubot unsuspended", getTextResult(t, result).Text)
+}
+
+func Test_DeleteEnterpriseUser(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteEnterpriseUser(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_user", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	tests := []struct {
+		name           string
+		client         *github.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "user deleted",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					deleteAdminUser,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			)),
+			expectError:  false,
+			expectedText: "User hubot deleted",
+		},
+		{
+			name:           "connected to GitHub.com",
+			client:         github.NewClient(mock.NewMockedHTTPClient()),
+			expectError:    false,
+			expectedErrMsg: "site administration is only available on GitHub Enterprise Server",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := DeleteEnterpriseUser(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"username": "hubot",
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_PromoteUserToAdmin(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := PromoteUserToAdmin(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "promote_user_to_admin", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	client := enterpriseServerClient(t, mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			putUserSiteAdmin,
+			mockResponse(t, http.StatusNoContent, nil),
+		),
+	))
+	_, handler := PromoteUserToAdmin(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"username": "hubot",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "User hubot promoted to site administrator", getTextResult(t, result).Text)
+}
+
+func Test_DemoteAdminToUser(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DemoteAdminToUser(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "demote_admin_to_user", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	tests := []struct {
+		name           string
+		client         *github.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "admin demoted",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					deleteUserSiteAdmin,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			)),
+			expectError:  false,
+			expectedText: "User hubot demoted to a regular user",
+		},
+		{
+			name: "server error",
+			client: enterpriseServerClient(t, mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					deleteUserSiteAdmin,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Internal Server Error"}),
+				),
+			)),
+			expectError:    true,
+			expectedErrMsg: "failed to demote user",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := DemoteAdminToUser(stubGetClientFn(tc.client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"username": "hubot",
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
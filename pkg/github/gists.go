@@ -0,0 +1,171 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// gistRevision is a revision of a gist and how much it changed the gist.
+type gistRevision struct {
+	Version      string             `json:"version"`
+	CommittedAt  *github.Timestamp  `json:"committed_at"`
+	Author       string             `json:"author"`
+	ChangeStatus gistRevisionChange `json:"change_status"`
+}
+
+type gistRevisionChange struct {
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+	Total     int `json:"total"`
+}
+
+// gistFile is a file of a gist, with its content.
+type gistFile struct {
+	Filename string `json:"filename"`
+	Language string `json:"language"`
+	Size     int    `json:"size"`
+	Content  string `json:"content"`
+}
+
+// ListGistRevisions creates a tool to list the revisions of a gist.
+func ListGistRevisions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_gist_revisions",
+			mcp.WithDescription(t("TOOL_LIST_GIST_REVISIONS_DESCRIPTION", "List the revisions of a gist, most recent first, with how many lines each one added and deleted. Pass the version of a revision to get_gist to see its files")),
+			mcp.WithString("gist_id",
+				mcp.Required(),
+				mcp.Description("ID of the gist"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			gistID, err := requiredParam[string](request, "gist_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			commits, resp, err := client.Gists.ListCommits(ctx, gistID, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("gist %s not found", gistID)), nil
+				}
+				return nil, fmt.Errorf("failed to list gist revisions: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			revisions := make([]gistRevision, 0, len(commits))
+			for _, commit := range commits {
+				revisions = append(revisions, gistRevision{
+					Version:     commit.GetVersion(),
+					CommittedAt: commit.CommittedAt,
+					Author:      commit.GetUser().GetLogin(),
+					ChangeStatus: gistRevisionChange{
+						Additions: commit.GetChangeStatus().GetAdditions(),
+						Deletions: commit.GetChangeStatus().GetDeletions(),
+						Total:     commit.GetChangeStatus().GetTotal(),
+					},
+				})
+			}
+
+			r, err := json.Marshal(revisions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetGist creates a tool to get a gist with the content of its files, at its latest or a given revision.
+func GetGist(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_gist",
+			mcp.WithDescription(t("TOOL_GET_GIST_DESCRIPTION", "Get a gist with the content of its files, as of its latest revision or a given one")),
+			mcp.WithString("gist_id",
+				mcp.Required(),
+				mcp.Description("ID of the gist"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Version of the revision to get, as returned by list_gist_revisions. Defaults to the latest revision"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			gistID, err := requiredParam[string](request, "gist_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			var gist *github.Gist
+			var resp *github.Response
+			if sha != "" {
+				gist, resp, err = client.Gists.GetRevision(ctx, gistID, sha)
+			} else {
+				gist, resp, err = client.Gists.Get(ctx, gistID)
+			}
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					if sha != "" {
+						return mcp.NewToolResultError(fmt.Sprintf("revision %s of gist %s not found", sha, gistID)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("gist %s not found", gistID)), nil
+				}
+				return nil, fmt.Errorf("failed to get gist: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			files := make([]gistFile, 0, len(gist.Files))
+			for _, file := range gist.Files {
+				files = append(files, gistFile{
+					Filename: file.GetFilename(),
+					Language: file.GetLanguage(),
+					Size:     file.GetSize(),
+					Content:  file.GetContent(),
+				})
+			}
+			slices.SortFunc(files, func(a, b gistFile) int {
+				return strings.Compare(a.Filename, b.Filename)
+			})
+
+			r, err := json.Marshal(map[string]interface{}{
+				"id":          gist.GetID(),
+				"description": gist.GetDescription(),
+				"public":      gist.GetPublic(),
+				"owner":       gist.GetOwner().GetLogin(),
+				"html_url":    gist.GetHTMLURL(),
+				"created_at":  gist.CreatedAt,
+				"updated_at":  gist.UpdatedAt,
+				"files":       files,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,279 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListGistRevisions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListGistRevisions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_gist_revisions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "gist_id")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"gist_id"})
+
+	committedAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	mockCommits := []*github.GistCommit{
+		{
+			Version:      github.Ptr("57a7f021a713b1c5a6a199b54cc514735d2d462f"),
+			User:         &github.User{Login: github.Ptr("octocat")},
+			ChangeStatus: &github.CommitStats{Additions: github.Ptr(3), Deletions: github.Ptr(1), Total: github.Ptr(4)},
+			CommittedAt:  &github.Timestamp{Time: committedAt},
+		},
+		{
+			Version:      github.Ptr("4e7d4a1b0c16d4b6e9f0ad2a9e0f1c1d5a6b7c8d"),
+			User:         &github.User{Login: github.Ptr("octocat")},
+			ChangeStatus: &github.CommitStats{Additions: github.Ptr(10), Deletions: github.Ptr(0), Total: github.Ptr(10)},
+			CommittedAt:  &github.Timestamp{Time: committedAt.Add(-24 * time.Hour)},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedRevisions []gistRevision
+	}{
+		{
+			name: "list revisions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetGistsCommitsByGistId,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "2",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockCommits),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"gist_id": "aa5a315d61ae9438b18d",
+				"page":    float64(2),
+				"perPage": float64(2),
+			},
+			expectError: false,
+			expectedRevisions: []gistRevision{
+				{
+					Version:      "57a7f021a713b1c5a6a199b54cc514735d2d462f",
+					CommittedAt:  &github.Timestamp{Time: committedAt},
+					Author:       "octocat",
+					ChangeStatus: gistRevisionChange{Additions: 3, Deletions: 1, Total: 4},
+				},
+				{
+					Version:      "4e7d4a1b0c16d4b6e9f0ad2a9e0f1c1d5a6b7c8d",
+					CommittedAt:  &github.Timestamp{Time: committedAt.Add(-24 * time.Hour)},
+					Author:       "octocat",
+					ChangeStatus: gistRevisionChange{Additions: 10, Deletions: 0, Total: 10},
+				},
+			},
+		},
+		{
+			name: "gist not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetGistsCommitsByGistId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"gist_id": "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "gist missing not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListGistRevisions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var revisions []gistRevision
+			err = json.Unmarshal([]byte(textContent.Text), &revisions)
+			require.NoError(t, err)
+			require.Len(t, revisions, len(tc.expectedRevisions))
+			for i, revision := range revisions {
+				assert.Equal(t, tc.expectedRevisions[i].Version, revision.Version)
+				assert.True(t, tc.expectedRevisions[i].CommittedAt.Equal(*revision.CommittedAt))
+				assert.Equal(t, tc.expectedRevisions[i].Author, revision.Author)
+				assert.Equal(t, tc.expectedRevisions[i].ChangeStatus, revision.ChangeStatus)
+			}
+		})
+	}
+}
+
+func Test_GetGist(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetGist(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_gist", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "gist_id")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"gist_id"})
+
+	mockGist := func(content string) *github.Gist {
+		return &github.Gist{
+			ID:          github.Ptr("aa5a315d61ae9438b18d"),
+			Description: github.Ptr("Hello world"),
+			Public:      github.Ptr(true),
+			Owner:       &github.User{Login: github.Ptr("octocat")},
+			HTMLURL:     github.Ptr("https://gist.github.com/aa5a315d61ae9438b18d"),
+			Files: map[github.GistFilename]github.GistFile{
+				"hello.py":  {Filename: github.Ptr("hello.py"), Language: github.Ptr("Python"), Size: github.Ptr(len(content)), Content: github.Ptr(content)},
+				"README.md": {Filename: github.Ptr("README.md"), Language: github.Ptr("Markdown"), Size: github.Ptr(7), Content: github.Ptr("# Hello")},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedFiles  []gistFile
+	}{
+		{
+			name: "latest revision",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetGistsByGistId,
+					mockGist(`print("hello, world")`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"gist_id": "aa5a315d61ae9438b18d",
+			},
+			expectError: false,
+			expectedFiles: []gistFile{
+				{Filename: "README.md", Language: "Markdown", Size: 7, Content: "# Hello"},
+				{Filename: "hello.py", Language: "Python", Size: 21, Content: `print("hello, world")`},
+			},
+		},
+		{
+			name: "given revision",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetGistsByGistIdBySha,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/gists/aa5a315d61ae9438b18d/4e7d4a1b", r.URL.Path)
+						mockResponse(t, http.StatusOK, mockGist(`print("hello")`))(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"gist_id": "aa5a315d61ae9438b18d",
+				"sha":     "4e7d4a1b",
+			},
+			expectError: false,
+			expectedFiles: []gistFile{
+				{Filename: "README.md", Language: "Markdown", Size: 7, Content: "# Hello"},
+				{Filename: "hello.py", Language: "Python", Size: 14, Content: `print("hello")`},
+			},
+		},
+		{
+			name: "revision not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetGistsByGistIdBySha,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"gist_id": "aa5a315d61ae9438b18d",
+				"sha":     "deadbeef",
+			},
+			expectError:    false,
+			expectedErrMsg: "revision deadbeef of gist aa5a315d61ae9438b18d not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetGist(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var gist struct {
+				ID    string     `json:"id"`
+				Owner string     `json:"owner"`
+				Files []gistFile `json:"files"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &gist)
+			require.NoError(t, err)
+			assert.Equal(t, "aa5a315d61ae9438b18d", gist.ID)
+			assert.Equal(t, "octocat", gist.Owner)
+			assert.Equal(t, tc.expectedFiles, gist.Files)
+		})
+	}
+}
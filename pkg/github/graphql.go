@@ -0,0 +1,88 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v69/github"
+)
+
+// graphQLRequest is the body of a request to the GitHub GraphQL API.
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLError is an error reported by the GitHub GraphQL API.
+type graphQLError struct {
+	Type    string `json:"type,omitempty"`
+	Message string `json:"message"`
+}
+
+// graphQLErrors are the errors reported in the body of a GraphQL response, returned by executeGraphQL so that
+// callers can tell them apart by type.
+type graphQLErrors []graphQLError
+
+func (e graphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+	return "GraphQL query failed: " + strings.Join(messages, "; ")
+}
+
+// isGraphQLNotFound reports whether err has a GraphQL error of type NOT_FOUND, which the API reports for an owner,
+// repository or other object the query looks up that doesn't exist.
+func isGraphQLNotFound(err error) bool {
+	var errs graphQLErrors
+	return errors.As(err, &errs) && slices.ContainsFunc(errs, func(e graphQLError) bool {
+		return e.Type == "NOT_FOUND"
+	})
+}
+
+// graphQLResponse is the body of a response from the GitHub GraphQL API.
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors graphQLErrors   `json:"errors,omitempty"`
+}
+
+// graphQLURL returns the GraphQL endpoint of the API the client points at.
+// GitHub Enterprise Server serves GraphQL from /api/graphql rather than below the /api/v3/ REST root.
+func graphQLURL(client *github.Client) string {
+	u := *client.BaseURL
+	if strings.HasSuffix(u.Path, "/api/v3/") {
+		u.Path = strings.TrimSuffix(u.Path, "v3/") + "graphql"
+		return u.String()
+	}
+	u.Path += "graphql"
+	return u.String()
+}
+
+// executeGraphQL runs a GraphQL query with the given variables and decodes the returned data into v.
+// Errors reported in the response body are returned as graphQLErrors, since the API answers them with a 200.
+func executeGraphQL(ctx context.Context, client *github.Client, query string, variables map[string]interface{}, v interface{}) error {
+	req, err := client.NewRequest("POST", graphQLURL(client), &graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return fmt.Errorf("failed to create GraphQL request: %w", err)
+	}
+
+	var result graphQLResponse
+	resp, err := client.Do(ctx, req, &result)
+	if err != nil {
+		return fmt.Errorf("failed to execute GraphQL query: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if len(result.Errors) > 0 {
+		return result.Errors
+	}
+
+	if err := json.Unmarshal(result.Data, v); err != nil {
+		return fmt.Errorf("failed to decode GraphQL response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GraphQLURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseURL  string
+		expected string
+	}{
+		{
+			name:     "github.com",
+			baseURL:  "https://api.github.com/",
+			expected: "https://api.github.com/graphql",
+		},
+		{
+			name:     "GitHub Enterprise Server",
+			baseURL:  "https://github.example.com/api/v3/",
+			expected: "https://github.example.com/api/graphql",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(nil)
+			baseURL, err := url.Parse(tc.baseURL)
+			require.NoError(t, err)
+			client.BaseURL = baseURL
+
+			assert.Equal(t, tc.expected, graphQLURL(client))
+		})
+	}
+}
+
+func Test_ExecuteGraphQL(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectNotFound bool
+		expectedLogin  string
+	}{
+		{
+			name: "successful query",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/graphql",
+						Method:  "POST",
+					},
+					expectRequestBody(t, map[string]interface{}{
+						"query":     "query($login: String!) { user(login: $login) { login } }",
+						"variables": map[string]interface{}{"login": "octocat"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]interface{}{
+							"data": map[string]interface{}{"user": map[string]interface{}{"login": "octocat"}},
+						}),
+					),
+				),
+			),
+			expectError:   false,
+			expectedLogin: "octocat",
+		},
+		{
+			name: "errors in response body",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/graphql",
+						Method:  "POST",
+					},
+					mockResponse(t, http.StatusOK, map[string]interface{}{
+						"data":   map[string]interface{}{"user": nil},
+						"errors": []map[string]interface{}{{"type": "NOT_FOUND", "message": "Could not resolve to a User with the login of 'octocat'."}},
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "GraphQL query failed: Could not resolve to a User with the login of 'octocat'.",
+			expectNotFound: true,
+		},
+		{
+			name: "errors of another type",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/graphql",
+						Method:  "POST",
+					},
+					mockResponse(t, http.StatusOK, map[string]interface{}{
+						"data":   nil,
+						"errors": []map[string]interface{}{{"type": "FORBIDDEN", "message": "Resource not accessible by integration"}},
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "GraphQL query failed: Resource not accessible by integration",
+			expectNotFound: false,
+		},
+		{
+			name: "request fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/graphql",
+						Method:  "POST",
+					},
+					mockResponse(t, http.StatusUnauthorized, map[string]string{"message": "Bad credentials"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to execute GraphQL query",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+
+			var data struct {
+				User struct {
+					Login string `json:"login"`
+				} `json:"user"`
+			}
+			err := executeGraphQL(context.Background(), client, "query($login: String!) { user(login: $login) { login } }", map[string]interface{}{"login": "octocat"}, &data)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				assert.Equal(t, tc.expectNotFound, isGraphQLNotFound(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedLogin, data.User.Login)
+		})
+	}
+}
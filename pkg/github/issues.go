@@ -1,17 +1,25 @@
 package github
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+	"unicode"
 
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v69/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
 )
 
 // GetIssue creates a tool to get details of a specific issue in a GitHub repository.
@@ -683,6 +691,907 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
+var (
+	// fullReferencePattern matches references to issues and pull requests in any repository, e.g. owner/repo#123
+	fullReferencePattern = regexp.MustCompile(`(?:^|[^\w/.-])([A-Za-z0-9][A-Za-z0-9-]*)/([A-Za-z0-9._-]+)#(\d+)\b`)
+	// shortReferencePattern matches references to issues and pull requests in the same repository, e.g. #123
+	shortReferencePattern = regexp.MustCompile(`(?:^|[^\w/#])#(\d+)\b`)
+	// commitReferencePattern matches abbreviated or full commit SHAs
+	commitReferencePattern = regexp.MustCompile(`\b[0-9a-f]{7,40}\b`)
+	// urlPattern matches http and https URLs
+	urlPattern = regexp.MustCompile(`https?://[^\s<>()\[\]"']+`)
+)
+
+// maxCrossReferences caps the number of references that get resolved against the API.
+const maxCrossReferences = 50
+
+// crossReference is a reference to an issue, pull request or commit found in an issue's text.
+type crossReference struct {
+	Type   string `json:"type"`
+	Owner  string `json:"owner"`
+	Repo   string `json:"repo"`
+	Number int    `json:"number,omitempty"`
+	SHA    string `json:"sha,omitempty"`
+	Title  string `json:"title,omitempty"`
+	State  string `json:"state,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// extractCrossReferences finds the issue, pull request and commit references as well as the external URLs
+// in the given texts. References without an explicit repository are attributed to owner/repo.
+// The results are deduplicated and returned in order of first appearance.
+func extractCrossReferences(owner, repo string, texts []string) ([]crossReference, []string) {
+	var refs []crossReference
+	var urls []string
+	seen := map[string]bool{}
+
+	for _, text := range texts {
+		for _, u := range urlPattern.FindAllString(text, -1) {
+			u = strings.TrimRight(u, ".,;:!?")
+			if !seen[u] {
+				seen[u] = true
+				urls = append(urls, u)
+			}
+		}
+		// Drop the URLs so their paths and fragments aren't mistaken for references
+		text = urlPattern.ReplaceAllString(text, " ")
+
+		addRef := func(ref crossReference, key string) {
+			if !seen[key] {
+				seen[key] = true
+				refs = append(refs, ref)
+			}
+		}
+		for _, m := range fullReferencePattern.FindAllStringSubmatch(text, -1) {
+			number, _ := strconv.Atoi(m[3])
+			addRef(crossReference{Owner: m[1], Repo: m[2], Number: number}, strings.ToLower(fmt.Sprintf("%s/%s#%d", m[1], m[2], number)))
+		}
+		text = fullReferencePattern.ReplaceAllString(text, " ")
+		for _, m := range shortReferencePattern.FindAllStringSubmatch(text, -1) {
+			number, _ := strconv.Atoi(m[1])
+			addRef(crossReference{Owner: owner, Repo: repo, Number: number}, strings.ToLower(fmt.Sprintf("%s/%s#%d", owner, repo, number)))
+		}
+		for _, sha := range commitReferencePattern.FindAllString(text, -1) {
+			// Skip plain numbers, which are far more likely than a SHA made only of digits
+			if !strings.ContainsAny(sha, "abcdef") {
+				continue
+			}
+			addRef(crossReference{Type: "commit", Owner: owner, Repo: repo, SHA: sha}, strings.ToLower(fmt.Sprintf("%s/%s@%s", owner, repo, sha)))
+		}
+	}
+	return refs, urls
+}
+
+// GetIssueCrossReferences creates a tool to find the issues, pull requests and commits mentioned in an issue.
+func GetIssueCrossReferences(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_issue_cross_references",
+			mcp.WithDescription(t("TOOL_GET_ISSUE_CROSS_REFERENCES_DESCRIPTION", "Find all issues, pull requests and commits mentioned in the body and comments of an issue, plus any external URLs")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			issue, resp, err := client.Issues.Get(ctx, owner, repo, issueNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue: %s", string(body))), nil
+			}
+
+			texts := []string{issue.GetBody()}
+			opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+			for {
+				comments, resp, err := client.Issues.ListComments(ctx, owner, repo, issueNumber, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get issue comments: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, c := range comments {
+					texts = append(texts, c.GetBody())
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			refs, urls := extractCrossReferences(owner, repo, texts)
+
+			// Issues and pull requests that mention this issue back show in its timeline, which resolves them
+			// without a request each
+			crossReferenced := map[string]*github.Issue{}
+			timelineOpts := &github.ListOptions{PerPage: 100}
+			for {
+				events, resp, err := client.Issues.ListIssueTimeline(ctx, owner, repo, issueNumber, timelineOpts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get issue timeline: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, event := range events {
+					if event.GetEvent() != "cross-referenced" || event.GetSource().GetIssue() == nil {
+						continue
+					}
+					source := event.GetSource().GetIssue()
+					crossReferenced[strings.ToLower(fmt.Sprintf("%s#%d", searchResultRepository(source), source.GetNumber()))] = source
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				timelineOpts.Page = resp.NextPage
+			}
+
+			// Resolve each reference to find out whether it exists and whether it is an issue or a pull request
+			resolved := []crossReference{}
+			unresolved := []crossReference{}
+			for i, ref := range refs {
+				if i >= maxCrossReferences {
+					unresolved = append(unresolved, refs[i:]...)
+					break
+				}
+				if ref.Number == issueNumber && strings.EqualFold(ref.Owner, owner) && strings.EqualFold(ref.Repo, repo) {
+					continue
+				}
+				if ref.Type == "commit" {
+					commit, _, err := client.Repositories.GetCommit(ctx, ref.Owner, ref.Repo, ref.SHA, nil)
+					if err != nil {
+						// A SHA that matches no commit is answered with a 422 rather than a 404
+						if isGitHubErrorStatus(err, http.StatusNotFound) || isGitHubErrorStatus(err, http.StatusUnprocessableEntity) {
+							unresolved = append(unresolved, ref)
+							continue
+						}
+						return nil, fmt.Errorf("failed to get commit %s/%s@%s: %w", ref.Owner, ref.Repo, ref.SHA, err)
+					}
+					ref.SHA = commit.GetSHA()
+					ref.Title, _, _ = strings.Cut(commit.GetCommit().GetMessage(), "\n")
+					ref.URL = commit.GetHTMLURL()
+					resolved = append(resolved, ref)
+					continue
+				}
+				referenced, ok := crossReferenced[strings.ToLower(fmt.Sprintf("%s/%s#%d", ref.Owner, ref.Repo, ref.Number))]
+				if !ok {
+					referenced, _, err = client.Issues.Get(ctx, ref.Owner, ref.Repo, ref.Number)
+					if err != nil {
+						if !isGitHubErrorStatus(err, http.StatusNotFound) {
+							return nil, fmt.Errorf("failed to get issue %s/%s#%d: %w", ref.Owner, ref.Repo, ref.Number, err)
+						}
+						ref.Type = "unknown"
+						unresolved = append(unresolved, ref)
+						continue
+					}
+				}
+				ref.Type = "issue"
+				if referenced.IsPullRequest() {
+					ref.Type = "pr"
+				}
+				ref.Title = referenced.GetTitle()
+				ref.State = referenced.GetState()
+				ref.URL = referenced.GetHTMLURL()
+				resolved = append(resolved, ref)
+			}
+
+			if urls == nil {
+				urls = []string{}
+			}
+			result := map[string]interface{}{
+				"references":            resolved,
+				"unresolved_references": unresolved,
+				"external_references":   urls,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+const (
+	// maxBulkConcurrency bounds the number of API calls the bulk tools have in flight at once.
+	maxBulkConcurrency = 5
+	// maxBulkIssues bounds the number of issues a single bulk tool call may touch.
+	maxBulkIssues = 50
+)
+
+// bulkIssueResult is the outcome of a bulk operation on a single issue.
+type bulkIssueResult struct {
+	IssueNumber   int      `json:"issue_number"`
+	Success       bool     `json:"success"`
+	LabelsApplied []string `json:"labels_applied,omitempty"`
+	Error         string   `json:"error,omitempty"`
+}
+
+// requiredBulkIssueNumbers fetches the issue numbers of a bulk tool call and checks they are within maxBulkIssues.
+func requiredBulkIssueNumbers(r mcp.CallToolRequest) ([]int, error) {
+	issueNumbers, err := RequiredIntArrayParam(r, "issue_numbers")
+	if err != nil {
+		return nil, err
+	}
+	if len(issueNumbers) > maxBulkIssues {
+		return nil, fmt.Errorf("at most %d issue numbers can be processed at once, got %d", maxBulkIssues, len(issueNumbers))
+	}
+	return issueNumbers, nil
+}
+
+// forEachConcurrently calls fn for every item with at most maxBulkConcurrency calls in flight and returns the
+// error of each call, in the order of items. fn is handed the index of its item, to record what it fetched in a
+// slice of the same length. A failure on one item doesn't stop the others, and items still waiting when ctx is
+// done get its error.
+func forEachConcurrently[T any](ctx context.Context, items []T, fn func(ctx context.Context, i int, item T) error) []error {
+	errs := make([]error, len(items))
+	sem := make(chan struct{}, maxBulkConcurrency)
+	var wg sync.WaitGroup
+
+	for i, item := range items {
+		wg.Add(1)
+		go func(i int, item T) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			errs[i] = fn(ctx, i, item)
+		}(i, item)
+	}
+
+	wg.Wait()
+	return errs
+}
+
+// runConcurrently calls each of fns like forEachConcurrently calls fn for items, for fetching independent
+// sources at once, and returns the error of each call in the order of fns.
+func runConcurrently(ctx context.Context, fns ...func(ctx context.Context) error) []error {
+	return forEachConcurrently(ctx, fns, func(ctx context.Context, _ int, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+}
+
+// forEachIssueConcurrently calls fn for every issue number with at most maxBulkConcurrency calls in flight.
+// fn may record extra details in the result it is handed. A failure on one issue doesn't stop the others;
+// the outcomes are returned in the order of issueNumbers.
+func forEachIssueConcurrently(ctx context.Context, issueNumbers []int, fn func(ctx context.Context, issueNumber int, result *bulkIssueResult) error) []bulkIssueResult {
+	results := make([]bulkIssueResult, len(issueNumbers))
+	for i, issueNumber := range issueNumbers {
+		results[i].IssueNumber = issueNumber
+	}
+	errs := forEachConcurrently(ctx, issueNumbers, func(ctx context.Context, i, issueNumber int) error {
+		return fn(ctx, issueNumber, &results[i])
+	})
+	for i, err := range errs {
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Success = true
+	}
+	return results
+}
+
+// listAllLabels returns every label of a repository, following pagination.
+func listAllLabels(ctx context.Context, client *github.Client, owner, repo string) ([]*github.Label, error) {
+	var labels []*github.Label
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := client.Issues.ListLabels(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repository labels: %w", err)
+		}
+		_ = resp.Body.Close()
+		labels = append(labels, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return labels, nil
+}
+
+// findMissingLabels returns the labels that don't exist in the repository, matching names case-insensitively
+// like GitHub does.
+func findMissingLabels(ctx context.Context, client *github.Client, owner, repo string, labels []string) ([]string, error) {
+	repoLabels, err := listAllLabels(ctx, client, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]bool{}
+	for _, label := range repoLabels {
+		existing[strings.ToLower(label.GetName())] = true
+	}
+
+	missing := []string{}
+	for _, label := range labels {
+		if !existing[strings.ToLower(label)] {
+			missing = append(missing, label)
+		}
+	}
+	return missing, nil
+}
+
+// BulkLabelIssues creates a tool to add and remove labels on multiple issues at once.
+func BulkLabelIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("bulk_label_issues",
+			mcp.WithDescription(t("TOOL_BULK_LABEL_ISSUES_DESCRIPTION", "Add and remove labels on up to 50 issues in a GitHub repository at once, reporting the outcome for each issue. All labels must already exist in the repository")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("issue_numbers",
+				mcp.Required(),
+				mcp.Description("Numbers of the issues to label (max 50)"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "number",
+					},
+				),
+			),
+			mcp.WithArray("labels",
+				mcp.Description("Labels to add to each issue"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithArray("remove_labels",
+				mcp.Description("Labels to remove from each issue"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumbers, err := requiredBulkIssueNumbers(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			labels, err := OptionalStringArrayParam(request, "labels")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			removeLabels, err := OptionalStringArrayParam(request, "remove_labels")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(labels) == 0 && len(removeLabels) == 0 {
+				return mcp.NewToolResultError("at least one of labels or remove_labels must be provided"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Check the labels up front so a typo doesn't leave the batch partially applied
+			missing, err := findMissingLabels(ctx, client, owner, repo, append(append([]string{}, labels...), removeLabels...))
+			if err != nil {
+				return nil, err
+			}
+			if len(missing) > 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("labels do not exist in %s/%s: %s", owner, repo, strings.Join(missing, ", "))), nil
+			}
+
+			results := forEachIssueConcurrently(ctx, issueNumbers, func(ctx context.Context, issueNumber int, result *bulkIssueResult) error {
+				if len(labels) > 0 {
+					_, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, issueNumber, labels)
+					if err != nil {
+						return fmt.Errorf("failed to add labels: %w", err)
+					}
+					_ = resp.Body.Close()
+					result.LabelsApplied = labels
+				}
+				for _, label := range removeLabels {
+					resp, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, issueNumber, label)
+					if err != nil {
+						return fmt.Errorf("failed to remove label %s: %w", label, err)
+					}
+					_ = resp.Body.Close()
+				}
+				return nil
+			})
+
+			r, err := json.Marshal(results)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// bulkAssigneeOptions returns the parameters of the bulk assignee tools, verb saying what is done with the
+// assignees.
+func bulkAssigneeOptions(verb string) []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		),
+		mcp.WithArray("issue_numbers",
+			mcp.Required(),
+			mcp.Description("Numbers of the issues to update (max 50)"),
+			mcp.Items(
+				map[string]interface{}{
+					"type": "number",
+				},
+			),
+		),
+		mcp.WithArray("assignees",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("Usernames to %s", verb)),
+			mcp.Items(
+				map[string]interface{}{
+					"type": "string",
+				},
+			),
+		),
+	}
+}
+
+// bulkAssigneeParams returns the repository, issues and assignees given to a bulk assignee tool.
+func bulkAssigneeParams(request mcp.CallToolRequest) (owner, repo string, issueNumbers []int, assignees []string, err error) {
+	if owner, err = requiredParam[string](request, "owner"); err != nil {
+		return "", "", nil, nil, err
+	}
+	if repo, err = requiredParam[string](request, "repo"); err != nil {
+		return "", "", nil, nil, err
+	}
+	if issueNumbers, err = requiredBulkIssueNumbers(request); err != nil {
+		return "", "", nil, nil, err
+	}
+	if assignees, err = OptionalStringArrayParam(request, "assignees"); err != nil {
+		return "", "", nil, nil, err
+	}
+	if len(assignees) == 0 {
+		return "", "", nil, nil, fmt.Errorf("missing required parameter: assignees")
+	}
+	return owner, repo, issueNumbers, assignees, nil
+}
+
+// BulkAddAssignee creates a tool to add assignees to multiple issues at once.
+func BulkAddAssignee(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{mcp.WithDescription(t("TOOL_BULK_ADD_ASSIGNEE_DESCRIPTION", "Add assignees to up to 50 issues in a GitHub repository at once, reporting the outcome for each issue"))}
+	return mcp.NewTool("bulk_add_assignee", append(options, bulkAssigneeOptions("add")...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, issueNumbers, assignees, err := bulkAssigneeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			results := forEachIssueConcurrently(ctx, issueNumbers, func(ctx context.Context, issueNumber int, _ *bulkIssueResult) error {
+				_, resp, err := client.Issues.AddAssignees(ctx, owner, repo, issueNumber, assignees)
+				if err != nil {
+					return fmt.Errorf("failed to add assignees: %w", err)
+				}
+				_ = resp.Body.Close()
+				return nil
+			})
+
+			r, err := json.Marshal(results)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// BulkRemoveAssignee creates a tool to remove assignees from multiple issues at once.
+func BulkRemoveAssignee(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{mcp.WithDescription(t("TOOL_BULK_REMOVE_ASSIGNEE_DESCRIPTION", "Remove assignees from up to 50 issues in a GitHub repository at once, reporting the outcome for each issue"))}
+	return mcp.NewTool("bulk_remove_assignee", append(options, bulkAssigneeOptions("remove")...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, issueNumbers, assignees, err := bulkAssigneeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			results := forEachIssueConcurrently(ctx, issueNumbers, func(ctx context.Context, issueNumber int, _ *bulkIssueResult) error {
+				_, resp, err := client.Issues.RemoveAssignees(ctx, owner, repo, issueNumber, assignees)
+				if err != nil {
+					return fmt.Errorf("failed to remove assignees: %w", err)
+				}
+				_ = resp.Body.Close()
+				return nil
+			})
+
+			r, err := json.Marshal(results)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// bulkCloseScopeQualifiers are the search qualifiers that would widen a bulk_close_issues search beyond its repository.
+var bulkCloseScopeQualifiers = []string{"repo:", "org:", "user:"}
+
+// BulkCloseIssues creates a tool to close the open issues of a repository matching a search query.
+func BulkCloseIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("bulk_close_issues",
+			mcp.WithDescription(t("TOOL_BULK_CLOSE_ISSUES_DESCRIPTION", fmt.Sprintf("Close up to %d open issues of a GitHub repository matching a search query, such as 'label:stale updated:<2024-01-01', optionally commenting on each first, and report the outcome for each issue. With dry_run, only list the issues that would be closed. When more issues match, capped is set and the call can be repeated", maxBulkIssues))),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Issue search qualifiers, such as 'label:stale updated:<2024-01-01'. The search is limited to the open issues of the repository, so repo:, org: and user: qualifiers are not allowed"),
+			),
+			mcp.WithString("state_reason",
+				mcp.Description("Reason for closing the issues. Defaults to not_planned"),
+				mcp.Enum("completed", "not_planned"),
+			),
+			mcp.WithString("comment",
+				mcp.Description("Comment to add to each issue before closing it"),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.Required(),
+				mcp.Description("Only list the issues that would be closed, without changing anything"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err := requiredParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			stateReason, err := OptionalParam[string](request, "state_reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			comment, err := OptionalParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dryRun, ok, err := OptionalParamOK[bool](request, "dry_run")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				return mcp.NewToolResultError("missing required parameter: dry_run"), nil
+			}
+			if stateReason == "" {
+				stateReason = "not_planned"
+			}
+			if stateReason != "completed" && stateReason != "not_planned" {
+				return mcp.NewToolResultError(fmt.Sprintf("state_reason must be completed or not_planned, got %q", stateReason)), nil
+			}
+			for _, field := range strings.Fields(strings.ToLower(query)) {
+				for _, qualifier := range bulkCloseScopeQualifiers {
+					if strings.HasPrefix(strings.TrimPrefix(field, "-"), qualifier) {
+						return mcp.NewToolResultError(fmt.Sprintf("query must not contain %s qualifiers, the search is limited to %s/%s", strings.TrimSuffix(qualifier, ":"), owner, repo)), nil
+					}
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			search := fmt.Sprintf("repo:%s/%s is:issue is:open %s", owner, repo, query)
+			found, resp, err := client.Search.Issues(ctx, search, &github.SearchOptions{ListOptions: github.ListOptions{PerPage: maxBulkIssues}})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid search query %q: %s", query, err)), nil
+				}
+				return nil, fmt.Errorf("failed to search issues: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			matched := make([]map[string]interface{}, 0, len(found.Issues))
+			issueNumbers := make([]int, 0, len(found.Issues))
+			for _, issue := range found.Issues {
+				matched = append(matched, map[string]interface{}{
+					"number":     issue.GetNumber(),
+					"title":      issue.GetTitle(),
+					"updated_at": issue.UpdatedAt,
+					"html_url":   issue.GetHTMLURL(),
+				})
+				issueNumbers = append(issueNumbers, issue.GetNumber())
+			}
+			result := map[string]interface{}{
+				"query":       search,
+				"total_count": found.GetTotal(),
+				"capped":      found.GetTotal() > len(issueNumbers),
+				"dry_run":     dryRun,
+				"issues":      matched,
+			}
+
+			if !dryRun {
+				result["results"] = forEachIssueConcurrently(ctx, issueNumbers, func(ctx context.Context, issueNumber int, _ *bulkIssueResult) error {
+					if comment != "" {
+						_, resp, err := client.Issues.CreateComment(ctx, owner, repo, issueNumber, &github.IssueComment{Body: github.Ptr(comment)})
+						if err != nil {
+							return fmt.Errorf("failed to comment: %w", err)
+						}
+						_ = resp.Body.Close()
+					}
+					_, resp, err := client.Issues.Edit(ctx, owner, repo, issueNumber, &github.IssueRequest{
+						State:       github.Ptr("closed"),
+						StateReason: github.Ptr(stateReason),
+					})
+					if err != nil {
+						return fmt.Errorf("failed to close issue: %w", err)
+					}
+					_ = resp.Body.Close()
+					return nil
+				})
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// maxBatchIssues bounds the number of issues batch_create_issues creates in a single call.
+const maxBatchIssues = 20
+
+// batchIssueResult is the outcome of creating a single issue of a batch.
+type batchIssueResult struct {
+	Index       int    `json:"index"`
+	IssueNumber int    `json:"issue_number,omitempty"`
+	HTMLURL     string `json:"html_url,omitempty"`
+	Success     bool   `json:"success"`
+	Error       string `json:"error,omitempty"`
+}
+
+// batchIssueRequests turns the issues parameter of batch_create_issues into issue requests, validating
+// every issue so that nothing is created when one of them is invalid.
+func batchIssueRequests(r mcp.CallToolRequest) ([]*github.IssueRequest, error) {
+	issuesObj, ok := r.Params.Arguments["issues"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("issues parameter must be an array of objects with a title")
+	}
+	if len(issuesObj) == 0 {
+		return nil, fmt.Errorf("issues must contain at least one issue")
+	}
+	if len(issuesObj) > maxBatchIssues {
+		return nil, fmt.Errorf("at most %d issues can be created at once, got %d", maxBatchIssues, len(issuesObj))
+	}
+
+	requests := make([]*github.IssueRequest, 0, len(issuesObj))
+	for i, obj := range issuesObj {
+		issueMap, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("issue %d must be an object with a title", i)
+		}
+		title, ok := issueMap["title"].(string)
+		if !ok || title == "" {
+			return nil, fmt.Errorf("issue %d must have a title", i)
+		}
+		issueRequest := &github.IssueRequest{Title: github.Ptr(title)}
+
+		if body, ok := issueMap["body"]; ok {
+			bodyStr, ok := body.(string)
+			if !ok {
+				return nil, fmt.Errorf("issue %d: body must be a string", i)
+			}
+			issueRequest.Body = github.Ptr(bodyStr)
+		}
+		for _, field := range []string{"labels", "assignees"} {
+			value, ok := issueMap[field]
+			if !ok {
+				continue
+			}
+			items, ok := value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("issue %d: %s must be an array of strings", i, field)
+			}
+			strs := make([]string, 0, len(items))
+			for _, item := range items {
+				s, ok := item.(string)
+				if !ok || s == "" {
+					return nil, fmt.Errorf("issue %d: %s must be non-empty strings", i, field)
+				}
+				strs = append(strs, s)
+			}
+			if field == "labels" {
+				issueRequest.Labels = &strs
+			} else {
+				issueRequest.Assignees = &strs
+			}
+		}
+		if milestone, ok := issueMap["milestone_number"]; ok {
+			number, ok := milestone.(float64)
+			if !ok || number <= 0 || number != float64(int(number)) {
+				return nil, fmt.Errorf("issue %d: milestone_number must be a positive integer", i)
+			}
+			issueRequest.Milestone = github.Ptr(int(number))
+		}
+		requests = append(requests, issueRequest)
+	}
+	return requests, nil
+}
+
+// BatchCreateIssues creates a tool to create multiple issues in a repository at once.
+func BatchCreateIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("batch_create_issues",
+			mcp.WithDescription(t("TOOL_BATCH_CREATE_ISSUES_DESCRIPTION", "Create up to 20 issues in a GitHub repository at once, reporting the outcome for each issue. Issues that were created are kept when others fail")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("issues",
+				mcp.Required(),
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"title"},
+						"properties": map[string]interface{}{
+							"title": map[string]interface{}{
+								"type":        "string",
+								"description": "Issue title",
+							},
+							"body": map[string]interface{}{
+								"type":        "string",
+								"description": "Issue body content",
+							},
+							"labels": map[string]interface{}{
+								"type":        "array",
+								"items":       map[string]interface{}{"type": "string"},
+								"description": "Labels to apply to the issue",
+							},
+							"assignees": map[string]interface{}{
+								"type":        "array",
+								"items":       map[string]interface{}{"type": "string"},
+								"description": "Usernames to assign to the issue",
+							},
+							"milestone_number": map[string]interface{}{
+								"type":        "number",
+								"description": "Milestone number",
+							},
+						},
+					}),
+				mcp.Description("The issues to create (max 20), each object with a title (string) and an optional body (string), labels (string[]), assignees (string[]) and milestone_number (number)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueRequests, err := batchIssueRequests(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Created issues are never rolled back, so every issue reports its own outcome
+			results := make([]batchIssueResult, len(issueRequests))
+			errs := forEachConcurrently(ctx, issueRequests, func(ctx context.Context, i int, issueRequest *github.IssueRequest) error {
+				issue, resp, err := client.Issues.Create(ctx, owner, repo, issueRequest)
+				if err != nil {
+					return fmt.Errorf("failed to create issue: %w", err)
+				}
+				_ = resp.Body.Close()
+				results[i].IssueNumber = issue.GetNumber()
+				results[i].HTMLURL = issue.GetHTMLURL()
+				return nil
+			})
+			for i, err := range errs {
+				results[i].Index = i
+				if err != nil {
+					results[i].Error = err.Error()
+					continue
+				}
+				results[i].Success = true
+			}
+
+			created := 0
+			for _, result := range results {
+				if result.Success {
+					created++
+				}
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"results":       results,
+				"created_count": created,
+				"failed_count":  len(results) - created,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 // parseISOTimestamp parses an ISO 8601 timestamp string into a time.Time object.
 // Returns the parsed time or an error if parsing fails.
 // Example formats supported: "2023-01-15T14:30:00Z", "2023-01-15"
@@ -691,18 +1600,1009 @@ func parseISOTimestamp(timestamp string) (time.Time, error) {
 		return time.Time{}, fmt.Errorf("empty timestamp")
 	}
 
-	// Try RFC3339 format (standard ISO 8601 with time)
-	t, err := time.Parse(time.RFC3339, timestamp)
-	if err == nil {
-		return t, nil
+	// Try RFC3339 format (standard ISO 8601 with time)
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err == nil {
+		return t, nil
+	}
+
+	// Try simple date format (YYYY-MM-DD)
+	t, err = time.Parse("2006-01-02", timestamp)
+	if err == nil {
+		return t, nil
+	}
+
+	// Return error with supported formats
+	return time.Time{}, fmt.Errorf("invalid ISO 8601 timestamp: %s (supported formats: YYYY-MM-DDThh:mm:ssZ or YYYY-MM-DD)", timestamp)
+}
+
+// issueReferencesQuery fetches the cross-reference events of an issue or pull request's timeline,
+// which record every issue and pull request that mentioned it.
+const issueReferencesQuery = `query($owner: String!, $repo: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $repo) {
+    issueOrPullRequest(number: $number) {
+      ... on Issue {
+        timelineItems(first: 100, after: $cursor, itemTypes: [CROSS_REFERENCED_EVENT]) {
+          pageInfo { hasNextPage endCursor }
+          nodes { ...crossReference }
+        }
+      }
+      ... on PullRequest {
+        timelineItems(first: 100, after: $cursor, itemTypes: [CROSS_REFERENCED_EVENT]) {
+          pageInfo { hasNextPage endCursor }
+          nodes { ...crossReference }
+        }
+      }
+    }
+  }
+}
+
+fragment crossReference on CrossReferencedEvent {
+  referencedAt
+  willCloseTarget
+  isCrossRepository
+  actor { login }
+  source {
+    __typename
+    ... on Issue { number title state url repository { nameWithOwner } }
+    ... on PullRequest { number title state url repository { nameWithOwner } }
+  }
+}`
+
+// issueBackReference is an issue or pull request that references another one.
+type issueBackReference struct {
+	Type            string `json:"type"`
+	Repository      string `json:"repository"`
+	Number          int    `json:"number"`
+	Title           string `json:"title"`
+	State           string `json:"state"`
+	URL             string `json:"url"`
+	CrossRepository bool   `json:"cross_repository"`
+	WillClose       bool   `json:"will_close"`
+	ReferencedAt    string `json:"referenced_at"`
+	Actor           string `json:"actor,omitempty"`
+}
+
+// ListIssueReferences creates a tool to list the issues and pull requests that reference an issue.
+func ListIssueReferences(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_issue_references",
+			mcp.WithDescription(t("TOOL_LIST_ISSUE_REFERENCES_DESCRIPTION", "List the issues and pull requests, in any repository, that reference an issue or pull request")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			references := []issueBackReference{}
+			variables := map[string]interface{}{
+				"owner":  owner,
+				"repo":   repo,
+				"number": issueNumber,
+			}
+			for {
+				var data struct {
+					Repository struct {
+						IssueOrPullRequest struct {
+							TimelineItems struct {
+								PageInfo struct {
+									HasNextPage bool   `json:"hasNextPage"`
+									EndCursor   string `json:"endCursor"`
+								} `json:"pageInfo"`
+								Nodes []struct {
+									ReferencedAt      string `json:"referencedAt"`
+									WillCloseTarget   bool   `json:"willCloseTarget"`
+									IsCrossRepository bool   `json:"isCrossRepository"`
+									Actor             *struct {
+										Login string `json:"login"`
+									} `json:"actor"`
+									Source struct {
+										Typename   string `json:"__typename"`
+										Number     int    `json:"number"`
+										Title      string `json:"title"`
+										State      string `json:"state"`
+										URL        string `json:"url"`
+										Repository struct {
+											NameWithOwner string `json:"nameWithOwner"`
+										} `json:"repository"`
+									} `json:"source"`
+								} `json:"nodes"`
+							} `json:"timelineItems"`
+						} `json:"issueOrPullRequest"`
+					} `json:"repository"`
+				}
+				if err := executeGraphQL(ctx, client, issueReferencesQuery, variables, &data); err != nil {
+					if isGraphQLNotFound(err) {
+						return mcp.NewToolResultError(fmt.Sprintf("issue %s/%s#%d not found", owner, repo, issueNumber)), nil
+					}
+					return nil, fmt.Errorf("failed to get issue references: %w", err)
+				}
+
+				timeline := data.Repository.IssueOrPullRequest.TimelineItems
+				for _, node := range timeline.Nodes {
+					ref := issueBackReference{
+						Repository:      node.Source.Repository.NameWithOwner,
+						Number:          node.Source.Number,
+						Title:           node.Source.Title,
+						State:           strings.ToLower(node.Source.State),
+						URL:             node.Source.URL,
+						CrossRepository: node.IsCrossRepository,
+						WillClose:       node.WillCloseTarget,
+						ReferencedAt:    node.ReferencedAt,
+					}
+					switch node.Source.Typename {
+					case "Issue":
+						ref.Type = "issue"
+					case "PullRequest":
+						ref.Type = "pull_request"
+					default:
+						continue
+					}
+					if node.Actor != nil {
+						ref.Actor = node.Actor.Login
+					}
+					references = append(references, ref)
+				}
+
+				if !timeline.PageInfo.HasNextPage {
+					break
+				}
+				variables["cursor"] = timeline.PageInfo.EndCursor
+			}
+
+			r, err := json.Marshal(references)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+const (
+	// defaultMaxLabelSuggestions is how many labels suggest_issue_labels returns unless asked otherwise.
+	defaultMaxLabelSuggestions = 5
+
+	// labelNameWeight and labelDescriptionWeight score a keyword found in a label's name higher than
+	// one only found in its description.
+	labelNameWeight        = 2
+	labelDescriptionWeight = 1
+)
+
+// labelStopWords are common words that say nothing about which label fits an issue.
+var labelStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "this": true, "that": true, "from": true,
+	"when": true, "not": true, "are": true, "was": true, "but": true, "have": true, "has": true,
+	"can": true, "into": true, "does": true, "should": true, "would": true, "will": true, "there": true,
+	"what": true, "which": true, "some": true, "any": true, "all": true, "its": true, "our": true,
+	"you": true, "your": true, "issue": true, "issues": true, "something": true, "isn": true,
+}
+
+// labelSuggestion is a label ranked by how well it matches an issue.
+type labelSuggestion struct {
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	Score           int      `json:"score"`
+	MatchedKeywords []string `json:"matched_keywords"`
+}
+
+// labelKeywords splits text into lowercase keywords, dropping short words and stop words.
+func labelKeywords(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	keywords := make([]string, 0, len(words))
+	for _, w := range words {
+		if len(w) < 3 || labelStopWords[w] {
+			continue
+		}
+		keywords = append(keywords, w)
+	}
+	return keywords
+}
+
+// keywordsMatch checks if two keywords are the same word, allowing for short suffixes such as plurals,
+// so "bug" matches "bugs" and "crash" matches "crashes".
+func keywordsMatch(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return strings.HasPrefix(b, a) && len(b)-len(a) <= 2
+}
+
+// containsKeyword checks if any of the keywords matches the given one.
+func containsKeyword(keywords []string, keyword string) bool {
+	for _, k := range keywords {
+		if keywordsMatch(k, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// rankLabelSuggestions scores every label by the overlap between the keywords of its name and description
+// and those of the issue. A keyword from the label's name counts twice as much as one from its description,
+// and a match in the issue title counts twice as much as one only in the body. Labels without any match are
+// left out; the rest are ordered by score, then name.
+func rankLabelSuggestions(labels []*github.Label, title, body string) []labelSuggestion {
+	titleKeywords := labelKeywords(title)
+	bodyKeywords := labelKeywords(body)
+
+	suggestions := []labelSuggestion{}
+	for _, label := range labels {
+		weights := map[string]int{}
+		var keywords []string
+		for _, k := range labelKeywords(label.GetDescription()) {
+			if _, ok := weights[k]; !ok {
+				keywords = append(keywords, k)
+			}
+			weights[k] = labelDescriptionWeight
+		}
+		for _, k := range labelKeywords(label.GetName()) {
+			if _, ok := weights[k]; !ok {
+				keywords = append(keywords, k)
+			}
+			weights[k] = labelNameWeight
+		}
+
+		suggestion := labelSuggestion{
+			Name:            label.GetName(),
+			Description:     label.GetDescription(),
+			MatchedKeywords: []string{},
+		}
+		for _, k := range keywords {
+			switch {
+			case containsKeyword(titleKeywords, k):
+				suggestion.Score += 2 * weights[k]
+			case containsKeyword(bodyKeywords, k):
+				suggestion.Score += weights[k]
+			default:
+				continue
+			}
+			suggestion.MatchedKeywords = append(suggestion.MatchedKeywords, k)
+		}
+		if suggestion.Score > 0 {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+
+	slices.SortFunc(suggestions, func(a, b labelSuggestion) int {
+		if a.Score != b.Score {
+			return cmp.Compare(b.Score, a.Score)
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return suggestions
+}
+
+// SuggestIssueLabels creates a tool to suggest existing repository labels for an issue.
+func SuggestIssueLabels(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("suggest_issue_labels",
+			mcp.WithDescription(t("TOOL_SUGGEST_ISSUE_LABELS_DESCRIPTION", "Suggest existing labels of a repository for an issue, ranked by how many keywords of the issue title and body match the label names and descriptions. This is a keyword heuristic, not a classifier")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Issue title"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Issue body"),
+			),
+			mcp.WithNumber("max_suggestions",
+				mcp.Description(fmt.Sprintf("Maximum number of labels to suggest, defaults to %d", defaultMaxLabelSuggestions)),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := requiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := OptionalParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxSuggestions, err := OptionalIntParamWithDefault(request, "max_suggestions", defaultMaxLabelSuggestions)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxSuggestions < 1 {
+				return mcp.NewToolResultError("max_suggestions must be at least 1"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			labels, err := listAllLabels(ctx, client, owner, repo)
+			if err != nil {
+				return nil, err
+			}
+
+			suggestions := rankLabelSuggestions(labels, title, body)
+			if len(suggestions) > maxSuggestions {
+				suggestions = suggestions[:maxSuggestions]
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"labels_considered": len(labels),
+				"suggestions":       suggestions,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// issueTemplateConfigPath is where GitHub looks for the configuration of a repository's issue template chooser.
+const issueTemplateConfigPath = ".github/ISSUE_TEMPLATE/config.yml"
+
+// issueTemplateContactLink is a link the issue template chooser shows alongside the templates,
+// such as a support forum.
+type issueTemplateContactLink struct {
+	Name  string `yaml:"name" json:"name"`
+	URL   string `yaml:"url" json:"url"`
+	About string `yaml:"about" json:"about"`
+}
+
+// issueTemplateConfig is the configuration of a repository's issue template chooser.
+type issueTemplateConfig struct {
+	BlankIssuesEnabled *bool                      `yaml:"blank_issues_enabled"`
+	ContactLinks       []issueTemplateContactLink `yaml:"contact_links"`
+}
+
+// GetIssueTemplateConfig creates a tool to get the configuration of a repository's issue template chooser.
+func GetIssueTemplateConfig(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_issue_template_config",
+			mcp.WithDescription(t("TOOL_GET_ISSUE_TEMPLATE_CONFIG_DESCRIPTION", "Get the configuration of a repository's issue template chooser from .github/ISSUE_TEMPLATE/config.yml: whether blank issues are allowed and the contact links shown")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var config issueTemplateConfig
+			configFound := true
+			fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, issueTemplateConfigPath, nil)
+			switch {
+			case isGitHubErrorStatus(err, http.StatusNotFound):
+				// Without a configuration, GitHub allows blank issues and shows no contact links
+				configFound = false
+			case err != nil:
+				return nil, fmt.Errorf("failed to get issue template config: %w", err)
+			default:
+				defer func() { _ = resp.Body.Close() }()
+
+				if resp.StatusCode != http.StatusOK || fileContent == nil {
+					body, err := io.ReadAll(resp.Body)
+					if err != nil {
+						return nil, fmt.Errorf("failed to read response body: %w", err)
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get issue template config: %s", string(body))), nil
+				}
+
+				content, err := fileContent.GetContent()
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode issue template config: %w", err)
+				}
+				if err := yaml.Unmarshal([]byte(content), &config); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to parse %s: %s", issueTemplateConfigPath, err)), nil
+				}
+			}
+
+			contactLinks := config.ContactLinks
+			if contactLinks == nil {
+				contactLinks = []issueTemplateContactLink{}
+			}
+			result := map[string]interface{}{
+				"config_found":         configFound,
+				"blank_issues_enabled": config.BlankIssuesEnabled == nil || *config.BlankIssuesEnabled,
+				"contact_links":        contactLinks,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// copiedLabel is a label copy_labels_between_repos created or updated in the target repository.
+type copiedLabel struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// failedLabel is a label copy_labels_between_repos couldn't copy.
+type failedLabel struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// oldestOpenSummary is what get_oldest_open returns for the oldest open issue or pull request.
+func oldestOpenSummary(number int, title, user string, createdAt github.Timestamp, htmlURL string) map[string]interface{} {
+	return map[string]interface{}{
+		"number":     number,
+		"title":      title,
+		"user":       user,
+		"created_at": createdAt,
+		"age_days":   int(time.Since(createdAt.Time).Hours() / 24),
+		"html_url":   htmlURL,
+	}
+}
+
+// GetOldestOpen creates a tool to get the oldest open issue or pull request of a repository.
+func GetOldestOpen(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_oldest_open",
+			mcp.WithDescription(t("TOOL_GET_OLDEST_OPEN_DESCRIPTION", "Get the oldest issue or pull request still open in a repository, with its age in days")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("type",
+				mcp.Required(),
+				mcp.Description("Whether to get the oldest open issue or pull request"),
+				mcp.Enum("issue", "pr"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			itemType, err := requiredParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if itemType != "issue" && itemType != "pr" {
+				return mcp.NewToolResultError("type must be issue or pr"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var result map[string]interface{}
+			if itemType == "pr" {
+				// Pull requests can be listed on their own, so the first one is the oldest
+				prs, resp, err := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+					State:       "open",
+					Sort:        "created",
+					Direction:   "asc",
+					ListOptions: github.ListOptions{PerPage: 1},
+				})
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to list pull requests: %w", err)
+				}
+				_ = resp.Body.Close()
+
+				if len(prs) == 0 {
+					return mcp.NewToolResultText(fmt.Sprintf("no open pull requests in %s/%s", owner, repo)), nil
+				}
+				oldest := prs[0]
+				result = oldestOpenSummary(oldest.GetNumber(), oldest.GetTitle(), oldest.GetUser().GetLogin(), oldest.GetCreatedAt(), oldest.GetHTMLURL())
+			} else {
+				// The issues listing includes pull requests, told apart by their pull_request field
+				opts := &github.IssueListByRepoOptions{
+					State:       "open",
+					Sort:        "created",
+					Direction:   "asc",
+					ListOptions: github.ListOptions{PerPage: 100},
+				}
+				var oldest *github.Issue
+				for oldest == nil {
+					issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+					if err != nil {
+						if resp != nil && resp.StatusCode == http.StatusNotFound {
+							return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+						}
+						return nil, fmt.Errorf("failed to list issues: %w", err)
+					}
+					_ = resp.Body.Close()
+
+					for _, issue := range issues {
+						if !issue.IsPullRequest() {
+							oldest = issue
+							break
+						}
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+
+				if oldest == nil {
+					return mcp.NewToolResultText(fmt.Sprintf("no open issues in %s/%s", owner, repo)), nil
+				}
+				result = oldestOpenSummary(oldest.GetNumber(), oldest.GetTitle(), oldest.GetUser().GetLogin(), oldest.GetCreatedAt(), oldest.GetHTMLURL())
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CopyLabelsBetweenRepos creates a tool to copy the labels of a repository to another.
+func CopyLabelsBetweenRepos(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("copy_labels_between_repos",
+			mcp.WithDescription(t("TOOL_COPY_LABELS_BETWEEN_REPOS_DESCRIPTION", "Copy labels, with their colors and descriptions, from a repository to another, such as to bootstrap a new repository with an organization's standard labels. Labels already in the target repository are skipped unless overwrite_existing is true. Reports the labels copied, skipped and failed")),
+			mcp.WithString("source_owner",
+				mcp.Required(),
+				mcp.Description("Owner of the repository to copy the labels from"),
+			),
+			mcp.WithString("source_repo",
+				mcp.Required(),
+				mcp.Description("Name of the repository to copy the labels from"),
+			),
+			mcp.WithString("target_owner",
+				mcp.Required(),
+				mcp.Description("Owner of the repository to copy the labels to"),
+			),
+			mcp.WithString("target_repo",
+				mcp.Required(),
+				mcp.Description("Name of the repository to copy the labels to"),
+			),
+			mcp.WithBoolean("overwrite_existing",
+				mcp.Description("Update the color and description of the labels the target repository already has, instead of skipping them. Defaults to false"),
+			),
+			mcp.WithArray("label_names",
+				mcp.Description("Names of the labels to copy. If not provided, every label is copied"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			sourceOwner, err := requiredParam[string](request, "source_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sourceRepo, err := requiredParam[string](request, "source_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetOwner, err := requiredParam[string](request, "target_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetRepo, err := requiredParam[string](request, "target_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			overwrite, err := OptionalParam[bool](request, "overwrite_existing")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			labelNames, err := OptionalStringArrayParam(request, "label_names")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if strings.EqualFold(sourceOwner+"/"+sourceRepo, targetOwner+"/"+targetRepo) {
+				return mcp.NewToolResultError("the source and target repositories must differ"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			sourceLabels, err := listAllLabels(ctx, client, sourceOwner, sourceRepo)
+			if err != nil {
+				return nil, err
+			}
+			targetLabels, err := listAllLabels(ctx, client, targetOwner, targetRepo)
+			if err != nil {
+				return nil, err
+			}
+			// Label names are case-insensitive on GitHub
+			existing := map[string]string{}
+			for _, label := range targetLabels {
+				existing[strings.ToLower(label.GetName())] = label.GetName()
+			}
+
+			failed := []failedLabel{}
+			toCopy := sourceLabels
+			if len(labelNames) > 0 {
+				bySourceName := map[string]*github.Label{}
+				for _, label := range sourceLabels {
+					bySourceName[strings.ToLower(label.GetName())] = label
+				}
+				toCopy = nil
+				for _, name := range labelNames {
+					label, ok := bySourceName[strings.ToLower(name)]
+					if !ok {
+						failed = append(failed, failedLabel{Name: name, Error: fmt.Sprintf("label not found in %s/%s", sourceOwner, sourceRepo)})
+						continue
+					}
+					toCopy = append(toCopy, label)
+				}
+			}
+
+			alreadyThere := make([]bool, len(toCopy))
+			for i, label := range toCopy {
+				_, exists := existing[strings.ToLower(label.GetName())]
+				alreadyThere[i] = exists && !overwrite
+			}
+			errs := forEachConcurrently(ctx, toCopy, func(ctx context.Context, i int, label *github.Label) error {
+				if alreadyThere[i] {
+					return nil
+				}
+				copied := &github.Label{
+					Color:       github.Ptr(label.GetColor()),
+					Description: github.Ptr(label.GetDescription()),
+				}
+				var resp *github.Response
+				var err error
+				if existingName, exists := existing[strings.ToLower(label.GetName())]; exists {
+					// The label keeps the name it has in the target repository, which may differ in case
+					_, resp, err = client.Issues.EditLabel(ctx, targetOwner, targetRepo, existingName, copied)
+				} else {
+					copied.Name = github.Ptr(label.GetName())
+					_, resp, err = client.Issues.CreateLabel(ctx, targetOwner, targetRepo, copied)
+				}
+				if err != nil {
+					return err
+				}
+				_ = resp.Body.Close()
+				return nil
+			})
+
+			copiedLabels := []copiedLabel{}
+			skipped := []string{}
+			for i, label := range toCopy {
+				switch {
+				case alreadyThere[i]:
+					skipped = append(skipped, label.GetName())
+				case errs[i] != nil:
+					failed = append(failed, failedLabel{Name: label.GetName(), Error: errs[i].Error()})
+				default:
+					name := label.GetName()
+					if existingName, ok := existing[strings.ToLower(name)]; ok {
+						name = existingName
+					}
+					copiedLabels = append(copiedLabels, copiedLabel{
+						Name:        name,
+						Color:       label.GetColor(),
+						Description: label.GetDescription(),
+					})
+				}
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"copied":  copiedLabels,
+				"skipped": skipped,
+				"failed":  failed,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// reactionSearchSorts maps the reaction types issues can be ranked by to the search sort ordering by them. Search
+// can't sort by rocket or eyes reactions.
+var reactionSearchSorts = map[string]string{
+	"+1":       "reactions-+1",
+	"-1":       "reactions--1",
+	"laugh":    "reactions-smile",
+	"confused": "reactions-thinking_face",
+	"heart":    "reactions-heart",
+	"hooray":   "reactions-tada",
+}
+
+// reactionCount is the number of reactions of a type in a reaction rollup.
+func reactionCount(reactions *github.Reactions, reaction string) int {
+	switch reaction {
+	case "+1":
+		return reactions.GetPlusOne()
+	case "-1":
+		return reactions.GetMinusOne()
+	case "laugh":
+		return reactions.GetLaugh()
+	case "confused":
+		return reactions.GetConfused()
+	case "heart":
+		return reactions.GetHeart()
+	case "hooray":
+		return reactions.GetHooray()
 	}
+	return 0
+}
 
-	// Try simple date format (YYYY-MM-DD)
-	t, err = time.Parse("2006-01-02", timestamp)
-	if err == nil {
-		return t, nil
+// topReactedIssuesQuery is the search for the open issues of a repository with at least minReactions reactions,
+// and the sort ranking them by their reactions of a type. The reactions qualifier counts every type, so it only
+// narrows the search down: the issues it finds still need their reactions of the type counted.
+func topReactedIssuesQuery(owner, repo string, minReactions int, reaction string) (string, string, error) {
+	sort, ok := reactionSearchSorts[reaction]
+	if !ok {
+		return "", "", fmt.Errorf("reaction must be one of +1, -1, laugh, confused, heart or hooray, got %q", reaction)
+	}
+	if minReactions < 1 {
+		return "", "", fmt.Errorf("min_reactions must be at least 1, got %d", minReactions)
 	}
+	return fmt.Sprintf("repo:%s/%s is:issue is:open reactions:>=%d", owner, repo, minReactions), sort, nil
+}
 
-	// Return error with supported formats
-	return time.Time{}, fmt.Errorf("invalid ISO 8601 timestamp: %s (supported formats: YYYY-MM-DDThh:mm:ssZ or YYYY-MM-DD)", timestamp)
+// ListTopReactedIssues creates a tool to list the open issues of a repository with the most reactions of a type.
+func ListTopReactedIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_top_reacted_issues",
+			mcp.WithDescription(t("TOOL_LIST_TOP_REACTED_ISSUES_DESCRIPTION", "List the open issues of a repository with at least a number of reactions of a type, most reacted first, to find the issues users want most")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("min_reactions",
+				mcp.Description("Minimum number of reactions of the type an issue must have. Defaults to 1"),
+				mcp.Min(1),
+			),
+			mcp.WithString("reaction",
+				mcp.Description("Type of reaction to count. Defaults to +1"),
+				mcp.Enum("+1", "-1", "laugh", "confused", "heart", "hooray"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum number of issues to return, defaults to 30"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			minReactions, err := OptionalIntParamWithDefault(request, "min_reactions", 1)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reaction, err := OptionalParam[string](request, "reaction")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if reaction == "" {
+				reaction = "+1"
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if limit < 1 || limit > 100 {
+				return mcp.NewToolResultError("limit must be between 1 and 100"), nil
+			}
+			query, sort, err := topReactedIssuesQuery(owner, repo, minReactions, reaction)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			found, resp, err := client.Search.Issues(ctx, query, &github.SearchOptions{
+				Sort:        sort,
+				Order:       "desc",
+				ListOptions: github.ListOptions{PerPage: limit},
+			})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found or can't be searched", owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to search issues: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			// Issues are sorted by their reactions of the type, so the first with too few ends the list
+			issues := make([]map[string]interface{}, 0, len(found.Issues))
+			for _, issue := range found.Issues {
+				count := reactionCount(issue.GetReactions(), reaction)
+				if count < minReactions {
+					break
+				}
+				issues = append(issues, map[string]interface{}{
+					"number":          issue.GetNumber(),
+					"title":           issue.GetTitle(),
+					"reactions":       count,
+					"total_reactions": issue.GetReactions().GetTotalCount(),
+					"comments":        issue.GetComments(),
+					"created_at":      issue.CreatedAt,
+					"html_url":        issue.GetHTMLURL(),
+				})
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"reaction":      reaction,
+				"min_reactions": minReactions,
+				"issues":        issues,
+				"capped":        len(issues) == len(found.Issues) && resp.NextPage != 0,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// listIssueDependencies lists the issues an issue is blocked by or blocking, depending on relation, which is
+// blocked_by or blocking. go-github doesn't support the issue dependencies API yet, so the requests are built here.
+func listIssueDependencies(ctx context.Context, client *github.Client, owner, repo string, issueNumber int, relation string) ([]*github.Issue, error) {
+	var all []*github.Issue
+	for page := 1; page != 0; {
+		u := fmt.Sprintf("repos/%v/%v/issues/%d/dependencies/%s?per_page=100&page=%d", owner, repo, issueNumber, relation, page)
+		req, err := client.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		var issues []*github.Issue
+		resp, err := client.Do(ctx, req, &issues)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the issues %d is %s: %w", issueNumber, strings.ReplaceAll(relation, "_", " "), err)
+		}
+		_ = resp.Body.Close()
+		all = append(all, issues...)
+		page = resp.NextPage
+	}
+	return all, nil
+}
+
+// ListIssueDependencies creates a tool to list the issues an issue is blocked by and the issues it blocks.
+func ListIssueDependencies(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_issue_dependencies",
+			mcp.WithDescription(t("TOOL_LIST_ISSUE_DEPENDENCIES_DESCRIPTION", "List the issues an issue is blocked by and the issues it blocks, with their repository and state. Dependencies can be in other repositories, which cross_repo marks")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := map[string]interface{}{
+				"repository":   fmt.Sprintf("%s/%s", owner, repo),
+				"issue_number": issueNumber,
+			}
+			for _, relation := range []string{"blocked_by", "blocking"} {
+				issues, err := listIssueDependencies(ctx, client, owner, repo, issueNumber, relation)
+				if err != nil {
+					if isGitHubErrorStatus(err, http.StatusNotFound) {
+						return mcp.NewToolResultError(fmt.Sprintf("issue %d not found in %s/%s, or issue dependencies aren't available there", issueNumber, owner, repo)), nil
+					}
+					return nil, err
+				}
+				dependencies := make([]map[string]interface{}, 0, len(issues))
+				for _, issue := range issues {
+					repository := searchResultRepository(issue)
+					dependencies = append(dependencies, map[string]interface{}{
+						"repository": repository,
+						"number":     issue.GetNumber(),
+						"title":      issue.GetTitle(),
+						"state":      issue.GetState(),
+						"html_url":   issue.GetHTMLURL(),
+						"cross_repo": !strings.EqualFold(repository, fmt.Sprintf("%s/%s", owner, repo)),
+					})
+				}
+				result[relation] = dependencies
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
 }
@@ -2,8 +2,12 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -1130,3 +1134,2415 @@ func Test_GetIssueComments(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetIssueCrossReferences(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetIssueCrossReferences(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_issue_cross_references", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	issues := map[string]*github.Issue{
+		"/repos/owner/repo/issues/42": {
+			Number: github.Ptr(42),
+			Body:   github.Ptr("Related to #7 and other/project#3, see https://example.com/docs#12 and commit abc1234."),
+		},
+		"/repos/owner/repo/issues/7": {
+			Number:  github.Ptr(7),
+			Title:   github.Ptr("Original bug"),
+			State:   github.Ptr("closed"),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/issues/7"),
+		},
+	}
+	// other/project#3 mentions the issue back, so it is resolved from the timeline rather than fetched
+	mockTimeline := []*github.Timeline{
+		{Event: github.Ptr("labeled")},
+		{
+			Event: github.Ptr("cross-referenced"),
+			Source: &github.Source{
+				Type: github.Ptr("issue"),
+				Issue: &github.Issue{
+					Number:           github.Ptr(3),
+					Title:            github.Ptr("Upstream fix"),
+					State:            github.Ptr("open"),
+					HTMLURL:          github.Ptr("https://github.com/other/project/pull/3"),
+					RepositoryURL:    github.Ptr("https://api.github.com/repos/Other/Project"),
+					PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/other/project/pulls/3")},
+				},
+			},
+		},
+	}
+	issuesHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issue, ok := issues[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		mockResponse(t, http.StatusOK, issue)(w, r)
+	})
+
+	mockComments := []*github.IssueComment{
+		{Body: github.Ptr("Duplicate of #7, also see #99")},
+	}
+	mockCommit := &github.RepositoryCommit{
+		SHA:     github.Ptr("abc1234def5678"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc1234def5678"),
+		Commit:  &github.Commit{Message: github.Ptr("Fix the bug\n\nLonger description")},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedErrMsg     string
+		expectedReferences []crossReference
+		expectedUnresolved []crossReference
+		expectedExternal   []string
+	}{
+		{
+			name: "successful cross reference extraction",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					issuesHandler,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					mockComments,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+					mockTimeline,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					mockCommit,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectError: false,
+			expectedReferences: []crossReference{
+				{Type: "pr", Owner: "other", Repo: "project", Number: 3, Title: "Upstream fix", State: "open", URL: "https://github.com/other/project/pull/3"},
+				{Type: "issue", Owner: "owner", Repo: "repo", Number: 7, Title: "Original bug", State: "closed", URL: "https://github.com/owner/repo/issues/7"},
+				{Type: "commit", Owner: "owner", Repo: "repo", SHA: "abc1234def5678", Title: "Fix the bug", URL: "https://github.com/owner/repo/commit/abc1234def5678"},
+			},
+			expectedUnresolved: []crossReference{
+				{Type: "unknown", Owner: "owner", Repo: "repo", Number: 99},
+			},
+			expectedExternal: []string{"https://example.com/docs#12"},
+		},
+		{
+			name: "resolving a reference fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Path == "/repos/owner/repo/issues/7" {
+							mockResponse(t, http.StatusInternalServerError, `{"message": "Server Error"}`)(w, r)
+							return
+						}
+						issuesHandler(w, r)
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					[]*github.IssueComment{},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+					mockTimeline,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get issue owner/repo#7",
+		},
+		{
+			name: "timeline fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					issuesHandler,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					mockComments,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusForbidden, `{"message": "Forbidden"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get issue timeline",
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Issue not found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetIssueCrossReferences(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returned struct {
+				References           []crossReference `json:"references"`
+				UnresolvedReferences []crossReference `json:"unresolved_references"`
+				ExternalReferences   []string         `json:"external_references"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedReferences, returned.References)
+			assert.Equal(t, tc.expectedUnresolved, returned.UnresolvedReferences)
+			assert.Equal(t, tc.expectedExternal, returned.ExternalReferences)
+		})
+	}
+}
+
+func Test_forEachConcurrently(t *testing.T) {
+	t.Run("errors in the order of the items, within the concurrency bound", func(t *testing.T) {
+		var inFlight, maxInFlight int32
+		items := make([]int, 20)
+		for i := range items {
+			items[i] = i
+		}
+		seen := make([]int, len(items))
+
+		errs := forEachConcurrently(context.Background(), items, func(_ context.Context, i, item int) error {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				observed := atomic.LoadInt32(&maxInFlight)
+				if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			seen[i] = item
+			if item%2 == 1 {
+				return fmt.Errorf("item %d failed", item)
+			}
+			return nil
+		})
+
+		require.Len(t, errs, len(items))
+		for i, err := range errs {
+			assert.Equal(t, i, seen[i])
+			if i%2 == 1 {
+				assert.EqualError(t, err, fmt.Sprintf("item %d failed", i))
+			} else {
+				assert.NoError(t, err)
+			}
+		}
+		assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(maxBulkConcurrency))
+	})
+
+	t.Run("items still waiting when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		started := make(chan struct{}, maxBulkConcurrency)
+		release := make(chan struct{})
+		items := make([]int, maxBulkConcurrency+3)
+		var errs []error
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			errs = forEachConcurrently(ctx, items, func(_ context.Context, _ int, _ int) error {
+				started <- struct{}{}
+				<-release
+				return nil
+			})
+		}()
+
+		// Once every slot is taken, the remaining items can only see the context being cancelled
+		for i := 0; i < maxBulkConcurrency; i++ {
+			<-started
+		}
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		<-done
+
+		canceled := 0
+		for _, err := range errs {
+			if err != nil {
+				assert.ErrorIs(t, err, context.Canceled)
+				canceled++
+			}
+		}
+		assert.Equal(t, 3, canceled)
+	})
+}
+
+func Test_BulkLabelIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := BulkLabelIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "bulk_label_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_numbers")
+	assert.Contains(t, tool.InputSchema.Properties, "labels")
+	assert.Contains(t, tool.InputSchema.Properties, "remove_labels")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_numbers"})
+
+	mockLabels := []*github.Label{
+		{Name: github.Ptr("stale")},
+		{Name: github.Ptr("Needs-Triage")},
+	}
+
+	tooManyIssues := make([]any, maxBulkIssues+1)
+	for i := range tooManyIssues {
+		tooManyIssues[i] = float64(i + 1)
+	}
+
+	// failIssue responds with a 404 for the given issue number and succeeds for all others
+	failIssue := func(number string, success interface{}) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if strings.Contains(r.URL.Path, "/issues/"+number+"/") {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				return
+			}
+			mockResponse(t, http.StatusOK, success)(w, r)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedResults []bulkIssueResult
+	}{
+		{
+			name: "mixed success and failure",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposLabelsByOwnerByRepo,
+					mockLabels,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, []interface{}{"stale"}).andThen(
+						failIssue("2", []*github.Label{{Name: github.Ptr("stale")}}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+					failIssue("3", []*github.Label{}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1), float64(2), float64(3)},
+				"labels":        []any{"stale"},
+				"remove_labels": []any{"needs-triage"},
+			},
+			expectError: false,
+			expectedResults: []bulkIssueResult{
+				{IssueNumber: 1, Success: true, LabelsApplied: []string{"stale"}},
+				{IssueNumber: 2, Success: false, Error: "failed to add labels"},
+				{IssueNumber: 3, Success: false, LabelsApplied: []string{"stale"}, Error: "failed to remove label needs-triage"},
+			},
+		},
+		{
+			name: "labels missing from repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposLabelsByOwnerByRepo,
+					mockLabels,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1), float64(2)},
+				"labels":        []any{"stale", "wontfix"},
+				"remove_labels": []any{"needs-triage", "invalid"},
+			},
+			expectError:    false,
+			expectedErrMsg: "labels do not exist in owner/repo: wontfix, invalid",
+		},
+		{
+			name:         "too many issues",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": tooManyIssues,
+				"labels":        []any{"stale"},
+			},
+			expectError:    false,
+			expectedErrMsg: "at most 50 issue numbers can be processed at once, got 51",
+		},
+		{
+			name:         "no labels provided",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1)},
+			},
+			expectError:    false,
+			expectedErrMsg: "at least one of labels or remove_labels must be provided",
+		},
+		{
+			name:         "missing issue numbers",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"labels": []any{"stale"},
+			},
+			expectError:    false,
+			expectedErrMsg: "missing required parameter: issue_numbers",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := BulkLabelIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returnedResults []bulkIssueResult
+			err = json.Unmarshal([]byte(textContent.Text), &returnedResults)
+			require.NoError(t, err)
+			require.Len(t, returnedResults, len(tc.expectedResults))
+			for i, expected := range tc.expectedResults {
+				assert.Equal(t, expected.IssueNumber, returnedResults[i].IssueNumber)
+				assert.Equal(t, expected.Success, returnedResults[i].Success)
+				assert.Equal(t, expected.LabelsApplied, returnedResults[i].LabelsApplied)
+				assert.Contains(t, returnedResults[i].Error, expected.Error)
+			}
+		})
+	}
+
+	t.Run("bounded concurrency", func(t *testing.T) {
+		var inFlight, maxInFlight int32
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposLabelsByOwnerByRepo,
+				mockLabels,
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					current := atomic.AddInt32(&inFlight, 1)
+					defer atomic.AddInt32(&inFlight, -1)
+					for {
+						observed := atomic.LoadInt32(&maxInFlight)
+						if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+							break
+						}
+					}
+					time.Sleep(20 * time.Millisecond)
+					mockResponse(t, http.StatusOK, []*github.Label{{Name: github.Ptr("stale")}})(w, r)
+				}),
+			),
+		)
+
+		issueNumbers := make([]any, 20)
+		for i := range issueNumbers {
+			issueNumbers[i] = float64(i + 1)
+		}
+
+		client := github.NewClient(mockedClient)
+		_, handler := BulkLabelIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":         "owner",
+			"repo":          "repo",
+			"issue_numbers": issueNumbers,
+			"labels":        []any{"stale"},
+		}))
+		require.NoError(t, err)
+
+		var returnedResults []bulkIssueResult
+		err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedResults)
+		require.NoError(t, err)
+		require.Len(t, returnedResults, len(issueNumbers))
+		for _, r := range returnedResults {
+			assert.True(t, r.Success)
+		}
+		assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(maxBulkConcurrency))
+		assert.Greater(t, atomic.LoadInt32(&maxInFlight), int32(1))
+	})
+}
+
+func Test_BulkAddAssignee(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := BulkAddAssignee(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "bulk_add_assignee", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_numbers")
+	assert.Contains(t, tool.InputSchema.Properties, "assignees")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_numbers", "assignees"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectedErrMsg  string
+		expectedResults []bulkIssueResult
+	}{
+		{
+			name: "mixed success and failure",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]interface{}{"assignees": []interface{}{"octocat"}}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+							if strings.Contains(r.URL.Path, "/issues/2/") {
+								w.WriteHeader(http.StatusNotFound)
+								_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+								return
+							}
+							mockResponse(t, http.StatusCreated, &github.Issue{Number: github.Ptr(1)})(w, r)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1), float64(2)},
+				"assignees":     []any{"octocat"},
+			},
+			expectedResults: []bulkIssueResult{
+				{IssueNumber: 1, Success: true},
+				{IssueNumber: 2, Success: false, Error: "failed to add assignees"},
+			},
+		},
+		{
+			name:         "missing assignees",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1)},
+			},
+			expectedErrMsg: "missing required parameter: assignees",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := BulkAddAssignee(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returnedResults []bulkIssueResult
+			err = json.Unmarshal([]byte(textContent.Text), &returnedResults)
+			require.NoError(t, err)
+			require.Len(t, returnedResults, len(tc.expectedResults))
+			for i, expected := range tc.expectedResults {
+				assert.Equal(t, expected.IssueNumber, returnedResults[i].IssueNumber)
+				assert.Equal(t, expected.Success, returnedResults[i].Success)
+				assert.Contains(t, returnedResults[i].Error, expected.Error)
+			}
+		})
+	}
+}
+
+func Test_BulkRemoveAssignee(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := BulkRemoveAssignee(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "bulk_remove_assignee", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "issue_numbers")
+	assert.Contains(t, tool.InputSchema.Properties, "assignees")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_numbers", "assignees"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectedErrMsg  string
+		expectedResults []bulkIssueResult
+	}{
+		{
+			name: "successful removal",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]interface{}{"assignees": []interface{}{"octocat", "hubot"}}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(4), float64(5)},
+				"assignees":     []any{"octocat", "hubot"},
+			},
+			expectedResults: []bulkIssueResult{
+				{IssueNumber: 4, Success: true},
+				{IssueNumber: 5, Success: true},
+			},
+		},
+		{
+			name: "removal fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(4)},
+				"assignees":     []any{"octocat"},
+			},
+			expectedResults: []bulkIssueResult{
+				{IssueNumber: 4, Success: false, Error: "failed to remove assignees"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := BulkRemoveAssignee(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returnedResults []bulkIssueResult
+			err = json.Unmarshal([]byte(textContent.Text), &returnedResults)
+			require.NoError(t, err)
+			require.Len(t, returnedResults, len(tc.expectedResults))
+			for i, expected := range tc.expectedResults {
+				assert.Equal(t, expected.IssueNumber, returnedResults[i].IssueNumber)
+				assert.Equal(t, expected.Success, returnedResults[i].Success)
+				assert.Contains(t, returnedResults[i].Error, expected.Error)
+			}
+		})
+	}
+}
+
+func Test_BulkCloseIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := BulkCloseIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "bulk_close_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "state_reason")
+	assert.Contains(t, tool.InputSchema.Properties, "comment")
+	assert.Contains(t, tool.InputSchema.Properties, "dry_run")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "query", "dry_run"})
+
+	staleIssues := []*github.Issue{
+		{Number: github.Ptr(11), Title: github.Ptr("Old bug")},
+		{Number: github.Ptr(12), Title: github.Ptr("Old request")},
+		{Number: github.Ptr(13), Title: github.Ptr("Locked issue")},
+	}
+	searchHandler := func(total int, issues []*github.Issue) http.HandlerFunc {
+		return expectQueryParams(t, map[string]string{
+			"q":        "repo:owner/repo is:issue is:open label:stale updated:<2024-01-01",
+			"per_page": "50",
+		}).andThen(
+			mockResponse(t, http.StatusOK, &github.IssuesSearchResult{Total: github.Ptr(total), Issues: issues}),
+		)
+	}
+	mustNotMutate := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s %s during a dry run", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectedErrMsg  string
+		expectedIssues  []int
+		expectedCapped  bool
+		expectedResults []bulkIssueResult
+	}{
+		{
+			name: "dry run lists without closing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					searchHandler(120, staleIssues),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					mustNotMutate,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					mustNotMutate,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"query":   "label:stale updated:<2024-01-01",
+				"comment": "Closing as stale",
+				"dry_run": true,
+			},
+			expectedIssues: []int{11, 12, 13},
+			expectedCapped: true,
+		},
+		{
+			name: "close with a comment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					searchHandler(3, staleIssues),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Path == "/repos/owner/repo/issues/13/comments" {
+							mockResponse(t, http.StatusForbidden, map[string]string{"message": "Issue is locked"})(w, r)
+							return
+						}
+						expectRequestBody(t, map[string]interface{}{"body": "Closing as stale"}).andThen(
+							mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(1))}),
+						)(w, r)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"state":        "closed",
+						"state_reason": "not_planned",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{State: github.Ptr("closed")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"query":   "label:stale updated:<2024-01-01",
+				"comment": "Closing as stale",
+				"dry_run": false,
+			},
+			expectedIssues: []int{11, 12, 13},
+			expectedCapped: false,
+			expectedResults: []bulkIssueResult{
+				{IssueNumber: 11, Success: true},
+				{IssueNumber: 12, Success: true},
+				{IssueNumber: 13, Success: false, Error: "failed to comment"},
+			},
+		},
+		{
+			name:         "query reaching another repository",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"query":   "label:stale repo:other/repo",
+				"dry_run": true,
+			},
+			expectedErrMsg: "query must not contain repo qualifiers, the search is limited to owner/repo",
+		},
+		{
+			name:         "missing dry_run",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"query": "label:stale",
+			},
+			expectedErrMsg: "missing required parameter: dry_run",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := BulkCloseIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Capped bool `json:"capped"`
+				DryRun bool `json:"dry_run"`
+				Issues []struct {
+					Number int `json:"number"`
+				} `json:"issues"`
+				Results []bulkIssueResult `json:"results"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCapped, response.Capped)
+			issueNumbers := []int{}
+			for _, issue := range response.Issues {
+				issueNumbers = append(issueNumbers, issue.Number)
+			}
+			assert.Equal(t, tc.expectedIssues, issueNumbers)
+			require.Len(t, response.Results, len(tc.expectedResults))
+			for i, expected := range tc.expectedResults {
+				assert.Equal(t, expected.IssueNumber, response.Results[i].IssueNumber)
+				assert.Equal(t, expected.Success, response.Results[i].Success)
+				assert.Contains(t, response.Results[i].Error, expected.Error)
+			}
+		})
+	}
+
+	t.Run("bounded concurrency", func(t *testing.T) {
+		issues := make([]*github.Issue, maxBulkIssues)
+		for i := range issues {
+			issues[i] = &github.Issue{Number: github.Ptr(i + 1)}
+		}
+		var inFlight, maxInFlight int32
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetSearchIssues,
+				&github.IssuesSearchResult{Total: github.Ptr(len(issues)), Issues: issues},
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					current := atomic.AddInt32(&inFlight, 1)
+					defer atomic.AddInt32(&inFlight, -1)
+					for {
+						observed := atomic.LoadInt32(&maxInFlight)
+						if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+							break
+						}
+					}
+					time.Sleep(20 * time.Millisecond)
+					mockResponse(t, http.StatusOK, &github.Issue{State: github.Ptr("closed")})(w, r)
+				}),
+			),
+		)
+
+		client := github.NewClient(mockedClient)
+		_, handler := BulkCloseIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":        "owner",
+			"repo":         "repo",
+			"query":        "label:stale",
+			"state_reason": "completed",
+			"dry_run":      false,
+		}))
+		require.NoError(t, err)
+
+		var response struct {
+			Results []bulkIssueResult `json:"results"`
+		}
+		err = json.Unmarshal([]byte(getTextResult(t, result).Text), &response)
+		require.NoError(t, err)
+		require.Len(t, response.Results, len(issues))
+		for _, r := range response.Results {
+			assert.True(t, r.Success)
+		}
+		assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(maxBulkConcurrency))
+		assert.Greater(t, atomic.LoadInt32(&maxInFlight), int32(1))
+	})
+}
+
+func Test_BatchCreateIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := BatchCreateIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "batch_create_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issues")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issues"})
+
+	tooManyIssues := make([]any, maxBatchIssues+1)
+	for i := range tooManyIssues {
+		tooManyIssues[i] = map[string]any{"title": fmt.Sprintf("Issue %d", i)}
+	}
+
+	// createIssues creates an issue numbered after its title, and fails with a 422 for the given title
+	createIssues := func(failTitle string) http.HandlerFunc {
+		var created atomic.Int32
+		return func(w http.ResponseWriter, r *http.Request) {
+			var issueRequest github.IssueRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&issueRequest))
+			if issueRequest.GetTitle() == failTitle {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+				return
+			}
+			if issueRequest.GetTitle() == "Labelled" {
+				assert.Equal(t, []string{"bug"}, issueRequest.GetLabels())
+				assert.Equal(t, []string{"octocat"}, issueRequest.GetAssignees())
+				assert.Equal(t, 3, issueRequest.GetMilestone())
+			}
+			number := 100 + int(created.Add(1))
+			mockResponse(t, http.StatusCreated, &github.Issue{
+				Number:  github.Ptr(number),
+				Title:   issueRequest.Title,
+				HTMLURL: github.Ptr(fmt.Sprintf("https://github.com/owner/repo/issues/%d", number)),
+			})(w, r)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedResults []batchIssueResult
+		expectedCreated int
+		expectedFailed  int
+	}{
+		{
+			name: "all issues created",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesByOwnerByRepo,
+					createIssues(""),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"issues": []any{
+					map[string]any{"title": "Plain"},
+					map[string]any{"title": "Labelled", "body": "Details", "labels": []any{"bug"}, "assignees": []any{"octocat"}, "milestone_number": float64(3)},
+				},
+			},
+			expectError: false,
+			expectedResults: []batchIssueResult{
+				{Index: 0, Success: true},
+				{Index: 1, Success: true},
+			},
+			expectedCreated: 2,
+			expectedFailed:  0,
+		},
+		{
+			name: "partial failure keeps created issues",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesByOwnerByRepo,
+					createIssues("Broken"),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"issues": []any{
+					map[string]any{"title": "First"},
+					map[string]any{"title": "Broken"},
+					map[string]any{"title": "Third"},
+				},
+			},
+			expectError: false,
+			expectedResults: []batchIssueResult{
+				{Index: 0, Success: true},
+				{Index: 1, Success: false, Error: "failed to create issue"},
+				{Index: 2, Success: true},
+			},
+			expectedCreated: 2,
+			expectedFailed:  1,
+		},
+		{
+			name:         "issue without a title",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"issues": []any{
+					map[string]any{"title": "First"},
+					map[string]any{"body": "No title"},
+				},
+			},
+			expectError:    false,
+			expectedErrMsg: "issue 1 must have a title",
+		},
+		{
+			name:         "empty label",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"issues": []any{
+					map[string]any{"title": "First", "labels": []any{"bug", ""}},
+				},
+			},
+			expectError:    false,
+			expectedErrMsg: "issue 0: labels must be non-empty strings",
+		},
+		{
+			name:         "milestone not positive",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"issues": []any{
+					map[string]any{"title": "First", "milestone_number": float64(0)},
+				},
+			},
+			expectError:    false,
+			expectedErrMsg: "issue 0: milestone_number must be a positive integer",
+		},
+		{
+			name:         "too many issues",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"issues": tooManyIssues,
+			},
+			expectError:    false,
+			expectedErrMsg: "at most 20 issues can be created at once, got 21",
+		},
+		{
+			name:         "no issues",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"issues": []any{},
+			},
+			expectError:    false,
+			expectedErrMsg: "issues must contain at least one issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := BatchCreateIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var response struct {
+				Results      []batchIssueResult `json:"results"`
+				CreatedCount int                `json:"created_count"`
+				FailedCount  int                `json:"failed_count"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCreated, response.CreatedCount)
+			assert.Equal(t, tc.expectedFailed, response.FailedCount)
+			require.Len(t, response.Results, len(tc.expectedResults))
+			for i, expected := range tc.expectedResults {
+				returned := response.Results[i]
+				assert.Equal(t, expected.Index, returned.Index)
+				assert.Equal(t, expected.Success, returned.Success)
+				if expected.Success {
+					assert.NotZero(t, returned.IssueNumber)
+					assert.Equal(t, fmt.Sprintf("https://github.com/owner/repo/issues/%d", returned.IssueNumber), returned.HTMLURL)
+					assert.Empty(t, returned.Error)
+				} else {
+					assert.Zero(t, returned.IssueNumber)
+					assert.Contains(t, returned.Error, expected.Error)
+				}
+			}
+		})
+	}
+}
+
+func Test_ListIssueReferences(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListIssueReferences(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_issue_references", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	graphQLEndpoint := mock.EndpointPattern{
+		Pattern: "/graphql",
+		Method:  "POST",
+	}
+	timelineResponse := func(nodes []map[string]interface{}, hasNextPage bool) map[string]interface{} {
+		return map[string]interface{}{
+			"data": map[string]interface{}{
+				"repository": map[string]interface{}{
+					"issueOrPullRequest": map[string]interface{}{
+						"timelineItems": map[string]interface{}{
+							"pageInfo": map[string]interface{}{"hasNextPage": hasNextPage, "endCursor": "cursor1"},
+							"nodes":    nodes,
+						},
+					},
+				},
+			},
+		}
+	}
+	crossRepoPR := map[string]interface{}{
+		"referencedAt":      "2025-03-01T12:00:00Z",
+		"willCloseTarget":   true,
+		"isCrossRepository": true,
+		"actor":             map[string]interface{}{"login": "hubot"},
+		"source": map[string]interface{}{
+			"__typename": "PullRequest",
+			"number":     7,
+			"title":      "Fix the crash",
+			"state":      "MERGED",
+			"url":        "https://github.com/other/fork/pull/7",
+			"repository": map[string]interface{}{"nameWithOwner": "other/fork"},
+		},
+	}
+	sameRepoIssue := map[string]interface{}{
+		"referencedAt":      "2025-03-02T12:00:00Z",
+		"willCloseTarget":   false,
+		"isCrossRepository": false,
+		"actor":             map[string]interface{}{"login": "octocat"},
+		"source": map[string]interface{}{
+			"__typename": "Issue",
+			"number":     12,
+			"title":      "Follow-up",
+			"state":      "OPEN",
+			"url":        "https://github.com/owner/repo/issues/12",
+			"repository": map[string]interface{}{"nameWithOwner": "owner/repo"},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedErrMsg     string
+		expectedReferences []issueBackReference
+	}{
+		{
+			name: "references from another repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body graphQLRequest
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						assert.Equal(t, float64(42), body.Variables["number"])
+						// The second page is requested with the cursor of the first one
+						if body.Variables["cursor"] == nil {
+							mockResponse(t, http.StatusOK, timelineResponse([]map[string]interface{}{crossRepoPR}, true))(w, r)
+							return
+						}
+						assert.Equal(t, "cursor1", body.Variables["cursor"])
+						mockResponse(t, http.StatusOK, timelineResponse([]map[string]interface{}{sameRepoIssue}, false))(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectError: false,
+			expectedReferences: []issueBackReference{
+				{
+					Type:            "pull_request",
+					Repository:      "other/fork",
+					Number:          7,
+					Title:           "Fix the crash",
+					State:           "merged",
+					URL:             "https://github.com/other/fork/pull/7",
+					CrossRepository: true,
+					WillClose:       true,
+					ReferencedAt:    "2025-03-01T12:00:00Z",
+					Actor:           "hubot",
+				},
+				{
+					Type:         "issue",
+					Repository:   "owner/repo",
+					Number:       12,
+					Title:        "Follow-up",
+					State:        "open",
+					URL:          "https://github.com/owner/repo/issues/12",
+					ReferencedAt: "2025-03-02T12:00:00Z",
+					Actor:        "octocat",
+				},
+			},
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					graphQLEndpoint,
+					map[string]interface{}{
+						"data": map[string]interface{}{
+							"repository": map[string]interface{}{"issueOrPullRequest": nil},
+						},
+						"errors": []map[string]interface{}{{
+							"type":    "NOT_FOUND",
+							"path":    []string{"repository", "issueOrPullRequest"},
+							"message": "Could not resolve to an issue or pull request with the number of 999.",
+						}},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+			},
+			expectError:    false,
+			expectedErrMsg: "issue owner/repo#999 not found",
+		},
+		{
+			name: "query fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					graphQLEndpoint,
+					map[string]interface{}{
+						"errors": []map[string]interface{}{{"message": "Could not resolve to a Repository"}},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "missing",
+				"issue_number": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "Could not resolve to a Repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListIssueReferences(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returned []issueBackReference
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedReferences, returned)
+		})
+	}
+}
+
+func Test_SuggestIssueLabels(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SuggestIssueLabels(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "suggest_issue_labels", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "title")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.Contains(t, tool.InputSchema.Properties, "max_suggestions")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "title"})
+
+	mockLabels := []*github.Label{
+		{Name: github.Ptr("bug"), Description: github.Ptr("Something isn't working")},
+		{Name: github.Ptr("crash")},
+		{Name: github.Ptr("documentation"), Description: github.Ptr("Improvements or additions to documentation")},
+		{Name: github.Ptr("good first issue"), Description: github.Ptr("Good for newcomers")},
+		{Name: github.Ptr("performance"), Description: github.Ptr("Slow or resource hungry code paths")},
+		{Name: github.Ptr("ui"), Description: github.Ptr("User interface and styling")},
+	}
+
+	issueArgs := map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"title": "App crashes when saving the documentation page",
+		"body":  "Saving is slow and the bug reproduces every time.",
+	}
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		requestArgs         map[string]interface{}
+		expectError         bool
+		expectedErrMsg      string
+		expectedSuggestions []labelSuggestion
+	}{
+		{
+			name: "ranks labels by keyword overlap",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposLabelsByOwnerByRepo,
+					mockLabels,
+				),
+			),
+			requestArgs: issueArgs,
+			expectError: false,
+			expectedSuggestions: []labelSuggestion{
+				// Name matches in the title score highest, ties are broken by name
+				{Name: "crash", Description: "", Score: 4, MatchedKeywords: []string{"crash"}},
+				{Name: "documentation", Description: "Improvements or additions to documentation", Score: 4, MatchedKeywords: []string{"documentation"}},
+				// A name match in the body scores more than a description match in the body
+				{Name: "bug", Description: "Something isn't working", Score: 2, MatchedKeywords: []string{"bug"}},
+				{Name: "performance", Description: "Slow or resource hungry code paths", Score: 1, MatchedKeywords: []string{"slow"}},
+			},
+		},
+		{
+			name: "limits the number of suggestions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposLabelsByOwnerByRepo,
+					mockLabels,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"title":           issueArgs["title"],
+				"body":            issueArgs["body"],
+				"max_suggestions": float64(2),
+			},
+			expectError: false,
+			expectedSuggestions: []labelSuggestion{
+				{Name: "crash", Description: "", Score: 4, MatchedKeywords: []string{"crash"}},
+				{Name: "documentation", Description: "Improvements or additions to documentation", Score: 4, MatchedKeywords: []string{"documentation"}},
+			},
+		},
+		{
+			name: "no matching labels",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposLabelsByOwnerByRepo,
+					mockLabels,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"title": "Question about licensing",
+			},
+			expectError:         false,
+			expectedSuggestions: []labelSuggestion{},
+		},
+		{
+			name: "listing labels fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLabelsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs:    issueArgs,
+			expectError:    true,
+			expectedErrMsg: "failed to list repository labels",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SuggestIssueLabels(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var response struct {
+				LabelsConsidered int               `json:"labels_considered"`
+				Suggestions      []labelSuggestion `json:"suggestions"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, len(mockLabels), response.LabelsConsidered)
+			assert.Equal(t, tc.expectedSuggestions, response.Suggestions)
+		})
+	}
+}
+
+func Test_GetIssueTemplateConfig(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetIssueTemplateConfig(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_issue_template_config", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	configFile := `blank_issues_enabled: false
+contact_links:
+  - name: GitHub Community Support
+    url: https://github.com/orgs/community/discussions
+    about: Please ask and answer questions here.
+  - name: Security vulnerability
+    url: https://example.com/security
+    about: Report security issues privately.
+`
+	mockConfigContent := &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Name:     github.Ptr("config.yml"),
+		Path:     github.Ptr(".github/ISSUE_TEMPLATE/config.yml"),
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(configFile))),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name: "config with contact links",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/contents/.github/ISSUE_TEMPLATE/config.yml", r.URL.Path)
+						mockResponse(t, http.StatusOK, mockConfigContent)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"config_found":         true,
+				"blank_issues_enabled": false,
+				"contact_links": []interface{}{
+					map[string]interface{}{
+						"name":  "GitHub Community Support",
+						"url":   "https://github.com/orgs/community/discussions",
+						"about": "Please ask and answer questions here.",
+					},
+					map[string]interface{}{
+						"name":  "Security vulnerability",
+						"url":   "https://example.com/security",
+						"about": "Report security issues privately.",
+					},
+				},
+			},
+		},
+		{
+			name: "missing config uses the defaults",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"config_found":         false,
+				"blank_issues_enabled": true,
+				"contact_links":        []interface{}{},
+			},
+		},
+		{
+			name: "contents request fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get issue template config",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetIssueTemplateConfig(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_GetOldestOpen(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOldestOpen(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_oldest_open", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "type")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "type"})
+
+	createdAt := time.Now().Add(-30 * 24 * time.Hour).UTC().Truncate(time.Second)
+	mockIssues := []*github.Issue{
+		{
+			Number:           github.Ptr(3),
+			Title:            github.Ptr("Add a dark theme"),
+			User:             &github.User{Login: github.Ptr("hubot")},
+			CreatedAt:        &github.Timestamp{Time: createdAt},
+			HTMLURL:          github.Ptr("https://github.com/owner/repo/pull/3"),
+			PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/3")},
+		},
+		{
+			Number:    github.Ptr(7),
+			Title:     github.Ptr("Crash on startup"),
+			User:      &github.User{Login: github.Ptr("octocat")},
+			CreatedAt: &github.Timestamp{Time: createdAt.Add(24 * time.Hour)},
+			HTMLURL:   github.Ptr("https://github.com/owner/repo/issues/7"),
+		},
+	}
+	listsOldestFirst := expectQueryParams(t, map[string]string{
+		"state":     "open",
+		"sort":      "created",
+		"direction": "asc",
+		"per_page":  "100",
+	}).andThen(
+		mockResponse(t, http.StatusOK, mockIssues),
+	)
+	mockPRs := []*github.PullRequest{
+		{
+			Number:    github.Ptr(3),
+			Title:     github.Ptr("Add a dark theme"),
+			User:      &github.User{Login: github.Ptr("hubot")},
+			CreatedAt: &github.Timestamp{Time: createdAt},
+			HTMLURL:   github.Ptr("https://github.com/owner/repo/pull/3"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name: "oldest issue skips pull requests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepo,
+					listsOldestFirst,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"type":  "issue",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"number":     float64(7),
+				"title":      "Crash on startup",
+				"user":       "octocat",
+				"created_at": createdAt.Add(24 * time.Hour).Format(time.RFC3339),
+				"age_days":   float64(29),
+				"html_url":   "https://github.com/owner/repo/issues/7",
+			},
+		},
+		{
+			name: "oldest pull request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"state":     "open",
+						"sort":      "created",
+						"direction": "asc",
+						"per_page":  "1",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockPRs),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"type":  "pr",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"number":     float64(3),
+				"title":      "Add a dark theme",
+				"user":       "hubot",
+				"created_at": createdAt.Format(time.RFC3339),
+				"age_days":   float64(30),
+				"html_url":   "https://github.com/owner/repo/pull/3",
+			},
+		},
+		{
+			name: "no open pull requests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepo,
+					[]*github.PullRequest{},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"type":  "pr",
+			},
+			expectError:  false,
+			expectedText: "no open pull requests in owner/repo",
+		},
+		{
+			name: "no open issues",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepo,
+					mockIssues[:1],
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"type":  "issue",
+			},
+			expectError:  false,
+			expectedText: "no open issues in owner/repo",
+		},
+		{
+			name:         "invalid type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"type":  "discussion",
+			},
+			expectError:    false,
+			expectedErrMsg: "type must be issue or pr",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOldestOpen(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+				return
+			}
+
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_CopyLabelsBetweenRepos(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CopyLabelsBetweenRepos(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "copy_labels_between_repos", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "source_owner")
+	assert.Contains(t, tool.InputSchema.Properties, "source_repo")
+	assert.Contains(t, tool.InputSchema.Properties, "target_owner")
+	assert.Contains(t, tool.InputSchema.Properties, "target_repo")
+	assert.Contains(t, tool.InputSchema.Properties, "overwrite_existing")
+	assert.Contains(t, tool.InputSchema.Properties, "label_names")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"source_owner", "source_repo", "target_owner", "target_repo"})
+
+	sourceLabels := []*github.Label{
+		{Name: github.Ptr("bug"), Color: github.Ptr("d73a4a"), Description: github.Ptr("Something isn't working")},
+		{Name: github.Ptr("needs-triage"), Color: github.Ptr("fbca04"), Description: github.Ptr("Not looked at yet")},
+		{Name: github.Ptr("security"), Color: github.Ptr("b60205"), Description: github.Ptr("Security issue")},
+	}
+	targetLabels := []*github.Label{
+		{Name: github.Ptr("Bug"), Color: github.Ptr("ee0701"), Description: github.Ptr("")},
+	}
+
+	// labelsHandler lists the labels of the source and target repositories.
+	labelsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/org/template/labels":
+			mockResponse(t, http.StatusOK, sourceLabels)(w, r)
+		case "/repos/org/new-repo/labels":
+			mockResponse(t, http.StatusOK, targetLabels)(w, r)
+		default:
+			t.Errorf("unexpected request for %s", r.URL.Path)
+		}
+	})
+	// createLabelHandler creates labels, failing for the given label.
+	createLabelHandler := func(failing string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var label github.Label
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&label))
+			if label.GetName() == failing {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+				return
+			}
+			mockResponse(t, http.StatusCreated, label)(w, r)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedCopied  []copiedLabel
+		expectedSkipped []string
+		expectedFailed  []string
+	}{
+		{
+			name: "copy all labels, skipping existing ones",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLabelsByOwnerByRepo,
+					labelsHandler,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposLabelsByOwnerByRepo,
+					createLabelHandler(""),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"source_owner": "org",
+				"source_repo":  "template",
+				"target_owner": "org",
+				"target_repo":  "new-repo",
+			},
+			expectError: false,
+			expectedCopied: []copiedLabel{
+				{Name: "needs-triage", Color: "fbca04", Description: "Not looked at yet"},
+				{Name: "security", Color: "b60205", Description: "Security issue"},
+			},
+			expectedSkipped: []string{"bug"},
+			expectedFailed:  []string{},
+		},
+		{
+			name: "overwrite existing labels",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLabelsByOwnerByRepo,
+					labelsHandler,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposLabelsByOwnerByRepoByName,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/org/new-repo/labels/Bug", r.URL.Path)
+						expectRequestBody(t, map[string]interface{}{
+							"color":       "d73a4a",
+							"description": "Something isn't working",
+						}).andThen(
+							mockResponse(t, http.StatusOK, sourceLabels[0]),
+						)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"source_owner":       "org",
+				"source_repo":        "template",
+				"target_owner":       "org",
+				"target_repo":        "new-repo",
+				"overwrite_existing": true,
+				"label_names":        []interface{}{"BUG"},
+			},
+			expectError: false,
+			expectedCopied: []copiedLabel{
+				{Name: "Bug", Color: "d73a4a", Description: "Something isn't working"},
+			},
+			expectedSkipped: []string{},
+			expectedFailed:  []string{},
+		},
+		{
+			name: "failures are reported per label",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLabelsByOwnerByRepo,
+					labelsHandler,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposLabelsByOwnerByRepo,
+					createLabelHandler("security"),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"source_owner": "org",
+				"source_repo":  "template",
+				"target_owner": "org",
+				"target_repo":  "new-repo",
+				"label_names":  []interface{}{"needs-triage", "security", "wontfix"},
+			},
+			expectError: false,
+			expectedCopied: []copiedLabel{
+				{Name: "needs-triage", Color: "fbca04", Description: "Not looked at yet"},
+			},
+			expectedSkipped: []string{},
+			expectedFailed:  []string{"wontfix", "security"},
+		},
+		{
+			name:         "same source and target",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"source_owner": "org",
+				"source_repo":  "template",
+				"target_owner": "Org",
+				"target_repo":  "template",
+			},
+			expectError:    false,
+			expectedErrMsg: "the source and target repositories must differ",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CopyLabelsBetweenRepos(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Copied  []copiedLabel `json:"copied"`
+				Skipped []string      `json:"skipped"`
+				Failed  []failedLabel `json:"failed"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCopied, response.Copied)
+			assert.Equal(t, tc.expectedSkipped, response.Skipped)
+			failedNames := []string{}
+			for _, failed := range response.Failed {
+				assert.NotEmpty(t, failed.Error)
+				failedNames = append(failedNames, failed.Name)
+			}
+			assert.Equal(t, tc.expectedFailed, failedNames)
+		})
+	}
+}
+
+func Test_topReactedIssuesQuery(t *testing.T) {
+	tests := []struct {
+		name           string
+		minReactions   int
+		reaction       string
+		expectedQuery  string
+		expectedSort   string
+		expectedErrMsg string
+	}{
+		{
+			name:          "thumbs up",
+			minReactions:  10,
+			reaction:      "+1",
+			expectedQuery: "repo:owner/repo is:issue is:open reactions:>=10",
+			expectedSort:  "reactions-+1",
+		},
+		{
+			name:          "hooray sorts by tada",
+			minReactions:  1,
+			reaction:      "hooray",
+			expectedQuery: "repo:owner/repo is:issue is:open reactions:>=1",
+			expectedSort:  "reactions-tada",
+		},
+		{
+			name:           "threshold below one",
+			minReactions:   0,
+			reaction:       "+1",
+			expectedErrMsg: "min_reactions must be at least 1, got 0",
+		},
+		{
+			name:           "reaction search can't sort by",
+			minReactions:   5,
+			reaction:       "rocket",
+			expectedErrMsg: `reaction must be one of +1, -1, laugh, confused, heart or hooray, got "rocket"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, sort, err := topReactedIssuesQuery("owner", "repo", tc.minReactions, tc.reaction)
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedQuery, query)
+			assert.Equal(t, tc.expectedSort, sort)
+		})
+	}
+}
+
+func Test_ListTopReactedIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTopReactedIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_top_reacted_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "min_reactions")
+	assert.Contains(t, tool.InputSchema.Properties, "reaction")
+	assert.Contains(t, tool.InputSchema.Properties, "limit")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockResult := &github.IssuesSearchResult{
+		Total: github.Ptr(3),
+		Issues: []*github.Issue{
+			{
+				Number:    github.Ptr(12),
+				Title:     github.Ptr("Support dark mode"),
+				Comments:  github.Ptr(8),
+				HTMLURL:   github.Ptr("https://github.com/owner/repo/issues/12"),
+				Reactions: &github.Reactions{TotalCount: github.Ptr(45), PlusOne: github.Ptr(40), Heart: github.Ptr(5)},
+			},
+			{
+				Number:    github.Ptr(7),
+				Title:     github.Ptr("Export to CSV"),
+				Comments:  github.Ptr(2),
+				HTMLURL:   github.Ptr("https://github.com/owner/repo/issues/7"),
+				Reactions: &github.Reactions{TotalCount: github.Ptr(11), PlusOne: github.Ptr(10), Eyes: github.Ptr(1)},
+			},
+			{
+				// Enough reactions in total, but too few of the type
+				Number:    github.Ptr(3),
+				Title:     github.Ptr("Crash on start"),
+				HTMLURL:   github.Ptr("https://github.com/owner/repo/issues/3"),
+				Reactions: &github.Reactions{TotalCount: github.Ptr(12), PlusOne: github.Ptr(2), Confused: github.Ptr(10)},
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedNumbers  []float64
+		expectedCounts   []float64
+		expectedReaction string
+	}{
+		{
+			name: "issues above the threshold",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					expectQueryParams(t, map[string]string{
+						"q":        "repo:owner/repo is:issue is:open reactions:>=10",
+						"sort":     "reactions-+1",
+						"order":    "desc",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"min_reactions": float64(10),
+			},
+			expectError:      false,
+			expectedNumbers:  []float64{12, 7},
+			expectedCounts:   []float64{40, 10},
+			expectedReaction: "+1",
+		},
+		{
+			name: "unknown repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "repository owner/missing not found or can't be searched",
+		},
+		{
+			name:         "threshold below one",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"min_reactions": float64(-1),
+			},
+			expectError:    false,
+			expectedErrMsg: "min_reactions must be at least 1, got -1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListTopReactedIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Reaction string                   `json:"reaction"`
+				Issues   []map[string]interface{} `json:"issues"`
+				Capped   bool                     `json:"capped"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedReaction, response.Reaction)
+			assert.False(t, response.Capped)
+			require.Len(t, response.Issues, len(tc.expectedNumbers))
+			for i, issue := range response.Issues {
+				assert.Equal(t, tc.expectedNumbers[i], issue["number"])
+				assert.Equal(t, tc.expectedCounts[i], issue["reactions"])
+			}
+		})
+	}
+}
+
+func Test_ListIssueDependencies(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListIssueDependencies(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_issue_dependencies", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	getIssueBlockedBy := mock.EndpointPattern{
+		Pattern: "/repos/{owner}/{repo}/issues/{issue_number}/dependencies/blocked_by",
+		Method:  "GET",
+	}
+	getIssueBlocking := mock.EndpointPattern{
+		Pattern: "/repos/{owner}/{repo}/issues/{issue_number}/dependencies/blocking",
+		Method:  "GET",
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedBlockedBy []interface{}
+		expectedBlocking  []interface{}
+	}{
+		{
+			name: "blocked by an issue in another repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getIssueBlockedBy,
+					expectQueryParams(t, map[string]string{"per_page": "100", "page": "1"}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Issue{
+							{
+								Number:        github.Ptr(17),
+								Title:         github.Ptr("Publish the v2 API"),
+								State:         github.Ptr("open"),
+								HTMLURL:       github.Ptr("https://github.com/owner/api/issues/17"),
+								RepositoryURL: github.Ptr("https://api.github.com/repos/owner/api"),
+							},
+						}),
+					),
+				),
+				mock.WithRequestMatch(
+					getIssueBlocking,
+					[]*github.Issue{
+						{
+							Number:        github.Ptr(50),
+							Title:         github.Ptr("Migrate the client"),
+							State:         github.Ptr("closed"),
+							HTMLURL:       github.Ptr("https://github.com/owner/repo/issues/50"),
+							RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+						},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectError: false,
+			expectedBlockedBy: []interface{}{
+				map[string]interface{}{
+					"repository": "owner/api",
+					"number":     float64(17),
+					"title":      "Publish the v2 API",
+					"state":      "open",
+					"html_url":   "https://github.com/owner/api/issues/17",
+					"cross_repo": true,
+				},
+			},
+			expectedBlocking: []interface{}{
+				map[string]interface{}{
+					"repository": "owner/repo",
+					"number":     float64(50),
+					"title":      "Migrate the client",
+					"state":      "closed",
+					"html_url":   "https://github.com/owner/repo/issues/50",
+					"cross_repo": false,
+				},
+			},
+		},
+		{
+			name: "no dependencies",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(getIssueBlockedBy, []*github.Issue{}),
+				mock.WithRequestMatch(getIssueBlocking, []*github.Issue{}),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectError:       false,
+			expectedBlockedBy: []interface{}{},
+			expectedBlocking:  []interface{}{},
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getIssueBlockedBy,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+			},
+			expectError:    false,
+			expectedErrMsg: "issue 999 not found in owner/repo, or issue dependencies aren't available there",
+		},
+		{
+			name: "server error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getIssueBlockedBy,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Internal Server Error"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list the issues 42 is blocked by",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListIssueDependencies(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, "owner/repo", response["repository"])
+			assert.Equal(t, float64(42), response["issue_number"])
+			assert.Equal(t, tc.expectedBlockedBy, response["blocked_by"])
+			assert.Equal(t, tc.expectedBlocking, response["blocking"])
+		})
+	}
+}
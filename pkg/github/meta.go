@@ -0,0 +1,143 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// lineAnchorPattern matches the line anchor of a blob URL, such as L10, L10-L20 or L10C4-L20C8 when columns are
+// selected too.
+var lineAnchorPattern = regexp.MustCompile(`^L(\d+)(?:C\d+)?(?:-L(\d+)(?:C\d+)?)?$`)
+
+// commitSHAPattern matches a full or abbreviated commit SHA.
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+
+// githubURLReference is what a GitHub URL points at. Which fields are set depends on the type.
+type githubURLReference struct {
+	Type      string `json:"type"`
+	Host      string `json:"host"`
+	Owner     string `json:"owner"`
+	Repo      string `json:"repo"`
+	Number    int    `json:"number,omitempty"`
+	SHA       string `json:"sha,omitempty"`
+	Ref       string `json:"ref,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Tab       string `json:"tab,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+}
+
+// parseGitHubURL parses the URL of a repository, issue, pull request, commit or file. The ref of a file URL is
+// taken to be the first path segment after blob, as telling a branch with slashes in its name apart from the
+// path needs the API. Any other page of a repository is parsed as the repository itself.
+func parseGitHubURL(rawURL string) (githubURLReference, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return githubURLReference{}, fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return githubURLReference{}, fmt.Errorf("URL scheme must be http or https, got %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return githubURLReference{}, fmt.Errorf("URL %q has no host", rawURL)
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] == "" || segments[1] == "" {
+		return githubURLReference{}, fmt.Errorf("URL %q does not point at a repository", rawURL)
+	}
+	ref := githubURLReference{
+		Type:  "repo",
+		Host:  strings.TrimPrefix(u.Host, "www."),
+		Owner: segments[0],
+		Repo:  strings.TrimSuffix(segments[1], ".git"),
+	}
+	if len(segments) < 4 {
+		return ref, nil
+	}
+
+	switch segments[2] {
+	case "issues", "pull":
+		number, err := strconv.Atoi(segments[3])
+		if err != nil || number < 1 {
+			return ref, nil
+		}
+		ref.Type, ref.Number = "issue", number
+		if segments[2] == "pull" {
+			ref.Type = "pr"
+			if len(segments) > 4 {
+				ref.Tab = segments[4]
+			}
+			if len(segments) > 5 && ref.Tab == "commits" && commitSHAPattern.MatchString(segments[5]) {
+				ref.SHA = segments[5]
+			}
+		}
+	case "commit":
+		if !commitSHAPattern.MatchString(segments[3]) {
+			return githubURLReference{}, fmt.Errorf("%q is not a commit SHA", segments[3])
+		}
+		ref.Type, ref.SHA = "commit", segments[3]
+	case "blob", "blame":
+		ref.Type, ref.Ref = "file", segments[3]
+		ref.Path = strings.Join(segments[4:], "/")
+		if u.Fragment == "" {
+			break
+		}
+		match := lineAnchorPattern.FindStringSubmatch(u.Fragment)
+		if match == nil {
+			break
+		}
+		ref.Type = "blob-range"
+		ref.StartLine, _ = strconv.Atoi(match[1])
+		ref.EndLine = ref.StartLine
+		if match[2] != "" {
+			ref.EndLine, _ = strconv.Atoi(match[2])
+		}
+		if ref.EndLine < ref.StartLine {
+			ref.StartLine, ref.EndLine = ref.EndLine, ref.StartLine
+		}
+	}
+	return ref, nil
+}
+
+// ParseGitHubURL creates a tool to parse a GitHub URL into the repository, issue, pull request, commit or file
+// it points at.
+func ParseGitHubURL(t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("parse_github_url",
+			mcp.WithDescription(t("TOOL_PARSE_GITHUB_URL_DESCRIPTION", "Parse a GitHub URL into what it points at: a repository, issue, pull request, commit, file or range of lines of a file, with its owner, repository and number, SHA or path. The ref of a file URL is the first segment after blob, so branches with a slash in their name are not told apart from the path")),
+			mcp.WithString("url",
+				mcp.Required(),
+				mcp.Description("GitHub URL, such as a blob permalink with a #L10-L20 line range, a pull request or a commit"),
+			),
+		),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			rawURL, err := requiredParam[string](request, "url")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := parseGitHubURL(rawURL)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			r, err := json.Marshal(ref)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,207 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseGitHubURL(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		expectedErrMsg string
+		expectedRef    githubURLReference
+	}{
+		{
+			name: "blob permalink with a line range",
+			url:  "https://github.com/owner/repo/blob/6dcb09b5b57875f334f61aebed695e2e4193db5e/pkg/github/tools.go#L10-L20",
+			expectedRef: githubURLReference{
+				Type:      "blob-range",
+				Host:      "github.com",
+				Owner:     "owner",
+				Repo:      "repo",
+				Ref:       "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+				Path:      "pkg/github/tools.go",
+				StartLine: 10,
+				EndLine:   20,
+			},
+		},
+		{
+			name: "blob with a single line and columns",
+			url:  "https://github.com/owner/repo/blob/main/README.md#L7C3-L7C9",
+			expectedRef: githubURLReference{
+				Type:      "blob-range",
+				Host:      "github.com",
+				Owner:     "owner",
+				Repo:      "repo",
+				Ref:       "main",
+				Path:      "README.md",
+				StartLine: 7,
+				EndLine:   7,
+			},
+		},
+		{
+			name: "blob without a line range",
+			url:  "github.com/owner/repo/blob/main/docs/index.md",
+			expectedRef: githubURLReference{
+				Type:  "file",
+				Host:  "github.com",
+				Owner: "owner",
+				Repo:  "repo",
+				Ref:   "main",
+				Path:  "docs/index.md",
+			},
+		},
+		{
+			name: "pull request files tab",
+			url:  "https://github.com/owner/repo/pull/42/files#diff-0123abcd",
+			expectedRef: githubURLReference{
+				Type:   "pr",
+				Host:   "github.com",
+				Owner:  "owner",
+				Repo:   "repo",
+				Number: 42,
+				Tab:    "files",
+			},
+		},
+		{
+			name: "commit of a pull request",
+			url:  "https://github.com/owner/repo/pull/42/commits/abc1234",
+			expectedRef: githubURLReference{
+				Type:   "pr",
+				Host:   "github.com",
+				Owner:  "owner",
+				Repo:   "repo",
+				Number: 42,
+				SHA:    "abc1234",
+				Tab:    "commits",
+			},
+		},
+		{
+			name: "commit",
+			url:  "https://www.github.com/owner/repo/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e?diff=split",
+			expectedRef: githubURLReference{
+				Type:  "commit",
+				Host:  "github.com",
+				Owner: "owner",
+				Repo:  "repo",
+				SHA:   "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			},
+		},
+		{
+			name: "issue on GitHub Enterprise Server",
+			url:  "https://ghe.example.com/owner/repo/issues/7#issuecomment-1",
+			expectedRef: githubURLReference{
+				Type:   "issue",
+				Host:   "ghe.example.com",
+				Owner:  "owner",
+				Repo:   "repo",
+				Number: 7,
+			},
+		},
+		{
+			name: "other repository page",
+			url:  "https://github.com/owner/repo.git/actions",
+			expectedRef: githubURLReference{
+				Type:  "repo",
+				Host:  "github.com",
+				Owner: "owner",
+				Repo:  "repo",
+			},
+		},
+		{
+			name:           "not a repository",
+			url:            "https://github.com/owner",
+			expectedErrMsg: `URL "https://github.com/owner" does not point at a repository`,
+		},
+		{
+			name:           "not a commit SHA",
+			url:            "https://github.com/owner/repo/commit/main",
+			expectedErrMsg: `"main" is not a commit SHA`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ref, err := parseGitHubURL(tc.url)
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRef, ref)
+		})
+	}
+}
+
+func Test_ParseGitHubURL(t *testing.T) {
+	// Verify tool definition once
+	tool, handler := ParseGitHubURL(translations.NullTranslationHelper)
+
+	assert.Equal(t, "parse_github_url", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "url")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"url"})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+		expectedRef    githubURLReference
+	}{
+		{
+			name: "pull request",
+			requestArgs: map[string]interface{}{
+				"url": "https://github.com/owner/repo/pull/42",
+			},
+			expectedRef: githubURLReference{
+				Type:   "pr",
+				Host:   "github.com",
+				Owner:  "owner",
+				Repo:   "repo",
+				Number: 42,
+			},
+		},
+		{
+			name: "unsupported scheme",
+			requestArgs: map[string]interface{}{
+				"url": "ssh://git@github.com/owner/repo",
+			},
+			expectedErrMsg: `URL scheme must be http or https, got "ssh"`,
+		},
+		{
+			name:           "missing url",
+			requestArgs:    map[string]interface{}{},
+			expectedErrMsg: "missing required parameter: url",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var ref githubURLReference
+			err = json.Unmarshal([]byte(textContent.Text), &ref)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRef, ref)
+		})
+	}
+}
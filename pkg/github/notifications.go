@@ -0,0 +1,300 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// repoSubscriptionSummary flattens the subscription of the authenticated user to a repository. A nil subscription
+// means the user doesn't watch the repository.
+func repoSubscriptionSummary(subscription *github.Subscription) map[string]interface{} {
+	summary := map[string]interface{}{
+		"subscribed": subscription.GetSubscribed(),
+		"ignored":    subscription.GetIgnored(),
+		"reason":     nil,
+		"created_at": nil,
+	}
+	if subscription.GetReason() != "" {
+		summary["reason"] = subscription.GetReason()
+	}
+	if subscription != nil && subscription.CreatedAt != nil {
+		summary["created_at"] = subscription.GetCreatedAt()
+	}
+	return summary
+}
+
+// GetRepoSubscription creates a tool to get the subscription of the authenticated user to a repository.
+func GetRepoSubscription(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repo_subscription",
+			mcp.WithDescription(t("TOOL_GET_REPO_SUBSCRIPTION_DESCRIPTION", "Get whether the authenticated user watches a repository, ignores its notifications, and why")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			// go-github returns no subscription, and no error, when the user doesn't watch the repository
+			subscription, resp, err := client.Activity.GetRepositorySubscription(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository subscription: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get repository subscription: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(repoSubscriptionSummary(subscription))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// setRepoSubscription sets the subscription of the authenticated user to the repository a tool is called with.
+func setRepoSubscription(ctx context.Context, getClient GetClientFn, request mcp.CallToolRequest, subscription *github.Subscription) (*mcp.CallToolResult, error) {
+	owner, err := requiredParam[string](request, "owner")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	repo, err := requiredParam[string](request, "repo")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+	updated, resp, err := client.Activity.SetRepositorySubscription(ctx, owner, repo, subscription)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+		}
+		return nil, fmt.Errorf("failed to set repository subscription: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("failed to set repository subscription: %s", string(body))), nil
+	}
+
+	r, err := json.Marshal(repoSubscriptionSummary(updated))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
+
+// WatchRepo creates a tool to watch a repository, receiving all of its notifications.
+func WatchRepo(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("watch_repo",
+			mcp.WithDescription(t("TOOL_WATCH_REPO_DESCRIPTION", "Watch a repository, so the authenticated user receives all of its notifications")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return setRepoSubscription(ctx, getClient, request, &github.Subscription{Subscribed: github.Ptr(true), Ignored: github.Ptr(false)})
+		}
+}
+
+// IgnoreRepo creates a tool to ignore the notifications of a repository.
+func IgnoreRepo(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("ignore_repo",
+			mcp.WithDescription(t("TOOL_IGNORE_REPO_DESCRIPTION", "Ignore a repository, so the authenticated user receives none of its notifications, even when mentioned")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return setRepoSubscription(ctx, getClient, request, &github.Subscription{Ignored: github.Ptr(true)})
+		}
+}
+
+// UnwatchRepo creates a tool to stop watching a repository.
+func UnwatchRepo(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unwatch_repo",
+			mcp.WithDescription(t("TOOL_UNWATCH_REPO_DESCRIPTION", "Stop watching a repository, or stop ignoring it. The authenticated user is then only notified about the conversations they participate in or are mentioned in")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Activity.DeleteRepositorySubscription(ctx, owner, repo)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to delete repository subscription: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete repository subscription: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Stopped watching %s/%s", owner, repo)), nil
+		}
+}
+
+// watchedRepo is a repository the authenticated user watches, with their subscription to it.
+type watchedRepo struct {
+	FullName   string            `json:"full_name"`
+	Reason     *string           `json:"reason"`
+	Subscribed bool              `json:"subscribed"`
+	Ignored    bool              `json:"ignored"`
+	LastPushAt *github.Timestamp `json:"last_push_at"`
+}
+
+// ListWatchedReposWithReasons creates a tool to list the repositories the authenticated user watches, with why
+// they receive their notifications.
+func ListWatchedReposWithReasons(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_watched_repos_with_reasons",
+			mcp.WithDescription(t("TOOL_LIST_WATCHED_REPOS_WITH_REASONS_DESCRIPTION", "List the repositories the authenticated user watches, with their subscription to each and the reason they receive its notifications. The filter applies to the requested page of watched repositories")),
+			mcp.WithString("filter_by_reason",
+				mcp.Description("Only list the repositories subscribed to for this reason, such as manual, comment, mention, team_mention or security_alert"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			filterByReason, err := OptionalParam[string](request, "filter_by_reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repos, resp, err := client.Activity.ListWatched(ctx, "", &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list watched repositories: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list watched repositories: %s", string(body))), nil
+			}
+
+			// The subscription takes a call per repository, so they're fetched concurrently, within bounds
+			subscriptions := make([]*github.Subscription, len(repos))
+			errs := forEachConcurrently(ctx, repos, func(ctx context.Context, i int, repo *github.Repository) error {
+				subscription, resp, err := client.Activity.GetRepositorySubscription(ctx, repo.GetOwner().GetLogin(), repo.GetName())
+				if err != nil {
+					return fmt.Errorf("failed to get subscription to %s: %w", repo.GetFullName(), err)
+				}
+				_ = resp.Body.Close()
+				subscriptions[i] = subscription
+				return nil
+			})
+			if err := errors.Join(errs...); err != nil {
+				return nil, err
+			}
+
+			watched := []watchedRepo{}
+			for i, repo := range repos {
+				subscription := subscriptions[i]
+				if filterByReason != "" && subscription.GetReason() != filterByReason {
+					continue
+				}
+				entry := watchedRepo{
+					FullName:   repo.GetFullName(),
+					Subscribed: subscription.GetSubscribed(),
+					Ignored:    subscription.GetIgnored(),
+					LastPushAt: repo.PushedAt,
+				}
+				if subscription.GetReason() != "" {
+					entry.Reason = github.Ptr(subscription.GetReason())
+				}
+				watched = append(watched, entry)
+			}
+
+			r, err := json.Marshal(watched)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
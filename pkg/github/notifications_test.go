@@ -0,0 +1,494 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepoSubscription(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepoSubscription(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_repo_subscription", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name: "watched repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposSubscriptionByOwnerByRepo,
+					&github.Subscription{
+						Subscribed: github.Ptr(true),
+						Ignored:    github.Ptr(false),
+						Reason:     github.Ptr("manual"),
+						CreatedAt:  &github.Timestamp{Time: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+					},
+				),
+			),
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"subscribed": true,
+				"ignored":    false,
+				"reason":     "manual",
+				"created_at": "2024-03-01T12:00:00Z",
+			},
+		},
+		{
+			name: "repository not watched",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposSubscriptionByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"subscribed": false,
+				"ignored":    false,
+				"reason":     nil,
+				"created_at": nil,
+			},
+		},
+		{
+			name: "subscription fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposSubscriptionByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get repository subscription",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepoSubscription(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, response)
+		})
+	}
+}
+
+func Test_WatchRepo(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := WatchRepo(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "watch_repo", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name: "successful watch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposSubscriptionByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{"subscribed": true, "ignored": false}).andThen(
+						mockResponse(t, http.StatusOK, &github.Subscription{
+							Subscribed: github.Ptr(true),
+							Ignored:    github.Ptr(false),
+							CreatedAt:  &github.Timestamp{Time: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+						}),
+					),
+				),
+			),
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"subscribed": true,
+				"ignored":    false,
+				"reason":     nil,
+				"created_at": "2024-03-01T12:00:00Z",
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposSubscriptionByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    false,
+			expectedErrMsg: "repository owner/repo not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := WatchRepo(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, response)
+		})
+	}
+}
+
+func Test_IgnoreRepo(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := IgnoreRepo(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "ignore_repo", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name: "successful ignore",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposSubscriptionByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{"ignored": true}).andThen(
+						mockResponse(t, http.StatusOK, &github.Subscription{
+							Subscribed: github.Ptr(false),
+							Ignored:    github.Ptr(true),
+						}),
+					),
+				),
+			),
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"subscribed": false,
+				"ignored":    true,
+				"reason":     nil,
+				"created_at": nil,
+			},
+		},
+		{
+			name: "subscription update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposSubscriptionByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to set repository subscription",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := IgnoreRepo(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, response)
+		})
+	}
+}
+
+func Test_UnwatchRepo(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UnwatchRepo(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "unwatch_repo", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "successful unwatch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposSubscriptionByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			expectError:  false,
+			expectedText: "Stopped watching owner/repo",
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposSubscriptionByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    false,
+			expectedErrMsg: "repository owner/repo not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UnwatchRepo(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_ListWatchedReposWithReasons(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWatchedReposWithReasons(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_watched_repos_with_reasons", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "filter_by_reason")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	pushedAt := &github.Timestamp{Time: time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)}
+	watchedRepos := []*github.Repository{
+		{Name: github.Ptr("api"), FullName: github.Ptr("octo/api"), Owner: &github.User{Login: github.Ptr("octo")}, PushedAt: pushedAt},
+		{Name: github.Ptr("web"), FullName: github.Ptr("octo/web"), Owner: &github.User{Login: github.Ptr("octo")}},
+		{Name: github.Ptr("docs"), FullName: github.Ptr("octo/docs"), Owner: &github.User{Login: github.Ptr("octo")}},
+	}
+	subscriptions := map[string]*github.Subscription{
+		"/repos/octo/api/subscription":  {Subscribed: github.Ptr(true), Ignored: github.Ptr(false), Reason: github.Ptr("manual")},
+		"/repos/octo/web/subscription":  {Subscribed: github.Ptr(true), Ignored: github.Ptr(false), Reason: github.Ptr("security_alert")},
+		"/repos/octo/docs/subscription": {Subscribed: github.Ptr(true), Ignored: github.Ptr(false)},
+	}
+	subscriptionHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subscription, ok := subscriptions[r.URL.Path]
+		if !ok {
+			mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+			return
+		}
+		mockResponse(t, http.StatusOK, subscription)(w, r)
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRepos  []watchedRepo
+	}{
+		{
+			name: "every watched repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserSubscriptions,
+					expectQueryParams(t, map[string]string{"page": "2", "per_page": "3"}).andThen(
+						mockResponse(t, http.StatusOK, watchedRepos),
+					),
+				),
+				mock.WithRequestMatchHandler(mock.GetReposSubscriptionByOwnerByRepo, subscriptionHandler),
+			),
+			requestArgs: map[string]interface{}{
+				"page":    float64(2),
+				"perPage": float64(3),
+			},
+			expectError: false,
+			expectedRepos: []watchedRepo{
+				{FullName: "octo/api", Reason: github.Ptr("manual"), Subscribed: true, LastPushAt: pushedAt},
+				{FullName: "octo/web", Reason: github.Ptr("security_alert"), Subscribed: true},
+				{FullName: "octo/docs", Subscribed: true},
+			},
+		},
+		{
+			name: "filtered by reason",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUserSubscriptions, watchedRepos),
+				mock.WithRequestMatchHandler(mock.GetReposSubscriptionByOwnerByRepo, subscriptionHandler),
+			),
+			requestArgs: map[string]interface{}{
+				"filter_by_reason": "security_alert",
+			},
+			expectError: false,
+			expectedRepos: []watchedRepo{
+				{FullName: "octo/web", Reason: github.Ptr("security_alert"), Subscribed: true},
+			},
+		},
+		{
+			name: "subscription fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUserSubscriptions, watchedRepos),
+				mock.WithRequestMatchHandler(
+					mock.GetReposSubscriptionByOwnerByRepo,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Server Error"}),
+				),
+			),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "failed to get subscription to octo/api",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWatchedReposWithReasons(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var response []watchedRepo
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRepos, response)
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// defaultOIDCClaimKeys are the claims the subject of an OIDC token is made of when nothing customizes it.
+var defaultOIDCClaimKeys = []string{"repo", "context"}
+
+// claimKeysParam returns the include_claim_keys parameter, which must name at least one claim when required.
+func claimKeysParam(request mcp.CallToolRequest, required bool) ([]string, error) {
+	keys, err := OptionalStringArrayParam(request, "include_claim_keys")
+	if err != nil {
+		return nil, err
+	}
+	if required && len(keys) == 0 {
+		return nil, fmt.Errorf("include_claim_keys must contain at least one claim")
+	}
+	return keys, nil
+}
+
+// GetOrgOIDCSubjectClaimTemplate creates a tool to get the OIDC subject claim customization template of an organization.
+func GetOrgOIDCSubjectClaimTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_oidc_subject_claim_customization_template",
+			mcp.WithDescription(t("TOOL_GET_ORG_OIDC_SUBJECT_CLAIM_CUSTOMIZATION_TEMPLATE_DESCRIPTION", "Get the claims the subject of the GitHub Actions OIDC tokens of an organization is made of. Requires a token with the admin:org scope")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			template, resp, err := client.Actions.GetOrgOIDCSubjectClaimCustomTemplate(ctx, org)
+			if err != nil {
+				if resp != nil {
+					switch resp.StatusCode {
+					case http.StatusForbidden:
+						return mcp.NewToolResultError(fmt.Sprintf("reading the OIDC subject claim template of %s requires a token with the admin:org scope", org)), nil
+					case http.StatusNotFound:
+						return mcp.NewToolResultError(fmt.Sprintf("organization %s not found", org)), nil
+					}
+				}
+				return nil, fmt.Errorf("failed to get OIDC subject claim template: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]interface{}{
+				"include_claim_keys": template.IncludeClaimKeys,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetRepoOIDCSubjectClaimTemplate creates a tool to get the OIDC subject claim customization template of a repository.
+func GetRepoOIDCSubjectClaimTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repo_oidc_subject_claim_customization_template",
+			mcp.WithDescription(t("TOOL_GET_REPO_OIDC_SUBJECT_CLAIM_CUSTOMIZATION_TEMPLATE_DESCRIPTION", "Get the claims the subject of the GitHub Actions OIDC tokens of a repository is made of, and whether they come from the repository's own template, its organization's, or the default one. Requires admin access to the repository")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			template, resp, err := client.Actions.GetRepoOIDCSubjectClaimCustomTemplate(ctx, owner, repo)
+			if err != nil {
+				if resp != nil {
+					switch resp.StatusCode {
+					case http.StatusForbidden:
+						return mcp.NewToolResultError(fmt.Sprintf("reading the OIDC subject claim template of %s/%s requires admin access to the repository", owner, repo)), nil
+					case http.StatusNotFound:
+						return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+					}
+				}
+				return nil, fmt.Errorf("failed to get OIDC subject claim template: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			result := map[string]interface{}{
+				"use_default":        template.GetUseDefault(),
+				"include_claim_keys": template.IncludeClaimKeys,
+				"source":             "repository",
+			}
+			if template.GetUseDefault() {
+				// The repository inherits the template of its organization, which personal accounts don't have
+				orgTemplate, resp, err := client.Actions.GetOrgOIDCSubjectClaimCustomTemplate(ctx, owner)
+				switch {
+				case err == nil:
+					_ = resp.Body.Close()
+					result["include_claim_keys"] = orgTemplate.IncludeClaimKeys
+					result["source"] = "organization"
+				case resp != nil && resp.StatusCode == http.StatusNotFound:
+					result["include_claim_keys"] = defaultOIDCClaimKeys
+					result["source"] = "default"
+				case resp != nil && resp.StatusCode == http.StatusForbidden:
+					// Repository admins may not be allowed to read the organization's template
+					result["include_claim_keys"] = nil
+					result["source"] = "organization"
+				default:
+					return nil, fmt.Errorf("failed to get OIDC subject claim template of %s: %w", owner, err)
+				}
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetOrgOIDCSubjectClaimTemplate creates a tool to set the OIDC subject claim customization template of an organization.
+func SetOrgOIDCSubjectClaimTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_org_oidc_subject_claim_customization_template",
+			mcp.WithDescription(t("TOOL_SET_ORG_OIDC_SUBJECT_CLAIM_CUSTOMIZATION_TEMPLATE_DESCRIPTION", "Set the claims the subject of the GitHub Actions OIDC tokens of an organization is made of. Cloud providers trusting the current subject may reject tokens afterwards. Requires a token with the admin:org scope")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			mcp.WithArray("include_claim_keys",
+				mcp.Required(),
+				mcp.Description("Names of the claims to make the subject of, in order, such as repo, context and ref"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			keys, err := claimKeysParam(request, true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Actions.SetOrgOIDCSubjectClaimCustomTemplate(ctx, org, &github.OIDCSubjectClaimCustomTemplate{
+				IncludeClaimKeys: keys,
+			})
+			if err != nil {
+				if resp != nil {
+					switch resp.StatusCode {
+					case http.StatusForbidden:
+						return mcp.NewToolResultError(fmt.Sprintf("setting the OIDC subject claim template of %s requires a token with the admin:org scope", org)), nil
+					case http.StatusNotFound:
+						return mcp.NewToolResultError(fmt.Sprintf("organization %s not found", org)), nil
+					case http.StatusUnprocessableEntity:
+						return mcp.NewToolResultError(fmt.Sprintf("invalid OIDC subject claim template: %s", err.Error())), nil
+					}
+				}
+				return nil, fmt.Errorf("failed to set OIDC subject claim template: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]interface{}{
+				"include_claim_keys": keys,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetRepoOIDCSubjectClaimTemplate creates a tool to set the OIDC subject claim customization template of a repository.
+func SetRepoOIDCSubjectClaimTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_repo_oidc_subject_claim_customization_template",
+			mcp.WithDescription(t("TOOL_SET_REPO_OIDC_SUBJECT_CLAIM_CUSTOMIZATION_TEMPLATE_DESCRIPTION", "Set the claims the subject of the GitHub Actions OIDC tokens of a repository is made of, or make the repository inherit the template of its organization again. Cloud providers trusting the current subject may reject tokens afterwards. Requires admin access to the repository")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("include_claim_keys",
+				mcp.Description("Names of the claims to make the subject of, in order, such as repo, context and ref. Required unless use_default is true"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithBoolean("use_default",
+				mcp.Description("Inherit the template of the organization, or the default one, instead of customizing it"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			useDefault, err := OptionalParam[bool](request, "use_default")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			keys, err := claimKeysParam(request, !useDefault)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if useDefault && len(keys) > 0 {
+				return mcp.NewToolResultError("include_claim_keys can't be used with use_default"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			template := &github.OIDCSubjectClaimCustomTemplate{
+				UseDefault:       github.Ptr(useDefault),
+				IncludeClaimKeys: keys,
+			}
+			resp, err := client.Actions.SetRepoOIDCSubjectClaimCustomTemplate(ctx, owner, repo, template)
+			if err != nil {
+				if resp != nil {
+					switch resp.StatusCode {
+					case http.StatusForbidden:
+						return mcp.NewToolResultError(fmt.Sprintf("setting the OIDC subject claim template of %s/%s requires admin access to the repository", owner, repo)), nil
+					case http.StatusNotFound:
+						return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+					case http.StatusUnprocessableEntity:
+						return mcp.NewToolResultError(fmt.Sprintf("invalid OIDC subject claim template: %s", err.Error())), nil
+					}
+				}
+				return nil, fmt.Errorf("failed to set OIDC subject claim template: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]interface{}{
+				"use_default":        useDefault,
+				"include_claim_keys": keys,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,486 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetOrgOIDCSubjectClaimTemplate(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgOIDCSubjectClaimTemplate(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_org_oidc_subject_claim_customization_template", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedTemplate map[string]interface{}
+	}{
+		{
+			name: "custom template",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsOidcCustomizationSubByOrg,
+					&github.OIDCSubjectClaimCustomTemplate{IncludeClaimKeys: []string{"repo", "context", "ref"}},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError: false,
+			expectedTemplate: map[string]interface{}{
+				"include_claim_keys": []interface{}{"repo", "context", "ref"},
+			},
+		},
+		{
+			name: "missing admin:org scope",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsOidcCustomizationSubByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    false,
+			expectedErrMsg: "reading the OIDC subject claim template of org requires a token with the admin:org scope",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOrgOIDCSubjectClaimTemplate(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var template map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &template)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTemplate, template)
+		})
+	}
+}
+
+func Test_GetRepoOIDCSubjectClaimTemplate(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepoOIDCSubjectClaimTemplate(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_repo_oidc_subject_claim_customization_template", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedTemplate map[string]interface{}
+	}{
+		{
+			name: "custom repository template",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsOidcCustomizationSubByOwnerByRepo,
+					&github.OIDCSubjectClaimCustomTemplate{
+						UseDefault:       github.Ptr(false),
+						IncludeClaimKeys: []string{"repo", "job_workflow_ref"},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "org",
+				"repo":  "deploy",
+			},
+			expectError: false,
+			expectedTemplate: map[string]interface{}{
+				"use_default":        false,
+				"include_claim_keys": []interface{}{"repo", "job_workflow_ref"},
+				"source":             "repository",
+			},
+		},
+		{
+			name: "inherits the organization template",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsOidcCustomizationSubByOwnerByRepo,
+					&github.OIDCSubjectClaimCustomTemplate{UseDefault: github.Ptr(true)},
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsOidcCustomizationSubByOrg,
+					&github.OIDCSubjectClaimCustomTemplate{IncludeClaimKeys: []string{"repo", "context", "ref"}},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "org",
+				"repo":  "web",
+			},
+			expectError: false,
+			expectedTemplate: map[string]interface{}{
+				"use_default":        true,
+				"include_claim_keys": []interface{}{"repo", "context", "ref"},
+				"source":             "organization",
+			},
+		},
+		{
+			name: "personal repository uses the default template",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsOidcCustomizationSubByOwnerByRepo,
+					&github.OIDCSubjectClaimCustomTemplate{UseDefault: github.Ptr(true)},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsOidcCustomizationSubByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octocat",
+				"repo":  "dotfiles",
+			},
+			expectError: false,
+			expectedTemplate: map[string]interface{}{
+				"use_default":        true,
+				"include_claim_keys": []interface{}{"repo", "context"},
+				"source":             "default",
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsOidcCustomizationSubByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "org",
+				"repo":  "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "repository org/missing not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepoOIDCSubjectClaimTemplate(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var template map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &template)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTemplate, template)
+		})
+	}
+}
+
+func Test_SetOrgOIDCSubjectClaimTemplate(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetOrgOIDCSubjectClaimTemplate(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_org_oidc_subject_claim_customization_template", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "include_claim_keys")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "include_claim_keys"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "set template",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsActionsOidcCustomizationSubByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"include_claim_keys": []interface{}{"repo", "context", "ref"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                "org",
+				"include_claim_keys": []interface{}{"repo", "context", "ref"},
+			},
+			expectError: false,
+		},
+		{
+			name:         "no claims",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":                "org",
+				"include_claim_keys": []interface{}{},
+			},
+			expectError:    false,
+			expectedErrMsg: "include_claim_keys must contain at least one claim",
+		},
+		{
+			name: "invalid claim",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsActionsOidcCustomizationSubByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Invalid claim key: colour"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                "org",
+				"include_claim_keys": []interface{}{"colour"},
+			},
+			expectError:    false,
+			expectedErrMsg: "invalid OIDC subject claim template",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetOrgOIDCSubjectClaimTemplate(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, `{"include_claim_keys": ["repo", "context", "ref"]}`, textContent.Text)
+		})
+	}
+}
+
+func Test_SetRepoOIDCSubjectClaimTemplate(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetRepoOIDCSubjectClaimTemplate(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_repo_oidc_subject_claim_customization_template", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "include_claim_keys")
+	assert.Contains(t, tool.InputSchema.Properties, "use_default")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult string
+	}{
+		{
+			name: "custom template",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsOidcCustomizationSubByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"use_default":        false,
+						"include_claim_keys": []interface{}{"repo", "job_workflow_ref"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":              "org",
+				"repo":               "deploy",
+				"include_claim_keys": []interface{}{"repo", "job_workflow_ref"},
+			},
+			expectError:    false,
+			expectedResult: `{"use_default": false, "include_claim_keys": ["repo", "job_workflow_ref"]}`,
+		},
+		{
+			name: "inherit the organization template again",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsOidcCustomizationSubByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"use_default": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "org",
+				"repo":        "deploy",
+				"use_default": true,
+			},
+			expectError:    false,
+			expectedResult: `{"use_default": true, "include_claim_keys": []}`,
+		},
+		{
+			name:         "claims with use_default",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":              "org",
+				"repo":               "deploy",
+				"use_default":        true,
+				"include_claim_keys": []interface{}{"repo"},
+			},
+			expectError:    false,
+			expectedErrMsg: "include_claim_keys can't be used with use_default",
+		},
+		{
+			name:         "no claims",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "org",
+				"repo":  "deploy",
+			},
+			expectError:    false,
+			expectedErrMsg: "include_claim_keys must contain at least one claim",
+		},
+		{
+			name: "not a repository admin",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsOidcCustomizationSubByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":              "org",
+				"repo":               "deploy",
+				"include_claim_keys": []interface{}{"repo"},
+			},
+			expectError:    false,
+			expectedErrMsg: "setting the OIDC subject claim template of org/deploy requires admin access to the repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetRepoOIDCSubjectClaimTemplate(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.JSONEq(t, tc.expectedResult, textContent.Text)
+		})
+	}
+}
@@ -0,0 +1,377 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// withInvitationID returns a ToolOption that adds the required "invitation_id" parameter to the tool.
+func withInvitationID() mcp.ToolOption {
+	return mcp.WithNumber("invitation_id",
+		mcp.Required(),
+		mcp.Description("The unique identifier of the invitation"),
+	)
+}
+
+// invitationNotFoundMessage explains a 404 for an organization invitation, which GitHub also
+// returns once an invitation has been accepted, cancelled or has expired.
+func invitationNotFoundMessage(org string, invitationID int) string {
+	return fmt.Sprintf("invitation %d not found in organization %s; it may have already been accepted, cancelled or expired", invitationID, org)
+}
+
+// invitationSummary returns the fields of an organization invitation that are relevant to a caller.
+// Invitations that failed, for example because they expired, carry a message saying so.
+func invitationSummary(inv *github.Invitation) map[string]interface{} {
+	summary := map[string]interface{}{
+		"id":                   inv.GetID(),
+		"role":                 inv.GetRole(),
+		"created_at":           inv.CreatedAt,
+		"failed_at":            inv.FailedAt,
+		"failed_reason":        inv.GetFailedReason(),
+		"team_count":           inv.GetTeamCount(),
+		"invitation_teams_url": inv.GetInvitationTeamURL(),
+	}
+	if inv.Login != nil {
+		summary["login"] = inv.GetLogin()
+	}
+	if inv.Email != nil {
+		summary["email"] = inv.GetEmail()
+	}
+	if inv.Inviter != nil {
+		summary["inviter"] = map[string]interface{}{
+			"login": inv.Inviter.GetLogin(),
+		}
+	}
+	if inv.FailedAt != nil {
+		reason := inv.GetFailedReason()
+		if reason == "" {
+			reason = "expired"
+		}
+		summary["message"] = fmt.Sprintf("invitation failed on %s (%s); cancel it and send a new invitation", inv.FailedAt.Format("2006-01-02"), reason)
+	}
+	return summary
+}
+
+// listOrgInvitations lists the pending invitations of an organization. go-github doesn't support
+// filtering by role or invitation source, so the request is built here.
+func listOrgInvitations(ctx context.Context, client *github.Client, org, role, source string, opts *github.ListOptions) ([]*github.Invitation, *github.Response, error) {
+	params := url.Values{}
+	if role != "" {
+		params.Set("role", role)
+	}
+	if source != "" {
+		params.Set("invitation_source", source)
+	}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.PerPage != 0 {
+		params.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+
+	u := fmt.Sprintf("orgs/%v/invitations", org)
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var invitations []*github.Invitation
+	resp, err := client.Do(ctx, req, &invitations)
+	if err != nil {
+		return nil, resp, err
+	}
+	return invitations, resp, nil
+}
+
+// ListOrgInvitations creates a tool to list the pending invitations of an organization.
+func ListOrgInvitations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_invitations",
+			mcp.WithDescription(t("TOOL_LIST_ORG_INVITATIONS_DESCRIPTION", "List the pending invitations of an organization. Requires organization owner permissions")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Filter invitations by the role they grant"),
+				mcp.Enum("all", "admin", "direct_member", "billing_manager", "hiring_manager"),
+			),
+			mcp.WithString("invitation_source",
+				mcp.Description("Filter invitations by how they were created"),
+				mcp.Enum("all", "member", "scim"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			source, err := OptionalParam[string](request, "invitation_source")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			invitations, resp, err := listOrgInvitations(ctx, client, org, role, source, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list organization invitations: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list organization invitations: %s", string(body))), nil
+			}
+
+			summaries := make([]map[string]interface{}, 0, len(invitations))
+			for _, inv := range invitations {
+				summaries = append(summaries, invitationSummary(inv))
+			}
+
+			r, err := json.Marshal(summaries)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListInvitationTeams creates a tool to list the teams an invitee will join once they accept an organization invitation.
+func ListInvitationTeams(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_invitation_teams",
+			mcp.WithDescription(t("TOOL_LIST_INVITATION_TEAMS_DESCRIPTION", "List the teams an invitee will join once they accept a pending organization invitation")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			withInvitationID(),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			invitationID, err := RequiredInt(request, "invitation_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			teams, resp, err := client.Organizations.ListOrgInvitationTeams(ctx, org, strconv.Itoa(invitationID), &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(invitationNotFoundMessage(org, invitationID)), nil
+				}
+				return nil, fmt.Errorf("failed to list invitation teams: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list invitation teams: %s", string(body))), nil
+			}
+
+			summaries := make([]map[string]interface{}, 0, len(teams))
+			for _, team := range teams {
+				summaries = append(summaries, map[string]interface{}{
+					"id":          team.GetID(),
+					"name":        team.GetName(),
+					"slug":        team.GetSlug(),
+					"description": team.GetDescription(),
+					"privacy":     team.GetPrivacy(),
+					"html_url":    team.GetHTMLURL(),
+				})
+			}
+
+			r, err := json.Marshal(summaries)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CancelOrgInvitation creates a tool to cancel a pending organization invitation.
+func CancelOrgInvitation(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("cancel_org_invitation",
+			mcp.WithDescription(t("TOOL_CANCEL_ORG_INVITATION_DESCRIPTION", "Cancel a pending organization invitation. Requires organization owner permissions")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			withInvitationID(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			invitationID, err := RequiredInt(request, "invitation_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Organizations.CancelInvite(ctx, org, int64(invitationID))
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(invitationNotFoundMessage(org, invitationID)), nil
+				}
+				return nil, fmt.Errorf("failed to cancel organization invitation: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to cancel organization invitation: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Invitation %d to %s cancelled", invitationID, org)), nil
+		}
+}
+
+// isAlreadyOrgMember checks if the error is the validation failure returned when inviting
+// someone who is already a member of the organization.
+func isAlreadyOrgMember(err error) bool {
+	var errorResponse *github.ErrorResponse
+	if !errors.As(err, &errorResponse) || !isGitHubErrorStatus(err, http.StatusUnprocessableEntity) {
+		return false
+	}
+	for _, e := range errorResponse.Errors {
+		if strings.Contains(e.Message, "already a part of") || strings.Contains(e.Message, "already a member") {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateOrgInvitation creates a tool to invite someone to an organization.
+func CreateOrgInvitation(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_org_invitation",
+			mcp.WithDescription(t("TOOL_CREATE_ORG_INVITATION_DESCRIPTION", "Invite someone to an organization by email or GitHub user ID. Requires organization owner permissions")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			mcp.WithString("email",
+				mcp.Description("Email address of the person to invite. Either email or invitee_id is required"),
+			),
+			mcp.WithNumber("invitee_id",
+				mcp.Description("GitHub user ID of the person to invite. Either email or invitee_id is required"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Role to grant, defaults to direct_member. reinstate gives back the role and access a former member had"),
+				mcp.Enum("admin", "direct_member", "billing_manager", "reinstate"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			email, err := OptionalParam[string](request, "email")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			inviteeID, err := OptionalIntParam(request, "invitee_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (email == "") == (inviteeID == 0) {
+				return mcp.NewToolResultError("either email or invitee_id is required, but not both"), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.CreateOrgInvitationOptions{}
+			invitee := email
+			if email != "" {
+				opts.Email = github.Ptr(email)
+			} else {
+				opts.InviteeID = github.Ptr(int64(inviteeID))
+				invitee = fmt.Sprintf("user %d", inviteeID)
+			}
+			if role != "" {
+				opts.Role = github.Ptr(role)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			invitation, resp, err := client.Organizations.CreateOrgInvitation(ctx, org, opts)
+			if err != nil {
+				if isAlreadyOrgMember(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is already a member of %s", invitee, org)), nil
+				}
+				if isGitHubErrorStatus(err, http.StatusUnprocessableEntity) {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot invite %s to %s: %s", invitee, org, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to create organization invitation: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(invitationSummary(invitation))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,497 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgInvitations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgInvitations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_invitations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "role")
+	assert.Contains(t, tool.InputSchema.Properties, "invitation_source")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	createdAt := &github.Timestamp{Time: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)}
+	failedAt := &github.Timestamp{Time: time.Date(2025, 3, 8, 9, 0, 0, 0, time.UTC)}
+	mockInvitations := []*github.Invitation{
+		{
+			ID:                github.Ptr(int64(1)),
+			Login:             github.Ptr("octocat"),
+			Role:              github.Ptr("direct_member"),
+			CreatedAt:         createdAt,
+			Inviter:           &github.User{Login: github.Ptr("admin")},
+			TeamCount:         github.Ptr(2),
+			InvitationTeamURL: github.Ptr("https://api.github.com/organizations/1/invitations/1/teams"),
+		},
+		{
+			ID:           github.Ptr(int64(2)),
+			Email:        github.Ptr("new@example.com"),
+			Role:         github.Ptr("direct_member"),
+			CreatedAt:    createdAt,
+			Inviter:      &github.User{Login: github.Ptr("admin")},
+			FailedAt:     failedAt,
+			FailedReason: github.Ptr("Invitation expired"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       []map[string]interface{}
+	}{
+		{
+			name: "lists direct member invitations",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsInvitationsByOrg,
+					expectQueryParams(t, map[string]string{
+						"role":              "direct_member",
+						"invitation_source": "member",
+						"page":              "1",
+						"per_page":          "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockInvitations),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":               "org",
+				"role":              "direct_member",
+				"invitation_source": "member",
+			},
+			expectError: false,
+			expected: []map[string]interface{}{
+				{
+					"id":                   float64(1),
+					"login":                "octocat",
+					"role":                 "direct_member",
+					"created_at":           "2025-03-01T09:00:00Z",
+					"failed_at":            nil,
+					"failed_reason":        "",
+					"inviter":              map[string]interface{}{"login": "admin"},
+					"team_count":           float64(2),
+					"invitation_teams_url": "https://api.github.com/organizations/1/invitations/1/teams",
+				},
+				{
+					"id":                   float64(2),
+					"email":                "new@example.com",
+					"role":                 "direct_member",
+					"created_at":           "2025-03-01T09:00:00Z",
+					"failed_at":            "2025-03-08T09:00:00Z",
+					"failed_reason":        "Invitation expired",
+					"inviter":              map[string]interface{}{"login": "admin"},
+					"team_count":           float64(0),
+					"invitation_teams_url": "",
+					"message":              "invitation failed on 2025-03-08 (Invitation expired); cancel it and send a new invitation",
+				},
+			},
+		},
+		{
+			name: "listing fails without owner permissions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsInvitationsByOrg,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list organization invitations",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgInvitations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned []map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_ListInvitationTeams(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListInvitationTeams(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_invitation_teams", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "invitation_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "invitation_id"})
+
+	mockTeams := []*github.Team{
+		{
+			ID:          github.Ptr(int64(10)),
+			Name:        github.Ptr("Backend"),
+			Slug:        github.Ptr("backend"),
+			Description: github.Ptr("Backend engineers"),
+			Privacy:     github.Ptr("closed"),
+			HTMLURL:     github.Ptr("https://github.com/orgs/org/teams/backend"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       []map[string]interface{}
+	}{
+		{
+			name: "lists the teams of an invitation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsInvitationsTeamsByOrgByInvitationId,
+					mockTeams,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":           "org",
+				"invitation_id": float64(1),
+			},
+			expectError: false,
+			expected: []map[string]interface{}{
+				{
+					"id":          float64(10),
+					"name":        "Backend",
+					"slug":        "backend",
+					"description": "Backend engineers",
+					"privacy":     "closed",
+					"html_url":    "https://github.com/orgs/org/teams/backend",
+				},
+			},
+		},
+		{
+			name: "invitation not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsInvitationsTeamsByOrgByInvitationId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":           "org",
+				"invitation_id": float64(999),
+			},
+			expectError:    false,
+			expectedErrMsg: "invitation 999 not found in organization org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListInvitationTeams(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned []map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_CancelOrgInvitation(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CancelOrgInvitation(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "cancel_org_invitation", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "invitation_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "invitation_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "cancels an invitation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsInvitationsByOrgByInvitationId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":           "org",
+				"invitation_id": float64(1),
+			},
+			expectError:  false,
+			expectedText: "Invitation 1 to org cancelled",
+		},
+		{
+			name: "invitation already gone",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsInvitationsByOrgByInvitationId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":           "org",
+				"invitation_id": float64(2),
+			},
+			expectError:    false,
+			expectedErrMsg: "it may have already been accepted, cancelled or expired",
+		},
+		{
+			name: "cancel fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsInvitationsByOrgByInvitationId,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":           "org",
+				"invitation_id": float64(3),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to cancel organization invitation",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CancelOrgInvitation(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_CreateOrgInvitation(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateOrgInvitation(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_org_invitation", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "email")
+	assert.Contains(t, tool.InputSchema.Properties, "invitee_id")
+	assert.Contains(t, tool.InputSchema.Properties, "role")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedID     float64
+	}{
+		{
+			name: "invites by email",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsInvitationsByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"email": "octocat@example.com",
+						"role":  "admin",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Invitation{
+							ID:    github.Ptr(int64(7)),
+							Email: github.Ptr("octocat@example.com"),
+							Role:  github.Ptr("admin"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":   "org",
+				"email": "octocat@example.com",
+				"role":  "admin",
+			},
+			expectError: false,
+			expectedID:  7,
+		},
+		{
+			name: "invites by user ID",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsInvitationsByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"invitee_id": float64(583231),
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Invitation{
+							ID:    github.Ptr(int64(8)),
+							Login: github.Ptr("octocat"),
+							Role:  github.Ptr("direct_member"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "org",
+				"invitee_id": float64(583231),
+			},
+			expectError: false,
+			expectedID:  8,
+		},
+		{
+			name: "already a member",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsInvitationsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed", "errors": [{"resource": "OrganizationInvitation", "code": "unprocessable", "field": "data", "message": "Invitee is already a part of this organization"}]}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "org",
+				"invitee_id": float64(583231),
+			},
+			expectError:    false,
+			expectedErrMsg: "user 583231 is already a member of org",
+		},
+		{
+			name:         "both email and user ID",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":        "org",
+				"email":      "octocat@example.com",
+				"invitee_id": float64(583231),
+			},
+			expectError:    false,
+			expectedErrMsg: "either email or invitee_id is required, but not both",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateOrgInvitation(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedID, returned["id"])
+		})
+	}
+}
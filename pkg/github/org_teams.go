@@ -0,0 +1,162 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// userTeamsQuery fetches the teams of an organization a user belongs to, along with the user's role in each.
+// Only the first 100 teams are fetched.
+const userTeamsQuery = `query($org: String!, $user: String!) {
+  organization(login: $org) {
+    teams(first: 100, userLogins: [$user]) {
+      pageInfo { hasNextPage }
+      nodes {
+        slug
+        name
+        privacy
+        parentTeam { slug }
+        members(first: 10, query: $user) {
+          edges {
+            role
+            node { login }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// userTeam is a team a user belongs to, and the user's role in it.
+type userTeam struct {
+	Slug           string  `json:"slug"`
+	Name           string  `json:"name"`
+	Privacy        string  `json:"privacy"`
+	Role           string  `json:"role"`
+	ParentTeamSlug *string `json:"parent_team_slug"`
+}
+
+// ListTeamsForUser creates a tool to list the teams of an organization a user belongs to.
+func ListTeamsForUser(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_teams_for_user",
+			mcp.WithDescription(t("TOOL_LIST_TEAMS_FOR_USER_DESCRIPTION", "List the teams of an organization a user belongs to, with their role in each team and in the organization. Returns at most 100 teams")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("GitHub username"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := requiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// A 404 means the user isn't a member, or the caller isn't allowed to see their membership
+			isMember := false
+			var orgRole, membershipState *string
+			membership, resp, err := client.Organizations.GetOrgMembership(ctx, username, org)
+			if err != nil {
+				if !isGitHubErrorStatus(err, http.StatusNotFound) {
+					return nil, fmt.Errorf("failed to get organization membership: %w", err)
+				}
+			} else {
+				defer func() { _ = resp.Body.Close() }()
+				isMember = membership.GetState() == "active"
+				orgRole = membership.Role
+				membershipState = membership.State
+			}
+
+			var data struct {
+				Organization struct {
+					Teams struct {
+						PageInfo struct {
+							HasNextPage bool `json:"hasNextPage"`
+						} `json:"pageInfo"`
+						Nodes []struct {
+							Slug       string `json:"slug"`
+							Name       string `json:"name"`
+							Privacy    string `json:"privacy"`
+							ParentTeam *struct {
+								Slug string `json:"slug"`
+							} `json:"parentTeam"`
+							Members struct {
+								Edges []struct {
+									Role string `json:"role"`
+									Node struct {
+										Login string `json:"login"`
+									} `json:"node"`
+								} `json:"edges"`
+							} `json:"members"`
+						} `json:"nodes"`
+					} `json:"teams"`
+				} `json:"organization"`
+			}
+			variables := map[string]interface{}{
+				"org":  org,
+				"user": username,
+			}
+			if err := executeGraphQL(ctx, client, userTeamsQuery, variables, &data); err != nil {
+				if isGraphQLNotFound(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("organization %s not found", org)), nil
+				}
+				return nil, fmt.Errorf("failed to list teams for user: %w", err)
+			}
+
+			teams := make([]userTeam, 0, len(data.Organization.Teams.Nodes))
+			for _, node := range data.Organization.Teams.Nodes {
+				team := userTeam{
+					Slug: node.Slug,
+					Name: node.Name,
+					// Report privacy the way the REST API does
+					Privacy: strings.ToLower(strings.Replace(node.Privacy, "VISIBLE", "CLOSED", 1)),
+					Role:    "member",
+				}
+				if node.ParentTeam != nil {
+					team.ParentTeamSlug = &node.ParentTeam.Slug
+				}
+				// The members query is a fuzzy search, so pick the user's own entry
+				for _, edge := range node.Members.Edges {
+					if strings.EqualFold(edge.Node.Login, username) {
+						team.Role = strings.ToLower(edge.Role)
+						break
+					}
+				}
+				teams = append(teams, team)
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"org":              org,
+				"username":         username,
+				"is_org_member":    isMember,
+				"org_role":         orgRole,
+				"membership_state": membershipState,
+				"teams":            teams,
+				"truncated":        data.Organization.Teams.PageInfo.HasNextPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,218 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListTeamsForUser(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeamsForUser(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_teams_for_user", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "username"})
+
+	graphQLEndpoint := mock.EndpointPattern{
+		Pattern: "/graphql",
+		Method:  "POST",
+	}
+	teamsResponse := map[string]interface{}{
+		"data": map[string]interface{}{
+			"organization": map[string]interface{}{
+				"teams": map[string]interface{}{
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+					"nodes": []map[string]interface{}{
+						{
+							"slug":       "engineering",
+							"name":       "Engineering",
+							"privacy":    "VISIBLE",
+							"parentTeam": nil,
+							"members": map[string]interface{}{
+								"edges": []map[string]interface{}{
+									// The members search is fuzzy and may return other users first
+									{"role": "MEMBER", "node": map[string]interface{}{"login": "octocat-bot"}},
+									{"role": "MAINTAINER", "node": map[string]interface{}{"login": "octocat"}},
+								},
+							},
+						},
+						{
+							"slug":       "security",
+							"name":       "Security",
+							"privacy":    "SECRET",
+							"parentTeam": map[string]interface{}{"slug": "engineering"},
+							"members": map[string]interface{}{
+								"edges": []map[string]interface{}{
+									{"role": "MEMBER", "node": map[string]interface{}{"login": "octocat"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedMember  bool
+		expectedOrgRole interface{}
+		expectedTeams   []userTeam
+	}{
+		{
+			name: "organization admin in two teams",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsMembershipsByOrgByUsername,
+					&github.Membership{State: github.Ptr("active"), Role: github.Ptr("admin")},
+				),
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body graphQLRequest
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						assert.Equal(t, "org", body.Variables["org"])
+						assert.Equal(t, "octocat", body.Variables["user"])
+						mockResponse(t, http.StatusOK, teamsResponse)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":      "org",
+				"username": "octocat",
+			},
+			expectError:     false,
+			expectedMember:  true,
+			expectedOrgRole: "admin",
+			expectedTeams: []userTeam{
+				{Slug: "engineering", Name: "Engineering", Privacy: "closed", Role: "maintainer"},
+				{Slug: "security", Name: "Security", Privacy: "secret", Role: "member", ParentTeamSlug: github.Ptr("engineering")},
+			},
+		},
+		{
+			name: "user outside the organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembershipsByOrgByUsername,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+				mock.WithRequestMatch(
+					graphQLEndpoint,
+					map[string]interface{}{
+						"data": map[string]interface{}{
+							"organization": map[string]interface{}{
+								"teams": map[string]interface{}{
+									"pageInfo": map[string]interface{}{"hasNextPage": false},
+									"nodes":    []map[string]interface{}{},
+								},
+							},
+						},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":      "org",
+				"username": "stranger",
+			},
+			expectError:     false,
+			expectedMember:  false,
+			expectedOrgRole: nil,
+			expectedTeams:   []userTeam{},
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembershipsByOrgByUsername,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+				mock.WithRequestMatch(
+					graphQLEndpoint,
+					map[string]interface{}{
+						"data":   map[string]interface{}{"organization": nil},
+						"errors": []map[string]interface{}{{"type": "NOT_FOUND", "path": []string{"organization"}, "message": "Could not resolve to an Organization with the login of 'ghost-org'."}},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":      "ghost-org",
+				"username": "octocat",
+			},
+			expectError:    false,
+			expectedErrMsg: "organization ghost-org not found",
+		},
+		{
+			name: "membership lookup fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembershipsByOrgByUsername,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":      "org",
+				"username": "octocat",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get organization membership",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListTeamsForUser(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				IsOrgMember bool        `json:"is_org_member"`
+				OrgRole     interface{} `json:"org_role"`
+				Teams       []userTeam  `json:"teams"`
+				Truncated   bool        `json:"truncated"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMember, response.IsOrgMember)
+			assert.Equal(t, tc.expectedOrgRole, response.OrgRole)
+			assert.Equal(t, tc.expectedTeams, response.Teams)
+			assert.False(t, response.Truncated)
+		})
+	}
+}
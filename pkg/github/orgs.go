@@ -0,0 +1,343 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// auditLogTimeLayouts are the formats accepted for the audit log time range.
+var auditLogTimeLayouts = []string{time.DateOnly, time.RFC3339}
+
+// auditLogPhrase builds an audit log search phrase out of a free-form phrase, an action and a time range.
+// The audit log API only takes a phrase, so the other filters are added to it as qualifiers.
+func auditLogPhrase(phrase, action, since, until string) (string, error) {
+	for name, value := range map[string]string{"since": since, "until": until} {
+		if value == "" {
+			continue
+		}
+		valid := false
+		for _, layout := range auditLogTimeLayouts {
+			if _, err := time.Parse(layout, value); err == nil {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return "", fmt.Errorf("%s must be a date (YYYY-MM-DD) or an ISO 8601 timestamp, got %q", name, value)
+		}
+	}
+
+	var qualifiers []string
+	if phrase != "" {
+		qualifiers = append(qualifiers, phrase)
+	}
+	if action != "" {
+		qualifiers = append(qualifiers, "action:"+action)
+	}
+	switch {
+	case since != "" && until != "":
+		qualifiers = append(qualifiers, fmt.Sprintf("created:%s..%s", since, until))
+	case since != "":
+		qualifiers = append(qualifiers, "created:>="+since)
+	case until != "":
+		qualifiers = append(qualifiers, "created:<="+until)
+	}
+	return strings.Join(qualifiers, " "), nil
+}
+
+// auditEventTarget returns what an audit log event acted on: the repository, team and user it names, if any.
+func auditEventTarget(entry *github.AuditEntry) map[string]string {
+	target := map[string]string{}
+	for _, field := range []string{"repo", "team"} {
+		if value, ok := entry.AdditionalFields[field].(string); ok && value != "" {
+			target[field] = value
+		}
+	}
+	if entry.GetUser() != "" {
+		target["user"] = entry.GetUser()
+	}
+	if len(target) == 0 {
+		return nil
+	}
+	return target
+}
+
+// ListAuditLog creates a tool to list the audit log events of an organization.
+func ListAuditLog(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_audit_log",
+			mcp.WithDescription(t("TOOL_LIST_AUDIT_LOG_DESCRIPTION", "List the audit log events of an organization, most recent first, with the action, actor, time and target of each. Requires organization owner permissions and GitHub Enterprise Cloud")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			mcp.WithString("phrase",
+				mcp.Description("Audit log search phrase, such as 'actor:octocat repo:org/repo'"),
+			),
+			mcp.WithString("action",
+				mcp.Description("Only return events of this action, such as 'repo.create', or of this category, such as 'repo'"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only return events that occurred on or after this date (YYYY-MM-DD) or ISO 8601 timestamp"),
+			),
+			mcp.WithString("until",
+				mcp.Description("Only return events that occurred on or before this date (YYYY-MM-DD) or ISO 8601 timestamp"),
+			),
+			mcp.WithString("include",
+				mcp.Description("Event types to return. Defaults to web"),
+				mcp.Enum("web", "git", "all"),
+			),
+			mcp.WithString("order",
+				mcp.Description("Order of the events. Defaults to desc"),
+				mcp.Enum("asc", "desc"),
+			),
+			mcp.WithString("after",
+				mcp.Description("Cursor to fetch the events after, as returned in next_cursor"),
+			),
+			mcp.WithString("before",
+				mcp.Description("Cursor to fetch the events before, as returned in previous_cursor"),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description("Results per page (min 1, max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			params := map[string]string{}
+			for _, name := range []string{"phrase", "action", "since", "until", "include", "order", "after", "before"} {
+				params[name], err = OptionalParam[string](request, name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+			perPage, err := OptionalIntParamWithDefault(request, "perPage", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params["after"] != "" && params["before"] != "" {
+				return mcp.NewToolResultError("only one of after and before can be given"), nil
+			}
+			phrase, err := auditLogPhrase(params["phrase"], params["action"], params["since"], params["until"])
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.GetAuditLogOptions{
+				ListCursorOptions: github.ListCursorOptions{
+					PerPage: perPage,
+					After:   params["after"],
+					Before:  params["before"],
+				},
+			}
+			if phrase != "" {
+				opts.Phrase = github.Ptr(phrase)
+			}
+			if params["include"] != "" {
+				opts.Include = github.Ptr(params["include"])
+			}
+			if params["order"] != "" {
+				opts.Order = github.Ptr(params["order"])
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			entries, resp, err := client.Organizations.GetAuditLog(ctx, org, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list audit log: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list audit log: %s", string(body))), nil
+			}
+
+			events := make([]map[string]interface{}, 0, len(entries))
+			for _, entry := range entries {
+				timestamp := entry.Timestamp
+				if timestamp == nil {
+					timestamp = entry.CreatedAt
+				}
+				events = append(events, map[string]interface{}{
+					"action":    entry.GetAction(),
+					"actor":     entry.GetActor(),
+					"timestamp": timestamp,
+					"target":    auditEventTarget(entry),
+				})
+			}
+
+			// The cursors come from the Link header; an empty one means there are no more events that way
+			result := map[string]interface{}{
+				"events":          events,
+				"next_cursor":     nil,
+				"previous_cursor": nil,
+			}
+			if resp.After != "" {
+				result["next_cursor"] = resp.After
+			}
+			if resp.Before != "" {
+				result["previous_cursor"] = resp.Before
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListCustomRepoRoles creates a tool to list the custom repository roles of an organization.
+func ListCustomRepoRoles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_custom_repo_roles",
+			mcp.WithDescription(t("TOOL_LIST_CUSTOM_REPO_ROLES_DESCRIPTION", "List the custom repository roles of an organization, with the base role each extends and the permissions it grants on top of it. Requires a token with the admin:org scope")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			roles, resp, err := client.Organizations.ListCustomRepoRoles(ctx, org)
+			if err != nil {
+				var errorResponse *github.ErrorResponse
+				if errors.As(err, &errorResponse) && resp != nil {
+					switch resp.StatusCode {
+					case http.StatusNotFound:
+						return mcp.NewToolResultError(fmt.Sprintf("organization %s not found", org)), nil
+					case http.StatusForbidden:
+						return mcp.NewToolResultError(fmt.Sprintf("cannot list the custom repository roles of %s, which requires the admin:org scope and organization owner permissions: %s", org, errorResponse.Message)), nil
+					}
+				}
+				return nil, fmt.Errorf("failed to list custom repository roles: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			result := make([]map[string]interface{}, 0, len(roles.CustomRepoRoles))
+			for _, role := range roles.CustomRepoRoles {
+				permissions := role.Permissions
+				if permissions == nil {
+					permissions = []string{}
+				}
+				result = append(result, map[string]interface{}{
+					"id":          role.GetID(),
+					"name":        role.GetName(),
+					"description": role.GetDescription(),
+					"base_role":   role.GetBaseRole(),
+					"permissions": permissions,
+					"created_at":  role.CreatedAt,
+					"updated_at":  role.UpdatedAt,
+				})
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListTeamRepos creates a tool to list the repositories a team of an organization can access.
+func ListTeamRepos(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_team_repos",
+			mcp.WithDescription(t("TOOL_LIST_TEAM_REPOS_DESCRIPTION", "List the repositories a team of an organization can access, with the team's role on each: admin, maintain, write, triage, read, or the name of a custom repository role")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Team slug"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := requiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			opts := &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			}
+			repos, resp, err := client.Teams.ListTeamReposBySlug(ctx, org, teamSlug, opts)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("team %s not found in organization %s", teamSlug, org)), nil
+				}
+				return nil, fmt.Errorf("failed to list team repositories: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			result := make([]map[string]interface{}, 0, len(repos))
+			for _, repo := range repos {
+				// The role name covers custom repository roles, which the permission flags can only approximate
+				role := repo.GetRoleName()
+				if role == "" {
+					role = "read"
+					for _, p := range repoPermissionLevels {
+						if repo.GetPermissions()[p.flag] {
+							role = p.level
+							break
+						}
+					}
+				}
+				result = append(result, map[string]interface{}{
+					"full_name": repo.GetFullName(),
+					"private":   repo.GetPrivate(),
+					"role":      role,
+				})
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
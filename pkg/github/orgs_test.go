@@ -0,0 +1,480 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListAuditLog(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListAuditLog(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_audit_log", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "phrase")
+	assert.Contains(t, tool.InputSchema.Properties, "action")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "until")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.Contains(t, tool.InputSchema.Properties, "before")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockEntries := []map[string]interface{}{
+		{
+			"action":     "repo.create",
+			"actor":      "octocat",
+			"@timestamp": 1735732800000,
+			"repo":       "org/new-repo",
+		},
+		{
+			"action":     "team.add_member",
+			"actor":      "hubot",
+			"@timestamp": 1735729200000,
+			"team":       "org/engineering",
+			"user":       "monalisa",
+		},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedErrMsg     string
+		expectedActions    []string
+		expectedTargets    []map[string]string
+		expectedNextCursor interface{}
+		expectedPrevCursor interface{}
+	}{
+		{
+			name: "phrase, action and time range are combined",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsAuditLogByOrg,
+					expectQueryParams(t, map[string]string{
+						"phrase":   "actor:octocat action:repo.create created:2025-01-01..2025-01-31",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockEntries[:1]),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":    "org",
+				"phrase": "actor:octocat",
+				"action": "repo.create",
+				"since":  "2025-01-01",
+				"until":  "2025-01-31",
+			},
+			expectError:        false,
+			expectedActions:    []string{"repo.create"},
+			expectedTargets:    []map[string]string{{"repo": "org/new-repo"}},
+			expectedNextCursor: nil,
+			expectedPrevCursor: nil,
+		},
+		{
+			name: "cursors are passed on and returned from the Link header",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsAuditLogByOrg,
+					expectQueryParams(t, map[string]string{
+						"after":    "MS42OTg",
+						"per_page": "2",
+						"order":    "desc",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/organizations/1/audit-log?per_page=2&after=MS43MDA>; rel="next", `+
+								`<https://api.github.com/organizations/1/audit-log?per_page=2&before=MS42OTk>; rel="prev"`)
+							mockResponse(t, http.StatusOK, mockEntries)(w, r)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":     "org",
+				"order":   "desc",
+				"after":   "MS42OTg",
+				"perPage": float64(2),
+			},
+			expectError:     false,
+			expectedActions: []string{"repo.create", "team.add_member"},
+			expectedTargets: []map[string]string{
+				{"repo": "org/new-repo"},
+				{"team": "org/engineering", "user": "monalisa"},
+			},
+			expectedNextCursor: "MS43MDA",
+			expectedPrevCursor: "MS42OTk",
+		},
+		{
+			name: "both cursors given",
+			requestArgs: map[string]interface{}{
+				"org":    "org",
+				"after":  "MS42OTg",
+				"before": "MS42OTk",
+			},
+			expectError:    false,
+			expectedErrMsg: "only one of after and before can be given",
+		},
+		{
+			name: "invalid time range",
+			requestArgs: map[string]interface{}{
+				"org":   "org",
+				"since": "last week",
+			},
+			expectError:    false,
+			expectedErrMsg: "since must be a date (YYYY-MM-DD) or an ISO 8601 timestamp",
+		},
+		{
+			name: "audit log not available",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsAuditLogByOrg,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list audit log",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListAuditLog(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Events []struct {
+					Action    string            `json:"action"`
+					Actor     string            `json:"actor"`
+					Timestamp *github.Timestamp `json:"timestamp"`
+					Target    map[string]string `json:"target"`
+				} `json:"events"`
+				NextCursor     interface{} `json:"next_cursor"`
+				PreviousCursor interface{} `json:"previous_cursor"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			require.Len(t, response.Events, len(tc.expectedActions))
+			for i, event := range response.Events {
+				assert.Equal(t, tc.expectedActions[i], event.Action)
+				assert.Equal(t, tc.expectedTargets[i], event.Target)
+				assert.NotNil(t, event.Timestamp)
+			}
+			assert.Equal(t, tc.expectedNextCursor, response.NextCursor)
+			assert.Equal(t, tc.expectedPrevCursor, response.PreviousCursor)
+		})
+	}
+}
+
+func Test_ListCustomRepoRoles(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCustomRepoRoles(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_custom_repo_roles", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockRoles := &github.OrganizationCustomRepoRoles{
+		TotalCount: github.Ptr(2),
+		CustomRepoRoles: []*github.CustomRepoRoles{
+			{
+				ID:          github.Ptr(int64(8030)),
+				Name:        github.Ptr("Security Engineer"),
+				Description: github.Ptr("Able to contribute code and maintain the security pipeline"),
+				BaseRole:    github.Ptr("maintain"),
+				Permissions: []string{"delete_alerts_code_scanning", "write_code_scanning"},
+			},
+			{
+				ID:       github.Ptr(int64(8031)),
+				Name:     github.Ptr("Viewer"),
+				BaseRole: github.Ptr("read"),
+			},
+		},
+	}
+
+	type customRole struct {
+		ID          int64    `json:"id"`
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		BaseRole    string   `json:"base_role"`
+		Permissions []string `json:"permissions"`
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRoles  []customRole
+	}{
+		{
+			name: "custom roles with permissions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsCustomRepositoryRolesByOrg,
+					mockRoles,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError: false,
+			expectedRoles: []customRole{
+				{
+					ID:          8030,
+					Name:        "Security Engineer",
+					Description: "Able to contribute code and maintain the security pipeline",
+					BaseRole:    "maintain",
+					Permissions: []string{"delete_alerts_code_scanning", "write_code_scanning"},
+				},
+				{
+					ID:          8031,
+					Name:        "Viewer",
+					BaseRole:    "read",
+					Permissions: []string{},
+				},
+			},
+		},
+		{
+			name: "missing admin:org scope",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCustomRepositoryRolesByOrg,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    false,
+			expectedErrMsg: "cannot list the custom repository roles of org, which requires the admin:org scope and organization owner permissions: Must have admin rights to Repository.",
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCustomRepositoryRolesByOrg,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "organization missing not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCustomRepoRoles(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var roles []customRole
+			err = json.Unmarshal([]byte(textContent.Text), &roles)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRoles, roles)
+		})
+	}
+}
+
+func Test_ListTeamRepos(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeamRepos(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_team_repos", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "team_slug")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug"})
+
+	mockRepos := []*github.Repository{
+		{
+			FullName:    github.Ptr("org/api"),
+			Private:     github.Ptr(true),
+			Permissions: map[string]bool{"admin": false, "maintain": false, "push": true, "triage": true, "pull": true},
+		},
+		{
+			FullName:    github.Ptr("org/infra"),
+			Private:     github.Ptr(true),
+			RoleName:    github.Ptr("Security Engineer"),
+			Permissions: map[string]bool{"admin": false, "maintain": true, "push": true, "triage": true, "pull": true},
+		},
+		{
+			FullName:    github.Ptr("org/docs"),
+			Permissions: map[string]bool{"admin": false, "maintain": false, "push": false, "triage": false, "pull": true},
+		},
+	}
+
+	type teamRepo struct {
+		FullName string `json:"full_name"`
+		Private  bool   `json:"private"`
+		Role     string `json:"role"`
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRepos  []teamRepo
+	}{
+		{
+			name: "permissions mapped to roles",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsReposByOrgByTeamSlug,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "3",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRepos),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "org",
+				"team_slug": "platform",
+				"page":      float64(2),
+				"perPage":   float64(3),
+			},
+			expectError: false,
+			expectedRepos: []teamRepo{
+				{FullName: "org/api", Private: true, Role: "write"},
+				{FullName: "org/infra", Private: true, Role: "Security Engineer"},
+				{FullName: "org/docs", Private: false, Role: "read"},
+			},
+		},
+		{
+			name: "team not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsReposByOrgByTeamSlug,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "org",
+				"team_slug": "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "team missing not found in organization org",
+		},
+		{
+			name: "server error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsReposByOrgByTeamSlug,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Internal Server Error"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "org",
+				"team_slug": "platform",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list team repositories",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListTeamRepos(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var repos []teamRepo
+			err = json.Unmarshal([]byte(textContent.Text), &repos)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRepos, repos)
+		})
+	}
+}
@@ -0,0 +1,1027 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// packageTypes are the package ecosystems supported by the GitHub Packages API.
+var packageTypes = []string{"npm", "maven", "rubygems", "docker", "nuget", "container"}
+
+// withPackageType returns a ToolOption that adds the required "package_type" parameter to the tool.
+func withPackageType() mcp.ToolOption {
+	return mcp.WithString("package_type",
+		mcp.Required(),
+		mcp.Description("Type of the package"),
+		mcp.Enum(packageTypes...),
+	)
+}
+
+// withPackageName returns a ToolOption that adds the required "package_name" parameter to the tool.
+func withPackageName() mcp.ToolOption {
+	return mcp.WithString("package_name",
+		mcp.Required(),
+		mcp.Description("Name of the package"),
+	)
+}
+
+// withPackageVersionID returns a ToolOption that adds the required "package_version_id" parameter to the tool.
+func withPackageVersionID() mcp.ToolOption {
+	return mcp.WithNumber("package_version_id",
+		mcp.Required(),
+		mcp.Description("Unique identifier of the package version"),
+	)
+}
+
+// packageSummary returns the fields of a package that are relevant to a caller.
+func packageSummary(p *github.Package) map[string]interface{} {
+	summary := map[string]interface{}{
+		"id":            p.GetID(),
+		"name":          p.GetName(),
+		"package_type":  p.GetPackageType(),
+		"version_count": p.GetVersionCount(),
+		"visibility":    p.GetVisibility(),
+		"created_at":    p.CreatedAt,
+		"updated_at":    p.UpdatedAt,
+		"html_url":      p.GetHTMLURL(),
+	}
+	if p.PackageVersion != nil {
+		summary["package_version"] = packageVersionSummary(p.PackageVersion)
+	}
+	return summary
+}
+
+// packageVersionSummary returns the fields of a package version that are relevant to a caller,
+// including the image tags for container packages.
+func packageVersionSummary(v *github.PackageVersion) map[string]interface{} {
+	summary := map[string]interface{}{
+		"id":         v.GetID(),
+		"name":       v.GetName(),
+		"created_at": v.CreatedAt,
+		"updated_at": v.UpdatedAt,
+		"html_url":   v.GetHTMLURL(),
+	}
+	if v.Metadata != nil {
+		metadata := map[string]interface{}{
+			"package_type": v.Metadata.GetPackageType(),
+		}
+		if v.Metadata.Container != nil {
+			metadata["container"] = map[string]interface{}{
+				"tags": v.Metadata.Container.Tags,
+			}
+		}
+		summary["metadata"] = metadata
+	}
+	return summary
+}
+
+// ListOrgPackages creates a tool to list the packages of an organization.
+func ListOrgPackages(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_packages",
+			mcp.WithDescription(t("TOOL_LIST_ORG_PACKAGES_DESCRIPTION", "List the packages of a given type published by an organization")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			withPackageType(),
+			mcp.WithString("visibility",
+				mcp.Description("Filter by visibility"),
+				mcp.Enum("public", "private", "internal"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := requiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			visibility, err := OptionalParam[string](request, "visibility")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.PackageListOptions{
+				PackageType: github.Ptr(packageType),
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+			if visibility != "" {
+				opts.Visibility = github.Ptr(visibility)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			packages, resp, err := client.Organizations.ListPackages(ctx, org, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list packages: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list packages: %s", string(body))), nil
+			}
+
+			summaries := make([]map[string]interface{}, 0, len(packages))
+			for _, p := range packages {
+				summaries = append(summaries, packageSummary(p))
+			}
+
+			r, err := json.Marshal(summaries)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetOrgPackage creates a tool to get a specific package of an organization.
+func GetOrgPackage(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_package",
+			mcp.WithDescription(t("TOOL_GET_ORG_PACKAGE_DESCRIPTION", "Get a specific package published by an organization")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			withPackageType(),
+			withPackageName(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := requiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageName, err := requiredParam[string](request, "package_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pkg, resp, err := client.Organizations.GetPackage(ctx, org, packageType, packageName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get package: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get package: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(packageSummary(pkg))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListOrgPackageVersions creates a tool to list the versions of a package of an organization.
+func ListOrgPackageVersions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_package_versions",
+			mcp.WithDescription(t("TOOL_LIST_ORG_PACKAGE_VERSIONS_DESCRIPTION", "List the versions of a package published by an organization")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			withPackageType(),
+			withPackageName(),
+			mcp.WithString("state",
+				mcp.Description("Filter by the state of the version"),
+				mcp.Enum("active", "deleted"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := requiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageName, err := requiredParam[string](request, "package_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.PackageListOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+			if state != "" {
+				opts.State = github.Ptr(state)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			versions, resp, err := client.Organizations.PackageGetAllVersions(ctx, org, packageType, packageName, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list package versions: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list package versions: %s", string(body))), nil
+			}
+
+			summaries := make([]map[string]interface{}, 0, len(versions))
+			for _, v := range versions {
+				summaries = append(summaries, packageVersionSummary(v))
+			}
+
+			r, err := json.Marshal(summaries)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetOrgPackageVersion creates a tool to get a specific version of a package of an organization.
+func GetOrgPackageVersion(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_package_version",
+			mcp.WithDescription(t("TOOL_GET_ORG_PACKAGE_VERSION_DESCRIPTION", "Get a specific version of a package published by an organization")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			withPackageType(),
+			withPackageName(),
+			withPackageVersionID(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := requiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageName, err := requiredParam[string](request, "package_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			versionID, err := RequiredInt(request, "package_version_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			version, resp, err := client.Organizations.PackageGetVersion(ctx, org, packageType, packageName, int64(versionID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get package version: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get package version: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(packageVersionSummary(version))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListUserPackages creates a tool to list the packages of a user.
+func ListUserPackages(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_user_packages",
+			mcp.WithDescription(t("TOOL_LIST_USER_PACKAGES_DESCRIPTION", "List the packages of a given type published by a user")),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the package owner"),
+			),
+			withPackageType(),
+			mcp.WithString("visibility",
+				mcp.Description("Filter by visibility"),
+				mcp.Enum("public", "private", "internal"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := requiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := requiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			visibility, err := OptionalParam[string](request, "visibility")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.PackageListOptions{
+				PackageType: github.Ptr(packageType),
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+			if visibility != "" {
+				opts.Visibility = github.Ptr(visibility)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			packages, resp, err := client.Users.ListPackages(ctx, username, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list packages: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list packages: %s", string(body))), nil
+			}
+
+			summaries := make([]map[string]interface{}, 0, len(packages))
+			for _, p := range packages {
+				summaries = append(summaries, packageSummary(p))
+			}
+
+			r, err := json.Marshal(summaries)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetUserPackageVersion creates a tool to get a specific version of a package of a user.
+func GetUserPackageVersion(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_user_package_version",
+			mcp.WithDescription(t("TOOL_GET_USER_PACKAGE_VERSION_DESCRIPTION", "Get a specific version of a package published by a user")),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the package owner"),
+			),
+			withPackageType(),
+			withPackageName(),
+			withPackageVersionID(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := requiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := requiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageName, err := requiredParam[string](request, "package_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			versionID, err := RequiredInt(request, "package_version_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			version, resp, err := client.Users.PackageGetVersion(ctx, username, packageType, packageName, int64(versionID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get package version: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get package version: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(packageVersionSummary(version))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// packageOwnerIsOrganization reports whether the owner of packages is an organization rather than a user,
+// as the packages of each live under different endpoints.
+func packageOwnerIsOrganization(ctx context.Context, client *github.Client, owner string) (bool, error) {
+	user, resp, err := client.Users.Get(ctx, owner)
+	if err != nil {
+		return false, fmt.Errorf("failed to get owner: %w", err)
+	}
+	_ = resp.Body.Close()
+	return user.GetType() == "Organization", nil
+}
+
+// packageGetAllVersions lists the versions of a package of an organization or a user.
+func packageGetAllVersions(ctx context.Context, client *github.Client, isOrg bool, owner, packageType, packageName string, opts *github.PackageListOptions) ([]*github.PackageVersion, *github.Response, error) {
+	if isOrg {
+		return client.Organizations.PackageGetAllVersions(ctx, owner, packageType, packageName, opts)
+	}
+	return client.Users.PackageGetAllVersions(ctx, owner, packageType, packageName, opts)
+}
+
+// ListPackages creates a tool to list the packages of a user or an organization.
+func ListPackages(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_packages",
+			mcp.WithDescription(t("TOOL_LIST_PACKAGES_DESCRIPTION", "List the packages of a given type published by a user or an organization, with their version counts. Whether the owner is a user or an organization is detected")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("User or organization owning the packages"),
+			),
+			withPackageType(),
+			mcp.WithString("visibility",
+				mcp.Description("Filter by visibility"),
+				mcp.Enum("public", "private", "internal"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := requiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			visibility, err := OptionalParam[string](request, "visibility")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.PackageListOptions{
+				PackageType: github.Ptr(packageType),
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+			if visibility != "" {
+				opts.Visibility = github.Ptr(visibility)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			isOrg, err := packageOwnerIsOrganization(ctx, client, owner)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(fmt.Sprintf("owner %s not found", owner)), nil
+				}
+				return nil, err
+			}
+
+			ownerType := "User"
+			var packages []*github.Package
+			var resp *github.Response
+			if isOrg {
+				ownerType = "Organization"
+				packages, resp, err = client.Organizations.ListPackages(ctx, owner, opts)
+			} else {
+				packages, resp, err = client.Users.ListPackages(ctx, owner, opts)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to list packages: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			summaries := make([]map[string]interface{}, 0, len(packages))
+			for _, p := range packages {
+				summaries = append(summaries, packageSummary(p))
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"owner_type": ownerType,
+				"packages":   summaries,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListPackageVersions creates a tool to list the versions of a package of a user or an organization.
+func ListPackageVersions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_package_versions",
+			mcp.WithDescription(t("TOOL_LIST_PACKAGE_VERSIONS_DESCRIPTION", "List the versions of a package published by a user or an organization. Whether the owner is a user or an organization is detected")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("User or organization owning the package"),
+			),
+			withPackageType(),
+			withPackageName(),
+			mcp.WithString("state",
+				mcp.Description("Filter by the state of the version"),
+				mcp.Enum("active", "deleted"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := requiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageName, err := requiredParam[string](request, "package_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.PackageListOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+			if state != "" {
+				opts.State = github.Ptr(state)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			isOrg, err := packageOwnerIsOrganization(ctx, client, owner)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(fmt.Sprintf("owner %s not found", owner)), nil
+				}
+				return nil, err
+			}
+
+			ownerType := "User"
+			if isOrg {
+				ownerType = "Organization"
+			}
+			versions, resp, err := packageGetAllVersions(ctx, client, isOrg, owner, packageType, packageName, opts)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("%s package %s not found for %s", packageType, packageName, owner)), nil
+				}
+				return nil, fmt.Errorf("failed to list package versions: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			summaries := make([]map[string]interface{}, 0, len(versions))
+			for _, v := range versions {
+				summaries = append(summaries, packageVersionSummary(v))
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"owner_type": ownerType,
+				"versions":   summaries,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListContainerTags creates a tool to list the tags and digests of the versions of a container image.
+func ListContainerTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_container_tags",
+			mcp.WithDescription(t("TOOL_LIST_CONTAINER_TAGS_DESCRIPTION", "List the versions of a container image published to the GitHub Container Registry by a user or an organization, with each version's digest and tags. Untagged versions, such as dangling layers left by pushing a tag again, are included with untagged set")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("User or organization owning the image"),
+			),
+			mcp.WithString("image",
+				mcp.Required(),
+				mcp.Description("Name of the image, without the ghcr.io/owner/ prefix"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			image, err := requiredParam[string](request, "image")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			isOrg, err := packageOwnerIsOrganization(ctx, client, owner)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(fmt.Sprintf("owner %s not found", owner)), nil
+				}
+				return nil, err
+			}
+
+			opts := &github.PackageListOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+			versions, resp, err := packageGetAllVersions(ctx, client, isOrg, owner, "container", image, opts)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("container image %s not found for %s", image, owner)), nil
+				}
+				return nil, fmt.Errorf("failed to list container image versions: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			// The name of a container version is its digest
+			result := make([]map[string]interface{}, 0, len(versions))
+			for _, v := range versions {
+				tags := []string{}
+				if v.Metadata != nil && v.Metadata.Container != nil && v.Metadata.Container.Tags != nil {
+					tags = v.Metadata.Container.Tags
+				}
+				result = append(result, map[string]interface{}{
+					"id":         v.GetID(),
+					"digest":     v.GetName(),
+					"tags":       tags,
+					"untagged":   len(tags) == 0,
+					"created_at": v.CreatedAt,
+					"updated_at": v.UpdatedAt,
+					"html_url":   v.GetHTMLURL(),
+				})
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteOrgPackage creates a tool to delete a package of an organization.
+func DeleteOrgPackage(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_org_package",
+			mcp.WithDescription(t("TOOL_DELETE_ORG_PACKAGE_DESCRIPTION", "Delete an entire package published by an organization. Requires a token with the delete:packages scope")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			withPackageType(),
+			withPackageName(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := requiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageName, err := requiredParam[string](request, "package_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Organizations.DeletePackage(ctx, org, packageType, packageName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete package: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete package: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Package %s deleted", packageName)), nil
+		}
+}
+
+// DeleteOrgPackageVersion creates a tool to delete a specific version of a package of an organization.
+func DeleteOrgPackageVersion(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_org_package_version",
+			mcp.WithDescription(t("TOOL_DELETE_ORG_PACKAGE_VERSION_DESCRIPTION", "Delete a specific version of a package published by an organization. Requires a token with the delete:packages scope")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			withPackageType(),
+			withPackageName(),
+			withPackageVersionID(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := requiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageName, err := requiredParam[string](request, "package_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			versionID, err := RequiredInt(request, "package_version_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Organizations.PackageDeleteVersion(ctx, org, packageType, packageName, int64(versionID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete package version: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete package version: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Version %d of package %s deleted", versionID, packageName)), nil
+		}
+}
+
+// RestoreOrgPackage creates a tool to restore a deleted package of an organization.
+func RestoreOrgPackage(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("restore_org_package",
+			mcp.WithDescription(t("TOOL_RESTORE_ORG_PACKAGE_DESCRIPTION", "Restore a package deleted by an organization within the last 30 days")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			withPackageType(),
+			withPackageName(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := requiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageName, err := requiredParam[string](request, "package_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Organizations.RestorePackage(ctx, org, packageType, packageName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to restore package: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to restore package: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Package %s restored", packageName)), nil
+		}
+}
+
+// RestoreOrgPackageVersion creates a tool to restore a deleted version of a package of an organization.
+func RestoreOrgPackageVersion(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("restore_org_package_version",
+			mcp.WithDescription(t("TOOL_RESTORE_ORG_PACKAGE_VERSION_DESCRIPTION", "Restore a package version deleted by an organization within the last 30 days")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			withPackageType(),
+			withPackageName(),
+			withPackageVersionID(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := requiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageName, err := requiredParam[string](request, "package_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			versionID, err := RequiredInt(request, "package_version_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Organizations.PackageRestoreVersion(ctx, org, packageType, packageName, int64(versionID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to restore package version: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to restore package version: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Version %d of package %s restored", versionID, packageName)), nil
+		}
+}
+
+// DeletePackageVersion creates a tool to delete a specific version of a package of a user or an organization.
+func DeletePackageVersion(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_package_version",
+			mcp.WithDescription(t("TOOL_DELETE_PACKAGE_VERSION_DESCRIPTION", "Delete a specific version of a package published by a user or an organization. Whether the owner is a user or an organization is detected. GitHub refuses to delete the last version of a package, which needs the whole package deleted instead, and versions of public packages downloaded over 5,000 times. Requires a token with the delete:packages scope")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("User or organization owning the package"),
+			),
+			withPackageType(),
+			withPackageName(),
+			withPackageVersionID(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := requiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageName, err := requiredParam[string](request, "package_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			versionID, err := RequiredInt(request, "package_version_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			isOrg, err := packageOwnerIsOrganization(ctx, client, owner)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(fmt.Sprintf("owner %s not found", owner)), nil
+				}
+				return nil, err
+			}
+
+			var resp *github.Response
+			if isOrg {
+				resp, err = client.Organizations.PackageDeleteVersion(ctx, owner, packageType, packageName, int64(versionID))
+			} else {
+				resp, err = client.Users.PackageDeleteVersion(ctx, owner, packageType, packageName, int64(versionID))
+			}
+			if err != nil {
+				// GitHub refuses to delete the last version of a package and heavily downloaded versions of
+				// public ones, with the reason
+				var errorResponse *github.ErrorResponse
+				if errors.As(err, &errorResponse) && resp != nil {
+					switch resp.StatusCode {
+					case http.StatusNotFound:
+						return mcp.NewToolResultError(fmt.Sprintf("version %d of %s package %s not found for %s", versionID, packageType, packageName, owner)), nil
+					case http.StatusBadRequest, http.StatusForbidden, http.StatusUnprocessableEntity:
+						return mcp.NewToolResultError(fmt.Sprintf("cannot delete version %d of %s package %s: %s", versionID, packageType, packageName, errorResponse.Message)), nil
+					}
+				}
+				return nil, fmt.Errorf("failed to delete package version: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Version %d of package %s deleted", versionID, packageName)), nil
+		}
+}
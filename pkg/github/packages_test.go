@@ -0,0 +1,1471 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgPackages(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgPackages(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_packages", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "package_type")
+	assert.Contains(t, tool.InputSchema.Properties, "visibility")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "package_type"})
+
+	mockPackages := []*github.Package{
+		{
+			ID:           github.Ptr(int64(1)),
+			Name:         github.Ptr("app"),
+			PackageType:  github.Ptr("container"),
+			VersionCount: github.Ptr(int64(3)),
+			Visibility:   github.Ptr("private"),
+			HTMLURL:      github.Ptr("https://github.com/orgs/org/packages/container/package/app"),
+		},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedPackages []*github.Package
+		expectedErrMsg   string
+	}{
+		{
+			name: "successful packages listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesByOrg,
+					expectQueryParams(t, map[string]string{
+						"package_type": "container",
+						"visibility":   "private",
+						"page":         "1",
+						"per_page":     "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockPackages),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":          "org",
+				"package_type": "container",
+				"visibility":   "private",
+			},
+			expectError:      false,
+			expectedPackages: mockPackages,
+		},
+		{
+			name: "packages listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesByOrg,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":          "org",
+				"package_type": "container",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list packages",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgPackages(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedPackages []*github.Package
+			err = json.Unmarshal([]byte(textContent.Text), &returnedPackages)
+			require.NoError(t, err)
+			require.Len(t, returnedPackages, len(tc.expectedPackages))
+			for i, p := range returnedPackages {
+				assert.Equal(t, *tc.expectedPackages[i].ID, *p.ID)
+				assert.Equal(t, *tc.expectedPackages[i].Name, *p.Name)
+				assert.Equal(t, *tc.expectedPackages[i].VersionCount, *p.VersionCount)
+				assert.Equal(t, *tc.expectedPackages[i].Visibility, *p.Visibility)
+			}
+		})
+	}
+}
+
+func Test_GetOrgPackage(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgPackage(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_org_package", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "package_type")
+	assert.Contains(t, tool.InputSchema.Properties, "package_name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "package_type", "package_name"})
+
+	mockPackage := &github.Package{
+		ID:           github.Ptr(int64(1)),
+		Name:         github.Ptr("app"),
+		PackageType:  github.Ptr("npm"),
+		VersionCount: github.Ptr(int64(10)),
+		Visibility:   github.Ptr("public"),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedPackage *github.Package
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful package fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsPackagesByOrgByPackageTypeByPackageName,
+					mockPackage,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":          "org",
+				"package_type": "npm",
+				"package_name": "app",
+			},
+			expectError:     false,
+			expectedPackage: mockPackage,
+		},
+		{
+			name: "package not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesByOrgByPackageTypeByPackageName,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":          "org",
+				"package_type": "npm",
+				"package_name": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get package",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOrgPackage(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedPackage github.Package
+			err = json.Unmarshal([]byte(textContent.Text), &returnedPackage)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.expectedPackage.ID, *returnedPackage.ID)
+			assert.Equal(t, *tc.expectedPackage.Name, *returnedPackage.Name)
+			assert.Equal(t, *tc.expectedPackage.PackageType, *returnedPackage.PackageType)
+		})
+	}
+}
+
+func Test_ListOrgPackageVersions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgPackageVersions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_package_versions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "package_type")
+	assert.Contains(t, tool.InputSchema.Properties, "package_name")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "package_type", "package_name"})
+
+	mockVersions := []*github.PackageVersion{
+		{
+			ID:   github.Ptr(int64(100)),
+			Name: github.Ptr("sha256:abc"),
+			Metadata: &github.PackageMetadata{
+				PackageType: github.Ptr("container"),
+				Container: &github.PackageContainerMetadata{
+					Tags: []string{"latest", "v1.0.0"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedTags   []string
+		expectedErrMsg string
+	}{
+		{
+			name: "successful versions listing with container tags",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesVersionsByOrgByPackageTypeByPackageName,
+					expectQueryParams(t, map[string]string{
+						"state":    "active",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockVersions),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":          "org",
+				"package_type": "container",
+				"package_name": "app",
+				"state":        "active",
+			},
+			expectError:  false,
+			expectedTags: []string{"latest", "v1.0.0"},
+		},
+		{
+			name: "versions listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesVersionsByOrgByPackageTypeByPackageName,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":          "org",
+				"package_type": "container",
+				"package_name": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list package versions",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgPackageVersions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedVersions []*github.PackageVersion
+			err = json.Unmarshal([]byte(textContent.Text), &returnedVersions)
+			require.NoError(t, err)
+			require.Len(t, returnedVersions, 1)
+			assert.Equal(t, int64(100), *returnedVersions[0].ID)
+			assert.Equal(t, tc.expectedTags, returnedVersions[0].Metadata.Container.Tags)
+		})
+	}
+}
+
+func Test_GetOrgPackageVersion(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgPackageVersion(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_org_package_version", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "package_version_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "package_type", "package_name", "package_version_id"})
+
+	mockVersion := &github.PackageVersion{
+		ID:   github.Ptr(int64(100)),
+		Name: github.Ptr("1.2.3"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful version fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsPackagesVersionsByOrgByPackageTypeByPackageNameByPackageVersionId,
+					mockVersion,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                "org",
+				"package_type":       "npm",
+				"package_name":       "app",
+				"package_version_id": float64(100),
+			},
+			expectError: false,
+		},
+		{
+			name: "version not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesVersionsByOrgByPackageTypeByPackageNameByPackageVersionId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                "org",
+				"package_type":       "npm",
+				"package_name":       "app",
+				"package_version_id": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get package version",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOrgPackageVersion(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedVersion github.PackageVersion
+			err = json.Unmarshal([]byte(textContent.Text), &returnedVersion)
+			require.NoError(t, err)
+			assert.Equal(t, *mockVersion.ID, *returnedVersion.ID)
+			assert.Equal(t, *mockVersion.Name, *returnedVersion.Name)
+		})
+	}
+}
+
+func Test_ListUserPackages(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListUserPackages(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_user_packages", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.Contains(t, tool.InputSchema.Properties, "package_type")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username", "package_type"})
+
+	mockPackages := []*github.Package{
+		{
+			ID:          github.Ptr(int64(2)),
+			Name:        github.Ptr("lib"),
+			PackageType: github.Ptr("npm"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful user packages listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersPackagesByUsername,
+					mockPackages,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username":     "octocat",
+				"package_type": "npm",
+			},
+			expectError: false,
+		},
+		{
+			name: "user packages listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersPackagesByUsername,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username":     "ghost",
+				"package_type": "npm",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list packages",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListUserPackages(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedPackages []*github.Package
+			err = json.Unmarshal([]byte(textContent.Text), &returnedPackages)
+			require.NoError(t, err)
+			require.Len(t, returnedPackages, 1)
+			assert.Equal(t, "lib", *returnedPackages[0].Name)
+		})
+	}
+}
+
+func Test_GetUserPackageVersion(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetUserPackageVersion(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_user_package_version", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username", "package_type", "package_name", "package_version_id"})
+
+	mockVersion := &github.PackageVersion{
+		ID:   github.Ptr(int64(7)),
+		Name: github.Ptr("2.0.0"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful user version fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersPackagesVersionsByUsernameByPackageTypeByPackageNameByPackageVersionId,
+					mockVersion,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username":           "octocat",
+				"package_type":       "npm",
+				"package_name":       "lib",
+				"package_version_id": float64(7),
+			},
+			expectError: false,
+		},
+		{
+			name: "user version fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersPackagesVersionsByUsernameByPackageTypeByPackageNameByPackageVersionId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username":           "octocat",
+				"package_type":       "npm",
+				"package_name":       "lib",
+				"package_version_id": float64(8),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get package version",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetUserPackageVersion(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedVersion github.PackageVersion
+			err = json.Unmarshal([]byte(textContent.Text), &returnedVersion)
+			require.NoError(t, err)
+			assert.Equal(t, *mockVersion.Name, *returnedVersion.Name)
+		})
+	}
+}
+
+func Test_ListPackages(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPackages(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_packages", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "package_type")
+	assert.Contains(t, tool.InputSchema.Properties, "visibility")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "package_type"})
+
+	mockOrgPackages := []*github.Package{
+		{
+			ID:           github.Ptr(int64(1)),
+			Name:         github.Ptr("api-image"),
+			PackageType:  github.Ptr("container"),
+			VersionCount: github.Ptr(int64(12)),
+		},
+	}
+	mockUserPackages := []*github.Package{
+		{
+			ID:           github.Ptr(int64(2)),
+			Name:         github.Ptr("lib"),
+			PackageType:  github.Ptr("npm"),
+			VersionCount: github.Ptr(int64(3)),
+		},
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedOwnerType string
+		expectedPackages  []*github.Package
+	}{
+		{
+			name: "organization packages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					&github.User{Login: github.Ptr("octo-org"), Type: github.Ptr("Organization")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesByOrg,
+					expectQueryParams(t, map[string]string{
+						"package_type": "container",
+						"visibility":   "private",
+						"page":         "1",
+						"per_page":     "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockOrgPackages),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "octo-org",
+				"package_type": "container",
+				"visibility":   "private",
+			},
+			expectError:       false,
+			expectedOwnerType: "Organization",
+			expectedPackages:  mockOrgPackages,
+		},
+		{
+			name: "user packages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					&github.User{Login: github.Ptr("octocat"), Type: github.Ptr("User")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetUsersPackagesByUsername,
+					expectQueryParams(t, map[string]string{
+						"package_type": "npm",
+						"page":         "2",
+						"per_page":     "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockUserPackages),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "octocat",
+				"package_type": "npm",
+				"page":         float64(2),
+				"perPage":      float64(10),
+			},
+			expectError:       false,
+			expectedOwnerType: "User",
+			expectedPackages:  mockUserPackages,
+		},
+		{
+			name: "owner not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersByUsername,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "ghost",
+				"package_type": "npm",
+			},
+			expectError:    false,
+			expectedErrMsg: "owner ghost not found",
+		},
+		{
+			name: "packages listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					&github.User{Login: github.Ptr("octo-org"), Type: github.Ptr("Organization")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesByOrg,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "octo-org",
+				"package_type": "npm",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list packages",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPackages(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned struct {
+				OwnerType string            `json:"owner_type"`
+				Packages  []*github.Package `json:"packages"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOwnerType, returned.OwnerType)
+			require.Len(t, returned.Packages, len(tc.expectedPackages))
+			for i, p := range returned.Packages {
+				assert.Equal(t, tc.expectedPackages[i].GetName(), p.GetName())
+				assert.Equal(t, tc.expectedPackages[i].GetPackageType(), p.GetPackageType())
+				assert.Equal(t, tc.expectedPackages[i].GetVersionCount(), p.GetVersionCount())
+			}
+		})
+	}
+}
+
+func Test_ListPackageVersions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPackageVersions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_package_versions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "package_type")
+	assert.Contains(t, tool.InputSchema.Properties, "package_name")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "package_type", "package_name"})
+
+	mockVersions := []*github.PackageVersion{
+		{ID: github.Ptr(int64(11)), Name: github.Ptr("1.1.0")},
+		{ID: github.Ptr(int64(10)), Name: github.Ptr("1.0.0")},
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedOwnerType string
+	}{
+		{
+			name: "organization package versions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					&github.User{Login: github.Ptr("octo-org"), Type: github.Ptr("Organization")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesVersionsByOrgByPackageTypeByPackageName,
+					expectQueryParams(t, map[string]string{
+						"state":    "active",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockVersions),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "octo-org",
+				"package_type": "npm",
+				"package_name": "lib",
+				"state":        "active",
+			},
+			expectError:       false,
+			expectedOwnerType: "Organization",
+		},
+		{
+			name: "user package versions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					&github.User{Login: github.Ptr("octocat"), Type: github.Ptr("User")},
+				),
+				mock.WithRequestMatch(
+					mock.GetUsersPackagesVersionsByUsernameByPackageTypeByPackageName,
+					mockVersions,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "octocat",
+				"package_type": "npm",
+				"package_name": "lib",
+			},
+			expectError:       false,
+			expectedOwnerType: "User",
+		},
+		{
+			name: "package not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					&github.User{Login: github.Ptr("octocat"), Type: github.Ptr("User")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetUsersPackagesVersionsByUsernameByPackageTypeByPackageName,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Package not found."}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "octocat",
+				"package_type": "npm",
+				"package_name": "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "npm package missing not found for octocat",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPackageVersions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned struct {
+				OwnerType string                   `json:"owner_type"`
+				Versions  []*github.PackageVersion `json:"versions"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOwnerType, returned.OwnerType)
+			require.Len(t, returned.Versions, 2)
+			assert.Equal(t, int64(11), returned.Versions[0].GetID())
+			assert.Equal(t, "1.0.0", returned.Versions[1].GetName())
+		})
+	}
+}
+
+func Test_ListContainerTags(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListContainerTags(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_container_tags", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "image")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "image"})
+
+	mockVersions := []*github.PackageVersion{
+		{
+			ID:   github.Ptr(int64(21)),
+			Name: github.Ptr("sha256:aaa"),
+			Metadata: &github.PackageMetadata{
+				PackageType: github.Ptr("container"),
+				Container:   &github.PackageContainerMetadata{Tags: []string{"latest", "v1.2.0"}},
+			},
+		},
+		{
+			ID:   github.Ptr(int64(20)),
+			Name: github.Ptr("sha256:bbb"),
+			Metadata: &github.PackageMetadata{
+				PackageType: github.Ptr("container"),
+				Container:   &github.PackageContainerMetadata{Tags: []string{}},
+			},
+		},
+		{
+			ID:   github.Ptr(int64(19)),
+			Name: github.Ptr("sha256:ccc"),
+		},
+	}
+
+	type containerTag struct {
+		ID       int64    `json:"id"`
+		Digest   string   `json:"digest"`
+		Tags     []string `json:"tags"`
+		Untagged bool     `json:"untagged"`
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedTags   []containerTag
+	}{
+		{
+			name: "tagged and untagged versions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					&github.User{Login: github.Ptr("octo-org"), Type: github.Ptr("Organization")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesVersionsByOrgByPackageTypeByPackageName,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/orgs/octo-org/packages/container/api/versions", r.URL.Path)
+						mockResponse(t, http.StatusOK, mockVersions)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octo-org",
+				"image": "api",
+			},
+			expectError: false,
+			expectedTags: []containerTag{
+				{ID: 21, Digest: "sha256:aaa", Tags: []string{"latest", "v1.2.0"}, Untagged: false},
+				{ID: 20, Digest: "sha256:bbb", Tags: []string{}, Untagged: true},
+				{ID: 19, Digest: "sha256:ccc", Tags: []string{}, Untagged: true},
+			},
+		},
+		{
+			name: "user image",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					&github.User{Login: github.Ptr("octocat"), Type: github.Ptr("User")},
+				),
+				mock.WithRequestMatch(
+					mock.GetUsersPackagesVersionsByUsernameByPackageTypeByPackageName,
+					mockVersions[:1],
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octocat",
+				"image": "tools",
+			},
+			expectError: false,
+			expectedTags: []containerTag{
+				{ID: 21, Digest: "sha256:aaa", Tags: []string{"latest", "v1.2.0"}, Untagged: false},
+			},
+		},
+		{
+			name: "image not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					&github.User{Login: github.Ptr("octocat"), Type: github.Ptr("User")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetUsersPackagesVersionsByUsernameByPackageTypeByPackageName,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Package not found."}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octocat",
+				"image": "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "container image missing not found for octocat",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListContainerTags(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned []containerTag
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTags, returned)
+		})
+	}
+}
+
+func Test_DeleteOrgPackage(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteOrgPackage(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_org_package", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "package_type", "package_name"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "successful package deletion",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsPackagesByOrgByPackageTypeByPackageName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":          "org",
+				"package_type": "npm",
+				"package_name": "app",
+			},
+			expectError:  false,
+			expectedText: "Package app deleted",
+		},
+		{
+			name: "package deletion without scope fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsPackagesByOrgByPackageTypeByPackageName,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have delete:packages scope"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":          "org",
+				"package_type": "npm",
+				"package_name": "app",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete package",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteOrgPackage(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_DeleteOrgPackageVersion(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteOrgPackageVersion(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_org_package_version", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "package_type", "package_name", "package_version_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "successful version deletion",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsPackagesVersionsByOrgByPackageTypeByPackageNameByPackageVersionId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                "org",
+				"package_type":       "npm",
+				"package_name":       "app",
+				"package_version_id": float64(100),
+			},
+			expectError:  false,
+			expectedText: "Version 100 of package app deleted",
+		},
+		{
+			name: "version deletion fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsPackagesVersionsByOrgByPackageTypeByPackageNameByPackageVersionId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                "org",
+				"package_type":       "npm",
+				"package_name":       "app",
+				"package_version_id": float64(101),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete package version",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteOrgPackageVersion(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_RestoreOrgPackage(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RestoreOrgPackage(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "restore_org_package", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "package_type", "package_name"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "successful package restore",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsPackagesRestoreByOrgByPackageTypeByPackageName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":          "org",
+				"package_type": "maven",
+				"package_name": "app",
+			},
+			expectError:  false,
+			expectedText: "Package app restored",
+		},
+		{
+			name: "package restore fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsPackagesRestoreByOrgByPackageTypeByPackageName,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":          "org",
+				"package_type": "maven",
+				"package_name": "app",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to restore package",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RestoreOrgPackage(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_RestoreOrgPackageVersion(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RestoreOrgPackageVersion(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "restore_org_package_version", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "package_type", "package_name", "package_version_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "successful version restore",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsPackagesVersionsRestoreByOrgByPackageTypeByPackageNameByPackageVersionId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                "org",
+				"package_type":       "maven",
+				"package_name":       "app",
+				"package_version_id": float64(5),
+			},
+			expectError:  false,
+			expectedText: "Version 5 of package app restored",
+		},
+		{
+			name: "version restore fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsPackagesVersionsRestoreByOrgByPackageTypeByPackageNameByPackageVersionId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                "org",
+				"package_type":       "maven",
+				"package_name":       "app",
+				"package_version_id": float64(6),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to restore package version",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RestoreOrgPackageVersion(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_DeletePackageVersion(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeletePackageVersion(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_package_version", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "package_type", "package_name", "package_version_id"})
+
+	organization := &github.User{Login: github.Ptr("octo-org"), Type: github.Ptr("Organization")}
+	user := &github.User{Login: github.Ptr("octocat"), Type: github.Ptr("User")}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "organization package version deleted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					organization,
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsPackagesVersionsByOrgByPackageTypeByPackageNameByPackageVersionId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":              "octo-org",
+				"package_type":       "container",
+				"package_name":       "api-image",
+				"package_version_id": float64(11),
+			},
+			expectError:  false,
+			expectedText: "Version 11 of package api-image deleted",
+		},
+		{
+			name: "user package version deleted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					user,
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteUsersPackagesVersionsByUsernameByPackageTypeByPackageNameByPackageVersionId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":              "octocat",
+				"package_type":       "npm",
+				"package_name":       "lib",
+				"package_version_id": float64(7),
+			},
+			expectError:  false,
+			expectedText: "Version 7 of package lib deleted",
+		},
+		{
+			name: "last version of a public package",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					organization,
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsPackagesVersionsByOrgByPackageTypeByPackageNameByPackageVersionId,
+					mockResponse(t, http.StatusBadRequest, map[string]string{"message": "You cannot delete the last version of a package. You must delete the package instead."}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":              "octo-org",
+				"package_type":       "npm",
+				"package_name":       "lib",
+				"package_version_id": float64(10),
+			},
+			expectError:    false,
+			expectedErrMsg: "cannot delete version 10 of npm package lib: You cannot delete the last version of a package. You must delete the package instead.",
+		},
+		{
+			name: "version not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					user,
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteUsersPackagesVersionsByUsernameByPackageTypeByPackageNameByPackageVersionId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":              "octocat",
+				"package_type":       "npm",
+				"package_name":       "lib",
+				"package_version_id": float64(999),
+			},
+			expectError:    false,
+			expectedErrMsg: "version 999 of npm package lib not found for octocat",
+		},
+		{
+			name: "owner not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersByUsername,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":              "ghost",
+				"package_type":       "npm",
+				"package_name":       "lib",
+				"package_version_id": float64(1),
+			},
+			expectError:    false,
+			expectedErrMsg: "owner ghost not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeletePackageVersion(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
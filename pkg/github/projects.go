@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// orgProjectsQuery fetches a page of the projects of an organization, along with how many items each holds.
+const orgProjectsQuery = `query($org: String!, $first: Int!, $after: String) {
+  organization(login: $org) {
+    projectsV2(first: $first, after: $after, orderBy: {field: NUMBER, direction: ASC}) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        number
+        title
+        url
+        closed
+        items { totalCount }
+      }
+    }
+  }
+}`
+
+// orgProject is a project of an organization.
+type orgProject struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	URL       string `json:"url"`
+	Closed    bool   `json:"closed"`
+	ItemCount int    `json:"item_count"`
+}
+
+// ListOrgProjects creates a tool to list the projects of an organization.
+func ListOrgProjects(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_projects",
+			mcp.WithDescription(t("TOOL_LIST_ORG_PROJECTS_DESCRIPTION", "List the projects of a GitHub organization, with the number of items in each")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("after",
+				mcp.Description("Cursor to fetch the projects after, as returned in next_cursor"),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description("Results per page (min 1, max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			after, err := OptionalParam[string](request, "after")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			perPage, err := OptionalIntParamWithDefault(request, "perPage", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if perPage < 1 || perPage > 100 {
+				return mcp.NewToolResultError("perPage must be between 1 and 100"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			variables := map[string]interface{}{
+				"org":   org,
+				"first": perPage,
+			}
+			if after != "" {
+				variables["after"] = after
+			}
+			var data struct {
+				Organization struct {
+					ProjectsV2 struct {
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+						Nodes []struct {
+							Number int    `json:"number"`
+							Title  string `json:"title"`
+							URL    string `json:"url"`
+							Closed bool   `json:"closed"`
+							Items  struct {
+								TotalCount int `json:"totalCount"`
+							} `json:"items"`
+						} `json:"nodes"`
+					} `json:"projectsV2"`
+				} `json:"organization"`
+			}
+			if err := executeGraphQL(ctx, client, orgProjectsQuery, variables, &data); err != nil {
+				if isGraphQLNotFound(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("organization %s not found", org)), nil
+				}
+				return nil, fmt.Errorf("failed to list organization projects: %w", err)
+			}
+
+			connection := data.Organization.ProjectsV2
+			projects := make([]orgProject, 0, len(connection.Nodes))
+			for _, node := range connection.Nodes {
+				projects = append(projects, orgProject{
+					Number:    node.Number,
+					Title:     node.Title,
+					URL:       node.URL,
+					Closed:    node.Closed,
+					ItemCount: node.Items.TotalCount,
+				})
+			}
+
+			result := map[string]interface{}{
+				"projects":    projects,
+				"next_cursor": nil,
+			}
+			if connection.PageInfo.HasNextPage {
+				result["next_cursor"] = connection.PageInfo.EndCursor
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,215 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgProjects(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgProjects(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_projects", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	graphQLEndpoint := mock.EndpointPattern{
+		Pattern: "/graphql",
+		Method:  "POST",
+	}
+	projectsResponse := map[string]interface{}{
+		"data": map[string]interface{}{
+			"organization": map[string]interface{}{
+				"projectsV2": map[string]interface{}{
+					"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "Y3Vyc29yOjI="},
+					"nodes": []map[string]interface{}{
+						{
+							"number": 1,
+							"title":  "Roadmap",
+							"url":    "https://github.com/orgs/org/projects/1",
+							"closed": false,
+							"items":  map[string]interface{}{"totalCount": 42},
+						},
+						{
+							"number": 2,
+							"title":  "2024 Planning",
+							"url":    "https://github.com/orgs/org/projects/2",
+							"closed": true,
+							"items":  map[string]interface{}{"totalCount": 0},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedErrMsg     string
+		expectedProjects   []orgProject
+		expectedNextCursor interface{}
+	}{
+		{
+			name: "first page of projects",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body graphQLRequest
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						assert.Equal(t, map[string]interface{}{"org": "org", "first": float64(30)}, body.Variables)
+						mockResponse(t, http.StatusOK, projectsResponse)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError: false,
+			expectedProjects: []orgProject{
+				{Number: 1, Title: "Roadmap", URL: "https://github.com/orgs/org/projects/1", ItemCount: 42},
+				{Number: 2, Title: "2024 Planning", URL: "https://github.com/orgs/org/projects/2", Closed: true, ItemCount: 0},
+			},
+			expectedNextCursor: "Y3Vyc29yOjI=",
+		},
+		{
+			name: "last page of projects",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body graphQLRequest
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						assert.Equal(t, map[string]interface{}{"org": "org", "first": float64(10), "after": "Y3Vyc29yOjI="}, body.Variables)
+						mockResponse(t, http.StatusOK, map[string]interface{}{
+							"data": map[string]interface{}{
+								"organization": map[string]interface{}{
+									"projectsV2": map[string]interface{}{
+										"pageInfo": map[string]interface{}{"hasNextPage": false, "endCursor": "Y3Vyc29yOjM="},
+										"nodes": []map[string]interface{}{
+											{
+												"number": 3,
+												"title":  "Bugs",
+												"url":    "https://github.com/orgs/org/projects/3",
+												"closed": false,
+												"items":  map[string]interface{}{"totalCount": 7},
+											},
+										},
+									},
+								},
+							},
+						})(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":     "org",
+				"after":   "Y3Vyc29yOjI=",
+				"perPage": float64(10),
+			},
+			expectError: false,
+			expectedProjects: []orgProject{
+				{Number: 3, Title: "Bugs", URL: "https://github.com/orgs/org/projects/3", ItemCount: 7},
+			},
+			expectedNextCursor: nil,
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					graphQLEndpoint,
+					map[string]interface{}{
+						"data":   map[string]interface{}{"organization": nil},
+						"errors": []map[string]interface{}{{"type": "NOT_FOUND", "path": []string{"organization"}, "message": "Could not resolve to an Organization with the login of 'missing'."}},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "organization missing not found",
+		},
+		{
+			name:         "perPage out of range",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":     "org",
+				"perPage": float64(500),
+			},
+			expectError:    false,
+			expectedErrMsg: "perPage must be between 1 and 100",
+		},
+		{
+			name: "query fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					graphQLEndpoint,
+					map[string]interface{}{
+						"data":   nil,
+						"errors": []map[string]interface{}{{"message": "Resource not accessible by integration"}},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list organization projects: GraphQL query failed: Resource not accessible by integration",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgProjects(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Projects   []orgProject `json:"projects"`
+				NextCursor interface{}  `json:"next_cursor"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedProjects, response.Projects)
+			assert.Equal(t, tc.expectedNextCursor, response.NextCursor)
+		})
+	}
+}
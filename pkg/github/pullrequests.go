@@ -3,10 +3,18 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"math"
 	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/github/github-mcp-server/pkg/codeowners"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v69/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -218,6 +226,9 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Description("Sort direction"),
 				mcp.Enum("asc", "desc"),
 			),
+			mcp.WithBoolean("draft",
+				mcp.Description("Only return draft pull requests when true, or only ready for review ones when false. Applied to each fetched page, so a page may hold fewer results than perPage"),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -249,6 +260,10 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			draft, filterDraft, err := OptionalParamOK[bool](request, "draft")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -284,6 +299,13 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list pull requests: %s", string(body))), nil
 			}
 
+			// The list endpoint can't filter by draft, so filter the fetched page instead
+			if filterDraft {
+				prs = slices.DeleteFunc(prs, func(pr *github.PullRequest) bool {
+					return pr.GetDraft() != draft
+				})
+			}
+
 			r, err := json.Marshal(prs)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
@@ -293,6 +315,102 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
+// GetPullRequestForBranch creates a tool to find the open pull request of a branch.
+func GetPullRequestForBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_for_branch",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_FOR_BRANCH_DESCRIPTION", "Find the open pull request of a branch. Returns its number, or all of them if the branch has several open pull requests")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Name of the head branch"),
+			),
+			mcp.WithString("head_owner",
+				mcp.Description("Owner of the fork the branch is in, for pull requests across repositories. Defaults to the repository owner"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := requiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			headOwner, err := OptionalParam[string](request, "head_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if headOwner == "" {
+				headOwner = owner
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			// The head filter must be qualified with the owner, otherwise it is ignored
+			opts := &github.PullRequestListOptions{
+				State:       "open",
+				Head:        headOwner + ":" + branch,
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			prs, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list pull requests: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list pull requests: %s", string(body))), nil
+			}
+
+			if len(prs) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("no open pull request found for branch %s:%s in %s/%s", headOwner, branch, owner, repo)), nil
+			}
+
+			pullRequests := make([]map[string]interface{}, 0, len(prs))
+			for _, pr := range prs {
+				pullRequests = append(pullRequests, map[string]interface{}{
+					"number":   pr.GetNumber(),
+					"title":    pr.GetTitle(),
+					"draft":    pr.GetDraft(),
+					"user":     pr.GetUser().GetLogin(),
+					"base":     pr.GetBase().GetRef(),
+					"html_url": pr.GetHTMLURL(),
+				})
+			}
+			result := map[string]interface{}{
+				"pull_requests": pullRequests,
+			}
+			if len(prs) == 1 {
+				result["number"] = prs[0].GetNumber()
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 // MergePullRequest creates a tool to merge a pull request.
 func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("merge_pull_request",
@@ -437,6 +555,75 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 		}
 }
 
+// GetPullRequestPatch creates a tool to get a pull request as a patch file.
+func GetPullRequestPatch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pr_patch",
+			mcp.WithDescription(t("TOOL_GET_PR_PATCH_DESCRIPTION", "Get the commits of a pull request as an mbox-format patch file, as used by git am. Patches over 100 KB are truncated")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			patch, resp, err := client.PullRequests.GetRaw(ctx, owner, repo, pullNumber, github.RawOptions{Type: github.Patch})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("pull request %d not found in %s/%s", pullNumber, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get pull request patch: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			truncatedPatch, truncated := truncatePatch(patch)
+			result := map[string]interface{}{
+				"patch":       truncatedPatch,
+				"truncated":   truncated,
+				"total_bytes": len(patch),
+			}
+			if truncated {
+				// Point at the full patch on the web so it can still be downloaded
+				pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get pull request: %w", err)
+				}
+				_ = resp.Body.Close()
+				result["patch_url"] = pr.GetPatchURL()
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 // GetPullRequestStatus creates a tool to get the combined status of all status checks for a pull request.
 func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request_status",
@@ -876,6 +1063,208 @@ func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelp
 		}
 }
 
+// GetPullRequestReviewSummary creates a tool to count the reviews on a pull request by state.
+func GetPullRequestReviewSummary(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_review_summary",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_REVIEW_SUMMARY_DESCRIPTION", "Get how many reviewers approved, requested changes on or commented on a pull request. Only the latest review of each reviewer is counted")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			reviews, err := listAllPullRequestReviews(ctx, client, owner, repo, pullNumber)
+			if err != nil {
+				return nil, err
+			}
+
+			// Reviews are listed oldest first, so the last one seen for a reviewer is their latest. As on GitHub, a
+			// comment doesn't replace an earlier approval or change request of the same reviewer
+			latestStates := map[string]string{}
+			for _, review := range reviews {
+				login, state := review.GetUser().GetLogin(), review.GetState()
+				switch state {
+				case "PENDING":
+					continue
+				case "COMMENTED":
+					if previous, ok := latestStates[login]; ok && previous != "COMMENTED" {
+						continue
+					}
+				}
+				latestStates[login] = state
+			}
+
+			summary := map[string]int{
+				"approved":          0,
+				"changes_requested": 0,
+				"commented":         0,
+				"dismissed":         0,
+			}
+			for _, state := range latestStates {
+				summary[strings.ToLower(state)]++
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"summary":   summary,
+				"reviewers": latestStates,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetPendingReviewers creates a tool to get the requested reviewers of a pull request who haven't reviewed it yet.
+func GetPendingReviewers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pending_reviewers",
+			mcp.WithDescription(t("TOOL_GET_PENDING_REVIEWERS_DESCRIPTION", "Get the users and teams whose review of a pull request is requested but who haven't reviewed it yet. Only reviews submitted since a reviewer was last requested count. A team's request is fulfilled once one of its members has reviewed; teams whose members can't be seen stay pending")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			requested, resp, err := client.PullRequests.ListReviewers(ctx, owner, repo, pullNumber, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("pull request #%d not found in %s/%s", pullNumber, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to list requested reviewers: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			reviews, err := listAllPullRequestReviews(ctx, client, owner, repo, pullNumber)
+			if err != nil {
+				return nil, err
+			}
+			// Drafts aren't visible to anyone else, and a dismissed review is owed again
+			lastReviewedAt := map[string]time.Time{}
+			for _, review := range reviews {
+				login := strings.ToLower(review.GetUser().GetLogin())
+				if state := review.GetState(); state != "PENDING" && state != "DISMISSED" && !review.GetSubmittedAt().Before(lastReviewedAt[login]) {
+					lastReviewedAt[login] = review.GetSubmittedAt().Time
+				}
+			}
+
+			// A review only answers the latest request of a reviewer, so one from before a re-request doesn't count
+			timeline, err := listAllTimelineEvents(ctx, client, owner, repo, pullNumber)
+			if err != nil {
+				return nil, err
+			}
+			userRequestedAt, teamRequestedAt := map[string]time.Time{}, map[string]time.Time{}
+			for _, event := range timeline {
+				if event.GetEvent() != "review_requested" {
+					continue
+				}
+				if event.Reviewer != nil {
+					userRequestedAt[strings.ToLower(event.GetReviewer().GetLogin())] = event.GetCreatedAt().Time
+				}
+				if event.RequestedTeam != nil {
+					teamRequestedAt[event.GetRequestedTeam().GetSlug()] = event.GetCreatedAt().Time
+				}
+			}
+			reviewedSince := func(login string, requestedAt time.Time) bool {
+				reviewedAt, ok := lastReviewedAt[strings.ToLower(login)]
+				return ok && !reviewedAt.Before(requestedAt)
+			}
+
+			pendingUsers := []string{}
+			for _, user := range requested.Users {
+				if !reviewedSince(user.GetLogin(), userRequestedAt[strings.ToLower(user.GetLogin())]) {
+					pendingUsers = append(pendingUsers, user.GetLogin())
+				}
+			}
+
+			// Team requests name no one in particular, so they are fulfilled by a review of any member
+			pendingTeams := []string{}
+			fulfilledTeams := []map[string]string{}
+			for _, team := range requested.Teams {
+				members, err := listTeamMemberLogins(ctx, client, owner, team.GetSlug())
+				if err != nil {
+					return nil, err
+				}
+				reviewer := ""
+				for _, member := range members {
+					if reviewedSince(member, teamRequestedAt[team.GetSlug()]) {
+						reviewer = member
+						break
+					}
+				}
+				if reviewer == "" {
+					pendingTeams = append(pendingTeams, team.GetSlug())
+					continue
+				}
+				fulfilledTeams = append(fulfilledTeams, map[string]string{
+					"team":        team.GetSlug(),
+					"reviewed_by": reviewer,
+				})
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"pending_users":   pendingUsers,
+				"pending_teams":   pendingTeams,
+				"fulfilled_teams": fulfilledTeams,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 // CreatePullRequestReview creates a tool to submit a review on a pull request.
 func CreatePullRequestReview(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("create_pull_request_review",
@@ -1183,3 +1572,3416 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// GetPullRequestStats creates a tool to get the diff stat summary of a pull request.
+func GetPullRequestStats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_stats",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_STATS_DESCRIPTION", "Get the diff stat summary (additions, deletions, changed files and commits) of a pull request without fetching file patches")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
+			}
+
+			stats := map[string]interface{}{
+				"additions":     pr.GetAdditions(),
+				"deletions":     pr.GetDeletions(),
+				"changed_files": pr.GetChangedFiles(),
+				"commits":       pr.GetCommits(),
+			}
+
+			r, err := json.Marshal(stats)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// Points of the components of the complexity score of a pull request. The score is the sum of:
+//   - one point for each changed file,
+//   - one point for each full 50 lines added or deleted,
+//   - three points for each distinct top-level directory touched, files at the root of the repository
+//     counting as one directory,
+//   - five points when files other than tests change but no test does.
+const (
+	complexityPointsPerFile      = 1
+	complexityLinesPerPoint      = 50
+	complexityPointsPerDirectory = 3
+	complexityPointsUntested     = 5
+)
+
+// testPathSegments are the directory names whose files are tests.
+var testPathSegments = map[string]bool{
+	"test":      true,
+	"tests":     true,
+	"__tests__": true,
+	"spec":      true,
+	"specs":     true,
+}
+
+// isTestPath reports whether a file is a test, either by the directory it lives in or by the naming
+// conventions of the common test frameworks, such as Go's _test.go, Jest's .test.js and pytest's test_*.py.
+func isTestPath(file string) bool {
+	segments := strings.Split(file, "/")
+	for _, s := range segments[:len(segments)-1] {
+		if testPathSegments[strings.ToLower(s)] {
+			return true
+		}
+	}
+	name := strings.ToLower(segments[len(segments)-1])
+	base := name
+	if i := strings.LastIndex(name, "."); i > 0 {
+		base = name[:i]
+	}
+	return strings.HasPrefix(name, "test_") ||
+		strings.HasSuffix(base, "_test") ||
+		strings.HasSuffix(base, ".test") ||
+		strings.HasSuffix(base, ".spec")
+}
+
+// pullRequestComplexity is the complexity score of a pull request and the components it is computed from.
+type pullRequestComplexity struct {
+	Score               int              `json:"score"`
+	FilesChanged        int              `json:"files_changed"`
+	LinesChanged        int              `json:"lines_changed"`
+	TopLevelDirectories int              `json:"top_level_directories"`
+	TouchesTests        bool             `json:"touches_tests"`
+	TouchesNonTests     bool             `json:"touches_non_tests"`
+	Points              complexityPoints `json:"points"`
+}
+
+// complexityPoints are the points each component adds to the complexity score of a pull request.
+type complexityPoints struct {
+	Files       int `json:"files"`
+	Lines       int `json:"lines"`
+	Directories int `json:"directories"`
+	Untested    int `json:"untested"`
+}
+
+// scorePullRequestComplexity computes the complexity score of the files a pull request changes.
+func scorePullRequestComplexity(files []*github.CommitFile) pullRequestComplexity {
+	var c pullRequestComplexity
+	directories := map[string]bool{}
+	for _, f := range files {
+		c.FilesChanged++
+		c.LinesChanged += f.GetAdditions() + f.GetDeletions()
+		dir, _, found := strings.Cut(f.GetFilename(), "/")
+		if !found {
+			dir = ""
+		}
+		directories[dir] = true
+		if isTestPath(f.GetFilename()) {
+			c.TouchesTests = true
+		} else {
+			c.TouchesNonTests = true
+		}
+	}
+	c.TopLevelDirectories = len(directories)
+
+	c.Points.Files = c.FilesChanged * complexityPointsPerFile
+	c.Points.Lines = c.LinesChanged / complexityLinesPerPoint
+	c.Points.Directories = c.TopLevelDirectories * complexityPointsPerDirectory
+	if c.TouchesNonTests && !c.TouchesTests {
+		c.Points.Untested = complexityPointsUntested
+	}
+	c.Score = c.Points.Files + c.Points.Lines + c.Points.Directories + c.Points.Untested
+	return c
+}
+
+// GetPullRequestComplexity creates a tool to get an approximate review complexity score of a pull request.
+func GetPullRequestComplexity(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_complexity",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_COMPLEXITY_DESCRIPTION", fmt.Sprintf("Get an approximate review complexity score of a pull request, to balance review load, and the components it is computed from. The score is %d point per changed file, 1 point per %d lines added or deleted, %d points per top-level directory touched, and %d points when no test changes along with other files", complexityPointsPerFile, complexityLinesPerPoint, complexityPointsPerDirectory, complexityPointsUntested))),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var files []*github.CommitFile
+			opts := &github.ListOptions{PerPage: 100}
+			for {
+				page, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("pull request #%d not found in %s/%s", pullNumber, owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to get pull request files: %w", err)
+				}
+				_ = resp.Body.Close()
+				files = append(files, page...)
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			r, err := json.Marshal(scorePullRequestComplexity(files))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// isCrossForkPullRequest reports whether the head of a pull request lives in another repository than its base.
+// A deleted head repository can only have been a fork, since the base repository still exists.
+func isCrossForkPullRequest(pr *github.PullRequest) bool {
+	head := pr.GetHead()
+	if head.Repo == nil {
+		return true
+	}
+	return !strings.EqualFold(head.Repo.GetFullName(), pr.GetBase().GetRepo().GetFullName())
+}
+
+// GetPullRequestRefs creates a tool to get where the head and base of a pull request live, to tell cross-fork pull requests apart.
+func GetPullRequestRefs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_refs",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_REFS_DESCRIPTION", "Get the repositories, branches and SHAs of the head and base of a pull request, and whether its head lives in a fork. head_repo is null when the fork was deleted")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("pull request #%d not found in %s/%s", pullNumber, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			head, base := pr.GetHead(), pr.GetBase()
+			baseRepo := base.GetRepo().GetFullName()
+			result := map[string]interface{}{
+				"head_repo":         nil,
+				"head_label":        head.GetLabel(),
+				"head_ref":          head.GetRef(),
+				"head_sha":          head.GetSHA(),
+				"base_repo":         baseRepo,
+				"base_ref":          base.GetRef(),
+				"base_sha":          base.GetSHA(),
+				"head_repo_deleted": head.Repo == nil,
+				"cross_fork":        isCrossForkPullRequest(pr),
+			}
+			if head.Repo != nil {
+				result["head_repo"] = head.Repo.GetFullName()
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// MapBranchesToPullRequests creates a tool to map the branches of a repository to their open pull requests.
+func MapBranchesToPullRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("map_branches_to_prs",
+			mcp.WithDescription(t("TOOL_MAP_BRANCHES_TO_PRS_DESCRIPTION", "Map each branch of a repository with an open pull request to the number of that pull request. Pull requests from forks are left out, as their branches aren't in the repository. A branch with several open pull requests, against different bases, maps to the most recent one and is listed in shared_branches with all of them")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Most recent first, so the first pull request of a branch is the one it maps to
+			branches := map[string]int{}
+			shared := map[string][]int{}
+			crossFork := 0
+			opts := &github.PullRequestListOptions{
+				State:       "open",
+				Sort:        "created",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			for {
+				prs, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to list pull requests: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, pr := range prs {
+					if isCrossForkPullRequest(pr) {
+						crossFork++
+						continue
+					}
+					branch := pr.GetHead().GetRef()
+					if first, ok := branches[branch]; ok {
+						if len(shared[branch]) == 0 {
+							shared[branch] = []int{first}
+						}
+						shared[branch] = append(shared[branch], pr.GetNumber())
+						continue
+					}
+					branches[branch] = pr.GetNumber()
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"branches":            branches,
+				"shared_branches":     shared,
+				"cross_fork_excluded": crossFork,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListTeamReviewRequests creates a tool to list the open pull requests whose review is requested from a team.
+func ListTeamReviewRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_team_review_requests",
+			mcp.WithDescription(t("TOOL_LIST_TEAM_REVIEW_REQUESTS_DESCRIPTION", "List open pull requests that are waiting on a review from a team")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Slug of the team"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := requiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The search silently returns nothing for an unknown team, so make sure it exists first
+			_, resp, err := client.Teams.GetTeamBySlug(ctx, org, teamSlug)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(fmt.Sprintf("team %s/%s not found", org, teamSlug)), nil
+				}
+				return nil, fmt.Errorf("failed to get team: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			query := fmt.Sprintf("is:pr is:open team-review-requested:%s/%s", org, teamSlug)
+			opts := &github.SearchOptions{
+				ListOptions: github.ListOptions{
+					PerPage: pagination.perPage,
+					Page:    pagination.page,
+				},
+			}
+			result, resp, err := client.Search.Issues(ctx, query, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to search pull requests: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to search pull requests: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// searchResultRepository returns the owner/name of the repository of an issue search result, which only names
+// it through its API URL.
+func searchResultRepository(issue *github.Issue) string {
+	_, fullName, _ := strings.Cut(issue.GetRepositoryURL(), "/repos/")
+	return fullName
+}
+
+// ListMyReviewLoad creates a tool to list the open pull requests across repositories waiting on a review from the authenticated user.
+func ListMyReviewLoad(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_my_review_load",
+			mcp.WithDescription(t("TOOL_LIST_MY_REVIEW_LOAD_DESCRIPTION", "List the open pull requests across all repositories waiting on a review from the authenticated user, oldest first, with the repository, title and age in days of each. Requests to the user's teams are included unless include_team_requests is false")),
+			mcp.WithBoolean("include_team_requests",
+				mcp.Description("Include pull requests whose review is requested from a team the user is in, rather than from the user directly. Defaults to true"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			includeTeams, ok, err := OptionalParamOK[bool](request, "include_team_requests")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				includeTeams = true
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// review-requested matches requests to the user and to their teams, user-review-requested only the former
+			query := "is:open is:pr review-requested:@me"
+			if !includeTeams {
+				query = "is:open is:pr user-review-requested:@me"
+			}
+			opts := &github.SearchOptions{
+				Sort:  "created",
+				Order: "asc",
+				ListOptions: github.ListOptions{
+					PerPage: pagination.perPage,
+					Page:    pagination.page,
+				},
+			}
+			result, resp, err := client.Search.Issues(ctx, query, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to search pull requests: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			now := time.Now()
+			pullRequests := make([]map[string]interface{}, 0, len(result.Issues))
+			for _, pr := range result.Issues {
+				pullRequests = append(pullRequests, map[string]interface{}{
+					"repository": searchResultRepository(pr),
+					"number":     pr.GetNumber(),
+					"title":      pr.GetTitle(),
+					"author":     pr.GetUser().GetLogin(),
+					"created_at": pr.CreatedAt,
+					"age_days":   int(now.Sub(pr.GetCreatedAt().Time).Hours() / 24),
+					"html_url":   pr.GetHTMLURL(),
+				})
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"query":         query,
+				"total_count":   result.GetTotal(),
+				"pull_requests": pullRequests,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// maxPullRequestsToScan bounds the number of pull requests list_prs_touching_path inspects,
+// since every pull request costs a separate files call.
+const maxPullRequestsToScan = 50
+
+// pathMatches checks if the changed file is the given path or lies below it when path is a directory.
+func pathMatches(file, path string) bool {
+	path = strings.TrimSuffix(path, "/")
+	return file == path || strings.HasPrefix(file, path+"/")
+}
+
+// ListPullRequestsTouchingPath creates a tool to find the pull requests that change a given file or directory.
+func ListPullRequestsTouchingPath(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_prs_touching_path",
+			mcp.WithDescription(t("TOOL_LIST_PRS_TOUCHING_PATH_DESCRIPTION", fmt.Sprintf("List pull requests whose changed files include a given file or directory. Only the %d most recently updated pull requests are scanned", maxPullRequestsToScan))),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("File or directory path, relative to the repository root"),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter by state, defaults to 'open'"),
+				mcp.Enum("open", "closed", "all"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := requiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if state == "" {
+				state = "open"
+			}
+			path = strings.TrimPrefix(path, "/")
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			opts := &github.PullRequestListOptions{
+				State:       state,
+				Sort:        "updated",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: maxPullRequestsToScan},
+			}
+			prs, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list pull requests: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list pull requests: %s", string(body))), nil
+			}
+
+			matches := []map[string]interface{}{}
+			for _, pr := range prs {
+				var matchingFiles []string
+				filesOpts := &github.ListOptions{PerPage: 100}
+				for {
+					files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pr.GetNumber(), filesOpts)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get pull request files: %w", err)
+					}
+					_ = resp.Body.Close()
+					for _, file := range files {
+						// Renames count for both the old and the new location
+						if pathMatches(file.GetFilename(), path) || pathMatches(file.GetPreviousFilename(), path) {
+							matchingFiles = append(matchingFiles, file.GetFilename())
+						}
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					filesOpts.Page = resp.NextPage
+				}
+
+				if len(matchingFiles) > 0 {
+					matches = append(matches, map[string]interface{}{
+						"number":         pr.GetNumber(),
+						"title":          pr.GetTitle(),
+						"state":          pr.GetState(),
+						"user":           pr.GetUser().GetLogin(),
+						"html_url":       pr.GetHTMLURL(),
+						"matching_files": matchingFiles,
+					})
+				}
+			}
+
+			result := map[string]interface{}{
+				"pull_requests": matches,
+				"scanned":       len(prs),
+				// More pull requests exist than were scanned, so the result may be incomplete
+				"capped": resp.NextPage != 0,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// getCodeowners fetches and parses the CODEOWNERS file of a repository at the given ref,
+// looking in the same locations as GitHub. It returns an empty path if the repository has none.
+func getCodeowners(ctx context.Context, client *github.Client, owner, repo, ref string) (string, *codeowners.File, error) {
+	for _, path := range codeowners.Locations {
+		fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+		if err != nil {
+			if isGitHubErrorStatus(err, http.StatusNotFound) {
+				continue
+			}
+			return "", nil, fmt.Errorf("failed to get %s: %w", path, err)
+		}
+		_ = resp.Body.Close()
+		if fileContent == nil {
+			continue
+		}
+
+		content, err := fileContent.GetContent()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		return path, codeowners.Parse(content), nil
+	}
+	return "", nil, nil
+}
+
+// GetPullRequestRequiredOwners creates a tool to find the code owners whose review a pull request requires.
+func GetPullRequestRequiredOwners(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pr_required_owners",
+			mcp.WithDescription(t("TOOL_GET_PR_REQUIRED_OWNERS_DESCRIPTION", "Get the code owners that must review a pull request according to the CODEOWNERS file of its base branch, and the files that make each of them required")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
+			}
+
+			// GitHub applies the CODEOWNERS file of the branch the pull request targets
+			baseRef := pr.GetBase().GetRef()
+			path, file, err := getCodeowners(ctx, client, owner, repo, baseRef)
+			if err != nil {
+				return nil, err
+			}
+			if file == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("no CODEOWNERS file found in %s/%s on branch %s", owner, repo, baseRef)), nil
+			}
+
+			ownerFiles := map[string][]string{}
+			unownedFiles := []string{}
+			opts := &github.ListOptions{PerPage: 100}
+			for {
+				files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get pull request files: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, f := range files {
+					owners := file.Owners(f.GetFilename())
+					if len(owners) == 0 {
+						unownedFiles = append(unownedFiles, f.GetFilename())
+						continue
+					}
+					for _, o := range owners {
+						ownerFiles[o] = append(ownerFiles[o], f.GetFilename())
+					}
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			requiredOwners := make([]map[string]interface{}, 0, len(ownerFiles))
+			for _, o := range slices.Sorted(maps.Keys(ownerFiles)) {
+				requiredOwners = append(requiredOwners, map[string]interface{}{
+					"owner": o,
+					"files": ownerFiles[o],
+				})
+			}
+
+			result := map[string]interface{}{
+				"codeowners_path": path,
+				"required_owners": requiredOwners,
+				"unowned_files":   unownedFiles,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// listTeamMemberLogins fetches the logins of every member of a team. It returns nil if the team
+// doesn't exist or isn't visible to the caller, which for secret teams can also be a 403.
+func listTeamMemberLogins(ctx context.Context, client *github.Client, org, teamSlug string) ([]string, error) {
+	var logins []string
+	opts := &github.TeamListTeamMembersOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		members, resp, err := client.Teams.ListTeamMembersBySlug(ctx, org, teamSlug, opts)
+		if err != nil {
+			if isGitHubErrorStatus(err, http.StatusNotFound) || isGitHubErrorStatus(err, http.StatusForbidden) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to list members of team %s/%s: %w", org, teamSlug, err)
+		}
+		_ = resp.Body.Close()
+		for _, member := range members {
+			logins = append(logins, member.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			return logins, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// SuggestReviewersFromCodeowners creates a tool to suggest reviewers for a pull request from the CODEOWNERS file of its base branch.
+func SuggestReviewersFromCodeowners(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("suggest_reviewers_from_codeowners",
+			mcp.WithDescription(t("TOOL_SUGGEST_REVIEWERS_FROM_CODEOWNERS_DESCRIPTION", "Suggest reviewers for a pull request from the code owners of its changed files, according to the CODEOWNERS file of its base branch. Team owners are expanded into their members and the pull request author is left out")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
+			}
+
+			baseRef := pr.GetBase().GetRef()
+			_, file, err := getCodeowners(ctx, client, owner, repo, baseRef)
+			if err != nil {
+				return nil, err
+			}
+			if file == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("no CODEOWNERS file found in %s/%s on branch %s", owner, repo, baseRef)), nil
+			}
+
+			// Group the changed files by the rule deciding their owners, which is the last matching one
+			ruleFiles := map[int][]string{}
+			rules := map[int]*codeowners.Rule{}
+			opts := &github.ListOptions{PerPage: 100}
+			for {
+				files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get pull request files: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, f := range files {
+					rule := file.Match(f.GetFilename())
+					if rule == nil || len(rule.Owners) == 0 {
+						continue
+					}
+					rules[rule.Line] = rule
+					ruleFiles[rule.Line] = append(ruleFiles[rule.Line], f.GetFilename())
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			author := pr.GetUser().GetLogin()
+			users := map[string]bool{}
+			teams := map[string]bool{}
+			addUser := func(login string) {
+				if !strings.EqualFold(login, author) {
+					users[login] = true
+				}
+			}
+			matchedRules := make([]map[string]interface{}, 0, len(rules))
+			for _, line := range slices.Sorted(maps.Keys(rules)) {
+				rule := rules[line]
+				matchedRules = append(matchedRules, map[string]interface{}{
+					"pattern":        rule.Pattern,
+					"owners":         rule.Owners,
+					"matching_files": ruleFiles[line],
+				})
+				for _, o := range rule.Owners {
+					if !strings.HasPrefix(o, "@") {
+						// Email owners can't be requested as reviewers
+						continue
+					}
+					org, teamSlug, isTeam := strings.Cut(strings.TrimPrefix(o, "@"), "/")
+					if !isTeam {
+						addUser(org)
+						continue
+					}
+					if teams[o] {
+						continue
+					}
+					teams[o] = true
+					members, err := listTeamMemberLogins(ctx, client, org, teamSlug)
+					if err != nil {
+						return nil, err
+					}
+					for _, login := range members {
+						addUser(login)
+					}
+				}
+			}
+
+			result := map[string]interface{}{
+				"suggested_users": append([]string{}, slices.Sorted(maps.Keys(users))...),
+				"suggested_teams": append([]string{}, slices.Sorted(maps.Keys(teams))...),
+				"matched_rules":   matchedRules,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// pullRequestTemplateLocations are the paths GitHub looks for a pull request template at.
+var pullRequestTemplateLocations = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"pull_request_template.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+}
+
+// htmlComment matches the HTML comments templates use to explain what goes in a section.
+var htmlComment = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+// markdownSection is a "## " section of a markdown document.
+type markdownSection struct {
+	Name    string
+	Content string
+}
+
+// markdownSections splits a markdown document into its "## " sections, in order. Text before the first
+// section is dropped.
+func markdownSections(content string) []markdownSection {
+	var sections []markdownSection
+	var lines []string
+	flush := func() {
+		if len(sections) > 0 {
+			sections[len(sections)-1].Content = strings.Join(lines, "\n")
+		}
+		lines = nil
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if !strings.HasPrefix(line, "## ") {
+			lines = append(lines, line)
+			continue
+		}
+		flush()
+		sections = append(sections, markdownSection{Name: strings.TrimSpace(strings.TrimRight(line[3:], "# "))})
+	}
+	flush()
+	return sections
+}
+
+// normalizeSectionContent drops HTML comments and collapses whitespace, so a section can be compared
+// with the placeholder text of the template.
+func normalizeSectionContent(content string) string {
+	return strings.Join(strings.Fields(htmlComment.ReplaceAllString(content, "")), " ")
+}
+
+// getPullRequestTemplate fetches the pull request template of a repository from its default branch,
+// looking in the same locations as GitHub. It returns an empty path if the repository has none.
+func getPullRequestTemplate(ctx context.Context, client *github.Client, owner, repo string) (string, string, error) {
+	return getFirstFile(ctx, client, owner, repo, "", pullRequestTemplateLocations)
+}
+
+// ValidatePullRequestTemplate creates a tool to check that the body of a pull request fills in every
+// section of the repository's pull request template.
+func ValidatePullRequestTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("validate_pr_template",
+			mcp.WithDescription(t("TOOL_VALIDATE_PR_TEMPLATE_DESCRIPTION", "Check that the body of a pull request fills in every '## ' section of the repository's pull request template. Sections left out of the body are missing, and sections left blank or with the template's placeholder text are empty")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
+			}
+
+			path, template, err := getPullRequestTemplate(ctx, client, owner, repo)
+			if err != nil {
+				return nil, err
+			}
+
+			missing := []string{}
+			empty := []string{}
+			if path != "" {
+				filled := map[string]string{}
+				for _, section := range markdownSections(pr.GetBody()) {
+					filled[strings.ToLower(section.Name)] = normalizeSectionContent(section.Content)
+				}
+				for _, section := range markdownSections(template) {
+					content, ok := filled[strings.ToLower(section.Name)]
+					switch {
+					case !ok:
+						missing = append(missing, section.Name)
+					case content == "" || content == normalizeSectionContent(section.Content):
+						empty = append(empty, section.Name)
+					}
+				}
+			}
+
+			result := map[string]interface{}{
+				"valid":            len(missing) == 0 && len(empty) == 0,
+				"template_found":   path != "",
+				"no_template":      path == "",
+				"template_path":    path,
+				"missing_sections": missing,
+				"empty_sections":   empty,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetPullRequestTiming creates a tool to get the cycle times of a pull request.
+func GetPullRequestTiming(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_timing",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_TIMING_DESCRIPTION", "Get when a pull request was created, first reviewed and merged, along with its time to first review and time to merge")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
+			}
+
+			reviews, err := listAllPullRequestReviews(ctx, client, owner, repo, pullNumber)
+			if err != nil {
+				return nil, err
+			}
+			var firstReviewAt *github.Timestamp
+			for _, review := range reviews {
+				// Pending reviews have not been submitted yet
+				if review.SubmittedAt == nil {
+					continue
+				}
+				if firstReviewAt == nil || review.SubmittedAt.Before(firstReviewAt.Time) {
+					firstReviewAt = review.SubmittedAt
+				}
+			}
+
+			createdAt := pr.GetCreatedAt()
+			result := map[string]interface{}{
+				"created_at":                   createdAt,
+				"first_review_at":              firstReviewAt,
+				"merged_at":                    pr.MergedAt,
+				"time_to_first_review_seconds": nil,
+				"time_to_merge_seconds":        nil,
+			}
+			if firstReviewAt != nil {
+				result["time_to_first_review_seconds"] = int64(firstReviewAt.Sub(createdAt.Time).Seconds())
+			}
+			if pr.MergedAt != nil {
+				result["time_to_merge_seconds"] = int64(pr.MergedAt.Sub(createdAt.Time).Seconds())
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// listAllPullRequestReviews fetches every review of a pull request, in the order they were created.
+func listAllPullRequestReviews(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) ([]*github.PullRequestReview, error) {
+	var all []*github.PullRequestReview
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request reviews: %w", err)
+		}
+		_ = resp.Body.Close()
+		all = append(all, reviews...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// listAllCheckRuns fetches the latest check runs of every check on a ref.
+func listAllCheckRuns(ctx context.Context, client *github.Client, owner, repo, ref string) ([]*github.CheckRun, error) {
+	var all []*github.CheckRun
+	opts := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		result, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list check runs: %w", err)
+		}
+		_ = resp.Body.Close()
+		all = append(all, result.CheckRuns...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// getFullCombinedStatus fetches the combined status of a ref with the statuses of every page, since a ref can
+// have more statuses than fit on one.
+func getFullCombinedStatus(ctx context.Context, client *github.Client, owner, repo, ref string) (*github.CombinedStatus, error) {
+	var combined *github.CombinedStatus
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, ref, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get combined status: %w", err)
+		}
+		_ = resp.Body.Close()
+		if combined == nil {
+			combined = page
+		} else {
+			combined.Statuses = append(combined.Statuses, page.Statuses...)
+		}
+		if resp.NextPage == 0 {
+			return combined, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// statusCheckOutcomes maps the name of every status and check run reported on a commit to
+// "passing", "failing" or "pending". A failing outcome takes precedence over the others.
+func statusCheckOutcomes(combined *github.CombinedStatus, checkRuns []*github.CheckRun) map[string]string {
+	outcomes := map[string]string{}
+	record := func(name, outcome string) {
+		if outcomes[name] != "failing" {
+			outcomes[name] = outcome
+		}
+	}
+	for _, status := range combined.Statuses {
+		switch status.GetState() {
+		case "success":
+			record(status.GetContext(), "passing")
+		case "failure", "error":
+			record(status.GetContext(), "failing")
+		default:
+			record(status.GetContext(), "pending")
+		}
+	}
+	for _, run := range checkRuns {
+		if run.GetStatus() != "completed" {
+			record(run.GetName(), "pending")
+			continue
+		}
+		switch run.GetConclusion() {
+		case "success", "neutral", "skipped":
+			record(run.GetName(), "passing")
+		default:
+			record(run.GetName(), "failing")
+		}
+	}
+	return outcomes
+}
+
+// GetPullRequestMergeRequirements creates a tool to check a pull request against the merge requirements of its base branch.
+func GetPullRequestMergeRequirements(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pr_merge_requirements",
+			mcp.WithDescription(t("TOOL_GET_PR_MERGE_REQUIREMENTS_DESCRIPTION", "Get the approvals, code owner reviews and status checks the protection of a pull request's base branch requires, and whether the pull request currently meets them. A reviewer whose latest review requests changes keeps the requirements from being met. The time and author of the last push are approximate unless it was a force push, as last_push_approximate reports")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
+			}
+			baseRef := pr.GetBase().GetRef()
+			headSHA := pr.GetHead().GetSHA()
+
+			// The requirements and the state of the pull request are independent of each other
+			var (
+				protection *github.Protection
+				reviews    []*github.PullRequestReview
+				combined   *github.CombinedStatus
+				checkRuns  []*github.CheckRun
+				headCommit *github.RepositoryCommit
+				timeline   []*github.Timeline
+			)
+			errs := runConcurrently(ctx,
+				func(ctx context.Context) error {
+					var resp *github.Response
+					var err error
+					protection, resp, err = client.Repositories.GetBranchProtection(ctx, owner, repo, baseRef)
+					if resp != nil {
+						_ = resp.Body.Close()
+					}
+					if errors.Is(err, github.ErrBranchNotProtected) {
+						protection = nil
+						return nil
+					}
+					if err != nil {
+						return fmt.Errorf("failed to get branch protection: %w", err)
+					}
+					return nil
+				},
+				func(ctx context.Context) (err error) {
+					reviews, err = listAllPullRequestReviews(ctx, client, owner, repo, pullNumber)
+					return err
+				},
+				func(ctx context.Context) (err error) {
+					combined, err = getFullCombinedStatus(ctx, client, owner, repo, headSHA)
+					return err
+				},
+				func(ctx context.Context) (err error) {
+					checkRuns, err = listAllCheckRuns(ctx, client, owner, repo, headSHA)
+					return err
+				},
+				func(ctx context.Context) error {
+					var resp *github.Response
+					var err error
+					headCommit, resp, err = client.Repositories.GetCommit(ctx, owner, repo, headSHA, nil)
+					if err != nil {
+						return fmt.Errorf("failed to get head commit: %w", err)
+					}
+					_ = resp.Body.Close()
+					return nil
+				},
+				func(ctx context.Context) (err error) {
+					timeline, err = listAllTimelineEvents(ctx, client, owner, repo, pullNumber)
+					return err
+				},
+			)
+			if err := errors.Join(errs...); err != nil {
+				return nil, err
+			}
+
+			var reviewRules github.PullRequestReviewsEnforcement
+			if protection != nil && protection.RequiredPullRequestReviews != nil {
+				reviewRules = *protection.RequiredPullRequestReviews
+			}
+
+			lastPusher, lastPushAt, lastPushApproximate := lastPush(timeline, headCommit)
+
+			approvers, changesRequestedBy, dismissedReviews := tallyReviews(reviews, reviewRules, lastPusher, lastPushAt)
+			// A change request blocks merging where reviews are required
+			reviewsRequired := protection != nil && protection.RequiredPullRequestReviews != nil
+			reviewsMet := len(approvers) >= reviewRules.RequiredApprovingReviewCount && (!reviewsRequired || len(changesRequestedBy) == 0)
+
+			codeownerReviewSatisfied := true
+			if reviewRules.RequireCodeOwnerReviews {
+				codeownerReviewSatisfied, err = codeownersApproved(ctx, client, owner, repo, pullNumber, baseRef, approvers)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			required := []string{}
+			if protection != nil && protection.RequiredStatusChecks != nil {
+				for _, check := range currentStatusChecks(protection.RequiredStatusChecks) {
+					required = append(required, check.Context)
+				}
+			}
+			outcomes := statusCheckOutcomes(combined, checkRuns)
+			passing, failing, pending := []string{}, []string{}, []string{}
+			for _, name := range required {
+				switch outcomes[name] {
+				case "passing":
+					passing = append(passing, name)
+				case "failing":
+					failing = append(failing, name)
+				default:
+					pending = append(pending, name)
+				}
+			}
+
+			result := map[string]interface{}{
+				"base_branch":                baseRef,
+				"branch_protected":           protection != nil,
+				"requirements_met":           reviewsMet && codeownerReviewSatisfied && len(passing) == len(required),
+				"approvals_required":         reviewRules.RequiredApprovingReviewCount,
+				"approvals_current":          len(approvers),
+				"approved_by":                approvers,
+				"changes_requested_by":       changesRequestedBy,
+				"dismissed_reviews":          dismissedReviews,
+				"dismiss_stale_reviews":      reviewRules.DismissStaleReviews,
+				"require_last_push_approval": reviewRules.RequireLastPushApproval,
+				"last_push_at":               lastPushAt,
+				"last_pushed_by":             lastPusher,
+				"last_push_approximate":      lastPushApproximate,
+				"codeowner_review_required":  reviewRules.RequireCodeOwnerReviews,
+				"codeowner_review_satisfied": codeownerReviewSatisfied,
+				"status_checks_required":     required,
+				"status_checks_passing":      passing,
+				"status_checks_failing":      failing,
+				"status_checks_pending":      pending,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// tallyReviews returns the reviewers whose approval counts towards the review rules of a pull request's
+// base branch and those requesting changes, both sorted, and how many reviews were dismissed. Only the
+// latest approval or change request of each reviewer counts, and where the rules require approval of
+// the last push, only approvals submitted after it by someone other than the pusher.
+func tallyReviews(reviews []*github.PullRequestReview, reviewRules github.PullRequestReviewsEnforcement, lastPusher string, lastPushAt github.Timestamp) (approvers, changesRequestedBy []string, dismissed int) {
+	latestReviews := map[string]*github.PullRequestReview{}
+	for _, review := range reviews {
+		switch review.GetState() {
+		case "APPROVED", "CHANGES_REQUESTED":
+			latestReviews[review.GetUser().GetLogin()] = review
+		case "DISMISSED":
+			dismissed++
+			delete(latestReviews, review.GetUser().GetLogin())
+		}
+	}
+	approvers, changesRequestedBy = []string{}, []string{}
+	for login, review := range latestReviews {
+		if review.GetState() != "APPROVED" {
+			changesRequestedBy = append(changesRequestedBy, login)
+			continue
+		}
+		if reviewRules.RequireLastPushApproval && (login == lastPusher || review.GetSubmittedAt().Before(lastPushAt.Time)) {
+			continue
+		}
+		approvers = append(approvers, login)
+	}
+	slices.Sort(approvers)
+	slices.Sort(changesRequestedBy)
+	return approvers, changesRequestedBy, dismissed
+}
+
+// lastPush finds who last pushed to the head branch of a pull request and when. A force push shows in its
+// timeline with both, but a pushed commit only with its git dates, so after one the GitHub committer of the
+// head commit stands in for the pusher, its author for commits made on github.com, and the latest date seen
+// for the push, which makes both approximate.
+func lastPush(timeline []*github.Timeline, headCommit *github.RepositoryCommit) (pusher string, at github.Timestamp, approximate bool) {
+	var forcePush, commit *github.Timeline
+	for _, event := range timeline {
+		switch event.GetEvent() {
+		case "head_ref_force_pushed":
+			forcePush, commit = event, nil
+		case "committed":
+			commit = event
+		}
+	}
+	if forcePush != nil && commit == nil {
+		return forcePush.GetActor().GetLogin(), forcePush.GetCreatedAt(), false
+	}
+
+	at = headCommit.GetCommit().GetCommitter().GetDate()
+	if date := commit.GetCommitter().GetDate(); date.After(at.Time) {
+		at = date
+	}
+	if date := forcePush.GetCreatedAt(); date.After(at.Time) {
+		at = date
+	}
+	pusher = headCommit.GetCommitter().GetLogin()
+	if pusher == "web-flow" {
+		pusher = headCommit.GetAuthor().GetLogin()
+	}
+	return pusher, at, true
+}
+
+// codeownersApproved checks if every file of a pull request that has code owners is approved by one
+// of them. Team owners are matched through the approvers' team memberships, email owners can't be.
+func codeownersApproved(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, baseRef string, approvers []string) (bool, error) {
+	_, file, err := getCodeowners(ctx, client, owner, repo, baseRef)
+	if err != nil || file == nil {
+		return true, err
+	}
+
+	approvedByOwner := map[string]bool{}
+	isApprovedBy := func(codeowner string) (bool, error) {
+		if approved, ok := approvedByOwner[codeowner]; ok {
+			return approved, nil
+		}
+		approved := false
+		org, teamSlug, isTeam := strings.Cut(strings.TrimPrefix(codeowner, "@"), "/")
+		for _, login := range approvers {
+			if !isTeam {
+				if strings.EqualFold(org, login) {
+					approved = true
+					break
+				}
+				continue
+			}
+			membership, resp, err := client.Teams.GetTeamMembershipBySlug(ctx, org, teamSlug, login)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					continue
+				}
+				return false, fmt.Errorf("failed to get team membership: %w", err)
+			}
+			_ = resp.Body.Close()
+			if membership.GetState() == "active" {
+				approved = true
+				break
+			}
+		}
+		approvedByOwner[codeowner] = approved
+		return approved, nil
+	}
+
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+		if err != nil {
+			return false, fmt.Errorf("failed to get pull request files: %w", err)
+		}
+		_ = resp.Body.Close()
+		for _, f := range files {
+			owners := file.Owners(f.GetFilename())
+			if len(owners) == 0 {
+				continue
+			}
+			fileApproved := false
+			for _, o := range owners {
+				if strings.Contains(o, "@") && !strings.HasPrefix(o, "@") {
+					// Email owners can't be matched to reviewers
+					continue
+				}
+				approved, err := isApprovedBy(o)
+				if err != nil {
+					return false, err
+				}
+				if approved {
+					fileApproved = true
+					break
+				}
+			}
+			if !fileApproved {
+				return false, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return true, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// blockingCheck is a required status check keeping a pull request from being merged.
+type blockingCheck struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// requiredCheckBlockers returns the required status checks of protection that a pull request is failing or
+// waiting on. It returns none if nothing but passing checks is needed, and also if the pull request is
+// blocked by something checks can't change: being a draft, merge conflicts, or missing approvals.
+func requiredCheckBlockers(ctx context.Context, client *github.Client, owner, repo string, number int, protection *github.Protection) ([]blockingCheck, error) {
+	pr, resp, err := client.PullRequests.Get(ctx, owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request %d: %w", number, err)
+	}
+	_ = resp.Body.Close()
+	// Mergeability is computed in the background and unknown until then, which isn't a conflict
+	if pr.GetDraft() || (pr.Mergeable != nil && !pr.GetMergeable()) {
+		return nil, nil
+	}
+
+	combined, err := getFullCombinedStatus(ctx, client, owner, repo, pr.GetHead().GetSHA())
+	if err != nil {
+		return nil, err
+	}
+	checkRuns, err := listAllCheckRuns(ctx, client, owner, repo, pr.GetHead().GetSHA())
+	if err != nil {
+		return nil, err
+	}
+	outcomes := statusCheckOutcomes(combined, checkRuns)
+	var blockers []blockingCheck
+	for _, check := range currentStatusChecks(protection.RequiredStatusChecks) {
+		switch outcome := outcomes[check.Context]; outcome {
+		case "passing":
+			continue
+		case "failing":
+			blockers = append(blockers, blockingCheck{Name: check.Context, State: outcome})
+		default:
+			blockers = append(blockers, blockingCheck{Name: check.Context, State: "pending"})
+		}
+	}
+	if len(blockers) == 0 || protection.RequiredPullRequestReviews == nil {
+		return blockers, nil
+	}
+
+	reviews, err := listAllPullRequestReviews(ctx, client, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	reviewRules := protection.RequiredPullRequestReviews
+	var lastPusher string
+	var lastPushAt github.Timestamp
+	if reviewRules.RequireLastPushApproval {
+		headCommit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, pr.GetHead().GetSHA(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get head commit of pull request %d: %w", number, err)
+		}
+		_ = resp.Body.Close()
+		timeline, err := listAllTimelineEvents(ctx, client, owner, repo, number)
+		if err != nil {
+			return nil, err
+		}
+		lastPusher, lastPushAt, _ = lastPush(timeline, headCommit)
+	}
+	approvers, changesRequestedBy, _ := tallyReviews(reviews, *reviewRules, lastPusher, lastPushAt)
+	if len(changesRequestedBy) > 0 || len(approvers) < reviewRules.RequiredApprovingReviewCount {
+		return nil, nil
+	}
+	if reviewRules.RequireCodeOwnerReviews {
+		approved, err := codeownersApproved(ctx, client, owner, repo, number, pr.GetBase().GetRef(), approvers)
+		if err != nil || !approved {
+			return nil, err
+		}
+	}
+	return blockers, nil
+}
+
+// ListPullRequestsBlockedByChecks creates a tool to list the open pull requests of a repository that only
+// wait on the required status checks of the default branch.
+func ListPullRequestsBlockedByChecks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_prs_blocked_by_checks",
+			mcp.WithDescription(t("TOOL_LIST_PRS_BLOCKED_BY_CHECKS_DESCRIPTION", fmt.Sprintf("List the open pull requests into the default branch of a repository that only the branch's required status checks keep from being merged, with the checks failing or pending. Drafts and pull requests with merge conflicts or missing approvals are left out. Only the %d most recently updated pull requests are scanned", maxPullRequestsToScan))),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			_ = resp.Body.Close()
+			baseBranch := repository.GetDefaultBranch()
+
+			protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, baseBranch)
+			if err != nil && !errors.Is(err, github.ErrBranchNotProtected) {
+				if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden) {
+					return mcp.NewToolResultError(fmt.Sprintf("the protection of %s in %s/%s can't be read, which needs admin access to the repository", baseBranch, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get branch protection: %w", err)
+			}
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			requiredChecks := []string{}
+			if protection != nil && protection.RequiredStatusChecks != nil {
+				for _, check := range currentStatusChecks(protection.RequiredStatusChecks) {
+					requiredChecks = append(requiredChecks, check.Context)
+				}
+			}
+			result := map[string]interface{}{
+				"base_branch":     baseBranch,
+				"required_checks": requiredChecks,
+				"pull_requests":   []map[string]interface{}{},
+				"scanned":         0,
+				"capped":          false,
+			}
+			// No pull request can wait on checks the branch doesn't require
+			if len(requiredChecks) == 0 {
+				r, err := json.Marshal(result)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				return mcp.NewToolResultText(string(r)), nil
+			}
+
+			opts := &github.PullRequestListOptions{
+				State:       "open",
+				Base:        baseBranch,
+				Sort:        "updated",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: maxPullRequestsToScan},
+			}
+			prs, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list pull requests: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			// Every pull request costs a few calls, so they are inspected concurrently, within bounds
+			blockers := make([][]blockingCheck, len(prs))
+			errs := forEachConcurrently(ctx, prs, func(ctx context.Context, i int, pr *github.PullRequest) error {
+				var err error
+				blockers[i], err = requiredCheckBlockers(ctx, client, owner, repo, pr.GetNumber(), protection)
+				return err
+			})
+			if err := errors.Join(errs...); err != nil {
+				return nil, err
+			}
+
+			blocked := []map[string]interface{}{}
+			for i, pr := range prs {
+				if len(blockers[i]) == 0 {
+					continue
+				}
+				blocked = append(blocked, map[string]interface{}{
+					"number":          pr.GetNumber(),
+					"title":           pr.GetTitle(),
+					"user":            pr.GetUser().GetLogin(),
+					"html_url":        pr.GetHTMLURL(),
+					"blocking_checks": blockers[i],
+				})
+			}
+			result["pull_requests"] = blocked
+			result["scanned"] = len(prs)
+			// More open pull requests exist than were scanned, so the result may be incomplete
+			result["capped"] = resp.NextPage != 0
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// mergeabilityRetryDelays are the waits between the fetches of a pull request whose mergeability GitHub is still
+// computing. Fetching a pull request is what starts the computation, so the first fetch often comes too early.
+var mergeabilityRetryDelays = []time.Duration{time.Second, 2 * time.Second}
+
+// getPullRequestMergeability fetches a pull request until GitHub has computed whether it can be merged, retrying
+// after each of mergeabilityRetryDelays. It reports false if mergeability is still unknown after the last fetch.
+func getPullRequestMergeability(ctx context.Context, client *github.Client, owner, repo string, number int) (*github.PullRequest, bool, error) {
+	for attempt := 0; ; attempt++ {
+		pr, resp, err := client.PullRequests.Get(ctx, owner, repo, number)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get pull request %d: %w", number, err)
+		}
+		_ = resp.Body.Close()
+		if pr.Mergeable != nil && pr.GetMergeableState() != "unknown" {
+			return pr, true, nil
+		}
+		if attempt == len(mergeabilityRetryDelays) {
+			return pr, false, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-time.After(mergeabilityRetryDelays[attempt]):
+		}
+	}
+}
+
+// ListConflictedPullRequests creates a tool to list the open pull requests of a repository with merge conflicts.
+func ListConflictedPullRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_conflicted_prs",
+			mcp.WithDescription(t("TOOL_LIST_CONFLICTED_PRS_DESCRIPTION", fmt.Sprintf("List the open pull requests of a repository with merge conflicts, which need a rebase or merge of their base branch. GitHub computes mergeability in the background, so pull requests it hasn't computed it for after a few seconds are listed as undetermined. Only the %d most recently updated pull requests are scanned", maxPullRequestsToScan))),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			opts := &github.PullRequestListOptions{
+				State:       "open",
+				Sort:        "updated",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: maxPullRequestsToScan},
+			}
+			prs, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to list pull requests: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			// The list leaves mergeability out, so every pull request is fetched on its own, concurrently within bounds
+			fetched := make([]*github.PullRequest, len(prs))
+			determined := make([]bool, len(prs))
+			errs := forEachConcurrently(ctx, prs, func(ctx context.Context, i int, pr *github.PullRequest) error {
+				var err error
+				fetched[i], determined[i], err = getPullRequestMergeability(ctx, client, owner, repo, pr.GetNumber())
+				return err
+			})
+			if err := errors.Join(errs...); err != nil {
+				return nil, err
+			}
+
+			conflicted := []map[string]interface{}{}
+			undetermined := []map[string]interface{}{}
+			for i, pr := range fetched {
+				entry := map[string]interface{}{
+					"number":   pr.GetNumber(),
+					"title":    pr.GetTitle(),
+					"user":     pr.GetUser().GetLogin(),
+					"head_ref": pr.GetHead().GetRef(),
+					"base_ref": pr.GetBase().GetRef(),
+					"html_url": pr.GetHTMLURL(),
+				}
+				switch {
+				case !determined[i]:
+					undetermined = append(undetermined, entry)
+				case pr.GetMergeableState() == "dirty":
+					conflicted = append(conflicted, entry)
+				}
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"conflicted":   conflicted,
+				"undetermined": undetermined,
+				"scanned":      len(prs),
+				// More open pull requests exist than were scanned, so the result may be incomplete
+				"capped": resp.NextPage != 0,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// pullRequestActivity is the most recent activity on a pull request and the kind of activity it was.
+type pullRequestActivity struct {
+	At   *github.Timestamp
+	Type string
+}
+
+// latestPullRequestActivity returns the most recent of the activities on a pull request, skipping those that
+// never happened. The result has no time when none of them did.
+func latestPullRequestActivity(activities ...pullRequestActivity) pullRequestActivity {
+	var latest pullRequestActivity
+	for _, activity := range activities {
+		if activity.At == nil || activity.At.IsZero() {
+			continue
+		}
+		if latest.At == nil || activity.At.After(latest.At.Time) {
+			latest = activity
+		}
+	}
+	return latest
+}
+
+// getPullRequestActivity fetches the time of the head commit, the latest conversation comment and the latest
+// submitted review of a pull request, and returns the most recent of them.
+func getPullRequestActivity(ctx context.Context, client *github.Client, owner, repo string, pr *github.PullRequest) (pullRequestActivity, error) {
+	commit, resp, err := client.Git.GetCommit(ctx, owner, repo, pr.GetHead().GetSHA())
+	if err != nil {
+		return pullRequestActivity{}, fmt.Errorf("failed to get head commit of pull request %d: %w", pr.GetNumber(), err)
+	}
+	_ = resp.Body.Close()
+	committedAt := commit.GetCommitter().Date
+
+	// Comments are listed oldest first, so the latest one is at the end of the last page
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	comments, resp, err := client.Issues.ListComments(ctx, owner, repo, pr.GetNumber(), opts)
+	if err != nil {
+		return pullRequestActivity{}, fmt.Errorf("failed to list comments of pull request %d: %w", pr.GetNumber(), err)
+	}
+	_ = resp.Body.Close()
+	if resp.LastPage != 0 {
+		opts.Page = resp.LastPage
+		comments, resp, err = client.Issues.ListComments(ctx, owner, repo, pr.GetNumber(), opts)
+		if err != nil {
+			return pullRequestActivity{}, fmt.Errorf("failed to list comments of pull request %d: %w", pr.GetNumber(), err)
+		}
+		_ = resp.Body.Close()
+	}
+	var commentedAt *github.Timestamp
+	if len(comments) > 0 {
+		commentedAt = comments[len(comments)-1].CreatedAt
+	}
+
+	reviews, err := listAllPullRequestReviews(ctx, client, owner, repo, pr.GetNumber())
+	if err != nil {
+		return pullRequestActivity{}, err
+	}
+	reviewActivities := make([]pullRequestActivity, 0, len(reviews))
+	for _, review := range reviews {
+		// Pending reviews have no submission time and aren't visible to anyone but their author
+		reviewActivities = append(reviewActivities, pullRequestActivity{At: review.SubmittedAt, Type: "review"})
+	}
+
+	return latestPullRequestActivity(
+		pullRequestActivity{At: committedAt, Type: "commit"},
+		pullRequestActivity{At: commentedAt, Type: "comment"},
+		latestPullRequestActivity(reviewActivities...),
+	), nil
+}
+
+// GetPullRequestsLastActivity creates a tool to find when each open pull request of a repository last moved.
+func GetPullRequestsLastActivity(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_prs_last_activity",
+			mcp.WithDescription(t("TOOL_GET_PRS_LAST_ACTIVITY_DESCRIPTION", fmt.Sprintf("List the open pull requests of a repository with the time of their latest activity, the most recent of their last commit, conversation comment and review, most recently active first. Only the %d most recently updated pull requests are scanned", maxPullRequestsToScan))),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			opts := &github.PullRequestListOptions{
+				State:       "open",
+				Sort:        "updated",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: maxPullRequestsToScan},
+			}
+			prs, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list pull requests: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list pull requests: %s", string(body))), nil
+			}
+
+			// Every pull request costs a few calls, so they are inspected concurrently, within bounds
+			activities := make([]pullRequestActivity, len(prs))
+			errs := forEachConcurrently(ctx, prs, func(ctx context.Context, i int, pr *github.PullRequest) error {
+				var err error
+				activities[i], err = getPullRequestActivity(ctx, client, owner, repo, pr)
+				return err
+			})
+			if err := errors.Join(errs...); err != nil {
+				return nil, err
+			}
+
+			// Most recently active first, with pull requests that never had any activity last
+			order := make([]int, len(prs))
+			for i := range order {
+				order[i] = i
+			}
+			activityAt := func(i int) github.Timestamp {
+				if activities[i].At == nil {
+					return github.Timestamp{}
+				}
+				return *activities[i].At
+			}
+			slices.SortStableFunc(order, func(a, b int) int {
+				return activityAt(b).Compare(activityAt(a).Time)
+			})
+
+			result := make([]map[string]interface{}, 0, len(prs))
+			for _, i := range order {
+				result = append(result, map[string]interface{}{
+					"number":             prs[i].GetNumber(),
+					"title":              prs[i].GetTitle(),
+					"user":               prs[i].GetUser().GetLogin(),
+					"html_url":           prs[i].GetHTMLURL(),
+					"last_activity_at":   activities[i].At,
+					"last_activity_type": activities[i].Type,
+				})
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"pull_requests": result,
+				"scanned":       len(prs),
+				// More open pull requests exist than were scanned, so the result may be incomplete
+				"capped": resp.NextPage != 0,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// listAllIssueComments fetches every conversation comment of an issue or pull request, oldest first.
+func listAllIssueComments(ctx context.Context, client *github.Client, owner, repo string, number int) ([]*github.IssueComment, error) {
+	var all []*github.IssueComment
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		comments, resp, err := client.Issues.ListComments(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request comments: %w", err)
+		}
+		_ = resp.Body.Close()
+		all = append(all, comments...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// listAllTimelineEvents fetches the whole timeline of an issue or pull request, oldest first.
+func listAllTimelineEvents(ctx context.Context, client *github.Client, owner, repo string, number int) ([]*github.Timeline, error) {
+	var all []*github.Timeline
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		events, resp, err := client.Issues.ListIssueTimeline(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request timeline: %w", err)
+		}
+		_ = resp.Body.Close()
+		all = append(all, events...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// listAllPullRequestComments fetches every review comment of a pull request.
+func listAllPullRequestComments(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) ([]*github.PullRequestComment, error) {
+	var all []*github.PullRequestComment
+	opts := &github.PullRequestListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		comments, resp, err := client.PullRequests.ListComments(ctx, owner, repo, pullNumber, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request review comments: %w", err)
+		}
+		_ = resp.Body.Close()
+		all = append(all, comments...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// conversationEntry is a comment, review or review comment of a pull request conversation transcript.
+type conversationEntry struct {
+	Type        string            `json:"type"`
+	Author      string            `json:"author"`
+	CreatedAt   *github.Timestamp `json:"created_at"`
+	Body        string            `json:"body"`
+	HTMLURL     string            `json:"html_url"`
+	ReviewState string            `json:"review_state,omitempty"`
+	Path        string            `json:"path,omitempty"`
+	Line        int               `json:"line,omitempty"`
+	InReplyToID int64             `json:"in_reply_to_id,omitempty"`
+}
+
+// pullRequestConversation merges the conversation comments, submitted reviews and review comments of a pull request
+// into a single transcript, oldest first. Entries created at the same time keep that order of their sources.
+func pullRequestConversation(comments []*github.IssueComment, reviews []*github.PullRequestReview, reviewComments []*github.PullRequestComment) []conversationEntry {
+	entries := make([]conversationEntry, 0, len(comments)+len(reviews)+len(reviewComments))
+	for _, comment := range comments {
+		entries = append(entries, conversationEntry{
+			Type:      "comment",
+			Author:    comment.GetUser().GetLogin(),
+			CreatedAt: comment.CreatedAt,
+			Body:      comment.GetBody(),
+			HTMLURL:   comment.GetHTMLURL(),
+		})
+	}
+	for _, review := range reviews {
+		// Pending reviews haven't been submitted, so they aren't part of the conversation yet
+		if review.SubmittedAt == nil {
+			continue
+		}
+		entries = append(entries, conversationEntry{
+			Type:        "review",
+			Author:      review.GetUser().GetLogin(),
+			CreatedAt:   review.SubmittedAt,
+			Body:        review.GetBody(),
+			HTMLURL:     review.GetHTMLURL(),
+			ReviewState: review.GetState(),
+		})
+	}
+	for _, comment := range reviewComments {
+		entries = append(entries, conversationEntry{
+			Type:        "review_comment",
+			Author:      comment.GetUser().GetLogin(),
+			CreatedAt:   comment.CreatedAt,
+			Body:        comment.GetBody(),
+			HTMLURL:     comment.GetHTMLURL(),
+			Path:        comment.GetPath(),
+			Line:        comment.GetLine(),
+			InReplyToID: comment.GetInReplyTo(),
+		})
+	}
+
+	entryTime := func(entry conversationEntry) github.Timestamp {
+		if entry.CreatedAt == nil {
+			return github.Timestamp{}
+		}
+		return *entry.CreatedAt
+	}
+	slices.SortStableFunc(entries, func(a, b conversationEntry) int {
+		return entryTime(a).Compare(entryTime(b).Time)
+	})
+	return entries
+}
+
+// GetPullRequestConversation creates a tool to get the whole conversation of a pull request as a single transcript.
+func GetPullRequestConversation(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_conversation",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_CONVERSATION_DESCRIPTION", "Get the whole conversation of a pull request as a single transcript, oldest first: its comments, submitted reviews and review comments, each tagged with its author and type")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The three sources are independent of each other
+			var (
+				comments       []*github.IssueComment
+				reviews        []*github.PullRequestReview
+				reviewComments []*github.PullRequestComment
+			)
+			errs := runConcurrently(ctx,
+				func(ctx context.Context) (err error) {
+					comments, err = listAllIssueComments(ctx, client, owner, repo, pullNumber)
+					return err
+				},
+				func(ctx context.Context) (err error) {
+					reviews, err = listAllPullRequestReviews(ctx, client, owner, repo, pullNumber)
+					return err
+				},
+				func(ctx context.Context) (err error) {
+					reviewComments, err = listAllPullRequestComments(ctx, client, owner, repo, pullNumber)
+					return err
+				},
+			)
+			if err := errors.Join(errs...); err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(fmt.Sprintf("pull request %d not found in %s/%s", pullNumber, owner, repo)), nil
+				}
+				return nil, err
+			}
+
+			r, err := json.Marshal(pullRequestConversation(comments, reviews, reviewComments))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// closingIssuesQuery fetches whether a pull request is merged, and the issues it closes with the latest closed
+// event of each, which records what closed the issue.
+const closingIssuesQuery = `query($owner: String!, $repo: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      merged
+      closingIssuesReferences(first: 50, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          number
+          title
+          state
+          url
+          repository { nameWithOwner }
+          timelineItems(last: 1, itemTypes: [CLOSED_EVENT]) {
+            nodes {
+              ... on ClosedEvent {
+                closer {
+                  __typename
+                  ... on PullRequest { number repository { nameWithOwner } }
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// closedByPullRequestIssue is an issue a pull request closes. Its status is will_close while the pull request
+// isn't merged, closed once merging the pull request closed it, and not_closed if the merged pull request
+// didn't close it, such as when it was reopened or closed by something else.
+type closedByPullRequestIssue struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	URL        string `json:"url"`
+	Status     string `json:"status"`
+}
+
+// ListClosedByPullRequest creates a tool to list the issues a pull request closes when merged.
+func ListClosedByPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_closed_by_pull_request",
+			mcp.WithDescription(t("TOOL_LIST_CLOSED_BY_PULL_REQUEST_DESCRIPTION", "List the issues a pull request closes when merged, such as with 'Fixes #123' in its body. Each issue's status is will_close while the pull request isn't merged, closed if merging it closed the issue, and not_closed otherwise")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			merged := false
+			issues := []closedByPullRequestIssue{}
+			variables := map[string]interface{}{
+				"owner":  owner,
+				"repo":   repo,
+				"number": pullNumber,
+			}
+			for {
+				var data struct {
+					Repository struct {
+						PullRequest struct {
+							Merged                  bool `json:"merged"`
+							ClosingIssuesReferences struct {
+								PageInfo struct {
+									HasNextPage bool   `json:"hasNextPage"`
+									EndCursor   string `json:"endCursor"`
+								} `json:"pageInfo"`
+								Nodes []struct {
+									Number     int    `json:"number"`
+									Title      string `json:"title"`
+									State      string `json:"state"`
+									URL        string `json:"url"`
+									Repository struct {
+										NameWithOwner string `json:"nameWithOwner"`
+									} `json:"repository"`
+									TimelineItems struct {
+										Nodes []struct {
+											Closer *struct {
+												Typename   string `json:"__typename"`
+												Number     int    `json:"number"`
+												Repository struct {
+													NameWithOwner string `json:"nameWithOwner"`
+												} `json:"repository"`
+											} `json:"closer"`
+										} `json:"nodes"`
+									} `json:"timelineItems"`
+								} `json:"nodes"`
+							} `json:"closingIssuesReferences"`
+						} `json:"pullRequest"`
+					} `json:"repository"`
+				}
+				if err := executeGraphQL(ctx, client, closingIssuesQuery, variables, &data); err != nil {
+					if isGraphQLNotFound(err) {
+						return mcp.NewToolResultError(fmt.Sprintf("pull request %s/%s#%d not found", owner, repo, pullNumber)), nil
+					}
+					return nil, fmt.Errorf("failed to get closing issues: %w", err)
+				}
+
+				pr := data.Repository.PullRequest
+				merged = pr.Merged
+				for _, node := range pr.ClosingIssuesReferences.Nodes {
+					issue := closedByPullRequestIssue{
+						Repository: node.Repository.NameWithOwner,
+						Number:     node.Number,
+						Title:      node.Title,
+						State:      strings.ToLower(node.State),
+						URL:        node.URL,
+						Status:     "will_close",
+					}
+					if merged {
+						// The issue was closed by this pull request if its latest closed event names it as the closer
+						issue.Status = "not_closed"
+						if issue.State == "closed" && len(node.TimelineItems.Nodes) > 0 {
+							closer := node.TimelineItems.Nodes[0].Closer
+							if closer != nil && closer.Typename == "PullRequest" && closer.Number == pullNumber &&
+								strings.EqualFold(closer.Repository.NameWithOwner, owner+"/"+repo) {
+								issue.Status = "closed"
+							}
+						}
+					}
+					issues = append(issues, issue)
+				}
+
+				if !pr.ClosingIssuesReferences.PageInfo.HasNextPage {
+					break
+				}
+				variables["cursor"] = pr.ClosingIssuesReferences.PageInfo.EndCursor
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"merged": merged,
+				"issues": issues,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// changedFile is a file changed between two commits.
+type changedFile struct {
+	Filename         string `json:"filename"`
+	Status           string `json:"status"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+}
+
+// lastReviewedCommit returns the commit the latest submitted review of the reviewer was made on, or "" if
+// they haven't reviewed the pull request.
+func lastReviewedCommit(reviews []*github.PullRequestReview, reviewer string) string {
+	commit := ""
+	for _, review := range reviews {
+		if !strings.EqualFold(review.GetUser().GetLogin(), reviewer) || review.GetState() == "PENDING" || review.GetCommitID() == "" {
+			continue
+		}
+		commit = review.GetCommitID()
+	}
+	return commit
+}
+
+// GetPullRequestFilesSinceReview creates a tool to list the files of a pull request changed since a reviewer last reviewed it.
+func GetPullRequestFilesSinceReview(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pr_files_since_review",
+			mcp.WithDescription(t("TOOL_GET_PR_FILES_SINCE_REVIEW_DESCRIPTION", "Get the files of a pull request changed since a reviewer last reviewed it, by comparing the commit of their latest review with the head of the pull request. If the branch was force pushed since, the files are compared from where both commits diverged, so some may have been reviewed already")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("reviewer",
+				mcp.Required(),
+				mcp.Description("Login of the reviewer"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reviewer, err := requiredParam[string](request, "reviewer")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("pull request %s/%s#%d not found", owner, repo, pullNumber)), nil
+				}
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			reviews, err := listAllPullRequestReviews(ctx, client, owner, repo, pullNumber)
+			if err != nil {
+				return nil, err
+			}
+			reviewedCommit := lastReviewedCommit(reviews, reviewer)
+			if reviewedCommit == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("%s has not reviewed pull request %s/%s#%d", reviewer, owner, repo, pullNumber)), nil
+			}
+
+			headCommit := pr.GetHead().GetSHA()
+			files := []changedFile{}
+			diverged := false
+			if reviewedCommit != headCommit {
+				comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, reviewedCommit, headCommit, nil)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the reviewed commit %s no longer exists in %s/%s", reviewedCommit, owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to compare commits: %w", err)
+				}
+				_ = resp.Body.Close()
+
+				diverged = comparison.GetStatus() == "diverged"
+				for _, file := range comparison.Files {
+					files = append(files, changedFile{
+						Filename:         file.GetFilename(),
+						Status:           file.GetStatus(),
+						Additions:        file.GetAdditions(),
+						Deletions:        file.GetDeletions(),
+						PreviousFilename: file.GetPreviousFilename(),
+					})
+				}
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"reviewed_commit": reviewedCommit,
+				"head_commit":     headCommit,
+				"force_pushed":    diverged,
+				"files":           files,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// commitPullRequest is a pull request including a commit.
+type commitPullRequest struct {
+	Number   int               `json:"number"`
+	Title    string            `json:"title"`
+	State    string            `json:"state"`
+	HTMLURL  string            `json:"html_url"`
+	MergedAt *github.Timestamp `json:"merged_at"`
+	BaseRef  string            `json:"base_ref"`
+	HeadRef  string            `json:"head_ref"`
+	Author   string            `json:"author"`
+}
+
+// ListPullRequestsForCommit creates a tool to list the pull requests including a commit.
+func ListPullRequestsForCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_prs_for_commit",
+			mcp.WithDescription(t("TOOL_LIST_PRS_FOR_COMMIT_DESCRIPTION", "List the pull requests including a commit, to trace which pull requests introduced it. direct_push is true if no pull request includes it, and first_merged_pr is the pull request merged first")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("Commit SHA"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := requiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pullRequests := []commitPullRequest{}
+			opts := &github.ListOptions{PerPage: 100}
+			for {
+				prs, resp, err := client.PullRequests.ListPullRequestsWithCommit(ctx, owner, repo, sha, opts)
+				if err != nil {
+					if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity) {
+						return mcp.NewToolResultError(fmt.Sprintf("commit %s not found in %s/%s", sha, owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to list pull requests for commit: %w", err)
+				}
+				_ = resp.Body.Close()
+
+				for _, pr := range prs {
+					pullRequests = append(pullRequests, commitPullRequest{
+						Number:   pr.GetNumber(),
+						Title:    pr.GetTitle(),
+						State:    pr.GetState(),
+						HTMLURL:  pr.GetHTMLURL(),
+						MergedAt: pr.MergedAt,
+						BaseRef:  pr.GetBase().GetRef(),
+						HeadRef:  pr.GetHead().GetRef(),
+						Author:   pr.GetUser().GetLogin(),
+					})
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			var firstMerged *commitPullRequest
+			for i := range pullRequests {
+				pr := &pullRequests[i]
+				if pr.MergedAt != nil && (firstMerged == nil || pr.MergedAt.Before(firstMerged.MergedAt.Time)) {
+					firstMerged = pr
+				}
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"pull_requests":   pullRequests,
+				"direct_push":     len(pullRequests) == 0,
+				"first_merged_pr": firstMerged,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// suggestionFencePattern matches the line opening a suggestion block: three or more backticks or tildes followed
+// by the word suggestion.
+var suggestionFencePattern = regexp.MustCompile("^\\s*(`{3,}|~{3,})suggestion\\s*$")
+
+// reviewSuggestion returns the lines the first suggestion block of a review comment body replaces the commented
+// lines with. An empty block suggests deleting the lines, and a block left open runs to the end of the body, the
+// way GitHub renders it.
+func reviewSuggestion(body string) ([]string, bool) {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		match := suggestionFencePattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		fence := match[1]
+		suggested := []string{}
+		for _, line := range lines[i+1:] {
+			closing := strings.TrimSpace(line)
+			if strings.HasPrefix(closing, fence) && strings.Trim(closing, fence[:1]) == "" {
+				break
+			}
+			suggested = append(suggested, line)
+		}
+		return suggested, true
+	}
+	return nil, false
+}
+
+// reviewCommentLines returns the lines of the file a review comment is on, as they were in the commit it was made
+// on. GitHub cuts the diff hunk of a comment off at its last line, so they are the last lines of the hunk on the
+// side of the diff the comment is on.
+func reviewCommentLines(comment *github.PullRequestComment) ([]string, error) {
+	end := comment.GetOriginalLine()
+	if end == 0 {
+		return nil, fmt.Errorf("review comment %d is on a file rather than on lines", comment.GetID())
+	}
+	start := comment.GetOriginalStartLine()
+	if start == 0 {
+		start = end
+	}
+
+	// Context lines are on both sides, while added lines are only on the right and removed ones only on the left
+	omitted := byte('-')
+	if comment.GetSide() == "LEFT" {
+		omitted = '+'
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(comment.GetDiffHunk(), "\n"), "\n") {
+		if strings.HasPrefix(line, "@@") || strings.HasPrefix(line, "\\") || line == "" || line[0] == omitted {
+			continue
+		}
+		lines = append(lines, line[1:])
+	}
+	count := end - start + 1
+	if len(lines) < count {
+		return nil, fmt.Errorf("the diff hunk of review comment %d has %d lines, fewer than the %d it is on", comment.GetID(), len(lines), count)
+	}
+	return lines[len(lines)-count:], nil
+}
+
+// GetReviewSuggestion creates a tool to get the change suggested by a pull request review comment.
+func GetReviewSuggestion(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_review_suggestion",
+			mcp.WithDescription(t("TOOL_GET_REVIEW_SUGGESTION_DESCRIPTION", "Get the change suggested by a pull request review comment: the lines it is on, taken from its diff hunk, and the lines its suggestion block replaces them with. has_suggestion is false for comments without a suggestion block")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("comment_id",
+				mcp.Required(),
+				mcp.Description("ID of the review comment"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			commentID, err := RequiredInt(request, "comment_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			comment, resp, err := client.PullRequests.GetComment(ctx, owner, repo, int64(commentID))
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("review comment %d not found in %s/%s", commentID, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get review comment: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			result := map[string]interface{}{
+				"comment_id": comment.GetID(),
+				"path":       comment.GetPath(),
+				"commit_id":  comment.GetOriginalCommitID(),
+			}
+			suggested, ok := reviewSuggestion(comment.GetBody())
+			if !ok {
+				result["has_suggestion"] = false
+				result["message"] = fmt.Sprintf("review comment %d has no suggestion", commentID)
+			} else {
+				original, err := reviewCommentLines(comment)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				start := comment.GetOriginalStartLine()
+				if start == 0 {
+					start = comment.GetOriginalLine()
+				}
+				result["has_suggestion"] = true
+				result["start_line"] = start
+				result["end_line"] = comment.GetOriginalLine()
+				result["original_lines"] = original
+				result["suggested_lines"] = suggested
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ApplyReviewSuggestion creates a tool to commit the change suggested by a pull request review comment to the pull
+// request's head branch.
+func ApplyReviewSuggestion(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("apply_review_suggestion",
+			mcp.WithDescription(t("TOOL_APPLY_REVIEW_SUGGESTION_DESCRIPTION", "Commit the change suggested by a pull request review comment to the head branch of the pull request, returning the SHA of the new commit. Refuses to apply the suggestion if the lines it replaces have changed since the comment was made")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithNumber("comment_id",
+				mcp.Required(),
+				mcp.Description("ID of the review comment with the suggestion"),
+			),
+			mcp.WithString("commit_message",
+				mcp.Description("Message of the commit. Defaults to \"Apply suggestion from code review\""),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			commentID, err := RequiredInt(request, "comment_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := OptionalParam[string](request, "commit_message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if message == "" {
+				message = "Apply suggestion from code review"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("pull request %d not found in %s/%s", pullNumber, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			_ = resp.Body.Close()
+			if pr.GetState() != "open" {
+				return mcp.NewToolResultError(fmt.Sprintf("pull request %d is %s, suggestions can only be applied to open pull requests", pullNumber, pr.GetState())), nil
+			}
+			headRepo := pr.GetHead().GetRepo()
+			if headRepo == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("the head repository of pull request %d no longer exists", pullNumber)), nil
+			}
+
+			comment, resp, err := client.PullRequests.GetComment(ctx, owner, repo, int64(commentID))
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("review comment %d not found in %s/%s", commentID, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get review comment: %w", err)
+			}
+			_ = resp.Body.Close()
+			if !strings.HasSuffix(comment.GetPullRequestURL(), fmt.Sprintf("/pulls/%d", pullNumber)) {
+				return mcp.NewToolResultError(fmt.Sprintf("review comment %d is not on pull request %d", commentID, pullNumber)), nil
+			}
+			suggested, ok := reviewSuggestion(comment.GetBody())
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("review comment %d has no suggestion", commentID)), nil
+			}
+			original, err := reviewCommentLines(comment)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// GitHub clears the line of comments whose lines were changed by a later push
+			end := comment.GetLine()
+			if end == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("review comment %d is outdated, the lines it is on have changed since it was made", commentID)), nil
+			}
+			start := comment.GetStartLine()
+			if start == 0 {
+				start = end
+			}
+
+			path := comment.GetPath()
+			headOwner, headName := headRepo.GetOwner().GetLogin(), headRepo.GetName()
+			fileContent, _, resp, err := client.Repositories.GetContents(ctx, headOwner, headName, path, &github.RepositoryContentGetOptions{Ref: pr.GetHead().GetSHA()})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("%s no longer exists on the head branch of pull request %d", path, pullNumber)), nil
+				}
+				return nil, fmt.Errorf("failed to get %s: %w", path, err)
+			}
+			_ = resp.Body.Close()
+			if fileContent == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is not a file", path)), nil
+			}
+			content, err := fileContent.GetContent()
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to decode %s: %s", path, err)), nil
+			}
+
+			lines := strings.Split(content, "\n")
+			if end > len(lines) || !slices.Equal(lines[start-1:end], original) {
+				return mcp.NewToolResultError(fmt.Sprintf("lines %d to %d of %s have changed since review comment %d was made, refusing to apply the suggestion", start, end, path, commentID)), nil
+			}
+			edited := slices.Concat(lines[:start-1], suggested, lines[end:])
+
+			// The blob SHA makes the update fail rather than overwrite the file if the branch has moved on
+			opts := &github.RepositoryContentFileOptions{
+				Message: github.Ptr(message),
+				Content: []byte(strings.Join(edited, "\n")),
+				SHA:     fileContent.SHA,
+				Branch:  github.Ptr(pr.GetHead().GetRef()),
+			}
+			updated, resp, err := client.Repositories.UpdateFile(ctx, headOwner, headName, path, opts)
+			if err != nil {
+				var errorResponse *github.ErrorResponse
+				if errors.As(err, &errorResponse) && resp != nil && (resp.StatusCode == http.StatusConflict || resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnprocessableEntity) {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot commit to %s of %s/%s: %s", pr.GetHead().GetRef(), headOwner, headName, errorResponse.Message)), nil
+				}
+				return nil, fmt.Errorf("failed to commit suggestion: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			r, err := json.Marshal(map[string]interface{}{
+				"commit_sha": updated.GetSHA(),
+				"html_url":   updated.GetHTMLURL(),
+				"branch":     pr.GetHead().GetRef(),
+				"path":       path,
+				"start_line": start,
+				"end_line":   end,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// pullRequestGate is what stands between a pull request and being merged, which its next steps are worked out from.
+type pullRequestGate struct {
+	Draft bool
+	// MergeabilityKnown is false while GitHub is still computing MergeableState.
+	MergeabilityKnown bool
+	MergeableState    string
+	BaseRef           string
+	FailingChecks     []string
+	PendingChecks     []string
+	// ChecksRequired is false if the checks aren't known to be required, as the branch protection can't be read.
+	ChecksRequired bool
+	// ChangesRequestedBy are the reviewers whose latest review requests changes.
+	ChangesRequestedBy []string
+	// RequestedReviewers are the users and teams a review is requested from and not yet given, as @login or @org/team.
+	RequestedReviewers     []string
+	ApprovalsRequired      int
+	Approvals              int
+	CodeownerReviewMissing bool
+}
+
+// nextStep is a thing to do, or to wait for, to get a pull request merged.
+type nextStep struct {
+	Kind     string `json:"kind"`
+	Step     string `json:"step"`
+	Blocking bool   `json:"blocking"`
+}
+
+// mentionList joins names into a list like "@a, @b and @c".
+func mentionList(names []string) string {
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// pullRequestNextSteps orders what is left to do to merge a pull request. Fixes only the author can make come
+// first, starting with those that make everything after them run again: leaving draft, resolving conflicts and
+// updating the branch all trigger new checks, and new commits can dismiss reviews. Waiting on checks and
+// reviewers comes last. A reviewer appears in one step only: one asked to review again is waited on rather than
+// having their requested changes listed. Merging is the last step once nothing blocking is left.
+func pullRequestNextSteps(gate pullRequestGate) []nextStep {
+	steps := []nextStep{}
+	add := func(kind string, blocking bool, format string, args ...interface{}) {
+		steps = append(steps, nextStep{Kind: kind, Step: fmt.Sprintf(format, args...), Blocking: blocking})
+	}
+
+	if gate.Draft {
+		add("ready_for_review", true, "Mark the pull request as ready for review")
+	}
+	switch {
+	case !gate.MergeabilityKnown:
+		add("check_mergeability", true, "Check again shortly, GitHub is still computing whether the pull request conflicts with %s", gate.BaseRef)
+	case gate.MergeableState == "dirty":
+		add("resolve_conflicts", true, "Resolve the merge conflicts with %s", gate.BaseRef)
+	case gate.MergeableState == "behind":
+		add("update_branch", true, "Update the branch with the latest changes from %s", gate.BaseRef)
+	}
+
+	checks := "checks"
+	if gate.ChecksRequired {
+		checks = "required checks"
+	}
+	if len(gate.FailingChecks) > 0 {
+		add("fix_checks", true, "Fix the failing %s: %s", checks, strings.Join(gate.FailingChecks, ", "))
+	}
+
+	requested := map[string]bool{}
+	for _, reviewer := range gate.RequestedReviewers {
+		requested[strings.ToLower(reviewer)] = true
+	}
+	toAddress := []string{}
+	for _, reviewer := range gate.ChangesRequestedBy {
+		if !requested[strings.ToLower(reviewer)] {
+			toAddress = append(toAddress, reviewer)
+		}
+	}
+	if len(toAddress) > 0 {
+		add("address_changes", true, "Address the changes requested by %s, then ask for a new review", mentionList(toAddress))
+	}
+
+	if len(gate.PendingChecks) > 0 {
+		add("wait_for_checks", true, "Wait for CI to finish the pending %s: %s", checks, strings.Join(gate.PendingChecks, ", "))
+	}
+
+	missing := max(gate.ApprovalsRequired-gate.Approvals, 0)
+	// Changes requested by a reviewer asked to review again block the merge until they review
+	reRequested := len(gate.ChangesRequestedBy) > len(toAddress)
+	if len(gate.RequestedReviewers) > 0 {
+		add("wait_for_reviews", missing > 0 || reRequested, "Wait for a review from %s", mentionList(gate.RequestedReviewers))
+	}
+	if missing > len(gate.RequestedReviewers) {
+		more := missing - len(gate.RequestedReviewers)
+		noun := "reviewer"
+		if more > 1 {
+			noun = "reviewers"
+		}
+		if len(gate.RequestedReviewers) > 0 {
+			add("request_approvals", true, "Request approval from %d more %s, %d approvals are required and %d given", more, noun, gate.ApprovalsRequired, gate.Approvals)
+		} else {
+			add("request_approvals", true, "Request approval from %d %s, %d approvals are required and %d given", more, noun, gate.ApprovalsRequired, gate.Approvals)
+		}
+	}
+	if gate.CodeownerReviewMissing {
+		add("codeowner_review", true, "Get an approval from a code owner of the changed files")
+	}
+
+	blocked := slices.ContainsFunc(steps, func(s nextStep) bool { return s.Blocking })
+	if !blocked {
+		add("merge", false, "Merge the pull request")
+	}
+	return steps
+}
+
+// GetPullRequestNextSteps creates a tool to work out what is left to do to get a pull request merged.
+func GetPullRequestNextSteps(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_next_steps",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_NEXT_STEPS_DESCRIPTION", "Get the ordered list of what is left to do to merge a pull request, combining its mergeability, merge conflicts, failing and pending checks and reviews: fixes for the author first, then what to wait for, and merging once nothing blocks it. Only the required checks count when the branch protection can be read, every check otherwise")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, known, err := getPullRequestMergeability(ctx, client, owner, repo, pullNumber)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(fmt.Sprintf("pull request %d not found in %s/%s", pullNumber, owner, repo)), nil
+				}
+				return nil, err
+			}
+			if pr.GetState() != "open" {
+				state := pr.GetState()
+				if pr.GetMerged() {
+					state = "merged"
+				}
+				r, err := json.Marshal(map[string]interface{}{
+					"state":          state,
+					"ready_to_merge": false,
+					"steps":          []nextStep{},
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				return mcp.NewToolResultText(string(r)), nil
+			}
+			baseRef := pr.GetBase().GetRef()
+			headSHA := pr.GetHead().GetSHA()
+
+			var (
+				protection         *github.Protection
+				protectionReadable = true
+				reviews            []*github.PullRequestReview
+				combined           *github.CombinedStatus
+				checkRuns          []*github.CheckRun
+			)
+			errs := runConcurrently(ctx,
+				func(ctx context.Context) error {
+					var resp *github.Response
+					var err error
+					protection, resp, err = client.Repositories.GetBranchProtection(ctx, owner, repo, baseRef)
+					if resp != nil {
+						_ = resp.Body.Close()
+					}
+					switch {
+					case errors.Is(err, github.ErrBranchNotProtected):
+						protection = nil
+					// Reading the protection needs admin access, without it every check is taken to matter
+					case resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden):
+						protection, protectionReadable = nil, false
+					case err != nil:
+						return fmt.Errorf("failed to get branch protection: %w", err)
+					}
+					return nil
+				},
+				func(ctx context.Context) (err error) {
+					reviews, err = listAllPullRequestReviews(ctx, client, owner, repo, pullNumber)
+					return err
+				},
+				func(ctx context.Context) error {
+					var resp *github.Response
+					var err error
+					combined, resp, err = client.Repositories.GetCombinedStatus(ctx, owner, repo, headSHA, &github.ListOptions{PerPage: 100})
+					if err != nil {
+						return fmt.Errorf("failed to get combined status: %w", err)
+					}
+					_ = resp.Body.Close()
+					return nil
+				},
+				func(ctx context.Context) (err error) {
+					checkRuns, err = listAllCheckRuns(ctx, client, owner, repo, headSHA)
+					return err
+				},
+			)
+			if err := errors.Join(errs...); err != nil {
+				return nil, err
+			}
+
+			gate := pullRequestGate{
+				Draft:             pr.GetDraft(),
+				MergeabilityKnown: known,
+				MergeableState:    pr.GetMergeableState(),
+				BaseRef:           baseRef,
+				ChecksRequired:    protectionReadable,
+			}
+
+			// Without protection nothing is required, unless it can't be read and all checks are taken to be
+			outcomes := statusCheckOutcomes(combined, checkRuns)
+			checks := slices.Sorted(maps.Keys(outcomes))
+			if protection != nil && protection.RequiredStatusChecks != nil {
+				checks = []string{}
+				for _, check := range currentStatusChecks(protection.RequiredStatusChecks) {
+					checks = append(checks, check.Context)
+				}
+			} else if protectionReadable {
+				checks = nil
+			}
+			for _, name := range checks {
+				switch outcomes[name] {
+				case "failing":
+					gate.FailingChecks = append(gate.FailingChecks, name)
+				case "passing":
+				default:
+					gate.PendingChecks = append(gate.PendingChecks, name)
+				}
+			}
+
+			// Only the latest approval or change request of each reviewer counts
+			latestReviews := map[string]string{}
+			for _, review := range reviews {
+				switch review.GetState() {
+				case "APPROVED", "CHANGES_REQUESTED":
+					latestReviews[review.GetUser().GetLogin()] = review.GetState()
+				case "DISMISSED":
+					delete(latestReviews, review.GetUser().GetLogin())
+				}
+			}
+			approvers := []string{}
+			for _, login := range slices.Sorted(maps.Keys(latestReviews)) {
+				if latestReviews[login] == "APPROVED" {
+					gate.Approvals++
+					approvers = append(approvers, login)
+				} else {
+					gate.ChangesRequestedBy = append(gate.ChangesRequestedBy, "@"+login)
+				}
+			}
+			for _, user := range pr.RequestedReviewers {
+				gate.RequestedReviewers = append(gate.RequestedReviewers, "@"+user.GetLogin())
+			}
+			for _, team := range pr.RequestedTeams {
+				gate.RequestedReviewers = append(gate.RequestedReviewers, fmt.Sprintf("@%s/%s", owner, team.GetSlug()))
+			}
+
+			if protection != nil && protection.RequiredPullRequestReviews != nil {
+				rules := protection.RequiredPullRequestReviews
+				gate.ApprovalsRequired = rules.RequiredApprovingReviewCount
+				if rules.RequireCodeOwnerReviews {
+					approved, err := codeownersApproved(ctx, client, owner, repo, pullNumber, baseRef, approvers)
+					if err != nil {
+						return nil, err
+					}
+					gate.CodeownerReviewMissing = !approved
+				}
+			}
+
+			steps := pullRequestNextSteps(gate)
+			result := map[string]interface{}{
+				"state":               "open",
+				"mergeable_state":     pr.GetMergeableState(),
+				"ready_to_merge":      steps[len(steps)-1].Kind == "merge",
+				"protection_readable": protectionReadable,
+				"steps":               steps,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// durationPercentile is the pth percentile of sorted durations by the nearest-rank method, the smallest duration
+// at least p percent of them are no longer than.
+func durationPercentile(sorted []time.Duration, p int) time.Duration {
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// firstReviewAt is when someone other than the author first submitted a review of a pull request, or nil if no
+// one has.
+func firstReviewAt(author string, reviews []*github.PullRequestReview) *github.Timestamp {
+	var first *github.Timestamp
+	for _, review := range reviews {
+		if review.GetState() == "PENDING" || review.SubmittedAt == nil || strings.EqualFold(review.GetUser().GetLogin(), author) {
+			continue
+		}
+		if first == nil || review.SubmittedAt.Before(first.Time) {
+			first = review.SubmittedAt
+		}
+	}
+	return first
+}
+
+// GetReviewTurnaroundStats creates a tool to get percentiles of the time pull requests of a repository waited
+// for their first review.
+func GetReviewTurnaroundStats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_review_turnaround_stats",
+			mcp.WithDescription(t("TOOL_GET_REVIEW_TURNAROUND_STATS_DESCRIPTION", fmt.Sprintf("Get the p50, p90 and p99 of the time from opening to first review, in hours, of the pull requests of a repository merged within a window of time ending now. Pull requests merged without a review are counted in the sample but left out of the percentiles. At most the %d most recently merged pull requests are sampled", maxPullRequestsToScan))),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("window_days",
+				mcp.Description("Number of days back to sample merged pull requests from. Defaults to 30"),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			windowDays, err := OptionalIntParamWithDefault(request, "window_days", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if windowDays < 1 {
+				return mcp.NewToolResultError(fmt.Sprintf("window_days must be at least 1, got %d", windowDays)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// A pull request is updated when it is merged, so once the pull requests are updated before the window
+			// none of the rest can have been merged in it
+			since := time.Now().AddDate(0, 0, -windowDays)
+			opts := &github.PullRequestListOptions{
+				State:       "closed",
+				Sort:        "updated",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			var sample []*github.PullRequest
+			capped := false
+			for {
+				prs, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to list pull requests: %w", err)
+				}
+				_ = resp.Body.Close()
+
+				done := false
+				for _, pr := range prs {
+					if pr.GetUpdatedAt().Before(since) {
+						done = true
+						break
+					}
+					if pr.MergedAt == nil || pr.GetMergedAt().Before(since) {
+						continue
+					}
+					if len(sample) == maxPullRequestsToScan {
+						capped, done = true, true
+						break
+					}
+					sample = append(sample, pr)
+				}
+				if done || resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			waits := make([]time.Duration, len(sample))
+			reviewed := make([]bool, len(sample))
+			errs := forEachConcurrently(ctx, sample, func(ctx context.Context, i int, pr *github.PullRequest) error {
+				reviews, err := listAllPullRequestReviews(ctx, client, owner, repo, pr.GetNumber())
+				if err != nil {
+					return err
+				}
+				if first := firstReviewAt(pr.GetUser().GetLogin(), reviews); first != nil {
+					waits[i], reviewed[i] = first.Sub(pr.GetCreatedAt().Time), true
+				}
+				return nil
+			})
+			if err := errors.Join(errs...); err != nil {
+				return nil, err
+			}
+
+			var reviewedWaits []time.Duration
+			for i, wait := range waits {
+				if reviewed[i] {
+					reviewedWaits = append(reviewedWaits, wait)
+				}
+			}
+			result := map[string]interface{}{
+				"window_days": windowDays,
+				"sample_size": len(sample),
+				"reviewed":    len(reviewedWaits),
+				"unreviewed":  len(sample) - len(reviewedWaits),
+				// More pull requests were merged in the window than were sampled
+				"capped": capped,
+			}
+			if len(reviewedWaits) > 0 {
+				slices.Sort(reviewedWaits)
+				hours := func(d time.Duration) float64 { return math.Round(d.Hours()*10) / 10 }
+				result["p50_hours"] = hours(durationPercentile(reviewedWaits, 50))
+				result["p90_hours"] = hours(durationPercentile(reviewedWaits, 90))
+				result["p99_hours"] = hours(durationPercentile(reviewedWaits, 99))
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
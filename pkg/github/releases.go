@@ -0,0 +1,377 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxReleaseReposToScan bounds the number of repositories list_org_recent_releases inspects,
+	// since every repository costs a separate releases call.
+	maxReleaseReposToScan = 50
+	// releasesPerRepo is how many of the latest releases of each repository are considered.
+	releasesPerRepo = 10
+)
+
+// ListOrgRecentReleases creates a tool to list the latest releases across the repositories of an organization.
+func ListOrgRecentReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_recent_releases",
+			mcp.WithDescription(t("TOOL_LIST_ORG_RECENT_RELEASES_DESCRIPTION", fmt.Sprintf("List the latest published releases across the repositories of an organization, most recent first. Only the %d most recently pushed repositories are scanned", maxReleaseReposToScan))),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum number of releases to return, defaults to 30"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only return releases published at or after this ISO 8601 timestamp (YYYY-MM-DDThh:mm:ssZ or YYYY-MM-DD)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sinceParam, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var since github.Timestamp
+			if sinceParam != "" {
+				parsed, err := parseISOTimestamp(sinceParam)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				since = github.Timestamp{Time: parsed}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			// Repositories released to recently were pushed to recently, so scan those first
+			repoOpts := &github.RepositoryListByOrgOptions{
+				Sort:        "pushed",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: maxReleaseReposToScan},
+			}
+			repos, resp, err := client.Repositories.ListByOrg(ctx, org, repoOpts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repositories: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list repositories: %s", string(body))), nil
+			}
+
+			type orgRelease struct {
+				repo    string
+				release *github.RepositoryRelease
+			}
+			var releases []orgRelease
+			for _, repo := range repos {
+				repoReleases, resp, err := client.Repositories.ListReleases(ctx, org, repo.GetName(), &github.ListOptions{PerPage: releasesPerRepo})
+				if err != nil {
+					return nil, fmt.Errorf("failed to list releases of %s: %w", repo.GetFullName(), err)
+				}
+				_ = resp.Body.Close()
+				for _, release := range repoReleases {
+					// Drafts have no publication date and aren't visible as releases yet
+					if release.GetDraft() || release.PublishedAt == nil || release.PublishedAt.Before(since.Time) {
+						continue
+					}
+					releases = append(releases, orgRelease{repo: repo.GetFullName(), release: release})
+				}
+			}
+
+			slices.SortFunc(releases, func(a, b orgRelease) int {
+				return b.release.PublishedAt.Compare(a.release.PublishedAt.Time)
+			})
+			if len(releases) > limit {
+				releases = releases[:limit]
+			}
+
+			result := make([]map[string]interface{}, 0, len(releases))
+			for _, r := range releases {
+				result = append(result, map[string]interface{}{
+					"repository":   r.repo,
+					"tag_name":     r.release.GetTagName(),
+					"name":         r.release.GetName(),
+					"prerelease":   r.release.GetPrerelease(),
+					"author":       r.release.GetAuthor().GetLogin(),
+					"published_at": r.release.PublishedAt,
+					"html_url":     r.release.GetHTMLURL(),
+				})
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"releases":      result,
+				"repos_scanned": len(repos),
+				// More repositories exist than were scanned, so older releases elsewhere may be missing
+				"truncated": resp.NextPage != 0,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// releaseSummary returns the fields of a release that matter to callers.
+func releaseSummary(release *github.RepositoryRelease) map[string]interface{} {
+	assets := make([]map[string]interface{}, 0, len(release.Assets))
+	for _, asset := range release.Assets {
+		assets = append(assets, map[string]interface{}{
+			"id":                   asset.GetID(),
+			"name":                 asset.GetName(),
+			"size":                 asset.GetSize(),
+			"download_count":       asset.GetDownloadCount(),
+			"browser_download_url": asset.GetBrowserDownloadURL(),
+		})
+	}
+	return map[string]interface{}{
+		"id":           release.GetID(),
+		"tag_name":     release.GetTagName(),
+		"name":         release.GetName(),
+		"body":         release.GetBody(),
+		"draft":        release.GetDraft(),
+		"prerelease":   release.GetPrerelease(),
+		"created_at":   release.CreatedAt,
+		"published_at": release.PublishedAt,
+		"html_url":     release.GetHTMLURL(),
+		"tarball_url":  release.GetTarballURL(),
+		"zipball_url":  release.GetZipballURL(),
+		"assets":       assets,
+		"author":       release.GetAuthor().GetLogin(),
+	}
+}
+
+// GetReleaseByTag creates a tool to get the release of a tag.
+func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_release_by_tag",
+			mcp.WithDescription(t("TOOL_GET_RELEASE_BY_TAG_DESCRIPTION", "Get the published release of a tag, such as v1.2.0, with its notes and assets")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Name of the tag, such as v1.2.0"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := requiredParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			release, resp, err := client.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+			if err != nil {
+				if resp == nil || resp.StatusCode != http.StatusNotFound {
+					return nil, fmt.Errorf("failed to get release: %w", err)
+				}
+				// Tell a tag that was never released apart from a tag that doesn't exist
+				_, resp, err := client.Git.GetRef(ctx, owner, repo, "tags/"+tag)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("tag %s not found in %s/%s", tag, owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to get tag: %w", err)
+				}
+				_ = resp.Body.Close()
+				return mcp.NewToolResultError(fmt.Sprintf("tag %s exists in %s/%s but has no published release", tag, owner, repo)), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(releaseSummary(release))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetLatestRelease creates a tool to get the latest release of a repository.
+func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_latest_release",
+			mcp.WithDescription(t("TOOL_GET_LATEST_RELEASE_DESCRIPTION", "Get the latest published release of a repository, with its notes and assets. Prereleases and drafts are never the latest release")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			release, resp, err := client.Repositories.GetLatestRelease(ctx, owner, repo)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("%s/%s has no published release, or doesn't exist", owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get latest release: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(releaseSummary(release))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// PromoteRelease creates a tool to promote a prerelease, such as a beta or a release candidate, to a stable release.
+func PromoteRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("promote_release",
+			mcp.WithDescription(t("TOOL_PROMOTE_RELEASE_DESCRIPTION", "Promote a prerelease, such as a beta or a release candidate, to a stable published release, optionally renaming it")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Description("ID of the release to promote. Either release_id or tag is required"),
+			),
+			mcp.WithString("tag",
+				mcp.Description("Tag of the release to promote, such as v1.2.0-rc.1. Either release_id or tag is required"),
+			),
+			mcp.WithString("new_name",
+				mcp.Description("New name of the release, such as v1.2.0"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := OptionalIntParam(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := OptionalParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newName, err := OptionalParam[string](request, "new_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (releaseID == 0) == (tag == "") {
+				return mcp.NewToolResultError("either release_id or tag is required, but not both"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var release *github.RepositoryRelease
+			var resp *github.Response
+			name := tag
+			if releaseID != 0 {
+				name = fmt.Sprintf("%d", releaseID)
+				release, resp, err = client.Repositories.GetRelease(ctx, owner, repo, int64(releaseID))
+			} else {
+				release, resp, err = client.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+			}
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("release %s not found in %s/%s", name, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get release: %w", err)
+			}
+			_ = resp.Body.Close()
+			if !release.GetPrerelease() {
+				return mcp.NewToolResultError(fmt.Sprintf("release %s of %s/%s is not a prerelease", name, owner, repo)), nil
+			}
+
+			update := &github.RepositoryRelease{
+				Draft:      github.Ptr(false),
+				Prerelease: github.Ptr(false),
+			}
+			if newName != "" {
+				update.Name = github.Ptr(newName)
+			}
+			promoted, resp, err := client.Repositories.EditRelease(ctx, owner, repo, release.GetID(), update)
+			if err != nil {
+				return nil, fmt.Errorf("failed to promote release: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]interface{}{
+				"id":           promoted.GetID(),
+				"tag_name":     promoted.GetTagName(),
+				"name":         promoted.GetName(),
+				"prerelease":   promoted.GetPrerelease(),
+				"published_at": promoted.PublishedAt,
+				"html_url":     promoted.GetHTMLURL(),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
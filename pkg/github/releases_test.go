@@ -0,0 +1,674 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgRecentReleases(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgRecentReleases(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_recent_releases", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "limit")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	published := func(day int) *github.Timestamp {
+		return &github.Timestamp{Time: time.Date(2025, 1, day, 12, 0, 0, 0, time.UTC)}
+	}
+	mockRepos := []*github.Repository{
+		{Name: github.Ptr("api"), FullName: github.Ptr("org/api")},
+		{Name: github.Ptr("cli"), FullName: github.Ptr("org/cli")},
+	}
+	mockReleases := map[string][]*github.RepositoryRelease{
+		"/repos/org/api/releases": {
+			{TagName: github.Ptr("v2.0.0"), PublishedAt: published(20)},
+			{TagName: github.Ptr("v2.1.0"), Draft: github.Ptr(true)},
+			{TagName: github.Ptr("v1.9.0"), PublishedAt: published(5)},
+		},
+		"/repos/org/cli/releases": {
+			{TagName: github.Ptr("v0.3.0"), PublishedAt: published(25), Prerelease: github.Ptr(true)},
+			{TagName: github.Ptr("v0.2.0"), PublishedAt: published(10)},
+		},
+	}
+	releasesHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockResponse(t, http.StatusOK, mockReleases[r.URL.Path])(w, r)
+	})
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedTags      []string
+		expectedRepos     []string
+		expectedTruncated bool
+	}{
+		{
+			name: "releases of all repositories are merged and sorted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					expectQueryParams(t, map[string]string{
+						"sort":      "pushed",
+						"direction": "desc",
+						"per_page":  "50",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRepos),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesByOwnerByRepo,
+					releasesHandler,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:       false,
+			expectedTags:      []string{"v0.3.0", "v2.0.0", "v0.2.0", "v1.9.0"},
+			expectedRepos:     []string{"org/cli", "org/api", "org/cli", "org/api"},
+			expectedTruncated: false,
+		},
+		{
+			name: "since and limit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsReposByOrg,
+					mockRepos,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesByOwnerByRepo,
+					releasesHandler,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":   "org",
+				"since": "2025-01-08",
+				"limit": float64(2),
+			},
+			expectError:       false,
+			expectedTags:      []string{"v0.3.0", "v2.0.0"},
+			expectedRepos:     []string{"org/cli", "org/api"},
+			expectedTruncated: false,
+		},
+		{
+			name: "more repositories than scanned",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Set("Link", `<https://api.github.com/orgs/org/repos?page=2>; rel="next"`)
+						mockResponse(t, http.StatusOK, mockRepos[:1])(w, r)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesByOwnerByRepo,
+					releasesHandler,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:       false,
+			expectedTags:      []string{"v2.0.0", "v1.9.0"},
+			expectedRepos:     []string{"org/api", "org/api"},
+			expectedTruncated: true,
+		},
+		{
+			name: "invalid since",
+			requestArgs: map[string]interface{}{
+				"org":   "org",
+				"since": "last week",
+			},
+			expectError:    false,
+			expectedErrMsg: "invalid ISO 8601 timestamp",
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list repositories",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgRecentReleases(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Releases []struct {
+					Repository string `json:"repository"`
+					TagName    string `json:"tag_name"`
+				} `json:"releases"`
+				Truncated bool `json:"truncated"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			require.Len(t, response.Releases, len(tc.expectedTags))
+			for i, release := range response.Releases {
+				assert.Equal(t, tc.expectedTags[i], release.TagName)
+				assert.Equal(t, tc.expectedRepos[i], release.Repository)
+			}
+			assert.Equal(t, tc.expectedTruncated, response.Truncated)
+		})
+	}
+}
+
+func Test_GetReleaseByTag(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetReleaseByTag(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_release_by_tag", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "tag")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag"})
+
+	publishedAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	mockRelease := &github.RepositoryRelease{
+		ID:          github.Ptr(int64(1)),
+		TagName:     github.Ptr("v1.2.0"),
+		Name:        github.Ptr("v1.2.0"),
+		Body:        github.Ptr("Bug fixes"),
+		Draft:       github.Ptr(false),
+		Prerelease:  github.Ptr(false),
+		CreatedAt:   &github.Timestamp{Time: publishedAt.Add(-time.Hour)},
+		PublishedAt: &github.Timestamp{Time: publishedAt},
+		HTMLURL:     github.Ptr("https://github.com/owner/repo/releases/tag/v1.2.0"),
+		TarballURL:  github.Ptr("https://api.github.com/repos/owner/repo/tarball/v1.2.0"),
+		ZipballURL:  github.Ptr("https://api.github.com/repos/owner/repo/zipball/v1.2.0"),
+		Author:      &github.User{Login: github.Ptr("octocat")},
+		Assets: []*github.ReleaseAsset{
+			{
+				ID:                 github.Ptr(int64(10)),
+				Name:               github.Ptr("server_linux_amd64.tar.gz"),
+				Size:               github.Ptr(1024),
+				DownloadCount:      github.Ptr(42),
+				BrowserDownloadURL: github.Ptr("https://github.com/owner/repo/releases/download/v1.2.0/server_linux_amd64.tar.gz"),
+			},
+		},
+	}
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedRelease map[string]interface{}
+	}{
+		{
+			name: "release found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposReleasesTagsByOwnerByRepoByTag,
+					mockRelease,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.2.0",
+			},
+			expectError: false,
+			expectedRelease: map[string]interface{}{
+				"id":           float64(1),
+				"tag_name":     "v1.2.0",
+				"name":         "v1.2.0",
+				"body":         "Bug fixes",
+				"draft":        false,
+				"prerelease":   false,
+				"created_at":   "2025-04-01T11:00:00Z",
+				"published_at": "2025-04-01T12:00:00Z",
+				"html_url":     "https://github.com/owner/repo/releases/tag/v1.2.0",
+				"tarball_url":  "https://api.github.com/repos/owner/repo/tarball/v1.2.0",
+				"zipball_url":  "https://api.github.com/repos/owner/repo/zipball/v1.2.0",
+				"author":       "octocat",
+				"assets": []interface{}{
+					map[string]interface{}{
+						"id":                   float64(10),
+						"name":                 "server_linux_amd64.tar.gz",
+						"size":                 float64(1024),
+						"download_count":       float64(42),
+						"browser_download_url": "https://github.com/owner/repo/releases/download/v1.2.0/server_linux_amd64.tar.gz",
+					},
+				},
+			},
+		},
+		{
+			name: "tag without release",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesTagsByOwnerByRepoByTag,
+					notFound,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					&github.Reference{Ref: github.Ptr("refs/tags/v1.3.0")},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.3.0",
+			},
+			expectError:    false,
+			expectedErrMsg: "tag v1.3.0 exists in owner/repo but has no published release",
+		},
+		{
+			name: "tag not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesTagsByOwnerByRepoByTag,
+					notFound,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					notFound,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v9.9.9",
+			},
+			expectError:    false,
+			expectedErrMsg: "tag v9.9.9 not found in owner/repo",
+		},
+		{
+			name: "release lookup fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesTagsByOwnerByRepoByTag,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusInternalServerError)
+						_, _ = w.Write([]byte(`{"message": "Server Error"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.2.0",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get release",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetReleaseByTag(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var release map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &release)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRelease, release)
+		})
+	}
+}
+
+func Test_GetLatestRelease(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetLatestRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_latest_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRelease := &github.RepositoryRelease{
+		ID:          github.Ptr(int64(2)),
+		TagName:     github.Ptr("v1.3.0"),
+		Name:        github.Ptr("v1.3.0"),
+		Prerelease:  github.Ptr(false),
+		PublishedAt: &github.Timestamp{Time: time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)},
+		HTMLURL:     github.Ptr("https://github.com/owner/repo/releases/tag/v1.3.0"),
+		Author:      &github.User{Login: github.Ptr("octocat")},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedRelease map[string]interface{}
+	}{
+		{
+			name: "latest release",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposReleasesLatestByOwnerByRepo,
+					mockRelease,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:     false,
+			expectedRelease: releaseSummary(mockRelease),
+		},
+		{
+			name: "no published release",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesLatestByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedErrMsg: "owner/repo has no published release, or doesn't exist",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetLatestRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Compare through JSON, as the release is returned in the shape of get_release_by_tag
+			expected, err := json.Marshal(tc.expectedRelease)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), textContent.Text)
+		})
+	}
+}
+
+func Test_PromoteRelease(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := PromoteRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "promote_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "release_id")
+	assert.Contains(t, tool.InputSchema.Properties, "tag")
+	assert.Contains(t, tool.InputSchema.Properties, "new_name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockPrerelease := &github.RepositoryRelease{
+		ID:         github.Ptr(int64(3)),
+		TagName:    github.Ptr("v1.4.0-rc.1"),
+		Name:       github.Ptr("v1.4.0 RC 1"),
+		Prerelease: github.Ptr(true),
+	}
+	mockPromoted := &github.RepositoryRelease{
+		ID:          github.Ptr(int64(3)),
+		TagName:     github.Ptr("v1.4.0-rc.1"),
+		Name:        github.Ptr("v1.4.0"),
+		Prerelease:  github.Ptr(false),
+		PublishedAt: &github.Timestamp{Time: time.Date(2025, 4, 2, 9, 0, 0, 0, time.UTC)},
+		HTMLURL:     github.Ptr("https://github.com/owner/repo/releases/tag/v1.4.0-rc.1"),
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedPromoted map[string]interface{}
+	}{
+		{
+			name: "promote by tag and rename",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposReleasesTagsByOwnerByRepoByTag,
+					mockPrerelease,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposReleasesByOwnerByRepoByReleaseId,
+					expectRequestBody(t, map[string]interface{}{
+						"name":       "v1.4.0",
+						"draft":      false,
+						"prerelease": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockPromoted),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"tag":      "v1.4.0-rc.1",
+				"new_name": "v1.4.0",
+			},
+			expectError: false,
+			expectedPromoted: map[string]interface{}{
+				"id":           float64(3),
+				"tag_name":     "v1.4.0-rc.1",
+				"name":         "v1.4.0",
+				"prerelease":   false,
+				"published_at": "2025-04-02T09:00:00Z",
+				"html_url":     "https://github.com/owner/repo/releases/tag/v1.4.0-rc.1",
+			},
+		},
+		{
+			name: "promote by id",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposReleasesByOwnerByRepoByReleaseId,
+					mockPrerelease,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposReleasesByOwnerByRepoByReleaseId,
+					expectRequestBody(t, map[string]interface{}{
+						"draft":      false,
+						"prerelease": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockPromoted),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(3),
+			},
+			expectError: false,
+			expectedPromoted: map[string]interface{}{
+				"id":           float64(3),
+				"tag_name":     "v1.4.0-rc.1",
+				"name":         "v1.4.0",
+				"prerelease":   false,
+				"published_at": "2025-04-02T09:00:00Z",
+				"html_url":     "https://github.com/owner/repo/releases/tag/v1.4.0-rc.1",
+			},
+		},
+		{
+			name: "release is not a prerelease",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposReleasesTagsByOwnerByRepoByTag,
+					mockPromoted,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.4.0-rc.1",
+			},
+			expectError:    false,
+			expectedErrMsg: "release v1.4.0-rc.1 of owner/repo is not a prerelease",
+		},
+		{
+			name: "release not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesByOwnerByRepoByReleaseId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(99),
+			},
+			expectError:    false,
+			expectedErrMsg: "release 99 not found in owner/repo",
+		},
+		{
+			name:         "both release_id and tag",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(3),
+				"tag":        "v1.4.0-rc.1",
+			},
+			expectError:    false,
+			expectedErrMsg: "either release_id or tag is required, but not both",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := PromoteRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var promoted map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &promoted)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPromoted, promoted)
+		})
+	}
+}
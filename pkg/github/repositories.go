@@ -1,16 +1,29 @@
 package github
 
 import (
+	"bytes"
+	"cmp"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/github/github-mcp-server/pkg/codeowners"
+	"github.com/github/github-mcp-server/pkg/manifest"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v69/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
 )
 
 func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
@@ -80,10 +93,25 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 		}
 }
 
-// ListCommits creates a tool to get commits of a branch in a repository.
-func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("list_commits",
-			mcp.WithDescription(t("TOOL_LIST_COMMITS_DESCRIPTION", "Get list of commits of a branch in a GitHub repository")),
+// maxPatchBytes caps the size of the patch returned by get_pr_patch and get_commit_patch.
+const maxPatchBytes = 100 * 1024
+
+// truncatePatch cuts a patch down to maxPatchBytes, ending on a whole line. It reports whether anything was cut off.
+func truncatePatch(patch string) (string, bool) {
+	if len(patch) <= maxPatchBytes {
+		return patch, false
+	}
+	patch = patch[:maxPatchBytes]
+	if i := strings.LastIndexByte(patch, '\n'); i >= 0 {
+		patch = patch[:i+1]
+	}
+	return strings.ToValidUTF8(patch, ""), true
+}
+
+// GetCommitPatch creates a tool to get a commit as a patch file.
+func GetCommitPatch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_commit_patch",
+			mcp.WithDescription(t("TOOL_GET_COMMIT_PATCH_DESCRIPTION", "Get a commit from a GitHub repository as an mbox-format patch file, as used by git am. Patches over 100 KB are truncated")),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -93,9 +121,9 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				mcp.Description("Repository name"),
 			),
 			mcp.WithString("sha",
-				mcp.Description("SHA or Branch name"),
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch name, or tag name"),
 			),
-			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -106,42 +134,41 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			sha, err := OptionalParam[string](request, "sha")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pagination, err := OptionalPaginationParams(request)
+			sha, err := requiredParam[string](request, "sha")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			opts := &github.CommitsListOptions{
-				SHA: sha,
-				ListOptions: github.ListOptions{
-					Page:    pagination.page,
-					PerPage: pagination.perPage,
-				},
-			}
-
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
+			patch, resp, err := client.Repositories.GetCommitRaw(ctx, owner, repo, sha, github.RawOptions{Type: github.Patch})
 			if err != nil {
-				return nil, fmt.Errorf("failed to list commits: %w", err)
+				if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity) {
+					return mcp.NewToolResultError(fmt.Sprintf("commit %s not found in %s/%s", sha, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get commit patch: %w", err)
 			}
-			defer func() { _ = resp.Body.Close() }()
+			_ = resp.Body.Close()
 
-			if resp.StatusCode != 200 {
-				body, err := io.ReadAll(resp.Body)
+			truncatedPatch, truncated := truncatePatch(patch)
+			result := map[string]interface{}{
+				"patch":       truncatedPatch,
+				"truncated":   truncated,
+				"total_bytes": len(patch),
+			}
+			if truncated {
+				// Point at the full patch on the web so it can still be downloaded
+				commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, sha, &github.ListOptions{PerPage: 1})
 				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+					return nil, fmt.Errorf("failed to get commit: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list commits: %s", string(body))), nil
+				_ = resp.Body.Close()
+				result["patch_url"] = commit.GetHTMLURL() + ".patch"
 			}
 
-			r, err := json.Marshal(commits)
+			r, err := json.Marshal(result)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -150,10 +177,26 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 		}
 }
 
-// ListBranches creates a tool to list branches in a GitHub repository.
-func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("list_branches",
-			mcp.WithDescription(t("TOOL_LIST_BRANCHES_DESCRIPTION", "List branches in a GitHub repository")),
+// commitSignatureType names the kind of signature a commit carries, from the armor header of the signature.
+func commitSignatureType(signature string) string {
+	switch {
+	case strings.Contains(signature, "-----BEGIN PGP SIGNATURE-----"):
+		return "gpg"
+	case strings.Contains(signature, "-----BEGIN SSH SIGNATURE-----"):
+		return "ssh"
+	case strings.Contains(signature, "-----BEGIN SIGNED MESSAGE-----"):
+		return "x509"
+	case signature != "":
+		return "unknown"
+	default:
+		return ""
+	}
+}
+
+// GetCommitVerification creates a tool to get whether a commit's signature was verified.
+func GetCommitVerification(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_commit_verification",
+			mcp.WithDescription(t("TOOL_GET_COMMIT_VERIFICATION_DESCRIPTION", "Get whether a commit in a GitHub repository is signed and whether GitHub verified the signature, with the reason and the kind of signature (gpg, ssh or x509)")),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -162,7 +205,10 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			WithPagination(),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch name, or tag name"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -173,38 +219,42 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			pagination, err := OptionalPaginationParams(request)
+			sha, err := requiredParam[string](request, "sha")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			opts := &github.BranchListOptions{
-				ListOptions: github.ListOptions{
-					Page:    pagination.page,
-					PerPage: pagination.perPage,
-				},
-			}
-
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-
-			branches, resp, err := client.Repositories.ListBranches(ctx, owner, repo, opts)
+			// Only the commit itself is needed, so fetch as few of its files as possible
+			commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, sha, &github.ListOptions{PerPage: 1})
 			if err != nil {
-				return nil, fmt.Errorf("failed to list branches: %w", err)
+				if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity) {
+					return mcp.NewToolResultError(fmt.Sprintf("commit %s not found in %s/%s", sha, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get commit: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list branches: %s", string(body))), nil
+			verification := commit.GetCommit().GetVerification()
+			reason := verification.GetReason()
+			if reason == "" {
+				reason = "unsigned"
+			}
+			result := map[string]interface{}{
+				"sha":            commit.GetSHA(),
+				"verified":       verification.GetVerified(),
+				"reason":         reason,
+				"signed":         reason != "unsigned",
+				"signature_type": nil,
+			}
+			if signatureType := commitSignatureType(verification.GetSignature()); signatureType != "" {
+				result["signature_type"] = signatureType
 			}
 
-			r, err := json.Marshal(branches)
+			r, err := json.Marshal(result)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -213,37 +263,22 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 		}
 }
 
-// CreateOrUpdateFile creates a tool to create or update a file in a GitHub repository.
-func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("create_or_update_file",
-			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_FILE_DESCRIPTION", "Create or update a single file in a GitHub repository")),
+// ListCommits creates a tool to get commits of a branch in a repository.
+func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_commits",
+			mcp.WithDescription(t("TOOL_LIST_COMMITS_DESCRIPTION", "Get list of commits of a branch in a GitHub repository")),
 			mcp.WithString("owner",
 				mcp.Required(),
-				mcp.Description("Repository owner (username or organization)"),
+				mcp.Description("Repository owner"),
 			),
 			mcp.WithString("repo",
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("path",
-				mcp.Required(),
-				mcp.Description("Path where to create/update the file"),
-			),
-			mcp.WithString("content",
-				mcp.Required(),
-				mcp.Description("Content of the file"),
-			),
-			mcp.WithString("message",
-				mcp.Required(),
-				mcp.Description("Commit message"),
-			),
-			mcp.WithString("branch",
-				mcp.Required(),
-				mcp.Description("Branch to create/update the file in"),
-			),
 			mcp.WithString("sha",
-				mcp.Description("SHA of file being replaced (for updates)"),
+				mcp.Description("SHA or Branch name"),
 			),
+			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -254,62 +289,42 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			path, err := requiredParam[string](request, "path")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			content, err := requiredParam[string](request, "content")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			message, err := requiredParam[string](request, "message")
+			sha, err := OptionalParam[string](request, "sha")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			branch, err := requiredParam[string](request, "branch")
+			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			// Convert content to base64
-			contentBytes := []byte(content)
-
-			// Create the file options
-			opts := &github.RepositoryContentFileOptions{
-				Message: github.Ptr(message),
-				Content: contentBytes,
-				Branch:  github.Ptr(branch),
-			}
-
-			// If SHA is provided, set it (for updates)
-			sha, err := OptionalParam[string](request, "sha")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			if sha != "" {
-				opts.SHA = github.Ptr(sha)
+			opts := &github.CommitsListOptions{
+				SHA: sha,
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
 			}
 
-			// Create or update the file
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			fileContent, resp, err := client.Repositories.CreateFile(ctx, owner, repo, path, opts)
+			commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
 			if err != nil {
-				return nil, fmt.Errorf("failed to create/update file: %w", err)
+				return nil, fmt.Errorf("failed to list commits: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != 200 && resp.StatusCode != 201 {
+			if resp.StatusCode != 200 {
 				body, err := io.ReadAll(resp.Body)
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create/update file: %s", string(body))), nil
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list commits: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(fileContent)
+			r, err := json.Marshal(commits)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -318,94 +333,137 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 		}
 }
 
-// CreateRepository creates a tool to create a new GitHub repository.
-func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("create_repository",
-			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_DESCRIPTION", "Create a new GitHub repository in your account")),
-			mcp.WithString("name",
+// maxCommitsSinceScan is how many commits list_commits_since walks looking for the since commit.
+const maxCommitsSinceScan = 500
+
+// ListCommitsSince creates a tool to list the commits of a ref made after a given commit.
+func ListCommitsSince(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_commits_since",
+			mcp.WithDescription(t("TOOL_LIST_COMMITS_SINCE_DESCRIPTION", fmt.Sprintf("List the commits of a branch or tag made after a given commit, newest first, up to %d commits", maxCommitsSinceScan))),
+			mcp.WithString("owner",
 				mcp.Required(),
-				mcp.Description("Repository name"),
+				mcp.Description("Repository owner"),
 			),
-			mcp.WithString("description",
-				mcp.Description("Repository description"),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
 			),
-			mcp.WithBoolean("private",
-				mcp.Description("Whether repo should be private"),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to list commits of. Defaults to the default branch"),
 			),
-			mcp.WithBoolean("autoInit",
-				mcp.Description("Initialize with README"),
+			mcp.WithString("since",
+				mcp.Required(),
+				mcp.Description("SHA of the commit to list the commits after, full or abbreviated to at least 7 characters. It isn't listed itself"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			name, err := requiredParam[string](request, "name")
+			owner, err := requiredParam[string](request, "owner")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			description, err := OptionalParam[string](request, "description")
+			repo, err := requiredParam[string](request, "repo")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			private, err := OptionalParam[bool](request, "private")
+			ref, err := OptionalParam[string](request, "ref")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			autoInit, err := OptionalParam[bool](request, "autoInit")
+			since, err := requiredParam[string](request, "since")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			if len(since) < 7 {
+				return mcp.NewToolResultError("since must be a commit SHA of at least 7 characters"), nil
+			}
+			since = strings.ToLower(since)
 
-			repo := &github.Repository{
-				Name:        github.Ptr(name),
-				Description: github.Ptr(description),
-				Private:     github.Ptr(private),
-				AutoInit:    github.Ptr(autoInit),
+			refName := ref
+			if refName == "" {
+				refName = "the default branch"
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			createdRepo, resp, err := client.Repositories.Create(ctx, "", repo)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create repository: %w", err)
-			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
+			result := []map[string]interface{}{}
+			opts := &github.CommitsListOptions{SHA: ref, ListOptions: github.ListOptions{PerPage: 100}}
+			for {
+				commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
 				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s or ref %s not found", owner, repo, refName)), nil
+					}
+					if resp != nil && resp.StatusCode == http.StatusConflict {
+						return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s is empty", owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to list commits: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create repository: %s", string(body))), nil
-			}
+				_ = resp.Body.Close()
 
-			r, err := json.Marshal(createdRepo)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
+				for _, commit := range commits {
+					if strings.HasPrefix(commit.GetSHA(), since) {
+						r, err := json.Marshal(map[string]interface{}{
+							"since":   commit.GetSHA(),
+							"commits": result,
+							"count":   len(result),
+						})
+						if err != nil {
+							return nil, fmt.Errorf("failed to marshal response: %w", err)
+						}
+						return mcp.NewToolResultText(string(r)), nil
+					}
+					if len(result) == maxCommitsSinceScan {
+						return mcp.NewToolResultError(fmt.Sprintf("commit %s not found in the last %d commits of %s: the history gap exceeds the cap", since, maxCommitsSinceScan, refName)), nil
+					}
 
-			return mcp.NewToolResultText(string(r)), nil
+					message, _, _ := strings.Cut(commit.GetCommit().GetMessage(), "\n")
+					author := commit.GetCommit().GetAuthor()
+					var authoredAt *github.Timestamp
+					if author != nil {
+						authoredAt = author.Date
+					}
+					result = append(result, map[string]interface{}{
+						"sha":     commit.GetSHA(),
+						"message": message,
+						"author": map[string]interface{}{
+							"name":  author.GetName(),
+							"email": author.GetEmail(),
+							"date":  authoredAt,
+						},
+						"html_url": commit.GetHTMLURL(),
+					})
+				}
+				if resp.NextPage == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("commit %s is not in the history of %s", since, refName)), nil
+				}
+				opts.Page = resp.NextPage
+			}
 		}
 }
 
-// GetFileContents creates a tool to get the contents of a file or directory from a GitHub repository.
-func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("get_file_contents",
-			mcp.WithDescription(t("TOOL_GET_FILE_CONTENTS_DESCRIPTION", "Get the contents of a file or directory from a GitHub repository")),
+// CommitTouches creates a tool to check which of a set of globs the files changed by a commit match.
+func CommitTouches(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("commit_touches",
+			mcp.WithDescription(t("TOOL_COMMIT_TOUCHES_DESCRIPTION", "Check whether a commit changed files matching each of a list of globs, such as '*.sql', and which of its files match. Globs follow the CODEOWNERS pattern syntax: a glob without a slash matches at any depth, a leading slash anchors it to the repository root, and a directory matches everything below it. A renamed file matches by its old path too")),
 			mcp.WithString("owner",
 				mcp.Required(),
-				mcp.Description("Repository owner (username or organization)"),
+				mcp.Description("Repository owner"),
 			),
 			mcp.WithString("repo",
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("path",
+			mcp.WithString("sha",
 				mcp.Required(),
-				mcp.Description("Path to file/directory"),
+				mcp.Description("Commit SHA, branch name, or tag name"),
 			),
-			mcp.WithString("branch",
-				mcp.Description("Branch to get contents from"),
+			mcp.WithArray("globs",
+				mcp.Required(),
+				mcp.Description("Globs to check the changed files against, in CODEOWNERS pattern syntax"),
+				mcp.Items(map[string]interface{}{"type": "string"}),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -417,42 +475,80 @@ func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			path, err := requiredParam[string](request, "path")
+			sha, err := requiredParam[string](request, "sha")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			branch, err := OptionalParam[string](request, "branch")
+			globs, err := OptionalStringArrayParam(request, "globs")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			if len(globs) == 0 {
+				return mcp.NewToolResultError("missing required parameter: globs"), nil
+			}
+			// Reject invalid globs before fetching anything
+			for _, glob := range globs {
+				if _, err := codeowners.MatchPattern(glob, ""); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			opts := &github.RepositoryContentGetOptions{Ref: branch}
-			fileContent, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get file contents: %w", err)
-			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != 200 {
-				body, err := io.ReadAll(resp.Body)
+			var files []*github.CommitFile
+			opts := &github.ListOptions{PerPage: 100}
+			for {
+				commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, sha, opts)
 				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+					if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity) {
+						return mcp.NewToolResultError(fmt.Sprintf("commit %s not found in %s/%s", sha, owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to get commit: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get file contents: %s", string(body))), nil
+				_ = resp.Body.Close()
+				files = append(files, commit.Files...)
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
 			}
 
-			var result interface{}
-			if fileContent != nil {
-				result = fileContent
-			} else {
-				result = dirContent
+			matches := make([]map[string]interface{}, 0, len(globs))
+			touchedAny := false
+			for _, glob := range globs {
+				paths := []string{}
+				for _, f := range files {
+					for _, path := range []string{f.GetFilename(), f.GetPreviousFilename()} {
+						if path == "" {
+							continue
+						}
+						matched, err := codeowners.MatchPattern(glob, path)
+						if err != nil {
+							return mcp.NewToolResultError(err.Error()), nil
+						}
+						if matched {
+							paths = append(paths, path)
+							break
+						}
+					}
+				}
+				touchedAny = touchedAny || len(paths) > 0
+				matches = append(matches, map[string]interface{}{
+					"glob":    glob,
+					"touched": len(paths) > 0,
+					"paths":   paths,
+				})
 			}
 
-			r, err := json.Marshal(result)
+			r, err := json.Marshal(map[string]interface{}{
+				"sha":           sha,
+				"files_changed": len(files),
+				"touched":       touchedAny,
+				"globs":         matches,
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -461,10 +557,10 @@ func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc
 		}
 }
 
-// ForkRepository creates a tool to fork a repository.
-func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("fork_repository",
-			mcp.WithDescription(t("TOOL_FORK_REPOSITORY_DESCRIPTION", "Fork a GitHub repository to your account or specified organization")),
+// GetLatestCommitForFile creates a tool to get the last commit that changed a file.
+func GetLatestCommitForFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_latest_commit_for_file",
+			mcp.WithDescription(t("TOOL_GET_LATEST_COMMIT_FOR_FILE_DESCRIPTION", "Get the last commit that changed a file, with its author, dates and the number of days since it was authored")),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -473,8 +569,12 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("organization",
-				mcp.Description("Organization to fork to"),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path to the file"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to look from. Defaults to the default branch"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -486,40 +586,69 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			org, err := OptionalParam[string](request, "organization")
+			path, err := requiredParam[string](request, "path")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			opts := &github.RepositoryCreateForkOptions{}
-			if org != "" {
-				opts.Organization = org
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			forkedRepo, resp, err := client.Repositories.CreateFork(ctx, owner, repo, opts)
+			commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, &github.CommitsListOptions{
+				SHA:         ref,
+				Path:        path,
+				ListOptions: github.ListOptions{PerPage: 1},
+			})
 			if err != nil {
-				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
-				// and it's not a real error.
-				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
-					return mcp.NewToolResultText("Fork is in progress"), nil
-				}
-				return nil, fmt.Errorf("failed to fork repository: %w", err)
+				return nil, fmt.Errorf("failed to list commits: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusAccepted {
+			if resp.StatusCode != http.StatusOK {
 				body, err := io.ReadAll(resp.Body)
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to fork repository: %s", string(body))), nil
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list commits: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(forkedRepo)
+			// A path no commit touched doesn't exist at the ref
+			if len(commits) == 0 {
+				if ref == "" {
+					return mcp.NewToolResultError(fmt.Sprintf("file not found: %s in %s/%s", path, owner, repo)), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("file not found: %s in %s/%s at %s", path, owner, repo, ref)), nil
+			}
+
+			commit := commits[0]
+			sha := commit.GetSHA()
+			message, _, _ := strings.Cut(commit.GetCommit().GetMessage(), "\n")
+			author := commit.GetCommit().GetAuthor()
+			result := map[string]interface{}{
+				"sha":       sha,
+				"short_sha": sha[:min(7, len(sha))],
+				"message":   message,
+				"author": map[string]interface{}{
+					"name":  author.GetName(),
+					"email": author.GetEmail(),
+					"date":  author.Date,
+				},
+				"committer": map[string]interface{}{
+					"date": commit.GetCommit().GetCommitter().Date,
+				},
+				"html_url": commit.GetHTMLURL(),
+				"age_days": nil,
+			}
+			if author.Date != nil {
+				result["age_days"] = int(time.Since(author.Date.Time).Hours() / 24)
+			}
+
+			r, err := json.Marshal(result)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -528,10 +657,10 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 		}
 }
 
-// CreateBranch creates a tool to create a new branch.
-func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("create_branch",
-			mcp.WithDescription(t("TOOL_CREATE_BRANCH_DESCRIPTION", "Create a new branch in a GitHub repository")),
+// ListBranches creates a tool to list branches in a GitHub repository.
+func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_branches",
+			mcp.WithDescription(t("TOOL_LIST_BRANCHES_DESCRIPTION", "List branches in a GitHub repository")),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -540,13 +669,7 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("branch",
-				mcp.Required(),
-				mcp.Description("Name for new branch"),
-			),
-			mcp.WithString("from_branch",
-				mcp.Description("Source branch (defaults to repo default)"),
-			),
+			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -557,13 +680,16 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			branch, err := requiredParam[string](request, "branch")
+			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			fromBranch, err := OptionalParam[string](request, "from_branch")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+
+			opts := &github.BranchListOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
 			}
 
 			client, err := getClient(ctx)
@@ -571,40 +697,21 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// Get the source branch SHA
-			var ref *github.Reference
-
-			if fromBranch == "" {
-				// Get default branch if from_branch not specified
-				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
-				if err != nil {
-					return nil, fmt.Errorf("failed to get repository: %w", err)
-				}
-				defer func() { _ = resp.Body.Close() }()
-
-				fromBranch = *repository.DefaultBranch
-			}
-
-			// Get SHA of source branch
-			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+fromBranch)
+			branches, resp, err := client.Repositories.ListBranches(ctx, owner, repo, opts)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get reference: %w", err)
+				return nil, fmt.Errorf("failed to list branches: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			// Create new branch
-			newRef := &github.Reference{
-				Ref:    github.Ptr("refs/heads/" + branch),
-				Object: &github.GitObject{SHA: ref.Object.SHA},
-			}
-
-			createdRef, resp, err := client.Git.CreateRef(ctx, owner, repo, newRef)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create branch: %w", err)
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list branches: %s", string(body))), nil
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(createdRef)
+			r, err := json.Marshal(branches)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -613,46 +720,37 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 		}
 }
 
-// PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
-func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("push_files",
-			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit")),
+// CreateOrUpdateFile creates a tool to create or update a file in a GitHub repository.
+func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_or_update_file",
+			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_FILE_DESCRIPTION", "Create or update a single file in a GitHub repository")),
 			mcp.WithString("owner",
 				mcp.Required(),
-				mcp.Description("Repository owner"),
+				mcp.Description("Repository owner (username or organization)"),
 			),
 			mcp.WithString("repo",
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("branch",
+			mcp.WithString("path",
 				mcp.Required(),
-				mcp.Description("Branch to push to"),
+				mcp.Description("Path where to create/update the file"),
 			),
-			mcp.WithArray("files",
+			mcp.WithString("content",
 				mcp.Required(),
-				mcp.Items(
-					map[string]interface{}{
-						"type":                 "object",
-						"additionalProperties": false,
-						"required":             []string{"path", "content"},
-						"properties": map[string]interface{}{
-							"path": map[string]interface{}{
-								"type":        "string",
-								"description": "path to the file",
-							},
-							"content": map[string]interface{}{
-								"type":        "string",
-								"description": "file content",
-							},
-						},
-					}),
-				mcp.Description("Array of file objects to push, each object with path (string) and content (string)"),
+				mcp.Description("Content of the file"),
 			),
 			mcp.WithString("message",
 				mcp.Required(),
 				mcp.Description("Commit message"),
 			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to create/update the file in"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("SHA of file being replaced (for updates)"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -663,7 +761,11 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			branch, err := requiredParam[string](request, "branch")
+			path, err := requiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := requiredParam[string](request, "content")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -671,86 +773,967 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			branch, err := requiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
-			// Parse files parameter - this should be an array of objects with path and content
-			filesObj, ok := request.Params.Arguments["files"].([]interface{})
-			if !ok {
-				return mcp.NewToolResultError("files parameter must be an array of objects with path and content"), nil
+			// Convert content to base64
+			contentBytes := []byte(content)
+
+			// Create the file options
+			opts := &github.RepositoryContentFileOptions{
+				Message: github.Ptr(message),
+				Content: contentBytes,
+				Branch:  github.Ptr(branch),
 			}
 
-			client, err := getClient(ctx)
+			// If SHA is provided, set it (for updates)
+			sha, err := OptionalParam[string](request, "sha")
 			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if sha != "" {
+				opts.SHA = github.Ptr(sha)
 			}
 
-			// Get the reference for the branch
-			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			// Create or update the file
+			client, err := getClient(ctx)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get branch reference: %w", err)
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			defer func() { _ = resp.Body.Close() }()
-
-			// Get the commit object that the branch points to
-			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
+			fileContent, resp, err := client.Repositories.CreateFile(ctx, owner, repo, path, opts)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get base commit: %w", err)
+				return nil, fmt.Errorf("failed to create/update file: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			// Create tree entries for all files
-			var entries []*github.TreeEntry
-
-			for _, file := range filesObj {
-				fileMap, ok := file.(map[string]interface{})
-				if !ok {
-					return mcp.NewToolResultError("each file must be an object with path and content"), nil
+			if resp.StatusCode != 200 && resp.StatusCode != 201 {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create/update file: %s", string(body))), nil
+			}
 
-				path, ok := fileMap["path"].(string)
-				if !ok || path == "" {
-					return mcp.NewToolResultError("each file must have a path"), nil
-				}
+			r, err := json.Marshal(fileContent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
 
-				content, ok := fileMap["content"].(string)
-				if !ok {
-					return mcp.NewToolResultError("each file must have content"), nil
-				}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
 
-				// Create a tree entry for the file
-				entries = append(entries, &github.TreeEntry{
-					Path:    github.Ptr(path),
-					Mode:    github.Ptr("100644"), // Regular file mode
-					Type:    github.Ptr("blob"),
-					Content: github.Ptr(content),
-				})
+// CreateRepository creates a tool to create a new GitHub repository.
+func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_repository",
+			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_DESCRIPTION", "Create a new GitHub repository in your account or in an organization")),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("org",
+				mcp.Description("Organization to create the repository in, defaults to the authenticated user's account"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Repository description"),
+			),
+			mcp.WithBoolean("private",
+				mcp.Description("Whether repo should be private"),
+			),
+			mcp.WithBoolean("autoInit",
+				mcp.Description("Initialize with README"),
+			),
+			mcp.WithString("gitignoreTemplate",
+				mcp.Description("Name of the .gitignore template to apply, e.g. 'Go'"),
+			),
+			mcp.WithString("licenseTemplate",
+				mcp.Description("Keyword of the open source license template to apply, e.g. 'mit'"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			// Create a new tree with the file entries
-			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, entries)
+			org, err := OptionalParam[string](request, "org")
 			if err != nil {
-				return nil, fmt.Errorf("failed to create tree: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-			defer func() { _ = resp.Body.Close() }()
-
-			// Create a new commit
-			commit := &github.Commit{
-				Message: github.Ptr(message),
-				Tree:    newTree,
-				Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-			newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+			private, err := OptionalParam[bool](request, "private")
 			if err != nil {
-				return nil, fmt.Errorf("failed to create commit: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-			defer func() { _ = resp.Body.Close() }()
-
-			// Update the reference to point to the new commit
-			ref.Object.SHA = newCommit.SHA
-			updatedRef, resp, err := client.Git.UpdateRef(ctx, owner, repo, ref, false)
+			autoInit, err := OptionalParam[bool](request, "autoInit")
 			if err != nil {
-				return nil, fmt.Errorf("failed to update reference: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			gitignoreTemplate, err := OptionalParam[string](request, "gitignoreTemplate")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			licenseTemplate, err := OptionalParam[string](request, "licenseTemplate")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-			defer func() { _ = resp.Body.Close() }()
+
+			repo := &github.Repository{
+				Name:        github.Ptr(name),
+				Description: github.Ptr(description),
+				Private:     github.Ptr(private),
+				AutoInit:    github.Ptr(autoInit),
+			}
+			if gitignoreTemplate != "" {
+				repo.GitignoreTemplate = github.Ptr(gitignoreTemplate)
+			}
+			if licenseTemplate != "" {
+				repo.LicenseTemplate = github.Ptr(licenseTemplate)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			// An empty org creates the repository for the authenticated user
+			createdRepo, resp, err := client.Repositories.Create(ctx, org, repo)
+			if err != nil {
+				if isRepositoryNameConflict(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("repository already exists: %s", name)), nil
+				}
+				return nil, fmt.Errorf("failed to create repository: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create repository: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(createdRepo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// isRepositoryNameConflict checks if the error is the validation failure returned
+// when a repository with the same name already exists for the owner.
+func isRepositoryNameConflict(err error) bool {
+	var errorResponse *github.ErrorResponse
+	if !errors.As(err, &errorResponse) || !isGitHubErrorStatus(err, http.StatusUnprocessableEntity) {
+		return false
+	}
+	for _, e := range errorResponse.Errors {
+		if e.Field == "name" && strings.Contains(e.Message, "already exists") {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateRepositoryFromTemplate creates a tool to create a new repository from a template repository.
+func CreateRepositoryFromTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_repository_from_template",
+			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_FROM_TEMPLATE_DESCRIPTION", "Create a new GitHub repository from a template repository")),
+			mcp.WithString("template_owner",
+				mcp.Required(),
+				mcp.Description("Owner of the template repository"),
+			),
+			mcp.WithString("template_repo",
+				mcp.Required(),
+				mcp.Description("Name of the template repository"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the new repository"),
+			),
+			mcp.WithString("owner",
+				mcp.Description("User or organization to own the new repository, defaults to the authenticated user"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Repository description"),
+			),
+			mcp.WithBoolean("private",
+				mcp.Description("Whether the new repository should be private"),
+			),
+			mcp.WithBoolean("include_all_branches",
+				mcp.Description("Include all branches of the template, not just the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			templateOwner, err := requiredParam[string](request, "template_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			templateRepo, err := requiredParam[string](request, "template_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			private, err := OptionalParam[bool](request, "private")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeAllBranches, err := OptionalParam[bool](request, "include_all_branches")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			templateRequest := &github.TemplateRepoRequest{
+				Name:               github.Ptr(name),
+				Private:            github.Ptr(private),
+				IncludeAllBranches: github.Ptr(includeAllBranches),
+			}
+			if owner != "" {
+				templateRequest.Owner = github.Ptr(owner)
+			}
+			if description != "" {
+				templateRequest.Description = github.Ptr(description)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			createdRepo, resp, err := client.Repositories.CreateFromTemplate(ctx, templateOwner, templateRepo, templateRequest)
+			if err != nil {
+				if isRepositoryNameConflict(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("repository already exists: %s", name)), nil
+				}
+				if isGitHubErrorStatus(err, http.StatusUnprocessableEntity) {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot create repository from template %s/%s: the template is not accessible to the authenticated user or is not marked as a template: %s", templateOwner, templateRepo, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to create repository from template: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create repository from template: %s", string(body))), nil
+			}
+
+			result := map[string]interface{}{
+				"full_name":  createdRepo.GetFullName(),
+				"html_url":   createdRepo.GetHTMLURL(),
+				"clone_url":  createdRepo.GetCloneURL(),
+				"ssh_url":    createdRepo.GetSSHURL(),
+				"created_at": createdRepo.GetCreatedAt(),
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListTemplateRepositories creates a tool to list the template repositories of a user or organization.
+func ListTemplateRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_template_repositories",
+			mcp.WithDescription(t("TOOL_LIST_TEMPLATE_REPOSITORIES_DESCRIPTION", "List the template repositories owned by a user or organization")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("User or organization that owns the templates"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.SearchOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			// The user qualifier matches repositories owned by organizations as well
+			query := fmt.Sprintf("user:%s template:true", owner)
+			result, resp, err := client.Search.Repositories(ctx, query, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to search template repositories: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to search template repositories: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetFileContents creates a tool to get the contents of a file or directory from a GitHub repository.
+func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_file_contents",
+			mcp.WithDescription(t("TOOL_GET_FILE_CONTENTS_DESCRIPTION", "Get the contents of a file or directory from a GitHub repository")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path to file/directory"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch to get contents from"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := requiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			opts := &github.RepositoryContentGetOptions{Ref: branch}
+			fileContent, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get file contents: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != 200 {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get file contents: %s", string(body))), nil
+			}
+
+			var result interface{}
+			if fileContent != nil {
+				result = fileContent
+			} else {
+				result = dirContent
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// maxFileRefs bounds the number of refs get_file_at_refs reads a file at in one call.
+const maxFileRefs = 20
+
+// fileAtRef is the content of a file at a single ref, or why it couldn't be read.
+type fileAtRef struct {
+	Ref     string  `json:"ref"`
+	Found   bool    `json:"found"`
+	SHA     string  `json:"sha,omitempty"`
+	Size    int     `json:"size,omitempty"`
+	Content *string `json:"content,omitempty"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// getFileAtRef reads a file at a single ref. A missing file or ref is reported as not found rather than an error.
+func getFileAtRef(ctx context.Context, client *github.Client, owner, repo, path, ref string) fileAtRef {
+	result := fileAtRef{Ref: ref}
+	fileContent, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+	if err != nil {
+		if isGitHubErrorStatus(err, http.StatusNotFound) {
+			result.Error = "not found"
+		} else {
+			result.Error = err.Error()
+		}
+		return result
+	}
+	_ = resp.Body.Close()
+
+	if fileContent == nil {
+		if dirContent != nil {
+			result.Error = "path is a directory"
+		} else {
+			result.Error = "no content returned"
+		}
+		return result
+	}
+
+	content, err := fileContent.GetContent()
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to decode content: %s", err)
+		return result
+	}
+	result.Found = true
+	result.SHA = fileContent.GetSHA()
+	result.Size = fileContent.GetSize()
+	result.Content = &content
+	return result
+}
+
+// GetFileAtRefs creates a tool to get the contents of a file at several refs at once.
+func GetFileAtRefs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_file_at_refs",
+			mcp.WithDescription(t("TOOL_GET_FILE_AT_REFS_DESCRIPTION", "Get the contents of a file at several branches, tags or commits in one call, for example to compare a config across environments. Refs the file doesn't exist at are reported as not found")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path to the file"),
+			),
+			mcp.WithArray("refs",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Branches, tags or commit SHAs to read the file at (max %d)", maxFileRefs)),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := requiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			refs, err := OptionalStringArrayParam(request, "refs")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(refs) == 0 {
+				return mcp.NewToolResultError("missing required parameter: refs"), nil
+			}
+			if len(refs) > maxFileRefs {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d refs can be read at once, got %d", maxFileRefs, len(refs))), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			results := make([]fileAtRef, len(refs))
+			errs := forEachConcurrently(ctx, refs, func(ctx context.Context, i int, ref string) error {
+				results[i] = getFileAtRef(ctx, client, owner, repo, path, ref)
+				return nil
+			})
+			for i, err := range errs {
+				// Only refs still waiting when the context is done fail as a whole
+				if err != nil {
+					results[i] = fileAtRef{Ref: refs[i], Error: err.Error()}
+				}
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"path":  path,
+				"files": results,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ForkRepository creates a tool to fork a repository.
+func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("fork_repository",
+			mcp.WithDescription(t("TOOL_FORK_REPOSITORY_DESCRIPTION", "Fork a GitHub repository to your account or specified organization")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("organization",
+				mcp.Description("Organization to fork to"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			org, err := OptionalParam[string](request, "organization")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.RepositoryCreateForkOptions{}
+			if org != "" {
+				opts.Organization = org
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			forkedRepo, resp, err := client.Repositories.CreateFork(ctx, owner, repo, opts)
+			if err != nil {
+				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
+				// and it's not a real error.
+				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
+					return mcp.NewToolResultText("Fork is in progress"), nil
+				}
+				return nil, fmt.Errorf("failed to fork repository: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusAccepted {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to fork repository: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(forkedRepo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateBranch creates a tool to create a new branch.
+func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_branch",
+			mcp.WithDescription(t("TOOL_CREATE_BRANCH_DESCRIPTION", "Create a new branch in a GitHub repository")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Name for new branch"),
+			),
+			mcp.WithString("from_branch",
+				mcp.Description("Source branch (defaults to repo default)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := requiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fromBranch, err := OptionalParam[string](request, "from_branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Get the source branch SHA
+			var ref *github.Reference
+
+			if fromBranch == "" {
+				// Get default branch if from_branch not specified
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				fromBranch = *repository.DefaultBranch
+			}
+
+			// Get SHA of source branch
+			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+fromBranch)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get reference: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Create new branch
+			newRef := &github.Reference{
+				Ref:    github.Ptr("refs/heads/" + branch),
+				Object: &github.GitObject{SHA: ref.Object.SHA},
+			}
+
+			createdRef, resp, err := client.Git.CreateRef(ctx, owner, repo, newRef)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create branch: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(createdRef)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// isRefAlreadyExists checks if the error is the validation failure returned when creating a ref that already exists.
+func isRefAlreadyExists(err error) bool {
+	var errorResponse *github.ErrorResponse
+	if !errors.As(err, &errorResponse) || !isGitHubErrorStatus(err, http.StatusUnprocessableEntity) {
+		return false
+	}
+	return strings.Contains(errorResponse.Message, "already exists")
+}
+
+// CreateTag creates a tool to create a tag, annotated when it has a message.
+func CreateTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_tag",
+			mcp.WithDescription(t("TOOL_CREATE_TAG_DESCRIPTION", "Create a tag pointing at a commit of a GitHub repository. The tag is annotated when it has a message, and lightweight otherwise")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Name of the tag, such as v1.2.0"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit to tag"),
+			),
+			mcp.WithString("message",
+				mcp.Description("Message of the tag, which makes it an annotated tag"),
+			),
+			mcp.WithString("tagger_name",
+				mcp.Description("Name of the author of an annotated tag, defaults to the authenticated user. Requires tagger_email"),
+			),
+			mcp.WithString("tagger_email",
+				mcp.Description("Email of the author of an annotated tag. Requires tagger_name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := requiredParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := requiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := OptionalParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			taggerName, err := OptionalParam[string](request, "tagger_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			taggerEmail, err := OptionalParam[string](request, "tagger_email")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			switch {
+			case (taggerName == "") != (taggerEmail == ""):
+				return mcp.NewToolResultError("tagger_name and tagger_email must be given together"), nil
+			case taggerName != "" && message == "":
+				return mcp.NewToolResultError("a tagger can only be given with a message, for an annotated tag"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// An annotated tag is a tag object the ref points at, while a lightweight tag points at the commit
+			refSHA := sha
+			if message != "" {
+				tagObject := &github.Tag{
+					Tag:     github.Ptr(tag),
+					Message: github.Ptr(message),
+					Object:  &github.GitObject{Type: github.Ptr("commit"), SHA: github.Ptr(sha)},
+				}
+				if taggerName != "" {
+					tagObject.Tagger = &github.CommitAuthor{Name: github.Ptr(taggerName), Email: github.Ptr(taggerEmail)}
+				}
+				created, resp, err := client.Git.CreateTag(ctx, owner, repo, tagObject)
+				if err != nil {
+					if isGitHubErrorStatus(err, http.StatusUnprocessableEntity) {
+						return mcp.NewToolResultError(fmt.Sprintf("cannot create tag %s in %s/%s: %s", tag, owner, repo, err.Error())), nil
+					}
+					return nil, fmt.Errorf("failed to create tag object: %w", err)
+				}
+				_ = resp.Body.Close()
+				refSHA = created.GetSHA()
+			}
+
+			ref, resp, err := client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr("refs/tags/" + tag),
+				Object: &github.GitObject{SHA: github.Ptr(refSHA)},
+			})
+			if err != nil {
+				if isRefAlreadyExists(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("tag %s already exists in %s/%s", tag, owner, repo)), nil
+				}
+				if isGitHubErrorStatus(err, http.StatusUnprocessableEntity) {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot create tag %s in %s/%s: %s", tag, owner, repo, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to create tag: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]interface{}{
+				"tag":       tag,
+				"ref":       ref.GetRef(),
+				"sha":       sha,
+				"annotated": message != "",
+			}
+			if message != "" {
+				result["tag_sha"] = refSHA
+			}
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
+func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("push_files",
+			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to push to"),
+			),
+			mcp.WithArray("files",
+				mcp.Required(),
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"path", "content"},
+						"properties": map[string]interface{}{
+							"path": map[string]interface{}{
+								"type":        "string",
+								"description": "path to the file",
+							},
+							"content": map[string]interface{}{
+								"type":        "string",
+								"description": "file content",
+							},
+						},
+					}),
+				mcp.Description("Array of file objects to push, each object with path (string) and content (string)"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := requiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := requiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Parse files parameter - this should be an array of objects with path and content
+			filesObj, ok := request.Params.Arguments["files"].([]interface{})
+			if !ok {
+				return mcp.NewToolResultError("files parameter must be an array of objects with path and content"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Get the reference for the branch
+			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get branch reference: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Get the commit object that the branch points to
+			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get base commit: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Create tree entries for all files
+			var entries []*github.TreeEntry
+
+			for _, file := range filesObj {
+				fileMap, ok := file.(map[string]interface{})
+				if !ok {
+					return mcp.NewToolResultError("each file must be an object with path and content"), nil
+				}
+
+				path, ok := fileMap["path"].(string)
+				if !ok || path == "" {
+					return mcp.NewToolResultError("each file must have a path"), nil
+				}
+
+				content, ok := fileMap["content"].(string)
+				if !ok {
+					return mcp.NewToolResultError("each file must have content"), nil
+				}
+
+				// Create a tree entry for the file
+				entries = append(entries, &github.TreeEntry{
+					Path:    github.Ptr(path),
+					Mode:    github.Ptr("100644"), // Regular file mode
+					Type:    github.Ptr("blob"),
+					Content: github.Ptr(content),
+				})
+			}
+
+			// Create a new tree with the file entries
+			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, entries)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create tree: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Create a new commit
+			commit := &github.Commit{
+				Message: github.Ptr(message),
+				Tree:    newTree,
+				Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+			}
+			newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create commit: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Update the reference to point to the new commit
+			ref.Object.SHA = newCommit.SHA
+			updatedRef, resp, err := client.Git.UpdateRef(ctx, owner, repo, ref, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update reference: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
 
 			r, err := json.Marshal(updatedRef)
 			if err != nil {
@@ -760,3 +1743,2358 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// GetForkParent creates a tool to get the upstream repositories of a fork.
+func GetForkParent(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_fork_parent",
+			mcp.WithDescription(t("TOOL_GET_FORK_PARENT_DESCRIPTION", "Get the immediate parent and the ultimate source repository of a fork")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get repository: %s", string(body))), nil
+			}
+
+			result := map[string]interface{}{
+				"fork": repository.GetFork(),
+			}
+			if repository.GetFork() {
+				// The API resolves the whole fork network, so the root is available without walking the chain
+				result["parent"] = repository.GetParent().GetFullName()
+				result["source"] = repository.GetSource().GetFullName()
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// repoPermissionLevels are the permission flags of a repository, from the highest level to the lowest,
+// with the name of the level each grants.
+var repoPermissionLevels = []struct {
+	flag  string
+	level string
+}{
+	{"admin", "admin"},
+	{"maintain", "maintain"},
+	{"push", "write"},
+	{"triage", "triage"},
+	{"pull", "read"},
+}
+
+// GetMyRepoPermission creates a tool to get the permission the authenticated user has on a repository.
+func GetMyRepoPermission(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_my_repo_permission",
+			mcp.WithDescription(t("TOOL_GET_MY_REPO_PERMISSION_DESCRIPTION", "Get the permission the authenticated token has on a repository: admin, maintain, write, triage, read, or none for a repository it can't see")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				// Private repositories the token can't access are indistinguishable from missing ones
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultText(`{"permission":"none"}`), nil
+				}
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Repositories seen without authentication come without permissions, but can still be read
+			permission := "read"
+			for _, p := range repoPermissionLevels {
+				if repository.GetPermissions()[p.flag] {
+					permission = p.level
+					break
+				}
+			}
+
+			r, err := json.Marshal(map[string]interface{}{"permission": permission})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// fundingFilePath is where GitHub looks for a repository's funding configuration.
+const fundingFilePath = ".github/FUNDING.yml"
+
+// parseFundingFile parses a FUNDING.yml file into the handles configured for each platform.
+// Platforms accept either a single handle or a list of handles; empty entries are dropped.
+func parseFundingFile(content string) (map[string][]string, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", fundingFilePath, err)
+	}
+
+	funding := map[string][]string{}
+	for platform, value := range raw {
+		var handles []string
+		switch v := value.(type) {
+		case string:
+			handles = append(handles, v)
+		case []interface{}:
+			for _, handle := range v {
+				if s, ok := handle.(string); ok {
+					handles = append(handles, s)
+				}
+			}
+		}
+		handles = slices.DeleteFunc(handles, func(s string) bool { return strings.TrimSpace(s) == "" })
+		if len(handles) > 0 {
+			funding[platform] = handles
+		}
+	}
+	return funding, nil
+}
+
+// GetFunding creates a tool to get the funding configuration of a repository.
+func GetFunding(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_funding",
+			mcp.WithDescription(t("TOOL_GET_FUNDING_DESCRIPTION", "Get the funding platforms and handles configured in a repository's .github/FUNDING.yml")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, fundingFilePath, nil)
+			if err != nil {
+				// A repository without a funding file simply isn't configured for funding
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultText(`{"funding":null}`), nil
+				}
+				return nil, fmt.Errorf("failed to get funding file: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK || fileContent == nil {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get funding file: %s", string(body))), nil
+			}
+
+			content, err := fileContent.GetContent()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode funding file: %w", err)
+			}
+			funding, err := parseFundingFile(content)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			r, err := json.Marshal(map[string]interface{}{"funding": funding})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// getFirstFile fetches the first of paths that exists in a repository at ref, or at the default branch if
+// ref is empty. It returns an empty path if none of them does.
+func getFirstFile(ctx context.Context, client *github.Client, owner, repo, ref string, paths []string) (string, string, error) {
+	var opts *github.RepositoryContentGetOptions
+	if ref != "" {
+		opts = &github.RepositoryContentGetOptions{Ref: ref}
+	}
+	for _, path := range paths {
+		fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
+		if err != nil {
+			if isGitHubErrorStatus(err, http.StatusNotFound) {
+				continue
+			}
+			return "", "", fmt.Errorf("failed to get %s: %w", path, err)
+		}
+		_ = resp.Body.Close()
+		if fileContent == nil {
+			continue
+		}
+
+		content, err := fileContent.GetContent()
+		if err != nil {
+			return "", "", fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		return path, content, nil
+	}
+	return "", "", nil
+}
+
+// communityDocLocations are the paths GitHub looks for each community health file at, in order.
+var communityDocLocations = []struct {
+	name  string
+	paths []string
+}{
+	{"contributing", []string{".github/CONTRIBUTING.md", "CONTRIBUTING.md", "docs/CONTRIBUTING.md"}},
+	{"code_of_conduct", []string{".github/CODE_OF_CONDUCT.md", "CODE_OF_CONDUCT.md", "docs/CODE_OF_CONDUCT.md"}},
+}
+
+// GetCommunityDocs creates a tool to get the contributing guidelines and code of conduct of a repository.
+func GetCommunityDocs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_community_docs",
+			mcp.WithDescription(t("TOOL_GET_COMMUNITY_DOCS_DESCRIPTION", "Get the CONTRIBUTING.md and CODE_OF_CONDUCT.md of a repository, looked for in .github/, the root and docs/ like GitHub does, with the path each was found at. Missing documents are null")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to read the documents at. Defaults to the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := map[string]interface{}{}
+			for _, doc := range communityDocLocations {
+				path, content, err := getFirstFile(ctx, client, owner, repo, ref, doc.paths)
+				if err != nil {
+					return nil, err
+				}
+				if path == "" {
+					result[doc.name] = nil
+					continue
+				}
+				result[doc.name] = map[string]interface{}{
+					"path":    path,
+					"content": content,
+				}
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// commitStatusStates are the states a commit status can be set to.
+var commitStatusStates = []string{"error", "failure", "pending", "success"}
+
+// CreateCommitStatus creates a tool to set a status on a commit.
+func CreateCommitStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_commit_status",
+			mcp.WithDescription(t("TOOL_CREATE_COMMIT_STATUS_DESCRIPTION", "Set a status on a commit, such as the result of an external CI system. Setting a status with the same context again replaces the previous one, as only the latest status for each context counts")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit to set the status on"),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("State of the status"),
+				mcp.Enum(commitStatusStates...),
+			),
+			mcp.WithString("target_url",
+				mcp.Description("URL to link from the status, such as the build output"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Short description of the status"),
+			),
+			mcp.WithString("context",
+				mcp.Description("Label that identifies the status among the others on the commit, defaults to 'default'"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := requiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := requiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !slices.Contains(commitStatusStates, state) {
+				return mcp.NewToolResultError(fmt.Sprintf("state must be one of %s", strings.Join(commitStatusStates, ", "))), nil
+			}
+			targetURL, err := OptionalParam[string](request, "target_url")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			statusContext, err := OptionalParam[string](request, "context")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			status := &github.RepoStatus{State: github.Ptr(state)}
+			if targetURL != "" {
+				status.TargetURL = github.Ptr(targetURL)
+			}
+			if description != "" {
+				status.Description = github.Ptr(description)
+			}
+			if statusContext != "" {
+				status.Context = github.Ptr(statusContext)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			created, resp, err := client.Repositories.CreateStatus(ctx, owner, repo, sha, status)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create commit status: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create commit status: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(created)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// commitComment is the body of a request to comment on a commit. go-github's RepositoryComment can only
+// position a comment by its offset in the diff, not by its line in the file, so the request is built here.
+type commitComment struct {
+	Body string  `json:"body"`
+	Path *string `json:"path,omitempty"`
+	Line *int    `json:"line,omitempty"`
+}
+
+// CreateCommitComment creates a tool to comment on a commit.
+func CreateCommitComment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_commit_comment",
+			mcp.WithDescription(t("TOOL_CREATE_COMMIT_COMMENT_DESCRIPTION", "Comment on a commit, either on the commit as a whole or on a line of one of the files it changes")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit to comment on"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("Comment text"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Path of the file to comment on, relative to the repository root. Requires line"),
+			),
+			mcp.WithNumber("line",
+				mcp.Description("Line of the file to comment on. It must be part of the commit's diff. Requires path"),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := requiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := requiredParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			line, err := OptionalIntParam(request, "line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (path == "") != (line == 0) {
+				return mcp.NewToolResultError("path and line must be given together"), nil
+			}
+
+			comment := &commitComment{Body: body}
+			if path != "" {
+				comment.Path = github.Ptr(path)
+				comment.Line = github.Ptr(line)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			req, err := client.NewRequest("POST", fmt.Sprintf("repos/%v/%v/commits/%v/comments", owner, repo, sha), comment)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			created := new(github.RepositoryComment)
+			resp, err := client.Do(ctx, req, created)
+			if err != nil {
+				if path != "" && isGitHubErrorStatus(err, http.StatusUnprocessableEntity) {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot comment on line %d of %s: commit %s doesn't change that line, only lines in the commit's diff can be commented on", line, path, sha)), nil
+				}
+				return nil, fmt.Errorf("failed to create commit comment: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create commit comment: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(created)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListCommitComments creates a tool to list the comments on a commit.
+func ListCommitComments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_commit_comments",
+			mcp.WithDescription(t("TOOL_LIST_COMMIT_COMMENTS_DESCRIPTION", "List the comments on a commit. Comments on a line of a file are marked as positioned and have its path and line, the others are on the commit as a whole")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := requiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			u := fmt.Sprintf("repos/%v/%v/commits/%v/comments?%s", owner, repo, sha, url.Values{
+				"page":     []string{strconv.Itoa(pagination.page)},
+				"per_page": []string{strconv.Itoa(pagination.perPage)},
+			}.Encode())
+			req, err := client.NewRequest("GET", u, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			// go-github's RepositoryComment lacks the line of positioned comments
+			var comments []struct {
+				github.RepositoryComment
+				Line *int `json:"line"`
+			}
+			resp, err := client.Do(ctx, req, &comments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list commit comments: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list commit comments: %s", string(body))), nil
+			}
+
+			result := make([]map[string]interface{}, 0, len(comments))
+			for _, c := range comments {
+				result = append(result, map[string]interface{}{
+					"id":         c.GetID(),
+					"author":     c.GetUser().GetLogin(),
+					"body":       c.GetBody(),
+					"positioned": c.Path != nil,
+					"path":       c.Path,
+					"line":       c.Line,
+					"position":   c.Position,
+					"created_at": c.CreatedAt,
+					"html_url":   c.GetHTMLURL(),
+				})
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// tagProtectionUnavailable reports a tag protection request that failed because the classic endpoint is gone.
+// It was retired on GitHub.com in favor of repository rulesets, but remains on older GitHub Enterprise Server versions.
+func tagProtectionUnavailable(err error) bool {
+	return isGitHubErrorStatus(err, http.StatusNotFound) || isGitHubErrorStatus(err, http.StatusGone)
+}
+
+// tagProtectionUnavailableMessage explains what to use instead of the retired tag protection endpoint.
+func tagProtectionUnavailableMessage(owner, repo string) string {
+	return fmt.Sprintf("tag protection is not available for %s/%s: the classic tag protection API has been replaced by repository rulesets, use a ruleset targeting tags instead", owner, repo)
+}
+
+// ListTagProtection creates a tool to list the tag protection rules of a repository.
+func ListTagProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_tag_protection",
+			mcp.WithDescription(t("TOOL_LIST_TAG_PROTECTION_DESCRIPTION", "List the tag name patterns protected in a repository using classic tag protection. Classic tag protection is superseded by repository rulesets and is only available where the API still supports it")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			protections, resp, err := client.Repositories.ListTagProtection(ctx, owner, repo) //nolint:staticcheck // rulesets don't replace this for servers that still only offer classic tag protection
+			if err != nil {
+				if tagProtectionUnavailable(err) {
+					return mcp.NewToolResultError(tagProtectionUnavailableMessage(owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to list tag protection: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list tag protection: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(protections)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateTagProtection creates a tool to protect the tags of a repository matching a pattern.
+func CreateTagProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_tag_protection",
+			mcp.WithDescription(t("TOOL_CREATE_TAG_PROTECTION_DESCRIPTION", "Protect the tags of a repository matching a name pattern using classic tag protection. Classic tag protection is superseded by repository rulesets and is only available where the API still supports it")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("pattern",
+				mcp.Required(),
+				mcp.Description("Tag name pattern to protect, using fnmatch syntax such as 'v*'"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pattern, err := requiredParam[string](request, "pattern")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			protection, resp, err := client.Repositories.CreateTagProtection(ctx, owner, repo, pattern) //nolint:staticcheck // rulesets don't replace this for servers that still only offer classic tag protection
+			if err != nil {
+				if tagProtectionUnavailable(err) {
+					return mcp.NewToolResultError(tagProtectionUnavailableMessage(owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to create tag protection: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create tag protection: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(protection)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// refGateBlocker is a status or check run that keeps a ref from being green, with its outcome as
+// statusCheckOutcomes reports it.
+type refGateBlocker struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// IsRefGreen creates a tool to check if every status and check run on a ref succeeded.
+func IsRefGreen(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("is_ref_green",
+			mcp.WithDescription(t("TOOL_IS_REF_GREEN_DESCRIPTION", "Check if a commit, branch or tag is green, meaning every commit status and check run on it succeeded. Pending, failed and errored ones are listed as blocking")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch name or tag name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := requiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			combined, err := getFullCombinedStatus(ctx, client, owner, repo, ref)
+			if err != nil {
+				return nil, err
+			}
+			checkRuns, err := listAllCheckRuns(ctx, client, owner, repo, ref)
+			if err != nil {
+				return nil, err
+			}
+
+			// The combined state is only a summary, so every status and check run is judged on its own
+			outcomes := statusCheckOutcomes(combined, checkRuns)
+			blocking := []refGateBlocker{}
+			for _, name := range slices.Sorted(maps.Keys(outcomes)) {
+				if outcome := outcomes[name]; outcome != "passing" {
+					blocking = append(blocking, refGateBlocker{Name: name, State: outcome})
+				}
+			}
+
+			result := map[string]interface{}{
+				"green":          len(blocking) == 0,
+				"sha":            combined.GetSHA(),
+				"statuses_count": len(combined.Statuses),
+				"checks_count":   len(checkRuns),
+				"blocking":       blocking,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// buildStatusFailure is a commit status or check run that failed, as reported by get_commit_build_status.
+type buildStatusFailure struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// GetCommitBuildStatus creates a tool to tell if CI passed on a commit, merging its commit statuses and check runs.
+func GetCommitBuildStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_commit_build_status",
+			mcp.WithDescription(t("TOOL_GET_COMMIT_BUILD_STATUS_DESCRIPTION", "Get the overall CI result of a commit from both its commit statuses and its check runs: 'failed' if any of them failed, 'success' if all succeeded, 'pending' otherwise. Use as a go/no-go gate before deploying")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("Commit SHA"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := requiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The two APIs are independent of each other
+			var (
+				combined  *github.CombinedStatus
+				checkRuns []*github.CheckRun
+			)
+			errs := runConcurrently(ctx,
+				func(ctx context.Context) (err error) {
+					combined, err = getFullCombinedStatus(ctx, client, owner, repo, sha)
+					return err
+				},
+				func(ctx context.Context) (err error) {
+					checkRuns, err = listAllCheckRuns(ctx, client, owner, repo, sha)
+					return err
+				},
+			)
+			if statusErr := errs[0]; statusErr != nil {
+				if isGitHubErrorStatus(statusErr, http.StatusNotFound) || isGitHubErrorStatus(statusErr, http.StatusUnprocessableEntity) {
+					return mcp.NewToolResultError(fmt.Sprintf("commit %s not found in %s/%s", sha, owner, repo)), nil
+				}
+				return nil, statusErr
+			}
+			if checkErr := errs[1]; checkErr != nil {
+				return nil, checkErr
+			}
+
+			sources := map[string]buildStatusFailure{}
+			statusContexts := make([]map[string]interface{}, 0, len(combined.Statuses))
+			for _, status := range combined.Statuses {
+				statusContexts = append(statusContexts, map[string]interface{}{
+					"context": status.GetContext(),
+					"state":   status.GetState(),
+				})
+				sources[status.GetContext()] = buildStatusFailure{Name: status.GetContext(), Type: "status", URL: status.GetTargetURL()}
+			}
+			checkConclusions := make([]map[string]interface{}, 0, len(checkRuns))
+			for _, run := range checkRuns {
+				// Runs that haven't completed have no conclusion yet
+				checkConclusions = append(checkConclusions, map[string]interface{}{
+					"name":       run.GetName(),
+					"conclusion": run.Conclusion,
+				})
+				sources[run.GetName()] = buildStatusFailure{Name: run.GetName(), Type: "check_run", URL: run.GetHTMLURL()}
+			}
+
+			failures := []buildStatusFailure{}
+			pending := false
+			outcomes := statusCheckOutcomes(combined, checkRuns)
+			for _, name := range slices.Sorted(maps.Keys(outcomes)) {
+				switch outcomes[name] {
+				case "failing":
+					failures = append(failures, sources[name])
+				case "pending":
+					pending = true
+				}
+			}
+
+			overall := "success"
+			switch {
+			case len(failures) > 0:
+				overall = "failed"
+			case pending:
+				overall = "pending"
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"overall":           overall,
+				"check_conclusions": checkConclusions,
+				"status_contexts":   statusContexts,
+				"blocking_failures": failures,
+				// Neither API estimates how long pending checks will take
+				"eta_seconds": nil,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+const (
+	// webhookHealthDeliveries is the number of recent deliveries get_webhook_health looks at.
+	webhookHealthDeliveries = 20
+	// maxWebhookRecentFailures caps the failing deliveries get_webhook_health lists.
+	maxWebhookRecentFailures = 5
+)
+
+// webhookHealthStatus classifies a webhook by the fraction of its recent deliveries that succeeded.
+func webhookHealthStatus(successRate float64) string {
+	switch {
+	case successRate >= 0.95:
+		return "healthy"
+	case successRate >= 0.5:
+		return "degraded"
+	default:
+		return "failing"
+	}
+}
+
+// GetWebhookHealth creates a tool to summarize the recent deliveries of a repository webhook.
+func GetWebhookHealth(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_webhook_health",
+			mcp.WithDescription(t("TOOL_GET_WEBHOOK_HEALTH_DESCRIPTION", fmt.Sprintf("Get the health of a repository webhook from its last %d deliveries: success rate, last success and failure, average duration and the most recent failures", webhookHealthDeliveries))),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the webhook"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := RequiredInt(request, "hook_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			deliveries, resp, err := client.Repositories.ListHookDeliveries(ctx, owner, repo, int64(hookID), &github.ListCursorOptions{PerPage: webhookHealthDeliveries})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list webhook deliveries: %s", string(body))), nil
+			}
+
+			// Deliveries are listed newest first
+			var lastSuccessAt, lastFailureAt *github.Timestamp
+			successes := 0
+			totalDuration := 0.0
+			recentFailures := []map[string]interface{}{}
+			for _, d := range deliveries {
+				duration := 0.0
+				if d.Duration != nil {
+					duration = *d.Duration
+				}
+				totalDuration += duration
+				if code := d.GetStatusCode(); code >= 200 && code < 300 {
+					successes++
+					if lastSuccessAt == nil {
+						lastSuccessAt = d.DeliveredAt
+					}
+					continue
+				}
+				if lastFailureAt == nil {
+					lastFailureAt = d.DeliveredAt
+				}
+				if len(recentFailures) < maxWebhookRecentFailures {
+					recentFailures = append(recentFailures, map[string]interface{}{
+						"delivery_id": d.GetID(),
+						"status_code": d.GetStatusCode(),
+						"status":      d.GetStatus(),
+						"event":       d.GetEvent(),
+						"duration":    duration,
+						"redelivery":  d.GetRedelivery(),
+					})
+				}
+			}
+
+			result := map[string]interface{}{
+				"deliveries_count":    len(deliveries),
+				"success_rate":        nil,
+				"health_status":       "unknown",
+				"last_success_at":     lastSuccessAt,
+				"last_failure_at":     lastFailureAt,
+				"average_duration_ms": nil,
+				"recent_failures":     recentFailures,
+			}
+			if len(deliveries) > 0 {
+				successRate := float64(successes) / float64(len(deliveries))
+				result["success_rate"] = successRate
+				result["health_status"] = webhookHealthStatus(successRate)
+				// The API reports durations in seconds
+				result["average_duration_ms"] = totalDuration / float64(len(deliveries)) * 1000
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+const (
+	defaultLargestFiles = 10
+	maxLargestFiles     = 100
+)
+
+// GetLargestFiles creates a tool to find the largest files of a repository.
+func GetLargestFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_largest_files",
+			mcp.WithDescription(t("TOOL_GET_LARGEST_FILES_DESCRIPTION", "Get the largest files of a repository at a ref, by size, with their paths. Useful to find what makes a repository big")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to look at. Defaults to the default branch"),
+			),
+			mcp.WithNumber("top",
+				mcp.Description(fmt.Sprintf("Number of files to return (min 1, max %d). Defaults to %d", maxLargestFiles, defaultLargestFiles)),
+				mcp.Min(1),
+				mcp.Max(maxLargestFiles),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			top, err := OptionalIntParamWithDefault(request, "top", defaultLargestFiles)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if top < 1 || top > maxLargestFiles {
+				return mcp.NewToolResultError(fmt.Sprintf("top must be between 1 and %d", maxLargestFiles)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if ref == "" {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				ref = repository.GetDefaultBranch()
+			}
+
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, ref, true)
+			if err != nil {
+				if isGitHubErrorStatus(err, http.StatusNotFound) {
+					return mcp.NewToolResultError(fmt.Sprintf("ref %s not found in %s/%s", ref, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get tree: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get tree: %s", string(body))), nil
+			}
+
+			// Subdirectories and submodules are entries too, only blobs have a size
+			blobs := slices.DeleteFunc(tree.Entries, func(e *github.TreeEntry) bool {
+				return e.GetType() != "blob"
+			})
+			slices.SortFunc(blobs, func(a, b *github.TreeEntry) int {
+				return cmp.Or(cmp.Compare(b.GetSize(), a.GetSize()), cmp.Compare(a.GetPath(), b.GetPath()))
+			})
+
+			files := make([]map[string]interface{}, 0, min(top, len(blobs)))
+			for _, blob := range blobs[:min(top, len(blobs))] {
+				files = append(files, map[string]interface{}{
+					"path": blob.GetPath(),
+					"size": blob.GetSize(),
+					"sha":  blob.GetSHA(),
+				})
+			}
+
+			result := map[string]interface{}{
+				"ref":           ref,
+				"files":         files,
+				"files_scanned": len(blobs),
+				"truncated":     tree.GetTruncated(),
+			}
+			if tree.GetTruncated() {
+				result["note"] = "The repository has too many files to list at once, so only part of it was scanned and larger files may be missing"
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// maxMergedBranchPullRequests bounds the number of closed pull requests cleanup_merged_branches inspects.
+const maxMergedBranchPullRequests = 100
+
+// listAllBranches fetches every branch of a repository.
+func listAllBranches(ctx context.Context, client *github.Client, owner, repo string) ([]*github.Branch, error) {
+	var all []*github.Branch
+	opts := &github.BranchListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		branches, resp, err := client.Repositories.ListBranches(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list branches: %w", err)
+		}
+		_ = resp.Body.Close()
+		all = append(all, branches...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// openPullRequestBranches maps the branches open pull requests are based on or opened from
+// to the number of one of those pull requests. Head branches of forks are left out.
+func openPullRequestBranches(ctx context.Context, client *github.Client, owner, repo, fullName string) (map[string]int, error) {
+	used := map[string]int{}
+	opts := &github.PullRequestListOptions{State: "open", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		prs, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list open pull requests: %w", err)
+		}
+		_ = resp.Body.Close()
+		for _, pr := range prs {
+			if _, ok := used[pr.GetBase().GetRef()]; !ok {
+				used[pr.GetBase().GetRef()] = pr.GetNumber()
+			}
+			if _, ok := used[pr.GetHead().GetRef()]; !ok && pr.GetHead().GetRepo().GetFullName() == fullName {
+				used[pr.GetHead().GetRef()] = pr.GetNumber()
+			}
+		}
+		if resp.NextPage == 0 {
+			return used, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// CleanupMergedBranches creates a tool to delete the branches of merged pull requests.
+func CleanupMergedBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("cleanup_merged_branches",
+			mcp.WithDescription(t("TOOL_CLEANUP_MERGED_BRANCHES_DESCRIPTION", fmt.Sprintf("Delete the branches of merged pull requests that still exist in a repository. The default branch, protected branches, branches open pull requests are based on or opened from and branches with commits pushed after the merge are kept. Only the %d most recently updated closed pull requests are inspected", maxMergedBranchPullRequests))),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.Required(),
+				mcp.Description("Only list the branches that would be deleted, without deleting them"),
+			),
+			mcp.WithBoolean("enable_auto_delete",
+				mcp.Description("Also make GitHub delete head branches automatically once their pull requests are merged. Ignored in a dry run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dryRun, ok, err := OptionalParamOK[bool](request, "dry_run")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				return mcp.NewToolResultError("missing required parameter: dry_run"), nil
+			}
+			enableAutoDelete, err := OptionalParam[bool](request, "enable_auto_delete")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			branches, err := listAllBranches(ctx, client, owner, repo)
+			if err != nil {
+				return nil, err
+			}
+			branchesByName := make(map[string]*github.Branch, len(branches))
+			for _, branch := range branches {
+				branchesByName[branch.GetName()] = branch
+			}
+
+			// A merged branch can still be the base of a stacked pull request, or the head of a
+			// new one, so branches open pull requests use are kept
+			usedBy, err := openPullRequestBranches(ctx, client, owner, repo, repository.GetFullName())
+			if err != nil {
+				return nil, err
+			}
+
+			prs, resp, err := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+				State:       "closed",
+				Sort:        "updated",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: maxMergedBranchPullRequests},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list pull requests: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list pull requests: %s", string(body))), nil
+			}
+
+			fullName := repository.GetFullName()
+			deleted := []map[string]interface{}{}
+			skipped := []map[string]interface{}{}
+			failed := []map[string]interface{}{}
+			seen := map[string]bool{}
+			for _, pr := range prs {
+				// Branches of forks can't be deleted here, and branches already deleted are done with
+				name := pr.GetHead().GetRef()
+				branch, exists := branchesByName[name]
+				if pr.MergedAt == nil || pr.GetHead().GetRepo().GetFullName() != fullName || !exists || seen[name] {
+					continue
+				}
+				seen[name] = true
+
+				var reason string
+				switch {
+				case name == repository.GetDefaultBranch():
+					reason = "default branch"
+				case branch.GetProtected():
+					reason = "protected branch"
+				case usedBy[name] != 0:
+					reason = fmt.Sprintf("used by open pull request #%d", usedBy[name])
+				case branch.GetCommit().GetSHA() != pr.GetHead().GetSHA():
+					// The branch moved on since the merge, deleting it would lose those commits
+					reason = "has commits that were not merged"
+				}
+				if reason != "" {
+					skipped = append(skipped, map[string]interface{}{"branch": name, "pull_number": pr.GetNumber(), "reason": reason})
+					continue
+				}
+
+				if !dryRun {
+					resp, err := client.Git.DeleteRef(ctx, owner, repo, "heads/"+name)
+					if err != nil {
+						failed = append(failed, map[string]interface{}{"branch": name, "pull_number": pr.GetNumber(), "error": err.Error()})
+						continue
+					}
+					_ = resp.Body.Close()
+				}
+				deleted = append(deleted, map[string]interface{}{"branch": name, "pull_number": pr.GetNumber()})
+			}
+
+			autoDelete := repository.GetDeleteBranchOnMerge()
+			if enableAutoDelete && !autoDelete && !dryRun {
+				_, resp, err := client.Repositories.Edit(ctx, owner, repo, &github.Repository{DeleteBranchOnMerge: github.Ptr(true)})
+				if err != nil {
+					return nil, fmt.Errorf("failed to enable automatic branch deletion: %w", err)
+				}
+				_ = resp.Body.Close()
+				autoDelete = true
+			}
+
+			deletedKey := "deleted"
+			if dryRun {
+				deletedKey = "would_delete"
+			}
+			result := map[string]interface{}{
+				"dry_run":                dryRun,
+				deletedKey:               deleted,
+				"skipped":                skipped,
+				"pull_requests_scanned":  len(prs),
+				"truncated":              resp.NextPage != 0,
+				"delete_branch_on_merge": autoDelete,
+			}
+			if len(failed) > 0 {
+				result["failed"] = failed
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// interactionLimitSummary describes the interaction limit of a repository, with "off" when there is none.
+func interactionLimitSummary(restriction *github.InteractionRestriction) map[string]interface{} {
+	if restriction.GetLimit() == "" {
+		return map[string]interface{}{"limit": "off"}
+	}
+	return map[string]interface{}{
+		"limit":      restriction.GetLimit(),
+		"origin":     restriction.GetOrigin(),
+		"expires_at": restriction.ExpiresAt,
+	}
+}
+
+// GetInteractionLimits creates a tool to get the interaction limit of a repository.
+func GetInteractionLimits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_interaction_limits",
+			mcp.WithDescription(t("TOOL_GET_INTERACTION_LIMITS_DESCRIPTION", "Get which users can currently comment, open issues and create pull requests in a repository, and when the limit expires. A limit set on the owning organization applies too")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			restriction, resp, err := client.Interactions.GetRestrictionsForRepo(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get interaction limits: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get interaction limits: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(interactionLimitSummary(restriction))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetInteractionLimits creates a tool to limit the interactions with a repository, or to remove the limit.
+func SetInteractionLimits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_interaction_limits",
+			mcp.WithDescription(t("TOOL_SET_INTERACTION_LIMITS_DESCRIPTION", "Temporarily limit which users can comment, open issues and create pull requests in a repository, such as during an incident, or remove the limit with 'off'. Requires repository admin permissions")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("limit",
+				mcp.Required(),
+				mcp.Description("Users allowed to interact: 'existing_users' (accounts older than 24 hours that contributed before), 'contributors_only' (users who contributed before), 'collaborators_only', or 'off' to remove the limit"),
+				mcp.Enum("existing_users", "contributors_only", "collaborators_only", "off"),
+			),
+			mcp.WithString("expiry",
+				mcp.Description("How long the limit lasts, defaults to one_day. Ignored when removing the limit"),
+				mcp.Enum("one_day", "three_days", "one_week", "one_month", "six_months"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := requiredParam[string](request, "limit")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !slices.Contains([]string{"existing_users", "contributors_only", "collaborators_only", "off"}, limit) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid limit %q, must be existing_users, contributors_only, collaborators_only or off", limit)), nil
+			}
+			expiry, err := OptionalParam[string](request, "expiry")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if expiry != "" && !slices.Contains([]string{"one_day", "three_days", "one_week", "one_month", "six_months"}, expiry) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid expiry %q, must be one_day, three_days, one_week, one_month or six_months", expiry)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if limit == "off" {
+				resp, err := client.Interactions.RemoveRestrictionsFromRepo(ctx, owner, repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to remove interaction limits: %w", err)
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				if resp.StatusCode != http.StatusNoContent {
+					body, err := io.ReadAll(resp.Body)
+					if err != nil {
+						return nil, fmt.Errorf("failed to read response body: %w", err)
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to remove interaction limits: %s", string(body))), nil
+				}
+
+				return mcp.NewToolResultText(fmt.Sprintf("Interaction limits of %s/%s removed", owner, repo)), nil
+			}
+
+			// go-github can't set the expiry, so the request is built here
+			limitRequest := map[string]string{"limit": limit}
+			if expiry != "" {
+				limitRequest["expiry"] = expiry
+			}
+			req, err := client.NewRequest("PUT", fmt.Sprintf("repos/%v/%v/interaction-limits", owner, repo), limitRequest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			restriction := new(github.InteractionRestriction)
+			resp, err := client.Do(ctx, req, restriction)
+			if err != nil {
+				// GitHub refuses to limit a repository whose organization already has a limit
+				if isGitHubErrorStatus(err, http.StatusConflict) {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to set interaction limits, the organization of %s/%s may already limit interactions: %s", owner, repo, err)), nil
+				}
+				return nil, fmt.Errorf("failed to set interaction limits: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to set interaction limits: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(interactionLimitSummary(restriction))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// statsRetryDelays are the waits between the attempts to fetch repository statistics GitHub is still computing.
+var statsRetryDelays = []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+
+// errStatsPending is returned by fetchRepositoryStats when GitHub is still computing the statistics after every retry.
+var errStatsPending = errors.New("statistics are still being computed")
+
+// fetchRepositoryStats calls fetch until GitHub is done computing the statistics it asks for. GitHub answers
+// 202 Accepted while it computes them in the background, so fetch is retried after each of statsRetryDelays.
+func fetchRepositoryStats(ctx context.Context, fetch func() (*github.Response, error)) (*github.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := fetch()
+		if err == nil || !isAcceptedError(err) {
+			return resp, err
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if attempt == len(statsRetryDelays) {
+			return nil, errStatsPending
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(statsRetryDelays[attempt]):
+		}
+	}
+}
+
+// codeFrequencyWeek is the number of lines added and deleted in a repository in a week.
+type codeFrequencyWeek struct {
+	Week      string `json:"week"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// GetCodeFrequency creates a tool to get the weekly number of lines added to and deleted from a repository.
+func GetCodeFrequency(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_code_frequency",
+			mcp.WithDescription(t("TOOL_GET_CODE_FREQUENCY_DESCRIPTION", "Get the number of lines added to and deleted from a repository each week, starting on Sundays. GitHub computes the statistics on demand, so the first call for a repository may take a few seconds")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			var stats []*github.WeeklyStats
+			resp, err := fetchRepositoryStats(ctx, func() (*github.Response, error) {
+				var resp *github.Response
+				var err error
+				stats, resp, err = client.Repositories.ListCodeFrequency(ctx, owner, repo)
+				return resp, err
+			})
+			if errors.Is(err, errStatsPending) {
+				return mcp.NewToolResultError(fmt.Sprintf("GitHub is still computing the code frequency of %s/%s, try again in a moment", owner, repo)), nil
+			}
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get code frequency: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// An empty repository has no statistics, which GitHub reports with 204 No Content
+			if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get code frequency: %s", string(body))), nil
+			}
+
+			// GitHub reports deletions as negative numbers, they're returned as counts of lines
+			weeks := make([]codeFrequencyWeek, 0, len(stats))
+			for _, week := range stats {
+				weeks = append(weeks, codeFrequencyWeek{
+					Week:      week.GetWeek().UTC().Format(time.DateOnly),
+					Additions: week.GetAdditions(),
+					Deletions: -week.GetDeletions(),
+				})
+			}
+
+			r, err := json.Marshal(map[string]interface{}{"weeks": weeks})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+var (
+	// squashMergeCommitTitles are the defaults GitHub can give the title of a squash merge commit.
+	squashMergeCommitTitles = []string{"PR_TITLE", "COMMIT_OR_PR_TITLE"}
+	// squashMergeCommitMessages are the defaults GitHub can give the message of a squash merge commit.
+	squashMergeCommitMessages = []string{"PR_BODY", "COMMIT_MESSAGES", "BLANK"}
+)
+
+// UpdateRepository creates a tool to update the settings of a repository.
+func UpdateRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_repository",
+			mcp.WithDescription(t("TOOL_UPDATE_REPOSITORY_DESCRIPTION", "Update the settings of a repository, such as the default title and message of squash merge commits. Requires admin permissions on the repository")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("squash_merge_commit_title",
+				mcp.Description("Default title of squash merge commits: the pull request title, or the commit message for pull requests with a single commit. Only applies when squash merging is enabled"),
+				mcp.Enum(squashMergeCommitTitles...),
+			),
+			mcp.WithString("squash_merge_commit_message",
+				mcp.Description("Default message of squash merge commits: the pull request body, the messages of the branch's commits, or none. Only applies when squash merging is enabled"),
+				mcp.Enum(squashMergeCommitMessages...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			update := &github.Repository{}
+			updateNeeded := false
+
+			squashTitle, err := OptionalParam[string](request, "squash_merge_commit_title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if squashTitle != "" {
+				if !slices.Contains(squashMergeCommitTitles, squashTitle) {
+					return mcp.NewToolResultError(fmt.Sprintf("squash_merge_commit_title must be one of %s", strings.Join(squashMergeCommitTitles, ", "))), nil
+				}
+				update.SquashMergeCommitTitle = github.Ptr(squashTitle)
+				updateNeeded = true
+			}
+			squashMessage, err := OptionalParam[string](request, "squash_merge_commit_message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if squashMessage != "" {
+				if !slices.Contains(squashMergeCommitMessages, squashMessage) {
+					return mcp.NewToolResultError(fmt.Sprintf("squash_merge_commit_message must be one of %s", strings.Join(squashMergeCommitMessages, ", "))), nil
+				}
+				update.SquashMergeCommitMessage = github.Ptr(squashMessage)
+				updateNeeded = true
+			}
+
+			if !updateNeeded {
+				return mcp.NewToolResultError("No update parameters provided."), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			updated, resp, err := client.Repositories.Edit(ctx, owner, repo, update)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to update repository: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update repository: %s", string(body))), nil
+			}
+
+			result := map[string]interface{}{
+				"allow_squash_merge":          updated.GetAllowSquashMerge(),
+				"squash_merge_commit_title":   updated.GetSquashMergeCommitTitle(),
+				"squash_merge_commit_message": updated.GetSquashMergeCommitMessage(),
+			}
+			// GitHub saves the format even while squash merging is disabled, where it has no effect
+			if !updated.GetAllowSquashMerge() {
+				result["warning"] = fmt.Sprintf("squash merging is disabled on %s/%s, so the squash merge commit format only applies once it is enabled", owner, repo)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetDefaultBranch creates a tool to switch the default branch of a repository to another branch, or to rename it.
+func SetDefaultBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_default_branch",
+			mcp.WithDescription(t("TOOL_SET_DEFAULT_BRANCH_DESCRIPTION", "Switch the default branch of a repository to another existing branch, or with rename set, rename the current default branch, which also retargets open pull requests and moves branch protections. Requires admin permissions on the repository")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to make the default, or with rename set, the new name of the default branch"),
+			),
+			mcp.WithBoolean("rename",
+				mcp.Description("Rename the current default branch to branch instead of switching to an existing branch. Defaults to false"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := requiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rename, err := OptionalParam[bool](request, "rename")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			_ = resp.Body.Close()
+			previous := repository.GetDefaultBranch()
+			if branch == previous {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is already the default branch of %s/%s", branch, owner, repo)), nil
+			}
+
+			// Looking the exact ref up avoids following the redirect GitHub keeps for renamed branches
+			_, resp, err = client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			exists := err == nil
+			if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+				return nil, fmt.Errorf("failed to get branch %s: %w", branch, err)
+			}
+			if exists {
+				_ = resp.Body.Close()
+			}
+
+			if rename {
+				if exists {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot rename %s to %s, a branch named %s already exists in %s/%s", previous, branch, branch, owner, repo)), nil
+				}
+				_, resp, err = client.Repositories.RenameBranch(ctx, owner, repo, previous, branch)
+			} else {
+				if !exists {
+					return mcp.NewToolResultError(fmt.Sprintf("branch %s not found in %s/%s", branch, owner, repo)), nil
+				}
+				_, resp, err = client.Repositories.Edit(ctx, owner, repo, &github.Repository{DefaultBranch: github.Ptr(branch)})
+			}
+			if err != nil {
+				var errorResponse *github.ErrorResponse
+				if errors.As(err, &errorResponse) && resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnprocessableEntity) {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot change the default branch of %s/%s: %s", owner, repo, errorResponse.Message)), nil
+				}
+				return nil, fmt.Errorf("failed to change default branch: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			r, err := json.Marshal(map[string]interface{}{
+				"previous_default_branch": previous,
+				"default_branch":          branch,
+				"renamed":                 rename,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// repoSettings are the settings apply_repo_settings applies, in the shape of a Probot settings.yml file.
+type repoSettings struct {
+	Description         *string                       `json:"description"`
+	Topics              *[]string                     `json:"topics"`
+	AllowSquashMerge    *bool                         `json:"allow_squash_merge"`
+	AllowMergeCommit    *bool                         `json:"allow_merge_commit"`
+	AllowRebaseMerge    *bool                         `json:"allow_rebase_merge"`
+	DeleteBranchOnMerge *bool                         `json:"delete_branch_on_merge"`
+	BranchProtection    *repoSettingsBranchProtection `json:"branch_protection"`
+}
+
+// repoSettingsBranchProtection is the protection apply_repo_settings gives a branch, replacing its current one.
+type repoSettingsBranchProtection struct {
+	Branch                       string    `json:"branch"`
+	RequiredApprovingReviewCount *int      `json:"required_approving_review_count"`
+	RequireCodeOwnerReviews      bool      `json:"require_code_owner_reviews"`
+	DismissStaleReviews          bool      `json:"dismiss_stale_reviews"`
+	RequiredStatusChecks         *[]string `json:"required_status_checks"`
+	StrictStatusChecks           bool      `json:"strict_status_checks"`
+	EnforceAdmins                bool      `json:"enforce_admins"`
+}
+
+// repoSettingResult is the outcome of applying one setting of apply_repo_settings.
+type repoSettingResult struct {
+	Setting string `json:"setting"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// parseRepoSettings decodes the settings object of apply_repo_settings, rejecting unknown settings so that a
+// misspelled one isn't silently left unapplied.
+func parseRepoSettings(raw map[string]interface{}) (*repoSettings, error) {
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	settings := &repoSettings{}
+	if err := decoder.Decode(settings); err != nil {
+		return nil, fmt.Errorf("invalid settings: %w", err)
+	}
+
+	if protection := settings.BranchProtection; protection != nil {
+		if protection.Branch == "" {
+			return nil, fmt.Errorf("branch_protection must name the branch to protect")
+		}
+		if count := protection.RequiredApprovingReviewCount; count != nil && (*count < 0 || *count > 6) {
+			return nil, fmt.Errorf("branch_protection.required_approving_review_count must be between 0 and 6, got %d", *count)
+		}
+	}
+	return settings, nil
+}
+
+// repoSettingsStep applies one setting of apply_repo_settings.
+type repoSettingsStep struct {
+	setting string
+	apply   func(ctx context.Context, client *github.Client, owner, repo string) (*github.Response, error)
+}
+
+// repoSettingsSteps returns the steps applying the given settings, in the order they are applied: the
+// description, then the merge options, the topics, and last the branch protection.
+func repoSettingsSteps(settings *repoSettings) []repoSettingsStep {
+	type step = repoSettingsStep
+	var steps []step
+
+	if settings.Description != nil {
+		steps = append(steps, step{"description", func(ctx context.Context, client *github.Client, owner, repo string) (*github.Response, error) {
+			_, resp, err := client.Repositories.Edit(ctx, owner, repo, &github.Repository{Description: settings.Description})
+			return resp, err
+		}})
+	}
+	if settings.AllowSquashMerge != nil || settings.AllowMergeCommit != nil || settings.AllowRebaseMerge != nil || settings.DeleteBranchOnMerge != nil {
+		steps = append(steps, step{"merge_options", func(ctx context.Context, client *github.Client, owner, repo string) (*github.Response, error) {
+			_, resp, err := client.Repositories.Edit(ctx, owner, repo, &github.Repository{
+				AllowSquashMerge:    settings.AllowSquashMerge,
+				AllowMergeCommit:    settings.AllowMergeCommit,
+				AllowRebaseMerge:    settings.AllowRebaseMerge,
+				DeleteBranchOnMerge: settings.DeleteBranchOnMerge,
+			})
+			return resp, err
+		}})
+	}
+	if settings.Topics != nil {
+		steps = append(steps, step{"topics", func(ctx context.Context, client *github.Client, owner, repo string) (*github.Response, error) {
+			_, resp, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo, *settings.Topics)
+			return resp, err
+		}})
+	}
+	if protection := settings.BranchProtection; protection != nil {
+		steps = append(steps, step{"branch_protection", func(ctx context.Context, client *github.Client, owner, repo string) (*github.Response, error) {
+			request := &github.ProtectionRequest{EnforceAdmins: protection.EnforceAdmins}
+			if protection.RequiredApprovingReviewCount != nil {
+				request.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+					RequiredApprovingReviewCount: *protection.RequiredApprovingReviewCount,
+					RequireCodeOwnerReviews:      protection.RequireCodeOwnerReviews,
+					DismissStaleReviews:          protection.DismissStaleReviews,
+				}
+			}
+			if protection.RequiredStatusChecks != nil {
+				request.RequiredStatusChecks = &github.RequiredStatusChecks{
+					Strict:   protection.StrictStatusChecks,
+					Contexts: protection.RequiredStatusChecks,
+				}
+			}
+			_, resp, err := client.Repositories.UpdateBranchProtection(ctx, owner, repo, protection.Branch, request)
+			return resp, err
+		}})
+	}
+	return steps
+}
+
+// ApplyRepoSettings creates a tool to apply a set of settings to a repository in one call, as with a Probot
+// settings.yml file.
+func ApplyRepoSettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("apply_repo_settings",
+			mcp.WithDescription(t("TOOL_APPLY_REPO_SETTINGS_DESCRIPTION", "Apply settings to a repository declaratively, like a Probot settings.yml file: the description, then the merge options, the topics, and last the branch protection. Each setting is applied even if an earlier one failed, and the result reports each of them, so a partial apply is visible. Requires admin permissions on the repository")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithObject("settings",
+				mcp.Required(),
+				mcp.Description("Settings to apply, leaving out the ones to keep as they are: description (string), topics (array of strings, replacing the current ones), allow_squash_merge, allow_merge_commit, allow_rebase_merge and delete_branch_on_merge (booleans), and branch_protection, an object replacing the protection of its branch (string, required), with required_approving_review_count (0 to 6), require_code_owner_reviews, dismiss_stale_reviews, required_status_checks (array of check names), strict_status_checks and enforce_admins"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rawSettings, ok, err := OptionalParamOK[map[string]interface{}](request, "settings")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				return mcp.NewToolResultError("missing required parameter: settings"), nil
+			}
+			settings, err := parseRepoSettings(rawSettings)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			steps := repoSettingsSteps(settings)
+			if len(steps) == 0 {
+				return mcp.NewToolResultError("No settings provided."), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			results := make([]repoSettingResult, 0, len(steps))
+			failed := 0
+			for _, step := range steps {
+				result := repoSettingResult{Setting: step.setting, Status: "applied"}
+				resp, err := step.apply(ctx, client, owner, repo)
+				if resp != nil {
+					_ = resp.Body.Close()
+				}
+				if err != nil {
+					result.Status, result.Error = "failed", err.Error()
+					failed++
+				}
+				results = append(results, result)
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"results":       results,
+				"applied_count": len(results) - failed,
+				"failed_count":  failed,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetDependencyManifest creates a tool to read the dependencies declared in the manifest of a repository.
+func GetDependencyManifest(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_dependency_manifest",
+			mcp.WithDescription(t("TOOL_GET_DEPENDENCY_MANIFEST_DESCRIPTION", "Get the dependencies declared in the manifest at the root of a repository, such as go.mod or package.json, each with its name, version and scope")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit to read the manifest at, the default branch if not provided"),
+			),
+			mcp.WithString("ecosystem",
+				mcp.Description("Ecosystem of the manifest to read. If not provided, the first manifest found is read, looking for go.mod then package.json"),
+				mcp.Enum(manifest.Ecosystems()...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ecosystem, err := OptionalParam[string](request, "ecosystem")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			candidates := manifest.Manifests
+			if ecosystem != "" {
+				i := slices.IndexFunc(manifest.Manifests, func(m manifest.Manifest) bool { return m.Ecosystem == ecosystem })
+				if i < 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("ecosystem must be one of %s", strings.Join(manifest.Ecosystems(), ", "))), nil
+				}
+				candidates = manifest.Manifests[i : i+1]
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			for _, m := range candidates {
+				fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, m.Path, &github.RepositoryContentGetOptions{Ref: ref})
+				if err != nil {
+					if isGitHubErrorStatus(err, http.StatusNotFound) {
+						continue
+					}
+					return nil, fmt.Errorf("failed to get %s: %w", m.Path, err)
+				}
+				_ = resp.Body.Close()
+				if fileContent == nil {
+					continue
+				}
+
+				content, err := fileContent.GetContent()
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode %s: %w", m.Path, err)
+				}
+				dependencies, err := m.Parse(content)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to parse %s: %s", m.Path, err)), nil
+				}
+
+				r, err := json.Marshal(map[string]interface{}{
+					"ecosystem":    m.Ecosystem,
+					"path":         m.Path,
+					"dependencies": dependencies,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return mcp.NewToolResultText(string(r)), nil
+			}
+
+			paths := make([]string, 0, len(candidates))
+			for _, m := range candidates {
+				paths = append(paths, m.Path)
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("no %s found at the root of %s/%s", strings.Join(paths, " or "), owner, repo)), nil
+		}
+}
+
+// RenderMarkdown creates a tool to render markdown to HTML the way GitHub does.
+func RenderMarkdown(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("render_markdown",
+			mcp.WithDescription(t("TOOL_RENDER_MARKDOWN_DESCRIPTION", "Render markdown to HTML the way GitHub does. In gfm mode, text is rendered like issue comments: line breaks are kept, @mentions are linked, and with a context repository, #123 references link to its issues and pull requests")),
+			mcp.WithString("text",
+				mcp.Required(),
+				mcp.Description("Markdown text to render"),
+			),
+			mcp.WithString("mode",
+				mcp.Description("Rendering mode: markdown renders like README files, gfm like issue comments. Defaults to markdown"),
+				mcp.Enum("markdown", "gfm"),
+			),
+			mcp.WithString("context",
+				mcp.Description("Repository to resolve references such as #123 against, as owner/repo. Only used in gfm mode"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			text, err := requiredParam[string](request, "text")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			mode, err := OptionalParam[string](request, "mode")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repoContext, err := OptionalParam[string](request, "context")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if mode == "" {
+				mode = "markdown"
+			}
+			if mode != "markdown" && mode != "gfm" {
+				return mcp.NewToolResultError("mode must be markdown or gfm"), nil
+			}
+			if repoContext != "" {
+				// GitHub ignores the context outside of gfm mode, which would leave references unlinked silently
+				if mode != "gfm" {
+					return mcp.NewToolResultError("context is only used in gfm mode"), nil
+				}
+				if owner, repo, ok := strings.Cut(repoContext, "/"); !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+					return mcp.NewToolResultError(fmt.Sprintf("context must be a repository as owner/repo, got %q", repoContext)), nil
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			html, resp, err := client.Markdown.Render(ctx, text, &github.MarkdownOptions{Mode: mode, Context: repoContext})
+			if err != nil {
+				return nil, fmt.Errorf("failed to render markdown: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(html), nil
+		}
+}
+
+const (
+	// maxArchiveBytes caps the size of the archive get_repository_archive returns inline.
+	maxArchiveBytes = 1024 * 1024
+	// maxArchiveManifestEntries caps the number of files listed in place of an archive over maxArchiveBytes.
+	maxArchiveManifestEntries = 1000
+)
+
+// GetRepositoryArchive creates a tool to download a tarball or zipball of a repository at a ref.
+func GetRepositoryArchive(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_archive",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_ARCHIVE_DESCRIPTION", fmt.Sprintf("Download a tarball or zipball of a repository at a ref, returned base64 encoded. Archives over %d bytes aren't returned; a manifest of the files the archive would contain, at most %d of them, is returned instead", maxArchiveBytes, maxArchiveManifestEntries))),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to download. Defaults to the default branch"),
+			),
+			mcp.WithString("format",
+				mcp.Description("Archive format. Defaults to tarball"),
+				mcp.Enum(string(github.Tarball), string(github.Zipball)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			format, err := OptionalParam[string](request, "format")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if format == "" {
+				format = string(github.Tarball)
+			}
+			if format != string(github.Tarball) && format != string(github.Zipball) {
+				return mcp.NewToolResultError("format must be tarball or zipball"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if ref == "" {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				ref = repository.GetDefaultBranch()
+			}
+
+			archiveURL, resp, err := client.Repositories.GetArchiveLink(ctx, owner, repo, github.ArchiveFormat(format), &github.RepositoryContentGetOptions{Ref: ref}, 1)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("ref %s not found in %s/%s", ref, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get archive URL: %w", err)
+			}
+
+			// The archive is served by codeload behind a short-lived URL, so it is fetched without the GitHub credentials
+			archiveRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, archiveURL.String(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create archive request: %w", err)
+			}
+			archiveResp, err := downloadClient(client).Do(archiveRequest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to download archive: %w", err)
+			}
+			defer func() { _ = archiveResp.Body.Close() }()
+
+			// Reading a byte past the limit tells an archive of exactly the limit apart from a larger one
+			archive, err := io.ReadAll(io.LimitReader(archiveResp.Body, maxArchiveBytes+1))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read response body: %w", err)
+			}
+			if archiveResp.StatusCode != http.StatusOK {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to download archive: %s", string(archive))), nil
+			}
+
+			result := map[string]interface{}{
+				"ref":    ref,
+				"format": format,
+			}
+			if len(archive) <= maxArchiveBytes {
+				result["size"] = len(archive)
+				result["content"] = base64.StdEncoding.EncodeToString(archive)
+			} else {
+				tree, resp, err := client.Git.GetTree(ctx, owner, repo, ref, true)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get tree: %w", err)
+				}
+				_ = resp.Body.Close()
+
+				blobs := slices.DeleteFunc(tree.Entries, func(e *github.TreeEntry) bool {
+					return e.GetType() != "blob"
+				})
+				entries := make([]map[string]interface{}, 0, min(len(blobs), maxArchiveManifestEntries))
+				for _, blob := range blobs[:min(len(blobs), maxArchiveManifestEntries)] {
+					entries = append(entries, map[string]interface{}{
+						"path": blob.GetPath(),
+						"size": blob.GetSize(),
+					})
+				}
+				result["too_large"] = true
+				result["note"] = fmt.Sprintf("The archive is larger than %d bytes, so a manifest of its files is returned instead. Fetch the files needed with get_file_contents", maxArchiveBytes)
+				result["entries"] = entries
+				result["total_entries"] = len(blobs)
+				result["truncated"] = tree.GetTruncated() || len(blobs) > maxArchiveManifestEntries
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -1,9 +1,15 @@
 package github
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -186,6 +192,126 @@ func Test_GetFileContents(t *testing.T) {
 	}
 }
 
+func Test_GetFileAtRefs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetFileAtRefs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_file_at_refs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "refs")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "refs"})
+
+	// Serve a different version of the file per ref, and nothing for unknown refs
+	contentsByRef := map[string]string{
+		"main":    "replicas: 3\n",
+		"staging": "replicas: 1\n",
+	}
+	contentsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ref := r.URL.Query().Get("ref")
+		content, ok := contentsByRef[ref]
+		if !ok {
+			mockResponse(t, http.StatusNotFound, map[string]string{"message": "No commit found for the ref " + ref})(w, r)
+			return
+		}
+		mockResponse(t, http.StatusOK, &github.RepositoryContent{
+			Type:     github.Ptr("file"),
+			Name:     github.Ptr("config.yml"),
+			Path:     github.Ptr("config.yml"),
+			SHA:      github.Ptr("sha-" + ref),
+			Size:     github.Ptr(len(content)),
+			Encoding: github.Ptr("base64"),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(content))),
+		})(w, r)
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedFiles  []fileAtRef
+	}{
+		{
+			name: "mix of existing and missing refs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					contentsHandler,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "config.yml",
+				"refs":  []interface{}{"main", "production", "staging"},
+			},
+			expectError: false,
+			// Results keep the order of the requested refs
+			expectedFiles: []fileAtRef{
+				{Ref: "main", Found: true, SHA: "sha-main", Size: 12, Content: github.Ptr("replicas: 3\n")},
+				{Ref: "production", Found: false, Error: "not found"},
+				{Ref: "staging", Found: true, SHA: "sha-staging", Size: 12, Content: github.Ptr("replicas: 1\n")},
+			},
+		},
+		{
+			name:         "no refs",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "config.yml",
+				"refs":  []interface{}{},
+			},
+			expectError:    false,
+			expectedErrMsg: "missing required parameter: refs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetFileAtRefs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Path  string      `json:"path"`
+				Files []fileAtRef `json:"files"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, "config.yml", response.Path)
+			assert.Equal(t, tc.expectedFiles, response.Files)
+		})
+	}
+}
+
 func Test_ForkRepository(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -475,6 +601,184 @@ func Test_CreateBranch(t *testing.T) {
 	}
 }
 
+func Test_CreateTag(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateTag(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_tag", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "tag")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "tagger_name")
+	assert.Contains(t, tool.InputSchema.Properties, "tagger_email")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag", "sha"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name: "lightweight tag",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"ref": "refs/tags/v1.0.0",
+						"sha": "abc123",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{
+							Ref:    github.Ptr("refs/tags/v1.0.0"),
+							Object: &github.GitObject{SHA: github.Ptr("abc123"), Type: github.Ptr("commit")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.0.0",
+				"sha":   "abc123",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"tag":       "v1.0.0",
+				"ref":       "refs/tags/v1.0.0",
+				"sha":       "abc123",
+				"annotated": false,
+			},
+		},
+		{
+			name: "annotated tag",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTagsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"tag":     "v1.0.0",
+						"message": "First release",
+						"object":  "abc123",
+						"type":    "commit",
+						"tagger": map[string]interface{}{
+							"name":  "Mona",
+							"email": "mona@example.com",
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Tag{
+							Tag: github.Ptr("v1.0.0"),
+							SHA: github.Ptr("def456"),
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"ref": "refs/tags/v1.0.0",
+						"sha": "def456",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{
+							Ref:    github.Ptr("refs/tags/v1.0.0"),
+							Object: &github.GitObject{SHA: github.Ptr("def456"), Type: github.Ptr("tag")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"tag":          "v1.0.0",
+				"sha":          "abc123",
+				"message":      "First release",
+				"tagger_name":  "Mona",
+				"tagger_email": "mona@example.com",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"tag":       "v1.0.0",
+				"ref":       "refs/tags/v1.0.0",
+				"sha":       "abc123",
+				"annotated": true,
+				"tag_sha":   "def456",
+			},
+		},
+		{
+			name: "tag already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Reference already exists"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.0.0",
+				"sha":   "abc123",
+			},
+			expectError:    false,
+			expectedErrMsg: "tag v1.0.0 already exists in owner/repo",
+		},
+		{
+			name:         "tagger without message",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"tag":          "v1.0.0",
+				"sha":          "abc123",
+				"tagger_name":  "Mona",
+				"tagger_email": "mona@example.com",
+			},
+			expectError:    false,
+			expectedErrMsg: "a tagger can only be given with a message",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateTag(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
 func Test_GetCommit(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -600,138 +904,114 @@ func Test_GetCommit(t *testing.T) {
 	}
 }
 
-func Test_ListCommits(t *testing.T) {
+// servePatch answers patch requests with the given patch and every other request with the given JSON object,
+// since the raw patch and the JSON object are served from the same endpoint.
+func servePatch(t *testing.T, patch string, object interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.Header.Get("Accept"), ".patch") {
+			_, _ = w.Write([]byte(patch))
+			return
+		}
+		mockResponse(t, http.StatusOK, object)(w, r)
+	}
+}
+
+func Test_GetCommitPatch(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := ListCommits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetCommitPatch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 
-	assert.Equal(t, "list_commits", tool.Name)
+	assert.Equal(t, "get_commit_patch", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "sha")
-	assert.Contains(t, tool.InputSchema.Properties, "page")
-	assert.Contains(t, tool.InputSchema.Properties, "perPage")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
 
-	// Setup mock commits for success case
-	mockCommits := []*github.RepositoryCommit{
-		{
-			SHA: github.Ptr("abc123def456"),
-			Commit: &github.Commit{
-				Message: github.Ptr("First commit"),
-				Author: &github.CommitAuthor{
-					Name:  github.Ptr("Test User"),
-					Email: github.Ptr("test@example.com"),
-					Date:  &github.Timestamp{Time: time.Now().Add(-48 * time.Hour)},
-				},
-			},
-			Author: &github.User{
-				Login: github.Ptr("testuser"),
-			},
-			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123def456"),
-		},
-		{
-			SHA: github.Ptr("def456abc789"),
-			Commit: &github.Commit{
-				Message: github.Ptr("Second commit"),
-				Author: &github.CommitAuthor{
-					Name:  github.Ptr("Another User"),
-					Email: github.Ptr("another@example.com"),
-					Date:  &github.Timestamp{Time: time.Now().Add(-24 * time.Hour)},
-				},
-			},
-			Author: &github.User{
-				Login: github.Ptr("anotheruser"),
-			},
-			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/def456abc789"),
-		},
+	smallPatch := "From abc123 Mon Sep 17 00:00:00 2001\nSubject: [PATCH] Fix typo\n\n---\n README.md | 2 +-\n"
+	largePatch := "From abc123 Mon Sep 17 00:00:00 2001\n" + strings.Repeat("+a line added by the commit\n", maxPatchBytes/20)
+	mockCommit := &github.RepositoryCommit{
+		SHA:     github.Ptr("abc123"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123"),
 	}
 
 	tests := []struct {
-		name            string
-		mockedClient    *http.Client
-		requestArgs     map[string]interface{}
-		expectError     bool
-		expectedCommits []*github.RepositoryCommit
-		expectedErrMsg  string
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedTruncated bool
+		expectedPatchURL  interface{}
+		expectedTotal     int
 	}{
 		{
-			name: "successful commits fetch with default params",
+			name: "small patch returned in full",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatch(
-					mock.GetReposCommitsByOwnerByRepo,
-					mockCommits,
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					servePatch(t, smallPatch, mockCommit),
 				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner": "owner",
 				"repo":  "repo",
+				"sha":   "abc123",
 			},
-			expectError:     false,
-			expectedCommits: mockCommits,
+			expectError:       false,
+			expectedTruncated: false,
+			expectedPatchURL:  nil,
+			expectedTotal:     len(smallPatch),
 		},
 		{
-			name: "successful commits fetch with branch",
+			name: "large patch truncated",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposCommitsByOwnerByRepo,
-					expectQueryParams(t, map[string]string{
-						"sha":      "main",
-						"page":     "1",
-						"per_page": "30",
-					}).andThen(
-						mockResponse(t, http.StatusOK, mockCommits),
-					),
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					servePatch(t, largePatch, mockCommit),
 				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner": "owner",
 				"repo":  "repo",
-				"sha":   "main",
+				"sha":   "abc123",
 			},
-			expectError:     false,
-			expectedCommits: mockCommits,
+			expectError:       false,
+			expectedTruncated: true,
+			expectedPatchURL:  "https://github.com/owner/repo/commit/abc123.patch",
+			expectedTotal:     len(largePatch),
 		},
 		{
-			name: "successful commits fetch with pagination",
+			name: "commit not found",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposCommitsByOwnerByRepo,
-					expectQueryParams(t, map[string]string{
-						"page":     "2",
-						"per_page": "10",
-					}).andThen(
-						mockResponse(t, http.StatusOK, mockCommits),
-					),
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":   "owner",
-				"repo":    "repo",
-				"page":    float64(2),
-				"perPage": float64(10),
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "missing",
 			},
-			expectError:     false,
-			expectedCommits: mockCommits,
+			expectError:    false,
+			expectedErrMsg: "commit missing not found in owner/repo",
 		},
 		{
-			name: "commits fetch fails",
+			name: "patch fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposCommitsByOwnerByRepo,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
-					}),
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Server Error"}),
 				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner": "owner",
-				"repo":  "nonexistent-repo",
+				"repo":  "repo",
+				"sha":   "abc123",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to list commits",
+			expectedErrMsg: "failed to get commit patch",
 		},
 	}
 
@@ -739,7 +1019,7 @@ func Test_ListCommits(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := ListCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := GetCommitPatch(stubGetClientFn(client), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -755,144 +1035,157 @@ func Test_ListCommits(t *testing.T) {
 			}
 
 			require.NoError(t, err)
-
-			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
 			// Unmarshal and verify the result
-			var returnedCommits []*github.RepositoryCommit
-			err = json.Unmarshal([]byte(textContent.Text), &returnedCommits)
+			var response struct {
+				Patch      string      `json:"patch"`
+				Truncated  bool        `json:"truncated"`
+				TotalBytes int         `json:"total_bytes"`
+				PatchURL   interface{} `json:"patch_url"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
 			require.NoError(t, err)
-			assert.Len(t, returnedCommits, len(tc.expectedCommits))
-			for i, commit := range returnedCommits {
-				assert.Equal(t, *tc.expectedCommits[i].SHA, *commit.SHA)
-				assert.Equal(t, *tc.expectedCommits[i].Commit.Message, *commit.Commit.Message)
-				assert.Equal(t, *tc.expectedCommits[i].Author.Login, *commit.Author.Login)
-				assert.Equal(t, *tc.expectedCommits[i].HTMLURL, *commit.HTMLURL)
+			assert.Equal(t, tc.expectedTruncated, response.Truncated)
+			assert.Equal(t, tc.expectedTotal, response.TotalBytes)
+			assert.Equal(t, tc.expectedPatchURL, response.PatchURL)
+			if tc.expectedTruncated {
+				assert.LessOrEqual(t, len(response.Patch), maxPatchBytes)
+				assert.True(t, strings.HasSuffix(response.Patch, "\n"))
+			} else {
+				assert.Equal(t, smallPatch, response.Patch)
 			}
 		})
 	}
 }
 
-func Test_CreateOrUpdateFile(t *testing.T) {
+func Test_GetCommitVerification(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := CreateOrUpdateFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetCommitVerification(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 
-	assert.Equal(t, "create_or_update_file", tool.Name)
+	assert.Equal(t, "get_commit_verification", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "path")
-	assert.Contains(t, tool.InputSchema.Properties, "content")
-	assert.Contains(t, tool.InputSchema.Properties, "message")
-	assert.Contains(t, tool.InputSchema.Properties, "branch")
 	assert.Contains(t, tool.InputSchema.Properties, "sha")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "content", "message", "branch"})
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
 
-	// Setup mock file content response
-	mockFileResponse := &github.RepositoryContentResponse{
-		Content: &github.RepositoryContent{
-			Name:        github.Ptr("example.md"),
-			Path:        github.Ptr("docs/example.md"),
-			SHA:         github.Ptr("abc123def456"),
-			Size:        github.Ptr(42),
-			HTMLURL:     github.Ptr("https://github.com/owner/repo/blob/main/docs/example.md"),
-			DownloadURL: github.Ptr("https://raw.githubusercontent.com/owner/repo/main/docs/example.md"),
-		},
-		Commit: github.Commit{
-			SHA:     github.Ptr("def456abc789"),
-			Message: github.Ptr("Add example file"),
-			Author: &github.CommitAuthor{
-				Name:  github.Ptr("Test User"),
-				Email: github.Ptr("test@example.com"),
-				Date:  &github.Timestamp{Time: time.Now()},
-			},
-			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/def456abc789"),
-		},
+	commitWithVerification := func(verification *github.SignatureVerification) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA:    github.Ptr("abc123"),
+			Commit: &github.Commit{Verification: verification},
+		}
 	}
 
 	tests := []struct {
-		name            string
-		mockedClient    *http.Client
-		requestArgs     map[string]interface{}
-		expectError     bool
-		expectedContent *github.RepositoryContentResponse
-		expectedErrMsg  string
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       map[string]interface{}
 	}{
 		{
-			name: "successful file creation",
+			name: "verified ssh signature",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.PutReposContentsByOwnerByRepoByPath,
-					expectRequestBody(t, map[string]interface{}{
-						"message": "Add example file",
-						"content": "IyBFeGFtcGxlCgpUaGlzIGlzIGFuIGV4YW1wbGUgZmlsZS4=", // Base64 encoded content
-						"branch":  "main",
-					}).andThen(
-						mockResponse(t, http.StatusOK, mockFileResponse),
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					expectQueryParams(t, map[string]string{"per_page": "1"}).andThen(
+						mockResponse(t, http.StatusOK, commitWithVerification(&github.SignatureVerification{
+							Verified:  github.Ptr(true),
+							Reason:    github.Ptr("valid"),
+							Signature: github.Ptr("-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n-----END SSH SIGNATURE-----"),
+						})),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":   "owner",
-				"repo":    "repo",
-				"path":    "docs/example.md",
-				"content": "# Example\n\nThis is an example file.",
-				"message": "Add example file",
-				"branch":  "main",
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "main",
+			},
+			expectError: false,
+			expected: map[string]interface{}{
+				"sha":            "abc123",
+				"verified":       true,
+				"reason":         "valid",
+				"signed":         true,
+				"signature_type": "ssh",
 			},
-			expectError:     false,
-			expectedContent: mockFileResponse,
 		},
 		{
-			name: "successful file update with SHA",
+			name: "unsigned commit",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.PutReposContentsByOwnerByRepoByPath,
-					expectRequestBody(t, map[string]interface{}{
-						"message": "Update example file",
-						"content": "IyBVcGRhdGVkIEV4YW1wbGUKClRoaXMgZmlsZSBoYXMgYmVlbiB1cGRhdGVkLg==", // Base64 encoded content
-						"branch":  "main",
-						"sha":     "abc123def456",
-					}).andThen(
-						mockResponse(t, http.StatusOK, mockFileResponse),
-					),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					commitWithVerification(&github.SignatureVerification{
+						Verified: github.Ptr(false),
+						Reason:   github.Ptr("unsigned"),
+					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":   "owner",
-				"repo":    "repo",
-				"path":    "docs/example.md",
-				"content": "# Updated Example\n\nThis file has been updated.",
-				"message": "Update example file",
-				"branch":  "main",
-				"sha":     "abc123def456",
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			},
+			expectError: false,
+			expected: map[string]interface{}{
+				"sha":            "abc123",
+				"verified":       false,
+				"reason":         "unsigned",
+				"signed":         false,
+				"signature_type": nil,
 			},
-			expectError:     false,
-			expectedContent: mockFileResponse,
 		},
 		{
-			name: "file creation fails",
+			name: "gpg signature that failed verification",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.PutReposContentsByOwnerByRepoByPath,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusUnprocessableEntity)
-						_, _ = w.Write([]byte(`{"message": "Invalid request"}`))
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					commitWithVerification(&github.SignatureVerification{
+						Verified:  github.Ptr(false),
+						Reason:    github.Ptr("unknown_key"),
+						Signature: github.Ptr("-----BEGIN PGP SIGNATURE-----\niQIz\n-----END PGP SIGNATURE-----"),
 					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":   "owner",
-				"repo":    "repo",
-				"path":    "docs/example.md",
-				"content": "#Invalid Content",
-				"message": "Invalid request",
-				"branch":  "nonexistent-branch",
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
 			},
-			expectError:    true,
-			expectedErrMsg: "failed to create/update file",
+			expectError: false,
+			expected: map[string]interface{}{
+				"sha":            "abc123",
+				"verified":       false,
+				"reason":         "unknown_key",
+				"signed":         true,
+				"signature_type": "gpg",
+			},
+		},
+		{
+			name: "commit not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "No commit found for SHA: missing"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "commit missing not found in owner/repo",
 		},
 	}
 
@@ -900,7 +1193,7 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := CreateOrUpdateFile(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := GetCommitVerification(stubGetClientFn(client), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -916,131 +1209,155 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 			}
 
 			require.NoError(t, err)
-
-			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
 			// Unmarshal and verify the result
-			var returnedContent github.RepositoryContentResponse
-			err = json.Unmarshal([]byte(textContent.Text), &returnedContent)
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
 			require.NoError(t, err)
-
-			// Verify content
-			assert.Equal(t, *tc.expectedContent.Content.Name, *returnedContent.Content.Name)
-			assert.Equal(t, *tc.expectedContent.Content.Path, *returnedContent.Content.Path)
-			assert.Equal(t, *tc.expectedContent.Content.SHA, *returnedContent.Content.SHA)
-
-			// Verify commit
-			assert.Equal(t, *tc.expectedContent.Commit.SHA, *returnedContent.Commit.SHA)
-			assert.Equal(t, *tc.expectedContent.Commit.Message, *returnedContent.Commit.Message)
+			assert.Equal(t, tc.expected, response)
 		})
 	}
 }
 
-func Test_CreateRepository(t *testing.T) {
+func Test_ListCommits(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := CreateRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := ListCommits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 
-	assert.Equal(t, "create_repository", tool.Name)
+	assert.Equal(t, "list_commits", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "name")
-	assert.Contains(t, tool.InputSchema.Properties, "description")
-	assert.Contains(t, tool.InputSchema.Properties, "private")
-	assert.Contains(t, tool.InputSchema.Properties, "autoInit")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
-	// Setup mock repository response
-	mockRepo := &github.Repository{
-		Name:        github.Ptr("test-repo"),
-		Description: github.Ptr("Test repository"),
-		Private:     github.Ptr(true),
-		HTMLURL:     github.Ptr("https://github.com/testuser/test-repo"),
-		CloneURL:    github.Ptr("https://github.com/testuser/test-repo.git"),
-		CreatedAt:   &github.Timestamp{Time: time.Now()},
-		Owner: &github.User{
-			Login: github.Ptr("testuser"),
+	// Setup mock commits for success case
+	mockCommits := []*github.RepositoryCommit{
+		{
+			SHA: github.Ptr("abc123def456"),
+			Commit: &github.Commit{
+				Message: github.Ptr("First commit"),
+				Author: &github.CommitAuthor{
+					Name:  github.Ptr("Test User"),
+					Email: github.Ptr("test@example.com"),
+					Date:  &github.Timestamp{Time: time.Now().Add(-48 * time.Hour)},
+				},
+			},
+			Author: &github.User{
+				Login: github.Ptr("testuser"),
+			},
+			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123def456"),
+		},
+		{
+			SHA: github.Ptr("def456abc789"),
+			Commit: &github.Commit{
+				Message: github.Ptr("Second commit"),
+				Author: &github.CommitAuthor{
+					Name:  github.Ptr("Another User"),
+					Email: github.Ptr("another@example.com"),
+					Date:  &github.Timestamp{Time: time.Now().Add(-24 * time.Hour)},
+				},
+			},
+			Author: &github.User{
+				Login: github.Ptr("anotheruser"),
+			},
+			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/def456abc789"),
 		},
 	}
 
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]interface{}
-		expectError    bool
-		expectedRepo   *github.Repository
-		expectedErrMsg string
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedCommits []*github.RepositoryCommit
+		expectedErrMsg  string
 	}{
 		{
-			name: "successful repository creation with all parameters",
+			name: "successful commits fetch with default params",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.EndpointPattern{
-						Pattern: "/user/repos",
-						Method:  "POST",
-					},
-					expectRequestBody(t, map[string]interface{}{
-						"name":        "test-repo",
-						"description": "Test repository",
-						"private":     true,
-						"auto_init":   true,
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepo,
+					mockCommits,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:     false,
+			expectedCommits: mockCommits,
+		},
+		{
+			name: "successful commits fetch with branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"sha":      "main",
+						"page":     "1",
+						"per_page": "30",
 					}).andThen(
-						mockResponse(t, http.StatusCreated, mockRepo),
+						mockResponse(t, http.StatusOK, mockCommits),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"name":        "test-repo",
-				"description": "Test repository",
-				"private":     true,
-				"autoInit":    true,
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "main",
 			},
-			expectError:  false,
-			expectedRepo: mockRepo,
+			expectError:     false,
+			expectedCommits: mockCommits,
 		},
 		{
-			name: "successful repository creation with minimal parameters",
+			name: "successful commits fetch with pagination",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.EndpointPattern{
-						Pattern: "/user/repos",
-						Method:  "POST",
-					},
-					expectRequestBody(t, map[string]interface{}{
-						"name":        "test-repo",
-						"auto_init":   false,
-						"description": "",
-						"private":     false,
+					mock.GetReposCommitsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "10",
 					}).andThen(
-						mockResponse(t, http.StatusCreated, mockRepo),
+						mockResponse(t, http.StatusOK, mockCommits),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"name": "test-repo",
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(2),
+				"perPage": float64(10),
 			},
-			expectError:  false,
-			expectedRepo: mockRepo,
+			expectError:     false,
+			expectedCommits: mockCommits,
 		},
 		{
-			name: "repository creation fails",
+			name: "commits fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.EndpointPattern{
-						Pattern: "/user/repos",
-						Method:  "POST",
-					},
+					mock.GetReposCommitsByOwnerByRepo,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusUnprocessableEntity)
-						_, _ = w.Write([]byte(`{"message": "Repository creation failed"}`))
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
 					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"name": "invalid-repo",
+				"owner": "owner",
+				"repo":  "nonexistent-repo",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to create repository",
+			expectedErrMsg: "failed to list commits",
 		},
 	}
 
@@ -1048,7 +1365,7 @@ func Test_CreateRepository(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := CreateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := ListCommits(stubGetClientFn(client), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -1069,311 +1386,4926 @@ func Test_CreateRepository(t *testing.T) {
 			textContent := getTextResult(t, result)
 
 			// Unmarshal and verify the result
-			var returnedRepo github.Repository
-			err = json.Unmarshal([]byte(textContent.Text), &returnedRepo)
-			assert.NoError(t, err)
-
-			// Verify repository details
-			assert.Equal(t, *tc.expectedRepo.Name, *returnedRepo.Name)
-			assert.Equal(t, *tc.expectedRepo.Description, *returnedRepo.Description)
-			assert.Equal(t, *tc.expectedRepo.Private, *returnedRepo.Private)
-			assert.Equal(t, *tc.expectedRepo.HTMLURL, *returnedRepo.HTMLURL)
-			assert.Equal(t, *tc.expectedRepo.Owner.Login, *returnedRepo.Owner.Login)
+			var returnedCommits []*github.RepositoryCommit
+			err = json.Unmarshal([]byte(textContent.Text), &returnedCommits)
+			require.NoError(t, err)
+			assert.Len(t, returnedCommits, len(tc.expectedCommits))
+			for i, commit := range returnedCommits {
+				assert.Equal(t, *tc.expectedCommits[i].SHA, *commit.SHA)
+				assert.Equal(t, *tc.expectedCommits[i].Commit.Message, *commit.Commit.Message)
+				assert.Equal(t, *tc.expectedCommits[i].Author.Login, *commit.Author.Login)
+				assert.Equal(t, *tc.expectedCommits[i].HTMLURL, *commit.HTMLURL)
+			}
 		})
 	}
 }
 
-func Test_PushFiles(t *testing.T) {
+func Test_ListCommitsSince(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := PushFiles(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := ListCommitsSince(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 
-	assert.Equal(t, "push_files", tool.Name)
+	assert.Equal(t, "list_commits_since", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "branch")
-	assert.Contains(t, tool.InputSchema.Properties, "files")
-	assert.Contains(t, tool.InputSchema.Properties, "message")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "files", "message"})
-
-	// Setup mock objects
-	mockRef := &github.Reference{
-		Ref: github.Ptr("refs/heads/main"),
-		Object: &github.GitObject{
-			SHA: github.Ptr("abc123"),
-			URL: github.Ptr("https://api.github.com/repos/owner/repo/git/trees/abc123"),
-		},
-	}
-
-	mockCommit := &github.Commit{
-		SHA: github.Ptr("abc123"),
-		Tree: &github.Tree{
-			SHA: github.Ptr("def456"),
-		},
-	}
-
-	mockTree := &github.Tree{
-		SHA: github.Ptr("ghi789"),
-	}
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "since"})
 
-	mockNewCommit := &github.Commit{
-		SHA:     github.Ptr("jkl012"),
-		Message: github.Ptr("Update multiple files"),
-		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/jkl012"),
+	mockCommits := []*github.RepositoryCommit{
+		{SHA: github.Ptr("ccc3333333"), Commit: &github.Commit{Message: github.Ptr("Third\n\nDetails")}},
+		{SHA: github.Ptr("bbb2222222"), Commit: &github.Commit{Message: github.Ptr("Second")}},
+		{SHA: github.Ptr("aaa1111111"), Commit: &github.Commit{Message: github.Ptr("First")}},
 	}
 
-	mockUpdatedRef := &github.Reference{
-		Ref: github.Ptr("refs/heads/main"),
-		Object: &github.GitObject{
-			SHA: github.Ptr("jkl012"),
-			URL: github.Ptr("https://api.github.com/repos/owner/repo/git/trees/jkl012"),
-		},
-	}
+	// A long history, 100 commits a page, none of them the since commit
+	longHistory := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		page = max(page, 1)
+		commits := make([]*github.RepositoryCommit, 0, 100)
+		for i := 0; i < 100; i++ {
+			commits = append(commits, &github.RepositoryCommit{SHA: github.Ptr(fmt.Sprintf("%07d%03d", page, i))})
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<https://api.github.com/repos/owner/repo/commits?page=%d>; rel="next"`, page+1))
+		mockResponse(t, http.StatusOK, commits)(w, r)
+	})
 
-	// Define test cases
 	tests := []struct {
 		name           string
 		mockedClient   *http.Client
 		requestArgs    map[string]interface{}
 		expectError    bool
-		expectedRef    *github.Reference
 		expectedErrMsg string
+		expectedSHAs   []string
 	}{
 		{
-			name: "successful push of multiple files",
+			name: "commits after the since commit",
 			mockedClient: mock.NewMockedHTTPClient(
-				// Get branch reference
-				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockRef,
-				),
-				// Get commit
-				mock.WithRequestMatch(
-					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
-					mockCommit,
-				),
-				// Create tree
-				mock.WithRequestMatchHandler(
-					mock.PostReposGitTreesByOwnerByRepo,
-					expectRequestBody(t, map[string]interface{}{
-						"base_tree": "def456",
-						"tree": []interface{}{
-							map[string]interface{}{
-								"path":    "README.md",
-								"mode":    "100644",
-								"type":    "blob",
-								"content": "# Updated README\n\nThis is an updated README file.",
-							},
-							map[string]interface{}{
-								"path":    "docs/example.md",
-								"mode":    "100644",
-								"type":    "blob",
-								"content": "# Example\n\nThis is an example file.",
-							},
-						},
-					}).andThen(
-						mockResponse(t, http.StatusCreated, mockTree),
-					),
-				),
-				// Create commit
 				mock.WithRequestMatchHandler(
-					mock.PostReposGitCommitsByOwnerByRepo,
-					expectRequestBody(t, map[string]interface{}{
-						"message": "Update multiple files",
-						"tree":    "ghi789",
-						"parents": []interface{}{"abc123"},
-					}).andThen(
-						mockResponse(t, http.StatusCreated, mockNewCommit),
-					),
-				),
-				// Update reference
-				mock.WithRequestMatchHandler(
-					mock.PatchReposGitRefsByOwnerByRepoByRef,
-					expectRequestBody(t, map[string]interface{}{
-						"sha":   "jkl012",
-						"force": false,
+					mock.GetReposCommitsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"sha":      "main",
+						"per_page": "100",
 					}).andThen(
-						mockResponse(t, http.StatusOK, mockUpdatedRef),
+						mockResponse(t, http.StatusOK, mockCommits),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "repo",
-				"branch": "main",
-				"files": []interface{}{
-					map[string]interface{}{
-						"path":    "README.md",
-						"content": "# Updated README\n\nThis is an updated README file.",
-					},
-					map[string]interface{}{
-						"path":    "docs/example.md",
-						"content": "# Example\n\nThis is an example file.",
-					},
-				},
-				"message": "Update multiple files",
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+				"since": "AAA1111",
 			},
-			expectError: false,
-			expectedRef: mockUpdatedRef,
+			expectError:  false,
+			expectedSHAs: []string{"ccc3333333", "bbb2222222"},
 		},
 		{
-			name:         "fails when files parameter is invalid",
+			name: "history gap exceeds the cap",
 			mockedClient: mock.NewMockedHTTPClient(
-			// No requests expected
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					longHistory,
+				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":   "owner",
-				"repo":    "repo",
-				"branch":  "main",
-				"files":   "invalid-files-parameter", // Not an array
-				"message": "Update multiple files",
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "aaa1111",
 			},
-			expectError:    false, // This returns a tool error, not a Go error
-			expectedErrMsg: "files parameter must be an array",
+			expectError:    false,
+			expectedErrMsg: "commit aaa1111 not found in the last 500 commits of the default branch: the history gap exceeds the cap",
 		},
 		{
-			name: "fails when files contains object without path",
+			name: "since commit not in the history",
 			mockedClient: mock.NewMockedHTTPClient(
-				// Get branch reference
 				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockRef,
-				),
-				// Get commit
-				mock.WithRequestMatch(
-					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
-					mockCommit,
+					mock.GetReposCommitsByOwnerByRepo,
+					mockCommits,
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "repo",
-				"branch": "main",
-				"files": []interface{}{
-					map[string]interface{}{
-						"content": "# Missing path",
-					},
-				},
-				"message": "Update file",
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+				"since": "ddd4444",
 			},
-			expectError:    false, // This returns a tool error, not a Go error
-			expectedErrMsg: "each file must have a path",
+			expectError:    false,
+			expectedErrMsg: "commit ddd4444 is not in the history of main",
 		},
 		{
-			name: "fails when files contains object without content",
+			name:         "since too short",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "aaa",
+			},
+			expectError:    false,
+			expectedErrMsg: "since must be a commit SHA of at least 7 characters",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCommitsSince(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Since   string `json:"since"`
+				Count   int    `json:"count"`
+				Commits []struct {
+					SHA     string `json:"sha"`
+					Message string `json:"message"`
+				} `json:"commits"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, "aaa1111111", response.Since)
+			assert.Equal(t, len(tc.expectedSHAs), response.Count)
+			shas := make([]string, 0, len(response.Commits))
+			for _, commit := range response.Commits {
+				shas = append(shas, commit.SHA)
+			}
+			assert.Equal(t, tc.expectedSHAs, shas)
+			assert.Equal(t, "Third", response.Commits[0].Message)
+		})
+	}
+}
+
+func Test_CommitTouches(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CommitTouches(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "commit_touches", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "globs")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha", "globs"})
+
+	mockCommit := &github.RepositoryCommit{
+		SHA: github.Ptr("abc123"),
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("db/migrations/001_init.sql")},
+			{Filename: github.Ptr("pkg/store/store.go")},
+			{Filename: github.Ptr("docs/schema.md"), PreviousFilename: github.Ptr("schema.sql")},
+		},
+	}
+
+	type globMatch struct {
+		Glob    string   `json:"glob"`
+		Touched bool     `json:"touched"`
+		Paths   []string `json:"paths"`
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedTouched bool
+		expectedGlobs   []globMatch
+	}{
+		{
+			name: "matching and non-matching globs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					mockCommit,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"globs": []interface{}{"*.sql", "/pkg/", "*.py"},
+			},
+			expectError:     false,
+			expectedTouched: true,
+			expectedGlobs: []globMatch{
+				{Glob: "*.sql", Touched: true, Paths: []string{"db/migrations/001_init.sql", "schema.sql"}},
+				{Glob: "/pkg/", Touched: true, Paths: []string{"pkg/store/store.go"}},
+				{Glob: "*.py", Touched: false, Paths: []string{}},
+			},
+		},
+		{
+			name: "no glob matches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					mockCommit,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"globs": []interface{}{"/store/"},
+			},
+			expectError:     false,
+			expectedTouched: false,
+			expectedGlobs: []globMatch{
+				{Glob: "/store/", Touched: false, Paths: []string{}},
+			},
+		},
+		{
+			name:         "unsupported glob",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"globs": []interface{}{"!*.sql"},
+			},
+			expectError:    false,
+			expectedErrMsg: `negated pattern "!*.sql" is not supported`,
+		},
+		{
+			name: "commit not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "No commit found for SHA: missing"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "missing",
+				"globs": []interface{}{"*.sql"},
+			},
+			expectError:    false,
+			expectedErrMsg: "commit missing not found in owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CommitTouches(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				FilesChanged int         `json:"files_changed"`
+				Touched      bool        `json:"touched"`
+				Globs        []globMatch `json:"globs"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, 3, response.FilesChanged)
+			assert.Equal(t, tc.expectedTouched, response.Touched)
+			assert.Equal(t, tc.expectedGlobs, response.Globs)
+		})
+	}
+}
+
+func Test_GetLatestCommitForFile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetLatestCommitForFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_latest_commit_for_file", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	authoredAt := time.Now().Add(-10 * 24 * time.Hour).UTC().Truncate(time.Second)
+	mockCommit := &github.RepositoryCommit{
+		SHA: github.Ptr("abc123def456"),
+		Commit: &github.Commit{
+			Message: github.Ptr("Fix the parser\n\nIt choked on empty lines."),
+			Author: &github.CommitAuthor{
+				Name:  github.Ptr("Mona Lisa"),
+				Email: github.Ptr("mona@example.com"),
+				Date:  &github.Timestamp{Time: authoredAt},
+			},
+			Committer: &github.CommitAuthor{
+				Date: &github.Timestamp{Time: authoredAt.Add(time.Hour)},
+			},
+		},
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123def456"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "last commit of a file at a ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"path":     "pkg/parser.go",
+						"sha":      "main",
+						"per_page": "1",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.RepositoryCommit{mockCommit}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "pkg/parser.go",
+				"ref":   "main",
+			},
+			expectError: false,
+		},
+		{
+			name: "file not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepo,
+					[]*github.RepositoryCommit{},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "missing.go",
+				"ref":   "main",
+			},
+			expectError:    false,
+			expectedErrMsg: "file not found: missing.go in owner/repo at main",
+		},
+		{
+			name: "commit listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "pkg/parser.go",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list commits",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetLatestCommitForFile(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				SHA      string `json:"sha"`
+				ShortSHA string `json:"short_sha"`
+				Message  string `json:"message"`
+				Author   struct {
+					Name  string    `json:"name"`
+					Email string    `json:"email"`
+					Date  time.Time `json:"date"`
+				} `json:"author"`
+				Committer struct {
+					Date time.Time `json:"date"`
+				} `json:"committer"`
+				HTMLURL string `json:"html_url"`
+				AgeDays int    `json:"age_days"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, "abc123def456", response.SHA)
+			assert.Equal(t, "abc123d", response.ShortSHA)
+			assert.Equal(t, "Fix the parser", response.Message)
+			assert.Equal(t, "Mona Lisa", response.Author.Name)
+			assert.Equal(t, "mona@example.com", response.Author.Email)
+			assert.True(t, authoredAt.Equal(response.Author.Date))
+			assert.True(t, authoredAt.Add(time.Hour).Equal(response.Committer.Date))
+			assert.Equal(t, mockCommit.GetHTMLURL(), response.HTMLURL)
+			assert.Equal(t, 10, response.AgeDays)
+		})
+	}
+}
+
+func Test_CreateOrUpdateFile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateOrUpdateFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_or_update_file", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "content", "message", "branch"})
+
+	// Setup mock file content response
+	mockFileResponse := &github.RepositoryContentResponse{
+		Content: &github.RepositoryContent{
+			Name:        github.Ptr("example.md"),
+			Path:        github.Ptr("docs/example.md"),
+			SHA:         github.Ptr("abc123def456"),
+			Size:        github.Ptr(42),
+			HTMLURL:     github.Ptr("https://github.com/owner/repo/blob/main/docs/example.md"),
+			DownloadURL: github.Ptr("https://raw.githubusercontent.com/owner/repo/main/docs/example.md"),
+		},
+		Commit: github.Commit{
+			SHA:     github.Ptr("def456abc789"),
+			Message: github.Ptr("Add example file"),
+			Author: &github.CommitAuthor{
+				Name:  github.Ptr("Test User"),
+				Email: github.Ptr("test@example.com"),
+				Date:  &github.Timestamp{Time: time.Now()},
+			},
+			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/def456abc789"),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedContent *github.RepositoryContentResponse
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful file creation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Add example file",
+						"content": "IyBFeGFtcGxlCgpUaGlzIGlzIGFuIGV4YW1wbGUgZmlsZS4=", // Base64 encoded content
+						"branch":  "main",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "# Example\n\nThis is an example file.",
+				"message": "Add example file",
+				"branch":  "main",
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name: "successful file update with SHA",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Update example file",
+						"content": "IyBVcGRhdGVkIEV4YW1wbGUKClRoaXMgZmlsZSBoYXMgYmVlbiB1cGRhdGVkLg==", // Base64 encoded content
+						"branch":  "main",
+						"sha":     "abc123def456",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "# Updated Example\n\nThis file has been updated.",
+				"message": "Update example file",
+				"branch":  "main",
+				"sha":     "abc123def456",
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name: "file creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Invalid request"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "#Invalid Content",
+				"message": "Invalid request",
+				"branch":  "nonexistent-branch",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create/update file",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateOrUpdateFile(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedContent github.RepositoryContentResponse
+			err = json.Unmarshal([]byte(textContent.Text), &returnedContent)
+			require.NoError(t, err)
+
+			// Verify content
+			assert.Equal(t, *tc.expectedContent.Content.Name, *returnedContent.Content.Name)
+			assert.Equal(t, *tc.expectedContent.Content.Path, *returnedContent.Content.Path)
+			assert.Equal(t, *tc.expectedContent.Content.SHA, *returnedContent.Content.SHA)
+
+			// Verify commit
+			assert.Equal(t, *tc.expectedContent.Commit.SHA, *returnedContent.Commit.SHA)
+			assert.Equal(t, *tc.expectedContent.Commit.Message, *returnedContent.Commit.Message)
+		})
+	}
+}
+
+func Test_CreateRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.Contains(t, tool.InputSchema.Properties, "private")
+	assert.Contains(t, tool.InputSchema.Properties, "autoInit")
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "gitignoreTemplate")
+	assert.Contains(t, tool.InputSchema.Properties, "licenseTemplate")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
+
+	// Setup mock repository response
+	mockRepo := &github.Repository{
+		Name:        github.Ptr("test-repo"),
+		Description: github.Ptr("Test repository"),
+		Private:     github.Ptr(true),
+		HTMLURL:     github.Ptr("https://github.com/testuser/test-repo"),
+		CloneURL:    github.Ptr("https://github.com/testuser/test-repo.git"),
+		CreatedAt:   &github.Timestamp{Time: time.Now()},
+		Owner: &github.User{
+			Login: github.Ptr("testuser"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedRepo   *github.Repository
+		expectedErrMsg string
+	}{
+		{
+			name: "successful user repository creation with templates",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					expectRequestBody(t, map[string]interface{}{
+						"name":               "test-repo",
+						"description":        "Test repository",
+						"private":            true,
+						"auto_init":          true,
+						"gitignore_template": "Go",
+						"license_template":   "mit",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name":              "test-repo",
+				"description":       "Test repository",
+				"private":           true,
+				"autoInit":          true,
+				"gitignoreTemplate": "Go",
+				"licenseTemplate":   "mit",
+			},
+			expectError:  false,
+			expectedRepo: mockRepo,
+		},
+		{
+			name: "successful organization repository creation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/orgs/test-org/repos",
+						Method:  "POST",
+					},
+					mockResponse(t, http.StatusCreated, mockRepo),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name":        "test-repo",
+				"org":         "test-org",
+				"description": "Test repository",
+				"private":     true,
+			},
+			expectError:  false,
+			expectedRepo: mockRepo,
+		},
+		{
+			name: "repository name already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Repository creation failed.", "errors": [{"resource": "Repository", "code": "custom", "field": "name", "message": "name already exists on this account"}]}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name": "test-repo",
+			},
+			expectError:    false,
+			expectedErrMsg: "repository already exists",
+		},
+		{
+			name: "successful repository creation with all parameters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					expectRequestBody(t, map[string]interface{}{
+						"name":        "test-repo",
+						"description": "Test repository",
+						"private":     true,
+						"auto_init":   true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name":        "test-repo",
+				"description": "Test repository",
+				"private":     true,
+				"autoInit":    true,
+			},
+			expectError:  false,
+			expectedRepo: mockRepo,
+		},
+		{
+			name: "successful repository creation with minimal parameters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					expectRequestBody(t, map[string]interface{}{
+						"name":        "test-repo",
+						"auto_init":   false,
+						"description": "",
+						"private":     false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name": "test-repo",
+			},
+			expectError:  false,
+			expectedRepo: mockRepo,
+		},
+		{
+			name: "repository creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Repository creation failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name": "invalid-repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returnedRepo github.Repository
+			err = json.Unmarshal([]byte(textContent.Text), &returnedRepo)
+			assert.NoError(t, err)
+
+			// Verify repository details
+			assert.Equal(t, *tc.expectedRepo.Name, *returnedRepo.Name)
+			assert.Equal(t, *tc.expectedRepo.Description, *returnedRepo.Description)
+			assert.Equal(t, *tc.expectedRepo.Private, *returnedRepo.Private)
+			assert.Equal(t, *tc.expectedRepo.HTMLURL, *returnedRepo.HTMLURL)
+			assert.Equal(t, *tc.expectedRepo.Owner.Login, *returnedRepo.Owner.Login)
+		})
+	}
+}
+
+func Test_PushFiles(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := PushFiles(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "push_files", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "files")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "files", "message"})
+
+	// Setup mock objects
+	mockRef := &github.Reference{
+		Ref: github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{
+			SHA: github.Ptr("abc123"),
+			URL: github.Ptr("https://api.github.com/repos/owner/repo/git/trees/abc123"),
+		},
+	}
+
+	mockCommit := &github.Commit{
+		SHA: github.Ptr("abc123"),
+		Tree: &github.Tree{
+			SHA: github.Ptr("def456"),
+		},
+	}
+
+	mockTree := &github.Tree{
+		SHA: github.Ptr("ghi789"),
+	}
+
+	mockNewCommit := &github.Commit{
+		SHA:     github.Ptr("jkl012"),
+		Message: github.Ptr("Update multiple files"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/jkl012"),
+	}
+
+	mockUpdatedRef := &github.Reference{
+		Ref: github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{
+			SHA: github.Ptr("jkl012"),
+			URL: github.Ptr("https://api.github.com/repos/owner/repo/git/trees/jkl012"),
+		},
+	}
+
+	// Define test cases
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedRef    *github.Reference
+		expectedErrMsg string
+	}{
+		{
+			name: "successful push of multiple files",
+			mockedClient: mock.NewMockedHTTPClient(
+				// Get branch reference
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				// Get commit
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				// Create tree
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "def456",
+						"tree": []interface{}{
+							map[string]interface{}{
+								"path":    "README.md",
+								"mode":    "100644",
+								"type":    "blob",
+								"content": "# Updated README\n\nThis is an updated README file.",
+							},
+							map[string]interface{}{
+								"path":    "docs/example.md",
+								"mode":    "100644",
+								"type":    "blob",
+								"content": "# Example\n\nThis is an example file.",
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockTree),
+					),
+				),
+				// Create commit
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Update multiple files",
+						"tree":    "ghi789",
+						"parents": []interface{}{"abc123"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockNewCommit),
+					),
+				),
+				// Update reference
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]interface{}{
+						"sha":   "jkl012",
+						"force": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockUpdatedRef),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# Updated README\n\nThis is an updated README file.",
+					},
+					map[string]interface{}{
+						"path":    "docs/example.md",
+						"content": "# Example\n\nThis is an example file.",
+					},
+				},
+				"message": "Update multiple files",
+			},
+			expectError: false,
+			expectedRef: mockUpdatedRef,
+		},
+		{
+			name:         "fails when files parameter is invalid",
+			mockedClient: mock.NewMockedHTTPClient(
+			// No requests expected
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"files":   "invalid-files-parameter", // Not an array
+				"message": "Update multiple files",
+			},
+			expectError:    false, // This returns a tool error, not a Go error
+			expectedErrMsg: "files parameter must be an array",
+		},
+		{
+			name: "fails when files contains object without path",
+			mockedClient: mock.NewMockedHTTPClient(
+				// Get branch reference
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				// Get commit
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"content": "# Missing path",
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    false, // This returns a tool error, not a Go error
+			expectedErrMsg: "each file must have a path",
+		},
+		{
+			name: "fails when files contains object without content",
+			mockedClient: mock.NewMockedHTTPClient(
+				// Get branch reference
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				// Get commit
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path": "README.md",
+						// Missing content
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    false, // This returns a tool error, not a Go error
+			expectedErrMsg: "each file must have content",
+		},
+		{
+			name: "fails to get branch reference",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "non-existent-branch",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# README",
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get branch reference",
+		},
+		{
+			name: "fails to get base commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				// Get branch reference
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				// Fail to get commit
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockResponse(t, http.StatusNotFound, nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# README",
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get base commit",
+		},
+		{
+			name: "fails to create tree",
+			mockedClient: mock.NewMockedHTTPClient(
+				// Get branch reference
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				// Get commit
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				// Fail to create tree
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					mockResponse(t, http.StatusInternalServerError, nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# README",
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create tree",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := PushFiles(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			if tc.expectedErrMsg != "" {
+				require.NotNil(t, result)
+				textContent := getTextResult(t, result)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedRef github.Reference
+			err = json.Unmarshal([]byte(textContent.Text), &returnedRef)
+			require.NoError(t, err)
+
+			assert.Equal(t, *tc.expectedRef.Ref, *returnedRef.Ref)
+			assert.Equal(t, *tc.expectedRef.Object.SHA, *returnedRef.Object.SHA)
+		})
+	}
+}
+
+func Test_ListBranches(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListBranches(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_branches", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// Setup mock branches for success case
+	mockBranches := []*github.Branch{
+		{
+			Name:   github.Ptr("main"),
+			Commit: &github.RepositoryCommit{SHA: github.Ptr("abc123")},
+		},
+		{
+			Name:   github.Ptr("develop"),
+			Commit: &github.RepositoryCommit{SHA: github.Ptr("def456")},
+		},
+	}
+
+	// Test cases
+	tests := []struct {
+		name          string
+		args          map[string]interface{}
+		mockResponses []mock.MockBackendOption
+		wantErr       bool
+		errContains   string
+	}{
+		{
+			name: "success",
+			args: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"page":  float64(2),
+			},
+			mockResponses: []mock.MockBackendOption{
+				mock.WithRequestMatch(
+					mock.GetReposBranchesByOwnerByRepo,
+					mockBranches,
+				),
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing owner",
+			args: map[string]interface{}{
+				"repo": "repo",
+			},
+			mockResponses: []mock.MockBackendOption{},
+			wantErr:       false,
+			errContains:   "missing required parameter: owner",
+		},
+		{
+			name: "missing repo",
+			args: map[string]interface{}{
+				"owner": "owner",
+			},
+			mockResponses: []mock.MockBackendOption{},
+			wantErr:       false,
+			errContains:   "missing required parameter: repo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock client
+			mockClient := github.NewClient(mock.NewMockedHTTPClient(tt.mockResponses...))
+			_, handler := ListBranches(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+			// Create request
+			request := createMCPRequest(tt.args)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.errContains != "" {
+					assert.Contains(t, err.Error(), tt.errContains)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+
+			if tt.errContains != "" {
+				textContent := getTextResult(t, result)
+				assert.Contains(t, textContent.Text, tt.errContains)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			require.NotEmpty(t, textContent.Text)
+
+			// Verify response
+			var branches []*github.Branch
+			err = json.Unmarshal([]byte(textContent.Text), &branches)
+			require.NoError(t, err)
+			assert.Len(t, branches, 2)
+			assert.Equal(t, "main", *branches[0].Name)
+			assert.Equal(t, "develop", *branches[1].Name)
+		})
+	}
+}
+
+func Test_GetForkParent(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetForkParent(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_fork_parent", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name: "fork with parent and source",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{
+						FullName: github.Ptr("me/repo"),
+						Fork:     github.Ptr(true),
+						Parent:   &github.Repository{FullName: github.Ptr("fork/repo")},
+						Source:   &github.Repository{FullName: github.Ptr("upstream/repo")},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "me",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"fork":   true,
+				"parent": "fork/repo",
+				"source": "upstream/repo",
+			},
+		},
+		{
+			name: "not a fork",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{
+						FullName: github.Ptr("upstream/repo"),
+						Fork:     github.Ptr(false),
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "upstream",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"fork": false,
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetForkParent(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_GetMyRepoPermission(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetMyRepoPermission(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_my_repo_permission", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedErrMsg     string
+		expectedPermission string
+	}{
+		{
+			name: "write permission",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{
+						FullName: github.Ptr("owner/repo"),
+						Permissions: map[string]bool{
+							"admin":    false,
+							"maintain": false,
+							"push":     true,
+							"triage":   true,
+							"pull":     true,
+						},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:        false,
+			expectedPermission: "write",
+		},
+		{
+			name: "inaccessible repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "secret",
+			},
+			expectError:        false,
+			expectedPermission: "none",
+		},
+		{
+			name: "request fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Internal Server Error"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetMyRepoPermission(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned map[string]string
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPermission, returned["permission"])
+		})
+	}
+}
+
+func Test_CreateRepositoryFromTemplate(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRepositoryFromTemplate(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_repository_from_template", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "template_owner")
+	assert.Contains(t, tool.InputSchema.Properties, "template_repo")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.Contains(t, tool.InputSchema.Properties, "private")
+	assert.Contains(t, tool.InputSchema.Properties, "include_all_branches")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"template_owner", "template_repo", "name"})
+
+	mockRepo := &github.Repository{
+		FullName:  github.Ptr("test-org/new-service"),
+		HTMLURL:   github.Ptr("https://github.com/test-org/new-service"),
+		CloneURL:  github.Ptr("https://github.com/test-org/new-service.git"),
+		SSHURL:    github.Ptr("git@github.com:test-org/new-service.git"),
+		CreatedAt: &github.Timestamp{Time: time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name: "successful creation from template",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGenerateByTemplateOwnerByTemplateRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"name":                 "new-service",
+						"owner":                "test-org",
+						"description":          "A new service",
+						"private":              true,
+						"include_all_branches": false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner": "test-org",
+				"template_repo":  "service-template",
+				"owner":          "test-org",
+				"name":           "new-service",
+				"description":    "A new service",
+				"private":        true,
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"full_name":  "test-org/new-service",
+				"html_url":   "https://github.com/test-org/new-service",
+				"clone_url":  "https://github.com/test-org/new-service.git",
+				"ssh_url":    "git@github.com:test-org/new-service.git",
+				"created_at": "2025-04-01T12:00:00Z",
+			},
+		},
+		{
+			name: "template not accessible",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGenerateByTemplateOwnerByTemplateRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Repository is not a template"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner": "other-org",
+				"template_repo":  "private-template",
+				"name":           "new-service",
+			},
+			expectError:    false,
+			expectedErrMsg: "cannot create repository from template other-org/private-template",
+		},
+		{
+			name: "template not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGenerateByTemplateOwnerByTemplateRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner": "test-org",
+				"template_repo":  "missing",
+				"name":           "new-service",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create repository from template",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRepositoryFromTemplate(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_ListTemplateRepositories(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTemplateRepositories(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_template_repositories", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	mockSearchResult := &github.RepositoriesSearchResult{
+		Total:             github.Ptr(1),
+		IncompleteResults: github.Ptr(false),
+		Repositories: []*github.Repository{
+			{
+				FullName:   github.Ptr("test-org/service-template"),
+				IsTemplate: github.Ptr(true),
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult *github.RepositoriesSearchResult
+	}{
+		{
+			name: "successful template listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchRepositories,
+					expectQueryParams(t, map[string]string{
+						"q":        "user:test-org template:true",
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockSearchResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "test-org",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+			expectError:    false,
+			expectedResult: mockSearchResult,
+		},
+		{
+			name: "search fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchRepositories,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "missing-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to search template repositories",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListTemplateRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returned github.RepositoriesSearchResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.expectedResult.Total, *returned.Total)
+			require.Len(t, returned.Repositories, 1)
+			assert.Equal(t, *tc.expectedResult.Repositories[0].FullName, *returned.Repositories[0].FullName)
+			assert.True(t, returned.Repositories[0].GetIsTemplate())
+		})
+	}
+}
+
+func Test_GetFunding(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetFunding(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_funding", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	fundingFile := `# These are supported funding model platforms
+github: [octocat, hubot]
+patreon: octocat
+open_collective: # Replace with a single Open Collective username
+ko_fi: ""
+custom: ["https://example.com/donate"]
+`
+	mockFundingContent := &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Name:     github.Ptr("FUNDING.yml"),
+		Path:     github.Ptr(".github/FUNDING.yml"),
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(fundingFile))),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name: "multi-platform funding file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/contents/.github/FUNDING.yml", r.URL.Path)
+						mockResponse(t, http.StatusOK, mockFundingContent)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"funding": map[string]interface{}{
+					"github":  []interface{}{"octocat", "hubot"},
+					"patreon": []interface{}{"octocat"},
+					"custom":  []interface{}{"https://example.com/donate"},
+				},
+			},
+		},
+		{
+			name: "missing funding file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"funding": nil,
+			},
+		},
+		{
+			name: "request fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get funding file",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetFunding(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_GetCommunityDocs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCommunityDocs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_community_docs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// mockDocs answers content requests with the files given, by path, and 404 for any other path
+	mockDocs := func(files map[string]string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			path := strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/contents/")
+			content, ok := files[path]
+			if !ok {
+				mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+				return
+			}
+			assert.Equal(t, "v1", r.URL.Query().Get("ref"))
+			mockResponse(t, http.StatusOK, &github.RepositoryContent{
+				Type:     github.Ptr("file"),
+				Path:     github.Ptr(path),
+				Encoding: github.Ptr("base64"),
+				Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(content))),
+			})(w, r)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name: "docs in .github",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockDocs(map[string]string{
+						".github/CONTRIBUTING.md":    "# Contributing\n",
+						".github/CODE_OF_CONDUCT.md": "# Code of Conduct\n",
+						"CONTRIBUTING.md":            "# Shadowed\n",
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "v1",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"contributing": map[string]interface{}{
+					"path":    ".github/CONTRIBUTING.md",
+					"content": "# Contributing\n",
+				},
+				"code_of_conduct": map[string]interface{}{
+					"path":    ".github/CODE_OF_CONDUCT.md",
+					"content": "# Code of Conduct\n",
+				},
+			},
+		},
+		{
+			name: "missing code of conduct",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockDocs(map[string]string{
+						"docs/CONTRIBUTING.md": "# Contributing\n",
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "v1",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"contributing": map[string]interface{}{
+					"path":    "docs/CONTRIBUTING.md",
+					"content": "# Contributing\n",
+				},
+				"code_of_conduct": nil,
+			},
+		},
+		{
+			name: "request fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get .github/CONTRIBUTING.md",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCommunityDocs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_CreateCommitStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCommitStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_commit_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "target_url")
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.Contains(t, tool.InputSchema.Properties, "context")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha", "state"})
+
+	mockStatus := &github.RepoStatus{
+		ID:          github.Ptr(int64(1)),
+		State:       github.Ptr("success"),
+		TargetURL:   github.Ptr("https://ci.example.com/builds/1"),
+		Description: github.Ptr("Build passed"),
+		Context:     github.Ptr("ci/external"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedStatus *github.RepoStatus
+	}{
+		{
+			name: "successful status creation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					expectRequestBody(t, map[string]interface{}{
+						"state":       "success",
+						"target_url":  "https://ci.example.com/builds/1",
+						"description": "Build passed",
+						"context":     "ci/external",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockStatus),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"sha":         "abc123",
+				"state":       "success",
+				"target_url":  "https://ci.example.com/builds/1",
+				"description": "Build passed",
+				"context":     "ci/external",
+			},
+			expectError:    false,
+			expectedStatus: mockStatus,
+		},
+		{
+			name:         "invalid state",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"state": "passed",
+			},
+			expectError:    false,
+			expectedErrMsg: "state must be one of error, failure, pending, success",
+		},
+		{
+			name: "commit not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "No commit found for SHA: abc123"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"state": "pending",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create commit status",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateCommitStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returned github.RepoStatus
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.expectedStatus.State, *returned.State)
+			assert.Equal(t, *tc.expectedStatus.TargetURL, *returned.TargetURL)
+			assert.Equal(t, *tc.expectedStatus.Description, *returned.Description)
+			assert.Equal(t, *tc.expectedStatus.Context, *returned.Context)
+		})
+	}
+}
+
+func Test_CreateCommitComment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCommitComment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_commit_comment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "line")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha", "body"})
+
+	mockComment := &github.RepositoryComment{
+		ID:       github.Ptr(int64(1)),
+		CommitID: github.Ptr("abc123"),
+		Body:     github.Ptr("Looks good"),
+		HTMLURL:  github.Ptr("https://github.com/owner/repo/commit/abc123#commitcomment-1"),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedComment *github.RepositoryComment
+	}{
+		{
+			name: "comment on the whole commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCommitsCommentsByOwnerByRepoByCommitSha,
+					expectRequestBody(t, map[string]interface{}{
+						"body": "Looks good",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockComment),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"body":  "Looks good",
+			},
+			expectError:     false,
+			expectedComment: mockComment,
+		},
+		{
+			name: "comment on a line",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCommitsCommentsByOwnerByRepoByCommitSha,
+					expectRequestBody(t, map[string]interface{}{
+						"body": "Looks good",
+						"path": "pkg/github/server.go",
+						"line": float64(42),
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockComment),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"body":  "Looks good",
+				"path":  "pkg/github/server.go",
+				"line":  float64(42),
+			},
+			expectError:     false,
+			expectedComment: mockComment,
+		},
+		{
+			name: "line outside the diff",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCommitsCommentsByOwnerByRepoByCommitSha,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"body":  "Looks good",
+				"path":  "pkg/github/server.go",
+				"line":  float64(900),
+			},
+			expectError:    false,
+			expectedErrMsg: "cannot comment on line 900 of pkg/github/server.go",
+		},
+		{
+			name: "line without path",
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"body":  "Looks good",
+				"line":  float64(42),
+			},
+			expectError:    false,
+			expectedErrMsg: "path and line must be given together",
+		},
+		{
+			name: "commit not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCommitsCommentsByOwnerByRepoByCommitSha,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "missing",
+				"body":  "Looks good",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create commit comment",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateCommitComment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned github.RepositoryComment
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedComment.GetID(), returned.GetID())
+			assert.Equal(t, tc.expectedComment.GetBody(), returned.GetBody())
+			assert.Equal(t, tc.expectedComment.GetHTMLURL(), returned.GetHTMLURL())
+		})
+	}
+}
+
+func Test_ListCommitComments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCommitComments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_commit_comments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
+
+	mockComments := []map[string]interface{}{
+		{
+			"id":       1,
+			"body":     "Nice cleanup",
+			"user":     map[string]interface{}{"login": "octocat"},
+			"path":     nil,
+			"line":     nil,
+			"position": nil,
+		},
+		{
+			"id":       2,
+			"body":     "This can panic on empty input",
+			"user":     map[string]interface{}{"login": "hubot"},
+			"path":     "pkg/parser.go",
+			"line":     42,
+			"position": 7,
+		},
+	}
+
+	type listedComment struct {
+		ID         int64   `json:"id"`
+		Author     string  `json:"author"`
+		Body       string  `json:"body"`
+		Positioned bool    `json:"positioned"`
+		Path       *string `json:"path"`
+		Line       *int    `json:"line"`
+		Position   *int    `json:"position"`
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedComments []listedComment
+	}{
+		{
+			name: "general and positioned comments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCommentsByOwnerByRepoByCommitSha,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockComments),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"sha":     "abc123",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+			expectError: false,
+			expectedComments: []listedComment{
+				{ID: 1, Author: "octocat", Body: "Nice cleanup", Positioned: false},
+				{ID: 2, Author: "hubot", Body: "This can panic on empty input", Positioned: true, Path: github.Ptr("pkg/parser.go"), Line: github.Ptr(42), Position: github.Ptr(7)},
+			},
+		},
+		{
+			name: "commit not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCommentsByOwnerByRepoByCommitSha,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list commit comments",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCommitComments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned []listedComment
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedComments, returned)
+		})
+	}
+}
+
+func Test_ListTagProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTagProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_tag_protection", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockProtections := []*github.TagProtection{
+		{ID: github.Ptr(int64(1)), Pattern: github.Ptr("v*")},
+		{ID: github.Ptr(int64(2)), Pattern: github.Ptr("release-*")},
+	}
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		requestArgs         map[string]interface{}
+		expectError         bool
+		expectedErrMsg      string
+		expectedProtections []*github.TagProtection
+	}{
+		{
+			name: "successful listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposTagsProtectionByOwnerByRepo,
+					mockProtections,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:         false,
+			expectedProtections: mockProtections,
+		},
+		{
+			name: "classic tag protection retired",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTagsProtectionByOwnerByRepo,
+					mockResponse(t, http.StatusGone, map[string]string{"message": "Gone"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedErrMsg: "use a ruleset targeting tags instead",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListTagProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returned []*github.TagProtection
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedProtections, returned)
+		})
+	}
+}
+
+func Test_CreateTagProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateTagProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_tag_protection", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pattern")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pattern"})
+
+	mockProtection := &github.TagProtection{ID: github.Ptr(int64(3)), Pattern: github.Ptr("v*")}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedErrMsg     string
+		expectedProtection *github.TagProtection
+	}{
+		{
+			name: "successful creation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposTagsProtectionByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"pattern": "v*",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockProtection),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"pattern": "v*",
+			},
+			expectError:        false,
+			expectedProtection: mockProtection,
+		},
+		{
+			name: "creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposTagsProtectionByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"pattern": "v*",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create tag protection",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateTagProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returned github.TagProtection
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.expectedProtection.ID, *returned.ID)
+			assert.Equal(t, *tc.expectedProtection.Pattern, *returned.Pattern)
+		})
+	}
+}
+
+func Test_IsRefGreen(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := IsRefGreen(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "is_ref_green", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	successStatus := &github.CombinedStatus{
+		State: github.Ptr("success"),
+		SHA:   github.Ptr("abc123"),
+		Statuses: []*github.RepoStatus{
+			{Context: github.Ptr("ci/build"), State: github.Ptr("success")},
+		},
+	}
+	checkRuns := func(runs ...*github.CheckRun) *github.ListCheckRunsResults {
+		return &github.ListCheckRunsResults{Total: github.Ptr(len(runs)), CheckRuns: runs}
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedGreen    bool
+		expectedBlocking []refGateBlocker
+	}{
+		{
+			name: "all green",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					successStatus,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					checkRuns(
+						&github.CheckRun{Name: github.Ptr("test"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+						&github.CheckRun{Name: github.Ptr("docs"), Status: github.Ptr("completed"), Conclusion: github.Ptr("skipped")},
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expectError:      false,
+			expectedGreen:    true,
+			expectedBlocking: []refGateBlocker{},
+		},
+		{
+			name: "pending check",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					successStatus,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					checkRuns(
+						&github.CheckRun{Name: github.Ptr("test"), Status: github.Ptr("in_progress")},
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expectError:   false,
+			expectedGreen: false,
+			expectedBlocking: []refGateBlocker{
+				{Name: "test", State: "pending"},
+			},
+		},
+		{
+			name: "failing status on a later page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Query().Get("page") == "2" {
+							mockResponse(t, http.StatusOK, &github.CombinedStatus{
+								State: github.Ptr("failure"),
+								SHA:   github.Ptr("abc123"),
+								Statuses: []*github.RepoStatus{
+									{Context: github.Ptr("ci/e2e"), State: github.Ptr("error")},
+								},
+							})(w, r)
+							return
+						}
+						w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/commits/main/status?page=2>; rel="next"`)
+						mockResponse(t, http.StatusOK, successStatus)(w, r)
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					checkRuns(),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expectError:   false,
+			expectedGreen: false,
+			expectedBlocking: []refGateBlocker{
+				{Name: "ci/e2e", State: "failing"},
+			},
+		},
+		{
+			name: "failing status and check",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					&github.CombinedStatus{
+						State: github.Ptr("failure"),
+						SHA:   github.Ptr("abc123"),
+						Statuses: []*github.RepoStatus{
+							{Context: github.Ptr("ci/build"), State: github.Ptr("success")},
+							{Context: github.Ptr("ci/deploy"), State: github.Ptr("failure")},
+						},
+					},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					checkRuns(
+						&github.CheckRun{Name: github.Ptr("lint"), Status: github.Ptr("completed"), Conclusion: github.Ptr("timed_out")},
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "abc123",
+			},
+			expectError:   false,
+			expectedGreen: false,
+			expectedBlocking: []refGateBlocker{
+				{Name: "ci/deploy", State: "failing"},
+				{Name: "lint", State: "failing"},
+			},
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get combined status",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := IsRefGreen(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returned struct {
+				Green    bool             `json:"green"`
+				SHA      string           `json:"sha"`
+				Blocking []refGateBlocker `json:"blocking"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedGreen, returned.Green)
+			assert.Equal(t, "abc123", returned.SHA)
+			assert.Equal(t, tc.expectedBlocking, returned.Blocking)
+		})
+	}
+}
+
+func Test_GetCommitBuildStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCommitBuildStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_commit_build_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
+
+	combinedStatus := func(statuses ...*github.RepoStatus) *github.CombinedStatus {
+		return &github.CombinedStatus{SHA: github.Ptr("abc123"), Statuses: statuses}
+	}
+	checkRuns := func(runs ...*github.CheckRun) *github.ListCheckRunsResults {
+		return &github.ListCheckRunsResults{Total: github.Ptr(len(runs)), CheckRuns: runs}
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		expectError      bool
+		expectedErrMsg   string
+		expectedOverall  string
+		expectedFailures []buildStatusFailure
+	}{
+		{
+			name: "statuses and checks succeeded",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					combinedStatus(&github.RepoStatus{Context: github.Ptr("ci/build"), State: github.Ptr("success")}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					checkRuns(
+						&github.CheckRun{Name: github.Ptr("test"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+						&github.CheckRun{Name: github.Ptr("docs"), Status: github.Ptr("completed"), Conclusion: github.Ptr("skipped")},
+					),
+				),
+			),
+			expectError:      false,
+			expectedOverall:  "success",
+			expectedFailures: []buildStatusFailure{},
+		},
+		{
+			name: "check run in progress",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					combinedStatus(&github.RepoStatus{Context: github.Ptr("ci/build"), State: github.Ptr("success")}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					checkRuns(&github.CheckRun{Name: github.Ptr("test"), Status: github.Ptr("in_progress")}),
+				),
+			),
+			expectError:      false,
+			expectedOverall:  "pending",
+			expectedFailures: []buildStatusFailure{},
+		},
+		{
+			name: "failures in both APIs outweigh pending ones",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					combinedStatus(
+						&github.RepoStatus{Context: github.Ptr("ci/build"), State: github.Ptr("error"), TargetURL: github.Ptr("https://ci.example.com/build/1")},
+						&github.RepoStatus{Context: github.Ptr("ci/deploy-preview"), State: github.Ptr("pending")},
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					checkRuns(
+						&github.CheckRun{Name: github.Ptr("test"), Status: github.Ptr("completed"), Conclusion: github.Ptr("timed_out"), HTMLURL: github.Ptr("https://github.com/owner/repo/runs/2")},
+						&github.CheckRun{Name: github.Ptr("lint"), Status: github.Ptr("queued")},
+					),
+				),
+			),
+			expectError:     false,
+			expectedOverall: "failed",
+			expectedFailures: []buildStatusFailure{
+				{Name: "ci/build", Type: "status", URL: "https://ci.example.com/build/1"},
+				{Name: "test", Type: "check_run", URL: "https://github.com/owner/repo/runs/2"},
+			},
+		},
+		{
+			name: "commit not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					checkRuns(),
+				),
+			),
+			expectError:    false,
+			expectedErrMsg: "commit abc123 not found in owner/repo",
+		},
+		{
+			name: "check runs fail",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					combinedStatus(),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Internal Server Error"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list check runs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCommitBuildStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned struct {
+				Overall          string                   `json:"overall"`
+				CheckConclusions []map[string]interface{} `json:"check_conclusions"`
+				StatusContexts   []map[string]interface{} `json:"status_contexts"`
+				BlockingFailures []buildStatusFailure     `json:"blocking_failures"`
+				ETASeconds       *int                     `json:"eta_seconds"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOverall, returned.Overall)
+			assert.Equal(t, tc.expectedFailures, returned.BlockingFailures)
+			assert.NotNil(t, returned.CheckConclusions)
+			assert.NotNil(t, returned.StatusContexts)
+			assert.Nil(t, returned.ETASeconds)
+		})
+	}
+}
+
+func Test_GetWebhookHealth(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWebhookHealth(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_webhook_health", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "hook_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "hook_id"})
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	delivery := func(id int64, statusCode int, minutesAgo int) *github.HookDelivery {
+		return &github.HookDelivery{
+			ID:          github.Ptr(id),
+			StatusCode:  github.Ptr(statusCode),
+			Status:      github.Ptr(http.StatusText(statusCode)),
+			Event:       github.Ptr("push"),
+			Duration:    github.Ptr(0.2),
+			Redelivery:  github.Ptr(false),
+			DeliveredAt: &github.Timestamp{Time: now.Add(-time.Duration(minutesAgo) * time.Minute)},
+		}
+	}
+
+	type failure struct {
+		DeliveryID int64 `json:"delivery_id"`
+		StatusCode int   `json:"status_code"`
+	}
+	type health struct {
+		DeliveriesCount   int        `json:"deliveries_count"`
+		SuccessRate       *float64   `json:"success_rate"`
+		HealthStatus      string     `json:"health_status"`
+		LastSuccessAt     *time.Time `json:"last_success_at"`
+		LastFailureAt     *time.Time `json:"last_failure_at"`
+		AverageDurationMS *float64   `json:"average_duration_ms"`
+		RecentFailures    []failure  `json:"recent_failures"`
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedHealth health
+	}{
+		{
+			name: "degraded webhook",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposHooksDeliveriesByOwnerByRepoByHookId,
+					expectQueryParams(t, map[string]string{
+						"per_page": "20",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.HookDelivery{
+							delivery(4, http.StatusOK, 1),
+							delivery(3, http.StatusBadGateway, 2),
+							delivery(2, http.StatusOK, 3),
+							delivery(1, http.StatusOK, 4),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(7),
+			},
+			expectError: false,
+			expectedHealth: health{
+				DeliveriesCount:   4,
+				SuccessRate:       github.Ptr(0.75),
+				HealthStatus:      "degraded",
+				LastSuccessAt:     github.Ptr(now.Add(-time.Minute)),
+				LastFailureAt:     github.Ptr(now.Add(-2 * time.Minute)),
+				AverageDurationMS: github.Ptr(200.0),
+				RecentFailures:    []failure{{DeliveryID: 3, StatusCode: http.StatusBadGateway}},
+			},
+		},
+		{
+			name: "no deliveries yet",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposHooksDeliveriesByOwnerByRepoByHookId,
+					[]*github.HookDelivery{},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(7),
+			},
+			expectError: false,
+			expectedHealth: health{
+				HealthStatus:   "unknown",
+				RecentFailures: []failure{},
+			},
+		},
+		{
+			name: "webhook not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposHooksDeliveriesByOwnerByRepoByHookId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list webhook deliveries",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetWebhookHealth(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returned health
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedHealth, returned)
+		})
+	}
+}
+
+func Test_GetLargestFiles(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetLargestFiles(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_largest_files", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "top")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockEntries := []*github.TreeEntry{
+		{Path: github.Ptr("README.md"), Type: github.Ptr("blob"), Size: github.Ptr(2048), SHA: github.Ptr("a1")},
+		{Path: github.Ptr("assets"), Type: github.Ptr("tree"), SHA: github.Ptr("b2")},
+		{Path: github.Ptr("assets/logo.png"), Type: github.Ptr("blob"), Size: github.Ptr(512000), SHA: github.Ptr("c3")},
+		{Path: github.Ptr("assets/demo.mp4"), Type: github.Ptr("blob"), Size: github.Ptr(9000000), SHA: github.Ptr("d4")},
+		{Path: github.Ptr("vendor/lib"), Type: github.Ptr("commit"), SHA: github.Ptr("e5")},
+		{Path: github.Ptr("assets/banner.png"), Type: github.Ptr("blob"), Size: github.Ptr(512000), SHA: github.Ptr("f6")},
+	}
+
+	type largeFile struct {
+		Path string `json:"path"`
+		Size int    `json:"size"`
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedRef       string
+		expectedFiles     []largeFile
+		expectedScanned   int
+		expectedTruncated bool
+	}{
+		{
+			name: "largest files of the default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{DefaultBranch: github.Ptr("main")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					expectQueryParams(t, map[string]string{"recursive": "1"}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+							assert.Equal(t, "/repos/owner/repo/git/trees/main", r.URL.Path)
+							mockResponse(t, http.StatusOK, &github.Tree{Entries: mockEntries})(w, r)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"top":   float64(3),
+			},
+			expectError: false,
+			expectedRef: "main",
+			expectedFiles: []largeFile{
+				{Path: "assets/demo.mp4", Size: 9000000},
+				{Path: "assets/banner.png", Size: 512000},
+				{Path: "assets/logo.png", Size: 512000},
+			},
+			expectedScanned:   4,
+			expectedTruncated: false,
+		},
+		{
+			name: "truncated tree",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					&github.Tree{Entries: mockEntries[:2], Truncated: github.Ptr(true)},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "v1.0.0",
+			},
+			expectError: false,
+			expectedRef: "v1.0.0",
+			expectedFiles: []largeFile{
+				{Path: "README.md", Size: 2048},
+			},
+			expectedScanned:   1,
+			expectedTruncated: true,
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "ref missing not found in owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetLargestFiles(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Ref          string      `json:"ref"`
+				Files        []largeFile `json:"files"`
+				FilesScanned int         `json:"files_scanned"`
+				Truncated    bool        `json:"truncated"`
+				Note         string      `json:"note"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRef, response.Ref)
+			assert.Equal(t, tc.expectedFiles, response.Files)
+			assert.Equal(t, tc.expectedScanned, response.FilesScanned)
+			assert.Equal(t, tc.expectedTruncated, response.Truncated)
+			if tc.expectedTruncated {
+				assert.Contains(t, response.Note, "only part of it was scanned")
+			} else {
+				assert.Empty(t, response.Note)
+			}
+		})
+	}
+}
+
+func Test_CleanupMergedBranches(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CleanupMergedBranches(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "cleanup_merged_branches", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "dry_run")
+	assert.Contains(t, tool.InputSchema.Properties, "enable_auto_delete")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "dry_run"})
+
+	mockRepo := &github.Repository{
+		FullName:      github.Ptr("owner/repo"),
+		DefaultBranch: github.Ptr("main"),
+	}
+	branch := func(name, sha string, protected bool) *github.Branch {
+		return &github.Branch{
+			Name:      github.Ptr(name),
+			Commit:    &github.RepositoryCommit{SHA: github.Ptr(sha)},
+			Protected: github.Ptr(protected),
+		}
+	}
+	mockBranches := []*github.Branch{
+		branch("main", "m1", true),
+		branch("feature", "f1", false),
+		branch("release", "r1", true),
+		branch("moved-on", "n2", false),
+		branch("open-work", "o1", false),
+		branch("stacked-base", "s1", false),
+	}
+	pr := func(number int, ref, sha, repoFullName string, merged bool) *github.PullRequest {
+		p := &github.PullRequest{
+			Number: github.Ptr(number),
+			Head: &github.PullRequestBranch{
+				Ref:  github.Ptr(ref),
+				SHA:  github.Ptr(sha),
+				Repo: &github.Repository{FullName: github.Ptr(repoFullName)},
+			},
+		}
+		if merged {
+			p.MergedAt = &github.Timestamp{Time: time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)}
+		}
+		return p
+	}
+	mockPRs := []*github.PullRequest{
+		pr(10, "feature", "f1", "owner/repo", true),
+		pr(11, "release", "r1", "owner/repo", true),
+		pr(12, "moved-on", "n1", "owner/repo", true),
+		pr(13, "open-work", "o1", "owner/repo", false),
+		pr(14, "feature", "f1", "fork/repo", true),
+		pr(15, "gone", "g1", "owner/repo", true),
+		pr(16, "stacked-base", "s1", "owner/repo", true),
+	}
+	// The merged stacked-base branch is still the base of an open pull request
+	openPR := pr(20, "stacked-top", "t1", "owner/repo", false)
+	openPR.Base = &github.PullRequestBranch{Ref: github.Ptr("stacked-base")}
+	mockOpenPRs := []*github.PullRequest{openPR}
+	expectedSkipped := []interface{}{
+		map[string]interface{}{"branch": "release", "pull_number": float64(11), "reason": "protected branch"},
+		map[string]interface{}{"branch": "moved-on", "pull_number": float64(12), "reason": "has commits that were not merged"},
+		map[string]interface{}{"branch": "stacked-base", "pull_number": float64(16), "reason": "used by open pull request #20"},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+		expectedFailed []string
+	}{
+		{
+			name: "dry run lists the branches without deleting them",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, mockRepo),
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepo, mockBranches),
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Query().Get("state") == "open" {
+							expectQueryParams(t, map[string]string{
+								"state":    "open",
+								"per_page": "100",
+							}).andThen(
+								mockResponse(t, http.StatusOK, mockOpenPRs),
+							).ServeHTTP(w, r)
+							return
+						}
+						expectQueryParams(t, map[string]string{
+							"state":     "closed",
+							"sort":      "updated",
+							"direction": "desc",
+							"per_page":  "100",
+						}).andThen(
+							mockResponse(t, http.StatusOK, mockPRs),
+						).ServeHTTP(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":              "owner",
+				"repo":               "repo",
+				"dry_run":            true,
+				"enable_auto_delete": true,
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"dry_run": true,
+				"would_delete": []interface{}{
+					map[string]interface{}{"branch": "feature", "pull_number": float64(10)},
+				},
+				"skipped":                expectedSkipped,
+				"pull_requests_scanned":  float64(7),
+				"truncated":              false,
+				"delete_branch_on_merge": false,
+			},
+		},
+		{
+			name: "deletes branches and enables automatic deletion",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, mockRepo),
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepo, mockBranches),
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepo, mockOpenPRs, mockPRs),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						// Only the merged, unprotected branch may be deleted
+						assert.Equal(t, "/repos/owner/repo/git/refs/heads/feature", r.URL.Path)
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"delete_branch_on_merge": true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":              "owner",
+				"repo":               "repo",
+				"dry_run":            false,
+				"enable_auto_delete": true,
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"dry_run": false,
+				"deleted": []interface{}{
+					map[string]interface{}{"branch": "feature", "pull_number": float64(10)},
+				},
+				"skipped":                expectedSkipped,
+				"pull_requests_scanned":  float64(7),
+				"truncated":              false,
+				"delete_branch_on_merge": true,
+			},
+		},
+		{
+			name: "reports branches that fail to delete",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, mockRepo),
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepo, mockBranches),
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepo, mockOpenPRs, mockPRs),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Reference does not exist"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"dry_run": false,
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"dry_run":                false,
+				"deleted":                []interface{}{},
+				"skipped":                expectedSkipped,
+				"pull_requests_scanned":  float64(7),
+				"truncated":              false,
+				"delete_branch_on_merge": false,
+			},
+			expectedFailed: []string{"feature"},
+		},
+		{
+			name:         "missing dry_run",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedErrMsg: "missing required parameter: dry_run",
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "missing",
+				"dry_run": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CleanupMergedBranches(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			var failed []string
+			if entries, ok := response["failed"].([]interface{}); ok {
+				for _, entry := range entries {
+					failed = append(failed, entry.(map[string]interface{})["branch"].(string))
+				}
+				delete(response, "failed")
+			}
+			assert.Equal(t, tc.expectedFailed, failed)
+			assert.Equal(t, tc.expectedResult, response)
+		})
+	}
+}
+
+func Test_GetInteractionLimits(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetInteractionLimits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_interaction_limits", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name: "limit in place",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposInteractionLimitsByOwnerByRepo,
+					&github.InteractionRestriction{
+						Limit:     github.Ptr("collaborators_only"),
+						Origin:    github.Ptr("repository"),
+						ExpiresAt: &github.Timestamp{Time: time.Date(2025, 4, 2, 12, 0, 0, 0, time.UTC)},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"limit":      "collaborators_only",
+				"origin":     "repository",
+				"expires_at": "2025-04-02T12:00:00Z",
+			},
+		},
+		{
+			name: "no limit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposInteractionLimitsByOwnerByRepo,
+					map[string]interface{}{},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedResult: map[string]interface{}{"limit": "off"},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposInteractionLimitsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get interaction limits",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetInteractionLimits(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, response)
+		})
+	}
+}
+
+func Test_SetInteractionLimits(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetInteractionLimits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_interaction_limits", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "limit")
+	assert.Contains(t, tool.InputSchema.Properties, "expiry")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "limit"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+		expectedText   string
+	}{
+		{
+			name: "sets a limit with an expiry",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposInteractionLimitsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"limit":  "contributors_only",
+						"expiry": "one_week",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.InteractionRestriction{
+							Limit:     github.Ptr("contributors_only"),
+							Origin:    github.Ptr("repository"),
+							ExpiresAt: &github.Timestamp{Time: time.Date(2025, 4, 8, 12, 0, 0, 0, time.UTC)},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"limit":  "contributors_only",
+				"expiry": "one_week",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"limit":      "contributors_only",
+				"origin":     "repository",
+				"expires_at": "2025-04-08T12:00:00Z",
+			},
+		},
+		{
+			name: "expiry left to GitHub's default",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposInteractionLimitsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"limit": "existing_users",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.InteractionRestriction{
+							Limit:     github.Ptr("existing_users"),
+							Origin:    github.Ptr("repository"),
+							ExpiresAt: &github.Timestamp{Time: time.Date(2025, 4, 2, 12, 0, 0, 0, time.UTC)},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"limit": "existing_users",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"limit":      "existing_users",
+				"origin":     "repository",
+				"expires_at": "2025-04-02T12:00:00Z",
+			},
+		},
+		{
+			name: "clears the limit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposInteractionLimitsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"limit":  "off",
+				"expiry": "one_day",
+			},
+			expectError:  false,
+			expectedText: "Interaction limits of owner/repo removed",
+		},
+		{
+			name: "organization already limits interactions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposInteractionLimitsByOwnerByRepo,
+					mockResponse(t, http.StatusConflict, map[string]string{"message": "Interaction restrictions are already in place for the organization"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"limit": "collaborators_only",
+			},
+			expectError:    false,
+			expectedErrMsg: "the organization of owner/repo may already limit interactions",
+		},
+		{
+			name:         "invalid limit",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"limit": "everyone",
+			},
+			expectError:    false,
+			expectedErrMsg: "invalid limit",
+		},
+		{
+			name:         "invalid expiry",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"limit":  "collaborators_only",
+				"expiry": "forever",
+			},
+			expectError:    false,
+			expectedErrMsg: "invalid expiry",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetInteractionLimits(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+				return
+			}
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, response)
+		})
+	}
+}
+
+func Test_GetCodeFrequency(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCodeFrequency(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_code_frequency", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// Don't wait between the attempts while GitHub computes the statistics
+	originalDelays := statsRetryDelays
+	statsRetryDelays = []time.Duration{time.Millisecond, time.Millisecond}
+	t.Cleanup(func() { statsRetryDelays = originalDelays })
+
+	codeFrequency := [][]int{
+		{1704067200, 120, -30},
+		{1704672000, 0, 0},
+	}
+	// computedAfter answers 202 Accepted until it was requested the given number of times
+	computedAfter := func(attempts int, requests *int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			*requests++
+			if *requests < attempts {
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte(`{}`))
+				return
+			}
+			mockResponse(t, http.StatusOK, codeFrequency)(w, r)
+		}
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     func(requests *int) *http.Client
+		expectError      bool
+		expectedErrMsg   string
+		expectedWeeks    []codeFrequencyWeek
+		expectedRequests int
+	}{
+		{
+			name: "statistics already computed",
+			mockedClient: func(requests *int) *http.Client {
+				return mock.NewMockedHTTPClient(
+					mock.WithRequestMatchHandler(mock.GetReposStatsCodeFrequencyByOwnerByRepo, computedAfter(1, requests)),
+				)
+			},
+			expectError: false,
+			expectedWeeks: []codeFrequencyWeek{
+				{Week: "2024-01-01", Additions: 120, Deletions: 30},
+				{Week: "2024-01-08", Additions: 0, Deletions: 0},
+			},
+			expectedRequests: 1,
+		},
+		{
+			name: "statistics computed after a 202",
+			mockedClient: func(requests *int) *http.Client {
+				return mock.NewMockedHTTPClient(
+					mock.WithRequestMatchHandler(mock.GetReposStatsCodeFrequencyByOwnerByRepo, computedAfter(2, requests)),
+				)
+			},
+			expectError: false,
+			expectedWeeks: []codeFrequencyWeek{
+				{Week: "2024-01-01", Additions: 120, Deletions: 30},
+				{Week: "2024-01-08", Additions: 0, Deletions: 0},
+			},
+			expectedRequests: 2,
+		},
+		{
+			name: "empty repository",
+			mockedClient: func(requests *int) *http.Client {
+				return mock.NewMockedHTTPClient(
+					mock.WithRequestMatchHandler(
+						mock.GetReposStatsCodeFrequencyByOwnerByRepo,
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							*requests++
+							w.WriteHeader(http.StatusNoContent)
+						}),
+					),
+				)
+			},
+			expectError:      false,
+			expectedWeeks:    []codeFrequencyWeek{},
+			expectedRequests: 1,
+		},
+		{
+			name: "still computing after every retry",
+			mockedClient: func(requests *int) *http.Client {
+				return mock.NewMockedHTTPClient(
+					mock.WithRequestMatchHandler(mock.GetReposStatsCodeFrequencyByOwnerByRepo, computedAfter(10, requests)),
+				)
+			},
+			expectError:      false,
+			expectedErrMsg:   "GitHub is still computing the code frequency of owner/repo",
+			expectedRequests: 3,
+		},
+		{
+			name: "repository not found",
+			mockedClient: func(requests *int) *http.Client {
+				return mock.NewMockedHTTPClient(
+					mock.WithRequestMatchHandler(
+						mock.GetReposStatsCodeFrequencyByOwnerByRepo,
+						http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+							*requests++
+							mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+						}),
+					),
+				)
+			},
+			expectError:      false,
+			expectedErrMsg:   "repository owner/repo not found",
+			expectedRequests: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			requests := 0
+			client := github.NewClient(tc.mockedClient(&requests))
+			_, handler := GetCodeFrequency(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRequests, requests)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Weeks []codeFrequencyWeek `json:"weeks"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedWeeks, response.Weeks)
+		})
+	}
+}
+
+func Test_UpdateRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "squash_merge_commit_title")
+	assert.Contains(t, tool.InputSchema.Properties, "squash_merge_commit_message")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name: "set the squash merge commit format",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"squash_merge_commit_title":   "PR_TITLE",
+						"squash_merge_commit_message": "PR_BODY",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{
+							AllowSquashMerge:         github.Ptr(true),
+							SquashMergeCommitTitle:   github.Ptr("PR_TITLE"),
+							SquashMergeCommitMessage: github.Ptr("PR_BODY"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                       "owner",
+				"repo":                        "repo",
+				"squash_merge_commit_title":   "PR_TITLE",
+				"squash_merge_commit_message": "PR_BODY",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"allow_squash_merge":          true,
+				"squash_merge_commit_title":   "PR_TITLE",
+				"squash_merge_commit_message": "PR_BODY",
+			},
+		},
+		{
+			name: "squash merging disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"squash_merge_commit_message": "BLANK",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{
+							AllowSquashMerge:         github.Ptr(false),
+							SquashMergeCommitTitle:   github.Ptr("COMMIT_OR_PR_TITLE"),
+							SquashMergeCommitMessage: github.Ptr("BLANK"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                       "owner",
+				"repo":                        "repo",
+				"squash_merge_commit_message": "BLANK",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"allow_squash_merge":          false,
+				"squash_merge_commit_title":   "COMMIT_OR_PR_TITLE",
+				"squash_merge_commit_message": "BLANK",
+				"warning":                     "squash merging is disabled on owner/repo, so the squash merge commit format only applies once it is enabled",
+			},
+		},
+		{
+			name:         "invalid squash merge commit title",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":                     "owner",
+				"repo":                      "repo",
+				"squash_merge_commit_title": "BRANCH_NAME",
+			},
+			expectError:    false,
+			expectedErrMsg: "squash_merge_commit_title must be one of PR_TITLE, COMMIT_OR_PR_TITLE",
+		},
+		{
+			name:         "invalid squash merge commit message",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":                       "owner",
+				"repo":                        "repo",
+				"squash_merge_commit_message": "pr_body",
+			},
+			expectError:    false,
+			expectedErrMsg: "squash_merge_commit_message must be one of PR_BODY, COMMIT_MESSAGES, BLANK",
+		},
+		{
+			name:         "no update parameters",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedErrMsg: "No update parameters provided.",
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                     "owner",
+				"repo":                      "repo",
+				"squash_merge_commit_title": "PR_TITLE",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, response)
+		})
+	}
+}
+
+func Test_SetDefaultBranch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetDefaultBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_default_branch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "rename")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	mockRepo := &github.Repository{
+		FullName:      github.Ptr("owner/repo"),
+		DefaultBranch: github.Ptr("master"),
+	}
+	mockRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{SHA: github.Ptr("abc123")},
+	}
+	notFound := mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedResponse map[string]interface{}
+	}{
+		{
+			name: "switch to an existing branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"default_branch": "main",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{DefaultBranch: github.Ptr("main")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectError: false,
+			expectedResponse: map[string]interface{}{
+				"previous_default_branch": "master",
+				"default_branch":          "main",
+				"renamed":                 false,
+			},
+		},
+		{
+			name: "rename the default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					notFound,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposBranchesRenameByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]interface{}{
+						"new_name": "main",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Branch{Name: github.Ptr("main")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"rename": true,
+			},
+			expectError: false,
+			expectedResponse: map[string]interface{}{
+				"previous_default_branch": "master",
+				"default_branch":          "main",
+				"renamed":                 true,
+			},
+		},
+		{
+			name: "missing branch is rejected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					notFound,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "mian",
+			},
+			expectError:    false,
+			expectedErrMsg: "branch mian not found in owner/repo",
+		},
+		{
+			name: "rename onto an existing branch is rejected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"rename": true,
+			},
+			expectError:    false,
+			expectedErrMsg: "cannot rename master to main, a branch named main already exists in owner/repo",
+		},
+		{
+			name: "already the default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "master",
+			},
+			expectError:    false,
+			expectedErrMsg: "master is already the default branch of owner/repo",
+		},
+		{
+			name: "insufficient permissions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectError:    false,
+			expectedErrMsg: "cannot change the default branch of owner/repo: Must have admin rights to Repository.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetDefaultBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
+
+func Test_ApplyRepoSettings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ApplyRepoSettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "apply_repo_settings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "settings")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "settings"})
+
+	// editRepositoryHandler checks that the description and the merge options are edited in that order.
+	editRepositoryHandler := func(t *testing.T) http.HandlerFunc {
+		expectedBodies := []map[string]interface{}{
+			{"description": "Tools for the MCP"},
+			{"allow_squash_merge": true, "allow_merge_commit": false, "delete_branch_on_merge": true},
+		}
+		edits := 0
+		return func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.Less(t, edits, len(expectedBodies))
+			assert.Equal(t, expectedBodies[edits], body)
+			edits++
+			mockResponse(t, http.StatusOK, &github.Repository{Name: github.Ptr("repo")})(w, r)
+		}
+	}
+	settings := map[string]interface{}{
+		"description":            "Tools for the MCP",
+		"topics":                 []interface{}{"mcp", "github"},
+		"allow_squash_merge":     true,
+		"allow_merge_commit":     false,
+		"delete_branch_on_merge": true,
+		"branch_protection": map[string]interface{}{
+			"branch":                          "main",
+			"required_approving_review_count": float64(2),
+			"require_code_owner_reviews":      true,
+			"required_status_checks":          []interface{}{"build"},
+			"strict_status_checks":            true,
+		},
+	}
+	protectionHandler := expectRequestBody(t, map[string]interface{}{
+		"required_status_checks": map[string]interface{}{
+			"strict":   true,
+			"contexts": []interface{}{"build"},
+		},
+		"required_pull_request_reviews": map[string]interface{}{
+			"dismiss_stale_reviews":           false,
+			"require_code_owner_reviews":      true,
+			"required_approving_review_count": float64(2),
+		},
+		"enforce_admins": false,
+		"restrictions":   nil,
+	}).andThen(
+		mockResponse(t, http.StatusOK, &github.Protection{}),
+	)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedResults []repoSettingResult
+		expectedApplied float64
+		expectedFailed  float64
+	}{
+		{
+			name: "apply all settings",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					editRepositoryHandler(t),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"names": []interface{}{"mcp", "github"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]interface{}{"names": []string{"mcp", "github"}}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+					protectionHandler,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"settings": settings,
+			},
+			expectError: false,
+			expectedResults: []repoSettingResult{
+				{Setting: "description", Status: "applied"},
+				{Setting: "merge_options", Status: "applied"},
+				{Setting: "topics", Status: "applied"},
+				{Setting: "branch_protection", Status: "applied"},
+			},
+			expectedApplied: 4,
+			expectedFailed:  0,
+		},
+		{
+			name: "failed setting doesn't stop the later ones",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					editRepositoryHandler(t),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Invalid topic name"}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+					protectionHandler,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"settings": settings,
+			},
+			expectError: false,
+			expectedResults: []repoSettingResult{
+				{Setting: "description", Status: "applied"},
+				{Setting: "merge_options", Status: "applied"},
+				{Setting: "topics", Status: "failed", Error: "Invalid topic name"},
+				{Setting: "branch_protection", Status: "applied"},
+			},
+			expectedApplied: 3,
+			expectedFailed:  1,
+		},
+		{
+			name: "only the provided settings are applied",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"names": []interface{}{},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]interface{}{"names": []string{}}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"settings": map[string]interface{}{"topics": []interface{}{}},
+			},
+			expectError: false,
+			expectedResults: []repoSettingResult{
+				{Setting: "topics", Status: "applied"},
+			},
+			expectedApplied: 1,
+			expectedFailed:  0,
+		},
+		{
+			name:         "unknown setting",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"settings": map[string]interface{}{"descripton": "typo"},
+			},
+			expectError:    false,
+			expectedErrMsg: `invalid settings: json: unknown field "descripton"`,
+		},
+		{
+			name:         "branch protection without a branch",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"settings": map[string]interface{}{
+					"branch_protection": map[string]interface{}{"enforce_admins": true},
+				},
+			},
+			expectError:    false,
+			expectedErrMsg: "branch_protection must name the branch to protect",
+		},
+		{
+			name:         "review count out of range",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"settings": map[string]interface{}{
+					"branch_protection": map[string]interface{}{"branch": "main", "required_approving_review_count": float64(7)},
+				},
+			},
+			expectError:    false,
+			expectedErrMsg: "branch_protection.required_approving_review_count must be between 0 and 6, got 7",
+		},
+		{
+			name:         "no settings",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"settings": map[string]interface{}{},
+			},
+			expectError:    false,
+			expectedErrMsg: "No settings provided.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ApplyRepoSettings(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Results      []repoSettingResult `json:"results"`
+				AppliedCount float64             `json:"applied_count"`
+				FailedCount  float64             `json:"failed_count"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			require.Len(t, response.Results, len(tc.expectedResults))
+			for i, expected := range tc.expectedResults {
+				assert.Equal(t, expected.Setting, response.Results[i].Setting)
+				assert.Equal(t, expected.Status, response.Results[i].Status)
+				assert.Contains(t, response.Results[i].Error, expected.Error)
+			}
+			assert.Equal(t, tc.expectedApplied, response.AppliedCount)
+			assert.Equal(t, tc.expectedFailed, response.FailedCount)
+		})
+	}
+}
+
+func Test_GetDependencyManifest(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetDependencyManifest(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_dependency_manifest", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "ecosystem")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	goMod := "module example.com/app\n\ngo 1.23\n\nrequire (\n\tgithub.com/google/go-github/v69 v69.2.0\n\tgolang.org/x/sys v0.31.0 // indirect\n)\n"
+	packageJSON := `{"dependencies": {"react": "^18.2.0"}, "devDependencies": {"typescript": "~5.4.0"}}`
+
+	// contentsHandler serves the given files, and 404 for any other path.
+	contentsHandler := func(files map[string]string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			path := strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/contents/")
+			content, ok := files[path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				return
+			}
+			assert.Equal(t, "v1.0.0", r.URL.Query().Get("ref"))
+			mockResponse(t, http.StatusOK, &github.RepositoryContent{
+				Type:     github.Ptr("file"),
+				Path:     github.Ptr(path),
+				Encoding: github.Ptr("base64"),
+				Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(content))),
+			})(w, r)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		files          map[string]string
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name:  "parse go.mod",
+			files: map[string]string{"go.mod": goMod, "package.json": packageJSON},
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "v1.0.0",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"ecosystem": "go",
+				"path":      "go.mod",
+				"dependencies": []interface{}{
+					map[string]interface{}{"name": "github.com/google/go-github/v69", "version": "v69.2.0", "scope": "direct"},
+					map[string]interface{}{"name": "golang.org/x/sys", "version": "v0.31.0", "scope": "indirect"},
+				},
+			},
+		},
+		{
+			name:  "parse package.json",
+			files: map[string]string{"go.mod": goMod, "package.json": packageJSON},
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"ref":       "v1.0.0",
+				"ecosystem": "npm",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"ecosystem": "npm",
+				"path":      "package.json",
+				"dependencies": []interface{}{
+					map[string]interface{}{"name": "react", "version": "^18.2.0", "scope": "runtime"},
+					map[string]interface{}{"name": "typescript", "version": "~5.4.0", "scope": "development"},
+				},
+			},
+		},
+		{
+			name:  "first manifest found",
+			files: map[string]string{"package.json": packageJSON},
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "v1.0.0",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"ecosystem": "npm",
+				"path":      "package.json",
+				"dependencies": []interface{}{
+					map[string]interface{}{"name": "react", "version": "^18.2.0", "scope": "runtime"},
+					map[string]interface{}{"name": "typescript", "version": "~5.4.0", "scope": "development"},
+				},
+			},
+		},
+		{
+			name:  "no manifest",
+			files: map[string]string{},
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedErrMsg: "no go.mod or package.json found at the root of owner/repo",
+		},
+		{
+			name:  "malformed manifest",
+			files: map[string]string{"package.json": `{"dependencies": ["react"]}`},
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"ref":       "v1.0.0",
+				"ecosystem": "npm",
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to parse package.json: package.json: dependencies must map package names to versions",
+		},
+		{
+			name:  "unsupported ecosystem",
+			files: map[string]string{},
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"ecosystem": "pip",
+			},
+			expectError:    false,
+			expectedErrMsg: "ecosystem must be one of go, npm",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					contentsHandler(tc.files),
+				),
+			))
+			_, handler := GetDependencyManifest(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, response)
+		})
+	}
+}
+
+func Test_RenderMarkdown(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RenderMarkdown(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "render_markdown", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "text")
+	assert.Contains(t, tool.InputSchema.Properties, "mode")
+	assert.Contains(t, tool.InputSchema.Properties, "context")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"text"})
+
+	// renderedHTML answers with the given HTML, the way the markdown endpoint does
+	renderedHTML := func(html string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/html;charset=utf-8")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(html))
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedHTML   string
+	}{
+		{
+			name: "plain markdown",
 			mockedClient: mock.NewMockedHTTPClient(
-				// Get branch reference
-				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockRef,
-				),
-				// Get commit
-				mock.WithRequestMatch(
-					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
-					mockCommit,
+				mock.WithRequestMatchHandler(
+					mock.PostMarkdown,
+					expectRequestBody(t, map[string]interface{}{
+						"text": "# Hello\n\nSee #123",
+						"mode": "markdown",
+					}).andThen(
+						renderedHTML("<h1>Hello</h1>\n<p>See #123</p>\n"),
+					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "repo",
-				"branch": "main",
-				"files": []interface{}{
-					map[string]interface{}{
-						"path": "README.md",
-						// Missing content
-					},
-				},
-				"message": "Update file",
+				"text": "# Hello\n\nSee #123",
 			},
-			expectError:    false, // This returns a tool error, not a Go error
-			expectedErrMsg: "each file must have content",
+			expectError:  false,
+			expectedHTML: "<h1>Hello</h1>\n<p>See #123</p>\n",
 		},
 		{
-			name: "fails to get branch reference",
+			name: "gfm with a context repository",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockResponse(t, http.StatusNotFound, nil),
+					mock.PostMarkdown,
+					expectRequestBody(t, map[string]interface{}{
+						"text":    "Fixed by @octocat in #123",
+						"mode":    "gfm",
+						"context": "owner/repo",
+					}).andThen(
+						renderedHTML(`<p>Fixed by <a class="user-mention" href="https://github.com/octocat">@octocat</a> in <a class="issue-link" href="https://github.com/owner/repo/issues/123">#123</a></p>`),
+					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "repo",
-				"branch": "non-existent-branch",
-				"files": []interface{}{
-					map[string]interface{}{
-						"path":    "README.md",
-						"content": "# README",
-					},
-				},
-				"message": "Update file",
+				"text":    "Fixed by @octocat in #123",
+				"mode":    "gfm",
+				"context": "owner/repo",
 			},
-			expectError:    true,
-			expectedErrMsg: "failed to get branch reference",
+			expectError:  false,
+			expectedHTML: `<p>Fixed by <a class="user-mention" href="https://github.com/octocat">@octocat</a> in <a class="issue-link" href="https://github.com/owner/repo/issues/123">#123</a></p>`,
 		},
 		{
-			name: "fails to get base commit",
-			mockedClient: mock.NewMockedHTTPClient(
-				// Get branch reference
-				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockRef,
-				),
-				// Fail to get commit
-				mock.WithRequestMatchHandler(
-					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
-					mockResponse(t, http.StatusNotFound, nil),
-				),
-			),
+			name:         "context outside of gfm mode",
+			mockedClient: mock.NewMockedHTTPClient(),
 			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "repo",
-				"branch": "main",
-				"files": []interface{}{
-					map[string]interface{}{
-						"path":    "README.md",
-						"content": "# README",
-					},
-				},
-				"message": "Update file",
+				"text":    "See #123",
+				"mode":    "markdown",
+				"context": "owner/repo",
 			},
-			expectError:    true,
-			expectedErrMsg: "failed to get base commit",
+			expectError:    false,
+			expectedErrMsg: "context is only used in gfm mode",
 		},
 		{
-			name: "fails to create tree",
-			mockedClient: mock.NewMockedHTTPClient(
-				// Get branch reference
-				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockRef,
-				),
-				// Get commit
-				mock.WithRequestMatch(
-					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
-					mockCommit,
-				),
-				// Fail to create tree
-				mock.WithRequestMatchHandler(
-					mock.PostReposGitTreesByOwnerByRepo,
-					mockResponse(t, http.StatusInternalServerError, nil),
-				),
-			),
+			name:         "context not a repository",
+			mockedClient: mock.NewMockedHTTPClient(),
 			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "repo",
-				"branch": "main",
-				"files": []interface{}{
-					map[string]interface{}{
-						"path":    "README.md",
-						"content": "# README",
-					},
-				},
-				"message": "Update file",
+				"text":    "See #123",
+				"mode":    "gfm",
+				"context": "owner",
 			},
-			expectError:    true,
-			expectedErrMsg: "failed to create tree",
+			expectError:    false,
+			expectedErrMsg: `context must be a repository as owner/repo, got "owner"`,
 		},
 	}
 
@@ -1381,7 +6313,7 @@ func Test_PushFiles(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := PushFiles(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := RenderMarkdown(stubGetClientFn(client), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -1396,135 +6328,177 @@ func Test_PushFiles(t *testing.T) {
 				return
 			}
 
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
 			if tc.expectedErrMsg != "" {
-				require.NotNil(t, result)
-				textContent := getTextResult(t, result)
+				assert.True(t, result.IsError)
 				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
 				return
 			}
 
-			require.NoError(t, err)
-
-			// Parse the result and get the text content if no error
-			textContent := getTextResult(t, result)
-
-			// Unmarshal and verify the result
-			var returnedRef github.Reference
-			err = json.Unmarshal([]byte(textContent.Text), &returnedRef)
-			require.NoError(t, err)
-
-			assert.Equal(t, *tc.expectedRef.Ref, *returnedRef.Ref)
-			assert.Equal(t, *tc.expectedRef.Object.SHA, *returnedRef.Object.SHA)
+			assert.Equal(t, tc.expectedHTML, textContent.Text)
 		})
 	}
 }
 
-func Test_ListBranches(t *testing.T) {
+func Test_GetRepositoryArchive(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := ListBranches(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetRepositoryArchive(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 
-	assert.Equal(t, "list_branches", tool.Name)
+	assert.Equal(t, "get_repository_archive", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "page")
-	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "format")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
-	// Setup mock branches for success case
-	mockBranches := []*github.Branch{
-		{
-			Name:   github.Ptr("main"),
-			Commit: &github.RepositoryCommit{SHA: github.Ptr("abc123")},
-		},
-		{
-			Name:   github.Ptr("develop"),
-			Commit: &github.RepositoryCommit{SHA: github.Ptr("def456")},
-		},
+	smallArchive := []byte("PK\x03\x04 small zipball")
+	largeArchive := bytes.Repeat([]byte{0x1f}, maxArchiveBytes+1)
+
+	// The codeload server the archive URL redirects to
+	codeloadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/small":
+			_, _ = w.Write(smallArchive)
+		case "/large":
+			_, _ = w.Write(largeArchive)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer codeloadServer.Close()
+
+	redirectTo := func(path string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Location", codeloadServer.URL+path)
+			w.WriteHeader(http.StatusFound)
+		}
 	}
 
-	// Test cases
 	tests := []struct {
-		name          string
-		args          map[string]interface{}
-		mockResponses []mock.MockBackendOption
-		wantErr       bool
-		errContains   string
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       map[string]interface{}
 	}{
 		{
-			name: "success",
-			args: map[string]interface{}{
-				"owner": "owner",
-				"repo":  "repo",
-				"page":  float64(2),
-			},
-			mockResponses: []mock.MockBackendOption{
-				mock.WithRequestMatch(
-					mock.GetReposBranchesByOwnerByRepo,
-					mockBranches,
+			name: "small archive returned inline",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposZipballByOwnerByRepoByRef,
+					redirectTo("/small"),
 				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"ref":    "v1.0.0",
+				"format": "zipball",
+			},
+			expectError: false,
+			expected: map[string]interface{}{
+				"ref":     "v1.0.0",
+				"format":  "zipball",
+				"size":    float64(len(smallArchive)),
+				"content": base64.StdEncoding.EncodeToString(smallArchive),
 			},
-			wantErr: false,
 		},
 		{
-			name: "missing owner",
-			args: map[string]interface{}{
-				"repo": "repo",
+			name: "oversize archive replaced by a manifest of the default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{DefaultBranch: github.Ptr("main")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposTarballByOwnerByRepoByRef,
+					redirectTo("/large"),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					&github.Tree{
+						Entries: []*github.TreeEntry{
+							{Path: github.Ptr("README.md"), Type: github.Ptr("blob"), Size: github.Ptr(120), SHA: github.Ptr("a1")},
+							{Path: github.Ptr("assets"), Type: github.Ptr("tree"), SHA: github.Ptr("b2")},
+							{Path: github.Ptr("assets/video.mp4"), Type: github.Ptr("blob"), Size: github.Ptr(5000000), SHA: github.Ptr("c3")},
+						},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expected: map[string]interface{}{
+				"ref":       "main",
+				"format":    "tarball",
+				"too_large": true,
+				"note":      fmt.Sprintf("The archive is larger than %d bytes, so a manifest of its files is returned instead. Fetch the files needed with get_file_contents", maxArchiveBytes),
+				"entries": []interface{}{
+					map[string]interface{}{"path": "README.md", "size": float64(120)},
+					map[string]interface{}{"path": "assets/video.mp4", "size": float64(5000000)},
+				},
+				"total_entries": float64(2),
+				"truncated":     false,
 			},
-			mockResponses: []mock.MockBackendOption{},
-			wantErr:       false,
-			errContains:   "missing required parameter: owner",
 		},
 		{
-			name: "missing repo",
-			args: map[string]interface{}{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTarballByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
 				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "missing",
 			},
-			mockResponses: []mock.MockBackendOption{},
-			wantErr:       false,
-			errContains:   "missing required parameter: repo",
+			expectError:    false,
+			expectedErrMsg: "ref missing not found in owner/repo",
 		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Create mock client
-			mockClient := github.NewClient(mock.NewMockedHTTPClient(tt.mockResponses...))
-			_, handler := ListBranches(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryArchive(stubGetClientFn(client), translations.NullTranslationHelper)
 
-			// Create request
-			request := createMCPRequest(tt.args)
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
 
 			// Call handler
 			result, err := handler(context.Background(), request)
-			if tt.wantErr {
+
+			// Verify results
+			if tc.expectError {
 				require.Error(t, err)
-				if tt.errContains != "" {
-					assert.Contains(t, err.Error(), tt.errContains)
-				}
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
 				return
 			}
 
 			require.NoError(t, err)
-			require.NotNil(t, result)
+			textContent := getTextResult(t, result)
 
-			if tt.errContains != "" {
-				textContent := getTextResult(t, result)
-				assert.Contains(t, textContent.Text, tt.errContains)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
 				return
 			}
 
-			textContent := getTextResult(t, result)
-			require.NotEmpty(t, textContent.Text)
-
-			// Verify response
-			var branches []*github.Branch
-			err = json.Unmarshal([]byte(textContent.Text), &branches)
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
 			require.NoError(t, err)
-			assert.Len(t, branches, 2)
-			assert.Equal(t, "main", *branches[0].Name)
-			assert.Equal(t, "develop", *branches[1].Name)
+			assert.Equal(t, tc.expected, response)
 		})
 	}
 }
@@ -0,0 +1,367 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// rulesetEnforcements are the enforcement levels of a ruleset.
+var rulesetEnforcements = []string{"active", "evaluate", "disabled"}
+
+// rulesetTargets are the kinds of refs a ruleset applies to.
+var rulesetTargets = []string{"branch", "tag", "push"}
+
+// rulesetDefinitionOptions returns the parameters defining a ruleset. A new ruleset needs a name, an
+// enforcement level and rules, while an update only changes what it is given.
+func rulesetDefinitionOptions(create bool) []mcp.ToolOption {
+	required := func() mcp.PropertyOption {
+		if create {
+			return mcp.Required()
+		}
+		return func(map[string]interface{}) {}
+	}
+	return []mcp.ToolOption{
+		mcp.WithString("name",
+			required(),
+			mcp.Description("Name of the ruleset"),
+		),
+		mcp.WithString("target",
+			mcp.Description("Kind of refs the ruleset applies to, branch by default when creating a ruleset"),
+			mcp.Enum(rulesetTargets...),
+		),
+		mcp.WithString("enforcement",
+			required(),
+			mcp.Description("Whether the ruleset is enforced, only evaluated to report what it would block, or disabled"),
+			mcp.Enum(rulesetEnforcements...),
+		),
+		mcp.WithObject("conditions",
+			mcp.Description("Refs the ruleset applies to, such as {\"ref_name\": {\"include\": [\"~DEFAULT_BRANCH\", \"refs/heads/release/*\"], \"exclude\": []}}"),
+		),
+		mcp.WithArray("rules",
+			required(),
+			mcp.Items(
+				map[string]interface{}{
+					"type":     "object",
+					"required": []string{"type"},
+					"properties": map[string]interface{}{
+						"type": map[string]interface{}{
+							"type":        "string",
+							"description": "type of the rule, such as deletion, non_fast_forward, required_linear_history, pull_request or required_status_checks",
+						},
+						"parameters": map[string]interface{}{
+							"type":        "object",
+							"description": "parameters of the rule, for the rule types that take some",
+						},
+					},
+				}),
+			mcp.Description("Rules of the ruleset, each object with a type and, for the rule types that take some, parameters. At least one rule is required, and the rules replace the current ones when updating a ruleset"),
+		),
+	}
+}
+
+// parseRulesetRules decodes the rules of a ruleset. Each rule is decoded on its own first so that a rule
+// of a type go-github doesn't know about is reported instead of silently dropped.
+func parseRulesetRules(raw []interface{}) (*github.RepositoryRulesetRules, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("rules must contain at least one rule")
+	}
+	for _, rule := range raw {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("each rule must be an object with a type and optional parameters")
+		}
+		ruleType, _ := ruleMap["type"].(string)
+		if ruleType == "" {
+			return nil, fmt.Errorf("each rule must have a type")
+		}
+		encoded, err := json.Marshal([]interface{}{rule})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rule %s: %w", ruleType, err)
+		}
+		single := &github.RepositoryRulesetRules{}
+		if err := json.Unmarshal(encoded, single); err != nil {
+			return nil, fmt.Errorf("invalid parameters for rule %s: %w", ruleType, err)
+		}
+		if reencoded, err := json.Marshal(single); err != nil || string(reencoded) == "[]" {
+			return nil, fmt.Errorf("unknown rule type %q", ruleType)
+		}
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules: %w", err)
+	}
+	rules := &github.RepositoryRulesetRules{}
+	if err := json.Unmarshal(encoded, rules); err != nil {
+		return nil, fmt.Errorf("invalid rules: %w", err)
+	}
+	return rules, nil
+}
+
+// parseRulesetConditions decodes the conditions of a ruleset, rejecting unknown conditions.
+func parseRulesetConditions(raw map[string]interface{}) (*github.RepositoryRulesetConditions, error) {
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conditions: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	conditions := &github.RepositoryRulesetConditions{}
+	if err := decoder.Decode(conditions); err != nil {
+		return nil, fmt.Errorf("invalid conditions: %w", err)
+	}
+	return conditions, nil
+}
+
+// applyRulesetDefinition sets the fields of the ruleset given in the request, returning whether any was given.
+func applyRulesetDefinition(request mcp.CallToolRequest, ruleset *github.RepositoryRuleset) (bool, error) {
+	changed := false
+
+	name, ok, err := OptionalParamOK[string](request, "name")
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		ruleset.Name, changed = name, true
+	}
+
+	target, ok, err := OptionalParamOK[string](request, "target")
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		if !slices.Contains(rulesetTargets, target) {
+			return false, fmt.Errorf("target must be one of %s", strings.Join(rulesetTargets, ", "))
+		}
+		ruleset.Target, changed = github.Ptr(github.RulesetTarget(target)), true
+	}
+
+	enforcement, ok, err := OptionalParamOK[string](request, "enforcement")
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		if !slices.Contains(rulesetEnforcements, enforcement) {
+			return false, fmt.Errorf("enforcement must be one of %s", strings.Join(rulesetEnforcements, ", "))
+		}
+		ruleset.Enforcement, changed = github.RulesetEnforcement(enforcement), true
+	}
+
+	rawConditions, ok, err := OptionalParamOK[map[string]interface{}](request, "conditions")
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		conditions, err := parseRulesetConditions(rawConditions)
+		if err != nil {
+			return false, err
+		}
+		ruleset.Conditions, changed = conditions, true
+	}
+
+	rawRules, ok, err := OptionalParamOK[[]interface{}](request, "rules")
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		rules, err := parseRulesetRules(rawRules)
+		if err != nil {
+			return false, err
+		}
+		ruleset.Rules, changed = rules, true
+	}
+
+	return changed, nil
+}
+
+// CreateRuleset creates a tool to create a ruleset in a repository.
+func CreateRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_CREATE_RULESET_DESCRIPTION", "Create a ruleset in a repository, protecting the branches or tags it targets with a set of rules")),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		),
+	}
+	return mcp.NewTool("create_ruleset", append(options, rulesetDefinitionOptions(true)...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, err := requiredParam[string](request, "name"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, err := requiredParam[string](request, "enforcement"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, ok := request.Params.Arguments["rules"]; !ok {
+				return mcp.NewToolResultError("missing required parameter: rules"), nil
+			}
+
+			ruleset := &github.RepositoryRuleset{Target: github.Ptr(github.RulesetTargetBranch)}
+			if _, err := applyRulesetDefinition(request, ruleset); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			created, resp, err := client.Repositories.CreateRuleset(ctx, owner, repo, *ruleset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create ruleset: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(created)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateRuleset creates a tool to update a ruleset of a repository.
+func UpdateRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_UPDATE_RULESET_DESCRIPTION", "Update a ruleset of a repository, keeping the parts of its definition that aren't given as they are")),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		),
+		mcp.WithNumber("ruleset_id",
+			mcp.Required(),
+			mcp.Description("ID of the ruleset"),
+		),
+	}
+	return mcp.NewTool("update_ruleset", append(options, rulesetDefinitionOptions(false)...)...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rulesetID, err := RequiredInt(request, "ruleset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			current, resp, err := client.Repositories.GetRuleset(ctx, owner, repo, int64(rulesetID), false)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("ruleset %d not found in %s/%s", rulesetID, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get ruleset: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			// The update replaces the whole definition, so it starts from the current one
+			ruleset := &github.RepositoryRuleset{
+				Name:         current.Name,
+				Target:       current.Target,
+				Enforcement:  current.Enforcement,
+				BypassActors: current.BypassActors,
+				Conditions:   current.Conditions,
+				Rules:        current.Rules,
+			}
+			changed, err := applyRulesetDefinition(request, ruleset)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !changed {
+				return mcp.NewToolResultError("No update parameters provided."), nil
+			}
+
+			updated, resp, err := client.Repositories.UpdateRuleset(ctx, owner, repo, int64(rulesetID), *ruleset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update ruleset: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(updated)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteRuleset creates a tool to delete a ruleset of a repository.
+func DeleteRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_ruleset",
+			mcp.WithDescription(t("TOOL_DELETE_RULESET_DESCRIPTION", "Delete a ruleset of a repository, lifting the rules it enforces")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("ruleset_id",
+				mcp.Required(),
+				mcp.Description("ID of the ruleset"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rulesetID, err := RequiredInt(request, "ruleset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Repositories.DeleteRuleset(ctx, owner, repo, int64(rulesetID))
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("ruleset %d not found in %s/%s", rulesetID, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to delete ruleset: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Deleted ruleset %d from %s/%s", rulesetID, owner, repo)), nil
+		}
+}
@@ -0,0 +1,464 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_ruleset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "target")
+	assert.Contains(t, tool.InputSchema.Properties, "enforcement")
+	assert.Contains(t, tool.InputSchema.Properties, "conditions")
+	assert.Contains(t, tool.InputSchema.Properties, "rules")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name", "enforcement", "rules"})
+
+	mockRuleset := &github.RepositoryRuleset{
+		ID:          github.Ptr(int64(42)),
+		Name:        "Protect main",
+		Target:      github.Ptr(github.RulesetTargetBranch),
+		Enforcement: github.RulesetEnforcementActive,
+	}
+	branchRules := []interface{}{
+		map[string]interface{}{"type": "deletion"},
+		map[string]interface{}{
+			"type": "required_status_checks",
+			"parameters": map[string]interface{}{
+				"required_status_checks":               []interface{}{map[string]interface{}{"context": "build"}},
+				"strict_required_status_checks_policy": true,
+			},
+		},
+		map[string]interface{}{"type": "non_fast_forward"},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedRuleset *github.RepositoryRuleset
+	}{
+		{
+			name: "create a branch ruleset",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposRulesetsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"name":        "Protect main",
+						"target":      "branch",
+						"source":      "",
+						"enforcement": "active",
+						"conditions": map[string]interface{}{
+							"ref_name": map[string]interface{}{
+								"include": []interface{}{"~DEFAULT_BRANCH"},
+								"exclude": []interface{}{},
+							},
+						},
+						"rules": branchRules,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRuleset),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"name":        "Protect main",
+				"enforcement": "active",
+				"conditions": map[string]interface{}{
+					"ref_name": map[string]interface{}{
+						"include": []interface{}{"~DEFAULT_BRANCH"},
+						"exclude": []interface{}{},
+					},
+				},
+				"rules": branchRules,
+			},
+			expectError:     false,
+			expectedRuleset: mockRuleset,
+		},
+		{
+			name:         "no rules",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"name":        "Protect main",
+				"enforcement": "active",
+				"rules":       []interface{}{},
+			},
+			expectError:    false,
+			expectedErrMsg: "rules must contain at least one rule",
+		},
+		{
+			name:         "unknown rule type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"name":        "Protect main",
+				"enforcement": "active",
+				"rules":       []interface{}{map[string]interface{}{"type": "no_force_push"}},
+			},
+			expectError:    false,
+			expectedErrMsg: `unknown rule type "no_force_push"`,
+		},
+		{
+			name:         "invalid enforcement",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"name":        "Protect main",
+				"enforcement": "enabled",
+				"rules":       branchRules,
+			},
+			expectError:    false,
+			expectedErrMsg: "enforcement must be one of active, evaluate, disabled",
+		},
+		{
+			name:         "unknown condition",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"name":        "Protect main",
+				"enforcement": "active",
+				"conditions":  map[string]interface{}{"branch_name": map[string]interface{}{}},
+				"rules":       branchRules,
+			},
+			expectError:    false,
+			expectedErrMsg: `invalid conditions: json: unknown field "branch_name"`,
+		},
+		{
+			name:         "missing rules",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"name":        "Protect main",
+				"enforcement": "active",
+			},
+			expectError:    false,
+			expectedErrMsg: "missing required parameter: rules",
+		},
+		{
+			name: "creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposRulesetsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Name must be unique"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"name":        "Protect main",
+				"enforcement": "active",
+				"rules":       branchRules,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create ruleset",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedRuleset github.RepositoryRuleset
+			err = json.Unmarshal([]byte(textContent.Text), &returnedRuleset)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.expectedRuleset.ID, *returnedRuleset.ID)
+			assert.Equal(t, tc.expectedRuleset.Name, returnedRuleset.Name)
+			assert.Equal(t, tc.expectedRuleset.Enforcement, returnedRuleset.Enforcement)
+		})
+	}
+}
+
+func Test_UpdateRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_ruleset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "ruleset_id")
+	assert.Contains(t, tool.InputSchema.Properties, "enforcement")
+	assert.Contains(t, tool.InputSchema.Properties, "rules")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ruleset_id"})
+
+	currentRuleset := &github.RepositoryRuleset{
+		ID:          github.Ptr(int64(42)),
+		Name:        "Protect main",
+		Target:      github.Ptr(github.RulesetTargetBranch),
+		Source:      "owner/repo",
+		SourceType:  github.Ptr(github.RulesetSourceTypeRepository),
+		Enforcement: github.RulesetEnforcementEvaluate,
+		Rules:       &github.RepositoryRulesetRules{Deletion: &github.EmptyRuleParameters{}},
+	}
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		requestArgs         map[string]interface{}
+		expectError         bool
+		expectedErrMsg      string
+		expectedEnforcement github.RulesetEnforcement
+	}{
+		{
+			name: "enforce the ruleset, keeping its rules",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+					currentRuleset,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposRulesetsByOwnerByRepoByRulesetId,
+					expectRequestBody(t, map[string]interface{}{
+						"name":        "Protect main",
+						"target":      "branch",
+						"source":      "",
+						"enforcement": "active",
+						"rules":       []interface{}{map[string]interface{}{"type": "deletion"}},
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepositoryRuleset{
+							ID:          github.Ptr(int64(42)),
+							Name:        "Protect main",
+							Enforcement: github.RulesetEnforcementActive,
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"ruleset_id":  float64(42),
+				"enforcement": "active",
+			},
+			expectError:         false,
+			expectedEnforcement: github.RulesetEnforcementActive,
+		},
+		{
+			name: "empty rules",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+					currentRuleset,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ruleset_id": float64(42),
+				"rules":      []interface{}{},
+			},
+			expectError:    false,
+			expectedErrMsg: "rules must contain at least one rule",
+		},
+		{
+			name: "nothing to update",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+					currentRuleset,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ruleset_id": float64(42),
+			},
+			expectError:    false,
+			expectedErrMsg: "No update parameters provided.",
+		},
+		{
+			name: "ruleset not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"ruleset_id":  float64(7),
+				"enforcement": "active",
+			},
+			expectError:    false,
+			expectedErrMsg: "ruleset 7 not found in owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedRuleset github.RepositoryRuleset
+			err = json.Unmarshal([]byte(textContent.Text), &returnedRuleset)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedEnforcement, returnedRuleset.Enforcement)
+		})
+	}
+}
+
+func Test_DeleteRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_ruleset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "ruleset_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ruleset_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "delete a ruleset",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposRulesetsByOwnerByRepoByRulesetId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ruleset_id": float64(42),
+			},
+			expectError:  false,
+			expectedText: "Deleted ruleset 42 from owner/repo",
+		},
+		{
+			name: "ruleset not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposRulesetsByOwnerByRepoByRulesetId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ruleset_id": float64(7),
+			},
+			expectError:    false,
+			expectedErrMsg: "ruleset 7 not found in owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v69/github"
@@ -138,6 +141,40 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 		}
 }
 
+// followersRangePattern matches the range syntax of the followers search qualifier: a number, a comparison
+// such as >=10, or a range such as 10..50, 10..* or *..50.
+var followersRangePattern = regexp.MustCompile(`^(\d+|[<>]=?\d+|(\d+)\.\.(\d+|\*)|\*\.\.\d+)$`)
+
+// userSearchQuery adds the location, language and followers qualifiers to a users search query.
+func userSearchQuery(query, location, language, followers string) (string, error) {
+	qualifiers := []string{query}
+	if location != "" {
+		// Quote locations such as "San Francisco" so the search doesn't split them into separate terms
+		if strings.ContainsAny(location, " \t") {
+			location = strconv.Quote(location)
+		}
+		qualifiers = append(qualifiers, "location:"+location)
+	}
+	if language != "" {
+		qualifiers = append(qualifiers, "language:"+language)
+	}
+	if followers != "" {
+		match := followersRangePattern.FindStringSubmatch(followers)
+		if match == nil {
+			return "", fmt.Errorf("followers must be a number, a comparison such as >=10, or a range such as 10..50, got %q", followers)
+		}
+		if match[2] != "" && match[3] != "*" {
+			low, _ := strconv.Atoi(match[2])
+			high, _ := strconv.Atoi(match[3])
+			if low > high {
+				return "", fmt.Errorf("followers range %q starts above where it ends", followers)
+			}
+		}
+		qualifiers = append(qualifiers, "followers:"+followers)
+	}
+	return strings.Join(qualifiers, " "), nil
+}
+
 // SearchUsers creates a tool to search for GitHub users.
 func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("search_users",
@@ -146,6 +183,15 @@ func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				mcp.Required(),
 				mcp.Description("Search query using GitHub users search syntax"),
 			),
+			mcp.WithString("location",
+				mcp.Description("Only find users whose profile location matches, such as 'Finland' or 'San Francisco'"),
+			),
+			mcp.WithString("language",
+				mcp.Description("Only find users with repositories mostly written in this language"),
+			),
+			mcp.WithString("followers",
+				mcp.Description("Only find users with this many followers: a number, a comparison such as '>=100', or a range such as '10..50'"),
+			),
 			mcp.WithString("sort",
 				mcp.Description("Sort field by category"),
 				mcp.Enum("followers", "repositories", "joined"),
@@ -161,6 +207,22 @@ func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			location, err := OptionalParam[string](request, "location")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			language, err := OptionalParam[string](request, "language")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			followers, err := OptionalParam[string](request, "followers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err = userSearchQuery(query, location, language, followers)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			sort, err := OptionalParam[string](request, "sort")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
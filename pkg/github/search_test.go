@@ -319,6 +319,9 @@ func Test_SearchUsers(t *testing.T) {
 	assert.Equal(t, "search_users", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "q")
+	assert.Contains(t, tool.InputSchema.Properties, "location")
+	assert.Contains(t, tool.InputSchema.Properties, "language")
+	assert.Contains(t, tool.InputSchema.Properties, "followers")
 	assert.Contains(t, tool.InputSchema.Properties, "sort")
 	assert.Contains(t, tool.InputSchema.Properties, "order")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
@@ -405,6 +408,71 @@ func Test_SearchUsers(t *testing.T) {
 			expectError:    false,
 			expectedResult: mockSearchResult,
 		},
+		{
+			name: "users search with qualifiers",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchUsers,
+					expectQueryParams(t, map[string]string{
+						"q":        `type:user location:"San Francisco" language:go followers:10..*`,
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockSearchResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"q":         "type:user",
+				"location":  "San Francisco",
+				"language":  "go",
+				"followers": "10..*",
+			},
+			expectError:    false,
+			expectedResult: mockSearchResult,
+		},
+		{
+			name: "users search with followers comparison",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchUsers,
+					expectQueryParams(t, map[string]string{
+						"q":        "octo location:finland followers:>=100",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockSearchResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"q":         "octo",
+				"location":  "finland",
+				"followers": ">=100",
+			},
+			expectError:    false,
+			expectedResult: mockSearchResult,
+		},
+		{
+			name:         "invalid followers range",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"q":         "octo",
+				"followers": "10-50",
+			},
+			expectError:    false,
+			expectedErrMsg: `followers must be a number, a comparison such as >=10, or a range such as 10..50, got "10-50"`,
+		},
+		{
+			name:         "followers range ending below its start",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"q":         "octo",
+				"followers": "50..10",
+			},
+			expectError:    false,
+			expectedErrMsg: `followers range "50..10" starts above where it ends`,
+		},
 		{
 			name: "search users fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -450,6 +518,12 @@ func Test_SearchUsers(t *testing.T) {
 
 			textContent := getTextResult(t, result)
 
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
+				return
+			}
+
 			// Unmarshal and verify the result
 			var returnedResult github.UsersSearchResult
 			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
@@ -0,0 +1,263 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// orgSecretScanningSettings are the secret scanning settings of an organization, as read from and written to
+// the organization itself. go-github's Organization lacks the non-provider patterns setting, so the requests
+// are built here.
+type orgSecretScanningSettings struct {
+	EnabledForNewRepos               *bool `json:"secret_scanning_enabled_for_new_repositories,omitempty"`
+	PushProtectionEnabledForNewRepos *bool `json:"secret_scanning_push_protection_enabled_for_new_repositories,omitempty"`
+	ValidityChecksEnabled            *bool `json:"secret_scanning_validity_checks_enabled,omitempty"`
+	NonProviderPatternsEnabled       *bool `json:"secret_scanning_non_provider_patterns_enabled,omitempty"`
+}
+
+// summary returns the settings with unreported ones as null, rather than dropping them.
+func (s *orgSecretScanningSettings) summary(org string) map[string]interface{} {
+	return map[string]interface{}{
+		"org": org,
+		"secret_scanning_enabled_for_new_repositories":                 s.EnabledForNewRepos,
+		"secret_scanning_push_protection_enabled_for_new_repositories": s.PushProtectionEnabledForNewRepos,
+		"secret_scanning_validity_checks_enabled":                      s.ValidityChecksEnabled,
+		"secret_scanning_non_provider_patterns_enabled":                s.NonProviderPatternsEnabled,
+	}
+}
+
+// doOrgSecretScanningSettings reads the secret scanning settings of an organization, or updates them when
+// update is set.
+func doOrgSecretScanningSettings(ctx context.Context, client *github.Client, org string, update *orgSecretScanningSettings) (*orgSecretScanningSettings, *github.Response, error) {
+	method := "GET"
+	var body interface{}
+	if update != nil {
+		method = "PATCH"
+		body = update
+	}
+	req, err := client.NewRequest(method, fmt.Sprintf("orgs/%v", org), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	settings := new(orgSecretScanningSettings)
+	resp, err := client.Do(ctx, req, settings)
+	if err != nil {
+		return nil, resp, err
+	}
+	return settings, resp, nil
+}
+
+// GetOrgSecretScanningSettings creates a tool to get the secret scanning settings of an organization.
+func GetOrgSecretScanningSettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_secret_scanning_settings",
+			mcp.WithDescription(t("TOOL_GET_ORG_SECRET_SCANNING_SETTINGS_DESCRIPTION", "Get the secret scanning settings of an organization: whether secret scanning and push protection are enabled for new repositories, and whether validity checks and non-provider patterns are enabled. Requires organization owner or security manager permissions")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			settings, resp, err := doOrgSecretScanningSettings(ctx, client, org, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get organization secret scanning settings: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get organization secret scanning settings: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(settings.summary(org))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateOrgSecretScanningSettings creates a tool to update the secret scanning settings of an organization.
+func UpdateOrgSecretScanningSettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_org_secret_scanning_settings",
+			mcp.WithDescription(t("TOOL_UPDATE_ORG_SECRET_SCANNING_SETTINGS_DESCRIPTION", "Update the secret scanning settings of an organization. Only the given settings are changed. Requires organization owner or security manager permissions")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			mcp.WithBoolean("secret_scanning_enabled_for_new_repositories",
+				mcp.Description("Enable secret scanning for new repositories"),
+			),
+			mcp.WithBoolean("secret_scanning_push_protection_enabled_for_new_repositories",
+				mcp.Description("Enable secret scanning push protection for new repositories"),
+			),
+			mcp.WithBoolean("secret_scanning_validity_checks_enabled",
+				mcp.Description("Enable checking detected secrets for validity with their provider"),
+			),
+			mcp.WithBoolean("secret_scanning_non_provider_patterns_enabled",
+				mcp.Description("Enable scanning for non-provider patterns, such as private keys and generic API keys"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			update := &orgSecretScanningSettings{}
+			updateNeeded := false
+			for param, field := range map[string]**bool{
+				"secret_scanning_enabled_for_new_repositories":                 &update.EnabledForNewRepos,
+				"secret_scanning_push_protection_enabled_for_new_repositories": &update.PushProtectionEnabledForNewRepos,
+				"secret_scanning_validity_checks_enabled":                      &update.ValidityChecksEnabled,
+				"secret_scanning_non_provider_patterns_enabled":                &update.NonProviderPatternsEnabled,
+			} {
+				value, ok, err := OptionalParamOK[bool](request, param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*field = github.Ptr(value)
+					updateNeeded = true
+				}
+			}
+
+			if !updateNeeded {
+				return mcp.NewToolResultError("No update parameters provided."), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			settings, resp, err := doOrgSecretScanningSettings(ctx, client, org, update)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update organization secret scanning settings: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update organization secret scanning settings: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(settings.summary(org))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListSecretScanningPushProtectionBypasses creates a tool to list the secrets that were pushed to an organization's
+// repositories by bypassing push protection.
+func ListSecretScanningPushProtectionBypasses(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_secret_scanning_push_protection_bypasses",
+			mcp.WithDescription(t("TOOL_LIST_SECRET_SCANNING_PUSH_PROTECTION_BYPASSES_DESCRIPTION", "List the secret scanning alerts of an organization whose secrets were pushed by bypassing push protection, with who bypassed it and when")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			mcp.WithString("secret_type_filter",
+				mcp.Description("Comma-separated list of secret types to return, such as 'github_personal_access_token'. Defaults to all secret types"),
+			),
+			mcp.WithString("resolution_filter",
+				mcp.Description("Comma-separated list of resolutions to return: false_positive, wont_fix, revoked, pattern_edited, pattern_deleted or used_in_tests"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			secretType, err := OptionalParam[string](request, "secret_type_filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resolution, err := OptionalParam[string](request, "resolution_filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			alerts, resp, err := client.SecretScanning.ListAlertsForOrg(ctx, org, &github.SecretScanningAlertListOptions{
+				SecretType: secretType,
+				Resolution: resolution,
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list secret scanning alerts: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list secret scanning alerts: %s", string(body))), nil
+			}
+
+			// The alerts endpoint can't filter on bypasses, so filter the fetched page instead
+			bypasses := []map[string]interface{}{}
+			for _, alert := range alerts {
+				if !alert.GetPushProtectionBypassed() {
+					continue
+				}
+				bypasses = append(bypasses, map[string]interface{}{
+					"alert_number":             alert.GetNumber(),
+					"repository":               alert.GetRepository().GetFullName(),
+					"secret_type":              alert.GetSecretType(),
+					"secret_type_display_name": alert.GetSecretTypeDisplayName(),
+					"state":                    alert.GetState(),
+					"resolution":               alert.Resolution,
+					"bypassed_by":              alert.GetPushProtectionBypassedBy().GetLogin(),
+					"bypassed_at":              alert.PushProtectionBypassedAt,
+					"html_url":                 alert.GetHTMLURL(),
+				})
+			}
+
+			r, err := json.Marshal(bypasses)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
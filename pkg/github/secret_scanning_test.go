@@ -0,0 +1,349 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetOrgSecretScanningSettings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgSecretScanningSettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_org_secret_scanning_settings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedSettings map[string]interface{}
+	}{
+		{
+			name: "successful settings retrieval",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsByOrg,
+					map[string]interface{}{
+						"login": "org",
+						"secret_scanning_enabled_for_new_repositories":                 true,
+						"secret_scanning_push_protection_enabled_for_new_repositories": false,
+						"secret_scanning_validity_checks_enabled":                      true,
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError: false,
+			expectedSettings: map[string]interface{}{
+				"org": "org",
+				"secret_scanning_enabled_for_new_repositories":                 true,
+				"secret_scanning_push_protection_enabled_for_new_repositories": false,
+				"secret_scanning_validity_checks_enabled":                      true,
+				// Settings the organization doesn't report are null
+				"secret_scanning_non_provider_patterns_enabled": nil,
+			},
+		},
+		{
+			name: "settings retrieval fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsByOrg,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get organization secret scanning settings",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOrgSecretScanningSettings(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var settings map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &settings)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSettings, settings)
+		})
+	}
+}
+
+func Test_UpdateOrgSecretScanningSettings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateOrgSecretScanningSettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_org_secret_scanning_settings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "secret_scanning_enabled_for_new_repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "secret_scanning_push_protection_enabled_for_new_repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "secret_scanning_validity_checks_enabled")
+	assert.Contains(t, tool.InputSchema.Properties, "secret_scanning_non_provider_patterns_enabled")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedSettings map[string]interface{}
+	}{
+		{
+			name: "only sends the given settings",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchOrgsByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"secret_scanning_push_protection_enabled_for_new_repositories": true,
+						"secret_scanning_non_provider_patterns_enabled":                false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]interface{}{
+							"login": "org",
+							"secret_scanning_enabled_for_new_repositories":                 true,
+							"secret_scanning_push_protection_enabled_for_new_repositories": true,
+							"secret_scanning_validity_checks_enabled":                      false,
+							"secret_scanning_non_provider_patterns_enabled":                false,
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+				"secret_scanning_push_protection_enabled_for_new_repositories": true,
+				"secret_scanning_non_provider_patterns_enabled":                false,
+			},
+			expectError: false,
+			expectedSettings: map[string]interface{}{
+				"org": "org",
+				"secret_scanning_enabled_for_new_repositories":                 true,
+				"secret_scanning_push_protection_enabled_for_new_repositories": true,
+				"secret_scanning_validity_checks_enabled":                      false,
+				"secret_scanning_non_provider_patterns_enabled":                false,
+			},
+		},
+		{
+			name:         "no settings given",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    false,
+			expectedErrMsg: "No update parameters provided.",
+		},
+		{
+			name: "update fails without permissions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchOrgsByOrg,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+				"secret_scanning_validity_checks_enabled": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update organization secret scanning settings",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateOrgSecretScanningSettings(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var settings map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &settings)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSettings, settings)
+		})
+	}
+}
+
+func Test_ListSecretScanningPushProtectionBypasses(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListSecretScanningPushProtectionBypasses(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_secret_scanning_push_protection_bypasses", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "secret_type_filter")
+	assert.Contains(t, tool.InputSchema.Properties, "resolution_filter")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockAlerts := []*github.SecretScanningAlert{
+		{
+			Number:                   github.Ptr(1),
+			Repository:               &github.Repository{FullName: github.Ptr("org/api")},
+			SecretType:               github.Ptr("github_personal_access_token"),
+			SecretTypeDisplayName:    github.Ptr("GitHub Personal Access Token"),
+			State:                    github.Ptr("resolved"),
+			Resolution:               github.Ptr("revoked"),
+			PushProtectionBypassed:   github.Ptr(true),
+			PushProtectionBypassedBy: &github.User{Login: github.Ptr("octocat")},
+			PushProtectionBypassedAt: &github.Timestamp{Time: time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)},
+			HTMLURL:                  github.Ptr("https://github.com/org/api/security/secret-scanning/1"),
+		},
+		{
+			// An alert found by scanning rather than pushed past push protection
+			Number:                 github.Ptr(2),
+			Repository:             &github.Repository{FullName: github.Ptr("org/web")},
+			SecretType:             github.Ptr("github_personal_access_token"),
+			State:                  github.Ptr("resolved"),
+			Resolution:             github.Ptr("revoked"),
+			PushProtectionBypassed: github.Ptr(false),
+		},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedBypasses []map[string]interface{}
+	}{
+		{
+			name: "only returns bypassed alerts",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsSecretScanningAlertsByOrg,
+					expectQueryParams(t, map[string]string{
+						"secret_type": "github_personal_access_token",
+						"resolution":  "revoked",
+						"page":        "1",
+						"per_page":    "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAlerts),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                "org",
+				"secret_type_filter": "github_personal_access_token",
+				"resolution_filter":  "revoked",
+			},
+			expectError: false,
+			expectedBypasses: []map[string]interface{}{
+				{
+					"alert_number":             float64(1),
+					"repository":               "org/api",
+					"secret_type":              "github_personal_access_token",
+					"secret_type_display_name": "GitHub Personal Access Token",
+					"state":                    "resolved",
+					"resolution":               "revoked",
+					"bypassed_by":              "octocat",
+					"bypassed_at":              "2025-02-01T10:00:00Z",
+					"html_url":                 "https://github.com/org/api/security/secret-scanning/1",
+				},
+			},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsSecretScanningAlertsByOrg,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list secret scanning alerts",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListSecretScanningPushProtectionBypasses(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var bypasses []map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &bypasses)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedBypasses, bypasses)
+		})
+	}
+}
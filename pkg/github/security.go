@@ -0,0 +1,653 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// securitySettingsSummary flattens the security and analysis settings of a repository into their statuses.
+func securitySettingsSummary(repo *github.Repository) map[string]interface{} {
+	settings := repo.GetSecurityAndAnalysis()
+	return map[string]interface{}{
+		"visibility":                      repo.GetVisibility(),
+		"advanced_security":               settings.GetAdvancedSecurity().GetStatus(),
+		"secret_scanning":                 settings.GetSecretScanning().GetStatus(),
+		"secret_scanning_push_protection": settings.GetSecretScanningPushProtection().GetStatus(),
+		"dependabot_security_updates":     settings.GetDependabotSecurityUpdates().GetStatus(),
+	}
+}
+
+// enabledStatus converts a boolean flag into the status string used by the security and analysis API.
+func enabledStatus(enabled bool) *string {
+	if enabled {
+		return github.Ptr("enabled")
+	}
+	return github.Ptr("disabled")
+}
+
+// GetSecuritySettings creates a tool to get the security and analysis settings of a repository.
+func GetSecuritySettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_security_settings",
+			mcp.WithDescription(t("TOOL_GET_SECURITY_SETTINGS_DESCRIPTION", "Get the security and analysis settings (advanced security, secret scanning, push protection, dependabot security updates) of a repository")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get repository: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(securitySettingsSummary(repository))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateSecuritySettings creates a tool to update the security and analysis settings of a repository.
+func UpdateSecuritySettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_security_settings",
+			mcp.WithDescription(t("TOOL_UPDATE_SECURITY_SETTINGS_DESCRIPTION", "Enable or disable security and analysis features of a repository. Advanced Security is always enabled on public repositories and cannot be toggled")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("advanced_security",
+				mcp.Description("Enable GitHub Advanced Security"),
+			),
+			mcp.WithBoolean("secret_scanning",
+				mcp.Description("Enable secret scanning"),
+			),
+			mcp.WithBoolean("secret_scanning_push_protection",
+				mcp.Description("Enable secret scanning push protection"),
+			),
+			mcp.WithBoolean("dependabot",
+				mcp.Description("Enable Dependabot security updates"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			settings := &github.SecurityAndAnalysis{}
+			updateNeeded := false
+
+			advancedSecurity, advancedSecurityOK, err := OptionalParamOK[bool](request, "advanced_security")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if advancedSecurityOK {
+				settings.AdvancedSecurity = &github.AdvancedSecurity{Status: enabledStatus(advancedSecurity)}
+				updateNeeded = true
+			}
+
+			if secretScanning, ok, err := OptionalParamOK[bool](request, "secret_scanning"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				settings.SecretScanning = &github.SecretScanning{Status: enabledStatus(secretScanning)}
+				updateNeeded = true
+			}
+
+			if pushProtection, ok, err := OptionalParamOK[bool](request, "secret_scanning_push_protection"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				settings.SecretScanningPushProtection = &github.SecretScanningPushProtection{Status: enabledStatus(pushProtection)}
+				updateNeeded = true
+			}
+
+			// Dependabot security updates are toggled through their own endpoint, as editing the repository doesn't
+			// change them
+			dependabot, dependabotOK, err := OptionalParamOK[bool](request, "dependabot")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if !updateNeeded && !dependabotOK {
+				return mcp.NewToolResultError("No update parameters provided."), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if advancedSecurityOK {
+				// Advanced Security can't be toggled on public repositories, so check the visibility first
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				if resp.StatusCode != http.StatusOK {
+					body, err := io.ReadAll(resp.Body)
+					if err != nil {
+						return nil, fmt.Errorf("failed to read response body: %w", err)
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get repository: %s", string(body))), nil
+				}
+
+				if !repository.GetPrivate() && repository.GetVisibility() != "internal" {
+					return mcp.NewToolResultError(fmt.Sprintf("Advanced Security is always enabled on public repository %s/%s and cannot be toggled", owner, repo)), nil
+				}
+			}
+
+			var updated *github.Repository
+			var resp *github.Response
+			if updateNeeded {
+				updated, resp, err = client.Repositories.Edit(ctx, owner, repo, &github.Repository{SecurityAndAnalysis: settings})
+			} else {
+				updated, resp, err = client.Repositories.Get(ctx, owner, repo)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to update security settings: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update security settings: %s", string(body))), nil
+			}
+
+			if dependabotOK {
+				var fixesResp *github.Response
+				if dependabot {
+					fixesResp, err = client.Repositories.EnableAutomatedSecurityFixes(ctx, owner, repo)
+				} else {
+					fixesResp, err = client.Repositories.DisableAutomatedSecurityFixes(ctx, owner, repo)
+				}
+				if err != nil {
+					return nil, fmt.Errorf("failed to update Dependabot security updates: %w", err)
+				}
+				_ = fixesResp.Body.Close()
+
+				if updated.SecurityAndAnalysis == nil {
+					updated.SecurityAndAnalysis = &github.SecurityAndAnalysis{}
+				}
+				updated.SecurityAndAnalysis.DependabotSecurityUpdates = &github.DependabotSecurityUpdates{Status: enabledStatus(dependabot)}
+			}
+
+			r, err := json.Marshal(securitySettingsSummary(updated))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetPrivateVulnReporting creates a tool to check if private vulnerability reporting is enabled on a repository.
+func GetPrivateVulnReporting(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_private_vuln_reporting",
+			mcp.WithDescription(t("TOOL_GET_PRIVATE_VULN_REPORTING_DESCRIPTION", "Check if private vulnerability reporting is enabled on a repository, letting security researchers report vulnerabilities to its maintainers privately")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			enabled, resp, err := client.Repositories.IsPrivateReportingEnabled(ctx, owner, repo)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get private vulnerability reporting: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]interface{}{"enabled": enabled})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetPrivateVulnReporting creates a tool to enable or disable private vulnerability reporting on a repository.
+func SetPrivateVulnReporting(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_private_vuln_reporting",
+			mcp.WithDescription(t("TOOL_SET_PRIVATE_VULN_REPORTING_DESCRIPTION", "Enable or disable private vulnerability reporting on a repository. It needs admin access to the repository, and isn't available on every repository")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("enabled",
+				mcp.Required(),
+				mcp.Description("Whether private vulnerability reporting is enabled"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			enabled, ok, err := OptionalParamOK[bool](request, "enabled")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				return mcp.NewToolResultError("missing required parameter: enabled"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			var resp *github.Response
+			action := "disable"
+			if enabled {
+				action = "enable"
+				resp, err = client.Repositories.EnablePrivateReporting(ctx, owner, repo)
+			} else {
+				resp, err = client.Repositories.DisablePrivateReporting(ctx, owner, repo)
+			}
+			if err != nil {
+				// Repositories that can't have private reporting, such as private ones without Advanced Security,
+				// are refused with the reason
+				var errorResponse *github.ErrorResponse
+				if errors.As(err, &errorResponse) && resp != nil {
+					switch resp.StatusCode {
+					case http.StatusNotFound:
+						return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+					case http.StatusForbidden, http.StatusUnprocessableEntity:
+						return mcp.NewToolResultError(fmt.Sprintf("cannot %s private vulnerability reporting on %s/%s: %s", action, owner, repo, errorResponse.Message)), nil
+					}
+				}
+				return nil, fmt.Errorf("failed to %s private vulnerability reporting: %w", action, err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]interface{}{"enabled": enabled})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// securityPostureFinding is the result of checking one category of the security posture of a repository.
+type securityPostureFinding struct {
+	Category string `json:"category"`
+	Status   string `json:"status"`
+	Severity string `json:"severity"`
+	Detail   string `json:"detail"`
+}
+
+// securityPostureCheck is a category of the security posture rubric. check reports whether the repository passes
+// it, an error meaning the category couldn't be checked.
+type securityPostureCheck struct {
+	category string
+	weight   int
+	severity string
+	check    func(ctx context.Context, client *github.Client, repo *github.Repository) (passed bool, detail string, err error)
+}
+
+// securityPostureRubric scores the security posture of a repository out of 100, each category that passes adding
+// its weight:
+//   - secret_scanning (20): secret scanning is on and no secret scanning alert is open, since a leaked secret is
+//     exploitable right away
+//   - dependabot_alerts (20): no critical or high severity Dependabot alert is open
+//   - vulnerability_alerts (15): Dependabot alerts are enabled, without which vulnerable dependencies go unnoticed
+//   - branch_protection (15): the default branch is protected, so nothing lands on it unreviewed
+//   - code_scanning (15): code scanning analyzed the repository in the last 30 days
+//   - automated_security_fixes (10): Dependabot opens pull requests fixing vulnerable dependencies
+//   - commit_signoff (5): commits made on the web must be signed off
+//
+// A category that can't be checked, such as when the token can't read the alerts, adds nothing to the score.
+var securityPostureRubric = []securityPostureCheck{
+	{category: "secret_scanning", weight: 20, severity: "critical", check: checkSecretScanningPosture},
+	{category: "dependabot_alerts", weight: 20, severity: "high", check: checkDependabotAlertsPosture},
+	{category: "vulnerability_alerts", weight: 15, severity: "high", check: checkVulnerabilityAlertsPosture},
+	{category: "branch_protection", weight: 15, severity: "high", check: checkBranchProtectionPosture},
+	{category: "code_scanning", weight: 15, severity: "medium", check: checkCodeScanningPosture},
+	{category: "automated_security_fixes", weight: 10, severity: "medium", check: checkAutomatedSecurityFixesPosture},
+	{category: "commit_signoff", weight: 5, severity: "low", check: checkCommitSignoffPosture},
+}
+
+// maxPostureAlertPages bounds the pages of alerts counted, so that repositories with thousands of alerts stay quick to check.
+const maxPostureAlertPages = 10
+
+// codeScanningStaleAfter is how long after its latest analysis code scanning counts as no longer running.
+const codeScanningStaleAfter = 30 * 24 * time.Hour
+
+func checkSecretScanningPosture(ctx context.Context, client *github.Client, repo *github.Repository) (bool, string, error) {
+	opts := &github.SecretScanningAlertListOptions{State: "open", ListCursorOptions: github.ListCursorOptions{PerPage: 100}}
+	open := 0
+	for page := 0; page < maxPostureAlertPages; page++ {
+		alerts, resp, err := client.SecretScanning.ListAlertsForRepo(ctx, repo.GetOwner().GetLogin(), repo.GetName(), opts)
+		if err != nil {
+			// GitHub answers 404 when secret scanning is off
+			if isGitHubErrorStatus(err, http.StatusNotFound) {
+				return false, "secret scanning is disabled", nil
+			}
+			return false, "", err
+		}
+		_ = resp.Body.Close()
+		open += len(alerts)
+		if resp.After == "" {
+			break
+		}
+		opts.After = resp.After
+	}
+	if open > 0 {
+		return false, fmt.Sprintf("%d open secret scanning alerts", open), nil
+	}
+	return true, "secret scanning is enabled with no open alerts", nil
+}
+
+// countOpenDependabotAlerts counts the open Dependabot alerts of a repository by the severity of their advisory,
+// looking at most at maxPages pages of alerts, or at all of them when maxPages is zero.
+func countOpenDependabotAlerts(ctx context.Context, client *github.Client, owner, repo string, maxPages int) (map[string]int, error) {
+	opts := &github.ListAlertsOptions{State: github.Ptr("open"), ListCursorOptions: github.ListCursorOptions{PerPage: 100}}
+	counts := map[string]int{}
+	for page := 0; maxPages == 0 || page < maxPages; page++ {
+		alerts, resp, err := client.Dependabot.ListRepoAlerts(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		_ = resp.Body.Close()
+		for _, alert := range alerts {
+			counts[alert.GetSecurityAdvisory().GetSeverity()]++
+		}
+		if resp.After == "" {
+			break
+		}
+		opts.After = resp.After
+	}
+	return counts, nil
+}
+
+func checkDependabotAlertsPosture(ctx context.Context, client *github.Client, repo *github.Repository) (bool, string, error) {
+	counts, err := countOpenDependabotAlerts(ctx, client, repo.GetOwner().GetLogin(), repo.GetName(), maxPostureAlertPages)
+	if err != nil {
+		return false, "", err
+	}
+	detail := fmt.Sprintf("open Dependabot alerts: %d critical, %d high, %d medium, %d low", counts["critical"], counts["high"], counts["medium"], counts["low"])
+	return counts["critical"]+counts["high"] == 0, detail, nil
+}
+
+func checkVulnerabilityAlertsPosture(ctx context.Context, client *github.Client, repo *github.Repository) (bool, string, error) {
+	enabled, resp, err := client.Repositories.GetVulnerabilityAlerts(ctx, repo.GetOwner().GetLogin(), repo.GetName())
+	if err != nil {
+		return false, "", err
+	}
+	_ = resp.Body.Close()
+	if !enabled {
+		return false, "Dependabot alerts are disabled", nil
+	}
+	return true, "Dependabot alerts are enabled", nil
+}
+
+func checkBranchProtectionPosture(ctx context.Context, client *github.Client, repo *github.Repository) (bool, string, error) {
+	branch := repo.GetDefaultBranch()
+	_, resp, err := client.Repositories.GetBranchProtection(ctx, repo.GetOwner().GetLogin(), repo.GetName(), branch)
+	if err != nil {
+		if errors.Is(err, github.ErrBranchNotProtected) {
+			return false, fmt.Sprintf("default branch %s is not protected", branch), nil
+		}
+		return false, "", err
+	}
+	_ = resp.Body.Close()
+	return true, fmt.Sprintf("default branch %s is protected", branch), nil
+}
+
+func checkCodeScanningPosture(ctx context.Context, client *github.Client, repo *github.Repository) (bool, string, error) {
+	analyses, resp, err := client.CodeScanning.ListAnalysesForRepo(ctx, repo.GetOwner().GetLogin(), repo.GetName(), &github.AnalysesListOptions{ListOptions: github.ListOptions{PerPage: 1}})
+	if err != nil {
+		// GitHub answers 404 when code scanning never analyzed the repository
+		if isGitHubErrorStatus(err, http.StatusNotFound) {
+			return false, "code scanning has never analyzed the repository", nil
+		}
+		return false, "", err
+	}
+	_ = resp.Body.Close()
+	if len(analyses) == 0 {
+		return false, "code scanning has never analyzed the repository", nil
+	}
+	latest := analyses[0].GetCreatedAt().Time
+	detail := fmt.Sprintf("latest code scanning analysis on %s", latest.UTC().Format(time.DateOnly))
+	return time.Since(latest) <= codeScanningStaleAfter, detail, nil
+}
+
+func checkAutomatedSecurityFixesPosture(ctx context.Context, client *github.Client, repo *github.Repository) (bool, string, error) {
+	fixes, resp, err := client.Repositories.GetAutomatedSecurityFixes(ctx, repo.GetOwner().GetLogin(), repo.GetName())
+	if err != nil {
+		return false, "", err
+	}
+	_ = resp.Body.Close()
+	switch {
+	case !fixes.GetEnabled():
+		return false, "Dependabot security updates are disabled", nil
+	case fixes.GetPaused():
+		return false, "Dependabot security updates are paused", nil
+	}
+	return true, "Dependabot security updates are enabled", nil
+}
+
+func checkCommitSignoffPosture(_ context.Context, _ *github.Client, repo *github.Repository) (bool, string, error) {
+	if !repo.GetWebCommitSignoffRequired() {
+		return false, "commits made on the web don't need to be signed off", nil
+	}
+	return true, "commits made on the web must be signed off", nil
+}
+
+// GetSecurityPosture creates a tool to get an overview of the security posture of a repository.
+func GetSecurityPosture(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_security_posture",
+			mcp.WithDescription(t("TOOL_GET_SECURITY_POSTURE_DESCRIPTION", "Get an overview of the security posture of a repository: secret scanning, Dependabot alerts and security updates, code scanning, default branch protection and commit signoff, each with a finding, and a score from 0 to 100. Categories the token can't check are reported as unknown and don't add to the score")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get repository: %s", string(body))), nil
+			}
+
+			findings := make([]securityPostureFinding, len(securityPostureRubric))
+			errs := forEachConcurrently(ctx, securityPostureRubric, func(ctx context.Context, i int, check securityPostureCheck) error {
+				ok, detail, err := check.check(ctx, client, repository)
+				if err != nil {
+					return err
+				}
+				findings[i] = securityPostureFinding{Category: check.category, Severity: check.severity, Status: "fail", Detail: detail}
+				if ok {
+					findings[i].Status = "pass"
+				}
+				return nil
+			})
+			// A check that failed to run, or never ran because the request was cancelled, can't tell either way
+			for i, err := range errs {
+				if err != nil {
+					check := securityPostureRubric[i]
+					findings[i] = securityPostureFinding{Category: check.category, Severity: check.severity, Status: "unknown", Detail: fmt.Sprintf("could not be checked: %s", err)}
+				}
+			}
+
+			score := 0
+			for i, check := range securityPostureRubric {
+				if findings[i].Status == "pass" {
+					score += check.weight
+				}
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"score":    score,
+				"findings": findings,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetDependabotAlertCounts creates a tool to count the open Dependabot alerts of a repository by severity.
+func GetDependabotAlertCounts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_dependabot_alert_counts",
+			mcp.WithDescription(t("TOOL_GET_DEPENDABOT_ALERT_COUNTS_DESCRIPTION", "Count the open Dependabot alerts of a repository by severity: critical, high, medium and low")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			counts, err := countOpenDependabotAlerts(ctx, client, owner, repo, 0)
+			if err != nil {
+				switch {
+				case isGitHubErrorStatus(err, http.StatusNotFound):
+					return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+				case isGitHubErrorStatus(err, http.StatusForbidden):
+					// GitHub answers 403 both when Dependabot alerts are off and when the token can't read them
+					return mcp.NewToolResultError(fmt.Sprintf("Dependabot alerts are disabled for %s/%s, or the token can't read them", owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to list Dependabot alerts: %w", err)
+			}
+
+			total := 0
+			for _, count := range counts {
+				total += count
+			}
+			r, err := json.Marshal(map[string]int{
+				"critical": counts["critical"],
+				"high":     counts["high"],
+				"medium":   counts["medium"],
+				"low":      counts["low"],
+				"total":    total,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
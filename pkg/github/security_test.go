@@ -0,0 +1,896 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetSecuritySettings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetSecuritySettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_security_settings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRepo := &github.Repository{
+		Name:       github.Ptr("repo"),
+		Private:    github.Ptr(true),
+		Visibility: github.Ptr("private"),
+		SecurityAndAnalysis: &github.SecurityAndAnalysis{
+			AdvancedSecurity:             &github.AdvancedSecurity{Status: github.Ptr("enabled")},
+			SecretScanning:               &github.SecretScanning{Status: github.Ptr("enabled")},
+			SecretScanningPushProtection: &github.SecretScanningPushProtection{Status: github.Ptr("disabled")},
+			DependabotSecurityUpdates:    &github.DependabotSecurityUpdates{Status: github.Ptr("enabled")},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedSettings map[string]string
+		expectedErrMsg   string
+	}{
+		{
+			name: "successful settings fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expectedSettings: map[string]string{
+				"visibility":                      "private",
+				"advanced_security":               "enabled",
+				"secret_scanning":                 "enabled",
+				"secret_scanning_push_protection": "disabled",
+				"dependabot_security_updates":     "enabled",
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetSecuritySettings(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedSettings map[string]string
+			err = json.Unmarshal([]byte(textContent.Text), &returnedSettings)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSettings, returnedSettings)
+		})
+	}
+}
+
+func Test_UpdateSecuritySettings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateSecuritySettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_security_settings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "advanced_security")
+	assert.Contains(t, tool.InputSchema.Properties, "secret_scanning")
+	assert.Contains(t, tool.InputSchema.Properties, "secret_scanning_push_protection")
+	assert.Contains(t, tool.InputSchema.Properties, "dependabot")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	updatedRepo := &github.Repository{
+		Name:       github.Ptr("repo"),
+		Private:    github.Ptr(true),
+		Visibility: github.Ptr("private"),
+		SecurityAndAnalysis: &github.SecurityAndAnalysis{
+			AdvancedSecurity: &github.AdvancedSecurity{Status: github.Ptr("enabled")},
+			SecretScanning:   &github.SecretScanning{Status: github.Ptr("enabled")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult map[string]string
+		expectedErrMsg string
+	}{
+		{
+			name: "enable advanced security and secret scanning on a private repo",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{Private: github.Ptr(true), Visibility: github.Ptr("private")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"security_and_analysis": map[string]interface{}{
+							"advanced_security": map[string]interface{}{"status": "enabled"},
+							"secret_scanning":   map[string]interface{}{"status": "enabled"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, updatedRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"advanced_security": true,
+				"secret_scanning":   true,
+			},
+			expectError: false,
+			expectedResult: map[string]string{
+				"visibility":                      "private",
+				"advanced_security":               "enabled",
+				"secret_scanning":                 "enabled",
+				"secret_scanning_push_protection": "",
+				"dependabot_security_updates":     "",
+			},
+		},
+		{
+			name: "toggling advanced security on a public repo is rejected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{Private: github.Ptr(false), Visibility: github.Ptr("public")},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"advanced_security": false,
+			},
+			expectError:    false,
+			expectedErrMsg: "Advanced Security is always enabled on public repository owner/repo",
+		},
+		{
+			name:         "no update parameters",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedErrMsg: "No update parameters provided.",
+		},
+		{
+			name: "enable dependabot security updates",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					updatedRepo,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposAutomatedSecurityFixesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"dependabot": true,
+			},
+			expectError: false,
+			expectedResult: map[string]string{
+				"visibility":                      "private",
+				"advanced_security":               "enabled",
+				"secret_scanning":                 "enabled",
+				"secret_scanning_push_protection": "",
+				"dependabot_security_updates":     "enabled",
+			},
+		},
+		{
+			name: "disable dependabot security updates and push protection",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"security_and_analysis": map[string]interface{}{
+							"secret_scanning_push_protection": map[string]interface{}{"status": "disabled"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{Visibility: github.Ptr("private")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposAutomatedSecurityFixesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                           "owner",
+				"repo":                            "repo",
+				"secret_scanning_push_protection": false,
+				"dependabot":                      false,
+			},
+			expectError: false,
+			expectedResult: map[string]string{
+				"visibility":                      "private",
+				"advanced_security":               "",
+				"secret_scanning":                 "",
+				"secret_scanning_push_protection": "",
+				"dependabot_security_updates":     "disabled",
+			},
+		},
+		{
+			name: "dependabot security updates fail",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					updatedRepo,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposAutomatedSecurityFixesByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"dependabot": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update Dependabot security updates",
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"secret_scanning": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update security settings",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateSecuritySettings(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedSettings map[string]string
+			err = json.Unmarshal([]byte(textContent.Text), &returnedSettings)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returnedSettings)
+		})
+	}
+}
+
+func Test_GetPrivateVulnReporting(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPrivateVulnReporting(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_private_vuln_reporting", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedEnabled bool
+	}{
+		{
+			name: "enabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPrivateVulnerabilityReportingByOwnerByRepo,
+					map[string]bool{"enabled": true},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:     false,
+			expectedEnabled: true,
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPrivateVulnerabilityReportingByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "repository owner/missing not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPrivateVulnReporting(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned map[string]bool
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedEnabled, returned["enabled"])
+		})
+	}
+}
+
+func Test_SetPrivateVulnReporting(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetPrivateVulnReporting(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_private_vuln_reporting", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "enabled")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "enabled"})
+
+	noContent := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedEnabled bool
+	}{
+		{
+			name: "enable",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposPrivateVulnerabilityReportingByOwnerByRepo,
+					noContent,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"enabled": true,
+			},
+			expectError:     false,
+			expectedEnabled: true,
+		},
+		{
+			name: "disable",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposPrivateVulnerabilityReportingByOwnerByRepo,
+					noContent,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"enabled": false,
+			},
+			expectError:     false,
+			expectedEnabled: false,
+		},
+		{
+			name: "unsupported repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposPrivateVulnerabilityReportingByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Private vulnerability reporting is not available for this repository"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "private-repo",
+				"enabled": true,
+			},
+			expectError:    false,
+			expectedErrMsg: "cannot enable private vulnerability reporting on owner/private-repo: Private vulnerability reporting is not available for this repository",
+		},
+		{
+			name:         "missing enabled",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedErrMsg: "missing required parameter: enabled",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetPrivateVulnReporting(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned map[string]bool
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedEnabled, returned["enabled"])
+		})
+	}
+}
+
+func Test_GetSecurityPosture(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetSecurityPosture(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_security_posture", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRepo := func(signoffRequired bool) *github.Repository {
+		return &github.Repository{
+			Name:                     github.Ptr("repo"),
+			Owner:                    &github.User{Login: github.Ptr("owner")},
+			DefaultBranch:            github.Ptr("main"),
+			WebCommitSignoffRequired: github.Ptr(signoffRequired),
+		}
+	}
+	recentAnalysis := time.Now().Add(-48 * time.Hour)
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		expectError      bool
+		expectedErrMsg   string
+		expectedScore    int
+		expectedFindings []securityPostureFinding
+	}{
+		{
+			name: "every category passes",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, mockRepo(true)),
+				mock.WithRequestMatch(mock.GetReposSecretScanningAlertsByOwnerByRepo, []*github.SecretScanningAlert{}),
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependabotAlertsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"state": "open", "per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, []*github.DependabotAlert{
+							{SecurityAdvisory: &github.DependabotSecurityAdvisory{Severity: github.Ptr("low")}},
+							{SecurityAdvisory: &github.DependabotSecurityAdvisory{Severity: github.Ptr("medium")}},
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposVulnerabilityAlertsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+				mock.WithRequestMatch(mock.GetReposBranchesProtectionByOwnerByRepoByBranch, &github.Protection{}),
+				mock.WithRequestMatch(
+					mock.GetReposCodeScanningAnalysesByOwnerByRepo,
+					[]*github.ScanningAnalysis{{CreatedAt: &github.Timestamp{Time: recentAnalysis}}},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposAutomatedSecurityFixesByOwnerByRepo,
+					&github.AutomatedSecurityFixes{Enabled: github.Ptr(true), Paused: github.Ptr(false)},
+				),
+			),
+			expectError:   false,
+			expectedScore: 100,
+			expectedFindings: []securityPostureFinding{
+				{Category: "secret_scanning", Status: "pass", Severity: "critical", Detail: "secret scanning is enabled with no open alerts"},
+				{Category: "dependabot_alerts", Status: "pass", Severity: "high", Detail: "open Dependabot alerts: 0 critical, 0 high, 1 medium, 1 low"},
+				{Category: "vulnerability_alerts", Status: "pass", Severity: "high", Detail: "Dependabot alerts are enabled"},
+				{Category: "branch_protection", Status: "pass", Severity: "high", Detail: "default branch main is protected"},
+				{Category: "code_scanning", Status: "pass", Severity: "medium", Detail: "latest code scanning analysis on " + recentAnalysis.UTC().Format(time.DateOnly)},
+				{Category: "automated_security_fixes", Status: "pass", Severity: "medium", Detail: "Dependabot security updates are enabled"},
+				{Category: "commit_signoff", Status: "pass", Severity: "low", Detail: "commits made on the web must be signed off"},
+			},
+		},
+		{
+			name: "failing and unknown categories",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, mockRepo(true)),
+				mock.WithRequestMatch(
+					mock.GetReposSecretScanningAlertsByOwnerByRepo,
+					[]*github.SecretScanningAlert{{Number: github.Ptr(1)}, {Number: github.Ptr(2)}},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependabotAlertsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Dependabot alerts are disabled for this repository."}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposVulnerabilityAlertsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Branch not protected"}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCodeScanningAnalysesByOwnerByRepo,
+					[]*github.ScanningAnalysis{{CreatedAt: &github.Timestamp{Time: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)}}},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposAutomatedSecurityFixesByOwnerByRepo,
+					&github.AutomatedSecurityFixes{Enabled: github.Ptr(true), Paused: github.Ptr(true)},
+				),
+			),
+			expectError:   false,
+			expectedScore: 20,
+			expectedFindings: []securityPostureFinding{
+				{Category: "secret_scanning", Status: "fail", Severity: "critical", Detail: "2 open secret scanning alerts"},
+				{Category: "dependabot_alerts", Status: "unknown", Severity: "high"},
+				{Category: "vulnerability_alerts", Status: "pass", Severity: "high", Detail: "Dependabot alerts are enabled"},
+				{Category: "branch_protection", Status: "fail", Severity: "high", Detail: "default branch main is not protected"},
+				{Category: "code_scanning", Status: "fail", Severity: "medium", Detail: "latest code scanning analysis on 2024-01-15"},
+				{Category: "automated_security_fixes", Status: "fail", Severity: "medium", Detail: "Dependabot security updates are paused"},
+				{Category: "commit_signoff", Status: "pass", Severity: "low", Detail: "commits made on the web must be signed off"},
+			},
+		},
+		{
+			name: "security features off",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, mockRepo(false)),
+				mock.WithRequestMatchHandler(
+					mock.GetReposSecretScanningAlertsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Secret scanning is disabled on this repository."}),
+				),
+				mock.WithRequestMatch(mock.GetReposDependabotAlertsByOwnerByRepo, []*github.DependabotAlert{}),
+				mock.WithRequestMatchHandler(
+					mock.GetReposVulnerabilityAlertsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Vulnerability alerts are disabled."}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Branch not protected"}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeScanningAnalysesByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "no analysis found"}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposAutomatedSecurityFixesByOwnerByRepo,
+					&github.AutomatedSecurityFixes{Enabled: github.Ptr(false), Paused: github.Ptr(false)},
+				),
+			),
+			expectError:   false,
+			expectedScore: 20,
+			expectedFindings: []securityPostureFinding{
+				{Category: "secret_scanning", Status: "fail", Severity: "critical", Detail: "secret scanning is disabled"},
+				{Category: "dependabot_alerts", Status: "pass", Severity: "high", Detail: "open Dependabot alerts: 0 critical, 0 high, 0 medium, 0 low"},
+				{Category: "vulnerability_alerts", Status: "fail", Severity: "high", Detail: "Dependabot alerts are disabled"},
+				{Category: "branch_protection", Status: "fail", Severity: "high", Detail: "default branch main is not protected"},
+				{Category: "code_scanning", Status: "fail", Severity: "medium", Detail: "code scanning has never analyzed the repository"},
+				{Category: "automated_security_fixes", Status: "fail", Severity: "medium", Detail: "Dependabot security updates are disabled"},
+				{Category: "commit_signoff", Status: "fail", Severity: "low", Detail: "commits made on the web don't need to be signed off"},
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    false,
+			expectedErrMsg: "repository owner/repo not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetSecurityPosture(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Score    int                      `json:"score"`
+				Findings []securityPostureFinding `json:"findings"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedScore, response.Score)
+			require.Len(t, response.Findings, len(tc.expectedFindings))
+			for i, expected := range tc.expectedFindings {
+				finding := response.Findings[i]
+				if expected.Status == "unknown" {
+					// The detail of an unknown category carries the error GitHub returned
+					assert.Contains(t, finding.Detail, "could not be checked")
+					finding.Detail = ""
+				}
+				assert.Equal(t, expected, finding)
+			}
+		})
+	}
+}
+
+func Test_GetDependabotAlertCounts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetDependabotAlertCounts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_dependabot_alert_counts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	alert := func(severity string) *github.DependabotAlert {
+		return &github.DependabotAlert{SecurityAdvisory: &github.DependabotSecurityAdvisory{Severity: github.Ptr(severity)}}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedCounts map[string]int
+	}{
+		{
+			name: "counts across pages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependabotAlertsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "open", r.URL.Query().Get("state"))
+						if r.URL.Query().Get("after") == "" {
+							w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/dependabot/alerts?state=open&after=Y3Vyc29y>; rel="next"`)
+							mockResponse(t, http.StatusOK, []*github.DependabotAlert{alert("critical"), alert("high"), alert("high"), alert("low")})(w, r)
+							return
+						}
+						assert.Equal(t, "Y3Vyc29y", r.URL.Query().Get("after"))
+						mockResponse(t, http.StatusOK, []*github.DependabotAlert{alert("medium"), alert("high")})(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expectedCounts: map[string]int{
+				"critical": 1,
+				"high":     3,
+				"medium":   1,
+				"low":      1,
+				"total":    6,
+			},
+		},
+		{
+			name: "no alerts",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposDependabotAlertsByOwnerByRepo,
+					[]*github.DependabotAlert{},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError: false,
+			expectedCounts: map[string]int{
+				"critical": 0,
+				"high":     0,
+				"medium":   0,
+				"low":      0,
+				"total":    0,
+			},
+		},
+		{
+			name: "Dependabot alerts disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependabotAlertsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Dependabot alerts are disabled for this repository."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    false,
+			expectedErrMsg: "Dependabot alerts are disabled for owner/repo, or the token can't read them",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetDependabotAlertCounts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var counts map[string]int
+			err = json.Unmarshal([]byte(textContent.Text), &counts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCounts, counts)
+		})
+	}
+}
@@ -59,6 +59,15 @@ func isAcceptedError(err error) bool {
 	return errors.As(err, &acceptedError)
 }
 
+// isGitHubErrorStatus checks if the error is a GitHub API error response with the given status code.
+func isGitHubErrorStatus(err error, statusCode int) bool {
+	var errorResponse *github.ErrorResponse
+	if !errors.As(err, &errorResponse) || errorResponse.Response == nil {
+		return false
+	}
+	return errorResponse.Response.StatusCode == statusCode
+}
+
 // requiredParam is a helper function that can be used to fetch a requested parameter from the request.
 // It does the following checks:
 // 1. Checks if the parameter is present in the request.
@@ -173,6 +182,44 @@ func OptionalStringArrayParam(r mcp.CallToolRequest, p string) ([]string, error)
 	}
 }
 
+// RequiredIntArrayParam is a helper function that can be used to fetch a requested parameter from the request.
+// It does the following checks:
+// 1. Checks if the parameter is present in the request.
+// 2. Checks if every element of the parameter is a number.
+// 3. Checks if the parameter is not empty, i.e: contains at least one element
+func RequiredIntArrayParam(r mcp.CallToolRequest, p string) ([]int, error) {
+	if _, ok := r.Params.Arguments[p]; !ok {
+		return nil, fmt.Errorf("missing required parameter: %s", p)
+	}
+
+	var intSlice []int
+	switch v := r.Params.Arguments[p].(type) {
+	case []int:
+		intSlice = v
+	case []float64:
+		intSlice = make([]int, len(v))
+		for i, f := range v {
+			intSlice[i] = int(f)
+		}
+	case []any:
+		intSlice = make([]int, len(v))
+		for i, e := range v {
+			f, ok := e.(float64)
+			if !ok {
+				return nil, fmt.Errorf("parameter %s is not of type number, is %T", p, e)
+			}
+			intSlice[i] = int(f)
+		}
+	default:
+		return nil, fmt.Errorf("parameter %s could not be coerced to []int, is %T", p, r.Params.Arguments[p])
+	}
+
+	if len(intSlice) == 0 {
+		return nil, fmt.Errorf("missing required parameter: %s", p)
+	}
+	return intSlice, nil
+}
+
 // WithPagination returns a ToolOption that adds "page" and "perPage" parameters to the tool.
 // The "page" parameter is optional, min 1. The "perPage" parameter is optional, min 1, max 100.
 func WithPagination() mcp.ToolOption {
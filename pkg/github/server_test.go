@@ -435,6 +435,79 @@ func TestOptionalStringArrayParam(t *testing.T) {
 	}
 }
 
+func TestRequiredIntArrayParam(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]interface{}
+		paramName   string
+		expected    []int
+		expectError bool
+	}{
+		{
+			name:        "parameter not in request",
+			params:      map[string]any{},
+			paramName:   "numbers",
+			expectError: true,
+		},
+		{
+			name: "valid any array parameter",
+			params: map[string]any{
+				"numbers": []any{float64(1), float64(2)},
+			},
+			paramName:   "numbers",
+			expected:    []int{1, 2},
+			expectError: false,
+		},
+		{
+			name: "valid float array parameter",
+			params: map[string]any{
+				"numbers": []float64{3, 4},
+			},
+			paramName:   "numbers",
+			expected:    []int{3, 4},
+			expectError: false,
+		},
+		{
+			name: "empty array parameter",
+			params: map[string]any{
+				"numbers": []any{},
+			},
+			paramName:   "numbers",
+			expectError: true,
+		},
+		{
+			name: "wrong type parameter",
+			params: map[string]any{
+				"numbers": 1,
+			},
+			paramName:   "numbers",
+			expectError: true,
+		},
+		{
+			name: "wrong slice type parameter",
+			params: map[string]any{
+				"numbers": []any{float64(1), "2"},
+			},
+			paramName:   "numbers",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.params)
+			result, err := RequiredIntArrayParam(request, tc.paramName)
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
 func TestOptionalPaginationParams(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,434 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// sponsorshipsConnection fetches a page of the active public sponsorships of the account queried as owner,
+// oldest first. Private sponsorships are left out, since only the sponsored account can see them.
+const sponsorshipsConnection = `sponsorshipsAsMaintainer(first: $first, after: $after, includePrivate: false, activeOnly: true, orderBy: {field: CREATED_AT, direction: ASC}) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        createdAt
+        privacyLevel
+        tier { name monthlyPriceInDollars }
+        sponsorEntity {
+          __typename
+          ... on User { login avatarUrl }
+          ... on Organization { login avatarUrl }
+        }
+      }
+    }`
+
+// orgSponsorsQuery fetches a page of the public sponsors of an organization.
+const orgSponsorsQuery = `query($login: String!, $first: Int!, $after: String) {
+  owner: organization(login: $login) {
+    ` + sponsorshipsConnection + `
+  }
+}`
+
+// userSponsorsQuery fetches a page of the public sponsors of a user.
+const userSponsorsQuery = `query($login: String!, $first: Int!, $after: String) {
+  owner: user(login: $login) {
+    ` + sponsorshipsConnection + `
+  }
+}`
+
+// viewerSponsorshipQuery fetches whether the authenticated user sponsors an account, and how.
+const viewerSponsorshipQuery = `query($login: String!) {
+  repositoryOwner(login: $login) {
+    __typename
+    login
+    ... on Sponsorable {
+      viewerIsSponsoring
+      sponsorshipForViewerAsSponsor {
+        createdAt
+        privacyLevel
+        tier { name monthlyPriceInDollars }
+      }
+    }
+  }
+}`
+
+// orgSponsorsTiersQuery fetches the published tiers of the sponsors profile of an organization.
+const orgSponsorsTiersQuery = `query($login: String!) {
+  organization(login: $login) {
+    sponsorsListing {
+      tiers(first: 100) {
+        nodes {
+          name
+          description
+          monthlyPriceInDollars
+          isOneTime
+          isCustomAmount
+        }
+      }
+    }
+  }
+}`
+
+// sponsorsTier is a tier of a sponsors profile, as returned by the GraphQL API.
+type sponsorsTier struct {
+	Name                  string `json:"name"`
+	Description           string `json:"description"`
+	MonthlyPriceInDollars int    `json:"monthlyPriceInDollars"`
+	IsOneTime             bool   `json:"isOneTime"`
+	IsCustomAmount        bool   `json:"isCustomAmount"`
+}
+
+// sponsor is an account publicly sponsoring a user or an organization.
+type sponsor struct {
+	Login string `json:"login"`
+	// AvatarURL is the URL of the sponsor's avatar.
+	AvatarURL string `json:"avatar_url"`
+	// Type is user or organization.
+	Type     string `json:"type"`
+	TierName string `json:"tier_name,omitempty"`
+	// TierMonthlyPriceInDollars is the price of the tier, or its one-time price for one-time tiers.
+	TierMonthlyPriceInDollars *int   `json:"tier_monthly_price_in_dollars,omitempty"`
+	SponsoringSince           string `json:"sponsoring_since"`
+}
+
+// sponsorsTierSlug returns the slug of a tier name: its letters and digits in lowercase, with dashes
+// in between, such as 5-a-month for "$5 a month". Slugs of slugs are themselves.
+func sponsorsTierSlug(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	}), "-")
+}
+
+// sponsorsPage is a page of the sponsors of an account.
+type sponsorsPage struct {
+	Sponsors   []sponsor   `json:"sponsors"`
+	NextCursor interface{} `json:"next_cursor"`
+}
+
+// listSponsors fetches a page of the public sponsors of the account queried as owner by query, keeping only
+// those on the tier with the given slug, if any. An account that doesn't exist is reported as a NOT_FOUND
+// GraphQL error.
+func listSponsors(ctx context.Context, client *github.Client, query, login, tierSlug, after string, perPage int) (*sponsorsPage, error) {
+	variables := map[string]interface{}{
+		"login": login,
+		"first": perPage,
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+	var data struct {
+		Owner struct {
+			SponsorshipsAsMaintainer struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []struct {
+					CreatedAt     string        `json:"createdAt"`
+					PrivacyLevel  string        `json:"privacyLevel"`
+					Tier          *sponsorsTier `json:"tier"`
+					SponsorEntity *struct {
+						Typename  string `json:"__typename"`
+						Login     string `json:"login"`
+						AvatarURL string `json:"avatarUrl"`
+					} `json:"sponsorEntity"`
+				} `json:"nodes"`
+			} `json:"sponsorshipsAsMaintainer"`
+		} `json:"owner"`
+	}
+	if err := executeGraphQL(ctx, client, query, variables, &data); err != nil {
+		return nil, err
+	}
+
+	connection := data.Owner.SponsorshipsAsMaintainer
+	page := &sponsorsPage{Sponsors: []sponsor{}}
+	for _, node := range connection.Nodes {
+		// The sponsor of a private sponsorship is hidden from everyone but the sponsored account
+		if node.SponsorEntity == nil || node.PrivacyLevel != "PUBLIC" {
+			continue
+		}
+		if tierSlug != "" && (node.Tier == nil || sponsorsTierSlug(node.Tier.Name) != sponsorsTierSlug(tierSlug)) {
+			continue
+		}
+		s := sponsor{
+			Login:           node.SponsorEntity.Login,
+			AvatarURL:       node.SponsorEntity.AvatarURL,
+			Type:            strings.ToLower(node.SponsorEntity.Typename),
+			SponsoringSince: node.CreatedAt,
+		}
+		if node.Tier != nil {
+			s.TierName = node.Tier.Name
+			s.TierMonthlyPriceInDollars = github.Ptr(node.Tier.MonthlyPriceInDollars)
+		}
+		page.Sponsors = append(page.Sponsors, s)
+	}
+	if connection.PageInfo.HasNextPage {
+		page.NextCursor = connection.PageInfo.EndCursor
+	}
+	return page, nil
+}
+
+// ListOrgSponsors creates a tool to list the public sponsors of an organization.
+func ListOrgSponsors(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_sponsors",
+			mcp.WithDescription(t("TOOL_LIST_ORG_SPONSORS_DESCRIPTION", "List the accounts publicly sponsoring a GitHub organization through GitHub Sponsors, oldest sponsorship first. Private sponsorships are left out")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("tier_slug",
+				mcp.Description("Only list the sponsors on this tier, given by its slug, such as 5-a-month for the \"$5 a month\" tier, or by its name. The filter applies to each page, so a page may hold fewer sponsors than perPage"),
+			),
+			mcp.WithString("after",
+				mcp.Description("Cursor to fetch the sponsors after, as returned in next_cursor"),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description("Results per page (min 1, max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tierSlug, err := OptionalParam[string](request, "tier_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			after, err := OptionalParam[string](request, "after")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			perPage, err := OptionalIntParamWithDefault(request, "perPage", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if perPage < 1 || perPage > 100 {
+				return mcp.NewToolResultError("perPage must be between 1 and 100"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			page, err := listSponsors(ctx, client, orgSponsorsQuery, org, tierSlug, after, perPage)
+			if err != nil {
+				if isGraphQLNotFound(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("organization %s not found", org)), nil
+				}
+				return nil, fmt.Errorf("failed to list organization sponsors: %w", err)
+			}
+
+			r, err := json.Marshal(page)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListUserSponsors creates a tool to list the public sponsors of a user.
+func ListUserSponsors(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_user_sponsors",
+			mcp.WithDescription(t("TOOL_LIST_USER_SPONSORS_DESCRIPTION", "List the accounts publicly sponsoring a GitHub user through GitHub Sponsors, oldest sponsorship first. Private sponsorships are left out")),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("GitHub username"),
+			),
+			mcp.WithString("after",
+				mcp.Description("Cursor to fetch the sponsors after, as returned in next_cursor"),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description("Results per page (min 1, max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := requiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			after, err := OptionalParam[string](request, "after")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			perPage, err := OptionalIntParamWithDefault(request, "perPage", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if perPage < 1 || perPage > 100 {
+				return mcp.NewToolResultError("perPage must be between 1 and 100"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			page, err := listSponsors(ctx, client, userSponsorsQuery, username, "", after, perPage)
+			if err != nil {
+				if isGraphQLNotFound(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("user %s not found", username)), nil
+				}
+				return nil, fmt.Errorf("failed to list user sponsors: %w", err)
+			}
+
+			r, err := json.Marshal(page)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// IsUserSponsor creates a tool to check whether the authenticated user sponsors a user or an organization.
+func IsUserSponsor(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("is_user_sponsor",
+			mcp.WithDescription(t("TOOL_IS_USER_SPONSOR_DESCRIPTION", "Check whether the authenticated user sponsors a GitHub user or organization, and on which tier")),
+			mcp.WithString("login",
+				mcp.Required(),
+				mcp.Description("Login of the user or organization"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			login, err := requiredParam[string](request, "login")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var data struct {
+				RepositoryOwner *struct {
+					Typename                      string `json:"__typename"`
+					Login                         string `json:"login"`
+					ViewerIsSponsoring            bool   `json:"viewerIsSponsoring"`
+					SponsorshipForViewerAsSponsor *struct {
+						CreatedAt    string        `json:"createdAt"`
+						PrivacyLevel string        `json:"privacyLevel"`
+						Tier         *sponsorsTier `json:"tier"`
+					} `json:"sponsorshipForViewerAsSponsor"`
+				} `json:"repositoryOwner"`
+			}
+			if err := executeGraphQL(ctx, client, viewerSponsorshipQuery, map[string]interface{}{"login": login}, &data); err != nil {
+				if isGraphQLNotFound(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("user or organization %s not found", login)), nil
+				}
+				return nil, fmt.Errorf("failed to get sponsorship: %w", err)
+			}
+			// Unlike user and organization, repositoryOwner is null rather than an error for an unknown login
+			if data.RepositoryOwner == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("user or organization %s not found", login)), nil
+			}
+
+			owner := data.RepositoryOwner
+			result := map[string]interface{}{
+				"login":      owner.Login,
+				"type":       strings.ToLower(owner.Typename),
+				"is_sponsor": owner.ViewerIsSponsoring,
+			}
+			// The authenticated user's own sponsorship is theirs to see, even when it's private
+			if sponsorship := owner.SponsorshipForViewerAsSponsor; sponsorship != nil {
+				result["sponsoring_since"] = sponsorship.CreatedAt
+				result["privacy"] = strings.ToLower(sponsorship.PrivacyLevel)
+				if sponsorship.Tier != nil {
+					result["tier_name"] = sponsorship.Tier.Name
+					result["tier_monthly_price_in_dollars"] = sponsorship.Tier.MonthlyPriceInDollars
+				}
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetOrgSponsorshipTier creates a tool to get a tier of the sponsors profile of an organization.
+func GetOrgSponsorshipTier(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_sponsorship_tier",
+			mcp.WithDescription(t("TOOL_GET_ORG_SPONSORSHIP_TIER_DESCRIPTION", "Get a published tier of the GitHub Sponsors profile of an organization, with its description and price")),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("tier_slug",
+				mcp.Required(),
+				mcp.Description("Slug of the tier, such as 5-a-month for the \"$5 a month\" tier, or its name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tierSlug, err := requiredParam[string](request, "tier_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var data struct {
+				Organization struct {
+					SponsorsListing *struct {
+						Tiers struct {
+							Nodes []sponsorsTier `json:"nodes"`
+						} `json:"tiers"`
+					} `json:"sponsorsListing"`
+				} `json:"organization"`
+			}
+			if err := executeGraphQL(ctx, client, orgSponsorsTiersQuery, map[string]interface{}{"login": org}, &data); err != nil {
+				if isGraphQLNotFound(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("organization %s not found", org)), nil
+				}
+				return nil, fmt.Errorf("failed to get sponsorship tiers: %w", err)
+			}
+			if data.Organization.SponsorsListing == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("organization %s has no GitHub Sponsors profile", org)), nil
+			}
+
+			tiers := data.Organization.SponsorsListing.Tiers.Nodes
+			slugs := make([]string, 0, len(tiers))
+			for _, tier := range tiers {
+				slug := sponsorsTierSlug(tier.Name)
+				if slug != sponsorsTierSlug(tierSlug) {
+					slugs = append(slugs, slug)
+					continue
+				}
+
+				r, err := json.Marshal(map[string]interface{}{
+					"name":                     tier.Name,
+					"slug":                     slug,
+					"description":              tier.Description,
+					"monthly_price_in_dollars": tier.MonthlyPriceInDollars,
+					"one_time":                 tier.IsOneTime,
+					"custom_amount":            tier.IsCustomAmount,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return mcp.NewToolResultText(string(r)), nil
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("organization %s has no published sponsorship tier %s, its tiers are: %s", org, tierSlug, strings.Join(slugs, ", "))), nil
+		}
+}
@@ -0,0 +1,630 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sponsorshipsResponse answers a sponsors query with the given sponsorships, as a single page unless
+// endCursor is given.
+func sponsorshipsResponse(nodes []map[string]interface{}, endCursor string) map[string]interface{} {
+	return map[string]interface{}{
+		"data": map[string]interface{}{
+			"owner": map[string]interface{}{
+				"sponsorshipsAsMaintainer": map[string]interface{}{
+					"pageInfo": map[string]interface{}{"hasNextPage": endCursor != "", "endCursor": endCursor},
+					"nodes":    nodes,
+				},
+			},
+		},
+	}
+}
+
+func Test_SponsorsTierSlug(t *testing.T) {
+	assert.Equal(t, "5-a-month", sponsorsTierSlug("$5 a month"))
+	assert.Equal(t, "5-a-month", sponsorsTierSlug("5-a-month"))
+	assert.Equal(t, "gold-sponsor-1-000-a-month", sponsorsTierSlug("Gold Sponsor: $1,000 a month"))
+}
+
+func Test_ListOrgSponsors(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgSponsors(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_sponsors", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "tier_slug")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	graphQLEndpoint := mock.EndpointPattern{
+		Pattern: "/graphql",
+		Method:  "POST",
+	}
+	sponsorships := []map[string]interface{}{
+		{
+			"createdAt":    "2024-01-15T10:00:00Z",
+			"privacyLevel": "PUBLIC",
+			"tier":         map[string]interface{}{"name": "$5 a month", "monthlyPriceInDollars": 5},
+			"sponsorEntity": map[string]interface{}{
+				"__typename": "User",
+				"login":      "octocat",
+				"avatarUrl":  "https://avatars.githubusercontent.com/u/1",
+			},
+		},
+		{
+			"createdAt":     "2024-02-01T10:00:00Z",
+			"privacyLevel":  "PRIVATE",
+			"tier":          map[string]interface{}{"name": "$5 a month", "monthlyPriceInDollars": 5},
+			"sponsorEntity": nil,
+		},
+		{
+			"createdAt":    "2024-03-01T10:00:00Z",
+			"privacyLevel": "PUBLIC",
+			"tier":         map[string]interface{}{"name": "$100 a month", "monthlyPriceInDollars": 100},
+			"sponsorEntity": map[string]interface{}{
+				"__typename": "Organization",
+				"login":      "acme",
+				"avatarUrl":  "https://avatars.githubusercontent.com/u/2",
+			},
+		},
+	}
+	octocat := sponsor{
+		Login:                     "octocat",
+		AvatarURL:                 "https://avatars.githubusercontent.com/u/1",
+		Type:                      "user",
+		TierName:                  "$5 a month",
+		TierMonthlyPriceInDollars: github.Ptr(5),
+		SponsoringSince:           "2024-01-15T10:00:00Z",
+	}
+	acme := sponsor{
+		Login:                     "acme",
+		AvatarURL:                 "https://avatars.githubusercontent.com/u/2",
+		Type:                      "organization",
+		TierName:                  "$100 a month",
+		TierMonthlyPriceInDollars: github.Ptr(100),
+		SponsoringSince:           "2024-03-01T10:00:00Z",
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedErrMsg     string
+		expectedSponsors   []sponsor
+		expectedNextCursor interface{}
+	}{
+		{
+			name: "public sponsors only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, sponsorshipsResponse(sponsorships, "Y3Vyc29yOjM=")),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "myorg",
+			},
+			expectError:        false,
+			expectedSponsors:   []sponsor{octocat, acme},
+			expectedNextCursor: "Y3Vyc29yOjM=",
+		},
+		{
+			name: "sponsors on a tier",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, sponsorshipsResponse(sponsorships, "")),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "myorg",
+				"tier_slug": "100-a-month",
+			},
+			expectError:      false,
+			expectedSponsors: []sponsor{acme},
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, map[string]interface{}{
+						"data":   map[string]interface{}{"owner": nil},
+						"errors": []map[string]interface{}{{"type": "NOT_FOUND", "path": []string{"owner"}, "message": "Could not resolve to an Organization with the login of 'missing'."}},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "organization missing not found",
+		},
+		{
+			name:         "invalid perPage",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":     "myorg",
+				"perPage": float64(101),
+			},
+			expectError:    false,
+			expectedErrMsg: "perPage must be between 1 and 100",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgSponsors(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var page sponsorsPage
+			err = json.Unmarshal([]byte(textContent.Text), &page)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSponsors, page.Sponsors)
+			assert.Equal(t, tc.expectedNextCursor, page.NextCursor)
+		})
+	}
+}
+
+func Test_ListUserSponsors(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListUserSponsors(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_user_sponsors", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	graphQLEndpoint := mock.EndpointPattern{
+		Pattern: "/graphql",
+		Method:  "POST",
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedSponsors []sponsor
+	}{
+		{
+			name: "sponsor without a visible tier",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, sponsorshipsResponse([]map[string]interface{}{
+						{
+							"createdAt":    "2024-01-15T10:00:00Z",
+							"privacyLevel": "PUBLIC",
+							"tier":         nil,
+							"sponsorEntity": map[string]interface{}{
+								"__typename": "User",
+								"login":      "hubot",
+								"avatarUrl":  "https://avatars.githubusercontent.com/u/3",
+							},
+						},
+					}, "")),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "octocat",
+			},
+			expectError: false,
+			expectedSponsors: []sponsor{
+				{
+					Login:           "hubot",
+					AvatarURL:       "https://avatars.githubusercontent.com/u/3",
+					Type:            "user",
+					SponsoringSince: "2024-01-15T10:00:00Z",
+				},
+			},
+		},
+		{
+			name: "no sponsors",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, sponsorshipsResponse([]map[string]interface{}{}, "")),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "ghost",
+			},
+			expectError:      false,
+			expectedSponsors: []sponsor{},
+		},
+		{
+			name: "user not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, map[string]interface{}{
+						"data":   map[string]interface{}{"owner": nil},
+						"errors": []map[string]interface{}{{"type": "NOT_FOUND", "path": []string{"owner"}, "message": "Could not resolve to a User with the login of 'nobody'."}},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "nobody",
+			},
+			expectError:    false,
+			expectedErrMsg: "user nobody not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListUserSponsors(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var page sponsorsPage
+			err = json.Unmarshal([]byte(textContent.Text), &page)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSponsors, page.Sponsors)
+			assert.Nil(t, page.NextCursor)
+		})
+	}
+}
+
+func Test_IsUserSponsor(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := IsUserSponsor(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "is_user_sponsor", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "login")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"login"})
+
+	graphQLEndpoint := mock.EndpointPattern{
+		Pattern: "/graphql",
+		Method:  "POST",
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name: "sponsoring privately",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, map[string]interface{}{
+						"data": map[string]interface{}{
+							"repositoryOwner": map[string]interface{}{
+								"__typename":         "Organization",
+								"login":              "acme",
+								"viewerIsSponsoring": true,
+								"sponsorshipForViewerAsSponsor": map[string]interface{}{
+									"createdAt":    "2024-01-15T10:00:00Z",
+									"privacyLevel": "PRIVATE",
+									"tier":         map[string]interface{}{"name": "$25 a month", "monthlyPriceInDollars": 25},
+								},
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"login": "acme",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"login":                         "acme",
+				"type":                          "organization",
+				"is_sponsor":                    true,
+				"sponsoring_since":              "2024-01-15T10:00:00Z",
+				"privacy":                       "private",
+				"tier_name":                     "$25 a month",
+				"tier_monthly_price_in_dollars": float64(25),
+			},
+		},
+		{
+			name: "not sponsoring",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, map[string]interface{}{
+						"data": map[string]interface{}{
+							"repositoryOwner": map[string]interface{}{
+								"__typename":                    "User",
+								"login":                         "octocat",
+								"viewerIsSponsoring":            false,
+								"sponsorshipForViewerAsSponsor": nil,
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"login": "octocat",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"login":      "octocat",
+				"type":       "user",
+				"is_sponsor": false,
+			},
+		},
+		{
+			name: "account not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, map[string]interface{}{
+						"data": map[string]interface{}{"repositoryOwner": nil},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"login": "nobody",
+			},
+			expectError:    false,
+			expectedErrMsg: "user or organization nobody not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := IsUserSponsor(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_GetOrgSponsorshipTier(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgSponsorshipTier(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_org_sponsorship_tier", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "tier_slug")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "tier_slug"})
+
+	graphQLEndpoint := mock.EndpointPattern{
+		Pattern: "/graphql",
+		Method:  "POST",
+	}
+	tiersResponse := map[string]interface{}{
+		"data": map[string]interface{}{
+			"organization": map[string]interface{}{
+				"sponsorsListing": map[string]interface{}{
+					"tiers": map[string]interface{}{
+						"nodes": []map[string]interface{}{
+							{
+								"name":                  "$5 a month",
+								"description":           "Our thanks",
+								"monthlyPriceInDollars": 5,
+								"isOneTime":             false,
+								"isCustomAmount":        false,
+							},
+							{
+								"name":                  "$100 one time",
+								"description":           "Your logo in the README",
+								"monthlyPriceInDollars": 100,
+								"isOneTime":             true,
+								"isCustomAmount":        false,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name: "tier by slug",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, tiersResponse),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "myorg",
+				"tier_slug": "100-one-time",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"name":                     "$100 one time",
+				"slug":                     "100-one-time",
+				"description":              "Your logo in the README",
+				"monthly_price_in_dollars": float64(100),
+				"one_time":                 true,
+				"custom_amount":            false,
+			},
+		},
+		{
+			name: "unknown tier",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, tiersResponse),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "myorg",
+				"tier_slug": "1000-a-month",
+			},
+			expectError:    false,
+			expectedErrMsg: "organization myorg has no published sponsorship tier 1000-a-month, its tiers are: 5-a-month, 100-one-time",
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, map[string]interface{}{
+						"data":   map[string]interface{}{"organization": nil},
+						"errors": []map[string]interface{}{{"type": "NOT_FOUND", "path": []string{"organization"}, "message": "Could not resolve to an Organization with the login of 'missing'."}},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "missing",
+				"tier_slug": "5-a-month",
+			},
+			expectError:    false,
+			expectedErrMsg: "organization missing not found",
+		},
+		{
+			name: "no sponsors profile",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, map[string]interface{}{
+						"data": map[string]interface{}{
+							"organization": map[string]interface{}{"sponsorsListing": nil},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "myorg",
+				"tier_slug": "5-a-month",
+			},
+			expectError:    false,
+			expectedErrMsg: "organization myorg has no GitHub Sponsors profile",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOrgSponsorshipTier(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
@@ -2,7 +2,13 @@ package github
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 
+	"github.com/github/github-mcp-server/pkg/config"
 	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v69/github"
@@ -11,29 +17,133 @@ import (
 
 type GetClientFn func(context.Context) (*github.Client, error)
 
-var DefaultTools = []string{"all"}
+// ClientOption configures the GitHub client returned by a GetClientFn created with NewGetClientFn.
+type ClientOption func(*clientConfig)
+
+type clientConfig struct {
+	client *github.Client
+}
+
+// WithGitHubClient makes the tools use the given GitHub client, such as one pointed at a test server.
+func WithGitHubClient(client *github.Client) ClientOption {
+	return func(c *clientConfig) {
+		c.client = client
+	}
+}
 
-func InitToolsets(passedToolsets []string, readOnly bool, getClient GetClientFn, t translations.TranslationHelperFunc, disabledTools []string) (*toolsets.ToolsetGroup, error) {
-	// Create a new toolset group
-	tsg := toolsets.NewToolsetGroup(readOnly, disabledTools)
+// WithHTTPClient makes the tools use a GitHub client that sends its requests through the given HTTP client.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *clientConfig) {
+		c.client = github.NewClient(client)
+	}
+}
 
+// NewGetClientFn returns a GetClientFn that hands every tool the same GitHub client, configured by
+// the given options, with the last one taking precedence. Without options it uses an unauthenticated
+// client for GitHub.com.
+func NewGetClientFn(opts ...ClientOption) GetClientFn {
+	cfg := &clientConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.client == nil {
+		cfg.client = github.NewClient(nil)
+	}
+	return func(_ context.Context) (*github.Client, error) {
+		return cfg.client, nil
+	}
+}
+
+// NewDescriptorRequestFn returns the function sending the requests of the tools of a toolset added from a
+// descriptor, with the GitHub client getClient returns.
+func NewDescriptorRequestFn(getClient GetClientFn) toolsets.DescriptorRequestFn {
+	return func(ctx context.Context, method, path string, query url.Values, body map[string]interface{}) (json.RawMessage, error) {
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		u := strings.TrimPrefix(path, "/")
+		if len(query) > 0 {
+			u += "?" + query.Encode()
+		}
+		var reqBody interface{}
+		if body != nil {
+			reqBody = body
+		}
+		req, err := client.NewRequest(method, u, reqBody)
+		if err != nil {
+			return nil, err
+		}
+		var response json.RawMessage
+		resp, err := client.Do(ctx, req, &response)
+		if err != nil {
+			return nil, err
+		}
+		_ = resp.Body.Close()
+		// Endpoints responding with no content, such as most DELETE ones, still get a JSON result
+		if len(response) == 0 {
+			response = json.RawMessage(`{}`)
+		}
+		return response, nil
+	}
+}
+
+var DefaultTools = []string{"all"}
+
+func InitToolsets(cfg config.ServerConfig, getClient GetClientFn, t translations.TranslationHelperFunc) (*toolsets.ToolsetGroup, error) {
 	// Define all available features with their default state (disabled)
 	// Create toolsets
 	repos := toolsets.NewToolset("repos", "GitHub Repository related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchRepositories(getClient, t)),
 			toolsets.NewServerTool(GetFileContents(getClient, t)),
+			toolsets.NewServerTool(GetFileAtRefs(getClient, t)),
+			toolsets.NewServerTool(GetLargestFiles(getClient, t)),
 			toolsets.NewServerTool(ListCommits(getClient, t)),
+			toolsets.NewServerTool(ListCommitsSince(getClient, t)),
+			toolsets.NewServerTool(GetLatestCommitForFile(getClient, t)),
 			toolsets.NewServerTool(SearchCode(getClient, t)),
 			toolsets.NewServerTool(GetCommit(getClient, t)),
+			toolsets.NewServerTool(GetCommitPatch(getClient, t)),
+			toolsets.NewServerTool(CommitTouches(getClient, t)),
+			toolsets.NewServerTool(GetCommitVerification(getClient, t)),
 			toolsets.NewServerTool(ListBranches(getClient, t)),
+			toolsets.NewServerTool(GetForkParent(getClient, t)),
+			toolsets.NewServerTool(GetMyRepoPermission(getClient, t)),
+			toolsets.NewServerTool(ListTemplateRepositories(getClient, t)),
+			toolsets.NewServerTool(GetFunding(getClient, t)),
+			toolsets.NewServerTool(GetCommunityDocs(getClient, t)),
+			toolsets.NewServerTool(ListTagProtection(getClient, t)),
+			toolsets.NewServerTool(GetRequiredReviewers(getClient, t)),
+			toolsets.NewServerTool(IsRefGreen(getClient, t)),
+			toolsets.NewServerTool(GetCommitBuildStatus(getClient, t)),
+			toolsets.NewServerTool(GetWebhookHealth(getClient, t)),
+			toolsets.NewServerTool(ListCommitComments(getClient, t)),
+			toolsets.NewServerTool(GetInteractionLimits(getClient, t)),
+			toolsets.NewServerTool(GetCodeFrequency(getClient, t)),
+			toolsets.NewServerTool(GetDependencyManifest(getClient, t)),
+			toolsets.NewServerTool(RenderMarkdown(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryArchive(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateOrUpdateFile(getClient, t)),
 			toolsets.NewServerTool(CreateRepository(getClient, t)),
+			toolsets.NewServerTool(CreateRepositoryFromTemplate(getClient, t)),
+			toolsets.NewServerTool(UpdateRepository(getClient, t)),
+			toolsets.NewServerTool(SetDefaultBranch(getClient, t)),
+			toolsets.NewServerTool(ApplyRepoSettings(getClient, t)),
+			toolsets.NewServerTool(CreateRuleset(getClient, t)),
+			toolsets.NewServerTool(UpdateRuleset(getClient, t)),
+			toolsets.NewServerTool(DeleteRuleset(getClient, t)),
 			toolsets.NewServerTool(ForkRepository(getClient, t)),
 			toolsets.NewServerTool(CreateBranch(getClient, t)),
+			toolsets.NewServerTool(CreateTag(getClient, t)),
 			toolsets.NewServerTool(PushFiles(getClient, t)),
+			toolsets.NewServerTool(CreateCommitStatus(getClient, t)),
+			toolsets.NewServerTool(CreateCommitComment(getClient, t)),
+			toolsets.NewServerTool(CreateTagProtection(getClient, t)),
+			toolsets.NewServerTool(CleanupMergedBranches(getClient, t)),
+			toolsets.NewServerTool(SetInteractionLimits(getClient, t)),
 		)
 	issues := toolsets.NewToolset("issues", "GitHub Issues related tools").
 		AddReadTools(
@@ -41,24 +151,69 @@ func InitToolsets(passedToolsets []string, readOnly bool, getClient GetClientFn,
 			toolsets.NewServerTool(SearchIssues(getClient, t)),
 			toolsets.NewServerTool(ListIssues(getClient, t)),
 			toolsets.NewServerTool(GetIssueComments(getClient, t)),
+			toolsets.NewServerTool(GetIssueCrossReferences(getClient, t)),
+			toolsets.NewServerTool(ListIssueReferences(getClient, t)),
+			toolsets.NewServerTool(SuggestIssueLabels(getClient, t)),
+			toolsets.NewServerTool(GetIssueTemplateConfig(getClient, t)),
+			toolsets.NewServerTool(GetAssigneeWorkload(getClient, t)),
+			toolsets.NewServerTool(SuggestAssignee(getClient, t)),
+			toolsets.NewServerTool(GetOldestOpen(getClient, t)),
+			toolsets.NewServerTool(GetActivityTrend(getClient, t)),
+			toolsets.NewServerTool(ListTopReactedIssues(getClient, t)),
+			toolsets.NewServerTool(ListIssueDependencies(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateIssue(getClient, t)),
 			toolsets.NewServerTool(AddIssueComment(getClient, t)),
 			toolsets.NewServerTool(UpdateIssue(getClient, t)),
+			toolsets.NewServerTool(BulkLabelIssues(getClient, t)),
+			toolsets.NewServerTool(BulkAddAssignee(getClient, t)),
+			toolsets.NewServerTool(BulkRemoveAssignee(getClient, t)),
+			toolsets.NewServerTool(BulkCloseIssues(getClient, t)),
+			toolsets.NewServerTool(BatchCreateIssues(getClient, t)),
+			toolsets.NewServerTool(CopyLabelsBetweenRepos(getClient, t)),
 		)
 	users := toolsets.NewToolset("users", "GitHub User related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchUsers(getClient, t)),
+			toolsets.NewServerTool(ListPinnedItems(getClient, t)),
+			toolsets.NewServerTool(ResolveMention(getClient, t)),
+			toolsets.NewServerTool(ListUserOrgs(getClient, t)),
 		)
 	pullRequests := toolsets.NewToolset("pull_requests", "GitHub Pull Request related tools").
 		AddReadTools(
 			toolsets.NewServerTool(GetPullRequest(getClient, t)),
 			toolsets.NewServerTool(ListPullRequests(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestForBranch(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestFiles(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestPatch(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestStatus(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestComments(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestReviews(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestReviewSummary(getClient, t)),
+			toolsets.NewServerTool(GetPendingReviewers(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestConversation(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestStats(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestComplexity(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestRefs(getClient, t)),
+			toolsets.NewServerTool(MapBranchesToPullRequests(getClient, t)),
+			toolsets.NewServerTool(ListTeamReviewRequests(getClient, t)),
+			toolsets.NewServerTool(ListMyReviewLoad(getClient, t)),
+			toolsets.NewServerTool(ListPullRequestsTouchingPath(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestsLastActivity(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestRequiredOwners(getClient, t)),
+			toolsets.NewServerTool(SuggestReviewersFromCodeowners(getClient, t)),
+			toolsets.NewServerTool(ValidatePullRequestTemplate(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestTiming(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestMergeRequirements(getClient, t)),
+			toolsets.NewServerTool(ListPullRequestsBlockedByChecks(getClient, t)),
+			toolsets.NewServerTool(ListConflictedPullRequests(getClient, t)),
+			toolsets.NewServerTool(GetReviewTurnaroundStats(getClient, t)),
+			toolsets.NewServerTool(ListClosedByPullRequest(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestFilesSinceReview(getClient, t)),
+			toolsets.NewServerTool(ListPullRequestsForCommit(getClient, t)),
+			toolsets.NewServerTool(GetReviewSuggestion(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestNextSteps(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(MergePullRequest(getClient, t)),
@@ -67,28 +222,275 @@ func InitToolsets(passedToolsets []string, readOnly bool, getClient GetClientFn,
 			toolsets.NewServerTool(CreatePullRequest(getClient, t)),
 			toolsets.NewServerTool(UpdatePullRequest(getClient, t)),
 			toolsets.NewServerTool(AddPullRequestReviewComment(getClient, t)),
+			toolsets.NewServerTool(ApplyReviewSuggestion(getClient, t)),
 		)
 	codeSecurity := toolsets.NewToolset("code_security", "Code security related tools, such as GitHub Code Scanning").
 		AddReadTools(
 			toolsets.NewServerTool(GetCodeScanningAlert(getClient, t)),
 			toolsets.NewServerTool(ListCodeScanningAlerts(getClient, t)),
 		)
+	security := toolsets.NewToolset("security", "Repository security settings related tools, such as security and analysis features").
+		AddReadTools(
+			toolsets.NewServerTool(GetSecuritySettings(getClient, t)),
+			toolsets.NewServerTool(GetSecurityPosture(getClient, t)),
+			toolsets.NewServerTool(GetDependabotAlertCounts(getClient, t)),
+			toolsets.NewServerTool(GetPrivateVulnReporting(getClient, t)),
+			toolsets.NewServerTool(GetDependencyReview(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(UpdateSecuritySettings(getClient, t)),
+			toolsets.NewServerTool(SetPrivateVulnReporting(getClient, t)),
+		)
+	secretScanningSettings := toolsets.NewToolset("secret_scanning_settings", "Organization secret scanning configuration related tools, such as push protection").
+		AddReadTools(
+			toolsets.NewServerTool(GetOrgSecretScanningSettings(getClient, t)),
+			toolsets.NewServerTool(ListSecretScanningPushProtectionBypasses(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(UpdateOrgSecretScanningSettings(getClient, t)),
+		)
+	packages := toolsets.NewToolset("packages", "GitHub Packages related tools, such as container images and npm packages").
+		AddReadTools(
+			toolsets.NewServerTool(ListOrgPackages(getClient, t)),
+			toolsets.NewServerTool(GetOrgPackage(getClient, t)),
+			toolsets.NewServerTool(ListOrgPackageVersions(getClient, t)),
+			toolsets.NewServerTool(GetOrgPackageVersion(getClient, t)),
+			toolsets.NewServerTool(ListUserPackages(getClient, t)),
+			toolsets.NewServerTool(GetUserPackageVersion(getClient, t)),
+			toolsets.NewServerTool(ListPackages(getClient, t)),
+			toolsets.NewServerTool(ListPackageVersions(getClient, t)),
+			toolsets.NewServerTool(ListContainerTags(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(DeleteOrgPackage(getClient, t)),
+			toolsets.NewServerTool(DeleteOrgPackageVersion(getClient, t)),
+			toolsets.NewServerTool(RestoreOrgPackage(getClient, t)),
+			toolsets.NewServerTool(RestoreOrgPackageVersion(getClient, t)),
+			toolsets.NewServerTool(DeletePackageVersion(getClient, t)),
+		)
+	dependabot := toolsets.NewToolset("dependabot", "Dependabot and dependency graph related tools, such as Dependabot secrets and dependency diffs").
+		AddReadTools(
+			toolsets.NewServerTool(GetDependabotSecretPublicKey(getClient, t)),
+			toolsets.NewServerTool(GetSBOMDiff(getClient, t)),
+		)
+	orgMembers := toolsets.NewToolset("org_members", "Organization membership related tools, such as pending invitations").
+		AddReadTools(
+			toolsets.NewServerTool(ListOrgInvitations(getClient, t)),
+			toolsets.NewServerTool(ListInvitationTeams(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateOrgInvitation(getClient, t)),
+			toolsets.NewServerTool(CancelOrgInvitation(getClient, t)),
+		)
+	orgTeams := toolsets.NewToolset("org_teams", "Organization team related tools, such as team memberships").
+		AddReadTools(
+			toolsets.NewServerTool(ListTeamsForUser(getClient, t)),
+		)
+	orgs := toolsets.NewToolset("orgs", "Organization related tools, such as the audit log").
+		AddReadTools(
+			toolsets.NewServerTool(ListAuditLog(getClient, t)),
+			toolsets.NewServerTool(ListCustomRepoRoles(getClient, t)),
+			toolsets.NewServerTool(ListTeamRepos(getClient, t)),
+			toolsets.NewServerTool(GetCopilotSeats(getClient, t)),
+		)
+	auditStreaming := toolsets.NewToolset("audit_streaming", "Enterprise audit log streaming related tools, such as the configurations streaming the audit log to a SIEM").
+		AddReadTools(
+			toolsets.NewServerTool(ListAuditLogStreamingConfigurations(getClient, t)),
+			toolsets.NewServerTool(GetAuditLogStreamingConfiguration(getClient, t)),
+			toolsets.NewServerTool(CheckAuditLogStreamingConfiguration(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateAuditLogStreamingConfiguration(getClient, t)),
+			toolsets.NewServerTool(UpdateAuditLogStreamingConfiguration(getClient, t)),
+			toolsets.NewServerTool(DeleteAuditLogStreamingConfiguration(getClient, t)),
+		)
+	copilotSeats := toolsets.NewToolset("copilot_seats", "GitHub Copilot seat management related tools, such as assigning seats to users and teams").
+		AddReadTools(
+			toolsets.NewServerTool(ListOrgCopilotSeats(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(AddCopilotSeatsForUsers(getClient, t)),
+			toolsets.NewServerTool(AddCopilotSeatsForTeams(getClient, t)),
+			toolsets.NewServerTool(CancelCopilotSeatAssignmentForUsers(getClient, t)),
+			toolsets.NewServerTool(CancelCopilotSeatAssignmentForTeams(getClient, t)),
+		)
+	actions := toolsets.NewToolset("actions", "GitHub Actions workflows and CI/CD related tools").
+		AddReadTools(
+			toolsets.NewServerTool(ListWorkflowRunJobs(getClient, t)),
+			toolsets.NewServerTool(GetWorkflowJobSteps(getClient, t)),
+			toolsets.NewServerTool(ListRecentFailures(getClient, t)),
+			toolsets.NewServerTool(ListOrgRedRepos(getClient, t)),
+			toolsets.NewServerTool(GetJobLog(getClient, t)),
+			toolsets.NewServerTool(ListPendingDeployments(getClient, t)),
+			toolsets.NewServerTool(ListEnvironmentSecrets(getClient, t)),
+			toolsets.NewServerTool(GetCurrentDeployments(getClient, t)),
+			toolsets.NewServerTool(GetActionsCacheUsage(getClient, t)),
+			toolsets.NewServerTool(ListActionsCaches(getClient, t)),
+			toolsets.NewServerTool(WaitForWorkflowRun(getClient, t)),
+			toolsets.NewServerTool(GetWorkflowDispatchSchema(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(ReviewPendingDeployment(getClient, t)),
+			toolsets.NewServerTool(RerunFailedJobs(getClient, t)),
+			toolsets.NewServerTool(RerunSpecificJob(getClient, t)),
+			toolsets.NewServerTool(DeleteActionsCache(getClient, t)),
+		)
+	actionsPermissions := toolsets.NewToolset("actions_permissions", "GitHub Actions permissions related tools, such as the actions organizations and repositories can run").
+		AddReadTools(
+			toolsets.NewServerTool(GetActionsPermissionsForOrg(getClient, t)),
+			toolsets.NewServerTool(GetAllowedActionsForOrg(getClient, t)),
+			toolsets.NewServerTool(GetActionsPermissionsForRepo(getClient, t)),
+			toolsets.NewServerTool(GetAllowedActionsForRepo(getClient, t)),
+			toolsets.NewServerTool(GetOrgWorkflowPermissions(getClient, t)),
+			toolsets.NewServerTool(GetRepoWorkflowPermissions(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(SetActionsPermissionsForOrg(getClient, t)),
+			toolsets.NewServerTool(SetAllowedActionsForOrg(getClient, t)),
+			toolsets.NewServerTool(SetActionsPermissionsForRepo(getClient, t)),
+			toolsets.NewServerTool(SetAllowedActionsForRepo(getClient, t)),
+			toolsets.NewServerTool(SetOrgWorkflowPermissions(getClient, t)),
+			toolsets.NewServerTool(SetRepoWorkflowPermissions(getClient, t)),
+		)
+	branchProtection := toolsets.NewToolset("branch_protection", "Branch protection related tools, such as required status checks").
+		AddReadTools(
+			toolsets.NewServerTool(ListRequiredStatusChecks(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(SetRequiredStatusChecks(getClient, t)),
+			toolsets.NewServerTool(AddRequiredStatusCheck(getClient, t)),
+			toolsets.NewServerTool(RemoveRequiredStatusCheck(getClient, t)),
+		)
+	releases := toolsets.NewToolset("releases", "GitHub Release related tools").
+		AddReadTools(
+			toolsets.NewServerTool(ListOrgRecentReleases(getClient, t)),
+			toolsets.NewServerTool(GetReleaseByTag(getClient, t)),
+			toolsets.NewServerTool(GetLatestRelease(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(PromoteRelease(getClient, t)),
+		)
+	classicProjects := toolsets.NewToolset("classic_projects", "GitHub Projects (classic) related tools, for GitHub Enterprise Server versions that still have them").
+		AddReadTools(
+			toolsets.NewServerTool(ListRepoProjects(getClient, t)),
+			toolsets.NewServerTool(GetProject(getClient, t)),
+			toolsets.NewServerTool(ListProjectColumns(getClient, t)),
+			toolsets.NewServerTool(ListProjectCards(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateProject(getClient, t)),
+			toolsets.NewServerTool(UpdateProject(getClient, t)),
+			toolsets.NewServerTool(DeleteProject(getClient, t)),
+			toolsets.NewServerTool(CreateProjectColumn(getClient, t)),
+			toolsets.NewServerTool(UpdateProjectColumn(getClient, t)),
+			toolsets.NewServerTool(DeleteProjectColumn(getClient, t)),
+			toolsets.NewServerTool(MoveProjectColumn(getClient, t)),
+			toolsets.NewServerTool(CreateProjectCard(getClient, t)),
+			toolsets.NewServerTool(UpdateProjectCard(getClient, t)),
+			toolsets.NewServerTool(DeleteProjectCard(getClient, t)),
+			toolsets.NewServerTool(MoveProjectCard(getClient, t)),
+		)
+	projects := toolsets.NewToolset("projects", "GitHub Projects related tools, such as organization projects").
+		AddReadTools(
+			toolsets.NewServerTool(ListOrgProjects(getClient, t)),
+		)
+	notifications := toolsets.NewToolset("notifications", "Repository notification related tools, such as watching repositories").
+		AddReadTools(
+			toolsets.NewServerTool(GetRepoSubscription(getClient, t)),
+			toolsets.NewServerTool(ListWatchedReposWithReasons(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(WatchRepo(getClient, t)),
+			toolsets.NewServerTool(IgnoreRepo(getClient, t)),
+			toolsets.NewServerTool(UnwatchRepo(getClient, t)),
+		)
+	gists := toolsets.NewToolset("gists", "GitHub Gist related tools, such as gist revisions").
+		AddReadTools(
+			toolsets.NewServerTool(ListGistRevisions(getClient, t)),
+			toolsets.NewServerTool(GetGist(getClient, t)),
+		)
+	oidc := toolsets.NewToolset("oidc", "GitHub Actions OIDC related tools, such as the subject claim customization templates cloud providers trust").
+		AddReadTools(
+			toolsets.NewServerTool(GetOrgOIDCSubjectClaimTemplate(getClient, t)),
+			toolsets.NewServerTool(GetRepoOIDCSubjectClaimTemplate(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(SetOrgOIDCSubjectClaimTemplate(getClient, t)),
+			toolsets.NewServerTool(SetRepoOIDCSubjectClaimTemplate(getClient, t)),
+		)
+	sponsors := toolsets.NewToolset("sponsors", "GitHub Sponsors related tools, such as the public sponsors of users and organizations").
+		AddReadTools(
+			toolsets.NewServerTool(ListOrgSponsors(getClient, t)),
+			toolsets.NewServerTool(ListUserSponsors(getClient, t)),
+			toolsets.NewServerTool(IsUserSponsor(getClient, t)),
+			toolsets.NewServerTool(GetOrgSponsorshipTier(getClient, t)),
+		)
 	// Keep experiments alive so the system doesn't error out when it's always enabled
 	experiments := toolsets.NewToolset("experiments", "Experimental features that are not considered stable yet")
 
-	// Add toolsets to the group
-	tsg.AddToolset(repos)
-	tsg.AddToolset(issues)
-	tsg.AddToolset(users)
-	tsg.AddToolset(pullRequests)
-	tsg.AddToolset(codeSecurity)
-	tsg.AddToolset(experiments)
-	// Enable the requested features
+	all := []*toolsets.Toolset{
+		repos,
+		issues,
+		users,
+		pullRequests,
+		codeSecurity,
+		security,
+		secretScanningSettings,
+		packages,
+		dependabot,
+		orgMembers,
+		orgTeams,
+		orgs,
+		auditStreaming,
+		copilotSeats,
+		actions,
+		actionsPermissions,
+		branchProtection,
+		releases,
+		classicProjects,
+		projects,
+		notifications,
+		gists,
+		oidc,
+		sponsors,
+		experiments,
+	}
 
-	if err := tsg.EnableToolsets(passedToolsets); err != nil {
-		return nil, err
+	// The meta toolset describes the group it is part of, so the group is only looked up when its tools are called
+	var tsg *toolsets.ToolsetGroup
+	meta := toolsets.NewToolset("meta", "Tools that describe the configuration of this MCP server and help with working with GitHub, such as parsing GitHub URLs").
+		AddReadTools(
+			toolsets.NewServerTool(GetServerConfig(func() *toolsets.ToolsetGroup { return tsg }, t)),
+			toolsets.NewServerTool(ParseGitHubURL(t)),
+		)
+	all = append(all, meta)
+
+	// The site administration APIs only exist on GitHub Enterprise Server, so the toolset is left out elsewhere
+	if client, err := getClient(context.Background()); err == nil && isEnterpriseServer(client) {
+		ghAdmin := toolsets.NewToolset("gh_admin", "GitHub Enterprise Server site administration tools, such as managing users and organizations").
+			AddReadTools(
+				toolsets.NewServerTool(ListEnterpriseOrgs(getClient, t)),
+				toolsets.NewServerTool(GetEnterpriseStats(getClient, t)),
+				toolsets.NewServerTool(ListPreReceiveHooks(getClient, t)),
+				toolsets.NewServerTool(GetPreReceiveHook(getClient, t)),
+			).
+			AddWriteTools(
+				toolsets.NewServerTool(CreateEnterpriseOrg(getClient, t)),
+				toolsets.NewServerTool(CreateEnterpriseUser(getClient, t)),
+				toolsets.NewServerTool(SuspendUser(getClient, t)),
+				toolsets.NewServerTool(UnsuspendUser(getClient, t)),
+				toolsets.NewServerTool(DeleteEnterpriseUser(getClient, t)),
+				toolsets.NewServerTool(PromoteUserToAdmin(getClient, t)),
+				toolsets.NewServerTool(DemoteAdminToUser(getClient, t)),
+			)
+		all = append(all, ghAdmin)
 	}
 
+	var err error
+	tsg, err = toolsets.NewToolsetGroupFromConfig(cfg, all)
+	if err != nil {
+		return nil, err
+	}
 	return tsg, nil
 }
 
@@ -0,0 +1,233 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/config"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripperFunc adapts a function to an http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestNewGetClientFn(t *testing.T) {
+	t.Run("defaults to a GitHub.com client", func(t *testing.T) {
+		client, err := NewGetClientFn()(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "https://api.github.com/", client.BaseURL.String())
+	})
+
+	t.Run("uses the given GitHub client", func(t *testing.T) {
+		ghClient := github.NewClient(nil)
+		client, err := NewGetClientFn(WithGitHubClient(ghClient))(context.Background())
+		require.NoError(t, err)
+		assert.Same(t, ghClient, client)
+	})
+
+	t.Run("sends requests through the given HTTP client", func(t *testing.T) {
+		var requested []string
+		httpClient := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			requested = append(requested, r.URL.Path)
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(http.StatusOK)
+			_, _ = rec.WriteString(`{"login":"octocat"}`)
+			return rec.Result(), nil
+		})}
+
+		client, err := NewGetClientFn(WithHTTPClient(httpClient))(context.Background())
+		require.NoError(t, err)
+		user, _, err := client.Users.Get(context.Background(), "")
+		require.NoError(t, err)
+		assert.Equal(t, "octocat", user.GetLogin())
+		assert.Equal(t, []string{"/user"}, requested)
+	})
+}
+
+func TestInitToolsetsWithTestServer(t *testing.T) {
+	// Serve the GitHub API from a local server and drive a tool end to end through the toolsets
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/repos/owner/repo/issues/42" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"number":42,"title":"Served by the test server","state":"open"}`))
+	}))
+	defer srv.Close()
+
+	ghClient := github.NewClient(srv.Client())
+	baseURL, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	ghClient.BaseURL = baseURL
+
+	tsg, err := InitToolsets(config.ServerConfig{EnabledToolsets: []string{"issues"}, ReadOnly: true}, NewGetClientFn(WithGitHubClient(ghClient)), translations.NullTranslationHelper)
+	require.NoError(t, err)
+
+	var getIssue server.ServerTool
+	found := false
+	_ = tsg.ForEachActiveTool(func(_, toolName string, tool server.ServerTool) error {
+		if toolName == "get_issue" {
+			getIssue, found = tool, true
+		}
+		return nil
+	})
+	require.True(t, found, "expected get_issue to be an active tool")
+
+	result, err := getIssue.Handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(42),
+	}))
+	require.NoError(t, err)
+
+	var issue github.Issue
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &issue))
+	assert.Equal(t, 42, issue.GetNumber())
+	assert.Equal(t, "Served by the test server", issue.GetTitle())
+}
+
+func TestInitToolsetsSiteAdministration(t *testing.T) {
+	enterpriseClient, err := github.NewClient(nil).WithEnterpriseURLs("https://ghes.example.com", "https://ghes.example.com")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		client         *github.Client
+		expectedExists bool
+	}{
+		{
+			name:           "GitHub.com",
+			client:         github.NewClient(nil),
+			expectedExists: false,
+		},
+		{
+			name:           "GitHub Enterprise Server",
+			client:         enterpriseClient,
+			expectedExists: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsg, err := InitToolsets(config.ServerConfig{EnabledToolsets: []string{"all"}}, NewGetClientFn(WithGitHubClient(tc.client)), translations.NullTranslationHelper)
+			require.NoError(t, err)
+
+			_, exists := tsg.Toolsets["gh_admin"]
+			assert.Equal(t, tc.expectedExists, exists)
+			if exists {
+				assert.True(t, tsg.IsEnabled("gh_admin"))
+			}
+		})
+	}
+}
+
+func TestInitToolsetsServerConfig(t *testing.T) {
+	tsg, err := InitToolsets(config.ServerConfig{
+		EnabledToolsets: []string{"meta", "issues"},
+		DisabledTools:   []string{"create_issue"},
+		ReadOnly:        true,
+	}, NewGetClientFn(), translations.NullTranslationHelper)
+	require.NoError(t, err)
+
+	var getServerConfig server.ServerTool
+	found := false
+	_ = tsg.ForEachActiveTool(func(_, toolName string, tool server.ServerTool) error {
+		if toolName == "get_server_config" {
+			getServerConfig, found = tool, true
+		}
+		return nil
+	})
+	require.True(t, found, "expected get_server_config to be an active tool")
+
+	// The meta toolset describes the group it was created for
+	result, err := getServerConfig.Handler(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	assert.Equal(t, tsg.SummarizeConfig(), getTextResult(t, result).Text)
+
+	_, err = InitToolsets(config.ServerConfig{EnabledToolsets: []string{"all"}, Timeout: "forever"}, NewGetClientFn(), translations.NullTranslationHelper)
+	assert.EqualError(t, err, `invalid configuration: invalid timeout "forever": it must be a duration such as 30s or 2m`)
+}
+
+func TestNewDescriptorRequestFn(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposTopicsByOwnerByRepo,
+			expectQueryParams(t, map[string]string{"per_page": "5"}).andThen(
+				mockResponse(t, http.StatusOK, map[string][]string{"names": {"go", "mcp"}}),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PutReposTopicsByOwnerByRepo,
+			expectRequestBody(t, map[string]interface{}{"names": "go"}).andThen(
+				mockResponse(t, http.StatusOK, map[string][]string{"names": {"go"}}),
+			),
+		),
+	)
+	tsg := toolsets.NewToolsetGroup(false, nil)
+	err := tsg.AddToolsetFromDescriptor(toolsets.ToolsetDescriptor{
+		Name: "topics",
+		Tools: []toolsets.ToolDescriptor{
+			{
+				Name: "get_topics",
+				Path: "/repos/{owner}/{repo}/topics",
+				Params: []toolsets.ToolParamDescriptor{
+					{Name: "owner", Required: true},
+					{Name: "repo", Required: true},
+					{Name: "per_page", Type: "number"},
+				},
+			},
+			{
+				Name:   "replace_topics",
+				Method: "PUT",
+				Path:   "/repos/{owner}/{repo}/topics",
+				Params: []toolsets.ToolParamDescriptor{
+					{Name: "owner", Required: true},
+					{Name: "repo", Required: true},
+					{Name: "names", Required: true},
+				},
+			},
+		},
+		Request: NewDescriptorRequestFn(stubGetClientFn(github.NewClient(mockedClient))),
+	})
+	require.NoError(t, err)
+	require.NoError(t, tsg.EnableToolset("topics"))
+
+	tools := map[string]server.ServerTool{}
+	_ = tsg.ForEachActiveTool(func(_, toolName string, tool server.ServerTool) error {
+		tools[toolName] = tool
+		return nil
+	})
+	require.Contains(t, tools, "get_topics")
+	require.Contains(t, tools, "replace_topics")
+
+	result, err := tools["get_topics"].Handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":    "owner",
+		"repo":     "repo",
+		"per_page": float64(5),
+	}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"names":["go","mcp"]}`, getTextResult(t, result).Text)
+
+	result, err = tools["replace_topics"].Handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"names": "go",
+	}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"names":["go"]}`, getTextResult(t, result).Text)
+}
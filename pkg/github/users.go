@@ -0,0 +1,292 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// pinnedItemsQuery fetches the repositories and gists a user has pinned to their profile.
+const pinnedItemsQuery = `query($login: String!) {
+  user(login: $login) {
+    pinnedItems(first: 6, types: [REPOSITORY, GIST]) {
+      nodes {
+        __typename
+        ... on Repository {
+          nameWithOwner
+          description
+          url
+          stargazerCount
+          primaryLanguage { name }
+        }
+        ... on Gist {
+          name
+          description
+          url
+          stargazerCount
+        }
+      }
+    }
+  }
+}`
+
+// pinnedItem is a repository or gist pinned to a user's profile.
+type pinnedItem struct {
+	Type            string `json:"type"`
+	Name            string `json:"name"`
+	Description     string `json:"description,omitempty"`
+	URL             string `json:"url"`
+	Stars           int    `json:"stars"`
+	PrimaryLanguage string `json:"primary_language,omitempty"`
+}
+
+// ListPinnedItems creates a tool to list the repositories and gists pinned to a user's profile.
+func ListPinnedItems(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_pinned_items",
+			mcp.WithDescription(t("TOOL_LIST_PINNED_ITEMS_DESCRIPTION", "List the repositories and gists a GitHub user has pinned to their profile")),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("GitHub username"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := requiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var data struct {
+				User struct {
+					PinnedItems struct {
+						Nodes []struct {
+							Typename        string `json:"__typename"`
+							NameWithOwner   string `json:"nameWithOwner"`
+							Name            string `json:"name"`
+							Description     string `json:"description"`
+							URL             string `json:"url"`
+							StargazerCount  int    `json:"stargazerCount"`
+							PrimaryLanguage *struct {
+								Name string `json:"name"`
+							} `json:"primaryLanguage"`
+						} `json:"nodes"`
+					} `json:"pinnedItems"`
+				} `json:"user"`
+			}
+			if err := executeGraphQL(ctx, client, pinnedItemsQuery, map[string]interface{}{"login": username}, &data); err != nil {
+				if isGraphQLNotFound(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("user %s not found", username)), nil
+				}
+				return nil, fmt.Errorf("failed to get pinned items: %w", err)
+			}
+
+			items := []pinnedItem{}
+			for _, node := range data.User.PinnedItems.Nodes {
+				item := pinnedItem{
+					Description: node.Description,
+					URL:         node.URL,
+					Stars:       node.StargazerCount,
+				}
+				switch node.Typename {
+				case "Repository":
+					item.Type = "repository"
+					item.Name = node.NameWithOwner
+					if node.PrimaryLanguage != nil {
+						item.PrimaryLanguage = node.PrimaryLanguage.Name
+					}
+				case "Gist":
+					item.Type = "gist"
+					item.Name = node.Name
+				default:
+					continue
+				}
+				items = append(items, item)
+			}
+
+			r, err := json.Marshal(items)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ResolveMention creates a tool to resolve an @mention to the user or team it refers to.
+func ResolveMention(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("resolve_mention",
+			mcp.WithDescription(t("TOOL_RESOLVE_MENTION_DESCRIPTION", "Resolve an @mention such as 'alice' or 'myorg/backend-team' to the GitHub user or team it refers to")),
+			mcp.WithString("mention",
+				mcp.Required(),
+				mcp.Description("The mention without the leading '@', either a username or 'org/team-slug'"),
+			),
+			mcp.WithString("owner",
+				mcp.Description("Owner of the repository the mention appeared in, used with repo to check if a user can be assigned"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Name of the repository the mention appeared in"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			mention, err := requiredParam[string](request, "mention")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			mention = strings.TrimPrefix(mention, "@")
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var result map[string]interface{}
+			if org, teamSlug, isTeam := strings.Cut(mention, "/"); isTeam {
+				team, resp, err := client.Teams.GetTeamBySlug(ctx, org, teamSlug)
+				if err != nil {
+					if isGitHubErrorStatus(err, http.StatusNotFound) {
+						return mcp.NewToolResultError(fmt.Sprintf("team not found: %s", mention)), nil
+					}
+					return nil, fmt.Errorf("failed to get team: %w", err)
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				result = map[string]interface{}{
+					"type":        "team",
+					"org":         org,
+					"slug":        team.GetSlug(),
+					"name":        team.GetName(),
+					"html_url":    team.GetHTMLURL(),
+					"members_url": team.GetMembersURL(),
+				}
+			} else {
+				user, resp, err := client.Users.Get(ctx, mention)
+				if err != nil {
+					if isGitHubErrorStatus(err, http.StatusNotFound) {
+						return mcp.NewToolResultError(fmt.Sprintf("user not found: %s", mention)), nil
+					}
+					return nil, fmt.Errorf("failed to get user: %w", err)
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				result = map[string]interface{}{
+					"type":       "user",
+					"login":      user.GetLogin(),
+					"name":       user.GetName(),
+					"avatar_url": user.GetAvatarURL(),
+					"html_url":   user.GetHTMLURL(),
+				}
+
+				if owner != "" && repo != "" {
+					assignable, resp, err := client.Issues.IsAssignee(ctx, owner, repo, user.GetLogin())
+					if err != nil {
+						return nil, fmt.Errorf("failed to check if user is assignable: %w", err)
+					}
+					defer func() { _ = resp.Body.Close() }()
+					result["assignable"] = assignable
+				}
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListUserOrgs creates a tool to list the organizations a user belongs to.
+func ListUserOrgs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_user_orgs",
+			mcp.WithDescription(t("TOOL_LIST_USER_ORGS_DESCRIPTION", "List the organizations a user belongs to. For the authenticated user ('me') every organization is listed with the user's role in it, for other users only their public memberships are visible")),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("GitHub username, or 'me' for the authenticated user"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := requiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			orgs := []map[string]interface{}{}
+			if username == "me" {
+				// The authenticated user can see all of their memberships, private ones included
+				opts := &github.ListOrgMembershipsOptions{State: "active", ListOptions: github.ListOptions{PerPage: 100}}
+				for {
+					memberships, resp, err := client.Organizations.ListOrgMemberships(ctx, opts)
+					if err != nil {
+						return nil, fmt.Errorf("failed to list organization memberships: %w", err)
+					}
+					_ = resp.Body.Close()
+					for _, m := range memberships {
+						orgs = append(orgs, map[string]interface{}{
+							"login": m.GetOrganization().GetLogin(),
+							"role":  m.GetRole(),
+						})
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+			} else {
+				opts := &github.ListOptions{PerPage: 100}
+				for {
+					list, resp, err := client.Organizations.List(ctx, username, opts)
+					if err != nil {
+						if isGitHubErrorStatus(err, http.StatusNotFound) {
+							return mcp.NewToolResultError(fmt.Sprintf("user not found: %s", username)), nil
+						}
+						return nil, fmt.Errorf("failed to list organizations: %w", err)
+					}
+					_ = resp.Body.Close()
+					for _, org := range list {
+						orgs = append(orgs, map[string]interface{}{
+							"login": org.GetLogin(),
+						})
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+			}
+
+			r, err := json.Marshal(map[string]interface{}{
+				"username":    username,
+				"orgs":        orgs,
+				"public_only": username != "me",
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,490 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListPinnedItems(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPinnedItems(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_pinned_items", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	graphQLEndpoint := mock.EndpointPattern{
+		Pattern: "/graphql",
+		Method:  "POST",
+	}
+	pinnedItemsResponse := func(nodes []map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"data": map[string]interface{}{
+				"user": map[string]interface{}{
+					"pinnedItems": map[string]interface{}{
+						"nodes": nodes,
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedItems  []pinnedItem
+	}{
+		{
+			name: "repositories and gists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, pinnedItemsResponse([]map[string]interface{}{
+						{
+							"__typename":      "Repository",
+							"nameWithOwner":   "octocat/Hello-World",
+							"description":     "My first repository",
+							"url":             "https://github.com/octocat/Hello-World",
+							"stargazerCount":  42,
+							"primaryLanguage": map[string]interface{}{"name": "Go"},
+						},
+						{
+							"__typename":     "Gist",
+							"name":           "aa5a315d61ae9438b18d",
+							"description":    "Useful snippets",
+							"url":            "https://gist.github.com/octocat/aa5a315d61ae9438b18d",
+							"stargazerCount": 3,
+						},
+					})),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "octocat",
+			},
+			expectError: false,
+			expectedItems: []pinnedItem{
+				{
+					Type:            "repository",
+					Name:            "octocat/Hello-World",
+					Description:     "My first repository",
+					URL:             "https://github.com/octocat/Hello-World",
+					Stars:           42,
+					PrimaryLanguage: "Go",
+				},
+				{
+					Type:        "gist",
+					Name:        "aa5a315d61ae9438b18d",
+					Description: "Useful snippets",
+					URL:         "https://gist.github.com/octocat/aa5a315d61ae9438b18d",
+					Stars:       3,
+				},
+			},
+		},
+		{
+			name: "no pinned items",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, pinnedItemsResponse([]map[string]interface{}{})),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "ghost",
+			},
+			expectError:   false,
+			expectedItems: []pinnedItem{},
+		},
+		{
+			name: "user not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, map[string]interface{}{
+						"data":   map[string]interface{}{"user": nil},
+						"errors": []map[string]interface{}{{"type": "NOT_FOUND", "path": []string{"user"}, "message": "Could not resolve to a User with the login of 'nobody'."}},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "nobody",
+			},
+			expectError:    false,
+			expectedErrMsg: "user nobody not found",
+		},
+		{
+			name: "query fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					graphQLEndpoint,
+					mockResponse(t, http.StatusOK, map[string]interface{}{
+						"data":   nil,
+						"errors": []map[string]interface{}{{"type": "RATE_LIMITED", "message": "API rate limit exceeded"}},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "octocat",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get pinned items",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPinnedItems(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returnedItems []pinnedItem
+			err = json.Unmarshal([]byte(textContent.Text), &returnedItems)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedItems, returnedItems)
+		})
+	}
+}
+
+func Test_ResolveMention(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ResolveMention(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "resolve_mention", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "mention")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"mention"})
+
+	mockUser := &github.User{
+		Login:     github.Ptr("alice"),
+		Name:      github.Ptr("Alice Smith"),
+		AvatarURL: github.Ptr("https://avatars.githubusercontent.com/u/1"),
+		HTMLURL:   github.Ptr("https://github.com/alice"),
+	}
+	mockTeam := &github.Team{
+		Slug:       github.Ptr("backend-team"),
+		Name:       github.Ptr("Backend Team"),
+		HTMLURL:    github.Ptr("https://github.com/orgs/myorg/teams/backend-team"),
+		MembersURL: github.Ptr("https://api.github.com/organizations/1/team/2/members{/member}"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult map[string]interface{}
+	}{
+		{
+			name: "user mention",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					mockUser,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"mention": "@alice",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"type":       "user",
+				"login":      "alice",
+				"name":       "Alice Smith",
+				"avatar_url": "https://avatars.githubusercontent.com/u/1",
+				"html_url":   "https://github.com/alice",
+			},
+		},
+		{
+			name: "user mention in a repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersByUsername,
+					mockUser,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposAssigneesByOwnerByRepoByAssignee,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"mention": "alice",
+				"owner":   "owner",
+				"repo":    "repo",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"type":       "user",
+				"login":      "alice",
+				"name":       "Alice Smith",
+				"avatar_url": "https://avatars.githubusercontent.com/u/1",
+				"html_url":   "https://github.com/alice",
+				"assignable": true,
+			},
+		},
+		{
+			name: "team mention",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsTeamsByOrgByTeamSlug,
+					mockTeam,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"mention": "myorg/backend-team",
+			},
+			expectError: false,
+			expectedResult: map[string]interface{}{
+				"type":        "team",
+				"org":         "myorg",
+				"slug":        "backend-team",
+				"name":        "Backend Team",
+				"html_url":    "https://github.com/orgs/myorg/teams/backend-team",
+				"members_url": "https://api.github.com/organizations/1/team/2/members{/member}",
+			},
+		},
+		{
+			name: "user not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersByUsername,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"mention": "nobody",
+			},
+			expectError:    false,
+			expectedErrMsg: "user not found: nobody",
+		},
+		{
+			name: "team not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsByOrgByTeamSlug,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"mention": "myorg/ghost-team",
+			},
+			expectError:    false,
+			expectedErrMsg: "team not found: myorg/ghost-team",
+		},
+		{
+			name: "lookup fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersByUsername,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Server Error"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"mention": "alice",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get user",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ResolveMention(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_ListUserOrgs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListUserOrgs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_user_orgs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	type userOrg struct {
+		Login string `json:"login"`
+		Role  string `json:"role,omitempty"`
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedErrMsg     string
+		expectedOrgs       []userOrg
+		expectedPublicOnly bool
+	}{
+		{
+			name: "authenticated user with roles",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserMembershipsOrgs,
+					expectQueryParams(t, map[string]string{
+						"state":    "active",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Membership{
+							{Role: github.Ptr("admin"), Organization: &github.Organization{Login: github.Ptr("acme")}},
+							{Role: github.Ptr("member"), Organization: &github.Organization{Login: github.Ptr("secret-org")}},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "me",
+			},
+			expectError: false,
+			expectedOrgs: []userOrg{
+				{Login: "acme", Role: "admin"},
+				{Login: "secret-org", Role: "member"},
+			},
+			expectedPublicOnly: false,
+		},
+		{
+			name: "another user's public organizations",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersOrgsByUsername,
+					[]*github.Organization{
+						{Login: github.Ptr("acme")},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "octocat",
+			},
+			expectError: false,
+			expectedOrgs: []userOrg{
+				{Login: "acme"},
+			},
+			expectedPublicOnly: true,
+		},
+		{
+			name: "user not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersOrgsByUsername,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "ghost-user",
+			},
+			expectError:    false,
+			expectedErrMsg: "user not found: ghost-user",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListUserOrgs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Orgs       []userOrg `json:"orgs"`
+				PublicOnly bool      `json:"public_only"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOrgs, response.Orgs)
+			assert.Equal(t, tc.expectedPublicOnly, response.PublicOnly)
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxWorkloadLogins bounds the number of people whose workload a single tool call compares. Each costs two
+	// searches, and the search API only allows 30 requests a minute.
+	maxWorkloadLogins = 15
+	// maxWorkloadCacheEntries bounds the number of workloads kept in a workloadCache.
+	maxWorkloadCacheEntries = 1000
+)
+
+// assigneeWorkload is how many open issues and pull requests of a repository are assigned to someone.
+type assigneeWorkload struct {
+	Login          string `json:"login"`
+	OpenIssues     int    `json:"open_issues"`
+	OpenPRs        int    `json:"open_prs"`
+	TotalOpenItems int    `json:"total_open_items"`
+}
+
+// workloadCache keeps the workloads fetched recently, by repository and login, to spare the search API
+// when triage tools ask about the same people again.
+type workloadCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]workloadCacheEntry
+}
+
+type workloadCacheEntry struct {
+	workload  assigneeWorkload
+	fetchedAt time.Time
+}
+
+func newWorkloadCache(ttl time.Duration) *workloadCache {
+	return &workloadCache{ttl: ttl, now: time.Now, entries: map[string]workloadCacheEntry{}}
+}
+
+// assigneeWorkloads is shared by get_assignee_workload and suggest_assignee. Tests replace it.
+var assigneeWorkloads = newWorkloadCache(5 * time.Minute)
+
+func workloadCacheKey(owner, repo, login string) string {
+	return strings.ToLower(owner + "/" + repo + "/" + login)
+}
+
+func (c *workloadCache) get(owner, repo, login string) (assigneeWorkload, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[workloadCacheKey(owner, repo, login)]
+	if !ok || c.now().Sub(entry.fetchedAt) >= c.ttl {
+		return assigneeWorkload{}, false
+	}
+	return entry.workload, true
+}
+
+// put caches a workload. When the cache is full, expired workloads are dropped first, then the oldest one.
+func (c *workloadCache) put(owner, repo string, workload assigneeWorkload) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if len(c.entries) >= maxWorkloadCacheEntries {
+		maps.DeleteFunc(c.entries, func(_ string, entry workloadCacheEntry) bool {
+			return now.Sub(entry.fetchedAt) >= c.ttl
+		})
+	}
+	if len(c.entries) >= maxWorkloadCacheEntries {
+		oldest := ""
+		for key, entry := range c.entries {
+			if oldest == "" || entry.fetchedAt.Before(c.entries[oldest].fetchedAt) {
+				oldest = key
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.entries[workloadCacheKey(owner, repo, workload.Login)] = workloadCacheEntry{workload: workload, fetchedAt: now}
+}
+
+// countOpenAssigned counts the open items of a kind, issue or pr, of a repository assigned to login.
+func countOpenAssigned(ctx context.Context, client *github.Client, owner, repo, login, kind string) (int, error) {
+	query := fmt.Sprintf("repo:%s/%s assignee:%s is:open is:%s", owner, repo, login, kind)
+	result, resp, err := client.Search.Issues(ctx, query, &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 1}})
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	return result.GetTotal(), nil
+}
+
+// getAssigneeWorkloads returns the workload of every login, sorted from the least to the most loaded.
+// Workloads fetched in the last five minutes are reused.
+func getAssigneeWorkloads(ctx context.Context, client *github.Client, owner, repo string, logins []string) ([]assigneeWorkload, error) {
+	workloads := make([]assigneeWorkload, len(logins))
+	errs := forEachConcurrently(ctx, logins, func(ctx context.Context, i int, login string) error {
+		if workload, ok := assigneeWorkloads.get(owner, repo, login); ok {
+			workloads[i] = workload
+			return nil
+		}
+		issues, err := countOpenAssigned(ctx, client, owner, repo, login, "issue")
+		if err != nil {
+			return fmt.Errorf("failed to count the open issues assigned to %s: %w", login, err)
+		}
+		prs, err := countOpenAssigned(ctx, client, owner, repo, login, "pr")
+		if err != nil {
+			return fmt.Errorf("failed to count the open pull requests assigned to %s: %w", login, err)
+		}
+		workloads[i] = assigneeWorkload{
+			Login:          login,
+			OpenIssues:     issues,
+			OpenPRs:        prs,
+			TotalOpenItems: issues + prs,
+		}
+		assigneeWorkloads.put(owner, repo, workloads[i])
+		return nil
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(workloads, func(a, b assigneeWorkload) int {
+		if a.TotalOpenItems != b.TotalOpenItems {
+			return a.TotalOpenItems - b.TotalOpenItems
+		}
+		return strings.Compare(strings.ToLower(a.Login), strings.ToLower(b.Login))
+	})
+	return workloads, nil
+}
+
+// GetAssigneeWorkload creates a tool to count the open issues and pull requests assigned to people in a repository.
+func GetAssigneeWorkload(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_assignee_workload",
+			mcp.WithDescription(t("TOOL_GET_ASSIGNEE_WORKLOAD_DESCRIPTION", "Count the open issues and pull requests of a repository assigned to each of the given people, sorted from the least to the most loaded. Counts are reused for five minutes")),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("assignees",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Logins of the people to compare, at most %d", maxWorkloadLogins)),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assignees, err := OptionalStringArrayParam(request, "assignees")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(assignees) == 0 {
+				return mcp.NewToolResultError("assignees must contain at least one login"), nil
+			}
+			if len(assignees) > maxWorkloadLogins {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d assignees can be compared at once, got %d", maxWorkloadLogins, len(assignees))), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			workloads, err := getAssigneeWorkloads(ctx, client, owner, repo, assignees)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := json.Marshal(workloads)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SuggestAssignee creates a tool to suggest the least loaded person to assign an issue of a repository to.
+func SuggestAssignee(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("suggest_assignee",
+			mcp.WithDescription(t("TOOL_SUGGEST_ASSIGNEE_DESCRIPTION", fmt.Sprintf("Suggest who to assign an issue of a repository to: the person who can be assigned with the fewest open issues and pull requests assigned in the repository. When more than %d people can be assigned, a random sample of %d of them is compared", maxWorkloadLogins, maxWorkloadLogins))),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("exclude_logins",
+				mcp.Description("Logins of the people not to suggest, such as those away"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			excludeLogins, err := OptionalStringArrayParam(request, "exclude_logins")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var candidates []string
+			opts := &github.ListOptions{PerPage: 100}
+			for {
+				users, resp, err := client.Issues.ListAssignees(ctx, owner, repo, opts)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to list assignees: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, user := range users {
+					excluded := slices.ContainsFunc(excludeLogins, func(login string) bool {
+						return strings.EqualFold(login, user.GetLogin())
+					})
+					if !excluded {
+						candidates = append(candidates, user.GetLogin())
+					}
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+			if len(candidates) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("no one left to assign issues of %s/%s to", owner, repo)), nil
+			}
+			if len(candidates) > maxWorkloadLogins {
+				// Comparing everyone would run into the search rate limit, so a fair sample is compared instead
+				rand.Shuffle(len(candidates), func(i, j int) {
+					candidates[i], candidates[j] = candidates[j], candidates[i]
+				})
+				candidates = candidates[:maxWorkloadLogins]
+			}
+
+			workloads, err := getAssigneeWorkloads(ctx, client, owner, repo, candidates)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := json.Marshal(workloads[0])
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
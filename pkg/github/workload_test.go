@@ -0,0 +1,384 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockWorkloadSearch answers issue searches with the open items assigned to each login, counting the searches made.
+func mockWorkloadSearch(t *testing.T, counts map[string]int, searches *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(searches, 1)
+		total, ok := counts[r.URL.Query().Get("q")]
+		require.True(t, ok, "unexpected search %q", r.URL.Query().Get("q"))
+		mockResponse(t, http.StatusOK, &github.IssuesSearchResult{Total: github.Ptr(total)})(w, r)
+	}
+}
+
+func Test_GetAssigneeWorkload(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetAssigneeWorkload(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_assignee_workload", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "assignees")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "assignees"})
+
+	counts := map[string]int{
+		"repo:owner/repo assignee:octocat is:open is:issue":  4,
+		"repo:owner/repo assignee:octocat is:open is:pr":     3,
+		"repo:owner/repo assignee:hubot is:open is:issue":    1,
+		"repo:owner/repo assignee:hubot is:open is:pr":       0,
+		"repo:owner/repo assignee:monalisa is:open is:issue": 0,
+		"repo:owner/repo assignee:monalisa is:open is:pr":    1,
+	}
+
+	tests := []struct {
+		name              string
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedWorkloads []assigneeWorkload
+	}{
+		{
+			name: "least loaded first",
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"assignees": []interface{}{"octocat", "hubot", "monalisa"},
+			},
+			expectError: false,
+			expectedWorkloads: []assigneeWorkload{
+				{Login: "hubot", OpenIssues: 1, OpenPRs: 0, TotalOpenItems: 1},
+				{Login: "monalisa", OpenIssues: 0, OpenPRs: 1, TotalOpenItems: 1},
+				{Login: "octocat", OpenIssues: 4, OpenPRs: 3, TotalOpenItems: 7},
+			},
+		},
+		{
+			name: "no assignees",
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"assignees": []interface{}{},
+			},
+			expectError:    false,
+			expectedErrMsg: "assignees must contain at least one login",
+		},
+		{
+			name: "too many assignees",
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"assignees": []interface{}{
+					"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l", "m", "n", "o", "p",
+				},
+			},
+			expectError:    false,
+			expectedErrMsg: "at most 15 assignees can be compared at once, got 16",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assigneeWorkloads = newWorkloadCache(5 * time.Minute)
+
+			// Setup client with mock
+			var searches int32
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					mockWorkloadSearch(t, counts, &searches),
+				),
+			))
+			_, handler := GetAssigneeWorkload(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var workloads []assigneeWorkload
+			err = json.Unmarshal([]byte(textContent.Text), &workloads)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedWorkloads, workloads)
+			assert.Equal(t, int32(2*len(tc.expectedWorkloads)), atomic.LoadInt32(&searches))
+		})
+	}
+
+	t.Run("workloads are reused for five minutes", func(t *testing.T) {
+		now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+		assigneeWorkloads = newWorkloadCache(5 * time.Minute)
+		assigneeWorkloads.now = func() time.Time { return now }
+
+		var searches int32
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetSearchIssues,
+				mockWorkloadSearch(t, counts, &searches),
+			),
+		))
+		_, handler := GetAssigneeWorkload(stubGetClientFn(client), translations.NullTranslationHelper)
+		request := createMCPRequest(map[string]interface{}{
+			"owner":     "owner",
+			"repo":      "repo",
+			"assignees": []interface{}{"octocat"},
+		})
+
+		_, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		now = now.Add(4 * time.Minute)
+		_, err = handler(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&searches))
+
+		now = now.Add(time.Minute)
+		_, err = handler(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), atomic.LoadInt32(&searches))
+	})
+}
+
+func Test_SuggestAssignee(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SuggestAssignee(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "suggest_assignee", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "exclude_logins")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockAssignees := []*github.User{
+		{Login: github.Ptr("octocat")},
+		{Login: github.Ptr("hubot")},
+		{Login: github.Ptr("monalisa")},
+	}
+	counts := map[string]int{
+		"repo:owner/repo assignee:octocat is:open is:issue":  4,
+		"repo:owner/repo assignee:octocat is:open is:pr":     3,
+		"repo:owner/repo assignee:hubot is:open is:issue":    0,
+		"repo:owner/repo assignee:hubot is:open is:pr":       0,
+		"repo:owner/repo assignee:monalisa is:open is:issue": 2,
+		"repo:owner/repo assignee:monalisa is:open is:pr":    1,
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedWorkload assigneeWorkload
+	}{
+		{
+			name: "least loaded assignee",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposAssigneesByOwnerByRepo,
+					mockAssignees,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					mockWorkloadSearch(t, counts, new(int32)),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:      false,
+			expectedWorkload: assigneeWorkload{Login: "hubot", TotalOpenItems: 0},
+		},
+		{
+			name: "excluded logins aren't suggested",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposAssigneesByOwnerByRepo,
+					mockAssignees,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					mockWorkloadSearch(t, counts, new(int32)),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"exclude_logins": []interface{}{"HUBOT"},
+			},
+			expectError:      false,
+			expectedWorkload: assigneeWorkload{Login: "monalisa", OpenIssues: 2, OpenPRs: 1, TotalOpenItems: 3},
+		},
+		{
+			name: "everyone excluded",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposAssigneesByOwnerByRepo,
+					mockAssignees,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"exclude_logins": []interface{}{"octocat", "hubot", "monalisa"},
+			},
+			expectError:    false,
+			expectedErrMsg: "no one left to assign issues of owner/repo to",
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposAssigneesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    false,
+			expectedErrMsg: "repository owner/missing not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assigneeWorkloads = newWorkloadCache(5 * time.Minute)
+
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SuggestAssignee(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var workload assigneeWorkload
+			err = json.Unmarshal([]byte(textContent.Text), &workload)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedWorkload, workload)
+		})
+	}
+
+	t.Run("a sample of many assignable people is compared", func(t *testing.T) {
+		assigneeWorkloads = newWorkloadCache(5 * time.Minute)
+
+		manyAssignees := make([]*github.User, 2*maxWorkloadLogins)
+		manyCounts := map[string]int{}
+		for i := range manyAssignees {
+			login := fmt.Sprintf("user%02d", i)
+			manyAssignees[i] = &github.User{Login: github.Ptr(login)}
+			manyCounts[fmt.Sprintf("repo:owner/repo assignee:%s is:open is:issue", login)] = i + 1
+			manyCounts[fmt.Sprintf("repo:owner/repo assignee:%s is:open is:pr", login)] = 0
+		}
+
+		var searches int32
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposAssigneesByOwnerByRepo,
+				manyAssignees,
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetSearchIssues,
+				mockWorkloadSearch(t, manyCounts, &searches),
+			),
+		))
+		_, handler := SuggestAssignee(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var workload assigneeWorkload
+		err = json.Unmarshal([]byte(textContent.Text), &workload)
+		require.NoError(t, err)
+		assert.Contains(t, manyCounts, fmt.Sprintf("repo:owner/repo assignee:%s is:open is:issue", workload.Login))
+		assert.Equal(t, int32(2*maxWorkloadLogins), atomic.LoadInt32(&searches))
+	})
+}
+
+func Test_workloadCache(t *testing.T) {
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	cache := newWorkloadCache(5 * time.Minute)
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < maxWorkloadCacheEntries; i++ {
+		cache.put("owner", "repo", assigneeWorkload{Login: fmt.Sprintf("user%d", i)})
+		now = now.Add(time.Millisecond)
+	}
+	require.Len(t, cache.entries, maxWorkloadCacheEntries)
+
+	// A full cache drops its oldest workload
+	cache.put("owner", "repo", assigneeWorkload{Login: "newcomer"})
+	assert.Len(t, cache.entries, maxWorkloadCacheEntries)
+	_, ok := cache.get("owner", "repo", "user0")
+	assert.False(t, ok)
+	_, ok = cache.get("owner", "repo", "user1")
+	assert.True(t, ok)
+
+	// Once they expire, a full cache drops all of them
+	now = now.Add(5 * time.Minute)
+	cache.put("owner", "repo", assigneeWorkload{Login: "latecomer"})
+	assert.Len(t, cache.entries, 1)
+	_, ok = cache.get("owner", "repo", "latecomer")
+	assert.True(t, ok)
+}
@@ -0,0 +1,150 @@
+// Package manifest parses dependency manifests, such as go.mod and package.json files,
+// into a list of dependencies common to every ecosystem.
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Dependency is a dependency declared in a manifest.
+type Dependency struct {
+	// Name is the name of the package, such as a Go module path or an npm package name.
+	Name string `json:"name"`
+	// Version is the version or version range the manifest asks for.
+	Version string `json:"version"`
+	// Scope is what the dependency is needed for, which depends on the ecosystem: direct or indirect
+	// for Go modules, and runtime, development, peer or optional for npm packages.
+	Scope string `json:"scope"`
+}
+
+// Manifest is a kind of dependency manifest.
+type Manifest struct {
+	// Ecosystem is the package ecosystem the manifest belongs to.
+	Ecosystem string
+	// Path is where the manifest is found, relative to the root of the project.
+	Path string
+	// Parse parses the content of the manifest.
+	Parse func(content string) ([]Dependency, error)
+}
+
+// Manifests are the supported manifests, in the order they are looked for.
+var Manifests = []Manifest{
+	{Ecosystem: "go", Path: "go.mod", Parse: ParseGoMod},
+	{Ecosystem: "npm", Path: "package.json", Parse: ParsePackageJSON},
+}
+
+// Ecosystems returns the ecosystems of the supported manifests.
+func Ecosystems() []string {
+	ecosystems := make([]string, 0, len(Manifests))
+	for _, m := range Manifests {
+		ecosystems = append(ecosystems, m.Ecosystem)
+	}
+	return ecosystems
+}
+
+// ParseGoMod parses the requirements of a go.mod file. Requirements marked // indirect have the
+// indirect scope, the others the direct scope. Replacements and exclusions are not applied.
+func ParseGoMod(content string) ([]Dependency, error) {
+	deps := []Dependency{}
+	block := ""
+	for i, line := range strings.Split(content, "\n") {
+		line, comment, _ := strings.Cut(line, "//")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var require []string
+		switch {
+		case block != "":
+			if fields[0] == ")" {
+				block = ""
+				continue
+			}
+			if block == "require" {
+				require = fields
+			}
+		case len(fields) == 2 && fields[1] == "(":
+			block = fields[0]
+			continue
+		case fields[0] == "require":
+			require = fields[1:]
+		}
+		if require == nil {
+			continue
+		}
+
+		if len(require) != 2 {
+			return nil, fmt.Errorf("go.mod:%d: malformed requirement %q", i+1, strings.TrimSpace(line))
+		}
+		name, err := unquoteModulePath(require[0])
+		if err != nil {
+			return nil, fmt.Errorf("go.mod:%d: %w", i+1, err)
+		}
+		scope := "direct"
+		if strings.TrimSpace(comment) == "indirect" || strings.HasPrefix(strings.TrimSpace(comment), "indirect;") {
+			scope = "indirect"
+		}
+		deps = append(deps, Dependency{Name: name, Version: require[1], Scope: scope})
+	}
+	if block != "" {
+		return nil, fmt.Errorf("go.mod: unterminated %s block", block)
+	}
+	return deps, nil
+}
+
+// unquoteModulePath returns a module path, which go.mod files may quote.
+func unquoteModulePath(path string) (string, error) {
+	if !strings.HasPrefix(path, `"`) && !strings.HasPrefix(path, "`") {
+		return path, nil
+	}
+	unquoted, err := strconv.Unquote(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid quoted module path %s", path)
+	}
+	return unquoted, nil
+}
+
+// packageJSONScopes maps the dependency fields of a package.json file to the scope of their dependencies.
+var packageJSONScopes = []struct {
+	field string
+	scope string
+}{
+	{"dependencies", "runtime"},
+	{"devDependencies", "development"},
+	{"peerDependencies", "peer"},
+	{"optionalDependencies", "optional"},
+}
+
+// ParsePackageJSON parses the dependencies of a package.json file, sorted by scope and then by name.
+func ParsePackageJSON(content string) ([]Dependency, error) {
+	var pkg map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(content), &pkg); err != nil {
+		return nil, fmt.Errorf("package.json: %w", err)
+	}
+
+	deps := []Dependency{}
+	for _, s := range packageJSONScopes {
+		raw, ok := pkg[s.field]
+		if !ok {
+			continue
+		}
+		var versions map[string]string
+		if err := json.Unmarshal(raw, &versions); err != nil {
+			return nil, fmt.Errorf("package.json: %s must map package names to versions", s.field)
+		}
+		names := make([]string, 0, len(versions))
+		for name := range versions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			deps = append(deps, Dependency{Name: name, Version: versions[name], Scope: s.scope})
+		}
+	}
+	return deps, nil
+}
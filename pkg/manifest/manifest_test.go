@@ -0,0 +1,80 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoMod(t *testing.T) {
+	content := `module github.com/github/github-mcp-server
+
+go 1.23.7
+
+require github.com/google/go-github/v69 v69.2.0
+
+require (
+	github.com/mark3labs/mcp-go v0.20.1
+	"github.com/spf13/cobra" v1.9.1 // pinned for the docs generator
+	github.com/davecgh/go-spew v1.1.1 // indirect
+)
+
+replace github.com/spf13/cobra => ../cobra
+
+exclude (
+	github.com/sirupsen/logrus v1.9.0
+)
+`
+
+	deps, err := ParseGoMod(content)
+	require.NoError(t, err)
+	assert.Equal(t, []Dependency{
+		{Name: "github.com/google/go-github/v69", Version: "v69.2.0", Scope: "direct"},
+		{Name: "github.com/mark3labs/mcp-go", Version: "v0.20.1", Scope: "direct"},
+		{Name: "github.com/spf13/cobra", Version: "v1.9.1", Scope: "direct"},
+		{Name: "github.com/davecgh/go-spew", Version: "v1.1.1", Scope: "indirect"},
+	}, deps)
+}
+
+func TestParseGoModMalformed(t *testing.T) {
+	_, err := ParseGoMod("module example.com/m\n\nrequire (\n\texample.com/dep\n)\n")
+	assert.EqualError(t, err, `go.mod:4: malformed requirement "example.com/dep"`)
+
+	_, err = ParseGoMod("module example.com/m\n\nrequire (\n\texample.com/dep v1.0.0\n")
+	assert.EqualError(t, err, "go.mod: unterminated require block")
+}
+
+func TestParsePackageJSON(t *testing.T) {
+	content := `{
+  "name": "web",
+  "version": "1.0.0",
+  "dependencies": {
+    "react": "^18.2.0",
+    "@octokit/rest": "20.0.2"
+  },
+  "devDependencies": {
+    "typescript": "~5.4.0"
+  },
+  "peerDependencies": {
+    "react-dom": ">=18"
+  }
+}`
+
+	deps, err := ParsePackageJSON(content)
+	require.NoError(t, err)
+	assert.Equal(t, []Dependency{
+		{Name: "@octokit/rest", Version: "20.0.2", Scope: "runtime"},
+		{Name: "react", Version: "^18.2.0", Scope: "runtime"},
+		{Name: "typescript", Version: "~5.4.0", Scope: "development"},
+		{Name: "react-dom", Version: ">=18", Scope: "peer"},
+	}, deps)
+}
+
+func TestParsePackageJSONMalformed(t *testing.T) {
+	_, err := ParsePackageJSON(`{"dependencies": ["react"]}`)
+	assert.EqualError(t, err, "package.json: dependencies must map package names to versions")
+
+	_, err = ParsePackageJSON(`{"name": `)
+	assert.Error(t, err)
+}
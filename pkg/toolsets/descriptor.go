@@ -0,0 +1,240 @@
+package toolsets
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DescriptorRequestFn sends a request of a tool generated from a descriptor to the GitHub REST API and returns the
+// JSON it responds with. The path is relative to the API root and already has its parameters filled in. The body
+// is nil for requests without body parameters.
+type DescriptorRequestFn func(ctx context.Context, method, path string, query url.Values, body map[string]interface{}) (json.RawMessage, error)
+
+// ToolsetDescriptor describes a toolset of passthrough tools, each calling a GitHub REST endpoint with its
+// arguments, so that such tools can be defined in a file rather than in Go.
+type ToolsetDescriptor struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Tools       []ToolDescriptor `json:"tools"`
+	// Request sends the requests of the tools. It can't be described in a file, so it is set by the caller.
+	Request DescriptorRequestFn `json:"-"`
+}
+
+// ToolDescriptor describes a tool calling a GitHub REST endpoint. The path is a template such as
+// /repos/{owner}/{repo}/topics, filled in from the parameters of the same names. Tools using GET are read tools,
+// any other method makes a write tool.
+type ToolDescriptor struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Method      string                `json:"method"`
+	Path        string                `json:"path"`
+	Params      []ToolParamDescriptor `json:"params"`
+}
+
+// ToolParamDescriptor describes an argument of a tool generated from a descriptor and where it goes in the
+// request: in the path, the query string or the JSON body. Parameters named in the path template go in the path,
+// others default to the query string for GET and DELETE requests and to the body otherwise. Field is the name the
+// argument is sent as, the name of the parameter by default.
+type ToolParamDescriptor struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	In          string `json:"in"`
+	Field       string `json:"field"`
+}
+
+// descriptorMethods are the HTTP methods a tool generated from a descriptor can use.
+var descriptorMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// pathParamPattern matches the parameters of a path template.
+var pathParamPattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// AddToolsetFromDescriptor validates a descriptor and adds the toolset it describes to the group, with a handler
+// generated for each of its tools. Like MergeFrom, it fails if the group already has a toolset of the same name,
+// and it fails if the group already has a tool of the same name as one of the descriptor, so a descriptor can't
+// replace or shadow a built-in toolset or tool.
+func (tg *ToolsetGroup) AddToolsetFromDescriptor(d ToolsetDescriptor) error {
+	if _, exists := tg.Toolsets[d.Name]; exists {
+		return fmt.Errorf("toolset %s already exists", d.Name)
+	}
+	if d.Request == nil {
+		return fmt.Errorf("toolset %s has no request function", d.Name)
+	}
+	if len(d.Tools) == 0 {
+		return fmt.Errorf("toolset %s has no tools", d.Name)
+	}
+	ts, err := NewToolsetSafe(d.Name, d.Description)
+	if err != nil {
+		return err
+	}
+
+	names := map[string]bool{}
+	for _, td := range d.Tools {
+		if names[td.Name] {
+			return fmt.Errorf("toolset %s has two tools named %s", d.Name, td.Name)
+		}
+		names[td.Name] = true
+		if owner, exists := tg.GetToolsetNameByToolName(td.Name); exists {
+			return fmt.Errorf("toolset %s: tool %s already exists in toolset %s", d.Name, td.Name, owner)
+		}
+		td.Method = strings.ToUpper(cmp.Or(td.Method, http.MethodGet))
+		tool, err := newDescriptorTool(td, d.Request)
+		if err != nil {
+			return fmt.Errorf("toolset %s: %w", d.Name, err)
+		}
+		if td.Method == http.MethodGet {
+			ts.AddReadTools(tool)
+		} else {
+			ts.AddWriteTools(tool)
+		}
+	}
+	return tg.AddToolset(ts)
+}
+
+// newDescriptorTool validates the descriptor of a tool, defaulting where its parameters go, and generates the tool
+// with a handler sending its request. The method must already be in upper case.
+func newDescriptorTool(td ToolDescriptor, request DescriptorRequestFn) (server.ServerTool, error) {
+	if td.Name == "" {
+		return server.ServerTool{}, fmt.Errorf("a tool has no name")
+	}
+	if !slices.Contains(descriptorMethods, td.Method) {
+		return server.ServerTool{}, fmt.Errorf("tool %s: method must be one of %s, got %q", td.Name, strings.Join(descriptorMethods, ", "), td.Method)
+	}
+	if !strings.HasPrefix(td.Path, "/") {
+		return server.ServerTool{}, fmt.Errorf("tool %s: path %q must start with /", td.Name, td.Path)
+	}
+
+	inPath := map[string]bool{}
+	for _, match := range pathParamPattern.FindAllStringSubmatch(td.Path, -1) {
+		inPath[match[1]] = true
+	}
+	options := []mcp.ToolOption{mcp.WithDescription(td.Description)}
+	params := make([]ToolParamDescriptor, len(td.Params))
+	for i, p := range td.Params {
+		if p.Name == "" {
+			return server.ServerTool{}, fmt.Errorf("tool %s: a parameter has no name", td.Name)
+		}
+		switch {
+		case inPath[p.Name] && p.In == "":
+			p.In = "path"
+		case p.In == "" && (td.Method == http.MethodGet || td.Method == http.MethodDelete):
+			p.In = "query"
+		case p.In == "":
+			p.In = "body"
+		}
+		if p.In != "path" && p.In != "query" && p.In != "body" {
+			return server.ServerTool{}, fmt.Errorf("tool %s: parameter %s must go in path, query or body, got %q", td.Name, p.Name, p.In)
+		}
+		if p.In == "path" {
+			if !inPath[p.Name] {
+				return server.ServerTool{}, fmt.Errorf("tool %s: path parameter %s is not in the path %s", td.Name, p.Name, td.Path)
+			}
+			if !p.Required {
+				return server.ServerTool{}, fmt.Errorf("tool %s: path parameter %s must be required", td.Name, p.Name)
+			}
+			delete(inPath, p.Name)
+		}
+		if p.Field == "" {
+			p.Field = p.Name
+		}
+
+		propertyOptions := []mcp.PropertyOption{mcp.Description(p.Description)}
+		if p.Required {
+			propertyOptions = append(propertyOptions, mcp.Required())
+		}
+		switch p.Type {
+		case "", "string":
+			p.Type = "string"
+			options = append(options, mcp.WithString(p.Name, propertyOptions...))
+		case "number":
+			options = append(options, mcp.WithNumber(p.Name, propertyOptions...))
+		case "boolean":
+			options = append(options, mcp.WithBoolean(p.Name, propertyOptions...))
+		default:
+			return server.ServerTool{}, fmt.Errorf("tool %s: parameter %s must be a string, number or boolean, got %q", td.Name, p.Name, p.Type)
+		}
+		params[i] = p
+	}
+	if missing := slices.Sorted(maps.Keys(inPath)); len(missing) > 0 {
+		return server.ServerTool{}, fmt.Errorf("tool %s: the path %s has a parameter %s the tool doesn't take", td.Name, td.Path, missing[0])
+	}
+
+	return NewServerTool(mcp.NewTool(td.Name, options...), descriptorToolHandler(td, params, request)), nil
+}
+
+// descriptorToolHandler generates the handler of a tool described by a descriptor, which fills in the request
+// template with the arguments of the call and returns the response as is.
+func descriptorToolHandler(td ToolDescriptor, params []ToolParamDescriptor, request DescriptorRequestFn) server.ToolHandlerFunc {
+	return func(ctx context.Context, r mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		path := td.Path
+		query := url.Values{}
+		var body map[string]interface{}
+		for _, p := range params {
+			value, ok := r.Params.Arguments[p.Name]
+			if !ok || value == nil {
+				if p.Required {
+					return mcp.NewToolResultError(fmt.Sprintf("missing required parameter: %s", p.Name)), nil
+				}
+				continue
+			}
+			text, err := descriptorArgument(p, value)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			switch p.In {
+			case "path":
+				// Escaping leaves dot segments alone, and they would point the request at another endpoint
+				if text == "" || text == "." || text == ".." {
+					return mcp.NewToolResultError(fmt.Sprintf("parameter %s can't be %q, as it goes in the path", p.Name, text)), nil
+				}
+				path = strings.ReplaceAll(path, "{"+p.Name+"}", url.PathEscape(text))
+			case "query":
+				query.Set(p.Field, text)
+			case "body":
+				if body == nil {
+					body = map[string]interface{}{}
+				}
+				body[p.Field] = value
+			}
+		}
+
+		response, err := request(ctx, td.Method, path, query, body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to call %s %s: %w", td.Method, path, err)
+		}
+		return mcp.NewToolResultText(string(response)), nil
+	}
+}
+
+// descriptorArgument checks that an argument has the type of its parameter and formats it for a path or query
+// string.
+func descriptorArgument(p ToolParamDescriptor, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		if p.Type == "string" {
+			return v, nil
+		}
+	case float64:
+		if p.Type == "number" {
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		}
+	case bool:
+		if p.Type == "boolean" {
+			return strconv.FormatBool(v), nil
+		}
+	}
+	return "", fmt.Errorf("parameter %s is not of type %s, is %T", p.Name, p.Type, value)
+}
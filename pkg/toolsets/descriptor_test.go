@@ -0,0 +1,270 @@
+package toolsets
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// recordedRequest is a request a tool generated from a descriptor made.
+type recordedRequest struct {
+	method string
+	path   string
+	query  url.Values
+	body   map[string]interface{}
+}
+
+func topicsDescriptor(requests *[]recordedRequest) ToolsetDescriptor {
+	return ToolsetDescriptor{
+		Name:        "topics",
+		Description: "Repository topics",
+		Tools: []ToolDescriptor{
+			{
+				Name:        "get_topics",
+				Description: "Get the topics of a repository",
+				Path:        "/repos/{owner}/{repo}/topics",
+				Params: []ToolParamDescriptor{
+					{Name: "owner", Required: true},
+					{Name: "repo", Required: true},
+					{Name: "per_page", Type: "number"},
+				},
+			},
+			{
+				Name:        "replace_topics",
+				Description: "Replace the topics of a repository",
+				Method:      "put",
+				Path:        "/repos/{owner}/{repo}/topics",
+				Params: []ToolParamDescriptor{
+					{Name: "owner", Required: true},
+					{Name: "repo", Required: true},
+					{Name: "topics", Field: "names", Required: true},
+				},
+			},
+		},
+		Request: func(_ context.Context, method, path string, query url.Values, body map[string]interface{}) (json.RawMessage, error) {
+			*requests = append(*requests, recordedRequest{method: method, path: path, query: query, body: body})
+			return json.RawMessage(`{"names":["go","mcp"]}`), nil
+		},
+	}
+}
+
+func callTool(t *testing.T, tool server.ServerTool, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	request := mcp.CallToolRequest{}
+	request.Params.Name = tool.Tool.Name
+	request.Params.Arguments = args
+	result, err := tool.Handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Expected no error calling %s, got %v", tool.Tool.Name, err)
+	}
+	return result
+}
+
+func TestAddToolsetFromDescriptor(t *testing.T) {
+	var requests []recordedRequest
+	tsg := NewToolsetGroup(false, nil)
+	if err := tsg.AddToolsetFromDescriptor(topicsDescriptor(&requests)); err != nil {
+		t.Fatalf("Expected no error adding the toolset, got %v", err)
+	}
+
+	ts := tsg.Toolsets["topics"]
+	if ts == nil {
+		t.Fatal("Expected the toolset to be added to the group")
+	}
+	if ts.Enabled {
+		t.Error("Expected the toolset to be disabled until enabled")
+	}
+	if len(ts.readTools) != 1 || ts.readTools[0].Tool.Name != "get_topics" {
+		t.Errorf("Expected get_topics to be the only read tool, got %v", ts.readTools)
+	}
+	if len(ts.writeTools) != 1 || ts.writeTools[0].Tool.Name != "replace_topics" {
+		t.Errorf("Expected replace_topics to be the only write tool, got %v", ts.writeTools)
+	}
+	if required := ts.readTools[0].Tool.InputSchema.Required; !reflect.DeepEqual(required, []string{"owner", "repo"}) {
+		t.Errorf("Expected owner and repo to be required, got %v", required)
+	}
+
+	result := callTool(t, ts.readTools[0], map[string]interface{}{"owner": "octo org", "repo": "repo", "per_page": float64(5)})
+	if result.IsError {
+		t.Fatalf("Expected get_topics to succeed, got %v", result.Content)
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; text != `{"names":["go","mcp"]}` {
+		t.Errorf("Expected the response to be returned as is, got %s", text)
+	}
+
+	result = callTool(t, ts.writeTools[0], map[string]interface{}{"owner": "owner", "repo": "repo", "topics": "go"})
+	if result.IsError {
+		t.Fatalf("Expected replace_topics to succeed, got %v", result.Content)
+	}
+
+	expected := []recordedRequest{
+		{method: "GET", path: "/repos/octo%20org/repo/topics", query: url.Values{"per_page": {"5"}}},
+		{method: "PUT", path: "/repos/owner/repo/topics", query: url.Values{}, body: map[string]interface{}{"names": "go"}},
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %+v, got %+v", expected, requests)
+	}
+}
+
+func TestDescriptorToolArguments(t *testing.T) {
+	var requests []recordedRequest
+	tsg := NewToolsetGroup(false, nil)
+	if err := tsg.AddToolsetFromDescriptor(topicsDescriptor(&requests)); err != nil {
+		t.Fatalf("Expected no error adding the toolset, got %v", err)
+	}
+	getTopics := tsg.Toolsets["topics"].readTools[0]
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		expectedErr string
+	}{
+		{
+			name:        "missing required argument",
+			args:        map[string]interface{}{"owner": "owner"},
+			expectedErr: "missing required parameter: repo",
+		},
+		{
+			name:        "argument of the wrong type",
+			args:        map[string]interface{}{"owner": "owner", "repo": "repo", "per_page": "5"},
+			expectedErr: "parameter per_page is not of type number, is string",
+		},
+		{
+			name:        "path traversal",
+			args:        map[string]interface{}{"owner": "..", "repo": "repo"},
+			expectedErr: `parameter owner can't be "..", as it goes in the path`,
+		},
+		{
+			name:        "current directory in the path",
+			args:        map[string]interface{}{"owner": "owner", "repo": "."},
+			expectedErr: `parameter repo can't be ".", as it goes in the path`,
+		},
+		{
+			name:        "empty path segment",
+			args:        map[string]interface{}{"owner": "", "repo": "repo"},
+			expectedErr: `parameter owner can't be "", as it goes in the path`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := callTool(t, getTopics, tc.args)
+			if !result.IsError {
+				t.Fatal("Expected the call to fail")
+			}
+			if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tc.expectedErr) {
+				t.Errorf("Expected error containing %q, got %q", tc.expectedErr, text)
+			}
+		})
+	}
+	if len(requests) != 0 {
+		t.Errorf("Expected no requests, got %+v", requests)
+	}
+}
+
+func TestAddToolsetFromDescriptorValidation(t *testing.T) {
+	tool := func(path string, params ...ToolParamDescriptor) []ToolDescriptor {
+		return []ToolDescriptor{{Name: "get_thing", Path: path, Params: params}}
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(d *ToolsetDescriptor)
+		expectedErr string
+	}{
+		{
+			name: "path parameter missing from the arguments",
+			modify: func(d *ToolsetDescriptor) {
+				d.Tools = tool("/repos/{owner}/{repo}", ToolParamDescriptor{Name: "owner", Required: true})
+			},
+			expectedErr: "toolset topics: tool get_thing: the path /repos/{owner}/{repo} has a parameter repo the tool doesn't take",
+		},
+		{
+			name: "optional path parameter",
+			modify: func(d *ToolsetDescriptor) {
+				d.Tools = tool("/users/{username}", ToolParamDescriptor{Name: "username"})
+			},
+			expectedErr: "tool get_thing: path parameter username must be required",
+		},
+		{
+			name: "path parameter not in the path",
+			modify: func(d *ToolsetDescriptor) {
+				d.Tools = tool("/user", ToolParamDescriptor{Name: "username", In: "path", Required: true})
+			},
+			expectedErr: "tool get_thing: path parameter username is not in the path /user",
+		},
+		{
+			name: "unknown parameter type",
+			modify: func(d *ToolsetDescriptor) {
+				d.Tools = tool("/user", ToolParamDescriptor{Name: "fields", Type: "array"})
+			},
+			expectedErr: `tool get_thing: parameter fields must be a string, number or boolean, got "array"`,
+		},
+		{
+			name: "unknown method",
+			modify: func(d *ToolsetDescriptor) {
+				d.Tools[0].Method = "TRACE"
+			},
+			expectedErr: `tool get_topics: method must be one of GET, POST, PUT, PATCH, DELETE, got "TRACE"`,
+		},
+		{
+			name: "duplicate tool",
+			modify: func(d *ToolsetDescriptor) {
+				d.Tools[1].Name = "get_topics"
+			},
+			expectedErr: "toolset topics has two tools named get_topics",
+		},
+		{
+			name: "invalid toolset name",
+			modify: func(d *ToolsetDescriptor) {
+				d.Name = "Topics"
+			},
+			expectedErr: `invalid toolset name "Topics"`,
+		},
+		{
+			name: "existing toolset",
+			modify: func(d *ToolsetDescriptor) {
+				d.Name = "repos"
+			},
+			expectedErr: "toolset repos already exists",
+		},
+		{
+			name: "tool shadowing a tool of another toolset",
+			modify: func(d *ToolsetDescriptor) {
+				d.Tools[1].Name = "create_issue"
+			},
+			expectedErr: "toolset topics: tool create_issue already exists in toolset repos",
+		},
+		{
+			name: "no request function",
+			modify: func(d *ToolsetDescriptor) {
+				d.Request = nil
+			},
+			expectedErr: "toolset topics has no request function",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsg := NewToolsetGroup(false, nil)
+			_ = tsg.AddToolset(NewToolset("repos", "Repositories").
+				AddWriteTools(NewServerTool(mcp.NewTool("create_issue"), nil)))
+			d := topicsDescriptor(&[]recordedRequest{})
+			tc.modify(&d)
+
+			err := tsg.AddToolsetFromDescriptor(d)
+			if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+				t.Fatalf("Expected error containing %q, got %v", tc.expectedErr, err)
+			}
+			if _, added := tsg.Toolsets[strings.ToLower(d.Name)]; added && d.Name != "repos" {
+				t.Errorf("Expected toolset %s not to be added", d.Name)
+			}
+		})
+	}
+}
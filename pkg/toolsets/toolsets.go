@@ -1,12 +1,22 @@
 package toolsets
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/github/github-mcp-server/pkg/config"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// NewServerTool pairs a tool definition with its handler. The handler is called with the context
+// of the MCP request, so it is cancelled when the client cancels the call or the server shuts down,
+// and handlers must pass it on to the GitHub API calls they make.
 func NewServerTool(tool mcp.Tool, handler server.ToolHandlerFunc) server.ServerTool {
 	return server.ServerTool{Tool: tool, Handler: handler}
 }
@@ -19,6 +29,7 @@ type Toolset struct {
 	writeTools    []server.ServerTool
 	readTools     []server.ServerTool
 	disabledTools map[string]bool // Map for efficient lookup
+	changes       int             // Times tools were added or removed, so groups can tell their index is out of date
 }
 
 func (t *Toolset) GetActiveTools() []server.ServerTool {
@@ -78,20 +89,90 @@ func (t *Toolset) AddWriteTools(tools ...server.ServerTool) *Toolset {
 	// Silently ignore if the toolset is read-only to avoid any breach of that contract
 	if !t.readOnly {
 		t.writeTools = append(t.writeTools, tools...)
+		t.changes++
 	}
 	return t
 }
 
 func (t *Toolset) AddReadTools(tools ...server.ServerTool) *Toolset {
 	t.readTools = append(t.readTools, tools...)
+	t.changes++
 	return t
 }
 
+// RemoveTool removes the read or write tools with the given name from the toolset, and reports whether it had
+// one. Servers the tool is already registered with keep it until the group's ReconcileTools is called.
+func (t *Toolset) RemoveTool(name string) bool {
+	isNamed := func(tool server.ServerTool) bool { return tool.Tool.Name == name }
+	before := len(t.readTools) + len(t.writeTools)
+	t.readTools = slices.DeleteFunc(t.readTools, isNamed)
+	t.writeTools = slices.DeleteFunc(t.writeTools, isNamed)
+	if len(t.readTools)+len(t.writeTools) == before {
+		return false
+	}
+	t.changes++
+	return true
+}
+
+// ReplaceHandler replaces the handler of the read or write tools with the given name, and reports whether the
+// toolset had one. The tools stay where they are, so which toolset a group finds them in doesn't change, and
+// servers the tool is already registered with keep calling the old handler until it is registered again.
+func (t *Toolset) ReplaceHandler(name string, handler server.ToolHandlerFunc) bool {
+	replaced := false
+	for _, tools := range [][]server.ServerTool{t.readTools, t.writeTools} {
+		for i := range tools {
+			if tools[i].Tool.Name == name {
+				tools[i].Handler = handler
+				replaced = true
+			}
+		}
+	}
+	return replaced
+}
+
+// Clone returns a disabled copy of the toolset named newName, for defining variants of a toolset such as a
+// read-only one. The copy has its own tool lists and disabled tools, so adding tools to or disabling tools in
+// either toolset doesn't affect the other. The copy is shallow: the tools themselves, including their handlers,
+// are shared.
+func (t *Toolset) Clone(newName string) *Toolset {
+	disabledTools := make(map[string]bool, len(t.disabledTools))
+	for name, disabled := range t.disabledTools {
+		disabledTools[name] = disabled
+	}
+	return &Toolset{
+		Name:          newName,
+		Description:   t.Description,
+		Enabled:       false,
+		readOnly:      t.readOnly,
+		writeTools:    slices.Clone(t.writeTools),
+		readTools:     slices.Clone(t.readTools),
+		disabledTools: disabledTools,
+	}
+}
+
+// String implements fmt.Stringer with a one-line summary of the toolset, counting its active tools
+// against the tools available to it.
+func (t *Toolset) String() string {
+	return fmt.Sprintf("Toolset{name=%q, enabled=%v, read_only=%v, tools=%d/%d}",
+		t.Name, t.Enabled, t.readOnly, len(t.GetActiveTools()), len(t.GetAvailableTools()))
+}
+
+// GoString implements fmt.GoStringer with a Go expression creating a similar toolset. Tools can't be
+// written as Go expressions, so they are left out, and so is the read-only flag, which the toolset
+// gets from the group it is added to.
+func (t *Toolset) GoString() string {
+	return fmt.Sprintf("&toolsets.Toolset{Name: %q, Description: %q, Enabled: %t}", t.Name, t.Description, t.Enabled)
+}
+
 type ToolsetGroup struct {
 	Toolsets      map[string]*Toolset
 	everythingOn  bool
 	readOnly      bool
-	disabledTools map[string]bool // Store disabled tools here
+	disabledTools map[string]bool   // Store disabled tools here
+	registered    map[string]bool   // Names of the tools the group registered with a server
+	toolToToolset map[string]string // Name of the toolset owning each tool, nil until indexed, see GetToolsetNameByToolName
+	indexed       map[string]int    // Changes of each toolset when the tools were last indexed
+	order         []string          // Names of the toolsets in the order they were first added
 }
 
 // NewToolsetGroup creates a new ToolsetGroup, initializing the disabled tools map.
@@ -105,25 +186,215 @@ func NewToolsetGroup(readOnly bool, disabledToolsList []string) *ToolsetGroup {
 		everythingOn:  false,
 		readOnly:      readOnly,
 		disabledTools: disabledToolsMap,
+		registered:    make(map[string]bool),
+	}
+}
+
+// NewToolsetGroupWithToolsets creates a new ToolsetGroup and adds all the provided toolsets to it. Like NewToolset,
+// it is meant for initialization and panics if a toolset has an invalid name.
+func NewToolsetGroupWithToolsets(readOnly bool, disabledToolsList []string, toolsets ...*Toolset) *ToolsetGroup {
+	tg := NewToolsetGroup(readOnly, disabledToolsList)
+	if err := tg.AddToolsets(toolsets...); err != nil {
+		panic(err)
 	}
+	return tg
 }
 
-func (tg *ToolsetGroup) AddToolset(ts *Toolset) {
+// NewToolsetGroupFromConfig creates a group from the configuration of the server: once the configuration is
+// validated, the toolsets are added to a group that is read-only and disables tools as configured, and the
+// configured toolsets are enabled.
+func NewToolsetGroupFromConfig(cfg config.ServerConfig, toolsets []*Toolset) (*ToolsetGroup, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	tg := NewToolsetGroup(cfg.ReadOnly, cfg.DisabledTools)
+	if err := tg.AddToolsets(toolsets...); err != nil {
+		return nil, err
+	}
+	if err := tg.EnableToolsets(cfg.EnabledToolsets); err != nil {
+		return nil, err
+	}
+	return tg, nil
+}
+
+// AddToolset adds a toolset to the group, replacing any toolset of the same name. It fails if the name of the
+// toolset isn't valid, which can only happen if it was changed after the toolset was created.
+func (tg *ToolsetGroup) AddToolset(ts *Toolset) error {
+	if !IsValidToolsetName(ts.Name) {
+		return invalidToolsetNameError(ts.Name)
+	}
 	if tg.readOnly {
 		ts.SetReadOnly()
 	}
 	ts.disabledTools = tg.disabledTools // Pass down the disabled map to the toolset
+	if _, exists := tg.Toolsets[ts.Name]; !exists {
+		tg.order = append(tg.order, ts.Name)
+	}
 	tg.Toolsets[ts.Name] = ts
+	tg.toolToToolset = nil
+	return nil
 }
 
-func NewToolset(name string, description string) *Toolset {
+// AddToolsets adds each of the provided toolsets to the group, as if AddToolset was called on each of them. It
+// fails without adding any of them if one has an invalid name.
+func (tg *ToolsetGroup) AddToolsets(toolsets ...*Toolset) error {
+	for _, ts := range toolsets {
+		if !IsValidToolsetName(ts.Name) {
+			return invalidToolsetNameError(ts.Name)
+		}
+	}
+	for _, ts := range toolsets {
+		_ = tg.AddToolset(ts)
+	}
+	return nil
+}
+
+// MergeFrom adds the toolsets of other to the group, failing without adding any of them if the group already has
+// a toolset of the same name. The toolsets are moved rather than copied: they become read-only if the group is and
+// stay read-only if they already were, and the disabled tools of both groups are disabled in all of their toolsets.
+func (tg *ToolsetGroup) MergeFrom(other *ToolsetGroup) error {
+	for name := range other.Toolsets {
+		if _, exists := tg.Toolsets[name]; exists {
+			return fmt.Errorf("toolset %s already exists", name)
+		}
+	}
+	tg.MergeFromOrReplace(other)
+	return nil
+}
+
+// MergeFromOrReplace is like MergeFrom, but replaces the toolsets of the group that have the same name as one
+// of other instead of failing.
+func (tg *ToolsetGroup) MergeFromOrReplace(other *ToolsetGroup) {
+	// The toolsets of the group share its disabled tools map, so this disables the tools in them too
+	for name, disabled := range other.disabledTools {
+		if disabled {
+			tg.disabledTools[name] = true
+		}
+	}
+	// The toolsets were validated when they were added to other, which is keyed by their names
+	_ = other.ForEachToolset(func(_ string, ts *Toolset) error {
+		// Merging can only tighten the read-only setting, so write tools of a read-only toolset stay hidden
+		if tg.readOnly {
+			ts.SetReadOnly()
+		}
+		_ = tg.AddToolset(ts)
+		return nil
+	})
+}
+
+// NewMergedToolsetGroup creates a group with the toolsets of all the given groups, as if MergeFrom was called with
+// each of them in turn, failing if two of them have a toolset of the same name. The group is read-only if any of
+// the groups is.
+func NewMergedToolsetGroup(groups ...*ToolsetGroup) (*ToolsetGroup, error) {
+	readOnly := false
+	for _, group := range groups {
+		readOnly = readOnly || group.readOnly
+	}
+	tg := NewToolsetGroup(readOnly, nil)
+	for _, group := range groups {
+		if err := tg.MergeFrom(group); err != nil {
+			return nil, err
+		}
+	}
+	return tg, nil
+}
+
+// GetToolsetNameByToolName returns the name of the toolset of the group that has a read or write tool with the
+// given name, whether or not the toolset is enabled or the tool disabled, and whether one was found. If several
+// toolsets have the tool, the one added to the group first is returned, however late the tool was added to it,
+// and a replaced toolset keeps the place of the one it replaced. The tools of all toolsets are indexed on the
+// first lookup, and again on the first lookup after a toolset was added or had tools added or removed, so
+// lookups in between don't go through the tools.
+func (tg *ToolsetGroup) GetToolsetNameByToolName(toolName string) (string, bool) {
+	if tg.indexOutdated() {
+		tg.indexTools()
+	}
+	name, ok := tg.toolToToolset[toolName]
+	return name, ok
+}
+
+// indexOutdated reports whether the tools of the group need to be indexed again.
+func (tg *ToolsetGroup) indexOutdated() bool {
+	if tg.toolToToolset == nil {
+		return true
+	}
+	for name, ts := range tg.Toolsets {
+		if ts.changes != tg.indexed[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// indexTools maps the name of each tool of the group to the first toolset, in the order they were added, that
+// has it.
+func (tg *ToolsetGroup) indexTools() {
+	tg.toolToToolset = make(map[string]string)
+	tg.indexed = make(map[string]int, len(tg.Toolsets))
+	for _, name := range tg.order {
+		ts, ok := tg.Toolsets[name]
+		if !ok {
+			continue
+		}
+		tg.indexed[name] = ts.changes
+		for _, tools := range [][]server.ServerTool{ts.readTools, ts.writeTools} {
+			for _, tool := range tools {
+				if _, indexed := tg.toolToToolset[tool.Tool.Name]; !indexed {
+					tg.toolToToolset[tool.Tool.Name] = name
+				}
+			}
+		}
+	}
+}
+
+// GetToolsetByToolName is like GetToolsetNameByToolName, but returns the toolset itself.
+func (tg *ToolsetGroup) GetToolsetByToolName(toolName string) (*Toolset, bool) {
+	name, ok := tg.GetToolsetNameByToolName(toolName)
+	if !ok {
+		return nil, false
+	}
+	return tg.Toolsets[name], true
+}
+
+// toolsetNamePattern is the form toolset names must have, since they are used in command-line flags and
+// environment variables.
+var toolsetNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// maxToolsetNameLength is the longest a toolset name can be.
+const maxToolsetNameLength = 50
+
+// IsValidToolsetName reports whether name can be the name of a toolset: a lowercase letter followed by lowercase
+// letters, digits and underscores, at most 50 characters long.
+func IsValidToolsetName(name string) bool {
+	return len(name) <= maxToolsetNameLength && toolsetNamePattern.MatchString(name)
+}
+
+func invalidToolsetNameError(name string) error {
+	return fmt.Errorf("invalid toolset name %q: it must start with a lowercase letter, only contain lowercase letters, digits and underscores, and be at most %d characters long", name, maxToolsetNameLength)
+}
+
+// NewToolsetSafe creates a toolset, failing if its name isn't valid according to IsValidToolsetName.
+func NewToolsetSafe(name string, description string) (*Toolset, error) {
+	if !IsValidToolsetName(name) {
+		return nil, invalidToolsetNameError(name)
+	}
 	return &Toolset{
 		Name:          name,
 		Description:   description,
 		Enabled:       false,
 		readOnly:      false,
 		disabledTools: make(map[string]bool), // Initialize the map
+	}, nil
+}
+
+// NewToolset creates a toolset like NewToolsetSafe, but panics if its name isn't valid. It is meant for the
+// toolsets created at initialization, whose names are fixed.
+func NewToolset(name string, description string) *Toolset {
+	ts, err := NewToolsetSafe(name, description)
+	if err != nil {
+		panic(err)
 	}
+	return ts
 }
 
 func (tg *ToolsetGroup) IsEnabled(name string) bool {
@@ -153,13 +424,9 @@ func (tg *ToolsetGroup) EnableToolsets(names []string) error {
 	}
 	// Do this after to ensure all toolsets are enabled if "all" is present anywhere in list
 	if tg.everythingOn {
-		for name := range tg.Toolsets {
-			err := tg.EnableToolset(name)
-			if err != nil {
-				return err
-			}
-		}
-		return nil
+		return tg.ForEachToolset(func(name string, _ *Toolset) error {
+			return tg.EnableToolset(name)
+		})
 	}
 	return nil
 }
@@ -174,8 +441,268 @@ func (tg *ToolsetGroup) EnableToolset(name string) error {
 	return nil
 }
 
+// RegisterTools registers the active tools of the enabled toolsets with the server.
 func (tg *ToolsetGroup) RegisterTools(s *server.MCPServer) {
-	for _, toolset := range tg.Toolsets {
-		toolset.RegisterTools(s) // Toolset's RegisterTools now handles disabled filtering
+	tg.RegisterToolsFiltered(s, func(server.ServerTool) bool { return true })
+}
+
+// RegisterToolsFiltered is like RegisterTools, but only registers the tools for which filter returns true.
+// The filter is given the whole tool, so it can look at its name, description or input schema.
+func (tg *ToolsetGroup) RegisterToolsFiltered(s *server.MCPServer, filter func(tool server.ServerTool) bool) {
+	_ = tg.ForEachActiveTool(func(_, _ string, tool server.ServerTool) error {
+		if filter(tool) {
+			s.AddTool(tool.Tool, tool.Handler)
+			tg.registered[tool.Tool.Name] = true
+		}
+		return nil
+	})
+}
+
+// FilterByToolNames returns a filter for RegisterToolsFiltered that only accepts the tools with the given names.
+func FilterByToolNames(names []string) func(tool server.ServerTool) bool {
+	accepted := make(map[string]bool, len(names))
+	for _, name := range names {
+		accepted[name] = true
+	}
+	return func(tool server.ServerTool) bool {
+		return accepted[tool.Tool.Name]
+	}
+}
+
+// ReconcileResult lists the tools ReconcileTools registered with and removed from the server, for audit logging.
+type ReconcileResult struct {
+	Added     []string
+	Removed   []string
+	Unchanged int
+}
+
+// ReconcileTools brings the tools the group registered with the server in line with its active tools, after
+// toolsets were enabled or tools disabled at runtime: tools that are no longer active are removed from the
+// server, and newly active ones are registered. Only the tools the group itself registered are considered, so
+// tools added to the server some other way are left alone. The names in the result are sorted.
+func (tg *ToolsetGroup) ReconcileTools(s *server.MCPServer) ReconcileResult {
+	active := map[string]server.ServerTool{}
+	_ = tg.ForEachActiveTool(func(_, toolName string, tool server.ServerTool) error {
+		active[toolName] = tool
+		return nil
+	})
+
+	result := ReconcileResult{Added: []string{}, Removed: []string{}}
+	for name := range tg.registered {
+		if _, ok := active[name]; !ok {
+			result.Removed = append(result.Removed, name)
+		}
+	}
+	for name, tool := range active {
+		if tg.registered[name] {
+			result.Unchanged++
+			continue
+		}
+		result.Added = append(result.Added, name)
+		s.AddTool(tool.Tool, tool.Handler)
+		tg.registered[name] = true
+	}
+	if len(result.Removed) > 0 {
+		s.DeleteTools(result.Removed...)
+		for _, name := range result.Removed {
+			delete(tg.registered, name)
+		}
+	}
+
+	sort.Strings(result.Added)
+	sort.Strings(result.Removed)
+	return result
+}
+
+// ToolsetFlags are the flags of a toolset a ToolsetState captures.
+type ToolsetFlags struct {
+	Enabled  bool `json:"enabled"`
+	ReadOnly bool `json:"read_only"`
+}
+
+// ToolsetState is which toolsets and tools of a group are on at some point, as captured by Snapshot, so that a
+// client can switch to another set of toolsets and later back with Restore.
+type ToolsetState struct {
+	EverythingOn  bool                    `json:"everything_on"`
+	Toolsets      map[string]ToolsetFlags `json:"toolsets"`
+	DisabledTools []string                `json:"disabled_tools"`
+}
+
+// Snapshot captures the enabled and read-only flags of each toolset of the group, whether everything is on and
+// the disabled tools, sorted.
+func (tg *ToolsetGroup) Snapshot() ToolsetState {
+	state := ToolsetState{
+		EverythingOn:  tg.everythingOn,
+		Toolsets:      make(map[string]ToolsetFlags, len(tg.Toolsets)),
+		DisabledTools: make([]string, 0, len(tg.disabledTools)),
+	}
+	for name, ts := range tg.Toolsets {
+		state.Toolsets[name] = ToolsetFlags{Enabled: ts.Enabled, ReadOnly: ts.readOnly}
+	}
+	for name, disabled := range tg.disabledTools {
+		if disabled {
+			state.DisabledTools = append(state.DisabledTools, name)
+		}
+	}
+	sort.Strings(state.DisabledTools)
+	return state
+}
+
+// Restore puts the group back in a state captured by Snapshot. It fails without changing anything if the state
+// has a toolset the group no longer has, or would make a toolset of a read-only group writable. Toolsets added
+// to the group after the snapshot was taken are left as they are. Restore only changes the group, so a caller
+// whose tools are already registered with a server calls ReconcileTools afterwards to bring the server in line.
+func (tg *ToolsetGroup) Restore(state ToolsetState) error {
+	names := make([]string, 0, len(state.Toolsets))
+	for name := range state.Toolsets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, exists := tg.Toolsets[name]; !exists {
+			return fmt.Errorf("toolset %s does not exist", name)
+		}
+		if tg.readOnly && !state.Toolsets[name].ReadOnly {
+			return fmt.Errorf("toolset %s can't be made writable in a read-only group", name)
+		}
+	}
+
+	for name, flags := range state.Toolsets {
+		ts := tg.Toolsets[name]
+		ts.Enabled = flags.Enabled
+		ts.readOnly = flags.ReadOnly || tg.readOnly
+	}
+	tg.everythingOn = state.EverythingOn
+	// The toolsets of the group share its disabled tools map, so it is refilled rather than replaced
+	clear(tg.disabledTools)
+	for _, name := range state.DisabledTools {
+		tg.disabledTools[name] = true
+	}
+	return nil
+}
+
+// ForEachToolset calls fn for each toolset in the group, in alphabetical order of the toolset names.
+// Iteration stops at the first non-nil error returned by fn, and that error is returned.
+func (tg *ToolsetGroup) ForEachToolset(fn func(name string, ts *Toolset) error) error {
+	names := make([]string, 0, len(tg.Toolsets))
+	for name := range tg.Toolsets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := fn(name, tg.Toolsets[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForEachEnabledToolset is like ForEachToolset, but skips toolsets that are not enabled.
+func (tg *ToolsetGroup) ForEachEnabledToolset(fn func(name string, ts *Toolset) error) error {
+	return tg.ForEachToolset(func(name string, ts *Toolset) error {
+		if !ts.Enabled {
+			return nil
+		}
+		return fn(name, ts)
+	})
+}
+
+// ForEachActiveTool calls fn for each active tool of each enabled toolset, ordered by toolset name.
+// Iteration stops at the first non-nil error returned by fn, and that error is returned.
+func (tg *ToolsetGroup) ForEachActiveTool(fn func(toolsetName, toolName string, tool server.ServerTool) error) error {
+	return tg.ForEachEnabledToolset(func(name string, ts *Toolset) error {
+		for _, tool := range ts.GetActiveTools() {
+			if err := fn(name, tool.Tool.Name, tool); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ExportInputSchemas returns the JSON Schema of the input of each active tool of the enabled toolsets, by tool
+// name, so clients can validate arguments before calling a tool. Tools defined with a raw input schema have it
+// returned as is. A schema that can't be marshalled, which would also fail when listing the tools, is left out.
+func (tg *ToolsetGroup) ExportInputSchemas() map[string]json.RawMessage {
+	schemas := map[string]json.RawMessage{}
+	_ = tg.ForEachActiveTool(func(_, toolName string, tool server.ServerTool) error {
+		if tool.Tool.RawInputSchema != nil {
+			schemas[toolName] = tool.Tool.RawInputSchema
+			return nil
+		}
+		if schema, err := json.Marshal(tool.Tool.InputSchema); err == nil {
+			schemas[toolName] = schema
+		}
+		return nil
+	})
+	return schemas
+}
+
+// SummarizeConfig returns a human-readable, multi-line summary of the group's configuration for
+// terminal display: the group-wide flags, one row per toolset and the globally disabled tools.
+func (tg *ToolsetGroup) SummarizeConfig() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "everythingOn: %t\nreadOnly: %t\n\n", tg.everythingOn, tg.readOnly)
+
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TOOLSET\tENABLED\tREAD-ONLY\tACTIVE TOOLS\tDISABLED TOOLS")
+	_ = tg.ForEachToolset(func(name string, ts *Toolset) error {
+		disabled := []string{}
+		for _, tool := range ts.GetAvailableTools() {
+			if ts.disabledTools[tool.Tool.Name] {
+				disabled = append(disabled, tool.Tool.Name)
+			}
+		}
+		fmt.Fprintf(w, "%s\t%t\t%t\t%d\t%s\n", name, ts.Enabled, ts.readOnly, len(ts.GetActiveTools()), joinOrNone(disabled))
+		return nil
+	})
+	_ = w.Flush()
+
+	disabled := make([]string, 0, len(tg.disabledTools))
+	for name := range tg.disabledTools {
+		disabled = append(disabled, name)
+	}
+	sort.Strings(disabled)
+	fmt.Fprintf(&b, "\nDisabled tools: %s\n", joinOrNone(disabled))
+
+	return b.String()
+}
+
+// String implements fmt.Stringer with the string form of each toolset, one per line, in alphabetical order of
+// the toolset names.
+func (tg *ToolsetGroup) String() string {
+	var b strings.Builder
+	_ = tg.ForEachToolset(func(_ string, ts *Toolset) error {
+		fmt.Fprintf(&b, "%v\n", ts)
+		return nil
+	})
+	return b.String()
+}
+
+// GoString implements fmt.GoStringer with a Go expression creating a similar group: one with the same
+// read-only flag and disabled tools, and the toolsets as written by their GoString.
+func (tg *ToolsetGroup) GoString() string {
+	disabled := make([]string, 0, len(tg.disabledTools))
+	for name := range tg.disabledTools {
+		disabled = append(disabled, name)
+	}
+	sort.Strings(disabled)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "toolsets.NewToolsetGroupWithToolsets(%t, %#v", tg.readOnly, disabled)
+	_ = tg.ForEachToolset(func(_ string, ts *Toolset) error {
+		fmt.Fprintf(&b, ", %#v", ts)
+		return nil
+	})
+	b.WriteString(")")
+	return b.String()
+}
+
+// joinOrNone joins names with commas, or returns "none" if there are no names.
+func joinOrNone(names []string) string {
+	if len(names) == 0 {
+		return "none"
 	}
+	return strings.Join(names, ", ")
 }
@@ -1,11 +1,42 @@
 package toolsets
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
+
+	"github.com/github/github-mcp-server/pkg/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
+func TestNewServerToolPropagatesContext(t *testing.T) {
+	type contextKey struct{}
+	var received context.Context
+	tool := NewServerTool(mcp.NewTool("test_tool"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		received = ctx
+		return mcp.NewToolResultText("ok"), ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), contextKey{}, "value"))
+	cancel()
+	_, err := tool.Handler(ctx, mcp.CallToolRequest{})
+
+	if received == nil || received.Value(contextKey{}) != "value" {
+		t.Fatal("Expected the handler to receive the request context")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the handler to observe the cancellation, got %v", err)
+	}
+}
+
 func TestNewToolsetGroup(t *testing.T) {
-	tsg := NewToolsetGroup(false)
+	tsg := NewToolsetGroup(false, nil)
 	if tsg == nil {
 		t.Fatal("Expected NewToolsetGroup to return a non-nil pointer")
 	}
@@ -21,25 +52,25 @@ func TestNewToolsetGroup(t *testing.T) {
 }
 
 func TestAddToolset(t *testing.T) {
-	tsg := NewToolsetGroup(false)
+	tsg := NewToolsetGroup(false, nil)
 
 	// Test adding a toolset
-	toolset := NewToolset("test-toolset", "A test toolset")
+	toolset := NewToolset("test_toolset", "A test toolset")
 	toolset.Enabled = true
-	tsg.AddToolset(toolset)
+	mustAddToolsets(t, tsg, toolset)
 
 	// Verify toolset was added correctly
 	if len(tsg.Toolsets) != 1 {
 		t.Errorf("Expected 1 toolset, got %d", len(tsg.Toolsets))
 	}
 
-	toolset, exists := tsg.Toolsets["test-toolset"]
+	toolset, exists := tsg.Toolsets["test_toolset"]
 	if !exists {
 		t.Fatal("Feature was not added to the map")
 	}
 
-	if toolset.Name != "test-toolset" {
-		t.Errorf("Expected toolset name to be 'test-toolset', got '%s'", toolset.Name)
+	if toolset.Name != "test_toolset" {
+		t.Errorf("Expected toolset name to be 'test_toolset', got '%s'", toolset.Name)
 	}
 
 	if toolset.Description != "A test toolset" {
@@ -51,18 +82,18 @@ func TestAddToolset(t *testing.T) {
 	}
 
 	// Test adding another toolset
-	anotherToolset := NewToolset("another-toolset", "Another test toolset")
-	tsg.AddToolset(anotherToolset)
+	anotherToolset := NewToolset("another_toolset", "Another test toolset")
+	mustAddToolsets(t, tsg, anotherToolset)
 
 	if len(tsg.Toolsets) != 2 {
 		t.Errorf("Expected 2 toolsets, got %d", len(tsg.Toolsets))
 	}
 
 	// Test overriding existing toolset
-	updatedToolset := NewToolset("test-toolset", "Updated description")
-	tsg.AddToolset(updatedToolset)
+	updatedToolset := NewToolset("test_toolset", "Updated description")
+	mustAddToolsets(t, tsg, updatedToolset)
 
-	toolset = tsg.Toolsets["test-toolset"]
+	toolset = tsg.Toolsets["test_toolset"]
 	if toolset.Description != "Updated description" {
 		t.Errorf("Expected toolset description to be updated to 'Updated description', got '%s'", toolset.Description)
 	}
@@ -72,8 +103,138 @@ func TestAddToolset(t *testing.T) {
 	}
 }
 
+func TestGetToolsetByToolName(t *testing.T) {
+	tsg := NewToolsetGroup(true, []string{"create_issue"})
+	issues := NewToolset("issues", "Issues").
+		AddReadTools(NewServerTool(mcp.NewTool("get_issue"), nil)).
+		AddWriteTools(NewServerTool(mcp.NewTool("create_issue"), nil))
+	mustAddToolsets(t, tsg, issues)
+	mustAddToolsets(t, tsg, NewToolset("search", "Search").
+		AddReadTools(NewServerTool(mcp.NewTool("get_issue"), nil), NewServerTool(mcp.NewTool("search_code"), nil)))
+
+	tests := []struct {
+		toolName        string
+		expectedToolset string
+	}{
+		// Disabled tools, write tools of read-only groups and tools of disabled toolsets are found too
+		{toolName: "create_issue", expectedToolset: "issues"},
+		// The toolset added first owns a tool two toolsets have
+		{toolName: "get_issue", expectedToolset: "issues"},
+		{toolName: "search_code", expectedToolset: "search"},
+		{toolName: "missing", expectedToolset: ""},
+	}
+	for _, tc := range tests {
+		name, ok := tsg.GetToolsetNameByToolName(tc.toolName)
+		if name != tc.expectedToolset || ok != (tc.expectedToolset != "") {
+			t.Errorf("Expected %s to be in toolset %q, got %q, %t", tc.toolName, tc.expectedToolset, name, ok)
+		}
+	}
+
+	ts, ok := tsg.GetToolsetByToolName("get_issue")
+	if !ok || ts != issues {
+		t.Errorf("Expected get_issue to be in the issues toolset, got %v, %t", ts, ok)
+	}
+	if ts, ok := tsg.GetToolsetByToolName("missing"); ok || ts != nil {
+		t.Errorf("Expected no toolset for a missing tool, got %v, %t", ts, ok)
+	}
+
+	// A tool added to a toolset already in the group is still found
+	issues.AddReadTools(NewServerTool(mcp.NewTool("list_issues"), nil))
+	if name, ok := tsg.GetToolsetNameByToolName("list_issues"); !ok || name != "issues" {
+		t.Errorf("Expected list_issues to be in toolset issues, got %q, %t", name, ok)
+	}
+
+	// Replacing a toolset drops the tools only it had
+	mustAddToolsets(t, tsg, NewToolset("issues", "Issues").
+		AddReadTools(NewServerTool(mcp.NewTool("get_issue_comments"), nil)))
+	if name, ok := tsg.GetToolsetNameByToolName("get_issue"); !ok || name != "search" {
+		t.Errorf("Expected get_issue to be in toolset search after issues was replaced, got %q, %t", name, ok)
+	}
+	if _, ok := tsg.GetToolsetNameByToolName("create_issue"); ok {
+		t.Error("Expected create_issue to be gone with the replaced toolset")
+	}
+	if name, ok := tsg.GetToolsetNameByToolName("get_issue_comments"); !ok || name != "issues" {
+		t.Errorf("Expected get_issue_comments to be in toolset issues, got %q, %t", name, ok)
+	}
+
+	// A tool added to two toolsets already in the group belongs to the one added first, not the first by name
+	repos := NewToolset("repos", "Repos")
+	actions := NewToolset("actions", "Actions")
+	mustAddToolsets(t, tsg, repos, actions)
+	actions.AddReadTools(NewServerTool(mcp.NewTool("get_workflow"), nil))
+	repos.AddReadTools(NewServerTool(mcp.NewTool("get_workflow"), nil))
+	if name, ok := tsg.GetToolsetNameByToolName("get_workflow"); !ok || name != "repos" {
+		t.Errorf("Expected get_workflow to be in toolset repos, got %q, %t", name, ok)
+	}
+
+	// Even once looked up in a toolset added later, a tool belongs to the first toolset that has it
+	users := NewToolset("users", "Users")
+	orgs := NewToolset("orgs", "Orgs").AddReadTools(NewServerTool(mcp.NewTool("get_me"), nil))
+	mustAddToolsets(t, tsg, users, orgs)
+	if name, ok := tsg.GetToolsetNameByToolName("get_me"); !ok || name != "orgs" {
+		t.Errorf("Expected get_me to be in toolset orgs, got %q, %t", name, ok)
+	}
+	users.AddReadTools(NewServerTool(mcp.NewTool("get_me"), nil))
+	if name, ok := tsg.GetToolsetNameByToolName("get_me"); !ok || name != "users" {
+		t.Errorf("Expected get_me to be in toolset users once added to it, got %q, %t", name, ok)
+	}
+	users.RemoveTool("get_me")
+	if name, ok := tsg.GetToolsetNameByToolName("get_me"); !ok || name != "orgs" {
+		t.Errorf("Expected get_me to be back in toolset orgs once removed from users, got %q, %t", name, ok)
+	}
+	orgs.RemoveTool("get_me")
+	if _, ok := tsg.GetToolsetNameByToolName("get_me"); ok {
+		t.Error("Expected get_me to be gone once removed from every toolset")
+	}
+}
+
+func TestToolsetNameValidation(t *testing.T) {
+	valid := []string{"repos", "code_security", "gh_admin", "v2", strings.Repeat("a", 50)}
+	for _, name := range valid {
+		if !IsValidToolsetName(name) {
+			t.Errorf("Expected %q to be a valid toolset name", name)
+		}
+		if _, err := NewToolsetSafe(name, "Valid"); err != nil {
+			t.Errorf("Expected no error creating toolset %q, got: %v", name, err)
+		}
+	}
+
+	invalid := []string{"", "Repos", "pull-requests", "code security", "2fa", "_private", strings.Repeat("a", 51)}
+	for _, name := range invalid {
+		if IsValidToolsetName(name) {
+			t.Errorf("Expected %q to be an invalid toolset name", name)
+		}
+		if _, err := NewToolsetSafe(name, "Invalid"); err == nil || !strings.Contains(err.Error(), "invalid toolset name") {
+			t.Errorf("Expected an invalid toolset name error creating toolset %q, got: %v", name, err)
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected NewToolset to panic on an invalid name")
+			}
+		}()
+		NewToolset("Invalid-Name", "Invalid")
+	}()
+
+	// A toolset renamed after it was created can't be added, and a batch with one is not added at all
+	tsg := NewToolsetGroup(false, nil)
+	renamed := NewToolset("renamed", "Renamed")
+	renamed.Name = "Renamed Toolset"
+	if err := tsg.AddToolset(renamed); err == nil {
+		t.Error("Expected an error adding a toolset with an invalid name")
+	}
+	if err := tsg.AddToolsets(NewToolset("valid", "Valid"), renamed); err == nil {
+		t.Error("Expected an error adding toolsets when one has an invalid name")
+	}
+	if len(tsg.Toolsets) != 0 {
+		t.Errorf("Expected no toolset to be added, got %d", len(tsg.Toolsets))
+	}
+}
+
 func TestIsEnabled(t *testing.T) {
-	tsg := NewToolsetGroup(false)
+	tsg := NewToolsetGroup(false, nil)
 
 	// Test with non-existent toolset
 	if tsg.IsEnabled("non-existent") {
@@ -81,23 +242,23 @@ func TestIsEnabled(t *testing.T) {
 	}
 
 	// Test with disabled toolset
-	disabledToolset := NewToolset("disabled-toolset", "A disabled toolset")
-	tsg.AddToolset(disabledToolset)
-	if tsg.IsEnabled("disabled-toolset") {
+	disabledToolset := NewToolset("disabled_toolset", "A disabled toolset")
+	mustAddToolsets(t, tsg, disabledToolset)
+	if tsg.IsEnabled("disabled_toolset") {
 		t.Error("Expected IsEnabled to return false for disabled toolset")
 	}
 
 	// Test with enabled toolset
-	enabledToolset := NewToolset("enabled-toolset", "An enabled toolset")
+	enabledToolset := NewToolset("enabled_toolset", "An enabled toolset")
 	enabledToolset.Enabled = true
-	tsg.AddToolset(enabledToolset)
-	if !tsg.IsEnabled("enabled-toolset") {
+	mustAddToolsets(t, tsg, enabledToolset)
+	if !tsg.IsEnabled("enabled_toolset") {
 		t.Error("Expected IsEnabled to return true for enabled toolset")
 	}
 }
 
 func TestEnableFeature(t *testing.T) {
-	tsg := NewToolsetGroup(false)
+	tsg := NewToolsetGroup(false, nil)
 
 	// Test enabling non-existent toolset
 	err := tsg.EnableToolset("non-existent")
@@ -106,37 +267,37 @@ func TestEnableFeature(t *testing.T) {
 	}
 
 	// Test enabling toolset
-	testToolset := NewToolset("test-toolset", "A test toolset")
-	tsg.AddToolset(testToolset)
+	testToolset := NewToolset("test_toolset", "A test toolset")
+	mustAddToolsets(t, tsg, testToolset)
 
-	if tsg.IsEnabled("test-toolset") {
+	if tsg.IsEnabled("test_toolset") {
 		t.Error("Expected toolset to be disabled initially")
 	}
 
-	err = tsg.EnableToolset("test-toolset")
+	err = tsg.EnableToolset("test_toolset")
 	if err != nil {
 		t.Errorf("Expected no error when enabling toolset, got: %v", err)
 	}
 
-	if !tsg.IsEnabled("test-toolset") {
+	if !tsg.IsEnabled("test_toolset") {
 		t.Error("Expected toolset to be enabled after EnableFeature call")
 	}
 
 	// Test enabling already enabled toolset
-	err = tsg.EnableToolset("test-toolset")
+	err = tsg.EnableToolset("test_toolset")
 	if err != nil {
 		t.Errorf("Expected no error when enabling already enabled toolset, got: %v", err)
 	}
 }
 
 func TestEnableToolsets(t *testing.T) {
-	tsg := NewToolsetGroup(false)
+	tsg := NewToolsetGroup(false, nil)
 
 	// Prepare toolsets
 	toolset1 := NewToolset("toolset1", "Feature 1")
 	toolset2 := NewToolset("toolset2", "Feature 2")
-	tsg.AddToolset(toolset1)
-	tsg.AddToolset(toolset2)
+	mustAddToolsets(t, tsg, toolset1)
+	mustAddToolsets(t, tsg, toolset2)
 
 	// Test enabling multiple toolsets
 	err := tsg.EnableToolsets([]string{"toolset1", "toolset2"})
@@ -165,7 +326,7 @@ func TestEnableToolsets(t *testing.T) {
 	}
 
 	// Test enabling everything through EnableToolsets
-	tsg = NewToolsetGroup(false)
+	tsg = NewToolsetGroup(false, nil)
 	err = tsg.EnableToolsets([]string{"all"})
 	if err != nil {
 		t.Errorf("Expected no error when enabling 'all', got: %v", err)
@@ -177,14 +338,14 @@ func TestEnableToolsets(t *testing.T) {
 }
 
 func TestEnableEverything(t *testing.T) {
-	tsg := NewToolsetGroup(false)
+	tsg := NewToolsetGroup(false, nil)
 
 	// Add a disabled toolset
-	testToolset := NewToolset("test-toolset", "A test toolset")
-	tsg.AddToolset(testToolset)
+	testToolset := NewToolset("test_toolset", "A test toolset")
+	mustAddToolsets(t, tsg, testToolset)
 
 	// Verify it's disabled
-	if tsg.IsEnabled("test-toolset") {
+	if tsg.IsEnabled("test_toolset") {
 		t.Error("Expected toolset to be disabled initially")
 	}
 
@@ -200,7 +361,7 @@ func TestEnableEverything(t *testing.T) {
 	}
 
 	// Verify the previously disabled toolset is now enabled
-	if !tsg.IsEnabled("test-toolset") {
+	if !tsg.IsEnabled("test_toolset") {
 		t.Error("Expected toolset to be enabled when everythingOn is true")
 	}
 
@@ -211,7 +372,7 @@ func TestEnableEverything(t *testing.T) {
 }
 
 func TestIsEnabledWithEverythingOn(t *testing.T) {
-	tsg := NewToolsetGroup(false)
+	tsg := NewToolsetGroup(false, nil)
 
 	// Enable "everything"
 	err := tsg.EnableToolsets([]string{"all"})
@@ -224,7 +385,832 @@ func TestIsEnabledWithEverythingOn(t *testing.T) {
 		t.Error("Expected IsEnabled to return true for any toolset when everythingOn is true")
 	}
 
-	if !tsg.IsEnabled("another-toolset") {
+	if !tsg.IsEnabled("another_toolset") {
 		t.Error("Expected IsEnabled to return true for any toolset when everythingOn is true")
 	}
 }
+
+func TestAddToolsets(t *testing.T) {
+	tsg := NewToolsetGroup(true, []string{"disabled_tool"})
+
+	toolset1 := NewToolset("toolset1", "Feature 1").
+		AddReadTools(NewServerTool(mcp.NewTool("read_tool"), nil))
+	toolset2 := NewToolset("toolset2", "Feature 2").
+		AddReadTools(NewServerTool(mcp.NewTool("disabled_tool"), nil))
+	mustAddToolsets(t, tsg, toolset1, toolset2)
+
+	if len(tsg.Toolsets) != 2 {
+		t.Fatalf("Expected 2 toolsets, got %d", len(tsg.Toolsets))
+	}
+
+	// Read-only must be propagated to every toolset in the batch
+	for name, ts := range tsg.Toolsets {
+		if !ts.readOnly {
+			t.Errorf("Expected toolset %s to be read-only", name)
+		}
+		ts.AddWriteTools(NewServerTool(mcp.NewTool("write_tool"), nil))
+		if len(ts.writeTools) != 0 {
+			t.Errorf("Expected write tools to be ignored for read-only toolset %s", name)
+		}
+	}
+
+	// Disabled tools must be inherited by every toolset in the batch
+	if err := tsg.EnableToolsets([]string{"toolset1", "toolset2"}); err != nil {
+		t.Fatalf("Expected no error when enabling toolsets, got: %v", err)
+	}
+	if len(toolset1.GetActiveTools()) != 1 {
+		t.Errorf("Expected toolset1 to have 1 active tool, got %d", len(toolset1.GetActiveTools()))
+	}
+	if len(toolset2.GetActiveTools()) != 0 {
+		t.Errorf("Expected toolset2 to have no active tools, got %d", len(toolset2.GetActiveTools()))
+	}
+}
+
+func TestNewToolsetGroupWithToolsets(t *testing.T) {
+	toolset1 := NewToolset("toolset1", "Feature 1")
+	toolset2 := NewToolset("toolset2", "Feature 2").
+		AddReadTools(NewServerTool(mcp.NewTool("disabled_tool"), nil))
+	tsg := NewToolsetGroupWithToolsets(true, []string{"disabled_tool"}, toolset1, toolset2)
+
+	if len(tsg.Toolsets) != 2 {
+		t.Fatalf("Expected 2 toolsets, got %d", len(tsg.Toolsets))
+	}
+	if !toolset1.readOnly || !toolset2.readOnly {
+		t.Error("Expected all toolsets to be read-only")
+	}
+	if !toolset1.disabledTools["disabled_tool"] || !toolset2.disabledTools["disabled_tool"] {
+		t.Error("Expected all toolsets to inherit the disabled tools")
+	}
+}
+
+func TestNewToolsetGroupFromConfig(t *testing.T) {
+	newToolsets := func() []*Toolset {
+		return []*Toolset{
+			NewToolset("issues", "Issues").
+				AddReadTools(
+					NewServerTool(mcp.NewTool("get_issue"), nil),
+					NewServerTool(mcp.NewTool("disabled_tool"), nil),
+				).
+				AddWriteTools(NewServerTool(mcp.NewTool("create_issue"), nil)),
+			NewToolset("repos", "Repos").
+				AddReadTools(NewServerTool(mcp.NewTool("get_repo"), nil)),
+		}
+	}
+
+	tsg, err := NewToolsetGroupFromConfig(config.ServerConfig{
+		EnabledToolsets: []string{"issues"},
+		DisabledTools:   []string{"disabled_tool"},
+		ReadOnly:        true,
+		Timeout:         "30s",
+		BaseURL:         "https://ghes.example.com",
+	}, newToolsets())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !tsg.IsEnabled("issues") || tsg.IsEnabled("repos") {
+		t.Error("Expected only the issues toolset to be enabled")
+	}
+	assertToolNames(t, "issues", tsg.Toolsets["issues"].GetActiveTools(), []string{"get_issue"})
+
+	tsg, err = NewToolsetGroupFromConfig(config.ServerConfig{EnabledToolsets: []string{"all"}}, newToolsets())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !tsg.IsEnabled("issues") || !tsg.IsEnabled("repos") {
+		t.Error("Expected all toolsets to be enabled")
+	}
+
+	// Invalid configurations and unknown toolsets don't create a group
+	_, err = NewToolsetGroupFromConfig(config.ServerConfig{Timeout: "soon"}, newToolsets())
+	if err == nil || !strings.HasPrefix(err.Error(), "invalid configuration: invalid timeout") {
+		t.Errorf("Expected an invalid timeout error, got: %v", err)
+	}
+	_, err = NewToolsetGroupFromConfig(config.ServerConfig{BaseURL: "ghes.example.com"}, newToolsets())
+	if err == nil || !strings.HasPrefix(err.Error(), "invalid configuration: invalid base URL") {
+		t.Errorf("Expected an invalid base URL error, got: %v", err)
+	}
+	_, err = NewToolsetGroupFromConfig(config.ServerConfig{EnabledToolsets: []string{"unknown"}}, newToolsets())
+	if err == nil || err.Error() != "toolset unknown does not exist" {
+		t.Errorf("Expected an unknown toolset error, got: %v", err)
+	}
+}
+
+func TestForEachToolset(t *testing.T) {
+	tsg := NewToolsetGroup(false, nil)
+	mustAddToolsets(t, tsg,
+		NewToolset("charlie", "C"),
+		NewToolset("alpha", "A"),
+		NewToolset("bravo", "B"),
+	)
+
+	// Toolsets are visited in alphabetical order
+	visited := []string{}
+	err := tsg.ForEachToolset(func(name string, _ *Toolset) error {
+		visited = append(visited, name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	expected := []string{"alpha", "bravo", "charlie"}
+	if len(visited) != len(expected) {
+		t.Fatalf("Expected %d toolsets to be visited, got %d", len(expected), len(visited))
+	}
+	for i := range expected {
+		if visited[i] != expected[i] {
+			t.Errorf("Expected toolset %d to be %s, got %s", i, expected[i], visited[i])
+		}
+	}
+
+	// The first error stops the iteration and is returned
+	stopErr := errors.New("stop")
+	visited = []string{}
+	err = tsg.ForEachToolset(func(name string, _ *Toolset) error {
+		visited = append(visited, name)
+		if name == "bravo" {
+			return stopErr
+		}
+		return nil
+	})
+	if !errors.Is(err, stopErr) {
+		t.Errorf("Expected stop error, got: %v", err)
+	}
+	if len(visited) != 2 {
+		t.Errorf("Expected iteration to stop after 2 toolsets, visited %d", len(visited))
+	}
+}
+
+func TestForEachEnabledToolset(t *testing.T) {
+	tsg := NewToolsetGroup(false, nil)
+	mustAddToolsets(t, tsg,
+		NewToolset("alpha", "A"),
+		NewToolset("bravo", "B"),
+		NewToolset("charlie", "C"),
+	)
+	if err := tsg.EnableToolsets([]string{"charlie", "alpha"}); err != nil {
+		t.Fatalf("Expected no error when enabling toolsets, got: %v", err)
+	}
+
+	visited := []string{}
+	err := tsg.ForEachEnabledToolset(func(name string, _ *Toolset) error {
+		visited = append(visited, name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(visited) != 2 || visited[0] != "alpha" || visited[1] != "charlie" {
+		t.Errorf("Expected [alpha charlie] to be visited, got %v", visited)
+	}
+}
+
+func TestForEachActiveTool(t *testing.T) {
+	tsg := NewToolsetGroup(true, []string{"disabled_tool"})
+	mustAddToolsets(t, tsg,
+		NewToolset("beta", "B").
+			AddReadTools(
+				NewServerTool(mcp.NewTool("read_b"), nil),
+				NewServerTool(mcp.NewTool("disabled_tool"), nil),
+			),
+		NewToolset("alpha", "A").
+			AddReadTools(NewServerTool(mcp.NewTool("read_a"), nil)).
+			AddWriteTools(NewServerTool(mcp.NewTool("write_a"), nil)),
+		NewToolset("gamma", "G").
+			AddReadTools(NewServerTool(mcp.NewTool("read_g"), nil)),
+	)
+	if err := tsg.EnableToolsets([]string{"alpha", "beta"}); err != nil {
+		t.Fatalf("Expected no error when enabling toolsets, got: %v", err)
+	}
+
+	visited := []string{}
+	err := tsg.ForEachActiveTool(func(toolsetName, toolName string, tool server.ServerTool) error {
+		if tool.Tool.Name != toolName {
+			t.Errorf("Expected tool name %s to match the server tool name %s", toolName, tool.Tool.Name)
+		}
+		visited = append(visited, toolsetName+"/"+toolName)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Write tools are excluded (read-only), as are disabled tools and disabled toolsets
+	expected := []string{"alpha/read_a", "beta/read_b"}
+	if len(visited) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, visited)
+	}
+	for i := range expected {
+		if visited[i] != expected[i] {
+			t.Errorf("Expected %s, got %s", expected[i], visited[i])
+		}
+	}
+}
+
+func TestExportInputSchemas(t *testing.T) {
+	rawSchema := json.RawMessage(`{"type":"object","properties":{"query":{"type":"string"}}}`)
+	tsg := NewToolsetGroup(true, []string{"disabled_tool"})
+	mustAddToolsets(t, tsg,
+		NewToolset("alpha", "A").
+			AddReadTools(
+				NewServerTool(mcp.NewTool("read_a", mcp.WithString("owner", mcp.Required())), nil),
+				NewServerTool(mcp.NewToolWithRawSchema("raw_a", "", rawSchema), nil),
+				NewServerTool(mcp.NewTool("disabled_tool"), nil),
+			).
+			AddWriteTools(NewServerTool(mcp.NewTool("write_a", mcp.WithString("body")), nil)),
+		NewToolset("beta", "B").
+			AddReadTools(NewServerTool(mcp.NewTool("read_b"), nil)),
+	)
+	if err := tsg.EnableToolset("alpha"); err != nil {
+		t.Fatalf("Expected no error when enabling toolset, got: %v", err)
+	}
+
+	schemas := tsg.ExportInputSchemas()
+
+	// The write tool is excluded (read-only), as are the disabled tool and the tools of disabled toolsets
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != "raw_a,read_a" {
+		t.Fatalf("Expected schemas for raw_a and read_a, got %v", names)
+	}
+
+	var schema struct {
+		Type       string                     `json:"type"`
+		Properties map[string]json.RawMessage `json:"properties"`
+		Required   []string                   `json:"required"`
+	}
+	if err := json.Unmarshal(schemas["read_a"], &schema); err != nil {
+		t.Fatalf("Expected the schema of read_a to be valid JSON, got: %v", err)
+	}
+	if schema.Type != "object" || len(schema.Properties) != 1 || len(schema.Required) != 1 || schema.Required[0] != "owner" {
+		t.Errorf("Expected an object schema requiring owner, got %s", schemas["read_a"])
+	}
+	if string(schemas["raw_a"]) != string(rawSchema) {
+		t.Errorf("Expected the raw schema of raw_a to be returned as is, got %s", schemas["raw_a"])
+	}
+}
+
+func TestSummarizeConfig(t *testing.T) {
+	tsg := NewToolsetGroup(false, []string{"disabled_tool", "unknown_tool"})
+	mustAddToolsets(t, tsg,
+		NewToolset("issues", "Issues").
+			AddReadTools(
+				NewServerTool(mcp.NewTool("get_issue"), nil),
+				NewServerTool(mcp.NewTool("disabled_tool"), nil),
+			).
+			AddWriteTools(NewServerTool(mcp.NewTool("create_issue"), nil)),
+		NewToolset("actions", "Actions").
+			AddReadTools(NewServerTool(mcp.NewTool("get_job_log"), nil)),
+	)
+	if err := tsg.EnableToolset("issues"); err != nil {
+		t.Fatalf("Expected no error when enabling toolset, got: %v", err)
+	}
+
+	expected := `everythingOn: false
+readOnly: false
+
+TOOLSET  ENABLED  READ-ONLY  ACTIVE TOOLS  DISABLED TOOLS
+actions  false    false      0             none
+issues   true     false      2             disabled_tool
+
+Disabled tools: disabled_tool, unknown_tool
+`
+	if summary := tsg.SummarizeConfig(); summary != expected {
+		t.Errorf("Expected summary:\n%s\ngot:\n%s", expected, summary)
+	}
+}
+
+func TestToolsetString(t *testing.T) {
+	toolset := NewToolset("issues", "Issues").
+		AddReadTools(NewServerTool(mcp.NewTool("get_issue"), nil)).
+		AddWriteTools(NewServerTool(mcp.NewTool("create_issue"), nil))
+
+	expected := `Toolset{name="issues", enabled=false, read_only=false, tools=0/2}`
+	if s := toolset.String(); s != expected {
+		t.Errorf("Expected %s, got %s", expected, s)
+	}
+
+	toolset.Enabled = true
+	toolset.SetReadOnly()
+	expected = `Toolset{name="issues", enabled=true, read_only=true, tools=1/1}`
+	if s := fmt.Sprintf("%v", toolset); s != expected {
+		t.Errorf("Expected %s, got %s", expected, s)
+	}
+
+	expected = `&toolsets.Toolset{Name: "issues", Description: "Issues", Enabled: true}`
+	if s := fmt.Sprintf("%#v", toolset); s != expected {
+		t.Errorf("Expected %s, got %s", expected, s)
+	}
+}
+
+func TestToolsetGroupString(t *testing.T) {
+	tsg := NewToolsetGroupWithToolsets(true, []string{"get_job_log"},
+		NewToolset("issues", "Issues").
+			AddReadTools(NewServerTool(mcp.NewTool("get_issue"), nil)),
+		NewToolset("actions", "Actions").
+			AddReadTools(NewServerTool(mcp.NewTool("get_job_log"), nil)),
+	)
+	if err := tsg.EnableToolsets([]string{"all"}); err != nil {
+		t.Fatalf("Expected no error when enabling toolsets, got: %v", err)
+	}
+
+	expected := `Toolset{name="actions", enabled=true, read_only=true, tools=0/1}
+Toolset{name="issues", enabled=true, read_only=true, tools=1/1}
+`
+	if s := fmt.Sprintf("%v", tsg); s != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, s)
+	}
+
+	expected = `toolsets.NewToolsetGroupWithToolsets(true, []string{"get_job_log"}, ` +
+		`&toolsets.Toolset{Name: "actions", Description: "Actions", Enabled: true}, ` +
+		`&toolsets.Toolset{Name: "issues", Description: "Issues", Enabled: true})`
+	if s := fmt.Sprintf("%#v", tsg); s != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, s)
+	}
+}
+
+func TestClone(t *testing.T) {
+	original := NewToolset("issues", "Issues").
+		AddReadTools(NewServerTool(mcp.NewTool("get_issue"), nil)).
+		AddWriteTools(NewServerTool(mcp.NewTool("create_issue"), nil))
+	original.Enabled = true
+	original.disabledTools["disabled_tool"] = true
+
+	clone := original.Clone("issues_copy")
+
+	if clone.Name != "issues_copy" {
+		t.Errorf("Expected clone name to be 'issues_copy', got '%s'", clone.Name)
+	}
+	if clone.Description != "Issues" {
+		t.Errorf("Expected clone description to be 'Issues', got '%s'", clone.Description)
+	}
+	if clone.Enabled {
+		t.Error("Expected clone to be disabled")
+	}
+	if !clone.disabledTools["disabled_tool"] {
+		t.Error("Expected clone to keep the disabled tools of the original")
+	}
+
+	// Changing either toolset must not affect the other
+	original.AddReadTools(NewServerTool(mcp.NewTool("list_issues"), nil))
+	original.disabledTools["get_issue"] = true
+	clone.AddWriteTools(NewServerTool(mcp.NewTool("update_issue"), nil))
+	clone.disabledTools["create_issue"] = true
+
+	assertToolNames(t, "original", original.GetAvailableTools(), []string{"get_issue", "list_issues", "create_issue"})
+	assertToolNames(t, "clone", clone.GetAvailableTools(), []string{"get_issue", "create_issue", "update_issue"})
+	if original.disabledTools["create_issue"] {
+		t.Error("Expected disabling a tool in the clone not to disable it in the original")
+	}
+	if clone.disabledTools["get_issue"] {
+		t.Error("Expected disabling a tool in the original not to disable it in the clone")
+	}
+
+	// A read-only toolset stays read-only when cloned
+	original.SetReadOnly()
+	readOnlyClone := original.Clone("issues_readonly")
+	readOnlyClone.AddWriteTools(NewServerTool(mcp.NewTool("delete_issue"), nil))
+	assertToolNames(t, "read-only clone", readOnlyClone.GetAvailableTools(), []string{"get_issue", "list_issues"})
+}
+
+func TestRemoveTool(t *testing.T) {
+	ts := NewToolset("issues", "Issues").
+		AddReadTools(NewServerTool(mcp.NewTool("get_issue"), nil), NewServerTool(mcp.NewTool("list_issues"), nil)).
+		AddWriteTools(NewServerTool(mcp.NewTool("create_issue"), nil))
+
+	if !ts.RemoveTool("get_issue") || !ts.RemoveTool("create_issue") {
+		t.Error("Expected the read and write tools to be removed")
+	}
+	if ts.RemoveTool("get_issue") {
+		t.Error("Expected removing a tool the toolset no longer has to report it")
+	}
+	assertToolNames(t, "toolset", ts.GetAvailableTools(), []string{"list_issues"})
+}
+
+func TestReplaceHandler(t *testing.T) {
+	handler := func(text string) server.ToolHandlerFunc {
+		return func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(text), nil
+		}
+	}
+	ts := NewToolset("issues", "Issues").
+		AddReadTools(NewServerTool(mcp.NewTool("get_issue"), handler("old")))
+	tsg := NewToolsetGroup(false, nil)
+	mustAddToolsets(t, tsg, ts)
+
+	if !ts.ReplaceHandler("get_issue", handler("new")) {
+		t.Fatal("Expected the handler of get_issue to be replaced")
+	}
+	if ts.ReplaceHandler("missing", handler("new")) {
+		t.Error("Expected replacing the handler of a missing tool to report it")
+	}
+	result, _ := ts.GetAvailableTools()[0].Handler(context.Background(), mcp.CallToolRequest{})
+	if text := result.Content[0].(mcp.TextContent).Text; text != "new" {
+		t.Errorf("Expected the new handler to be called, got %q", text)
+	}
+	if name, ok := tsg.GetToolsetNameByToolName("get_issue"); !ok || name != "issues" {
+		t.Errorf("Expected get_issue to stay in toolset issues, got %q, %t", name, ok)
+	}
+}
+
+func TestRegisterToolsFiltered(t *testing.T) {
+	newGroup := func() *ToolsetGroup {
+		tsg := NewToolsetGroup(false, []string{"disabled_tool"})
+		mustAddToolsets(t, tsg,
+			NewToolset("issues", "Issues").
+				AddReadTools(
+					NewServerTool(mcp.NewTool("get_issue", mcp.WithDescription("Get an issue")), nil),
+					NewServerTool(mcp.NewTool("disabled_tool"), nil),
+				).
+				AddWriteTools(NewServerTool(mcp.NewTool("create_issue", mcp.WithDescription("Create an issue")), nil)),
+			NewToolset("repos", "Repos").
+				AddReadTools(NewServerTool(mcp.NewTool("get_repo"), nil)),
+		)
+		if err := tsg.EnableToolset("issues"); err != nil {
+			t.Fatalf("Expected no error when enabling toolset, got: %v", err)
+		}
+		return tsg
+	}
+
+	s := server.NewMCPServer("test", "1.0.0")
+	newGroup().RegisterTools(s)
+	assertRegisteredTools(t, s, []string{"create_issue", "get_issue"})
+
+	// The filter sees the whole tool, and only ever the active ones
+	s = server.NewMCPServer("test", "1.0.0")
+	seen := []string{}
+	newGroup().RegisterToolsFiltered(s, func(tool server.ServerTool) bool {
+		seen = append(seen, tool.Tool.Name)
+		return strings.HasPrefix(tool.Tool.Description, "Create")
+	})
+	assertRegisteredTools(t, s, []string{"create_issue"})
+	if strings.Join(seen, ",") != "get_issue,create_issue" {
+		t.Errorf("Expected the filter to be called for get_issue and create_issue, got %v", seen)
+	}
+
+	// Names of disabled tools or tools of disabled toolsets don't register them
+	s = server.NewMCPServer("test", "1.0.0")
+	newGroup().RegisterToolsFiltered(s, FilterByToolNames([]string{"get_issue", "disabled_tool", "get_repo", "unknown"}))
+	assertRegisteredTools(t, s, []string{"get_issue"})
+
+	s = server.NewMCPServer("test", "1.0.0")
+	newGroup().RegisterToolsFiltered(s, FilterByToolNames(nil))
+	assertRegisteredTools(t, s, []string{})
+}
+
+func TestReconcileTools(t *testing.T) {
+	tsg := NewToolsetGroup(false, nil)
+	mustAddToolsets(t, tsg,
+		NewToolset("issues", "Issues").
+			AddReadTools(NewServerTool(mcp.NewTool("get_issue"), nil)).
+			AddWriteTools(NewServerTool(mcp.NewTool("create_issue"), nil)),
+		NewToolset("repos", "Repos").
+			AddReadTools(NewServerTool(mcp.NewTool("get_repo"), nil)),
+	)
+	if err := tsg.EnableToolset("issues"); err != nil {
+		t.Fatalf("Expected no error when enabling toolset, got: %v", err)
+	}
+
+	s := server.NewMCPServer("test", "1.0.0")
+	s.AddTool(mcp.NewTool("get_me"), nil)
+	tsg.RegisterTools(s)
+	assertRegisteredTools(t, s, []string{"create_issue", "get_issue", "get_me"})
+
+	// Nothing changed since the tools were registered
+	result := tsg.ReconcileTools(s)
+	if len(result.Added) != 0 || len(result.Removed) != 0 || result.Unchanged != 2 {
+		t.Errorf("Expected 2 unchanged tools and none added or removed, got %+v", result)
+	}
+
+	// A toolset enabled and a tool disabled at runtime; tools the group didn't register stay
+	if err := tsg.EnableToolset("repos"); err != nil {
+		t.Fatalf("Expected no error when enabling toolset, got: %v", err)
+	}
+	tsg.disabledTools["create_issue"] = true
+	tsg.Toolsets["issues"].disabledTools["create_issue"] = true
+	result = tsg.ReconcileTools(s)
+	if strings.Join(result.Added, ",") != "get_repo" || strings.Join(result.Removed, ",") != "create_issue" || result.Unchanged != 1 {
+		t.Errorf("Expected get_repo added, create_issue removed and 1 unchanged tool, got %+v", result)
+	}
+	assertRegisteredTools(t, s, []string{"get_issue", "get_me", "get_repo"})
+
+	// Reconciling again is a no-op
+	result = tsg.ReconcileTools(s)
+	if len(result.Added) != 0 || len(result.Removed) != 0 || result.Unchanged != 2 {
+		t.Errorf("Expected 2 unchanged tools and none added or removed, got %+v", result)
+	}
+}
+
+func mustAddToolsets(t *testing.T, tsg *ToolsetGroup, toolsets ...*Toolset) {
+	t.Helper()
+	if err := tsg.AddToolsets(toolsets...); err != nil {
+		t.Fatalf("Expected no error when adding toolsets, got: %v", err)
+	}
+}
+
+func assertRegisteredTools(t *testing.T, s *server.MCPServer, expected []string) {
+	t.Helper()
+	response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("Expected no error marshaling the response, got: %v", err)
+	}
+	var decoded struct {
+		Result mcp.ListToolsResult `json:"result"`
+	}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Expected no error unmarshaling the response, got: %v", err)
+	}
+	names := []string{}
+	for _, tool := range decoded.Result.Tools {
+		names = append(names, tool.Name)
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected registered tools %v, got %v", expected, names)
+	}
+}
+
+func TestMergeFrom(t *testing.T) {
+	tsg := NewToolsetGroup(true, []string{"disabled_a"})
+	mustAddToolsets(t, tsg, NewToolset("alpha", "A").
+		AddReadTools(
+			NewServerTool(mcp.NewTool("read_a"), nil),
+			NewServerTool(mcp.NewTool("disabled_b"), nil),
+		))
+
+	other := NewToolsetGroup(false, []string{"disabled_b"})
+	mustAddToolsets(t, other, NewToolset("beta", "B").
+		AddReadTools(
+			NewServerTool(mcp.NewTool("read_b"), nil),
+			NewServerTool(mcp.NewTool("disabled_a"), nil),
+		).
+		AddWriteTools(NewServerTool(mcp.NewTool("write_b"), nil)))
+
+	if err := tsg.MergeFrom(other); err != nil {
+		t.Fatalf("Expected no error when merging, got: %v", err)
+	}
+	if _, exists := tsg.Toolsets["beta"]; !exists {
+		t.Fatal("Expected the merged group to have toolset 'beta'")
+	}
+	if err := tsg.EnableToolsets([]string{"alpha", "beta"}); err != nil {
+		t.Fatalf("Expected no error when enabling toolsets, got: %v", err)
+	}
+
+	// The group stays read-only, and the disabled tools of both groups apply to every toolset
+	if !tsg.readOnly {
+		t.Error("Expected the group to stay read-only")
+	}
+	assertToolNames(t, "alpha", tsg.Toolsets["alpha"].GetActiveTools(), []string{"read_a"})
+	assertToolNames(t, "beta", tsg.Toolsets["beta"].GetActiveTools(), []string{"read_b"})
+
+	// A collision fails before any toolset is added
+	colliding := NewToolsetGroup(false, []string{"other_disabled"})
+	mustAddToolsets(t, colliding, NewToolset("gamma", "G"), NewToolset("alpha", "Other A"))
+	err := tsg.MergeFrom(colliding)
+	if err == nil || err.Error() != "toolset alpha already exists" {
+		t.Fatalf("Expected a collision error for toolset alpha, got: %v", err)
+	}
+	if _, exists := tsg.Toolsets["gamma"]; exists {
+		t.Error("Expected no toolset to be added when merging fails")
+	}
+	if tsg.Toolsets["alpha"].Description != "A" {
+		t.Error("Expected toolset 'alpha' not to be replaced when merging fails")
+	}
+	if tsg.disabledTools["other_disabled"] {
+		t.Error("Expected no tool to be disabled when merging fails")
+	}
+
+	tsg.MergeFromOrReplace(colliding)
+	if tsg.Toolsets["alpha"].Description != "Other A" {
+		t.Error("Expected toolset 'alpha' to be replaced")
+	}
+	if _, exists := tsg.Toolsets["gamma"]; !exists {
+		t.Error("Expected toolset 'gamma' to be added")
+	}
+	if !tsg.Toolsets["alpha"].readOnly || !tsg.disabledTools["other_disabled"] {
+		t.Error("Expected the replacing toolset to take on the settings of the group")
+	}
+}
+
+func TestMergeFromWritableGroup(t *testing.T) {
+	tsg := NewToolsetGroup(false, nil)
+	other := NewToolsetGroup(true, nil)
+	mustAddToolsets(t, other, NewToolset("beta", "B").
+		AddReadTools(NewServerTool(mcp.NewTool("read_b"), nil)))
+	other.Toolsets["beta"].writeTools = append(other.Toolsets["beta"].writeTools, NewServerTool(mcp.NewTool("write_b"), nil))
+
+	if err := tsg.MergeFrom(other); err != nil {
+		t.Fatalf("Expected no error when merging, got: %v", err)
+	}
+	if err := tsg.EnableToolset("beta"); err != nil {
+		t.Fatalf("Expected no error when enabling toolset, got: %v", err)
+	}
+
+	// A read-only toolset stays read-only in a writable group
+	assertToolNames(t, "beta", tsg.Toolsets["beta"].GetActiveTools(), []string{"read_b"})
+
+	s := server.NewMCPServer("test", "1.0.0")
+	tsg.RegisterTools(s)
+	assertRegisteredTools(t, s, []string{"read_b"})
+}
+
+func TestNewMergedToolsetGroup(t *testing.T) {
+	first := NewToolsetGroup(false, []string{"disabled_a"})
+	mustAddToolsets(t, first, NewToolset("alpha", "A").
+		AddWriteTools(NewServerTool(mcp.NewTool("write_a"), nil)))
+	second := NewToolsetGroup(true, []string{"disabled_b"})
+	mustAddToolsets(t, second, NewToolset("beta", "B"))
+
+	tsg, err := NewMergedToolsetGroup(first, second)
+	if err != nil {
+		t.Fatalf("Expected no error when merging, got: %v", err)
+	}
+	if len(tsg.Toolsets) != 2 {
+		t.Errorf("Expected 2 toolsets, got %d", len(tsg.Toolsets))
+	}
+	if !tsg.readOnly || !tsg.Toolsets["alpha"].readOnly {
+		t.Error("Expected the merged group to be read-only when one of the groups is")
+	}
+	if !tsg.disabledTools["disabled_a"] || !tsg.disabledTools["disabled_b"] {
+		t.Error("Expected the merged group to disable the tools disabled in either group")
+	}
+
+	duplicate := NewToolsetGroup(false, nil)
+	mustAddToolsets(t, duplicate, NewToolset("alpha", "Another A"))
+	if _, err := NewMergedToolsetGroup(first, duplicate); err == nil {
+		t.Error("Expected an error when two groups have a toolset of the same name")
+	}
+}
+
+func assertToolNames(t *testing.T, label string, tools []server.ServerTool, expected []string) {
+	t.Helper()
+	if len(tools) != len(expected) {
+		t.Fatalf("Expected %s to have %d tools, got %d", label, len(expected), len(tools))
+	}
+	for i, tool := range tools {
+		if tool.Tool.Name != expected[i] {
+			t.Errorf("Expected %s tool %d to be %s, got %s", label, i, expected[i], tool.Tool.Name)
+		}
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	tsg := NewToolsetGroup(false, []string{"delete_repo"})
+	mustAddToolsets(t, tsg,
+		NewToolset("issues", "Issues").
+			AddReadTools(NewServerTool(mcp.NewTool("get_issue"), nil)).
+			AddWriteTools(NewServerTool(mcp.NewTool("create_issue"), nil)),
+		NewToolset("repos", "Repos").
+			AddReadTools(NewServerTool(mcp.NewTool("get_repo"), nil)).
+			AddWriteTools(NewServerTool(mcp.NewTool("delete_repo"), nil)),
+	)
+	if err := tsg.EnableToolset("issues"); err != nil {
+		t.Fatalf("Expected no error when enabling toolset, got: %v", err)
+	}
+	s := server.NewMCPServer("test", "1.0.0")
+	tsg.RegisterTools(s)
+
+	snapshot := tsg.Snapshot()
+	expected := ToolsetState{
+		EverythingOn: false,
+		Toolsets: map[string]ToolsetFlags{
+			"issues": {Enabled: true, ReadOnly: false},
+			"repos":  {Enabled: false, ReadOnly: false},
+		},
+		DisabledTools: []string{"delete_repo"},
+	}
+	if !reflect.DeepEqual(snapshot, expected) {
+		t.Errorf("Expected snapshot %+v, got %+v", expected, snapshot)
+	}
+
+	// Switch to another profile
+	if err := tsg.EnableToolsets([]string{"all"}); err != nil {
+		t.Fatalf("Expected no error when enabling all toolsets, got: %v", err)
+	}
+	tsg.Toolsets["issues"].SetReadOnly()
+	tsg.disabledTools["get_issue"] = true
+	delete(tsg.disabledTools, "delete_repo")
+	tsg.ReconcileTools(s)
+	assertRegisteredTools(t, s, []string{"delete_repo", "get_repo"})
+
+	// And back
+	if err := tsg.Restore(snapshot); err != nil {
+		t.Fatalf("Expected no error when restoring, got: %v", err)
+	}
+	if !reflect.DeepEqual(tsg.Snapshot(), snapshot) {
+		t.Errorf("Expected the restored group to have snapshot %+v, got %+v", snapshot, tsg.Snapshot())
+	}
+	if tsg.IsEnabled("repos") {
+		t.Error("Expected repos to be disabled again")
+	}
+	// The toolsets see the restored disabled tools
+	active := []string{}
+	_ = tsg.ForEachActiveTool(func(_, toolName string, _ server.ServerTool) error {
+		active = append(active, toolName)
+		return nil
+	})
+	if strings.Join(active, ",") != "get_issue,create_issue" {
+		t.Errorf("Expected get_issue and create_issue to be active, got %v", active)
+	}
+	// Restoring leaves the server alone until the tools are reconciled
+	assertRegisteredTools(t, s, []string{"delete_repo", "get_repo"})
+	result := tsg.ReconcileTools(s)
+	if strings.Join(result.Added, ",") != "create_issue,get_issue" || strings.Join(result.Removed, ",") != "delete_repo,get_repo" {
+		t.Errorf("Expected create_issue and get_issue added and delete_repo and get_repo removed, got %+v", result)
+	}
+	assertRegisteredTools(t, s, []string{"create_issue", "get_issue"})
+
+	// A snapshot survives being stored as JSON
+	encoded, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("Expected no error marshaling the snapshot, got: %v", err)
+	}
+	var decoded ToolsetState
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Expected no error unmarshaling the snapshot, got: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, snapshot) {
+		t.Errorf("Expected the decoded snapshot %+v, got %+v", snapshot, decoded)
+	}
+}
+
+func TestRestoreErrors(t *testing.T) {
+	tsg := NewToolsetGroup(true, nil)
+	mustAddToolsets(t, tsg, NewToolset("issues", "Issues"))
+	if err := tsg.EnableToolset("issues"); err != nil {
+		t.Fatalf("Expected no error when enabling toolset, got: %v", err)
+	}
+	before := tsg.Snapshot()
+
+	tests := []struct {
+		name        string
+		state       ToolsetState
+		expectedErr string
+	}{
+		{
+			name: "toolset no longer in the group",
+			state: ToolsetState{
+				EverythingOn: true,
+				Toolsets: map[string]ToolsetFlags{
+					"issues": {Enabled: false, ReadOnly: true},
+					"repos":  {Enabled: true, ReadOnly: true},
+				},
+				DisabledTools: []string{"get_issue"},
+			},
+			expectedErr: "toolset repos does not exist",
+		},
+		{
+			name: "writable toolset in a read-only group",
+			state: ToolsetState{
+				Toolsets: map[string]ToolsetFlags{
+					"issues": {Enabled: true, ReadOnly: false},
+				},
+			},
+			expectedErr: "toolset issues can't be made writable in a read-only group",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tsg.Restore(tc.state)
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("Expected error %q, got %v", tc.expectedErr, err)
+			}
+			if !reflect.DeepEqual(tsg.Snapshot(), before) {
+				t.Errorf("Expected a failed restore to change nothing, got %+v", tsg.Snapshot())
+			}
+		})
+	}
+}
+
+func TestRestoreReadOnlyGroup(t *testing.T) {
+	tsg := NewToolsetGroup(true, nil)
+	mustAddToolsets(t, tsg,
+		NewToolset("issues", "Issues").
+			AddReadTools(NewServerTool(mcp.NewTool("get_issue"), nil)).
+			AddWriteTools(NewServerTool(mcp.NewTool("create_issue"), nil)),
+	)
+
+	err := tsg.Restore(ToolsetState{
+		Toolsets: map[string]ToolsetFlags{
+			"issues": {Enabled: true, ReadOnly: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error when restoring, got: %v", err)
+	}
+	if !tsg.Toolsets["issues"].readOnly {
+		t.Error("Expected issues to stay read-only in a read-only group")
+	}
+	active := []string{}
+	_ = tsg.ForEachActiveTool(func(_, toolName string, _ server.ServerTool) error {
+		active = append(active, toolName)
+		return nil
+	})
+	if strings.Join(active, ",") != "get_issue" {
+		t.Errorf("Expected only get_issue to be active, got %v", active)
+	}
+}